@@ -0,0 +1,31 @@
+// Package auth provides the protocol agnostic types of the identities which
+// requests are signed with, and the signing properties which configure how
+// a signer signs a request.
+package auth
+
+import "time"
+
+// Identity is the credentials a request is signed with, e.g. an access key,
+// token, or API key. Implementations provide the signer specific values.
+type Identity interface {
+	// Expiration returns the time the identity expires. The zero time means
+	// the identity does not expire.
+	Expiration() time.Time
+}
+
+// AnonymousIdentity is the identity of requests which are not signed.
+type AnonymousIdentity struct{}
+
+var _ Identity = (*AnonymousIdentity)(nil)
+
+// Expiration returns the zero time, an anonymous identity does not expire.
+func (*AnonymousIdentity) Expiration() time.Time {
+	return time.Time{}
+}
+
+// IsExpired returns whether the identity is expired relative to now. An
+// identity with a zero expiration never expires.
+func IsExpired(identity Identity, now time.Time) bool {
+	exp := identity.Expiration()
+	return !exp.IsZero() && !now.Before(exp)
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+type mockIdentity struct {
+	expiration time.Time
+}
+
+func (m mockIdentity) Expiration() time.Time { return m.expiration }
+
+func TestIsExpired(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		identity Identity
+		expect   bool
+	}{
+		"anonymous": {
+			identity: &AnonymousIdentity{},
+		},
+		"not expired": {
+			identity: mockIdentity{expiration: now.Add(time.Minute)},
+		},
+		"expired": {
+			identity: mockIdentity{expiration: now.Add(-time.Minute)},
+			expect:   true,
+		},
+		"expires now": {
+			identity: mockIdentity{expiration: now},
+			expect:   true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := c.expect, IsExpired(c.identity, now); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"time"
+
+	smithy "github.com/aws/smithy-go"
+)
+
+// The signing properties are set on the smithy.Properties passed to a signer
+// by the endpoint and auth scheme resolution of an operation, for the signer
+// to read the configuration of its signature from. Signers ignore the
+// properties they do not support.
+var (
+	signingNameKey       = smithy.NewPropertyKey[string]("smithy.auth", "signingName")
+	signingRegionKey     = smithy.NewPropertyKey[string]("smithy.auth", "signingRegion")
+	signingRegionSetKey  = smithy.NewPropertyKey[[]string]("smithy.auth", "signingRegionSet")
+	unsignedPayloadKey   = smithy.NewPropertyKey[bool]("smithy.auth", "unsignedPayload")
+	payloadSHA256Key     = smithy.NewPropertyKey[string]("smithy.auth", "payloadSHA256")
+	signingExpirationKey = smithy.NewPropertyKey[time.Duration]("smithy.auth", "signingExpiration")
+)
+
+// signingRegionSetWildcard is the region set of a signature valid in all
+// regions.
+const signingRegionSetWildcard = "*"
+
+// GetSigningName returns the name of the service the request is signed for,
+// and whether it was set.
+func GetSigningName(p smithy.PropertiesReader) (string, bool) {
+	return signingNameKey.Get(p)
+}
+
+// SetSigningName sets the name of the service the request is signed for.
+func SetSigningName(p *smithy.Properties, name string) {
+	signingNameKey.Set(p, name)
+}
+
+// GetSigningRegion returns the region the request is signed for, and whether
+// it was set.
+func GetSigningRegion(p smithy.PropertiesReader) (string, bool) {
+	return signingRegionKey.Get(p)
+}
+
+// SetSigningRegion sets the region the request is signed for.
+func SetSigningRegion(p *smithy.Properties, region string) {
+	signingRegionKey.Set(p, region)
+}
+
+// GetSigningRegionSet returns the set of regions a multi-region signature of
+// the request is valid in, and whether it was set.
+func GetSigningRegionSet(p smithy.PropertiesReader) ([]string, bool) {
+	return signingRegionSetKey.Get(p)
+}
+
+// SetSigningRegionSet sets the set of regions a multi-region signature of the
+// request is valid in. A set of "*" means the signature is valid in all
+// regions.
+func SetSigningRegionSet(p *smithy.Properties, regions []string) {
+	signingRegionSetKey.Set(p, append([]string(nil), regions...))
+}
+
+// IsAllRegionsSigningRegionSet returns whether the region set is the wildcard
+// set, valid in all regions.
+func IsAllRegionsSigningRegionSet(regions []string) bool {
+	return len(regions) == 1 && regions[0] == signingRegionSetWildcard
+}
+
+// GetUnsignedPayload returns whether the request's payload is excluded from
+// the signature.
+func GetUnsignedPayload(p smithy.PropertiesReader) bool {
+	v, _ := unsignedPayloadKey.Get(p)
+	return v
+}
+
+// SetUnsignedPayload sets whether the request's payload is excluded from the
+// signature.
+func SetUnsignedPayload(p *smithy.Properties, unsigned bool) {
+	unsignedPayloadKey.Set(p, unsigned)
+}
+
+// GetPayloadSHA256 returns the precomputed, hex encoded, SHA256 hash of the
+// request's payload, and whether it was set.
+func GetPayloadSHA256(p smithy.PropertiesReader) (string, bool) {
+	return payloadSHA256Key.Get(p)
+}
+
+// SetPayloadSHA256 sets the precomputed, hex encoded, SHA256 hash of the
+// request's payload, so the signer does not need to read the payload to
+// compute it.
+func SetPayloadSHA256(p *smithy.Properties, hash string) {
+	payloadSHA256Key.Set(p, hash)
+}
+
+// GetSigningExpiration returns the duration the signature is valid for, and
+// whether it was set.
+func GetSigningExpiration(p smithy.PropertiesReader) (time.Duration, bool) {
+	return signingExpirationKey.Get(p)
+}
+
+// SetSigningExpiration sets the duration the signature is valid for, e.g.
+// the expiration of a presigned URL.
+func SetSigningExpiration(p *smithy.Properties, d time.Duration) {
+	signingExpirationKey.Set(p, d)
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	smithy "github.com/aws/smithy-go"
+)
+
+func TestSigningProperties(t *testing.T) {
+	var p smithy.Properties
+
+	if _, ok := GetSigningName(&p); ok {
+		t.Errorf("expect no signing name")
+	}
+	if _, ok := GetSigningRegionSet(&p); ok {
+		t.Errorf("expect no signing region set")
+	}
+	if GetUnsignedPayload(&p) {
+		t.Errorf("expect signed payload by default")
+	}
+
+	SetSigningName(&p, "service")
+	SetSigningRegion(&p, "us-west-2")
+	regions := []string{"us-west-2", "us-east-1"}
+	SetSigningRegionSet(&p, regions)
+	regions[0] = "modified"
+	SetUnsignedPayload(&p, true)
+	SetPayloadSHA256(&p, "abc123")
+	SetSigningExpiration(&p, 15*time.Minute)
+
+	snapshot := p.Snapshot()
+
+	if v, ok := GetSigningName(snapshot); !ok || v != "service" {
+		t.Errorf("expect service signing name, got %q, %v", v, ok)
+	}
+	if v, ok := GetSigningRegion(snapshot); !ok || v != "us-west-2" {
+		t.Errorf("expect us-west-2 signing region, got %q, %v", v, ok)
+	}
+	set, ok := GetSigningRegionSet(snapshot)
+	if !ok || len(set) != 2 || set[0] != "us-west-2" || set[1] != "us-east-1" {
+		t.Errorf("expect region set to be copied, got %v, %v", set, ok)
+	}
+	if !GetUnsignedPayload(snapshot) {
+		t.Errorf("expect unsigned payload")
+	}
+	if v, ok := GetPayloadSHA256(snapshot); !ok || v != "abc123" {
+		t.Errorf("expect payload hash, got %q, %v", v, ok)
+	}
+	if v, ok := GetSigningExpiration(snapshot); !ok || v != 15*time.Minute {
+		t.Errorf("expect 15m expiration, got %v, %v", v, ok)
+	}
+}
+
+func TestIsAllRegionsSigningRegionSet(t *testing.T) {
+	cases := map[string]struct {
+		regions []string
+		expect  bool
+	}{
+		"wildcard":         {regions: []string{"*"}, expect: true},
+		"single region":    {regions: []string{"us-west-2"}},
+		"wildcard and one": {regions: []string{"*", "us-west-2"}},
+		"empty":            {},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := c.expect, IsAllRegionsSigningRegionSet(c.regions); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
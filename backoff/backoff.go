@@ -0,0 +1,164 @@
+// Package backoff provides the exponential backoff strategies computing the
+// delay between the attempts of retries, waiters, and applications, so they
+// share the same backoff math.
+//
+// The strategies read their jitter from the random source of the context,
+// see rand.WithEntropySource, and Wait sleeps with the clock of the context,
+// see smithytime.WithClock, so backoff can be made deterministic in tests.
+// SleepWithDeadline does not sleep when the context's deadline leaves too
+// little time for the attempt which would follow the delay.
+package backoff
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/smithy-go/rand"
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+// Strategy computes the delay before an attempt.
+type Strategy interface {
+	// Delay returns the delay before the attempt, starting at 1 for the
+	// first attempt after the initial one. The previous delay is the delay
+	// returned for the preceding attempt, zero for the first.
+	Delay(ctx context.Context, attempt int, previous time.Duration) (time.Duration, error)
+}
+
+// StrategyFunc is a function type that implements the Strategy interface.
+type StrategyFunc func(ctx context.Context, attempt int, previous time.Duration) (time.Duration, error)
+
+// Delay delegates to the wrapped function.
+func (fn StrategyFunc) Delay(ctx context.Context, attempt int, previous time.Duration) (time.Duration, error) {
+	return fn(ctx, attempt, previous)
+}
+
+// CappedExponential returns the delay of the attempt doubling from base for
+// each attempt, base * 2^(attempt-1), capped at max. Returns zero for
+// attempts less than 1.
+func CappedExponential(attempt int, base, max time.Duration) time.Duration {
+	if attempt <= 0 || base <= 0 {
+		return 0
+	}
+	if base >= max {
+		return max
+	}
+	// the delay exceeds max before the shift overflows.
+	if attempt > 63 {
+		return max
+	}
+	if d := base << uint(attempt-1); d > 0 && d < max && d>>uint(attempt-1) == base {
+		return d
+	}
+	return max
+}
+
+// Exponential is the capped exponential backoff strategy, without jitter.
+type Exponential struct {
+	// Base is the delay of the first attempt.
+	Base time.Duration
+
+	// Max is the maximum delay of an attempt.
+	Max time.Duration
+}
+
+// Delay returns the capped exponential delay of the attempt.
+func (s Exponential) Delay(_ context.Context, attempt int, _ time.Duration) (time.Duration, error) {
+	return CappedExponential(attempt, s.Base, s.Max), nil
+}
+
+// FullJitter is the exponential backoff strategy with the delay randomized
+// between zero and the capped exponential delay.
+type FullJitter struct {
+	// Base is the exponential delay of the first attempt.
+	Base time.Duration
+
+	// Max is the maximum delay of an attempt.
+	Max time.Duration
+}
+
+// Delay returns a random delay between zero and the capped exponential
+// delay of the attempt.
+func (s FullJitter) Delay(ctx context.Context, attempt int, _ time.Duration) (time.Duration, error) {
+	return jitter(ctx, 0, CappedExponential(attempt, s.Base, s.Max))
+}
+
+// EqualJitter is the exponential backoff strategy with the delay randomized
+// between half of, and the whole, capped exponential delay.
+type EqualJitter struct {
+	// Base is the exponential delay of the first attempt.
+	Base time.Duration
+
+	// Max is the maximum delay of an attempt.
+	Max time.Duration
+}
+
+// Delay returns a random delay between half of, and the whole, capped
+// exponential delay of the attempt.
+func (s EqualJitter) Delay(ctx context.Context, attempt int, _ time.Duration) (time.Duration, error) {
+	d := CappedExponential(attempt, s.Base, s.Max)
+	return jitter(ctx, d/2, d)
+}
+
+// DecorrelatedJitter is the backoff strategy with the delay randomized
+// between base and three times the previous delay, capped at max.
+type DecorrelatedJitter struct {
+	// Base is the minimum delay of an attempt.
+	Base time.Duration
+
+	// Max is the maximum delay of an attempt.
+	Max time.Duration
+}
+
+// Delay returns a random delay between base and three times the previous
+// delay, capped at max. Returns zero for attempts less than 1.
+func (s DecorrelatedJitter) Delay(ctx context.Context, attempt int, previous time.Duration) (time.Duration, error) {
+	if attempt <= 0 || s.Base <= 0 {
+		return 0, nil
+	}
+	if previous < s.Base {
+		previous = s.Base
+	}
+
+	upper := s.Max
+	if previous <= s.Max/3 {
+		upper = previous * 3
+	}
+	if upper <= s.Base {
+		return upper, nil
+	}
+	return jitter(ctx, s.Base, upper)
+}
+
+// jitter returns a random delay between min, inclusive, and max, exclusive,
+// read from the random source of the context. Returns min if max is not
+// greater than min.
+func jitter(ctx context.Context, min, max time.Duration) (time.Duration, error) {
+	if max <= min {
+		return min, nil
+	}
+	d, err := rand.ContextInt63n(ctx, int64(max-min))
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute backoff jitter, %w", err)
+	}
+	return min + time.Duration(d), nil
+}
+
+// Wait waits for the delay of the attempt computed by the strategy, or the
+// context to be canceled. Returns the delay, to be passed as the previous
+// delay of the next attempt. Returns the context's error if it is canceled
+// before the delay elapses.
+func Wait(ctx context.Context, s Strategy, attempt int, previous time.Duration) (time.Duration, error) {
+	delay, err := s.Delay(ctx, attempt, previous)
+	if err != nil {
+		return 0, err
+	}
+	if delay <= 0 {
+		return 0, ctx.Err()
+	}
+	if err := smithytime.SleepWithContext(ctx, delay); err != nil {
+		return delay, err
+	}
+	return delay, nil
+}
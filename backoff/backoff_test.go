@@ -0,0 +1,187 @@
+package backoff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/rand"
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+func TestCappedExponential(t *testing.T) {
+	cases := map[string]struct {
+		Attempt   int
+		Base, Max time.Duration
+		Expect    time.Duration
+	}{
+		"zeroth attempt": {
+			Attempt: 0, Base: time.Second, Max: time.Minute,
+			Expect: 0,
+		},
+		"first attempt": {
+			Attempt: 1, Base: time.Second, Max: time.Minute,
+			Expect: time.Second,
+		},
+		"fourth attempt": {
+			Attempt: 4, Base: time.Second, Max: time.Minute,
+			Expect: 8 * time.Second,
+		},
+		"capped": {
+			Attempt: 7, Base: time.Second, Max: time.Minute,
+			Expect: time.Minute,
+		},
+		"overflow": {
+			Attempt: 40, Base: time.Second, Max: time.Hour,
+			Expect: time.Hour,
+		},
+		"large attempt": {
+			Attempt: 1000, Base: time.Nanosecond, Max: time.Hour,
+			Expect: time.Hour,
+		},
+		"base exceeds max": {
+			Attempt: 1, Base: time.Minute, Max: time.Second,
+			Expect: time.Second,
+		},
+		"zero base": {
+			Attempt: 3, Base: 0, Max: time.Second,
+			Expect: 0,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := c.Expect, CappedExponential(c.Attempt, c.Base, c.Max); e != a {
+				t.Errorf("expect %v delay, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestStrategies(t *testing.T) {
+	base, max := 100*time.Millisecond, 5*time.Second
+
+	cases := map[string]struct {
+		Strategy Strategy
+		Bounds   func(attempt int, previous time.Duration) (min, max time.Duration)
+	}{
+		"exponential": {
+			Strategy: Exponential{Base: base, Max: max},
+			Bounds: func(attempt int, _ time.Duration) (time.Duration, time.Duration) {
+				d := CappedExponential(attempt, base, max)
+				return d, d
+			},
+		},
+		"full jitter": {
+			Strategy: FullJitter{Base: base, Max: max},
+			Bounds: func(attempt int, _ time.Duration) (time.Duration, time.Duration) {
+				return 0, CappedExponential(attempt, base, max)
+			},
+		},
+		"equal jitter": {
+			Strategy: EqualJitter{Base: base, Max: max},
+			Bounds: func(attempt int, _ time.Duration) (time.Duration, time.Duration) {
+				d := CappedExponential(attempt, base, max)
+				return d / 2, d
+			},
+		},
+		"decorrelated jitter": {
+			Strategy: DecorrelatedJitter{Base: base, Max: max},
+			Bounds: func(_ int, previous time.Duration) (time.Duration, time.Duration) {
+				if previous < base {
+					previous = base
+				}
+				if upper := previous * 3; upper < max {
+					return base, upper
+				}
+				return base, max
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := rand.WithEntropySource(context.Background(), rand.NewSeededReader(1))
+
+			if d, err := c.Strategy.Delay(ctx, 0, 0); err != nil || d != 0 {
+				t.Errorf("expect no delay for zeroth attempt, got %v, %v", d, err)
+			}
+
+			var previous time.Duration
+			for attempt := 1; attempt <= 20; attempt++ {
+				d, err := c.Strategy.Delay(ctx, attempt, previous)
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				lower, upper := c.Bounds(attempt, previous)
+				if d < lower || d > upper {
+					t.Errorf("attempt %d: expect delay within [%v, %v], got %v", attempt, lower, upper, d)
+				}
+				previous = d
+			}
+		})
+	}
+}
+
+func TestStrategies_Deterministic(t *testing.T) {
+	strategy := FullJitter{Base: time.Second, Max: time.Minute}
+
+	delays := func() []time.Duration {
+		ctx := rand.WithEntropySource(context.Background(), rand.NewSeededReader(42))
+		var ds []time.Duration
+		for attempt := 1; attempt <= 5; attempt++ {
+			d, err := strategy.Delay(ctx, attempt, 0)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			ds = append(ds, d)
+		}
+		return ds
+	}
+
+	first, second := delays(), delays()
+	for i := range first {
+		if e, a := first[i], second[i]; e != a {
+			t.Errorf("attempt %d: expect %v delay, got %v", i+1, e, a)
+		}
+	}
+}
+
+func TestWait(t *testing.T) {
+	clock := smithytime.NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := smithytime.WithClock(context.Background(), clock)
+
+	type result struct {
+		delay time.Duration
+		err   error
+	}
+	done := make(chan result)
+	go func() {
+		d, err := Wait(ctx, Exponential{Base: time.Second, Max: time.Minute}, 3, 0)
+		done <- result{d, err}
+	}()
+
+	for clock.Timers() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	clock.Advance(4 * time.Second)
+
+	r := <-done
+	if r.err != nil {
+		t.Fatalf("expect no error, got %v", r.err)
+	}
+	if e, a := 4*time.Second, r.delay; e != a {
+		t.Errorf("expect %v delay, got %v", e, a)
+	}
+}
+
+func TestWait_Canceled(t *testing.T) {
+	clock := smithytime.NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx, cancel := context.WithCancel(smithytime.WithClock(context.Background(), clock))
+	cancel()
+
+	_, err := Wait(ctx, Exponential{Base: time.Second, Max: time.Minute}, 1, 0)
+	if e, a := context.Canceled, err; e != a {
+		t.Errorf("expect %v error, got %v", e, a)
+	}
+}
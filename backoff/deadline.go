@@ -0,0 +1,75 @@
+package backoff
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+// DeadlineExceededError is returned by SleepWithDeadline when the time
+// remaining before the context's deadline cannot accommodate the delay and
+// the minimum duration of the attempt which would follow it. The attempt
+// would be doomed to time out, and should not be made.
+type DeadlineExceededError struct {
+	// Delay is the delay which was not slept.
+	Delay time.Duration
+
+	// MinAttemptTime is the minimum duration of an attempt.
+	MinAttemptTime time.Duration
+
+	// Remaining is the time which remained before the context's deadline.
+	Remaining time.Duration
+}
+
+func (e *DeadlineExceededError) Error() string {
+	return fmt.Sprintf("insufficient time before deadline for delay %v and attempt of %v, %v remaining",
+		e.Delay, e.MinAttemptTime, e.Remaining)
+}
+
+// Timeout returns that the error is a timeout.
+func (e *DeadlineExceededError) Timeout() bool { return true }
+
+// RetryableError returns false, as an attempt made after the delay would
+// not complete before the deadline.
+func (e *DeadlineExceededError) RetryableError() bool { return false }
+
+// Is returns true if the target is context.DeadlineExceeded.
+func (e *DeadlineExceededError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// SleepWithDeadline sleeps for the delay, or until the context is canceled.
+// If the context has a deadline, and the time remaining before it, relative
+// to the context's clock, is less than the delay plus minAttemptTime,
+// SleepWithDeadline returns a *DeadlineExceededError immediately, without
+// sleeping.
+//
+// Returns the time slept, measured by the context's clock, to account the
+// delays between attempts, e.g. in the AttemptInfo's Delay. The time slept
+// is less than the delay if the context is canceled.
+func SleepWithDeadline(ctx context.Context, delay, minAttemptTime time.Duration) (time.Duration, error) {
+	clock := smithytime.GetClock(ctx)
+	start := clock.Now()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining := deadline.Sub(start)
+		if remaining < 0 {
+			remaining = 0
+		}
+		if remaining < delay+minAttemptTime {
+			return 0, &DeadlineExceededError{
+				Delay:          delay,
+				MinAttemptTime: minAttemptTime,
+				Remaining:      remaining,
+			}
+		}
+	}
+
+	if delay <= 0 {
+		return 0, ctx.Err()
+	}
+	err := smithytime.SleepWithContext(ctx, delay)
+	return clock.Now().Sub(start), err
+}
@@ -0,0 +1,124 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	smithy "github.com/aws/smithy-go"
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+func TestSleepWithDeadline(t *testing.T) {
+	// context deadlines are relative to the system clock.
+	start := time.Now()
+
+	cases := map[string]struct {
+		Deadline       time.Duration
+		Delay          time.Duration
+		MinAttemptTime time.Duration
+		ExpectErr      bool
+		ExpectSlept    time.Duration
+	}{
+		"no deadline": {
+			Delay:       time.Second,
+			ExpectSlept: time.Second,
+		},
+		"sufficient time": {
+			Deadline:       time.Minute,
+			Delay:          time.Second,
+			MinAttemptTime: 5 * time.Second,
+			ExpectSlept:    time.Second,
+		},
+		"insufficient time for attempt": {
+			Deadline:       time.Minute,
+			Delay:          40 * time.Second,
+			MinAttemptTime: 30 * time.Second,
+			ExpectErr:      true,
+		},
+		"deadline passed": {
+			Deadline:  -time.Second,
+			Delay:     time.Second,
+			ExpectErr: true,
+		},
+		"no delay": {
+			Deadline: time.Minute,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			clock := smithytime.NewFakeClock(start)
+			ctx := smithytime.WithClock(context.Background(), clock)
+			if c.Deadline != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithDeadline(ctx, start.Add(c.Deadline))
+				defer cancel()
+			}
+
+			type result struct {
+				slept time.Duration
+				err   error
+			}
+			done := make(chan result, 1)
+			go func() {
+				slept, err := SleepWithDeadline(ctx, c.Delay, c.MinAttemptTime)
+				done <- result{slept, err}
+			}()
+
+			var r result
+			if c.ExpectErr || c.Delay == 0 {
+				r = <-done
+			} else {
+				for clock.Timers() == 0 {
+					time.Sleep(time.Millisecond)
+				}
+				clock.Advance(c.Delay)
+				r = <-done
+			}
+
+			if c.ExpectErr {
+				var v *DeadlineExceededError
+				if !errors.As(r.err, &v) {
+					t.Fatalf("expect *DeadlineExceededError, got %T, %v", r.err, r.err)
+				}
+				if e, a := c.Delay, v.Delay; e != a {
+					t.Errorf("expect %v delay, got %v", e, a)
+				}
+				if !errors.Is(r.err, context.DeadlineExceeded) {
+					t.Errorf("expect error to be context.DeadlineExceeded")
+				}
+				if retryable, ok := smithy.IsErrorRetryable(r.err); !ok || retryable {
+					t.Errorf("expect not retryable, got %v, %v", retryable, ok)
+				}
+				return
+			}
+			if r.err != nil {
+				t.Fatalf("expect no error, got %v", r.err)
+			}
+			if e, a := c.ExpectSlept, r.slept; e != a {
+				t.Errorf("expect %v slept, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestSleepWithDeadline_Canceled(t *testing.T) {
+	clock := smithytime.NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx, cancel := context.WithCancel(smithytime.WithClock(context.Background(), clock))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := SleepWithDeadline(ctx, time.Minute, time.Second)
+		done <- err
+	}()
+	for clock.Timers() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+
+	if e, a := context.Canceled, <-done; e != a {
+		t.Errorf("expect %v error, got %v", e, a)
+	}
+}
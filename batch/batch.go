@@ -0,0 +1,204 @@
+// Package batch provides a utility for executing many operation calls of a
+// client with bounded concurrency, retrying failed items, and reporting the
+// result of every item.
+package batch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/backoff"
+	"github.com/aws/smithy-go/rand"
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+// Options provides the configuration of Execute.
+type Options struct {
+	// Concurrency is the maximum number of items executed concurrently.
+	// Defaults to 10.
+	Concurrency int
+
+	// MaxAttempts is the maximum number of times an item is executed, if it
+	// fails with a retryable error. Defaults to 1, items are not retried.
+	//
+	// The operation's client retries each call per its own retry strategy,
+	// this is the number of calls made for the item.
+	MaxAttempts int
+
+	// Retryable returns whether an item which failed with the error should
+	// be retried. Defaults to retrying errors which are retryable per
+	// smithy.IsErrorRetryable.
+	Retryable func(error) bool
+
+	// MinRetryDelay and MaxRetryDelay bound the exponential backoff, with
+	// jitter, between an item's attempts. Default to 100 milliseconds, and 5
+	// seconds.
+	MinRetryDelay time.Duration
+	MaxRetryDelay time.Duration
+}
+
+// Result is the result of executing an item.
+type Result[O any] struct {
+	// Output is the output of the item's successful attempt.
+	Output O
+
+	// Err is the error of the item's last attempt, nil if the item
+	// succeeded.
+	Err error
+
+	// Attempts is the number of times the item was executed. Zero if the
+	// item was not executed because the context was canceled.
+	Attempts int
+}
+
+// ItemError is the error of an item which failed.
+type ItemError struct {
+	// Index is the index of the item's input.
+	Index int
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("item %d, %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ItemError) Unwrap() error { return e.Err }
+
+// Error is the aggregate error of the items which failed, in input order.
+type Error struct {
+	Errors []*ItemError
+
+	// Total is the number of items executed.
+	Total int
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d items failed", len(e.Errors), e.Total)
+	for i, err := range e.Errors {
+		if i == 3 {
+			fmt.Fprintf(&b, ", and %d more", len(e.Errors)-i)
+			break
+		}
+		fmt.Fprintf(&b, "; %v", err)
+	}
+	return b.String()
+}
+
+// Unwrap returns the errors of the failed items.
+func (e *Error) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Execute calls fn for each input, concurrently, and returns the result of
+// each call in input order. Failed items are retried per the options. The
+// results of successful items are preserved if others fail, in which case
+// an *Error describing the failed items is also returned.
+//
+// If the context is canceled, items not yet started are not executed, and
+// fail with the context's error.
+func Execute[I, O any](ctx context.Context, inputs []I, fn func(context.Context, I) (O, error), optFns ...func(*Options)) (
+	[]Result[O], error,
+) {
+	o := Options{
+		Concurrency:   10,
+		MaxAttempts:   1,
+		Retryable:     isErrorRetryable,
+		MinRetryDelay: 100 * time.Millisecond,
+		MaxRetryDelay: 5 * time.Second,
+	}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	if o.Concurrency <= 0 {
+		return nil, fmt.Errorf("batch concurrency must be greater than zero, got %d", o.Concurrency)
+	}
+	if o.MaxAttempts <= 0 {
+		return nil, fmt.Errorf("batch max attempts must be greater than zero, got %d", o.MaxAttempts)
+	}
+
+	results := make([]Result[O], len(inputs))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < o.Concurrency && w < len(inputs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = execute(ctx, inputs[i], fn, o)
+			}
+		}()
+	}
+
+feed:
+	for i := range inputs {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			for ; i < len(inputs); i++ {
+				results[i].Err = ctx.Err()
+			}
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	var errs []*ItemError
+	for i, r := range results {
+		if r.Err != nil {
+			errs = append(errs, &ItemError{Index: i, Err: r.Err})
+		}
+	}
+	if len(errs) != 0 {
+		return results, &Error{Errors: errs, Total: len(inputs)}
+	}
+	return results, nil
+}
+
+// execute calls fn for the input until it succeeds, fails with an error
+// which is not retryable, or the attempts are exhausted.
+func execute[I, O any](ctx context.Context, input I, fn func(context.Context, I) (O, error), o Options) Result[O] {
+	var r Result[O]
+	for {
+		r.Attempts++
+		r.Output, r.Err = fn(ctx, input)
+		if r.Err == nil || r.Attempts >= o.MaxAttempts || !o.Retryable(r.Err) {
+			return r
+		}
+
+		if err := smithytime.SleepWithContext(ctx, retryDelay(ctx, r.Attempts, o)); err != nil {
+			return r
+		}
+	}
+}
+
+// retryDelay returns the exponential backoff before the attempt's retry,
+// with full jitter between the minimum delay and the backoff.
+func retryDelay(ctx context.Context, attempt int, o Options) time.Duration {
+	delay := backoff.CappedExponential(attempt, o.MinRetryDelay, o.MaxRetryDelay)
+	if delay <= o.MinRetryDelay {
+		return delay
+	}
+
+	jitter, err := rand.ContextInt63n(ctx, int64(delay-o.MinRetryDelay))
+	if err != nil {
+		return delay
+	}
+	return o.MinRetryDelay + time.Duration(jitter)
+}
+
+func isErrorRetryable(err error) bool {
+	retryable, _ := smithy.IsErrorRetryable(err)
+	return retryable
+}
@@ -0,0 +1,247 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type retryableError struct{ retryable bool }
+
+func (e retryableError) Error() string        { return fmt.Sprintf("retryable %t", e.retryable) }
+func (e retryableError) RetryableError() bool { return e.retryable }
+
+func TestExecute(t *testing.T) {
+	cases := map[string]struct {
+		Inputs         []int
+		Fn             func(attempts map[int]*int32) func(context.Context, int) (string, error)
+		Options        func(*Options)
+		ExpectOutputs  []string
+		ExpectAttempts []int
+		ExpectFailed   []int
+	}{
+		"all succeed": {
+			Inputs: []int{1, 2, 3},
+			Fn: func(map[int]*int32) func(context.Context, int) (string, error) {
+				return func(ctx context.Context, i int) (string, error) {
+					return fmt.Sprintf("out-%d", i), nil
+				}
+			},
+			ExpectOutputs:  []string{"out-1", "out-2", "out-3"},
+			ExpectAttempts: []int{1, 1, 1},
+		},
+		"successes preserved": {
+			Inputs: []int{1, 2, 3},
+			Fn: func(map[int]*int32) func(context.Context, int) (string, error) {
+				return func(ctx context.Context, i int) (string, error) {
+					if i == 2 {
+						return "", fmt.Errorf("failed")
+					}
+					return fmt.Sprintf("out-%d", i), nil
+				}
+			},
+			ExpectOutputs:  []string{"out-1", "", "out-3"},
+			ExpectAttempts: []int{1, 1, 1},
+			ExpectFailed:   []int{1},
+		},
+		"retried": {
+			Inputs: []int{1, 2},
+			Fn: func(attempts map[int]*int32) func(context.Context, int) (string, error) {
+				return func(ctx context.Context, i int) (string, error) {
+					if n := atomic.AddInt32(attempts[i], 1); n < 3 {
+						return "", retryableError{retryable: true}
+					}
+					return fmt.Sprintf("out-%d", i), nil
+				}
+			},
+			Options: func(o *Options) {
+				o.MaxAttempts = 3
+			},
+			ExpectOutputs:  []string{"out-1", "out-2"},
+			ExpectAttempts: []int{3, 3},
+		},
+		"attempts exhausted": {
+			Inputs: []int{1},
+			Fn: func(map[int]*int32) func(context.Context, int) (string, error) {
+				return func(ctx context.Context, i int) (string, error) {
+					return "", retryableError{retryable: true}
+				}
+			},
+			Options: func(o *Options) {
+				o.MaxAttempts = 2
+			},
+			ExpectOutputs:  []string{""},
+			ExpectAttempts: []int{2},
+			ExpectFailed:   []int{0},
+		},
+		"not retryable": {
+			Inputs: []int{1, 2},
+			Fn: func(map[int]*int32) func(context.Context, int) (string, error) {
+				return func(ctx context.Context, i int) (string, error) {
+					return "", retryableError{retryable: false}
+				}
+			},
+			Options: func(o *Options) {
+				o.MaxAttempts = 3
+			},
+			ExpectOutputs:  []string{"", ""},
+			ExpectAttempts: []int{1, 1},
+			ExpectFailed:   []int{0, 1},
+		},
+		"custom retryable": {
+			Inputs: []int{1},
+			Fn: func(attempts map[int]*int32) func(context.Context, int) (string, error) {
+				return func(ctx context.Context, i int) (string, error) {
+					if n := atomic.AddInt32(attempts[i], 1); n < 2 {
+						return "", fmt.Errorf("transient")
+					}
+					return "out", nil
+				}
+			},
+			Options: func(o *Options) {
+				o.MaxAttempts = 2
+				o.Retryable = func(err error) bool { return err.Error() == "transient" }
+			},
+			ExpectOutputs:  []string{"out"},
+			ExpectAttempts: []int{2},
+		},
+		"no inputs": {
+			Fn: func(map[int]*int32) func(context.Context, int) (string, error) {
+				return func(ctx context.Context, i int) (string, error) {
+					t.Errorf("expect fn not called")
+					return "", nil
+				}
+			},
+			ExpectOutputs:  []string{},
+			ExpectAttempts: []int{},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			attempts := map[int]*int32{}
+			for _, i := range c.Inputs {
+				attempts[i] = new(int32)
+			}
+
+			results, err := Execute(context.Background(), c.Inputs, c.Fn(attempts), func(o *Options) {
+				o.MinRetryDelay = time.Millisecond
+				o.MaxRetryDelay = time.Millisecond
+				if c.Options != nil {
+					c.Options(o)
+				}
+			})
+
+			if e, a := len(c.ExpectOutputs), len(results); e != a {
+				t.Fatalf("expect %v results, got %v", e, a)
+			}
+			for i, r := range results {
+				if e, a := c.ExpectOutputs[i], r.Output; e != a {
+					t.Errorf("%d, expect %v output, got %v", i, e, a)
+				}
+				if e, a := c.ExpectAttempts[i], r.Attempts; e != a {
+					t.Errorf("%d, expect %v attempts, got %v", i, e, a)
+				}
+			}
+
+			if len(c.ExpectFailed) == 0 {
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				return
+			}
+
+			var batchErr *Error
+			if !errors.As(err, &batchErr) {
+				t.Fatalf("expect *Error, got %T, %v", err, err)
+			}
+			if e, a := len(c.Inputs), batchErr.Total; e != a {
+				t.Errorf("expect %v total, got %v", e, a)
+			}
+			if e, a := len(c.ExpectFailed), len(batchErr.Errors); e != a {
+				t.Fatalf("expect %v failed, got %v", e, a)
+			}
+			for i, itemErr := range batchErr.Errors {
+				if e, a := c.ExpectFailed[i], itemErr.Index; e != a {
+					t.Errorf("expect %v failed index, got %v", e, a)
+				}
+				if results[itemErr.Index].Err == nil {
+					t.Errorf("expect result %v error", itemErr.Index)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	inputs := make([]int, 20)
+
+	_, err := Execute(context.Background(), inputs, func(ctx context.Context, i int) (int, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return i, nil
+	}, func(o *Options) {
+		o.Concurrency = 3
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if a := atomic.LoadInt32(&maxInFlight); a > 3 || a < 1 {
+		t.Errorf("expect at most 3 in-flight, got %v", a)
+	}
+}
+
+func TestExecuteCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := Execute(ctx, []int{1, 2, 3}, func(ctx context.Context, i int) (int, error) {
+		return i, ctx.Err()
+	})
+
+	var batchErr *Error
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expect *Error, got %T, %v", err, err)
+	}
+	if e, a := 3, len(batchErr.Errors); e != a {
+		t.Errorf("expect %v failed, got %v", e, a)
+	}
+	for i, r := range results {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("%d, expect canceled error, got %v", i, r.Err)
+		}
+	}
+	if e, a := "3 of 3 items failed", err.Error(); !strings.HasPrefix(a, e) {
+		t.Errorf("expect error %q prefix, got %q", e, a)
+	}
+}
+
+func TestExecuteInvalidOptions(t *testing.T) {
+	cases := map[string]func(*Options){
+		"concurrency":  func(o *Options) { o.Concurrency = 0 },
+		"max attempts": func(o *Options) { o.MaxAttempts = 0 },
+	}
+
+	for name, fn := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Execute(context.Background(), []int{1}, func(ctx context.Context, i int) (int, error) {
+				return i, nil
+			}, fn); err == nil {
+				t.Errorf("expect error")
+			}
+		})
+	}
+}
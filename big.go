@@ -0,0 +1,217 @@
+package smithy
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// BigInteger is an arbitrary precision integer, the value of a Smithy
+// bigInteger shape. The zero value is 0. A BigInteger is immutable, its
+// methods return copies of its value.
+type BigInteger struct {
+	v *big.Int
+}
+
+// NewBigInteger returns a BigInteger of the value of v.
+func NewBigInteger(v *big.Int) BigInteger {
+	if v == nil {
+		return BigInteger{}
+	}
+	return BigInteger{v: new(big.Int).Set(v)}
+}
+
+// ParseBigInteger parses the base 10 integer, such as the text of a JSON
+// number, or XML element. Returns an error if s is not an integer.
+func ParseBigInteger(s string) (BigInteger, error) {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return BigInteger{}, fmt.Errorf("invalid big integer, %q", s)
+	}
+	return BigInteger{v: v}, nil
+}
+
+// Int returns a copy of the value as a big.Int.
+func (i BigInteger) Int() *big.Int {
+	if i.v == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(i.v)
+}
+
+// String returns the value as a base 10 integer.
+func (i BigInteger) String() string {
+	if i.v == nil {
+		return "0"
+	}
+	return i.v.String()
+}
+
+// MarshalText returns the value as a base 10 integer, implementing
+// encoding.TextMarshaler.
+func (i BigInteger) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText parses the base 10 integer, implementing
+// encoding.TextUnmarshaler.
+func (i *BigInteger) UnmarshalText(text []byte) error {
+	v, err := ParseBigInteger(string(text))
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+// BigDecimal is an arbitrary precision decimal number, the value of a Smithy
+// bigDecimal shape. The value is an unscaled integer, and a scale, of the
+// value unscaled × 10^-scale, so decimal numbers are represented exactly,
+// unlike as a float64, or big.Float. The zero value is 0. A BigDecimal is
+// immutable, its methods return copies of its value.
+type BigDecimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewBigDecimal returns the BigDecimal of the value unscaled × 10^-scale.
+func NewBigDecimal(unscaled *big.Int, scale int32) BigDecimal {
+	if unscaled == nil {
+		return BigDecimal{scale: scale}
+	}
+	return BigDecimal{unscaled: new(big.Int).Set(unscaled), scale: scale}
+}
+
+// ParseBigDecimal parses the decimal number, such as the text of a JSON
+// number, or XML element, e.g. "-12.50", or "1.5e-10". The digits of the
+// number are preserved, including trailing zeros. Returns an error if s is
+// not a decimal number.
+func ParseBigDecimal(s string) (BigDecimal, error) {
+	mantissa, exp := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i != -1 {
+		v, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return BigDecimal{}, fmt.Errorf("invalid big decimal exponent, %q", s)
+		}
+		mantissa, exp = s[:i], v
+	}
+
+	digits, scale := mantissa, int64(0)
+	if i := strings.IndexByte(mantissa, '.'); i != -1 {
+		digits = mantissa[:i] + mantissa[i+1:]
+		scale = int64(len(mantissa) - i - 1)
+	}
+	if len(strings.TrimLeft(digits, "+-")) == 0 || strings.ContainsAny(digits, "_.") {
+		return BigDecimal{}, fmt.Errorf("invalid big decimal, %q", s)
+	}
+
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return BigDecimal{}, fmt.Errorf("invalid big decimal, %q", s)
+	}
+
+	scale -= exp
+	if scale < -1<<31 || scale > 1<<31-1 {
+		return BigDecimal{}, fmt.Errorf("big decimal exponent out of range, %q", s)
+	}
+	return BigDecimal{unscaled: unscaled, scale: int32(scale)}, nil
+}
+
+// Unscaled returns a copy of the unscaled integer value of the number.
+func (d BigDecimal) Unscaled() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(d.unscaled)
+}
+
+// Scale returns the scale of the number, the number of digits of the
+// unscaled value after the decimal point. A negative scale multiplies the
+// unscaled value by a power of ten.
+func (d BigDecimal) Scale() int32 {
+	return d.scale
+}
+
+// Rat returns the exact value of the number as a big.Rat.
+func (d BigDecimal) Rat() *big.Rat {
+	r := new(big.Rat).SetInt(d.Unscaled())
+	if d.scale == 0 {
+		return r
+	}
+
+	scale := int64(d.scale)
+	if scale < 0 {
+		scale = -scale
+	}
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(scale), nil)
+	if d.scale > 0 {
+		return r.Quo(r, new(big.Rat).SetInt(pow))
+	}
+	return r.Mul(r, new(big.Rat).SetInt(pow))
+}
+
+// Float returns the value of the number as a big.Float, with the precision
+// of prec bits, rounded if the number cannot be represented exactly.
+func (d BigDecimal) Float(prec uint) *big.Float {
+	return new(big.Float).SetPrec(prec).SetRat(d.Rat())
+}
+
+// String returns the number as a decimal number, in scientific notation if
+// its exponent is large, or small, with all of its digits, e.g. "12.50",
+// "0.000001", or "1.5E-10".
+func (d BigDecimal) String() string {
+	unscaled := d.Unscaled()
+
+	coeff := unscaled.String()
+	var sign string
+	if unscaled.Sign() < 0 {
+		sign, coeff = "-", coeff[1:]
+	}
+
+	scale := int64(d.scale)
+	adjusted := -scale + int64(len(coeff)-1)
+
+	if scale >= 0 && adjusted >= -6 {
+		switch {
+		case scale == 0:
+			return sign + coeff
+		case int64(len(coeff)) > scale:
+			i := int64(len(coeff)) - scale
+			return sign + coeff[:i] + "." + coeff[i:]
+		default:
+			return sign + "0." + strings.Repeat("0", int(scale)-len(coeff)) + coeff
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(sign)
+	sb.WriteString(coeff[:1])
+	if len(coeff) > 1 {
+		sb.WriteByte('.')
+		sb.WriteString(coeff[1:])
+	}
+	sb.WriteByte('E')
+	if adjusted >= 0 {
+		sb.WriteByte('+')
+	}
+	sb.WriteString(strconv.FormatInt(adjusted, 10))
+	return sb.String()
+}
+
+// MarshalText returns the number as a decimal number, see String,
+// implementing encoding.TextMarshaler.
+func (d BigDecimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses the decimal number, implementing
+// encoding.TextUnmarshaler.
+func (d *BigDecimal) UnmarshalText(text []byte) error {
+	v, err := ParseBigDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
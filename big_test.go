@@ -0,0 +1,176 @@
+package smithy
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestParseBigInteger(t *testing.T) {
+	cases := map[string]struct {
+		Value     string
+		Expect    string
+		ExpectErr bool
+	}{
+		"small":    {Value: "42", Expect: "42"},
+		"negative": {Value: "-42", Expect: "-42"},
+		"large": {
+			Value:  "123456789012345678901234567890",
+			Expect: "123456789012345678901234567890",
+		},
+		"decimal": {Value: "1.5", ExpectErr: true},
+		"empty":   {Value: "", ExpectErr: true},
+		"hex":     {Value: "0x10", ExpectErr: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, err := ParseBigInteger(c.Value)
+			if c.ExpectErr {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Expect, v.String(); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestBigIntegerCopies(t *testing.T) {
+	src := big.NewInt(10)
+	v := NewBigInteger(src)
+	src.SetInt64(20)
+	v.Int().SetInt64(30)
+
+	if e, a := "10", v.String(); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := "0", (BigInteger{}).String(); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestParseBigDecimal(t *testing.T) {
+	cases := map[string]struct {
+		Value          string
+		ExpectUnscaled string
+		ExpectScale    int32
+		ExpectString   string
+		ExpectErr      bool
+	}{
+		"integer": {
+			Value: "42", ExpectUnscaled: "42", ExpectScale: 0, ExpectString: "42",
+		},
+		"trailing zeros": {
+			Value: "12.50", ExpectUnscaled: "1250", ExpectScale: 2, ExpectString: "12.50",
+		},
+		"negative fraction": {
+			Value: "-0.001", ExpectUnscaled: "-1", ExpectScale: 3, ExpectString: "-0.001",
+		},
+		"exponent": {
+			Value: "1.5e-10", ExpectUnscaled: "15", ExpectScale: 11, ExpectString: "1.5E-10",
+		},
+		"positive exponent": {
+			Value: "1.5E+3", ExpectUnscaled: "15", ExpectScale: -2, ExpectString: "1.5E+3",
+		},
+		"small plain": {
+			Value: "0.000001", ExpectUnscaled: "1", ExpectScale: 6, ExpectString: "0.000001",
+		},
+		"not exact float": {
+			Value:          "0.1000000000000000000000000001",
+			ExpectUnscaled: "1000000000000000000000000001",
+			ExpectScale:    28,
+			ExpectString:   "0.1000000000000000000000000001",
+		},
+		"leading point": {
+			Value: ".5", ExpectUnscaled: "5", ExpectScale: 1, ExpectString: "0.5",
+		},
+		"empty":           {Value: "", ExpectErr: true},
+		"sign only":       {Value: "-", ExpectErr: true},
+		"point only":      {Value: ".", ExpectErr: true},
+		"two points":      {Value: "1.2.3", ExpectErr: true},
+		"empty exponent":  {Value: "1e", ExpectErr: true},
+		"NaN":             {Value: "NaN", ExpectErr: true},
+		"exponent range":  {Value: "1e9999999999", ExpectErr: true},
+		"underscore":      {Value: "1_000", ExpectErr: true},
+		"trailing spaces": {Value: "1 ", ExpectErr: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, err := ParseBigDecimal(c.Value)
+			if c.ExpectErr {
+				if err == nil {
+					t.Fatalf("expect error, got %v", v)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.ExpectUnscaled, v.Unscaled().String(); e != a {
+				t.Errorf("expect unscaled %v, got %v", e, a)
+			}
+			if e, a := c.ExpectScale, v.Scale(); e != a {
+				t.Errorf("expect scale %v, got %v", e, a)
+			}
+			if e, a := c.ExpectString, v.String(); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+
+			// the string form parses to the same value.
+			v2, err := ParseBigDecimal(v.String())
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if v.Rat().Cmp(v2.Rat()) != 0 {
+				t.Errorf("expect %v to round-trip, got %v", v, v2)
+			}
+		})
+	}
+}
+
+func TestBigDecimalRat(t *testing.T) {
+	cases := map[string]struct {
+		Value  BigDecimal
+		Expect *big.Rat
+	}{
+		"zero":     {Value: BigDecimal{}, Expect: big.NewRat(0, 1)},
+		"fraction": {Value: NewBigDecimal(big.NewInt(125), 2), Expect: big.NewRat(5, 4)},
+		"negative scale": {
+			Value:  NewBigDecimal(big.NewInt(-3), -2),
+			Expect: big.NewRat(-300, 1),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if a := c.Value.Rat(); c.Expect.Cmp(a) != 0 {
+				t.Errorf("expect %v, got %v", c.Expect, a)
+			}
+		})
+	}
+}
+
+func TestBigDecimalText(t *testing.T) {
+	var v struct {
+		A BigDecimal
+		B BigInteger
+	}
+	if err := json.Unmarshal([]byte(`{"A":"3.14159","B":"-7"}`), &v); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := `{"A":"3.14159","B":"-7"}`, string(b); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
@@ -0,0 +1,115 @@
+package codec
+
+import (
+	"fmt"
+	"mime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Shape IDs of the protocols with a codec provided by this package, see
+// JSONCodec.
+const (
+	ProtocolAWSJSON10 = "aws.protocols#awsJson1_0"
+	ProtocolAWSJSON11 = "aws.protocols#awsJson1_1"
+)
+
+// Codec marshals and unmarshals values for a protocol.
+type Codec interface {
+	// Protocol returns the shape ID of the protocol the codec implements.
+	Protocol() string
+
+	// MediaType returns the media type of payloads marshaled by the codec.
+	MediaType() string
+
+	// Marshal returns the encoding of v.
+	Marshal(v interface{}) ([]byte, error)
+
+	// Unmarshal decodes data into the value pointed to by v.
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// UnsupportedProtocolError is returned when no codec is registered for a
+// protocol.
+type UnsupportedProtocolError struct {
+	Protocol string
+}
+
+func (e *UnsupportedProtocolError) Error() string {
+	return fmt.Sprintf("no codec registered for protocol %q", e.Protocol)
+}
+
+// Registry is a set of codecs by protocol. Registry is safe for concurrent
+// use.
+type Registry struct {
+	mu     sync.RWMutex
+	codecs map[string]Codec
+}
+
+// NewRegistry returns a Registry with the codecs registered.
+func NewRegistry(codecs ...Codec) *Registry {
+	r := &Registry{
+		codecs: make(map[string]Codec, len(codecs)),
+	}
+	for _, c := range codecs {
+		r.Register(c)
+	}
+	return r
+}
+
+// Register registers the codec for its protocol, replacing any codec
+// previously registered for the protocol.
+func (r *Registry) Register(c Codec) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.codecs == nil {
+		r.codecs = map[string]Codec{}
+	}
+	r.codecs[c.Protocol()] = c
+}
+
+// Lookup returns the codec registered for the protocol. Returns an
+// UnsupportedProtocolError if no codec is registered.
+func (r *Registry) Lookup(protocol string) (Codec, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.codecs[protocol]
+	if !ok {
+		return nil, &UnsupportedProtocolError{Protocol: protocol}
+	}
+	return c, nil
+}
+
+// LookupMediaType returns the codec registered with the media type. Media
+// type parameters, such as charset, are ignored. If more than one codec has
+// the media type, the codec of the first protocol in sorted order is
+// returned.
+func (r *Registry) LookupMediaType(mediaType string) (Codec, bool) {
+	if mt, _, err := mime.ParseMediaType(mediaType); err == nil {
+		mediaType = mt
+	}
+
+	for _, p := range r.Protocols() {
+		c, _ := r.Lookup(p)
+		if strings.EqualFold(c.MediaType(), mediaType) {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// Protocols returns the sorted protocols with a registered codec.
+func (r *Registry) Protocols() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	protocols := make([]string, 0, len(r.codecs))
+	for p := range r.codecs {
+		protocols = append(protocols, p)
+	}
+	sort.Strings(protocols)
+	return protocols
+}
@@ -0,0 +1,91 @@
+package codec
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testShape struct {
+	Name  string `document:"name"`
+	Count int    `document:"count,omitempty"`
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(
+		NewJSONCodec(ProtocolAWSJSON11, "application/x-amz-json-1.1"),
+	)
+	r.Register(NewJSONCodec(ProtocolAWSJSON10, "application/x-amz-json-1.0"))
+
+	if e, a := []string{ProtocolAWSJSON10, ProtocolAWSJSON11}, r.Protocols(); !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v protocols, got %v", e, a)
+	}
+
+	c, err := r.Lookup(ProtocolAWSJSON10)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "application/x-amz-json-1.0", c.MediaType(); e != a {
+		t.Errorf("expect %v media type, got %v", e, a)
+	}
+
+	_, err = r.Lookup("smithy.protocols#rpcv2Cbor")
+	var unsupported *UnsupportedProtocolError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expect unsupported protocol error, got %v", err)
+	}
+	if e, a := "smithy.protocols#rpcv2Cbor", unsupported.Protocol; e != a {
+		t.Errorf("expect %v protocol, got %v", e, a)
+	}
+}
+
+func TestJSONCodec(t *testing.T) {
+	c := NewJSONCodec(ProtocolAWSJSON10, "application/x-amz-json-1.0")
+
+	b, err := c.Marshal(&testShape{Name: "abc"})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := `{"name":"abc"}`, string(b); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	var actual testShape
+	if err := c.Unmarshal([]byte(`{"name":"xyz","count":3}`), &actual); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := (testShape{Name: "xyz", Count: 3}), actual; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestRegistry_LookupMediaType(t *testing.T) {
+	r := NewRegistry(
+		NewJSONCodec(ProtocolAWSJSON10, "application/x-amz-json-1.0"),
+		NewJSONCodec(ProtocolAWSJSON11, "application/x-amz-json-1.1"),
+	)
+
+	cases := map[string]struct {
+		mediaType string
+		expect    string
+	}{
+		"exact":      {"application/x-amz-json-1.1", ProtocolAWSJSON11},
+		"parameters": {"application/x-amz-json-1.1; charset=utf-8", ProtocolAWSJSON11},
+		"case":       {"Application/X-Amz-Json-1.0", ProtocolAWSJSON10},
+		"unknown":    {"application/cbor", ""},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, ok := r.LookupMediaType(c.mediaType)
+			if e, a := len(c.expect) != 0, ok; e != a {
+				t.Fatalf("expect found %v, got %v", e, a)
+			}
+			if ok {
+				if e, a := c.expect, actual.Protocol(); e != a {
+					t.Errorf("expect %v protocol, got %v", e, a)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Package codec provides the protocol codec abstraction used to serialize
+// operation inputs and deserialize operation outputs independently of
+// generated protocol code.
+//
+// A Codec marshals and unmarshals values for a single protocol, identified
+// by the protocol's shape ID, such as aws.protocols#awsJson1_0. Codecs are collected in a Registry, and the codec
+// an operation uses is selected on its stack by the middleware added with
+// AddResolveCodecMiddleware. The protocol can be switched for a single call,
+// e.g. after protocol negotiation, with WithProtocol.
+//
+//	registry := codec.NewRegistry(
+//		codec.NewJSONCodec(codec.ProtocolAWSJSON10, "application/x-amz-json-1.0"),
+//		codec.NewJSONCodec(codec.ProtocolAWSJSON11, "application/x-amz-json-1.1"),
+//	)
+//
+//	err := codec.AddResolveCodecMiddleware(stack, registry, codec.ProtocolAWSJSON10)
+//
+// Middleware in later steps retrieve the resolved codec with GetCodec.
+//
+// # Scope
+//
+// This package provides the Codec abstraction, the Registry, and JSONCodec,
+// for the payloads of the awsJson1_0 and awsJson1_1 protocols. JSONCodec
+// converts values by reflection with the document/json encoder and decoder,
+// using the field names, and document struct tags, of the values. It does not
+// apply Smithy traits, such as timestampFormat, and does not support
+// timestamps or unions, so it is suited to payloads whose shapes do not use
+// them.
+//
+// No codec is provided for the restJson1, restXml, awsQuery, or rpcv2Cbor
+// protocols, which require the HTTP binding, XML, query, and CBOR
+// serialization of their shapes' traits. Codecs for those protocols, such as
+// generated code, are registered with the shape ID of their protocol.
+package codec
@@ -0,0 +1,50 @@
+package codec
+
+import (
+	"bytes"
+
+	"github.com/aws/smithy-go/document/json"
+)
+
+// JSONCodec is a Codec for the payloads of the awsJson1_0 and awsJson1_1
+// protocols. Values are converted with the document/json encoder and decoder.
+// Smithy traits of the values' shapes are not applied, see the package
+// documentation.
+type JSONCodec struct {
+	protocol  string
+	mediaType string
+
+	encoder *json.Encoder
+	decoder *json.Decoder
+}
+
+// NewJSONCodec returns a JSONCodec for the protocol, marshaling payloads of
+// the media type.
+func NewJSONCodec(protocol, mediaType string) *JSONCodec {
+	return &JSONCodec{
+		protocol:  protocol,
+		mediaType: mediaType,
+		encoder:   json.NewEncoder(),
+		decoder:   json.NewDecoder(),
+	}
+}
+
+// Protocol returns the shape ID of the codec's protocol.
+func (c *JSONCodec) Protocol() string {
+	return c.protocol
+}
+
+// MediaType returns the media type of the codec's payloads.
+func (c *JSONCodec) MediaType() string {
+	return c.mediaType
+}
+
+// Marshal returns the JSON encoding of v.
+func (c *JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return c.encoder.Encode(v)
+}
+
+// Unmarshal decodes the JSON data into the value pointed to by v.
+func (c *JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return c.decoder.Decode(bytes.NewReader(data), v)
+}
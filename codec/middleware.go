@@ -0,0 +1,104 @@
+package codec
+
+import (
+	"context"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+type codecKey struct{}
+
+// WithCodec returns a context with the codec the operation uses to
+// serialize and deserialize its payloads.
+//
+// Scoped to stack values. Use middleware#ClearStackValues to clear all stack
+// values.
+func WithCodec(ctx context.Context, c Codec) context.Context {
+	return middleware.WithStackValue(ctx, codecKey{}, c)
+}
+
+// GetCodec returns the codec the operation uses, or nil if none was set.
+//
+// Scoped to stack values. Use middleware#ClearStackValues to clear all stack
+// values.
+func GetCodec(ctx context.Context) Codec {
+	c, _ := middleware.GetStackValue(ctx, codecKey{}).(Codec)
+	return c
+}
+
+type protocolKey struct{}
+
+// WithProtocol returns a context that selects the protocol used by the
+// operation calls made with it, overriding the default protocol of the
+// operation's ResolveCodec middleware.
+func WithProtocol(ctx context.Context, protocol string) context.Context {
+	return context.WithValue(ctx, protocolKey{}, protocol)
+}
+
+// GetProtocol returns the protocol selected by WithProtocol, or an empty
+// string if none was selected.
+func GetProtocol(ctx context.Context) string {
+	v, _ := ctx.Value(protocolKey{}).(string)
+	return v
+}
+
+// ResolveCodec is an initialize middleware that resolves the codec the
+// operation uses from a Registry.
+type ResolveCodec struct {
+	// Registry is the set of codecs the operation supports.
+	Registry *Registry
+
+	// Protocol is the default protocol of the operation, used unless a
+	// protocol is selected with WithProtocol.
+	Protocol string
+}
+
+// ID returns the middleware identifier.
+func (*ResolveCodec) ID() string {
+	return "ResolveCodec"
+}
+
+// HandleInitialize resolves the codec of the selected protocol, and sets it
+// on the context for the rest of the stack.
+func (m *ResolveCodec) HandleInitialize(
+	ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+) (
+	out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+) {
+	protocol := GetProtocol(ctx)
+	if len(protocol) == 0 {
+		protocol = m.Protocol
+	}
+
+	c, err := m.Registry.Lookup(protocol)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	return next.HandleInitialize(WithCodec(ctx, c), in)
+}
+
+// AddResolveCodecMiddleware adds the ResolveCodec middleware to the front of
+// the stack's initialize step.
+func AddResolveCodecMiddleware(stack *middleware.Stack, registry *Registry, protocol string) error {
+	return stack.Initialize.Add(&ResolveCodec{
+		Registry: registry,
+		Protocol: protocol,
+	}, middleware.Before)
+}
+
+type responseCodecKey struct{}
+
+// SetResponseCodec sets the codec negotiated for the operation's response
+// on the metadata, overriding the codec resolved for the operation when the
+// response is deserialized.
+func SetResponseCodec(metadata *middleware.Metadata, c Codec) {
+	metadata.Set(responseCodecKey{}, c)
+}
+
+// GetResponseCodec returns the codec negotiated for the operation's
+// response, if any.
+func GetResponseCodec(metadata middleware.Metadata) (Codec, bool) {
+	c, ok := metadata.Get(responseCodecKey{}).(Codec)
+	return c, ok
+}
@@ -0,0 +1,69 @@
+package codec
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestResolveCodec(t *testing.T) {
+	registry := NewRegistry(
+		NewJSONCodec(ProtocolAWSJSON10, "application/x-amz-json-1.0"),
+		NewJSONCodec(ProtocolAWSJSON11, "application/x-amz-json-1.1"),
+	)
+
+	cases := map[string]struct {
+		ctx       context.Context
+		expect    string
+		expectErr bool
+	}{
+		"default protocol": {
+			ctx:    context.Background(),
+			expect: ProtocolAWSJSON10,
+		},
+		"selected protocol": {
+			ctx:    WithProtocol(context.Background(), ProtocolAWSJSON11),
+			expect: ProtocolAWSJSON11,
+		},
+		"unsupported protocol": {
+			ctx:       WithProtocol(context.Background(), "aws.protocols#restXml"),
+			expectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			stack := middleware.NewStack("test", func() interface{} { return struct{}{} })
+			if err := AddResolveCodecMiddleware(stack, registry, ProtocolAWSJSON10); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			var actual Codec
+			handler := middleware.DecorateHandler(middleware.HandlerFunc(
+				func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+					actual = GetCodec(ctx)
+					return nil, middleware.Metadata{}, nil
+				}), stack)
+
+			_, _, err := handler.Handle(c.ctx, struct{}{})
+			if c.expectErr {
+				var unsupported *UnsupportedProtocolError
+				if !errors.As(err, &unsupported) {
+					t.Fatalf("expect unsupported protocol error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if actual == nil {
+				t.Fatalf("expect codec to be resolved")
+			}
+			if e, a := c.expect, actual.Protocol(); e != a {
+				t.Errorf("expect %v protocol, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// Package document provides the types and interfaces for working with Smithy
+// document shapes, untyped data that is serialized with the protocol of the
+// operation it is used with.
+//
+// Go values are converted to and from documents by protocol specific
+// encoders and decoders, such as the document/json package. Struct fields are
+// mapped to document object members by name, which can be customized with
+// the "document" struct tag.
+//
+//	type Example struct {
+//		// Encoded as the "name" member.
+//		Name string `document:"name"`
+//		// Omitted from the document if the value is the zero value.
+//		Count int `document:",omitempty"`
+//		// Never encoded or decoded.
+//		Internal string `document:"-"`
+//	}
+//
+// Document numbers are decoded as the Number type when the target is an
+// empty interface, preserving the number's precision until the caller
+// converts the Number to a Go numeric type, big.Int, or big.Float.
+//
+// Object members which are not modeled by the fields of a struct are
+// discarded, unless the struct has an UnknownMembers field, and the decoder
+// is configured to retain unknown members. The retained members are read
+// with GetUnknownMembers.
+package document
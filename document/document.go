@@ -0,0 +1,182 @@
+package document
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// Marshaler is an interface for a type that marshals a document to its
+// protocol-specific byte representation and returns the resulting bytes. A
+// non-nil error will be returned if an error is encountered during
+// marshaling.
+//
+// Marshal supports basic scalars (int, uint, float, bool, string), big.Int,
+// big.Float, smithy.BigInteger, and smithy.BigDecimal, maps, slices, and
+// structs. Anonymous nested types are
+// flattened based on Go anonymous type visibility.
+//
+// When defining struct types, the `document` struct tag can be used to
+// control how the value will be marshaled into the resulting protocol
+// document.
+type Marshaler interface {
+	MarshalSmithyDocument() ([]byte, error)
+}
+
+// Unmarshaler is an interface for a type that unmarshals a document from its
+// protocol-specific representation, and stores the result into the value
+// pointed by v. If v is nil or not a pointer then InvalidUnmarshalError will
+// be returned.
+//
+// Unmarshaler supports the same encodings produced by a document Marshaler.
+// This includes support for the `document` struct tag.
+type Unmarshaler interface {
+	UnmarshalSmithyDocument(v interface{}) error
+}
+
+// Document is a Smithy document value that can be marshaled to its protocol
+// representation, and unmarshaled into a Go value.
+type Document interface {
+	Marshaler
+	Unmarshaler
+}
+
+// NoSerde is a sentinel value to indicate that a given type should not be
+// marshaled or unmarshaled into a protocol document.
+type NoSerde struct{}
+
+func (n NoSerde) noSmithyDocumentSerde() {}
+
+type noSmithyDocumentSerde interface {
+	noSmithyDocumentSerde()
+}
+
+// IsNoSerde returns whether the given type implements the no smithy document
+// serde interface.
+func IsNoSerde(x interface{}) bool {
+	_, ok := x.(noSmithyDocumentSerde)
+	return ok
+}
+
+// Number is an arbitrary precision numerical value of a document. The value
+// is the number's text representation, converted to a Go numeric type only
+// when requested.
+type Number string
+
+// Int64 returns the number as a int64. Returns an error if the number is not
+// an integer, or overflows int64.
+func (n Number) Int64() (int64, error) {
+	return n.intOfBitSize(64)
+}
+
+func (n Number) intOfBitSize(bitSize int) (int64, error) {
+	return strconv.ParseInt(string(n), 10, bitSize)
+}
+
+// Uint64 returns the number as a uint64. Returns an error if the number is
+// not an unsigned integer, or overflows uint64.
+func (n Number) Uint64() (uint64, error) {
+	return n.uintOfBitSize(64)
+}
+
+func (n Number) uintOfBitSize(bitSize int) (uint64, error) {
+	return strconv.ParseUint(string(n), 10, bitSize)
+}
+
+// Float32 returns the number parsed as a 32-bit float, returns a float64.
+func (n Number) Float32() (float64, error) {
+	return n.floatOfBitSize(32)
+}
+
+// Float64 returns the number as a float64.
+func (n Number) Float64() (float64, error) {
+	return n.floatOfBitSize(64)
+}
+
+func (n Number) floatOfBitSize(bitSize int) (float64, error) {
+	return strconv.ParseFloat(string(n), bitSize)
+}
+
+// BigInt returns the number as a big.Int. Returns an error if the number is
+// not an integer.
+func (n Number) BigInt() (*big.Int, error) {
+	v, ok := new(big.Int).SetString(string(n), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer number, %q", string(n))
+	}
+	return v, nil
+}
+
+// BigFloat returns the number as a big.Float, with enough precision to
+// represent the number's text exactly where possible.
+func (n Number) BigFloat() (*big.Float, error) {
+	v, _, err := big.ParseFloat(string(n), 10, uint(len(n))*4+64, big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf("invalid decimal number, %q, %w", string(n), err)
+	}
+	return v, nil
+}
+
+// String returns the number value as a string.
+func (n Number) String() string {
+	return string(n)
+}
+
+// UnmarshalTypeError is an error type representing an error
+// unmarshaling a Smithy document to a Go value type. This is different
+// from UnmarshalError in that it does not wrap an underlying error type.
+type UnmarshalTypeError struct {
+	Value string
+	Type  reflect.Type
+	Err   error
+}
+
+// Unwrap returns the underlying unwrapped error if any.
+func (e *UnmarshalTypeError) Unwrap() error {
+	return e.Err
+}
+
+// Error returns the string representation of the error.
+// Satisfying the error interface.
+func (e *UnmarshalTypeError) Error() string {
+	msg := fmt.Sprintf("unmarshal failed, cannot unmarshal %s into Go value type %s",
+		e.Value, e.Type.String())
+	if e.Err != nil {
+		msg += ", " + e.Err.Error()
+	}
+	return msg
+}
+
+// An InvalidUnmarshalError is an error type representing an invalid type
+// encountered while unmarshaling a Smithy document to a Go value type.
+type InvalidUnmarshalError struct {
+	Type reflect.Type
+}
+
+// Error returns the string representation of the error.
+// Satisfying the error interface.
+func (e *InvalidUnmarshalError) Error() string {
+	var msg string
+	if e.Type == nil {
+		msg = "cannot unmarshal to nil value"
+	} else if e.Type.Kind() != reflect.Ptr {
+		msg = fmt.Sprintf("cannot unmarshal to non-pointer value, got %s", e.Type.String())
+	} else {
+		msg = fmt.Sprintf("cannot unmarshal to nil value, %s", e.Type.String())
+	}
+
+	return fmt.Sprintf("unmarshal failed, %s", msg)
+}
+
+// An InvalidMarshalError is an error type representing an error
+// occurring when marshaling a Go value type.
+type InvalidMarshalError struct {
+	Message string
+}
+
+// Error returns the string representation of the error.
+// Satisfying the error interface.
+func (e *InvalidMarshalError) Error() string {
+	return fmt.Sprintf("marshal failed, %s", e.Message)
+}
@@ -0,0 +1,34 @@
+package document
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestNumber(t *testing.T) {
+	if v, err := Number("-12").Int64(); err != nil || v != -12 {
+		t.Errorf("expect -12, got %v, %v", v, err)
+	}
+	if _, err := Number("-12").Uint64(); err == nil {
+		t.Errorf("expect error for negative uint64")
+	}
+	if v, err := Number("1.5").Float64(); err != nil || v != 1.5 {
+		t.Errorf("expect 1.5, got %v, %v", v, err)
+	}
+	if _, err := Number("1.5").Int64(); err == nil {
+		t.Errorf("expect error for non-integer int64")
+	}
+
+	bi, err := Number("123456789012345678901234567890").BigInt()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	expect, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	if bi.Cmp(expect) != 0 {
+		t.Errorf("expect %v, got %v", expect, bi)
+	}
+
+	if _, err := Number("abc").BigFloat(); err == nil {
+		t.Errorf("expect error for invalid number")
+	}
+}
@@ -0,0 +1,288 @@
+// Package serde provides the shared reflection utilities used by the
+// protocol document encoders and decoders.
+package serde
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/smithy-go/document"
+)
+
+const tagKey = "document"
+
+// Tag is the parsed "document" struct tag of a field.
+type Tag struct {
+	Name      string
+	Ignore    bool
+	OmitEmpty bool
+}
+
+// ParseTag parses the "document" struct tag value of a field.
+func ParseTag(tag string) (t Tag) {
+	if tag == "-" {
+		t.Ignore = true
+		return t
+	}
+
+	parts := strings.Split(tag, ",")
+	t.Name = parts[0]
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "omitempty":
+			t.OmitEmpty = true
+		}
+	}
+	return t
+}
+
+// Field is an encodable field of a struct type.
+type Field struct {
+	Tag
+
+	// Index is the field index sequence, for use with
+	// reflect.Value.FieldByIndex.
+	Index []int
+	Type  reflect.Type
+}
+
+// CachedFields is the set of encodable fields of a struct type, in
+// declaration order.
+type CachedFields struct {
+	fields      []Field
+	fieldsByKey map[string]int
+	fieldsByLwr map[string]int
+
+	unknownMembers []int
+}
+
+// All returns all the encodable fields of the struct type.
+func (f *CachedFields) All() []Field {
+	return f.fields
+}
+
+// FieldByName returns the field for the document member name. The name is
+// matched exactly before falling back to a case-insensitive match.
+func (f *CachedFields) FieldByName(name string) (Field, bool) {
+	if i, ok := f.fieldsByKey[name]; ok {
+		return f.fields[i], true
+	}
+	if i, ok := f.fieldsByLwr[strings.ToLower(name)]; ok {
+		return f.fields[i], true
+	}
+	return Field{}, false
+}
+
+// UnknownMembersIndex returns the index of the struct's field of type
+// document.UnknownMembers, and whether the struct has one. The field is not
+// one of the encodable fields.
+func (f *CachedFields) UnknownMembersIndex() ([]int, bool) {
+	return f.unknownMembers, f.unknownMembers != nil
+}
+
+var unknownMembersType = reflect.TypeOf(document.UnknownMembers(nil))
+
+var fieldCache sync.Map // map[reflect.Type]*CachedFields
+
+// GetStructFields returns the encodable fields of the struct type, caching
+// the result for subsequent calls.
+func GetStructFields(t reflect.Type) *CachedFields {
+	if v, ok := fieldCache.Load(t); ok {
+		return v.(*CachedFields)
+	}
+
+	f := enumFields(t)
+	v, _ := fieldCache.LoadOrStore(t, f)
+	return v.(*CachedFields)
+}
+
+// enumFields returns the exported fields of the struct type, flattening
+// anonymous struct fields. Fields of shallower depth take precedence over
+// fields of the same name that are more deeply nested, and fields of the same
+// name at the same depth are dropped, matching Go's anonymous field
+// visibility.
+func enumFields(t reflect.Type) *CachedFields {
+	var candidates []candidate
+	var unknownMembers []int
+	visited := map[reflect.Type]bool{}
+
+	type level struct {
+		typ   reflect.Type
+		index []int
+	}
+	current := []level{{typ: t}}
+
+	for depth := 0; len(current) != 0; depth++ {
+		var next []level
+		for _, l := range current {
+			if visited[l.typ] {
+				continue
+			}
+			visited[l.typ] = true
+
+			for i := 0; i < l.typ.NumField(); i++ {
+				sf := l.typ.Field(i)
+				tag := ParseTag(sf.Tag.Get(tagKey))
+				if tag.Ignore {
+					continue
+				}
+
+				index := make([]int, len(l.index)+1)
+				copy(index, l.index)
+				index[len(l.index)] = i
+
+				ft := sf.Type
+				if sf.Anonymous {
+					if ft.Kind() == reflect.Ptr {
+						ft = ft.Elem()
+					}
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+					if tag.Name == "" && ft.Kind() == reflect.Struct {
+						next = append(next, level{typ: ft, index: index})
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+
+				if sf.Type == unknownMembersType {
+					if depth == 0 && unknownMembers == nil {
+						unknownMembers = index
+					}
+					continue
+				}
+
+				tagged := tag.Name != ""
+				if !tagged {
+					tag.Name = sf.Name
+				}
+				candidates = append(candidates, candidate{
+					Field: Field{
+						Tag:   tag,
+						Index: index,
+						Type:  sf.Type,
+					},
+					depth:  depth,
+					tagged: tagged,
+				})
+			}
+		}
+		current = next
+	}
+
+	// Resolve name conflicts by the shallowest depth, preferring tagged
+	// fields. Ambiguous names are dropped.
+	byName := map[string][]candidate{}
+	var order []string
+	for _, c := range candidates {
+		if _, ok := byName[c.Name]; !ok {
+			order = append(order, c.Name)
+		}
+		byName[c.Name] = append(byName[c.Name], c)
+	}
+
+	var fields []Field
+	for _, name := range order {
+		if field, ok := dominantField(byName[name]); ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return lessIndex(fields[i].Index, fields[j].Index)
+	})
+
+	f := &CachedFields{
+		fields:      fields,
+		fieldsByKey: map[string]int{},
+		fieldsByLwr: map[string]int{},
+
+		unknownMembers: unknownMembers,
+	}
+	for i, field := range fields {
+		f.fieldsByKey[field.Name] = i
+		lwr := strings.ToLower(field.Name)
+		if _, ok := f.fieldsByLwr[lwr]; !ok {
+			f.fieldsByLwr[lwr] = i
+		}
+	}
+
+	return f
+}
+
+type candidate struct {
+	Field
+	depth  int
+	tagged bool
+}
+
+// dominantField returns the field of the shallowest depth, preferring a
+// tagged field if multiple exist at that depth. Returns false if the field
+// is ambiguous.
+func dominantField(cs []candidate) (Field, bool) {
+	minDepth := cs[0].depth
+	for _, c := range cs[1:] {
+		if c.depth < minDepth {
+			minDepth = c.depth
+		}
+	}
+
+	var dominant []candidate
+	for _, c := range cs {
+		if c.depth == minDepth {
+			dominant = append(dominant, c)
+		}
+	}
+	if len(dominant) == 1 {
+		return dominant[0].Field, true
+	}
+
+	var tagged []candidate
+	for _, c := range dominant {
+		if c.tagged {
+			tagged = append(tagged, c)
+		}
+	}
+	if len(tagged) == 1 {
+		return tagged[0].Field, true
+	}
+	return Field{}, false
+}
+
+func lessIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// IsZeroValue returns whether the value is the zero value of its type, for
+// fields with the omitempty tag option.
+func IsZeroValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
+
+// ValueElem walks the pointers and interfaces of the value, returning the
+// first value that is neither. Returns an invalid value if a nil pointer or
+// interface was encountered.
+func ValueElem(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
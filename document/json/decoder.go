@@ -0,0 +1,373 @@
+package json
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/document"
+	"github.com/aws/smithy-go/document/internal/serde"
+)
+
+// DecoderOptions is the set of options that can be configured for a Decoder.
+type DecoderOptions struct {
+	// RetainUnknownMembers retains the members of JSON objects which are not
+	// modeled by the fields of the struct they are decoded into, in the
+	// struct's document.UnknownMembers field, if it has one. Retrieved with
+	// document.GetUnknownMembers.
+	RetainUnknownMembers bool
+}
+
+// Decoder is a Smithy document decoder for JSON based protocols.
+type Decoder struct {
+	options DecoderOptions
+}
+
+// NewDecoder returns a Decoder for deserializing Smithy documents.
+func NewDecoder(optFns ...func(options *DecoderOptions)) *Decoder {
+	o := DecoderOptions{}
+
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	return &Decoder{
+		options: o,
+	}
+}
+
+// DecodeJSONInterface decodes the supported JSON input types and stores the
+// result in the value pointed by toValue.
+//
+// If toValue is not a compatible type, or an error occurs while decoding
+// DecodeJSONInterface will return an error.
+//
+// The supported input JSON types are:
+//   - json.Number
+//   - []interface{}
+//   - map[string]interface{}
+//   - string
+//   - bool
+//   - nil
+//
+// The input is expected to be decoded by a json.Decoder with UseNumber
+// enabled, so that the precision of numbers is preserved.
+func (d *Decoder) DecodeJSONInterface(input interface{}, toValue interface{}) error {
+	if document.IsNoSerde(toValue) {
+		return fmt.Errorf("unsupported type: %T", toValue)
+	}
+
+	v := reflect.ValueOf(toValue)
+
+	if v.Kind() != reflect.Ptr || v.IsNil() || !v.IsValid() {
+		return &document.InvalidUnmarshalError{Type: reflect.TypeOf(toValue)}
+	}
+
+	return d.decode(input, v.Elem())
+}
+
+func (d *Decoder) decode(jv interface{}, rv reflect.Value) error {
+	if jv == nil {
+		switch rv.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+			rv.Set(reflect.Zero(rv.Type()))
+		}
+		return nil
+	}
+
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return d.decode(jv, rv.Elem())
+	}
+
+	if rv.Kind() == reflect.Interface {
+		if rv.NumMethod() == 0 {
+			rv.Set(reflect.ValueOf(toInterface(jv)))
+			return nil
+		}
+		if documentType.Implements(rv.Type()) {
+			return d.decodeDocument(jv, rv)
+		}
+		return &document.UnmarshalTypeError{Value: jsonTypeName(jv), Type: rv.Type()}
+	}
+
+	switch tv := jv.(type) {
+	case json.Number:
+		return d.decodeNumber(document.Number(tv), rv)
+	case string:
+		return d.decodeString(tv, rv)
+	case bool:
+		if rv.Kind() != reflect.Bool {
+			return &document.UnmarshalTypeError{Value: "bool", Type: rv.Type()}
+		}
+		rv.SetBool(tv)
+		return nil
+	case []interface{}:
+		return d.decodeList(tv, rv)
+	case map[string]interface{}:
+		return d.decodeMap(tv, rv)
+	default:
+		return fmt.Errorf("unsupported json type, %T", jv)
+	}
+}
+
+// decodeDocument sets the interface value to a document of the JSON input
+// value, for members that are documents themselves, e.g. document.Document.
+func (d *Decoder) decodeDocument(jv interface{}, rv reflect.Value) error {
+	b, err := json.Marshal(jv)
+	if err != nil {
+		return err
+	}
+	rv.Set(reflect.ValueOf(NewDocumentFromBytes(b)))
+	return nil
+}
+
+func (d *Decoder) decodeNumber(n document.Number, rv reflect.Value) error {
+	switch rv.Type() {
+	case numberType:
+		rv.SetString(string(n))
+		return nil
+	case bigIntType:
+		v, err := n.BigInt()
+		if err != nil {
+			return &document.UnmarshalTypeError{Value: "number " + n.String(), Type: rv.Type(), Err: err}
+		}
+		rv.Set(reflect.ValueOf(*v))
+		return nil
+	case bigFloatType:
+		v, err := n.BigFloat()
+		if err != nil {
+			return &document.UnmarshalTypeError{Value: "number " + n.String(), Type: rv.Type(), Err: err}
+		}
+		rv.Set(reflect.ValueOf(*v))
+		return nil
+	case bigIntegerTy:
+		v, err := smithy.ParseBigInteger(string(n))
+		if err != nil {
+			return &document.UnmarshalTypeError{Value: "number " + n.String(), Type: rv.Type(), Err: err}
+		}
+		rv.Set(reflect.ValueOf(v))
+		return nil
+	case bigDecimalTy:
+		v, err := smithy.ParseBigDecimal(string(n))
+		if err != nil {
+			return &document.UnmarshalTypeError{Value: "number " + n.String(), Type: rv.Type(), Err: err}
+		}
+		rv.Set(reflect.ValueOf(v))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(string(n), 10, rv.Type().Bits())
+		if err != nil {
+			return &document.UnmarshalTypeError{Value: "number " + n.String(), Type: rv.Type(), Err: err}
+		}
+		rv.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v, err := strconv.ParseUint(string(n), 10, rv.Type().Bits())
+		if err != nil {
+			return &document.UnmarshalTypeError{Value: "number " + n.String(), Type: rv.Type(), Err: err}
+		}
+		rv.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(string(n), rv.Type().Bits())
+		if err != nil {
+			return &document.UnmarshalTypeError{Value: "number " + n.String(), Type: rv.Type(), Err: err}
+		}
+		rv.SetFloat(v)
+	default:
+		return &document.UnmarshalTypeError{Value: "number", Type: rv.Type()}
+	}
+
+	return nil
+}
+
+func (d *Decoder) decodeString(s string, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+	case reflect.Float32, reflect.Float64:
+		var v float64
+		switch s {
+		case "NaN":
+			v = math.NaN()
+		case "Infinity":
+			v = math.Inf(1)
+		case "-Infinity":
+			v = math.Inf(-1)
+		default:
+			return &document.UnmarshalTypeError{Value: "string", Type: rv.Type()}
+		}
+		rv.SetFloat(v)
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() != reflect.Uint8 {
+			return &document.UnmarshalTypeError{Value: "string", Type: rv.Type()}
+		}
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return &document.UnmarshalTypeError{Value: "string", Type: rv.Type(), Err: err}
+		}
+		rv.SetBytes(b)
+	default:
+		return &document.UnmarshalTypeError{Value: "string", Type: rv.Type()}
+	}
+
+	return nil
+}
+
+func (d *Decoder) decodeList(vs []interface{}, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Slice:
+		s := reflect.MakeSlice(rv.Type(), len(vs), len(vs))
+		for i, v := range vs {
+			if err := d.decode(v, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		rv.Set(s)
+	case reflect.Array:
+		if len(vs) > rv.Len() {
+			return &document.UnmarshalTypeError{
+				Value: "list",
+				Type:  rv.Type(),
+				Err:   fmt.Errorf("list length %d exceeds array length %d", len(vs), rv.Len()),
+			}
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if i >= len(vs) {
+				rv.Index(i).Set(reflect.Zero(rv.Type().Elem()))
+				continue
+			}
+			if err := d.decode(vs[i], rv.Index(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return &document.UnmarshalTypeError{Value: "list", Type: rv.Type()}
+	}
+
+	return nil
+}
+
+func (d *Decoder) decodeMap(vs map[string]interface{}, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return &document.UnmarshalTypeError{Value: "map", Type: rv.Type()}
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMapWithSize(rv.Type(), len(vs)))
+		}
+		for k, v := range vs {
+			ev := reflect.New(rv.Type().Elem()).Elem()
+			if err := d.decode(v, ev); err != nil {
+				return err
+			}
+			rv.SetMapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()), ev)
+		}
+	case reflect.Struct:
+		fields := serde.GetStructFields(rv.Type())
+		for k, v := range vs {
+			f, ok := fields.FieldByName(k)
+			if !ok {
+				d.retainUnknownMember(rv, fields, k, toInterface(v))
+				continue
+			}
+			fv, err := allocFieldByIndex(rv, f.Index)
+			if err != nil {
+				return err
+			}
+			if err := d.decode(v, fv); err != nil {
+				return err
+			}
+		}
+	default:
+		return &document.UnmarshalTypeError{Value: "map", Type: rv.Type()}
+	}
+
+	return nil
+}
+
+// retainUnknownMember sets the unknown member in the struct's
+// document.UnknownMembers field, if the decoder retains unknown members, and
+// the struct has the field.
+func (d *Decoder) retainUnknownMember(rv reflect.Value, fields *serde.CachedFields, key string, v interface{}) {
+	if !d.options.RetainUnknownMembers {
+		return
+	}
+	index, ok := fields.UnknownMembersIndex()
+	if !ok {
+		return
+	}
+
+	mv := rv.FieldByIndex(index)
+	if mv.IsNil() {
+		mv.Set(reflect.ValueOf(document.UnknownMembers{}))
+	}
+	mv.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(&v).Elem())
+}
+
+// allocFieldByIndex returns the nested field of the struct, allocating nil
+// embedded struct pointers along the path.
+func allocFieldByIndex(rv reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				if !rv.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct, %v", rv.Type().Elem())
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, nil
+}
+
+// toInterface converts the JSON input value to the value stored in an empty
+// interface, with numbers converted to document.Number.
+func toInterface(jv interface{}) interface{} {
+	switch tv := jv.(type) {
+	case json.Number:
+		return document.Number(tv)
+	case []interface{}:
+		vs := make([]interface{}, len(tv))
+		for i, v := range tv {
+			vs[i] = toInterface(v)
+		}
+		return vs
+	case map[string]interface{}:
+		vs := make(map[string]interface{}, len(tv))
+		for k, v := range tv {
+			vs[k] = toInterface(v)
+		}
+		return vs
+	default:
+		return jv
+	}
+}
+
+func jsonTypeName(jv interface{}) string {
+	switch jv.(type) {
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "map"
+	default:
+		return fmt.Sprintf("%T", jv)
+	}
+}
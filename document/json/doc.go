@@ -0,0 +1,10 @@
+// Package json provides a document Encoder and Decoder implementation that
+// is used to implement Smithy document types for JSON based protocols. The
+// Encoder and Decoder convert between Go values and the JSON representation
+// of a document.
+//
+// NewDocument returns a document.Document backed by a Go value, and
+// NewDocumentFromBytes returns a document.Document backed by the JSON bytes
+// received from a service. Documents backed by bytes are decoded lazily when
+// unmarshaled, streaming the JSON tokens into the target value.
+package json
@@ -0,0 +1,107 @@
+package json
+
+import (
+	"bytes"
+	"reflect"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/document"
+)
+
+var documentType = reflect.TypeOf((*documentFromBytes)(nil))
+
+var (
+	_ smithy.Document = (*documentFromValue)(nil)
+	_ smithy.Document = (*documentFromBytes)(nil)
+)
+
+// Marshal returns the JSON document encoding of v.
+func Marshal(v interface{}) ([]byte, error) {
+	return NewEncoder().Encode(v)
+}
+
+// Unmarshal decodes the JSON document encoded bytes into the value pointed
+// to by v.
+func Unmarshal(b []byte, v interface{}) error {
+	return unmarshalBytes(b, v)
+}
+
+// NewDocument returns a document.Document backed by the Go value v. The
+// value is marshaled with an Encoder when the document is marshaled.
+func NewDocument(v interface{}) document.Document {
+	return &documentFromValue{value: v}
+}
+
+// NewDocumentFromBytes returns a document.Document backed by the JSON
+// encoded bytes of a document, e.g. a document member received from a
+// service. The document is decoded lazily, each time it is unmarshaled, and
+// is marshaled by returning the bytes as is.
+func NewDocumentFromBytes(b []byte) document.Document {
+	return &documentFromBytes{value: b}
+}
+
+type documentFromValue struct {
+	value interface{}
+}
+
+// MarshalSmithyDocument returns the JSON encoding of the document's value.
+func (d *documentFromValue) MarshalSmithyDocument() ([]byte, error) {
+	return NewEncoder().Encode(d.value)
+}
+
+// UnmarshalSmithyDocument stores the document's value in the value pointed
+// to by v.
+func (d *documentFromValue) UnmarshalSmithyDocument(v interface{}) error {
+	b, err := d.MarshalSmithyDocument()
+	if err != nil {
+		return err
+	}
+	return unmarshalBytes(b, v)
+}
+
+// UnmarshalDocument stores the document's value in the value pointed to by v.
+func (d *documentFromValue) UnmarshalDocument(v interface{}) error {
+	return d.UnmarshalSmithyDocument(v)
+}
+
+// GetValue returns the document's value decoded as an empty interface.
+func (d *documentFromValue) GetValue() (interface{}, error) {
+	var v interface{}
+	if err := d.UnmarshalSmithyDocument(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+type documentFromBytes struct {
+	value []byte
+}
+
+// MarshalSmithyDocument returns the JSON encoded bytes of the document.
+func (d *documentFromBytes) MarshalSmithyDocument() ([]byte, error) {
+	return d.value, nil
+}
+
+// UnmarshalSmithyDocument decodes the document into the value pointed to by
+// v.
+func (d *documentFromBytes) UnmarshalSmithyDocument(v interface{}) error {
+	return unmarshalBytes(d.value, v)
+}
+
+// UnmarshalDocument decodes the document into the value pointed to by v.
+func (d *documentFromBytes) UnmarshalDocument(v interface{}) error {
+	return d.UnmarshalSmithyDocument(v)
+}
+
+// GetValue returns the document decoded as an empty interface.
+func (d *documentFromBytes) GetValue() (interface{}, error) {
+	var v interface{}
+	if err := d.UnmarshalSmithyDocument(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func unmarshalBytes(b []byte, v interface{}) error {
+	return NewDecoder().Decode(bytes.NewReader(b), v)
+}
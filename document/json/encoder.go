@@ -0,0 +1,244 @@
+package json
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/document"
+	"github.com/aws/smithy-go/document/internal/serde"
+	"github.com/aws/smithy-go/encoding/json"
+)
+
+// EncoderOptions is the set of options that can be configured for an
+// Encoder.
+type EncoderOptions struct{}
+
+// Encoder is a Smithy document encoder for JSON based protocols.
+type Encoder struct {
+	options EncoderOptions
+}
+
+// NewEncoder returns an Encoder for serializing Smithy documents.
+func NewEncoder(optFns ...func(options *EncoderOptions)) *Encoder {
+	o := EncoderOptions{}
+
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	return &Encoder{
+		options: o,
+	}
+}
+
+// Encode returns the JSON encoding of v.
+func (e *Encoder) Encode(v interface{}) ([]byte, error) {
+	encoder := json.NewEncoder()
+
+	if err := e.encode(encoder.Value, reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+
+	encodedBytes := encoder.Bytes()
+
+	if len(encodedBytes) == 0 {
+		return nil, nil
+	}
+
+	return encodedBytes, nil
+}
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	bigIntegerTy = reflect.TypeOf(smithy.BigInteger{})
+	bigDecimalTy = reflect.TypeOf(smithy.BigDecimal{})
+	numberType   = reflect.TypeOf(document.Number(""))
+	timeType     = reflect.TypeOf(time.Time{})
+	byteSliceTyp = reflect.TypeOf([]byte(nil))
+)
+
+func (e *Encoder) encode(jv json.Value, rv reflect.Value) error {
+	if document.IsNoSerde(valueInterface(rv)) {
+		return &document.InvalidMarshalError{Message: rv.Type().String() + " cannot be marshaled"}
+	}
+
+	if rv.IsValid() && rv.Type() != byteSliceTyp {
+		if m, ok := valueInterface(rv).(document.Marshaler); ok {
+			if rv.Kind() == reflect.Ptr && rv.IsNil() {
+				jv.Null()
+				return nil
+			}
+			b, err := m.MarshalSmithyDocument()
+			if err != nil {
+				return err
+			}
+			jv.Write(b)
+			return nil
+		}
+	}
+
+	rv = serde.ValueElem(rv)
+	if !rv.IsValid() {
+		jv.Null()
+		return nil
+	}
+
+	switch rv.Type() {
+	case numberType:
+		return e.encodeNumber(jv, rv.Interface().(document.Number))
+	case bigIntType:
+		v := rv.Interface().(big.Int)
+		jv.BigInteger(&v)
+		return nil
+	case bigFloatType:
+		v := rv.Interface().(big.Float)
+		jv.BigDecimal(&v)
+		return nil
+	case bigIntegerTy:
+		jv.BigIntegerValue(rv.Interface().(smithy.BigInteger))
+		return nil
+	case bigDecimalTy:
+		jv.BigDecimalValue(rv.Interface().(smithy.BigDecimal))
+		return nil
+	case timeType:
+		return &document.InvalidMarshalError{Message: "time.Time values are not supported by documents"}
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		jv.Boolean(rv.Bool())
+	case reflect.String:
+		jv.String(rv.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		jv.Long(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		jv.Write(strconv.AppendUint(nil, rv.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		encodeFloat(jv, rv.Float(), rv.Type().Bits())
+	case reflect.Slice:
+		if rv.IsNil() {
+			jv.Null()
+			return nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			jv.Base64EncodeBytes(rv.Bytes())
+			return nil
+		}
+		return e.encodeList(jv, rv)
+	case reflect.Array:
+		return e.encodeList(jv, rv)
+	case reflect.Map:
+		return e.encodeMap(jv, rv)
+	case reflect.Struct:
+		return e.encodeStruct(jv, rv)
+	default:
+		return &document.InvalidMarshalError{Message: "unsupported type " + rv.Type().String()}
+	}
+
+	return nil
+}
+
+func (e *Encoder) encodeNumber(jv json.Value, n document.Number) error {
+	if _, err := n.BigFloat(); err != nil {
+		return &document.InvalidMarshalError{Message: err.Error()}
+	}
+	jv.Write([]byte(n))
+	return nil
+}
+
+func (e *Encoder) encodeList(jv json.Value, rv reflect.Value) error {
+	array := jv.Array()
+	defer array.Close()
+
+	for i := 0; i < rv.Len(); i++ {
+		if err := e.encode(array.Value(), rv.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Encoder) encodeMap(jv json.Value, rv reflect.Value) error {
+	if rv.IsNil() {
+		jv.Null()
+		return nil
+	}
+	if rv.Type().Key().Kind() != reflect.String {
+		return &document.InvalidMarshalError{Message: "map key type must be string, got " + rv.Type().Key().String()}
+	}
+
+	object := jv.Object()
+	defer object.Close()
+
+	iter := rv.MapRange()
+	for iter.Next() {
+		if err := e.encode(object.Key(iter.Key().String()), iter.Value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Encoder) encodeStruct(jv json.Value, rv reflect.Value) error {
+	object := jv.Object()
+	defer object.Close()
+
+	for _, f := range serde.GetStructFields(rv.Type()).All() {
+		fv, ok := fieldByIndex(rv, f.Index)
+		if !ok {
+			continue
+		}
+		if f.OmitEmpty && serde.IsZeroValue(fv) {
+			continue
+		}
+		if err := e.encode(object.Key(f.Name), fv); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fieldByIndex returns the nested field of the struct, or false if an
+// embedded struct pointer along the path is nil.
+func fieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+func encodeFloat(jv json.Value, v float64, bits int) {
+	switch {
+	case math.IsNaN(v):
+		jv.String("NaN")
+	case math.IsInf(v, 1):
+		jv.String("Infinity")
+	case math.IsInf(v, -1):
+		jv.String("-Infinity")
+	case bits == 32:
+		jv.Float(float32(v))
+	default:
+		jv.Double(v)
+	}
+}
+
+func valueInterface(rv reflect.Value) interface{} {
+	if !rv.IsValid() || !rv.CanInterface() {
+		return nil
+	}
+	return rv.Interface()
+}
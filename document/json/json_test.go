@@ -0,0 +1,355 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/document"
+)
+
+type Embedded struct {
+	EmbeddedName string
+}
+
+type testStruct struct {
+	Embedded
+
+	Name     string  `document:"name"`
+	Count    int     `document:",omitempty"`
+	Ratio    float64 `document:"ratio"`
+	Ignored  string  `document:"-"`
+	Tags     []string
+	Attrs    map[string]int
+	Optional *bool
+	Nested   *testStruct
+	Value    interface{}
+	Blob     []byte
+	unexport string
+}
+
+func TestEncoder(t *testing.T) {
+	cases := map[string]struct {
+		Value     interface{}
+		Expect    string
+		ExpectErr bool
+	}{
+		"nil": {
+			Value:  nil,
+			Expect: "null",
+		},
+		"scalars": {
+			Value:  []interface{}{true, "abc", int8(-1), uint64(math.MaxUint64), float32(1.5), 2.25},
+			Expect: `[true,"abc",-1,18446744073709551615,1.5,2.25]`,
+		},
+		"special floats": {
+			Value:  []float64{math.NaN(), math.Inf(1), math.Inf(-1)},
+			Expect: `["NaN","Infinity","-Infinity"]`,
+		},
+		"number": {
+			Value:  document.Number("123456789012345678901234567890.123456789"),
+			Expect: `123456789012345678901234567890.123456789`,
+		},
+		"invalid number": {
+			Value:     document.Number("abc"),
+			ExpectErr: true,
+		},
+		"big int": {
+			Value:  new(big.Int).Lsh(big.NewInt(1), 100),
+			Expect: `1267650600228229401496703205376`,
+		},
+		"big integer": {
+			Value:  smithy.NewBigInteger(new(big.Int).Lsh(big.NewInt(1), 100)),
+			Expect: `1267650600228229401496703205376`,
+		},
+		"big decimal": {
+			Value:  []smithy.BigDecimal{smithy.NewBigDecimal(big.NewInt(1250), 2)},
+			Expect: `[12.50]`,
+		},
+		"struct": {
+			Value: testStruct{
+				Embedded: Embedded{EmbeddedName: "embedded"},
+				Name:     "name",
+				Ignored:  "ignored",
+				Tags:     []string{"a"},
+				Attrs:    map[string]int{"k": 1},
+				Nested:   &testStruct{Name: "nested"},
+				Blob:     []byte("hi"),
+				unexport: "unexported",
+			},
+			Expect: `{"EmbeddedName":"embedded","name":"name","ratio":0,"Tags":["a"],"Attrs":{"k":1},"Optional":null,` +
+				`"Nested":{"EmbeddedName":"","name":"nested","ratio":0,"Tags":null,"Attrs":null,"Optional":null,"Nested":null,"Value":null,"Blob":null},` +
+				`"Value":null,"Blob":"aGk="}`,
+		},
+		"document": {
+			Value:  map[string]interface{}{"doc": NewDocumentFromBytes([]byte(`{"a":1}`))},
+			Expect: `{"doc":{"a":1}}`,
+		},
+		"non-string map key": {
+			Value:     map[int]string{1: "a"},
+			ExpectErr: true,
+		},
+		"no serde": {
+			Value:     document.NoSerde{},
+			ExpectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			b, err := Marshal(c.Value)
+			if c.ExpectErr {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Expect, string(b); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	var s testStruct
+	err := Unmarshal([]byte(`{
+		"EmbeddedName": "embedded",
+		"NAME": "name",
+		"Count": 3,
+		"ratio": "Infinity",
+		"Ignored": "ignored",
+		"Tags": ["a", "b"],
+		"Attrs": {"k": 1},
+		"Optional": true,
+		"Nested": {"name": "nested"},
+		"Value": {"big": 123456789012345678901234567890, "list": [1.5, null]},
+		"Blob": "aGk=",
+		"unknown": 1
+	}`), &s)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	optional := true
+	expect := testStruct{
+		Embedded: Embedded{EmbeddedName: "embedded"},
+		Name:     "name",
+		Count:    3,
+		Ratio:    math.Inf(1),
+		Tags:     []string{"a", "b"},
+		Attrs:    map[string]int{"k": 1},
+		Optional: &optional,
+		Nested:   &testStruct{Name: "nested"},
+		Value: map[string]interface{}{
+			"big":  document.Number("123456789012345678901234567890"),
+			"list": []interface{}{document.Number("1.5"), nil},
+		},
+		Blob: []byte("hi"),
+	}
+	if !reflect.DeepEqual(expect, s) {
+		t.Errorf("expect %#v, got %#v", expect, s)
+	}
+}
+
+func TestDecoder_Errors(t *testing.T) {
+	cases := map[string]struct {
+		Input  string
+		Target interface{}
+		Expect interface{}
+	}{
+		"overflow": {
+			Input:  `300`,
+			Target: new(int8),
+			Expect: &document.UnmarshalTypeError{},
+		},
+		"type mismatch": {
+			Input:  `"abc"`,
+			Target: new(int),
+			Expect: &document.UnmarshalTypeError{},
+		},
+		"non-pointer": {
+			Input:  `1`,
+			Target: 1,
+			Expect: &document.InvalidUnmarshalError{},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Unmarshal([]byte(c.Input), c.Target)
+			if err == nil {
+				t.Fatalf("expect error, got none")
+			}
+			target := reflect.New(reflect.TypeOf(c.Expect)).Interface()
+			if !errors.As(err, target) {
+				t.Errorf("expect %T error, got %T, %v", c.Expect, err, err)
+			}
+		})
+	}
+}
+
+func TestDocument_Precision(t *testing.T) {
+	const n = "12345678901234567890.12345678901234567890"
+
+	doc := NewDocumentFromBytes([]byte(n))
+
+	var bf big.Float
+	if err := doc.UnmarshalSmithyDocument(&bf); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	expect, _, _ := big.ParseFloat(n, 10, bf.Prec(), big.ToNearestEven)
+	if bf.Cmp(expect) != 0 {
+		t.Errorf("expect %v, got %v", expect, &bf)
+	}
+
+	v, err := NewDocument(document.Number(n)).(interface {
+		GetValue() (interface{}, error)
+	}).GetValue()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := document.Number(n), v; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestDocument_Member(t *testing.T) {
+	var v struct {
+		Doc document.Document
+	}
+	if err := Unmarshal([]byte(`{"Doc":{"a":[1,2]}}`), &v); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var inner struct {
+		A []int `document:"a"`
+	}
+	if err := v.Doc.UnmarshalSmithyDocument(&inner); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := []int{1, 2}, inner.A; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestDecoderBigNumbers(t *testing.T) {
+	var v struct {
+		Integer  smithy.BigInteger
+		Decimal  smithy.BigDecimal
+		Decimals []*smithy.BigDecimal
+	}
+	err := Unmarshal([]byte(`{
+		"Integer": 123456789012345678901234567890,
+		"Decimal": 0.1000000000000000000000000001,
+		"Decimals": [1.5e-10, null]
+	}`), &v)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := "123456789012345678901234567890", v.Integer.String(); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := "0.1000000000000000000000000001", v.Decimal.String(); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := 2, len(v.Decimals); e != a {
+		t.Fatalf("expect %v decimals, got %v", e, a)
+	}
+	if e, a := "1.5E-10", v.Decimals[0].String(); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if v.Decimals[1] != nil {
+		t.Errorf("expect nil decimal, got %v", v.Decimals[1])
+	}
+
+	err = Unmarshal([]byte(`{"Integer": 1.5}`), &v)
+	var typeErr *document.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		t.Errorf("expect %T error, got %v", typeErr, err)
+	}
+}
+
+type retainingStruct struct {
+	Name   string
+	Nested *retainingStruct
+
+	UnknownMembers document.UnknownMembers
+}
+
+func TestDecoder_RetainUnknownMembers(t *testing.T) {
+	const input = `{
+		"Name": "name",
+		"Added": {"count": 1, "list": ["a"]},
+		"Nested": {"Name": "nested", "Flag": true}
+	}`
+
+	decoders := map[string]func(*Decoder, interface{}) error{
+		"interface": func(d *Decoder, v interface{}) error {
+			var jv interface{}
+			dec := json.NewDecoder(strings.NewReader(input))
+			dec.UseNumber()
+			if err := dec.Decode(&jv); err != nil {
+				return err
+			}
+			return d.DecodeJSONInterface(jv, v)
+		},
+		"stream": func(d *Decoder, v interface{}) error {
+			return d.Decode(strings.NewReader(input), v)
+		},
+	}
+
+	for name, decode := range decoders {
+		t.Run(name, func(t *testing.T) {
+			var v retainingStruct
+			decoder := NewDecoder(func(o *DecoderOptions) { o.RetainUnknownMembers = true })
+			if err := decode(decoder, &v); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			expect := document.UnknownMembers{
+				"Added": map[string]interface{}{
+					"count": document.Number("1"),
+					"list":  []interface{}{"a"},
+				},
+			}
+			if e, a := expect, document.GetUnknownMembers(&v); !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v unknown members, got %v", e, a)
+			}
+			if e, a := (document.UnknownMembers{"Flag": true}), document.GetUnknownMembers(v.Nested); !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v nested unknown members, got %v", e, a)
+			}
+
+			// not retained by default
+			v = retainingStruct{}
+			if err := decode(NewDecoder(), &v); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if a := document.GetUnknownMembers(v); a != nil {
+				t.Errorf("expect no unknown members, got %v", a)
+			}
+		})
+	}
+}
+
+func TestEncoder_UnknownMembersNotEncoded(t *testing.T) {
+	b, err := NewEncoder().Encode(retainingStruct{
+		Name:           "name",
+		UnknownMembers: document.UnknownMembers{"Added": 1},
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := `{"Name":"name","Nested":null}`, string(b); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
@@ -0,0 +1,205 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/aws/smithy-go/document"
+	smithyjson "github.com/aws/smithy-go/encoding/json"
+)
+
+// Result is the value found by a document query. A Result whose path was not
+// found in the document does not exist, and its typed getters return an
+// error.
+type Result struct {
+	path   document.Path
+	raw    []byte
+	exists bool
+}
+
+// Get returns the value of the document at the path, e.g. "a.b[2].c", see
+// document.ParsePath for the path syntax. The document is scanned without
+// being decoded, only the value at the path is retained.
+//
+// Returns an error if the path is invalid, or the document is not valid
+// JSON. A path that does not exist in the document is not an error, and
+// returns a Result that does not exist.
+func Get(doc document.Marshaler, path string) (Result, error) {
+	b, err := doc.MarshalSmithyDocument()
+	if err != nil {
+		return Result{}, err
+	}
+	return GetBytes(b, path)
+}
+
+// GetBytes returns the value of the JSON encoded document at the path, see
+// Get.
+func GetBytes(b []byte, path string) (Result, error) {
+	p, err := document.ParsePath(path)
+	if err != nil {
+		return Result{}, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	raw, ok, err := seekPath(decoder, p)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{path: p, raw: raw, exists: ok}, nil
+}
+
+// seekPath scans the JSON tokens of the decoder for the value at the path,
+// returning false if the path does not exist.
+func seekPath(dec *json.Decoder, path document.Path) (json.RawMessage, bool, error) {
+	for len(path) != 0 {
+		seg := path[0]
+
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, false, err
+		}
+		delim, _ := tok.(json.Delim)
+
+		var found bool
+		switch {
+		case seg.IsIndex && delim == '[':
+			found, err = seekIndex(dec, seg.Index)
+		case !seg.IsIndex && delim == '{':
+			found, err = seekMember(dec, seg.Name)
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if !found {
+			return nil, false, nil
+		}
+
+		path = path[1:]
+	}
+
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		if err == io.EOF {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return raw, true, nil
+}
+
+func seekIndex(dec *json.Decoder, index int) (bool, error) {
+	for i := 0; dec.More(); i++ {
+		if i == index {
+			return true, nil
+		}
+		if err := smithyjson.DiscardUnknownField(dec); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+func seekMember(dec *json.Decoder, name string) (bool, error) {
+	for dec.More() {
+		key, err := decodeKey(dec)
+		if err != nil {
+			return false, err
+		}
+		if key == name {
+			return true, nil
+		}
+		if err := smithyjson.DiscardUnknownField(dec); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// Path returns the path of the query.
+func (r Result) Path() document.Path {
+	return r.path
+}
+
+// Exists returns whether the path of the query was found in the document.
+func (r Result) Exists() bool {
+	return r.exists
+}
+
+// IsNull returns whether the value exists and is null.
+func (r Result) IsNull() bool {
+	return r.exists && string(r.raw) == "null"
+}
+
+// Raw returns the JSON encoded bytes of the value, nil if the value does not
+// exist.
+func (r Result) Raw() []byte {
+	return r.raw
+}
+
+// Document returns the value as a document, nil if the value does not exist.
+func (r Result) Document() document.Document {
+	if !r.exists {
+		return nil
+	}
+	return NewDocumentFromBytes(r.raw)
+}
+
+// Unmarshal decodes the value into the value pointed to by v.
+func (r Result) Unmarshal(v interface{}) error {
+	if !r.exists {
+		return &PathNotFoundError{Path: r.path}
+	}
+	return unmarshalBytes(r.raw, v)
+}
+
+// String returns the value as a string.
+func (r Result) String() (v string, err error) {
+	err = r.Unmarshal(&v)
+	return v, err
+}
+
+// Bool returns the value as a bool.
+func (r Result) Bool() (v bool, err error) {
+	err = r.Unmarshal(&v)
+	return v, err
+}
+
+// Number returns the value as a document.Number, preserving its precision.
+func (r Result) Number() (v document.Number, err error) {
+	err = r.Unmarshal(&v)
+	return v, err
+}
+
+// Int64 returns the value as an int64. Returns an error if the value is not
+// an integer, or overflows int64.
+func (r Result) Int64() (v int64, err error) {
+	err = r.Unmarshal(&v)
+	return v, err
+}
+
+// Float64 returns the value as a float64. The strings "NaN", "Infinity", and
+// "-Infinity" are returned as their float64 values.
+func (r Result) Float64() (v float64, err error) {
+	err = r.Unmarshal(&v)
+	return v, err
+}
+
+// Blob returns the value as bytes, decoded from a base64 encoded string.
+func (r Result) Blob() (v []byte, err error) {
+	err = r.Unmarshal(&v)
+	return v, err
+}
+
+// PathNotFoundError is returned by the typed getters of a Result whose path
+// was not found in the document.
+type PathNotFoundError struct {
+	Path document.Path
+}
+
+// Error returns the string representation of the error.
+func (e *PathNotFoundError) Error() string {
+	return "document path not found, " + e.Path.String()
+}
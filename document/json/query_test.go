@@ -0,0 +1,142 @@
+package json
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/aws/smithy-go/document"
+)
+
+func TestGet(t *testing.T) {
+	doc := NewDocumentFromBytes([]byte(`{
+		"a": {
+			"skip": [{"b": 0}],
+			"b": [10, 20, {"c": "value", "n": 123456789012345678901234567890}],
+			"flag": true,
+			"inf": "-Infinity",
+			"blob": "aGk=",
+			"null": null
+		}
+	}`))
+
+	cases := map[string]struct {
+		Path   string
+		Get    func(Result) (interface{}, error)
+		Expect interface{}
+		Exists bool
+	}{
+		"string": {
+			Path:   "a.b[2].c",
+			Get:    func(r Result) (interface{}, error) { return r.String() },
+			Expect: "value",
+			Exists: true,
+		},
+		"int": {
+			Path:   "a.b[1]",
+			Get:    func(r Result) (interface{}, error) { return r.Int64() },
+			Expect: int64(20),
+			Exists: true,
+		},
+		"number": {
+			Path:   "a.b[2].n",
+			Get:    func(r Result) (interface{}, error) { return r.Number() },
+			Expect: document.Number("123456789012345678901234567890"),
+			Exists: true,
+		},
+		"bool": {
+			Path:   "a.flag",
+			Get:    func(r Result) (interface{}, error) { return r.Bool() },
+			Expect: true,
+			Exists: true,
+		},
+		"float": {
+			Path:   "a.inf",
+			Get:    func(r Result) (interface{}, error) { return r.Float64() },
+			Expect: math.Inf(-1),
+			Exists: true,
+		},
+		"blob": {
+			Path:   "a.blob",
+			Get:    func(r Result) (interface{}, error) { v, err := r.Blob(); return string(v), err },
+			Expect: "hi",
+			Exists: true,
+		},
+		"missing member": {
+			Path: "a.missing",
+		},
+		"index out of range": {
+			Path: "a.b[3]",
+		},
+		"index of object": {
+			Path: "a[0]",
+		},
+		"member of list": {
+			Path: "a.b.c",
+		},
+		"member of scalar": {
+			Path: "a.flag.c",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, err := Get(doc, c.Path)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Exists, r.Exists(); e != a {
+				t.Fatalf("expect exists %v, got %v", e, a)
+			}
+			if !c.Exists {
+				var nf *PathNotFoundError
+				if _, err := r.String(); !errors.As(err, &nf) {
+					t.Errorf("expect path not found error, got %v", err)
+				}
+				return
+			}
+
+			v, err := c.Get(r)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Expect, v; e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestGet_Document(t *testing.T) {
+	r, err := GetBytes([]byte(`[{"a": {"b": [1, 2]}}, {"null": null}]`), "[0].a")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var v struct {
+		B []int `document:"b"`
+	}
+	if err := r.Document().UnmarshalSmithyDocument(&v); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := 2, len(v.B); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	r, err = GetBytes([]byte(`[{"a": {"b": [1, 2]}}, {"null": null}]`), "[1].null")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if !r.IsNull() {
+		t.Errorf("expect null value")
+	}
+}
+
+func TestGet_Errors(t *testing.T) {
+	if _, err := GetBytes([]byte(`{}`), "a..b"); err == nil {
+		t.Errorf("expect invalid path error")
+	}
+	if _, err := GetBytes([]byte(`{"a": [1,`), "a[1]"); err == nil {
+		t.Errorf("expect invalid JSON error")
+	}
+}
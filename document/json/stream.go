@@ -0,0 +1,295 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+
+	"github.com/aws/smithy-go/document"
+	"github.com/aws/smithy-go/document/internal/serde"
+	smithyjson "github.com/aws/smithy-go/encoding/json"
+)
+
+// Decode decodes the JSON document read from r, and stores the result in the
+// value pointed by toValue.
+//
+// Unlike DecodeJSONInterface the document is decoded from the stream of JSON
+// tokens directly into toValue, without first materializing the document as
+// an interface{} tree. Object members without a corresponding struct field
+// are skipped, unless retained, see RetainUnknownMembers, and
+// document.Document members retain their raw JSON bytes to be decoded lazily
+// when accessed.
+func (d *Decoder) Decode(r io.Reader, toValue interface{}) error {
+	if document.IsNoSerde(toValue) {
+		return fmt.Errorf("unsupported type: %T", toValue)
+	}
+
+	v := reflect.ValueOf(toValue)
+	if v.Kind() != reflect.Ptr || v.IsNil() || !v.IsValid() {
+		return &document.InvalidUnmarshalError{Type: reflect.TypeOf(toValue)}
+	}
+
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	if err := d.decodeStream(decoder, v.Elem()); err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return newDecodeError(decoder, "", err)
+	}
+	return nil
+}
+
+// DecodeError is the error decoding a JSON document from a stream, with the
+// member path of the value being decoded, and the byte offset of the stream,
+// where decoding failed.
+type DecodeError struct {
+	// Path is the path of the value being decoded, e.g. "items[2].name",
+	// using the JSON names of object members.
+	Path string
+
+	// Offset is the byte offset of the stream where decoding failed.
+	Offset int64
+
+	Err error
+}
+
+// Error returns the string representation of the error.
+func (e *DecodeError) Error() string {
+	if len(e.Path) == 0 {
+		return fmt.Sprintf("decode failed at offset %d, %v", e.Offset, e.Err)
+	}
+	return fmt.Sprintf("decode %s failed at offset %d, %v", e.Path, e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// DecodePath returns the path of the value being decoded.
+func (e *DecodeError) DecodePath() string { return e.Path }
+
+// DecodeOffset returns the byte offset of the stream where decoding failed.
+func (e *DecodeError) DecodeOffset() int64 { return e.Offset }
+
+// newDecodeError returns err as a DecodeError, prepending the member, or
+// list index, to its path. Errors not yet a DecodeError are located at the
+// decoder's offset, or the offset of the syntax error.
+func newDecodeError(dec *json.Decoder, member string, err error) error {
+	de, ok := err.(*DecodeError)
+	if !ok {
+		de = &DecodeError{Offset: dec.InputOffset(), Err: err}
+		if syntaxErr, ok := err.(*json.SyntaxError); ok {
+			de.Offset = syntaxErr.Offset
+		}
+	}
+
+	switch {
+	case len(member) == 0:
+	case len(de.Path) == 0:
+		de.Path = member
+	case de.Path[0] == '[':
+		de.Path = member + de.Path
+	default:
+		de.Path = member + "." + de.Path
+	}
+	return de
+}
+
+// decodeStream decodes the next JSON value of the stream into rv.
+func (d *Decoder) decodeStream(dec *json.Decoder, rv reflect.Value) error {
+	if rv.Kind() == reflect.Interface && rv.NumMethod() != 0 && documentType.Implements(rv.Type()) {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		if string(raw) == "null" {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		rv.Set(reflect.ValueOf(NewDocumentFromBytes(raw)))
+		return nil
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	return d.decodeToken(dec, tok, rv)
+}
+
+// decodeToken decodes the JSON value starting with the token into rv.
+func (d *Decoder) decodeToken(dec *json.Decoder, tok json.Token, rv reflect.Value) error {
+	if tok == nil {
+		return d.decode(nil, rv)
+	}
+
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		rv = rv.Elem()
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return d.decode(tok, rv)
+	}
+
+	switch {
+	case delim == '{' && rv.Kind() == reflect.Struct:
+		return d.decodeStreamStruct(dec, rv)
+	case delim == '{' && rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		return d.decodeStreamMap(dec, rv)
+	case delim == '[' && rv.Kind() == reflect.Slice:
+		return d.decodeStreamSlice(dec, rv)
+	}
+
+	// Remaining targets, e.g. interfaces and arrays, are decoded from the
+	// materialized value.
+	jv, err := collectValue(dec, tok)
+	if err != nil {
+		return err
+	}
+	return d.decode(jv, rv)
+}
+
+func (d *Decoder) decodeStreamStruct(dec *json.Decoder, rv reflect.Value) error {
+	fields := serde.GetStructFields(rv.Type())
+
+	for dec.More() {
+		key, err := decodeKey(dec)
+		if err != nil {
+			return err
+		}
+
+		f, ok := fields.FieldByName(key)
+		if !ok {
+			if _, retain := fields.UnknownMembersIndex(); retain && d.options.RetainUnknownMembers {
+				tok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				jv, err := collectValue(dec, tok)
+				if err != nil {
+					return err
+				}
+				d.retainUnknownMember(rv, fields, key, toInterface(jv))
+				continue
+			}
+			if err := smithyjson.DiscardUnknownField(dec); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fv, err := allocFieldByIndex(rv, f.Index)
+		if err != nil {
+			return err
+		}
+		if err := d.decodeStream(dec, fv); err != nil {
+			return newDecodeError(dec, key, err)
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+func (d *Decoder) decodeStreamMap(dec *json.Decoder, rv reflect.Value) error {
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMap(rv.Type()))
+	}
+
+	for dec.More() {
+		key, err := decodeKey(dec)
+		if err != nil {
+			return err
+		}
+
+		ev := reflect.New(rv.Type().Elem()).Elem()
+		if err := d.decodeStream(dec, ev); err != nil {
+			return newDecodeError(dec, key, err)
+		}
+		rv.SetMapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()), ev)
+	}
+
+	return expectDelim(dec, '}')
+}
+
+func (d *Decoder) decodeStreamSlice(dec *json.Decoder, rv reflect.Value) error {
+	s := reflect.MakeSlice(rv.Type(), 0, 0)
+
+	for i := 0; dec.More(); i++ {
+		ev := reflect.New(rv.Type().Elem()).Elem()
+		if err := d.decodeStream(dec, ev); err != nil {
+			return newDecodeError(dec, "["+strconv.Itoa(i)+"]", err)
+		}
+		s = reflect.Append(s, ev)
+	}
+	rv.Set(s)
+
+	return expectDelim(dec, ']')
+}
+
+func decodeKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("expected string key, found %T", tok)
+	}
+	return key, nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("expected %v delimiter, found %v", delim, tok)
+	}
+	return nil
+}
+
+// collectValue materializes the JSON value starting with the token as an
+// interface{} tree.
+func collectValue(dec *json.Decoder, tok json.Token) (interface{}, error) {
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+
+	switch delim {
+	case '{':
+		object := map[string]interface{}{}
+		for dec.More() {
+			key, err := decodeKey(dec)
+			if err != nil {
+				return nil, err
+			}
+			var v interface{}
+			if err := dec.Decode(&v); err != nil {
+				return nil, err
+			}
+			object[key] = v
+		}
+		return object, expectDelim(dec, '}')
+	case '[':
+		array := []interface{}{}
+		for dec.More() {
+			var v interface{}
+			if err := dec.Decode(&v); err != nil {
+				return nil, err
+			}
+			array = append(array, v)
+		}
+		return array, expectDelim(dec, ']')
+	default:
+		return nil, fmt.Errorf("unexpected %v delimiter", delim)
+	}
+}
@@ -0,0 +1,127 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/document"
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	const input = `{
+		"name": "name",
+		"unknown": {"large": [1, 2, 3, {"a": "b"}]},
+		"Tags": ["a", "b"],
+		"Attrs": {"k": 1},
+		"Nested": {"name": "nested", "Optional": null},
+		"Value": [1.5, {"a": null}]
+	}`
+
+	var streamed testStruct
+	if err := NewDecoder().Decode(strings.NewReader(input), &streamed); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
+	var jv interface{}
+	if err := decoder.Decode(&jv); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	var materialized testStruct
+	if err := NewDecoder().DecodeJSONInterface(jv, &materialized); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if !reflect.DeepEqual(materialized, streamed) {
+		t.Errorf("expect %#v, got %#v", materialized, streamed)
+	}
+}
+
+func TestDecoder_DecodeLazyDocument(t *testing.T) {
+	var v struct {
+		Name string
+		Doc  document.Document
+		Null document.Document
+	}
+	err := NewDecoder().Decode(strings.NewReader(`{"Name":"abc","Doc":{"b": [1, 2.50]},"Null":null}`), &v)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "abc", v.Name; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if v.Null != nil {
+		t.Errorf("expect nil document, got %v", v.Null)
+	}
+
+	// Raw bytes of the document are retained as is.
+	b, err := v.Doc.MarshalSmithyDocument()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := []byte(`{"b": [1, 2.50]}`), b; !bytes.Equal(e, a) {
+		t.Errorf("expect %s, got %s", e, a)
+	}
+
+	var inner map[string][]document.Number
+	if err := v.Doc.UnmarshalSmithyDocument(&inner); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := document.Number("2.50"), inner["b"][1]; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestDecoder_DecodeError(t *testing.T) {
+	cases := map[string]struct {
+		Input        string
+		ExpectPath   string
+		ExpectOffset int64
+		ExpectType   interface{}
+	}{
+		"nested type mismatch": {
+			Input:        `{"Nested": {"Tags": ["a", 1]}}`,
+			ExpectPath:   "Nested.Tags[1]",
+			ExpectOffset: 27,
+			ExpectType:   &document.UnmarshalTypeError{},
+		},
+		"map value": {
+			Input:        `{"Attrs": {"k": "v"}}`,
+			ExpectPath:   "Attrs.k",
+			ExpectOffset: 19,
+			ExpectType:   &document.UnmarshalTypeError{},
+		},
+		"syntax error": {
+			Input:        `{"name": "a" "ratio": 1}`,
+			ExpectOffset: 14,
+			ExpectType:   &json.SyntaxError{},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var v testStruct
+			err := NewDecoder().Decode(strings.NewReader(c.Input), &v)
+
+			var decodeErr *DecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("expect DecodeError, got %T, %v", err, err)
+			}
+			if e, a := c.ExpectPath, decodeErr.DecodePath(); e != a {
+				t.Errorf("expect %q path, got %q", e, a)
+			}
+			if e, a := c.ExpectOffset, decodeErr.DecodeOffset(); e != a {
+				t.Errorf("expect %v offset, got %v", e, a)
+			}
+			target := reflect.New(reflect.TypeOf(c.ExpectType)).Interface()
+			if !errors.As(err, target) {
+				t.Errorf("expect %T error, got %v", c.ExpectType, err)
+			}
+		})
+	}
+}
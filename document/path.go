@@ -0,0 +1,106 @@
+package document
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PathSegment is a single segment of a document Path, either an object
+// member name, or a list index.
+type PathSegment struct {
+	// Name is the object member name, if IsIndex is false.
+	Name string
+
+	// Index is the list index, if IsIndex is true.
+	Index int
+
+	// IsIndex is whether the segment is a list index.
+	IsIndex bool
+}
+
+// String returns the path syntax of the segment.
+func (s PathSegment) String() string {
+	if s.IsIndex {
+		return "[" + strconv.Itoa(s.Index) + "]"
+	}
+	r := strings.NewReplacer(`\`, `\\`, `.`, `\.`, `[`, `\[`)
+	return r.Replace(s.Name)
+}
+
+// Path is the location of a value within a document, e.g. the path
+// "a.b[2].c" is the member "c" of the third element of the list member "b",
+// of the member "a" of the document.
+type Path []PathSegment
+
+// String returns the path syntax of the path.
+func (p Path) String() string {
+	var sb strings.Builder
+	for i, s := range p {
+		if i > 0 && !s.IsIndex {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(s.String())
+	}
+	return sb.String()
+}
+
+// ParsePath parses the path syntax into a Path. Member names are separated by
+// ".", and list indexes are enclosed in "[" and "]". The characters ".",
+// "[", and "\" within member names are escaped with "\".
+//
+// Example: a.b[2].c
+func ParsePath(v string) (Path, error) {
+	var path Path
+	var name strings.Builder
+	inName := false
+
+	flushName := func() {
+		if inName {
+			path = append(path, PathSegment{Name: name.String()})
+			name.Reset()
+			inName = false
+		}
+	}
+
+	for i := 0; i < len(v); i++ {
+		switch c := v[i]; c {
+		case '\\':
+			if i+1 >= len(v) {
+				return nil, fmt.Errorf("invalid document path %q, trailing escape", v)
+			}
+			i++
+			name.WriteByte(v[i])
+			inName = true
+
+		case '.':
+			if !inName && (i == 0 || v[i-1] != ']') {
+				return nil, fmt.Errorf("invalid document path %q, empty member name at %d", v, i)
+			}
+			flushName()
+			if i+1 >= len(v) || v[i+1] == '.' || v[i+1] == '[' {
+				return nil, fmt.Errorf("invalid document path %q, empty member name at %d", v, i+1)
+			}
+
+		case '[':
+			flushName()
+			end := strings.IndexByte(v[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid document path %q, unterminated index at %d", v, i)
+			}
+			index, err := strconv.Atoi(v[i+1 : i+end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid document path %q, invalid index %q", v, v[i+1:i+end])
+			}
+			path = append(path, PathSegment{Index: index, IsIndex: true})
+			i += end
+
+		default:
+			name.WriteByte(c)
+			inName = true
+		}
+	}
+	flushName()
+
+	return path, nil
+}
@@ -0,0 +1,74 @@
+package document
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	cases := map[string]struct {
+		Path      string
+		Expect    Path
+		ExpectErr bool
+	}{
+		"empty": {
+			Path: "",
+		},
+		"members": {
+			Path:   "a.b.c",
+			Expect: Path{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+		},
+		"indexes": {
+			Path: "a.b[2].c[0][1]",
+			Expect: Path{
+				{Name: "a"}, {Name: "b"}, {Index: 2, IsIndex: true},
+				{Name: "c"}, {Index: 0, IsIndex: true}, {Index: 1, IsIndex: true},
+			},
+		},
+		"root index": {
+			Path:   "[3].a",
+			Expect: Path{{Index: 3, IsIndex: true}, {Name: "a"}},
+		},
+		"escaped": {
+			Path:   `a\.b.c\[0]`,
+			Expect: Path{{Name: "a.b"}, {Name: "c[0]"}},
+		},
+		"empty member": {
+			Path:      "a..b",
+			ExpectErr: true,
+		},
+		"trailing dot": {
+			Path:      "a.",
+			ExpectErr: true,
+		},
+		"invalid index": {
+			Path:      "a[b]",
+			ExpectErr: true,
+		},
+		"unterminated index": {
+			Path:      "a[1",
+			ExpectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := ParsePath(c.Path)
+			if c.ExpectErr {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Expect, p; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+			if e, a := c.Path, p.String(); e != a {
+				t.Errorf("expect %v path string, got %v", e, a)
+			}
+		})
+	}
+}
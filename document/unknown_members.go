@@ -0,0 +1,46 @@
+package document
+
+import "reflect"
+
+// UnknownMembers retains the members of a document object which are not
+// modeled by the fields of the struct the object is decoded into, keyed by
+// member name, so applications built against an older model can read
+// members the service has since added.
+//
+// A struct retains unknown members in an exported field of type
+// UnknownMembers, when decoded by a decoder configured to retain them, e.g.
+// the document/json Decoder's RetainUnknownMembers option. The field is not
+// itself a member of the document, and is not encoded.
+//
+//	type Example struct {
+//		Name string
+//
+//		UnknownMembers document.UnknownMembers
+//	}
+type UnknownMembers map[string]interface{}
+
+var unknownMembersType = reflect.TypeOf(UnknownMembers(nil))
+
+// GetUnknownMembers returns the unknown members retained by the struct, or
+// pointer to struct, v. Returns nil if v does not retain unknown members, or
+// none were decoded.
+func GetUnknownMembers(v interface{}) UnknownMembers {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < rv.NumField(); i++ {
+		if rv.Type().Field(i).Type == unknownMembersType {
+			m, _ := rv.Field(i).Interface().(UnknownMembers)
+			return m
+		}
+	}
+	return nil
+}
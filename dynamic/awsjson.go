@@ -0,0 +1,179 @@
+package dynamic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/model"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// awsJSONProtocol is the awsJson1_0 and awsJson1_1 protocols. Requests are
+// POSTed to the endpoint's path, with the operation identified by the
+// X-Amz-Target header.
+type awsJSONProtocol struct {
+	codec       *jsonCodec
+	contentType string
+}
+
+// OperationError is a modeled error of an operation, deserialized from an
+// error response.
+type OperationError struct {
+	// Shape is the absolute shape ID of the error's structure.
+	Shape model.ShapeID
+
+	// Members are the members of the error's structure.
+	Members map[string]interface{}
+
+	fault    smithy.ErrorFault
+	metadata smithy.ErrorMetadata
+}
+
+// ErrorCode returns the name of the error's shape.
+func (e *OperationError) ErrorCode() string { return e.Shape.Name() }
+
+// ErrorMessage returns the error's message member, if present.
+func (e *OperationError) ErrorMessage() string {
+	for _, name := range []string{"message", "Message"} {
+		if v, ok := e.Members[name].(string); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// ErrorFault returns the fault of the error's error trait.
+func (e *OperationError) ErrorFault() smithy.ErrorFault { return e.fault }
+
+// ErrorMetadata returns the metadata of the error response.
+func (e *OperationError) ErrorMetadata() smithy.ErrorMetadata { return e.metadata }
+
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("api error %s: %s", e.ErrorCode(), e.ErrorMessage())
+}
+
+// errorRegistry returns the registry of the operation's modeled errors, and
+// the service's common errors.
+func (c *Client) errorRegistry(op *model.Shape) *smithyhttp.ErrorRegistry {
+	registry := smithyhttp.NewErrorRegistry()
+	for _, refs := range [][]model.Reference{c.service.Errors, op.Errors} {
+		for _, ref := range refs {
+			shape, ok := c.model.Shapes[ref.Target]
+			if !ok {
+				continue
+			}
+			registry.Register(shape.ID.Name(), c.errorDeserializer(shape))
+		}
+	}
+	return registry
+}
+
+func (c *Client) errorDeserializer(shape *model.Shape) smithyhttp.ErrorResponseDeserializer {
+	fault := smithy.FaultUnknown
+	switch v, _ := shape.Traits.String(model.TraitError); v {
+	case "client":
+		fault = smithy.FaultClient
+	case "server":
+		fault = smithy.FaultServer
+	}
+
+	return func(resp *smithyhttp.Response, body io.Reader) error {
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			return &smithy.DeserializationError{Err: fmt.Errorf("failed to read error response body, %w", err)}
+		}
+		members, err := c.protocol.codec.deserialize(shape, b, shape.ID.Name())
+		if err != nil {
+			return newDeserializationError(err, shape, b)
+		}
+		return &OperationError{
+			Shape:   shape.ID,
+			Members: members,
+			fault:   fault,
+			metadata: smithy.ErrorMetadata{
+				StatusCode: resp.StatusCode,
+			},
+		}
+	}
+}
+
+type awsJSONSerializer struct {
+	client    *Client
+	operation *model.Shape
+}
+
+func (*awsJSONSerializer) ID() string { return "OperationSerializer" }
+
+func (m *awsJSONSerializer) HandleSerialize(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+	out middleware.SerializeOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*smithyhttp.Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type %T", in.Request)
+	}
+	input, _ := in.Parameters.(map[string]interface{})
+
+	if req, err = m.client.serializeRequest(req, m.operation, input); err != nil {
+		return out, metadata, &smithy.SerializationError{Err: err}
+	}
+	in.Request = req
+
+	return next.HandleSerialize(ctx, in)
+}
+
+type awsJSONDeserializer struct {
+	client    *Client
+	operation *model.Shape
+}
+
+func (*awsJSONDeserializer) ID() string { return "OperationDeserializer" }
+
+func (m *awsJSONDeserializer) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	resp, ok := out.RawResponse.(*smithyhttp.Response)
+	if !ok {
+		return out, metadata, &smithy.DeserializationError{Err: fmt.Errorf("unknown transport type %T", out.RawResponse)}
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return out, metadata, &smithy.DeserializationError{Err: fmt.Errorf("failed to read response body, %w", err)}
+	}
+
+	outputShape := &model.Shape{Type: model.TypeStructure}
+	if m.operation.Output != nil {
+		if outputShape, ok = m.client.model.Shape(m.operation.Output.Target); !ok {
+			return out, metadata, fmt.Errorf("output shape %s not found in model", m.operation.Output.Target)
+		}
+	}
+
+	output, err := m.client.protocol.codec.deserialize(outputShape, body, "output")
+	if err != nil {
+		return out, metadata, newDeserializationError(err, outputShape, body)
+	}
+	out.Result = output
+	return out, metadata, nil
+}
+
+// newDeserializationError returns the DeserializationError for the error
+// deserializing the shape, with the path of the value from a ValueError.
+func newDeserializationError(err error, shape *model.Shape, body []byte) error {
+	e := smithy.NewDeserializationError(err, shape.ID.Name(), body)
+	var valueErr *ValueError
+	if errors.As(err, &valueErr) {
+		e.Path = valueErr.Path
+	}
+	return e
+}
@@ -0,0 +1,172 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/smithy-go/codec"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/model"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// Options provides the configuration of a Client.
+type Options struct {
+	// Endpoint is the URL the operations' requests are sent to. Required.
+	Endpoint string
+
+	// HTTPClient sends the operations' requests. Defaults to a new
+	// http.Client.
+	HTTPClient smithyhttp.ClientDo
+
+	// APIOptions are applied to the stack of each operation call, e.g. to
+	// add request signing.
+	APIOptions []func(*middleware.Stack) error
+}
+
+// Client invokes the operations of a service of a model loaded at runtime,
+// serializing inputs from, and deserializing outputs to, generic Go values.
+//
+// The service's protocol is selected from its protocol traits. The supported
+// protocols are awsJson1_0 and awsJson1_1.
+type Client struct {
+	model    *model.Model
+	service  *model.Shape
+	protocol *awsJSONProtocol
+	endpoint *url.URL
+	options  Options
+
+	operations []model.ShapeID
+}
+
+// New returns a Client for the service, identified by its absolute shape ID,
+// of the model. Returns an error if the service is not in the model, or has
+// no supported protocol.
+func New(m *model.Model, service model.ShapeID, optFns ...func(*Options)) (*Client, error) {
+	var o Options
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	if o.HTTPClient == nil {
+		o.HTTPClient = &http.Client{}
+	}
+
+	endpoint, err := url.Parse(o.Endpoint)
+	if err != nil || len(endpoint.Scheme) == 0 || len(endpoint.Host) == 0 {
+		return nil, fmt.Errorf("invalid endpoint %q", o.Endpoint)
+	}
+
+	ops, err := m.ServiceOperations(service)
+	if err != nil {
+		return nil, err
+	}
+	s := m.Shapes[service]
+
+	protocol, err := resolveProtocol(m, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		model:      m,
+		service:    s,
+		protocol:   protocol,
+		endpoint:   endpoint,
+		options:    o,
+		operations: ops,
+	}, nil
+}
+
+// resolveProtocol returns the first supported protocol of the service's
+// protocol traits.
+func resolveProtocol(m *model.Model, service *model.Shape) (*awsJSONProtocol, error) {
+	jc := &jsonCodec{model: m, defaultTimestampFormat: timestampEpochSeconds}
+
+	switch {
+	case service.Traits.Has(model.ShapeID(codec.ProtocolAWSJSON10)):
+		return &awsJSONProtocol{codec: jc, contentType: "application/x-amz-json-1.0"}, nil
+	case service.Traits.Has(model.ShapeID(codec.ProtocolAWSJSON11)):
+		return &awsJSONProtocol{codec: jc, contentType: "application/x-amz-json-1.1"}, nil
+	}
+
+	var protocols []string
+	for id := range service.Traits {
+		if strings.HasSuffix(id.Namespace(), ".protocols") {
+			protocols = append(protocols, string(id))
+		}
+	}
+	return nil, &codec.UnsupportedProtocolError{Protocol: strings.Join(protocols, ", ")}
+}
+
+// Operations returns the absolute shape IDs of the service's operations,
+// sorted.
+func (c *Client) Operations() []model.ShapeID {
+	return append([]model.ShapeID(nil), c.operations...)
+}
+
+// Invoke invokes the operation, identified by its absolute shape ID or its
+// name, with the input, returning the operation's output.
+func (c *Client) Invoke(ctx context.Context, operation string, input map[string]interface{}) (map[string]interface{}, error) {
+	invoker, err := c.Invoker(operation)
+	if err != nil {
+		return nil, err
+	}
+	return invoker.Invoke(ctx, input)
+}
+
+// Invoker returns the invoker of the operation, identified by its absolute
+// shape ID or its name. Returns an error if the operation is not bound to
+// the client's service.
+func (c *Client) Invoker(operation string) (*middleware.StackInvoker[map[string]interface{}, map[string]interface{}], error) {
+	op, err := c.resolveOperation(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	newStack := func() *middleware.Stack {
+		return middleware.NewStack(op.ID.Name(), smithyhttp.NewStackRequest)
+	}
+	addOperationMiddleware := func(stack *middleware.Stack) error {
+		return c.addOperationMiddleware(stack, op)
+	}
+
+	return middleware.NewStackInvoker[map[string]interface{}, map[string]interface{}](
+		newStack,
+		&awsJSONSerializer{client: c, operation: op},
+		&awsJSONDeserializer{client: c, operation: op},
+		smithyhttp.NewClientHandler(c.options.HTTPClient),
+		func(o *middleware.StackInvokerOptions) {
+			o.APIOptions = append([]func(*middleware.Stack) error{addOperationMiddleware}, c.options.APIOptions...)
+		},
+	), nil
+}
+
+func (c *Client) resolveOperation(operation string) (*model.Shape, error) {
+	for _, id := range c.operations {
+		if string(id) == operation || (!strings.Contains(operation, "#") && id.Name() == operation) {
+			op, ok := c.model.Shapes[id]
+			if !ok || op.Type != model.TypeOperation {
+				return nil, fmt.Errorf("operation %s not found in model", id)
+			}
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("operation %s is not bound to service %s", operation, c.service.ID)
+}
+
+// addOperationMiddleware adds the middleware of the operation's stack.
+func (c *Client) addOperationMiddleware(stack *middleware.Stack, op *model.Shape) error {
+	if err := middleware.AddOperationMetadataMiddleware(stack, c.service.ID.Name(), op.ID.Name()); err != nil {
+		return err
+	}
+	if err := smithyhttp.AddComputeContentLengthMiddleware(stack); err != nil {
+		return err
+	}
+	return smithyhttp.AddErrorResponseRouterMiddleware(stack, c.errorRegistry(op),
+		smithyhttp.ErrorCodeFromHeader("X-Amzn-ErrorType"),
+		smithyhttp.ErrorCodeFromJSONField("__type", "code"),
+	)
+}
@@ -0,0 +1,375 @@
+package dynamic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/codec"
+	"github.com/aws/smithy-go/model"
+)
+
+const testModel = `{
+	"smithy": "2.0",
+	"shapes": {
+		"example.weather#Weather": {
+			"type": "service",
+			"version": "2006-03-01",
+			"operations": [{"target": "example.weather#GetCurrentTime"}],
+			"resources": [{"target": "example.weather#City"}],
+			"traits": {"aws.protocols#awsJson1_0": {}}
+		},
+		"example.weather#City": {
+			"type": "resource",
+			"read": {"target": "example.weather#GetCity"},
+			"list": {"target": "example.weather#ListCities"}
+		},
+		"example.weather#GetCurrentTime": {
+			"type": "operation",
+			"output": {"target": "example.weather#GetCurrentTimeOutput"}
+		},
+		"example.weather#GetCurrentTimeOutput": {
+			"type": "structure",
+			"members": {
+				"time": {"target": "smithy.api#Timestamp"}
+			}
+		},
+		"example.weather#ListCities": {
+			"type": "operation",
+			"input": {"target": "smithy.api#Unit"},
+			"output": {"target": "smithy.api#Unit"}
+		},
+		"example.weather#GetCity": {
+			"type": "operation",
+			"input": {"target": "example.weather#GetCityInput"},
+			"output": {"target": "example.weather#GetCityOutput"},
+			"errors": [{"target": "example.weather#NoSuchResource"}]
+		},
+		"example.weather#GetCityInput": {
+			"type": "structure",
+			"members": {
+				"cityId": {"target": "smithy.api#String", "traits": {"smithy.api#required": {}}},
+				"verbose": {"target": "smithy.api#Boolean"}
+			}
+		},
+		"example.weather#GetCityOutput": {
+			"type": "structure",
+			"members": {
+				"name": {"target": "smithy.api#String"},
+				"population": {"target": "smithy.api#Long"},
+				"area": {"target": "smithy.api#Double"},
+				"founded": {
+					"target": "smithy.api#Timestamp",
+					"traits": {"smithy.api#timestampFormat": "date-time"}
+				},
+				"flag": {"target": "smithy.api#Blob"},
+				"neighborhoods": {"target": "example.weather#Neighborhoods"},
+				"coordinates": {"target": "example.weather#Coordinates"},
+				"census": {"target": "smithy.api#BigInteger"}
+			}
+		},
+		"example.weather#Neighborhoods": {
+			"type": "list",
+			"member": {"target": "smithy.api#String"}
+		},
+		"example.weather#Coordinates": {
+			"type": "map",
+			"key": {"target": "smithy.api#String"},
+			"value": {"target": "smithy.api#Float"}
+		},
+		"example.weather#NoSuchResource": {
+			"type": "structure",
+			"members": {
+				"resourceType": {"target": "smithy.api#String"},
+				"message": {"target": "smithy.api#String"}
+			},
+			"traits": {"smithy.api#error": "client"}
+		}
+	}
+}`
+
+func loadTestModel(t *testing.T) *model.Model {
+	t.Helper()
+	m, err := model.Load(strings.NewReader(testModel))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	return m
+}
+
+func TestClientOperations(t *testing.T) {
+	client, err := New(loadTestModel(t), "example.weather#Weather", func(o *Options) {
+		o.Endpoint = "https://weather.example.com"
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := []model.ShapeID{
+		"example.weather#GetCity",
+		"example.weather#GetCurrentTime",
+		"example.weather#ListCities",
+	}
+	if e, a := expect, client.Operations(); !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v operations, got %v", e, a)
+	}
+}
+
+func TestClientInvoke(t *testing.T) {
+	cases := map[string]struct {
+		Operation    string
+		Input        map[string]interface{}
+		Status       int
+		Header       map[string]string
+		Body         string
+		ExpectTarget string
+		ExpectBody   string
+		ExpectOutput map[string]interface{}
+		ExpectErr    func(*testing.T, error)
+	}{
+		"success": {
+			Operation:    "example.weather#GetCity",
+			Input:        map[string]interface{}{"cityId": "seattle", "verbose": true},
+			Status:       200,
+			Body:         `{"name":"Seattle","population":737015,"area":217.4,"founded":"1851-11-13T00:00:00Z","flag":"AQI=","neighborhoods":["Ballard","Fremont"],"coordinates":{"lat":47.6},"census":123456789012345678901234567890}`,
+			ExpectTarget: "Weather.GetCity",
+			ExpectBody:   `{"cityId":"seattle","verbose":true}`,
+			ExpectOutput: map[string]interface{}{
+				"name":          "Seattle",
+				"population":    int64(737015),
+				"area":          217.4,
+				"founded":       time.Date(1851, 11, 13, 0, 0, 0, 0, time.UTC),
+				"flag":          []byte{1, 2},
+				"neighborhoods": []interface{}{"Ballard", "Fremont"},
+				"coordinates":   map[string]interface{}{"lat": 47.6},
+				"census":        bigInt("123456789012345678901234567890"),
+			},
+		},
+		"operation by name": {
+			Operation:    "GetCurrentTime",
+			Status:       200,
+			Body:         `{"time":1700000000.5}`,
+			ExpectTarget: "Weather.GetCurrentTime",
+			ExpectBody:   `{}`,
+			ExpectOutput: map[string]interface{}{
+				"time": time.Unix(1700000000, 500000000).UTC(),
+			},
+		},
+		"unit input and output": {
+			Operation:    "ListCities",
+			Status:       200,
+			ExpectTarget: "Weather.ListCities",
+			ExpectBody:   `{}`,
+			ExpectOutput: map[string]interface{}{},
+		},
+		"modeled error": {
+			Operation: "GetCity",
+			Input:     map[string]interface{}{"cityId": "atlantis"},
+			Status:    400,
+			Body:      `{"__type":"example.weather#NoSuchResource","resourceType":"City","message":"no such city"}`,
+			ExpectErr: func(t *testing.T, err error) {
+				var opErr *OperationError
+				if !errors.As(err, &opErr) {
+					t.Fatalf("expect *OperationError, got %T, %v", err, err)
+				}
+				if e, a := "NoSuchResource", opErr.ErrorCode(); e != a {
+					t.Errorf("expect %v code, got %v", e, a)
+				}
+				if e, a := "no such city", opErr.ErrorMessage(); e != a {
+					t.Errorf("expect %v message, got %v", e, a)
+				}
+				if e, a := smithy.FaultClient, opErr.ErrorFault(); e != a {
+					t.Errorf("expect %v fault, got %v", e, a)
+				}
+				if e, a := "City", opErr.Members["resourceType"]; e != a {
+					t.Errorf("expect %v resourceType, got %v", e, a)
+				}
+			},
+		},
+		"unmodeled error": {
+			Operation: "GetCity",
+			Input:     map[string]interface{}{"cityId": "seattle"},
+			Status:    500,
+			Header:    map[string]string{"X-Amzn-ErrorType": "InternalFailure"},
+			Body:      `{}`,
+			ExpectErr: func(t *testing.T, err error) {
+				var apiErr smithy.APIError
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("expect smithy.APIError, got %T, %v", err, err)
+				}
+				if e, a := "InternalFailure", apiErr.ErrorCode(); e != a {
+					t.Errorf("expect %v code, got %v", e, a)
+				}
+			},
+		},
+		"missing required member": {
+			Operation: "GetCity",
+			Input:     map[string]interface{}{"verbose": true},
+			ExpectErr: func(t *testing.T, err error) {
+				var valueErr *ValueError
+				if !errors.As(err, &valueErr) {
+					t.Fatalf("expect *ValueError, got %T, %v", err, err)
+				}
+				if e, a := "input", valueErr.Path; e != a {
+					t.Errorf("expect %v path, got %v", e, a)
+				}
+			},
+		},
+		"invalid member type": {
+			Operation: "GetCity",
+			Input:     map[string]interface{}{"cityId": 42},
+			ExpectErr: func(t *testing.T, err error) {
+				var valueErr *ValueError
+				if !errors.As(err, &valueErr) {
+					t.Fatalf("expect *ValueError, got %T, %v", err, err)
+				}
+				if e, a := "input.cityId", valueErr.Path; e != a {
+					t.Errorf("expect %v path, got %v", e, a)
+				}
+			},
+		},
+		"unknown operation": {
+			Operation: "DeleteCity",
+			ExpectErr: func(t *testing.T, err error) {
+				if e, a := "not bound to service", err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %v, got %v", e, a)
+				}
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if e, a := "POST", r.Method; e != a {
+					t.Errorf("expect %v method, got %v", e, a)
+				}
+				if e, a := "application/x-amz-json-1.0", r.Header.Get("Content-Type"); e != a {
+					t.Errorf("expect %v content type, got %v", e, a)
+				}
+				if e, a := c.ExpectTarget, r.Header.Get("X-Amz-Target"); len(e) != 0 && e != a {
+					t.Errorf("expect %v target, got %v", e, a)
+				}
+				body, _ := ioutil.ReadAll(r.Body)
+				if e, a := c.ExpectBody, string(body); len(e) != 0 && e != a {
+					t.Errorf("expect %v body, got %v", e, a)
+				}
+
+				for k, v := range c.Header {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(c.Status)
+				w.Write([]byte(c.Body))
+			}))
+			defer server.Close()
+
+			client, err := New(loadTestModel(t), "example.weather#Weather", func(o *Options) {
+				o.Endpoint = server.URL
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			out, err := client.Invoke(context.Background(), c.Operation, c.Input)
+			if c.ExpectErr != nil {
+				if err == nil {
+					t.Fatalf("expect error")
+				}
+				c.ExpectErr(t, err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := len(c.ExpectOutput), len(out); e != a {
+				t.Errorf("expect %v output members, got %v", e, a)
+			}
+			for k, e := range c.ExpectOutput {
+				a := out[k]
+				switch ev := e.(type) {
+				case time.Time:
+					if !ev.Equal(a.(time.Time)) {
+						t.Errorf("expect %v %v, got %v", k, e, a)
+					}
+				case *big.Int:
+					if ev.Cmp(a.(*big.Int)) != 0 {
+						t.Errorf("expect %v %v, got %v", k, e, a)
+					}
+				default:
+					if !reflect.DeepEqual(e, a) {
+						t.Errorf("expect %v %#v, got %#v", k, e, a)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNewClientErrors(t *testing.T) {
+	cases := map[string]struct {
+		Service   model.ShapeID
+		Endpoint  string
+		Model     func(*model.Model)
+		ExpectErr func(*testing.T, error)
+	}{
+		"unknown service": {
+			Service:  "example.weather#Unknown",
+			Endpoint: "https://weather.example.com",
+		},
+		"invalid endpoint": {
+			Service:  "example.weather#Weather",
+			Endpoint: "weather.example.com",
+		},
+		"unsupported protocol": {
+			Service:  "example.weather#Weather",
+			Endpoint: "https://weather.example.com",
+			Model: func(m *model.Model) {
+				m.Shapes["example.weather#Weather"].Traits = model.Traits{
+					"aws.protocols#restXml": json.RawMessage(`{}`),
+				}
+			},
+			ExpectErr: func(t *testing.T, err error) {
+				var protocolErr *codec.UnsupportedProtocolError
+				if !errors.As(err, &protocolErr) {
+					t.Fatalf("expect *codec.UnsupportedProtocolError, got %T, %v", err, err)
+				}
+				if e, a := "aws.protocols#restXml", protocolErr.Protocol; e != a {
+					t.Errorf("expect %v protocol, got %v", e, a)
+				}
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := loadTestModel(t)
+			if c.Model != nil {
+				c.Model(m)
+			}
+			_, err := New(m, c.Service, func(o *Options) {
+				o.Endpoint = c.Endpoint
+			})
+			if err == nil {
+				t.Fatalf("expect error")
+			}
+			if c.ExpectErr != nil {
+				c.ExpectErr(t, err)
+			}
+		})
+	}
+}
+
+func bigInt(s string) *big.Int {
+	i, _ := new(big.Int).SetString(s, 10)
+	return i
+}
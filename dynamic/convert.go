@@ -0,0 +1,543 @@
+package dynamic
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	smithyjson "github.com/aws/smithy-go/encoding/json"
+	"github.com/aws/smithy-go/model"
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+// Timestamp formats of the timestampFormat trait.
+const (
+	timestampEpochSeconds = "epoch-seconds"
+	timestampDateTime     = "date-time"
+	timestampHTTPDate     = "http-date"
+)
+
+// ValueError is returned when a value does not conform to the shape it is
+// serialized, or deserialized, as.
+type ValueError struct {
+	// Path is the location of the value, e.g. "input.items[2].name".
+	Path string
+	Err  error
+}
+
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ValueError) Unwrap() error { return e.Err }
+
+func valueErrorf(path, format string, v ...interface{}) error {
+	return &ValueError{Path: path, Err: fmt.Errorf(format, v...)}
+}
+
+// jsonCodec converts values between their Go representation, see the
+// package documentation, and their JSON representation per the shapes of the
+// model.
+type jsonCodec struct {
+	model *model.Model
+
+	// defaultTimestampFormat is the protocol's format of timestamps without
+	// a timestampFormat trait.
+	defaultTimestampFormat string
+}
+
+// timestampFormat returns the format of a timestamp, from the member's
+// trait, the target's trait, or the protocol's default.
+func (c *jsonCodec) timestampFormat(member *model.Member, target *model.Shape) string {
+	if member != nil {
+		if v, ok := member.Traits.String(model.TraitTimestampFormat); ok {
+			return v
+		}
+	}
+	if v, ok := target.Traits.String(model.TraitTimestampFormat); ok {
+		return v
+	}
+	return c.defaultTimestampFormat
+}
+
+func (c *jsonCodec) target(member *model.Member, path string) (*model.Shape, error) {
+	if member == nil {
+		return nil, valueErrorf(path, "shape has no member definition")
+	}
+	s, ok := c.model.Shape(member.Target)
+	if !ok {
+		return nil, valueErrorf(path, "target shape %s not found in model", member.Target)
+	}
+	return s, nil
+}
+
+// serialize encodes the structure shape's value v.
+func (c *jsonCodec) serialize(shape *model.Shape, v map[string]interface{}, path string) ([]byte, error) {
+	enc := smithyjson.NewEncoder()
+	if err := c.serializeStructure(enc.Value, shape, v, path); err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
+
+func (c *jsonCodec) serializeValue(jv smithyjson.Value, member *model.Member, v interface{}, path string) error {
+	shape, err := c.target(member, path)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		jv.Null()
+		return nil
+	}
+
+	switch shape.Type {
+	case model.TypeStructure, model.TypeUnion:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return valueErrorf(path, "expected map[string]interface{} for %s, got %T", shape.Type, v)
+		}
+		return c.serializeStructure(jv, shape, m, path)
+
+	case model.TypeList, model.TypeSet:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return valueErrorf(path, "expected slice for %s, got %T", shape.Type, v)
+		}
+		arr := jv.Array()
+		defer arr.Close()
+		for i := 0; i < rv.Len(); i++ {
+			if err := c.serializeValue(arr.Value(), shape.Member, rv.Index(i).Interface(),
+				path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case model.TypeMap:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+			return valueErrorf(path, "expected map with string keys, got %T", v)
+		}
+		keys := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+
+		obj := jv.Object()
+		defer obj.Close()
+		for _, k := range keys {
+			elem := rv.MapIndex(reflect.ValueOf(k).Convert(rv.Type().Key())).Interface()
+			if err := c.serializeValue(obj.Key(k), shape.Value, elem, path+"["+strconv.Quote(k)+"]"); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case model.TypeString, model.TypeEnum:
+		s, ok := v.(string)
+		if !ok {
+			return valueErrorf(path, "expected string for %s, got %T", shape.Type, v)
+		}
+		jv.String(s)
+		return nil
+
+	case model.TypeBlob:
+		switch b := v.(type) {
+		case []byte:
+			jv.Base64EncodeBytes(b)
+		case string:
+			jv.Base64EncodeBytes([]byte(b))
+		default:
+			return valueErrorf(path, "expected []byte for blob, got %T", v)
+		}
+		return nil
+
+	case model.TypeBoolean:
+		b, ok := v.(bool)
+		if !ok {
+			return valueErrorf(path, "expected bool for boolean, got %T", v)
+		}
+		jv.Boolean(b)
+		return nil
+
+	case model.TypeByte, model.TypeShort, model.TypeInteger, model.TypeLong, model.TypeIntEnum:
+		i, err := toInt64(v)
+		if err != nil {
+			return &ValueError{Path: path, Err: err}
+		}
+		jv.Long(i)
+		return nil
+
+	case model.TypeFloat, model.TypeDouble:
+		f, err := toFloat64(v)
+		if err != nil {
+			return &ValueError{Path: path, Err: err}
+		}
+		switch {
+		case math.IsNaN(f):
+			jv.String("NaN")
+		case math.IsInf(f, 1):
+			jv.String("Infinity")
+		case math.IsInf(f, -1):
+			jv.String("-Infinity")
+		case shape.Type == model.TypeFloat:
+			jv.Float(float32(f))
+		default:
+			jv.Double(f)
+		}
+		return nil
+
+	case model.TypeBigInteger, model.TypeBigDecimal:
+		switch n := v.(type) {
+		case *big.Int:
+			jv.BigInteger(n)
+		case *big.Float:
+			jv.BigDecimal(n)
+		case json.Number:
+			jv.Write([]byte(n))
+		default:
+			f, err := toFloat64(v)
+			if err != nil {
+				return &ValueError{Path: path, Err: err}
+			}
+			jv.Double(f)
+		}
+		return nil
+
+	case model.TypeTimestamp:
+		t, ok := v.(time.Time)
+		if !ok {
+			return valueErrorf(path, "expected time.Time for timestamp, got %T", v)
+		}
+		switch format := c.timestampFormat(member, shape); format {
+		case timestampDateTime:
+			jv.String(smithytime.FormatDateTime(t))
+		case timestampHTTPDate:
+			jv.String(smithytime.FormatHTTPDate(t))
+		case timestampEpochSeconds:
+			jv.Double(smithytime.FormatEpochSeconds(t))
+		default:
+			return valueErrorf(path, "unsupported timestamp format %q", format)
+		}
+		return nil
+
+	case model.TypeDocument:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return &ValueError{Path: path, Err: err}
+		}
+		jv.Write(b)
+		return nil
+
+	default:
+		return valueErrorf(path, "unsupported shape type %q", shape.Type)
+	}
+}
+
+func (c *jsonCodec) serializeStructure(jv smithyjson.Value, shape *model.Shape, v map[string]interface{}, path string) error {
+	for name := range v {
+		if _, ok := shape.Members[name]; !ok {
+			return valueErrorf(path, "unknown member %q of %s", name, shape.ID)
+		}
+	}
+
+	names := make([]string, 0, len(shape.Members))
+	for name := range shape.Members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var set int
+	obj := jv.Object()
+	defer obj.Close()
+	for _, name := range names {
+		member := shape.Members[name]
+		mv, ok := v[name]
+		if !ok || mv == nil {
+			if member.Traits.Has(model.TraitRequired) && shape.Type == model.TypeStructure {
+				return valueErrorf(path, "missing required member %q", name)
+			}
+			continue
+		}
+		set++
+		if err := c.serializeValue(obj.Key(name), member, mv, path+"."+name); err != nil {
+			return err
+		}
+	}
+
+	if shape.Type == model.TypeUnion && set != 1 {
+		return valueErrorf(path, "expected exactly one member of union %s to be set, got %d", shape.ID, set)
+	}
+	return nil
+}
+
+// deserialize decodes the JSON body as the structure shape.
+func (c *jsonCodec) deserialize(shape *model.Shape, body []byte, path string) (map[string]interface{}, error) {
+	if len(body) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	var raw interface{}
+	if err := decodeJSON(body, &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return map[string]interface{}{}, nil
+	}
+	v, err := c.deserializeStructure(shape, raw, path)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (c *jsonCodec) deserializeValue(member *model.Member, raw interface{}, path string) (interface{}, error) {
+	shape, err := c.target(member, path)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	switch shape.Type {
+	case model.TypeStructure, model.TypeUnion:
+		return c.deserializeStructure(shape, raw, path)
+
+	case model.TypeList, model.TypeSet:
+		arr, ok := raw.([]interface{})
+		if !ok {
+			return nil, valueErrorf(path, "expected JSON array for %s, got %T", shape.Type, raw)
+		}
+		out := make([]interface{}, len(arr))
+		for i, elem := range arr {
+			if out[i], err = c.deserializeValue(shape.Member, elem, path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+
+	case model.TypeMap:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, valueErrorf(path, "expected JSON object for map, got %T", raw)
+		}
+		out := make(map[string]interface{}, len(obj))
+		for k, elem := range obj {
+			if out[k], err = c.deserializeValue(shape.Value, elem, path+"["+strconv.Quote(k)+"]"); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+
+	case model.TypeString, model.TypeEnum:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, valueErrorf(path, "expected JSON string for %s, got %T", shape.Type, raw)
+		}
+		return s, nil
+
+	case model.TypeBlob:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, valueErrorf(path, "expected JSON string for blob, got %T", raw)
+		}
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, &ValueError{Path: path, Err: err}
+		}
+		return b, nil
+
+	case model.TypeBoolean:
+		b, ok := raw.(bool)
+		if !ok {
+			return nil, valueErrorf(path, "expected JSON boolean, got %T", raw)
+		}
+		return b, nil
+
+	case model.TypeByte, model.TypeShort, model.TypeInteger, model.TypeLong, model.TypeIntEnum:
+		n, ok := raw.(json.Number)
+		if !ok {
+			return nil, valueErrorf(path, "expected JSON number for %s, got %T", shape.Type, raw)
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return nil, &ValueError{Path: path, Err: err}
+		}
+		return i, nil
+
+	case model.TypeFloat, model.TypeDouble:
+		switch v := raw.(type) {
+		case json.Number:
+			f, err := v.Float64()
+			if err != nil {
+				return nil, &ValueError{Path: path, Err: err}
+			}
+			return f, nil
+		case string:
+			switch v {
+			case "NaN":
+				return math.NaN(), nil
+			case "Infinity":
+				return math.Inf(1), nil
+			case "-Infinity":
+				return math.Inf(-1), nil
+			}
+		}
+		return nil, valueErrorf(path, "expected JSON number for %s, got %v", shape.Type, raw)
+
+	case model.TypeBigInteger:
+		n, ok := raw.(json.Number)
+		if !ok {
+			return nil, valueErrorf(path, "expected JSON number for bigInteger, got %T", raw)
+		}
+		i, ok := new(big.Int).SetString(string(n), 10)
+		if !ok {
+			return nil, valueErrorf(path, "invalid bigInteger %v", n)
+		}
+		return i, nil
+
+	case model.TypeBigDecimal:
+		n, ok := raw.(json.Number)
+		if !ok {
+			return nil, valueErrorf(path, "expected JSON number for bigDecimal, got %T", raw)
+		}
+		f, ok := new(big.Float).SetString(string(n))
+		if !ok {
+			return nil, valueErrorf(path, "invalid bigDecimal %v", n)
+		}
+		return f, nil
+
+	case model.TypeTimestamp:
+		return c.deserializeTimestamp(member, shape, raw, path)
+
+	case model.TypeDocument:
+		return raw, nil
+
+	default:
+		return nil, valueErrorf(path, "unsupported shape type %q", shape.Type)
+	}
+}
+
+func (c *jsonCodec) deserializeStructure(shape *model.Shape, raw interface{}, path string) (map[string]interface{}, error) {
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, valueErrorf(path, "expected JSON object for %s, got %T", shape.Type, raw)
+	}
+
+	out := make(map[string]interface{}, len(obj))
+	for name, elem := range obj {
+		member, ok := shape.Members[name]
+		if !ok {
+			// Unknown members, e.g. "__type", are ignored.
+			continue
+		}
+		v, err := c.deserializeValue(member, elem, path+"."+name)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			out[name] = v
+		}
+	}
+	return out, nil
+}
+
+func (c *jsonCodec) deserializeTimestamp(member *model.Member, shape *model.Shape, raw interface{}, path string) (interface{}, error) {
+	switch format := c.timestampFormat(member, shape); format {
+	case timestampEpochSeconds:
+		n, ok := raw.(json.Number)
+		if !ok {
+			return nil, valueErrorf(path, "expected JSON number for epoch-seconds timestamp, got %T", raw)
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return nil, &ValueError{Path: path, Err: err}
+		}
+		return smithytime.ParseEpochSeconds(f), nil
+
+	case timestampDateTime, timestampHTTPDate:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, valueErrorf(path, "expected JSON string for %s timestamp, got %T", format, raw)
+		}
+		var t time.Time
+		var err error
+		if format == timestampDateTime {
+			t, err = smithytime.ParseDateTime(s)
+		} else {
+			t, err = smithytime.ParseHTTPDate(s)
+		}
+		if err != nil {
+			return nil, &ValueError{Path: path, Err: err}
+		}
+		return t, nil
+
+	default:
+		return nil, valueErrorf(path, "unsupported timestamp format %q", format)
+	}
+}
+
+func decodeJSON(body []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response body, %w", err)
+	}
+	return nil
+}
+
+func toInt64(v interface{}) (int64, error) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), nil
+	case int8:
+		return int64(n), nil
+	case int16:
+		return int64(n), nil
+	case int32:
+		return int64(n), nil
+	case int64:
+		return n, nil
+	case uint8:
+		return int64(n), nil
+	case uint16:
+		return int64(n), nil
+	case uint32:
+		return int64(n), nil
+	case float64:
+		if n != math.Trunc(n) {
+			return 0, fmt.Errorf("expected integer, got %v", n)
+		}
+		return int64(n), nil
+	case json.Number:
+		return n.Int64()
+	default:
+		return 0, fmt.Errorf("expected integer, got %T", v)
+	}
+}
+
+func toFloat64(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case float32:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	case json.Number:
+		return n.Float64()
+	default:
+		i, err := toInt64(v)
+		if err != nil {
+			return 0, fmt.Errorf("expected number, got %T", v)
+		}
+		return float64(i), nil
+	}
+}
@@ -0,0 +1,45 @@
+// Package dynamic provides a client which invokes the operations of a
+// service described by a Smithy model loaded at runtime, without generated
+// code. Inputs are serialized from, and outputs deserialized to, generic Go
+// values per the shapes of the model, for use by tooling, CLIs, and
+// exploratory testing. Models are loaded with the model package.
+//
+//	m, err := model.Load(f)
+//	if err != nil {
+//		return err
+//	}
+//	client, err := dynamic.New(m, "example.weather#Weather", func(o *dynamic.Options) {
+//		o.Endpoint = "http://localhost:8000"
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	out, err := client.Invoke(ctx, "GetCity", map[string]interface{}{
+//		"cityId": "seattle",
+//	})
+//
+// The Go representation of each shape type is:
+//
+//	structure, union, map  map[string]interface{}
+//	list, set              []interface{}
+//	string, enum           string
+//	blob                   []byte
+//	boolean                bool
+//	byte ... long, intEnum int64
+//	float, double          float64
+//	bigInteger             *big.Int
+//	bigDecimal             *big.Float
+//	timestamp              time.Time
+//	document               the value decoded by encoding/json, with numbers
+//	                       as json.Number
+//
+// Inputs may also use other Go integer and float types for numbers, strings
+// for blobs, and slices and maps of other element types.
+//
+// Requests may also be built without being sent. NewRequest returns the
+// request of an operation, and SerializeDocument serializes a document as
+// the request body of any structure shape of the model, for tooling, such as
+// API explorers, that constructs requests generically.
+//
+// The supported protocols are awsJson1_0 and awsJson1_1.
+package dynamic
@@ -0,0 +1,69 @@
+package dynamic
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/aws/smithy-go/model"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// SerializeDocument serializes the document, the Go representation of a
+// structure or union, see the package documentation, as the body of a
+// request of the client's protocol per the shape identified by its absolute
+// shape ID. Returns a *ValueError if the document does not conform to the
+// shape.
+//
+// SerializeDocument allows tooling to construct, and inspect, request
+// bodies of shapes of the model without invoking an operation.
+func (c *Client) SerializeDocument(shape model.ShapeID, doc map[string]interface{}) ([]byte, error) {
+	s, ok := c.model.Shape(shape)
+	if !ok {
+		return nil, fmt.Errorf("shape %s not found in model", shape)
+	}
+	if s.Type != model.TypeStructure && s.Type != model.TypeUnion {
+		return nil, fmt.Errorf("shape %s is a %s, expected structure or union", shape, s.Type)
+	}
+	return c.protocol.codec.serialize(s, doc, "document")
+}
+
+// NewRequest returns the request of the operation, identified by its
+// absolute shape ID or its name, with the input serialized as its body,
+// without sending it. The request is the same as the request Invoke sends,
+// before the middleware of the operation's stack, such as request signing,
+// is applied.
+func (c *Client) NewRequest(operation string, input map[string]interface{}) (*smithyhttp.Request, error) {
+	op, err := c.resolveOperation(operation)
+	if err != nil {
+		return nil, err
+	}
+	return c.serializeRequest(smithyhttp.NewStackRequest().(*smithyhttp.Request), op, input)
+}
+
+// serializeRequest serializes the operation's input to the request per the
+// client's protocol.
+func (c *Client) serializeRequest(req *smithyhttp.Request, op *model.Shape, input map[string]interface{}) (*smithyhttp.Request, error) {
+	inputShape := &model.Shape{Type: model.TypeStructure}
+	if op.Input != nil {
+		var ok bool
+		if inputShape, ok = c.model.Shape(op.Input.Target); !ok {
+			return nil, fmt.Errorf("input shape %s not found in model", op.Input.Target)
+		}
+	}
+
+	body, err := c.protocol.codec.serialize(inputShape, input, "input")
+	if err != nil {
+		return nil, err
+	}
+
+	u := *c.endpoint
+	if len(u.Path) == 0 {
+		u.Path = "/"
+	}
+	req.URL = &u
+	req.Method = "POST"
+	req.Header.Set("Content-Type", c.protocol.contentType)
+	req.Header.Set("X-Amz-Target", c.service.ID.Name()+"."+op.ID.Name())
+
+	return req.SetStream(bytes.NewReader(body))
+}
@@ -0,0 +1,126 @@
+package dynamic
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/model"
+)
+
+func TestClientSerializeDocument(t *testing.T) {
+	client, err := New(loadTestModel(t), "example.weather#Weather", func(o *Options) {
+		o.Endpoint = "https://weather.example.com"
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	cases := map[string]struct {
+		Shape      string
+		Doc        map[string]interface{}
+		ExpectBody string
+		ExpectErr  string
+	}{
+		"structure": {
+			Shape: "example.weather#GetCityOutput",
+			Doc: map[string]interface{}{
+				"name":          "Seattle",
+				"population":    737015,
+				"neighborhoods": []string{"Ballard"},
+				"coordinates":   map[string]float64{"lat": 47.5},
+				"census":        bigInt("123456789012345678901234567890"),
+			},
+			ExpectBody: `{"census":123456789012345678901234567890,"coordinates":{"lat":47.5},"name":"Seattle","neighborhoods":["Ballard"],"population":737015}`,
+		},
+		"empty": {
+			Shape:      "example.weather#GetCurrentTimeOutput",
+			ExpectBody: `{}`,
+		},
+		"missing required member": {
+			Shape:     "example.weather#GetCityInput",
+			ExpectErr: `missing required member "cityId"`,
+		},
+		"invalid value": {
+			Shape:     "example.weather#GetCityOutput",
+			Doc:       map[string]interface{}{"neighborhoods": "Ballard"},
+			ExpectErr: "document.neighborhoods",
+		},
+		"unknown shape": {
+			Shape:     "example.weather#Unknown",
+			ExpectErr: "not found",
+		},
+		"not a structure": {
+			Shape:     "example.weather#Neighborhoods",
+			ExpectErr: "expected structure or union",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			body, err := client.SerializeDocument(model.ShapeID(c.Shape), c.Doc)
+			if len(c.ExpectErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error")
+				}
+				if e, a := c.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect %q in error, got %q", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.ExpectBody, string(body); e != a {
+				t.Errorf("expect %v body, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestClientNewRequest(t *testing.T) {
+	client, err := New(loadTestModel(t), "example.weather#Weather", func(o *Options) {
+		o.Endpoint = "https://weather.example.com"
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	req, err := client.NewRequest("GetCity", map[string]interface{}{"cityId": "seattle"})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "POST", req.Method; e != a {
+		t.Errorf("expect %v method, got %v", e, a)
+	}
+	if e, a := "https://weather.example.com/", req.URL.String(); e != a {
+		t.Errorf("expect %v URL, got %v", e, a)
+	}
+	if e, a := "application/x-amz-json-1.0", req.Header.Get("Content-Type"); e != a {
+		t.Errorf("expect %v content type, got %v", e, a)
+	}
+	if e, a := "Weather.GetCity", req.Header.Get("X-Amz-Target"); e != a {
+		t.Errorf("expect %v target, got %v", e, a)
+	}
+	body, err := ioutil.ReadAll(req.GetStream())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := `{"cityId":"seattle"}`, string(body); e != a {
+		t.Errorf("expect %v body, got %v", e, a)
+	}
+
+	_, err = client.NewRequest("GetCity", map[string]interface{}{"cityId": 1})
+	var valueErr *ValueError
+	if !errors.As(err, &valueErr) {
+		t.Fatalf("expect *ValueError, got %T, %v", err, err)
+	}
+	if e, a := "input.cityId", valueErr.Path; e != a {
+		t.Errorf("expect %v path, got %v", e, a)
+	}
+
+	if _, err := client.NewRequest("Unknown", nil); err == nil {
+		t.Errorf("expect error for unbound operation")
+	}
+}
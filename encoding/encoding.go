@@ -6,26 +6,82 @@ import (
 	"strconv"
 )
 
+// Default exponent thresholds of FloatFormat.
+const (
+	DefaultFloatExponentMin = 1e-6
+	DefaultFloatExponentMax = 1e21
+)
+
+// FloatFormat controls how float values are encoded. The zero value encodes
+// floats as EncodeFloat does, with the shortest representation that
+// round-trips, in scientific notation if the value's magnitude is less than
+// 1e-6, or at least 1e21.
+type FloatFormat struct {
+	// FixedPrecision is whether floats are encoded with Precision digits
+	// after the decimal point, instead of the shortest representation that
+	// round-trips. Values encoded with a fixed precision may not round-trip.
+	FixedPrecision bool
+
+	// Precision is the number of digits after the decimal point if
+	// FixedPrecision is set. In scientific notation, it is the number of
+	// digits of the mantissa after the decimal point.
+	Precision int
+
+	// DisableExponent is whether scientific notation is never used, for
+	// services that reject it. Floats are always encoded in decimal
+	// notation, such as 1000000000000000000000.
+	DisableExponent bool
+
+	// ExponentMin is the magnitude below which non-zero floats are encoded
+	// in scientific notation. Defaults to DefaultFloatExponentMin.
+	ExponentMin float64
+
+	// ExponentMax is the magnitude at, or above, which floats are encoded in
+	// scientific notation. Defaults to DefaultFloatExponentMax.
+	ExponentMax float64
+}
+
 // EncodeFloat encodes a float value as per the stdlib encoder for json and xml protocol
 // This encodes a float value into dst while attempting to conform to ES6 ToString for Numbers
 //
 // Based on encoding/json floatEncoder from the Go Standard Library
 // https://golang.org/src/encoding/json/encode.go
 func EncodeFloat(dst []byte, v float64, bits int) []byte {
+	return EncodeFloatFormat(dst, v, bits, FloatFormat{})
+}
+
+// EncodeFloatFormat encodes a float value into dst, formatted as configured
+// by the FloatFormat. Panics if the value is infinite, or NaN, which
+// protocols encode as strings.
+func EncodeFloatFormat(dst []byte, v float64, bits int, f FloatFormat) []byte {
 	if math.IsInf(v, 0) || math.IsNaN(v) {
 		panic(fmt.Sprintf("invalid float value: %s", strconv.FormatFloat(v, 'g', -1, bits)))
 	}
 
+	expMin, expMax := f.ExponentMin, f.ExponentMax
+	if expMin == 0 {
+		expMin = DefaultFloatExponentMin
+	}
+	if expMax == 0 {
+		expMax = DefaultFloatExponentMax
+	}
+
 	abs := math.Abs(v)
 	fmt := byte('f')
 
-	if abs != 0 {
-		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+	if abs != 0 && !f.DisableExponent {
+		if bits == 64 && (abs < expMin || abs >= expMax) ||
+			bits == 32 && (float32(abs) < float32(expMin) || float32(abs) >= float32(expMax)) {
 			fmt = 'e'
 		}
 	}
 
-	dst = strconv.AppendFloat(dst, v, fmt, -1, bits)
+	prec := -1
+	if f.FixedPrecision && f.Precision >= 0 {
+		prec = f.Precision
+	}
+
+	dst = strconv.AppendFloat(dst, v, fmt, prec, bits)
 
 	if fmt == 'e' {
 		// clean up e-09 to e-9
@@ -0,0 +1,101 @@
+package encoding
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEncodeFloatFormat(t *testing.T) {
+	cases := map[string]struct {
+		Value  float64
+		Bits   int
+		Format FloatFormat
+		Expect string
+	}{
+		"shortest": {
+			Value:  0.1,
+			Bits:   64,
+			Expect: "0.1",
+		},
+		"shortest float32": {
+			Value:  float64(float32(0.1)),
+			Bits:   32,
+			Expect: "0.1",
+		},
+		"default large exponent": {
+			Value:  1e21,
+			Bits:   64,
+			Expect: "1e+21",
+		},
+		"default small exponent": {
+			Value:  1e-7,
+			Bits:   64,
+			Expect: "1e-7",
+		},
+		"exponent disabled": {
+			Value:  1e21,
+			Bits:   64,
+			Format: FloatFormat{DisableExponent: true},
+			Expect: "1000000000000000000000",
+		},
+		"small exponent disabled": {
+			Value:  1.5e-7,
+			Bits:   64,
+			Format: FloatFormat{DisableExponent: true},
+			Expect: "0.00000015",
+		},
+		"fixed precision": {
+			Value:  1.0 / 3,
+			Bits:   64,
+			Format: FloatFormat{FixedPrecision: true, Precision: 2},
+			Expect: "0.33",
+		},
+		"fixed zero precision": {
+			Value:  2.5,
+			Bits:   64,
+			Format: FloatFormat{FixedPrecision: true},
+			Expect: "2",
+		},
+		"fixed precision exponent": {
+			Value:  1.23456e-9,
+			Bits:   64,
+			Format: FloatFormat{FixedPrecision: true, Precision: 2},
+			Expect: "1.23e-9",
+		},
+		"custom thresholds": {
+			Value:  12345,
+			Bits:   64,
+			Format: FloatFormat{ExponentMin: 1e-3, ExponentMax: 1e4},
+			Expect: "1.2345e+04",
+		},
+		"custom thresholds below max": {
+			Value:  0.01,
+			Bits:   64,
+			Format: FloatFormat{ExponentMin: 1e-3, ExponentMax: 1e4},
+			Expect: "0.01",
+		},
+		"zero": {
+			Value:  0,
+			Bits:   64,
+			Format: FloatFormat{ExponentMin: 1e-3},
+			Expect: "0",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := c.Expect, string(EncodeFloatFormat(nil, c.Value, c.Bits, c.Format)); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestEncodeFloatFormatInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expect panic")
+		}
+	}()
+	EncodeFloatFormat(nil, math.NaN(), 64, FloatFormat{})
+}
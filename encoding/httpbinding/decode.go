@@ -0,0 +1,71 @@
+package httpbinding
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SplitHeaderListValues splits the values of a header bound to a list member
+// into the individual list items. Each header value may contain multiple
+// comma separated items. Items may be double quoted to include commas, with
+// backslash escaping of quotes and backslashes within quoted items.
+// Unquoted items are trimmed of surrounding whitespace.
+func SplitHeaderListValues(vs []string) ([]string, error) {
+	var items []string
+	for _, v := range vs {
+		if err := splitHeaderListValue(v, &items); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+func splitHeaderListValue(v string, items *[]string) error {
+	for len(v) != 0 {
+		v = strings.TrimLeft(v, " \t")
+		if len(v) == 0 {
+			*items = append(*items, "")
+			return nil
+		}
+
+		if v[0] != '"' {
+			i := strings.IndexByte(v, ',')
+			if i == -1 {
+				*items = append(*items, strings.TrimSpace(v))
+				return nil
+			}
+			*items = append(*items, strings.TrimSpace(v[:i]))
+			v = v[i+1:]
+			if len(v) == 0 {
+				*items = append(*items, "")
+			}
+			continue
+		}
+
+		var sb strings.Builder
+		i := 1
+		for ; i < len(v) && v[i] != '"'; i++ {
+			if v[i] == '\\' && i+1 < len(v) {
+				i++
+			}
+			sb.WriteByte(v[i])
+		}
+		if i >= len(v) {
+			return fmt.Errorf("unterminated quoted header list item, %q", v)
+		}
+		*items = append(*items, sb.String())
+
+		v = strings.TrimLeft(v[i+1:], " \t")
+		if len(v) == 0 {
+			return nil
+		}
+		if v[0] != ',' {
+			return fmt.Errorf("invalid header list item after quoted value, %q", v)
+		}
+		v = v[1:]
+		if len(v) == 0 {
+			*items = append(*items, "")
+		}
+	}
+	return nil
+}
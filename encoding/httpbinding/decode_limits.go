@@ -0,0 +1,73 @@
+package httpbinding
+
+import "fmt"
+
+// Default limits of DecodeHeaderListValues.
+const (
+	DefaultDecodeMaxBytes = 64 << 10
+	DefaultDecodeMaxItems = 1000
+)
+
+// DecodeLimits bounds the resources used by DecodeHeaderListValues. A zero
+// limit uses its default.
+type DecodeLimits struct {
+	// MaxBytes is the maximum total size of the header values. Defaults to
+	// DefaultDecodeMaxBytes.
+	MaxBytes int
+
+	// MaxItems is the maximum number of list items. Defaults to
+	// DefaultDecodeMaxItems.
+	MaxItems int
+}
+
+func (l DecodeLimits) withDefaults() DecodeLimits {
+	if l.MaxBytes == 0 {
+		l.MaxBytes = DefaultDecodeMaxBytes
+	}
+	if l.MaxItems == 0 {
+		l.MaxItems = DefaultDecodeMaxItems
+	}
+	return l
+}
+
+// DecodeLimitError is returned by DecodeHeaderListValues when the header
+// values exceed one of its DecodeLimits.
+type DecodeLimitError struct {
+	// Limit is the name of the limit exceeded, e.g. "MaxItems".
+	Limit string
+	Max   int
+}
+
+func (e *DecodeLimitError) Error() string {
+	return fmt.Sprintf("header list exceeds %s limit of %d", e.Limit, e.Max)
+}
+
+// DecodeHeaderListValues splits the values of a header bound to a list
+// member into the individual list items, see SplitHeaderListValues. Returns
+// a *DecodeLimitError if the values exceed the limits, bounding the memory
+// used to decode untrusted headers.
+//
+// DecodeHeaderListValues uses no global state, and is suitable as the target
+// of fuzz tests of protocols binding list members to headers.
+func DecodeHeaderListValues(vs []string, limits DecodeLimits) ([]string, error) {
+	limits = limits.withDefaults()
+
+	var n int
+	for _, v := range vs {
+		n += len(v)
+	}
+	if n > limits.MaxBytes {
+		return nil, &DecodeLimitError{Limit: "MaxBytes", Max: limits.MaxBytes}
+	}
+
+	var items []string
+	for _, v := range vs {
+		if err := splitHeaderListValue(v, &items); err != nil {
+			return nil, err
+		}
+		if len(items) > limits.MaxItems {
+			return nil, &DecodeLimitError{Limit: "MaxItems", Max: limits.MaxItems}
+		}
+	}
+	return items, nil
+}
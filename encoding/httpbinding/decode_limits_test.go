@@ -0,0 +1,86 @@
+package httpbinding
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeHeaderListValues(t *testing.T) {
+	cases := map[string]struct {
+		Values      []string
+		Limits      DecodeLimits
+		Expect      []string
+		ExpectErr   bool
+		ExpectLimit string
+	}{
+		"items": {
+			Values: []string{`a, "b, c"`, "d"},
+			Expect: []string{"a", "b, c", "d"},
+		},
+		"unterminated quote": {
+			Values:    []string{`"a`},
+			ExpectErr: true,
+		},
+		"max bytes": {
+			Values:      []string{"abc", "def"},
+			Limits:      DecodeLimits{MaxBytes: 5},
+			ExpectLimit: "MaxBytes",
+		},
+		"max items": {
+			Values:      []string{"a,b", "c"},
+			Limits:      DecodeLimits{MaxItems: 2},
+			ExpectLimit: "MaxItems",
+		},
+		"default max items": {
+			Values:      []string{strings.Repeat(",", DefaultDecodeMaxItems)},
+			ExpectLimit: "MaxItems",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			items, err := DecodeHeaderListValues(c.Values, c.Limits)
+			if len(c.ExpectLimit) != 0 {
+				var limitErr *DecodeLimitError
+				if !errors.As(err, &limitErr) {
+					t.Fatalf("expect %T error, got %v", limitErr, err)
+				}
+				if e, a := c.ExpectLimit, limitErr.Limit; e != a {
+					t.Errorf("expect %v limit, got %v", e, a)
+				}
+				return
+			}
+			if c.ExpectErr {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Expect, items; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func FuzzDecodeHeaderListValues(f *testing.F) {
+	f.Add(`a, b ,c`)
+	f.Add(`"a, b", "c \"d\"",e`)
+	f.Add(`Mon, 16 Dec 2019 23:48:18 GMT, "Tue, 17 Dec 2019 23:48:18 GMT"`)
+
+	f.Fuzz(func(t *testing.T, v string) {
+		limits := DecodeLimits{MaxBytes: 4096, MaxItems: 256}
+		items, err := DecodeHeaderListValues([]string{v}, limits)
+		if err != nil {
+			return
+		}
+		if len(items) > limits.MaxItems {
+			t.Errorf("expect at most %v items, got %v", limits.MaxItems, len(items))
+		}
+	})
+}
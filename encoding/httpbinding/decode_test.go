@@ -0,0 +1,61 @@
+package httpbinding
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitHeaderListValues(t *testing.T) {
+	cases := map[string]struct {
+		Values    []string
+		Expect    []string
+		ExpectErr bool
+	}{
+		"single": {
+			Values: []string{"foo"},
+			Expect: []string{"foo"},
+		},
+		"comma separated": {
+			Values: []string{"foo, bar ,baz"},
+			Expect: []string{"foo", "bar", "baz"},
+		},
+		"multiple values": {
+			Values: []string{"foo", "bar, baz"},
+			Expect: []string{"foo", "bar", "baz"},
+		},
+		"quoted": {
+			Values: []string{`"a, b", "c \"d\"",e`},
+			Expect: []string{"a, b", `c "d"`, "e"},
+		},
+		"trailing empty": {
+			Values: []string{"foo,"},
+			Expect: []string{"foo", ""},
+		},
+		"unterminated quote": {
+			Values:    []string{`"foo`},
+			ExpectErr: true,
+		},
+		"invalid after quote": {
+			Values:    []string{`"foo" bar`},
+			ExpectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := SplitHeaderListValues(c.Values)
+			if c.ExpectErr {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if !reflect.DeepEqual(c.Expect, actual) {
+				t.Errorf("expect %q, got %q", c.Expect, actual)
+			}
+		})
+	}
+}
@@ -101,12 +101,12 @@ func (e *Encoder) SetURI(key string) URIValue {
 
 // SetQuery returns a QueryValue used for setting the given query key
 func (e *Encoder) SetQuery(key string) QueryValue {
-	return NewQueryValue(e.query, key, false)
+	return newQueryValue(e.query, key, false, true)
 }
 
 // AddQuery returns a QueryValue used for appending the given query key
 func (e *Encoder) AddQuery(key string) QueryValue {
-	return NewQueryValue(e.query, key, true)
+	return newQueryValue(e.query, key, true, true)
 }
 
 // HasQuery returns if a query with the key specified exists with one or
@@ -0,0 +1,65 @@
+package httpbinding
+
+import (
+	"math/big"
+	"net/http"
+	"testing"
+)
+
+func BenchmarkHeaderValue(b *testing.B) {
+	blob := []byte("hello world")
+	bigInt := big.NewInt(1234567890)
+
+	cases := map[string]func(HeaderValue){
+		"String":     func(h HeaderValue) { h.String("value") },
+		"Boolean":    func(h HeaderValue) { h.Boolean(true) },
+		"Integer":    func(h HeaderValue) { h.Integer(1234567) },
+		"Long":       func(h HeaderValue) { h.Long(1234567890123) },
+		"Double":     func(h HeaderValue) { h.Double(1234.5678) },
+		"Blob":       func(h HeaderValue) { h.Blob(blob) },
+		"BigInteger": func(h HeaderValue) { h.BigInteger(bigInt) },
+	}
+
+	for name, fn := range cases {
+		b.Run(name, func(b *testing.B) {
+			encoder, err := NewEncoder("/", "", http.Header{})
+			if err != nil {
+				b.Fatalf("expect no error, got %v", err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				fn(encoder.SetHeader("X-Amz-Value"))
+			}
+		})
+	}
+}
+
+func BenchmarkQueryValue(b *testing.B) {
+	blob := []byte("hello world")
+	bigInt := big.NewInt(1234567890)
+
+	cases := map[string]func(QueryValue){
+		"String":     func(q QueryValue) { q.String("value") },
+		"Boolean":    func(q QueryValue) { q.Boolean(true) },
+		"Integer":    func(q QueryValue) { q.Integer(1234567) },
+		"Long":       func(q QueryValue) { q.Long(1234567890123) },
+		"Double":     func(q QueryValue) { q.Double(1234.5678) },
+		"Blob":       func(q QueryValue) { q.Blob(blob) },
+		"BigInteger": func(q QueryValue) { q.BigInteger(bigInt) },
+	}
+
+	for name, fn := range cases {
+		b.Run(name, func(b *testing.B) {
+			encoder, err := NewEncoder("/", "", http.Header{})
+			if err != nil {
+				b.Fatalf("expect no error, got %v", err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				fn(encoder.SetQuery("value"))
+			}
+		})
+	}
+}
@@ -99,6 +99,25 @@ func TestEncoderHasQuery(t *testing.T) {
 
 }
 
+func TestEncoderSetQueryReusesValues(t *testing.T) {
+	encoder, err := NewEncoder("/", "key=a&key=b", http.Header{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	encoder.SetQuery("key").String("value")
+	if e, a := []string{"value"}, encoder.query["key"]; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		encoder.SetQuery("key").Boolean(true)
+	})
+	if allocs != 0 {
+		t.Errorf("expect no allocations setting the query value, got %v", allocs)
+	}
+}
+
 func TestEncodeContentLength(t *testing.T) {
 	cases := map[string]struct {
 		headerValue string
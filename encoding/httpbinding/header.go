@@ -15,6 +15,12 @@ type Headers struct {
 	prefix string
 }
 
+// NewHeaders returns a Headers that encodes header keys with the prefix into
+// the given http.Header.
+func NewHeaders(header http.Header, prefix string) Headers {
+	return Headers{header: header, prefix: strings.TrimSpace(prefix)}
+}
+
 // AddHeader returns a HeaderValue used to append values to prefix+key
 func (h Headers) AddHeader(key string) HeaderValue {
 	return h.newHeaderValue(key, true)
@@ -36,6 +42,13 @@ type HeaderValue struct {
 	append bool
 }
 
+// NewHeaderValue creates a new HeaderValue which enables encoding a header
+// value into the given http.Header. If append is true, values are appended
+// to the existing values of the header, otherwise they are replaced.
+func NewHeaderValue(header http.Header, key string, append bool) HeaderValue {
+	return newHeaderValue(header, key, append)
+}
+
 func newHeaderValue(header http.Header, key string, append bool) HeaderValue {
 	return HeaderValue{header: header, key: strings.TrimSpace(key), append: append}
 }
@@ -103,6 +116,10 @@ func (h HeaderValue) float(v float64, bitSize int) {
 
 // BigInteger encodes the value v as a query string value
 func (h HeaderValue) BigInteger(v *big.Int) {
+	if v.IsInt64() {
+		h.Long(v.Int64())
+		return
+	}
 	h.modifyHeader(v.String())
 }
 
@@ -0,0 +1,99 @@
+package httpbinding
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// MediaType encodes the value v of a string member with the mediaType trait
+// as a base64 header string value, as the value may contain characters not
+// allowed in a header.
+func (h HeaderValue) MediaType(v string) {
+	h.modifyHeader(base64.StdEncoding.EncodeToString([]byte(v)))
+}
+
+// List encodes the items of a list member as comma separated header values,
+// quoting items which contain commas or double quotes. If maxLen is greater
+// than zero the items are split across multiple instances of the header, so
+// that no instance's value exceeds maxLen, unless a single item does. The
+// instances are decoded into the list's items with SplitHeaderListValues.
+func (h HeaderValue) List(vs []string, maxLen int) {
+	for i, v := range JoinHeaderListValues(vs, maxLen) {
+		if i == 0 {
+			h.modifyHeader(v)
+			continue
+		}
+		h.header[h.key] = append(h.header[h.key], v)
+	}
+}
+
+// JoinHeaderListValues joins the items of a list member into comma separated
+// header values, quoting items which contain commas or double quotes. If
+// maxLen is greater than zero the items are split across multiple values, so
+// that no value exceeds maxLen, unless a single item does. Returns no values
+// for an empty list.
+func JoinHeaderListValues(vs []string, maxLen int) []string {
+	var values []string
+	var sb strings.Builder
+	for _, v := range vs {
+		item := quoteHeaderListItem(v)
+		if sb.Len() != 0 && maxLen > 0 && sb.Len()+len(", ")+len(item) > maxLen {
+			values = append(values, sb.String())
+			sb.Reset()
+		}
+		if sb.Len() != 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(item)
+	}
+	if len(vs) != 0 {
+		values = append(values, sb.String())
+	}
+	return values
+}
+
+func quoteHeaderListItem(v string) string {
+	if !strings.ContainsAny(v, `,"`) && strings.TrimSpace(v) == v {
+		return v
+	}
+	var sb strings.Builder
+	sb.Grow(len(v) + 2)
+	sb.WriteByte('"')
+	for i := 0; i < len(v); i++ {
+		if v[i] == '"' || v[i] == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(v[i])
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// DecodeBase64HeaderValue decodes the base64 header value of a blob member.
+// The value is decoded with the standard base64 alphabet, with padding.
+// Values without padding are also accepted, as some services omit it.
+// Surrounding whitespace is ignored.
+func DecodeBase64HeaderValue(v string) ([]byte, error) {
+	v = strings.TrimSpace(v)
+
+	enc := base64.StdEncoding
+	if len(v)%4 != 0 {
+		enc = base64.RawStdEncoding
+	}
+	b, err := enc.DecodeString(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 header value, %w", err)
+	}
+	return b, nil
+}
+
+// DecodeMediaTypeHeaderValue decodes the base64 header value of a string
+// member with the mediaType trait, see HeaderValue.MediaType.
+func DecodeMediaTypeHeaderValue(v string) (string, error) {
+	b, err := DecodeBase64HeaderValue(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
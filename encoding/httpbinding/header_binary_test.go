@@ -0,0 +1,133 @@
+package httpbinding
+
+import (
+	"bytes"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHeaderValue_MediaType(t *testing.T) {
+	header := http.Header{}
+	NewHeaderValue(header, "X-Json", false).MediaType(`{"a": "b"}`)
+
+	if e, a := "eyJhIjogImIifQ==", header.Get("X-Json"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	v, err := DecodeMediaTypeHeaderValue(header.Get("X-Json"))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := `{"a": "b"}`, v; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestDecodeBase64HeaderValue(t *testing.T) {
+	cases := map[string]struct {
+		Value     string
+		Expect    []byte
+		ExpectErr bool
+	}{
+		"padded": {
+			Value:  "YmF6YQ==",
+			Expect: []byte("baza"),
+		},
+		"unpadded": {
+			Value:  "YmF6YQ",
+			Expect: []byte("baza"),
+		},
+		"whitespace": {
+			Value:  " YmF6 ",
+			Expect: []byte("baz"),
+		},
+		"empty": {
+			Value:  "",
+			Expect: []byte{},
+		},
+		"invalid": {
+			Value:     "Ym$6",
+			ExpectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, err := DecodeBase64HeaderValue(c.Value)
+			if c.ExpectErr {
+				if err == nil {
+					t.Fatalf("expect error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Expect, v; !bytes.Equal(e, a) {
+				t.Errorf("expect %q, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestJoinHeaderListValues(t *testing.T) {
+	cases := map[string]struct {
+		Values []string
+		MaxLen int
+		Expect []string
+	}{
+		"empty": {},
+		"single value": {
+			Values: []string{"a", "b c", `d,e`, `f"g`},
+			Expect: []string{`a, b c, "d,e", "f\"g"`},
+		},
+		"split": {
+			Values: []string{"aaaa", "bbbb", "cccc", "dddd"},
+			MaxLen: 10,
+			Expect: []string{"aaaa, bbbb", "cccc, dddd"},
+		},
+		"item exceeds max": {
+			Values: []string{"a", strings.Repeat("b", 12), "c"},
+			MaxLen: 10,
+			Expect: []string{"a", strings.Repeat("b", 12), "c"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			values := JoinHeaderListValues(c.Values, c.MaxLen)
+			if e, a := c.Expect, values; !reflect.DeepEqual(e, a) {
+				t.Fatalf("expect %q, got %q", e, a)
+			}
+
+			items, err := SplitHeaderListValues(values)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := len(c.Values), len(items); e != a {
+				t.Fatalf("expect %v items, got %v", e, a)
+			}
+			for i := range items {
+				if e, a := c.Values[i], items[i]; e != a {
+					t.Errorf("expect %q item, got %q", e, a)
+				}
+			}
+		})
+	}
+}
+
+func TestHeaderValue_List(t *testing.T) {
+	header := http.Header{"X-List": []string{"existing"}}
+	NewHeaderValue(header, "X-List", false).List([]string{"aaaa", "bbbb", "cccc"}, 10)
+
+	if e, a := []string{"aaaa, bbbb", "cccc"}, header["X-List"]; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+
+	NewHeaderValue(header, "X-List", true).List([]string{"dddd"}, 10)
+	if e, a := []string{"aaaa, bbbb", "cccc", "dddd"}, header["X-List"]; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+}
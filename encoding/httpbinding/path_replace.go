@@ -3,6 +3,8 @@ package httpbinding
 import (
 	"bytes"
 	"fmt"
+
+	"github.com/aws/smithy-go/sync/bufferpool"
 )
 
 const (
@@ -80,13 +82,15 @@ func replacePathElement(path, fieldBuf []byte, key, val string, escape bool) ([]
 
 // EscapePath escapes part of a URL path in Amazon style.
 func EscapePath(path string, encodeSep bool) string {
-	var buf bytes.Buffer
+	buf := bufferpool.Get(len(path))
+	defer bufferpool.Put(buf)
+
 	for i := 0; i < len(path); i++ {
 		c := path[i]
 		if noEscape[c] || (c == '/' && !encodeSep) {
 			buf.WriteByte(c)
 		} else {
-			fmt.Fprintf(&buf, "%%%02X", c)
+			fmt.Fprintf(buf, "%%%02X", c)
 		}
 	}
 	return buf.String()
@@ -13,22 +13,35 @@ type QueryValue struct {
 	query  url.Values
 	key    string
 	append bool
+
+	// reuse is whether the key's existing values slice may be reused when
+	// setting the value. Only true for the Encoder's own query, whose slices
+	// are not shared with callers.
+	reuse bool
 }
 
 // NewQueryValue creates a new QueryValue which enables encoding
 // a query value into the given url.Values.
 func NewQueryValue(query url.Values, key string, append bool) QueryValue {
+	return newQueryValue(query, key, append, false)
+}
+
+func newQueryValue(query url.Values, key string, append, reuse bool) QueryValue {
 	return QueryValue{
 		query:  query,
 		key:    key,
 		append: append,
+		reuse:  reuse,
 	}
 }
 
 func (qv QueryValue) updateKey(value string) {
-	if qv.append {
+	switch {
+	case qv.append:
 		qv.query.Add(qv.key, value)
-	} else {
+	case qv.reuse:
+		qv.query[qv.key] = append(qv.query[qv.key][:0], value)
+	default:
 		qv.query.Set(qv.key, value)
 	}
 }
@@ -94,6 +107,10 @@ func (qv QueryValue) float(v float64, bitSize int) {
 
 // BigInteger encodes v as a query string value
 func (qv QueryValue) BigInteger(v *big.Int) {
+	if v.IsInt64() {
+		qv.Long(v.Int64())
+		return
+	}
 	qv.updateKey(v.String())
 }
 
@@ -232,3 +232,17 @@ func setQueryValue(qv QueryValue, args []interface{}) error {
 		return fmt.Errorf("unhandled query value type")
 	}
 }
+
+func TestQueryValueSetDoesNotModifyPreviousValues(t *testing.T) {
+	query := url.Values{"key": []string{"previous"}}
+	previous := query["key"]
+
+	NewQueryValue(query, "key", false).String("value")
+
+	if e, a := "previous", previous[0]; e != a {
+		t.Errorf("expect previously retrieved values unmodified, %v, got %v", e, a)
+	}
+	if e, a := []string{"value"}, query["key"]; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
@@ -2,6 +2,8 @@ package json
 
 import (
 	"bytes"
+
+	"github.com/aws/smithy-go/encoding"
 )
 
 // Array represent the encoding of a JSON Array
@@ -9,6 +11,9 @@ type Array struct {
 	w          *bytes.Buffer
 	writeComma bool
 	scratch    *[]byte
+	sortKeys   bool
+
+	floatFormat *encoding.FloatFormat
 }
 
 func newArray(w *bytes.Buffer, scratch *[]byte) *Array {
@@ -26,7 +31,10 @@ func (a *Array) Value() Value {
 		a.writeComma = true
 	}
 
-	return newValue(a.w, a.scratch)
+	v := newValue(a.w, a.scratch)
+	v.sortKeys = a.sortKeys
+	v.floatFormat = a.floatFormat
+	return v
 }
 
 // Close encodes the end of the JSON Array
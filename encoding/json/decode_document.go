@@ -0,0 +1,172 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Default limits of DecodeDocument.
+const (
+	DefaultDecodeMaxBytes  = 1 << 20
+	DefaultDecodeMaxDepth  = 64
+	DefaultDecodeMaxTokens = 100000
+)
+
+// DecodeLimits bounds the resources used by DecodeDocument. A zero limit
+// uses its default.
+type DecodeLimits struct {
+	// MaxBytes is the maximum size of the document. Defaults to
+	// DefaultDecodeMaxBytes.
+	MaxBytes int
+
+	// MaxDepth is the maximum nesting depth of objects and arrays. Defaults
+	// to DefaultDecodeMaxDepth.
+	MaxDepth int
+
+	// MaxTokens is the maximum number of tokens of the document, including
+	// object keys and delimiters. Defaults to DefaultDecodeMaxTokens.
+	MaxTokens int
+}
+
+func (l DecodeLimits) withDefaults() DecodeLimits {
+	if l.MaxBytes == 0 {
+		l.MaxBytes = DefaultDecodeMaxBytes
+	}
+	if l.MaxDepth == 0 {
+		l.MaxDepth = DefaultDecodeMaxDepth
+	}
+	if l.MaxTokens == 0 {
+		l.MaxTokens = DefaultDecodeMaxTokens
+	}
+	return l
+}
+
+// DecodeLimitError is returned by DecodeDocument when the document exceeds
+// one of its DecodeLimits.
+type DecodeLimitError struct {
+	// Limit is the name of the limit exceeded, e.g. "MaxDepth".
+	Limit string
+	Max   int
+}
+
+func (e *DecodeLimitError) Error() string {
+	return fmt.Sprintf("JSON document exceeds %s limit of %d", e.Limit, e.Max)
+}
+
+// DecodeDocument decodes the JSON document, returning its value as a
+// map[string]interface{}, []interface{}, json.Number, string, bool, or nil.
+// Numbers are decoded as json.Number, so decoding is lossless, and
+// deterministic. Returns a *DecodeLimitError if the document exceeds the
+// limits, bounding the memory used to decode untrusted documents.
+//
+// DecodeDocument uses no global state, and is suitable as the target of
+// fuzz tests of protocols decoding JSON documents.
+func DecodeDocument(data []byte, limits DecodeLimits) (interface{}, error) {
+	limits = limits.withDefaults()
+	if len(data) > limits.MaxBytes {
+		return nil, &DecodeLimitError{Limit: "MaxBytes", Max: limits.MaxBytes}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	d := &documentDecoder{decoder: decoder, limits: limits}
+	v, err := d.value(0)
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("invalid JSON, unexpected data after document")
+	}
+	return v, nil
+}
+
+type documentDecoder struct {
+	decoder *json.Decoder
+	limits  DecodeLimits
+	tokens  int
+}
+
+func (d *documentDecoder) token() (json.Token, error) {
+	d.tokens++
+	if d.tokens > d.limits.MaxTokens {
+		return nil, &DecodeLimitError{Limit: "MaxTokens", Max: d.limits.MaxTokens}
+	}
+	return d.decoder.Token()
+}
+
+func (d *documentDecoder) value(depth int) (interface{}, error) {
+	t, err := d.token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := t.(json.Delim)
+	if !ok {
+		return t, nil
+	}
+	if depth++; depth > d.limits.MaxDepth {
+		return nil, &DecodeLimitError{Limit: "MaxDepth", Max: d.limits.MaxDepth}
+	}
+
+	var v interface{}
+	switch delim {
+	case '{':
+		v, err = d.object(depth)
+	case '[':
+		v, err = d.array(depth)
+	default:
+		return nil, fmt.Errorf("invalid JSON, unexpected delimiter %v", delim)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the decoder checks the closing delimiter matches.
+	if _, err := d.token(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (d *documentDecoder) object(depth int) (map[string]interface{}, error) {
+	object := map[string]interface{}{}
+	for d.decoder.More() {
+		t, err := d.token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := t.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid JSON, expected string key, found %T", t)
+		}
+
+		v, err := d.value(depth)
+		if err != nil {
+			return nil, err
+		}
+		object[key] = v
+	}
+	return object, nil
+}
+
+func (d *documentDecoder) array(depth int) ([]interface{}, error) {
+	array := []interface{}{}
+	for d.decoder.More() {
+		v, err := d.value(depth)
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, v)
+	}
+	return array, nil
+}
@@ -0,0 +1,108 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeDocument(t *testing.T) {
+	cases := map[string]struct {
+		Data        string
+		Limits      DecodeLimits
+		Expect      interface{}
+		ExpectErr   bool
+		ExpectLimit string
+	}{
+		"object": {
+			Data: `{"a": [1, "b", true, null], "c": {"d": 1.5}}`,
+			Expect: map[string]interface{}{
+				"a": []interface{}{json.Number("1"), "b", true, nil},
+				"c": map[string]interface{}{"d": json.Number("1.5")},
+			},
+		},
+		"scalar": {
+			Data:   `"abc"`,
+			Expect: "abc",
+		},
+		"empty":            {Data: ``, ExpectErr: true},
+		"trailing data":    {Data: `{} {}`, ExpectErr: true},
+		"unterminated":     {Data: `{"a": [1, 2`, ExpectErr: true},
+		"mismatched delim": {Data: `{"a": [1, 2}}`, ExpectErr: true},
+		"max bytes": {
+			Data:        `[1, 2, 3]`,
+			Limits:      DecodeLimits{MaxBytes: 4},
+			ExpectLimit: "MaxBytes",
+		},
+		"max depth": {
+			Data:        `[[[1]]]`,
+			Limits:      DecodeLimits{MaxDepth: 2},
+			ExpectLimit: "MaxDepth",
+		},
+		"max tokens": {
+			Data:        `[1, 2, 3]`,
+			Limits:      DecodeLimits{MaxTokens: 3},
+			ExpectLimit: "MaxTokens",
+		},
+		"default max depth": {
+			Data:        strings.Repeat("[", DefaultDecodeMaxDepth+1) + strings.Repeat("]", DefaultDecodeMaxDepth+1),
+			ExpectLimit: "MaxDepth",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, err := DecodeDocument([]byte(c.Data), c.Limits)
+			if len(c.ExpectLimit) != 0 {
+				var limitErr *DecodeLimitError
+				if !errors.As(err, &limitErr) {
+					t.Fatalf("expect %T error, got %v", limitErr, err)
+				}
+				if e, a := c.ExpectLimit, limitErr.Limit; e != a {
+					t.Errorf("expect %v limit, got %v", e, a)
+				}
+				return
+			}
+			if c.ExpectErr {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Expect, v; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func FuzzDecodeDocument(f *testing.F) {
+	f.Add([]byte(`{"a": [1, "b", true, null], "c": {"d": 1.5e10}}`))
+	f.Add([]byte(`[{"__type": "ValidationException", "message": "é"}]`))
+	f.Add([]byte(`"abc"`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		v, err := DecodeDocument(data, DecodeLimits{MaxBytes: 4096, MaxDepth: 16, MaxTokens: 1024})
+		if err != nil {
+			return
+		}
+
+		// a decoded document must encode, and decode again to the same value.
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("expect decoded document to encode, got %v", err)
+		}
+		v2, err := DecodeDocument(b, DecodeLimits{MaxDepth: 16, MaxTokens: 1024})
+		if err != nil {
+			t.Fatalf("expect encoded document to decode, got %v", err)
+		}
+		if !reflect.DeepEqual(v, v2) {
+			t.Errorf("expect %v, got %v", v, v2)
+		}
+	})
+}
@@ -2,21 +2,74 @@ package json
 
 import (
 	"bytes"
+
+	"github.com/aws/smithy-go/encoding"
+	"github.com/aws/smithy-go/sync/bufferpool"
 )
 
 // Encoder is JSON encoder that supports construction of JSON values
 // using methods.
 type Encoder struct {
-	w *bytes.Buffer
+	w      *bytes.Buffer
+	pooled bool
 	Value
 }
 
+// EncoderOptions is the set of options that can be configured for an Encoder.
+type EncoderOptions struct {
+	// SortObjectKeys writes the members of objects in sorted key order, for
+	// reproducible documents, e.g. payload hashing, cache keys, and golden
+	// file tests. Members are buffered until their object is closed. Defaults
+	// to false, writing members in the order they are encoded.
+	SortObjectKeys bool
+
+	// FloatFormat is the format of encoded float values, such as a fixed
+	// precision, or never using scientific notation. Defaults to the
+	// shortest representation that round-trips, see encoding.FloatFormat.
+	FloatFormat encoding.FloatFormat
+}
+
 // NewEncoder returns a new JSON encoder
-func NewEncoder() *Encoder {
+func NewEncoder(optFns ...func(*EncoderOptions)) *Encoder {
 	writer := bytes.NewBuffer(nil)
 	scratch := make([]byte, 64)
 
-	return &Encoder{w: writer, Value: newValue(writer, &scratch)}
+	return &Encoder{w: writer, Value: newEncoderValue(writer, &scratch, optFns)}
+}
+
+// NewPooledEncoder returns a new JSON encoder whose buffer is retrieved from
+// the shared buffer pool. Release must be called to return the buffer to the
+// pool once the encoded bytes are no longer used.
+func NewPooledEncoder(optFns ...func(*EncoderOptions)) *Encoder {
+	writer := bufferpool.Get(0)
+	scratch := make([]byte, 64)
+
+	return &Encoder{w: writer, pooled: true, Value: newEncoderValue(writer, &scratch, optFns)}
+}
+
+func newEncoderValue(w *bytes.Buffer, scratch *[]byte, optFns []func(*EncoderOptions)) Value {
+	var o EncoderOptions
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	v := newValue(w, scratch)
+	v.sortKeys = o.SortObjectKeys
+	if o.FloatFormat != (encoding.FloatFormat{}) {
+		v.floatFormat = &o.FloatFormat
+	}
+	return v
+}
+
+// Release returns the encoder's buffer to the shared buffer pool, if the
+// encoder was created with NewPooledEncoder. The encoder, and the slice
+// returned by Bytes, must not be used after the encoder is released.
+func (e *Encoder) Release() {
+	if !e.pooled || e.w == nil {
+		return
+	}
+	bufferpool.Put(e.w)
+	e.w = nil
 }
 
 // String returns the String output of the JSON encoder
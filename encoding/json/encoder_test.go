@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/aws/smithy-go/encoding"
 	"github.com/aws/smithy-go/encoding/json"
 )
 
@@ -32,3 +33,59 @@ func TestEncoder(t *testing.T) {
 		t.Errorf("expected %s, but got %s", e, a)
 	}
 }
+
+func TestPooledEncoder(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		encoder := json.NewPooledEncoder()
+
+		object := encoder.Object()
+		object.Key("key").String("value")
+		object.Close()
+
+		if e, a := `{"key":"value"}`, encoder.String(); e != a {
+			t.Errorf("expect %v, got %v", e, a)
+		}
+		encoder.Release()
+	}
+}
+
+func TestEncoderFloatFormat(t *testing.T) {
+	cases := map[string]struct {
+		Format encoding.FloatFormat
+		Expect string
+	}{
+		"default": {
+			Expect: `{"a":1e+21,"b":[0.3333333333333333,{"c":1.5e-7}]}`,
+		},
+		"no exponent": {
+			Format: encoding.FloatFormat{DisableExponent: true},
+			Expect: `{"a":1000000000000000000000,"b":[0.3333333333333333,{"c":0.00000015}]}`,
+		},
+		"fixed precision": {
+			Format: encoding.FloatFormat{FixedPrecision: true, Precision: 3, DisableExponent: true},
+			Expect: `{"a":1000000000000000000000.000,"b":[0.333,{"c":0.000}]}`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			encoder := json.NewEncoder(func(o *json.EncoderOptions) {
+				o.FloatFormat = c.Format
+			})
+
+			object := encoder.Object()
+			object.Key("a").Double(1e21)
+			array := object.Key("b").Array()
+			array.Value().Double(1.0 / 3)
+			nested := array.Value().Object()
+			nested.Key("c").Float(1.5e-7)
+			nested.Close()
+			array.Close()
+			object.Close()
+
+			if e, a := c.Expect, encoder.String(); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
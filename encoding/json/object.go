@@ -2,6 +2,9 @@ package json
 
 import (
 	"bytes"
+	"sort"
+
+	"github.com/aws/smithy-go/encoding"
 )
 
 // Object represents the encoding of a JSON Object type
@@ -9,6 +12,18 @@ type Object struct {
 	w          *bytes.Buffer
 	writeComma bool
 	scratch    *[]byte
+
+	// sortKeys indicates if the object's members are buffered, and written
+	// in sorted key order when the object is closed.
+	sortKeys bool
+	members  []objectMember
+
+	floatFormat *encoding.FloatFormat
+}
+
+type objectMember struct {
+	key   string
+	value *bytes.Buffer
 }
 
 func newObject(w *bytes.Buffer, scratch *[]byte) *Object {
@@ -27,16 +42,44 @@ func (o *Object) writeKey(key string) {
 // Returns a Value encoder that should be used to encode
 // a JSON value type.
 func (o *Object) Key(name string) Value {
+	if o.sortKeys {
+		m := objectMember{key: name, value: bytes.NewBuffer(nil)}
+		o.members = append(o.members, m)
+
+		v := newValue(m.value, o.scratch)
+		v.sortKeys = true
+		v.floatFormat = o.floatFormat
+		return v
+	}
+
 	if o.writeComma {
 		o.w.WriteRune(comma)
 	} else {
 		o.writeComma = true
 	}
 	o.writeKey(name)
-	return newValue(o.w, o.scratch)
+
+	v := newValue(o.w, o.scratch)
+	v.floatFormat = o.floatFormat
+	return v
 }
 
-// Close encodes the end of the JSON Object
+// Close encodes the end of the JSON Object. If the encoder was created with
+// SortObjectKeys, the object's members are written sorted by key first.
 func (o *Object) Close() {
+	if o.sortKeys {
+		sort.SliceStable(o.members, func(i, j int) bool {
+			return o.members[i].key < o.members[j].key
+		})
+		for i, m := range o.members {
+			if i > 0 {
+				o.w.WriteRune(comma)
+			}
+			o.writeKey(m.key)
+			o.w.Write(m.value.Bytes())
+		}
+		o.members = nil
+	}
+
 	o.w.WriteRune(rightBrace)
 }
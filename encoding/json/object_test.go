@@ -19,3 +19,46 @@ func TestObject(t *testing.T) {
 		t.Errorf("expected %+q, but got %+q", e, a)
 	}
 }
+
+func TestObjectSortKeys(t *testing.T) {
+	cases := map[string]struct {
+		Sort   bool
+		Expect string
+	}{
+		"insertion order": {
+			Expect: `{"foo":"bar","baz":[{"b":1,"a":2}],"bar":{"z":true,"y":false}}`,
+		},
+		"sorted": {
+			Sort:   true,
+			Expect: `{"bar":{"y":false,"z":true},"baz":[{"a":2,"b":1}],"foo":"bar"}`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			encoder := NewEncoder(func(o *EncoderOptions) {
+				o.SortObjectKeys = c.Sort
+			})
+
+			object := encoder.Object()
+			object.Key("foo").String("bar")
+
+			array := object.Key("baz").Array()
+			nested := array.Value().Object()
+			nested.Key("b").Integer(1)
+			nested.Key("a").Integer(2)
+			nested.Close()
+			array.Close()
+
+			nested = object.Key("bar").Object()
+			nested.Key("z").Boolean(true)
+			nested.Key("y").Boolean(false)
+			nested.Close()
+			object.Close()
+
+			if e, a := c.Expect, encoder.String(); e != a {
+				t.Errorf("expected %+q, but got %+q", e, a)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strconv"
 
+	smithy "github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/encoding"
 )
 
@@ -14,6 +15,14 @@ import (
 type Value struct {
 	w       *bytes.Buffer
 	scratch *[]byte
+
+	// sortKeys indicates if the keys of objects nested in the Value are
+	// sorted.
+	sortKeys bool
+
+	// floatFormat is the format of floats nested in the Value, nil for the
+	// default format.
+	floatFormat *encoding.FloatFormat
 }
 
 // newValue returns a new Value encoder
@@ -58,7 +67,11 @@ func (jv Value) Double(v float64) {
 }
 
 func (jv Value) float(v float64, bits int) {
-	*jv.scratch = encoding.EncodeFloat((*jv.scratch)[:0], v, bits)
+	if jv.floatFormat != nil {
+		*jv.scratch = encoding.EncodeFloatFormat((*jv.scratch)[:0], v, bits, *jv.floatFormat)
+	} else {
+		*jv.scratch = encoding.EncodeFloat((*jv.scratch)[:0], v, bits)
+	}
 	jv.w.Write(*jv.scratch)
 }
 
@@ -80,12 +93,18 @@ func (jv Value) Write(v []byte) {
 
 // Array returns a new Array encoder
 func (jv Value) Array() *Array {
-	return newArray(jv.w, jv.scratch)
+	a := newArray(jv.w, jv.scratch)
+	a.sortKeys = jv.sortKeys
+	a.floatFormat = jv.floatFormat
+	return a
 }
 
 // Object returns a new Object encoder
 func (jv Value) Object() *Object {
-	return newObject(jv.w, jv.scratch)
+	o := newObject(jv.w, jv.scratch)
+	o.sortKeys = jv.sortKeys
+	o.floatFormat = jv.floatFormat
+	return o
 }
 
 // Null encodes a null JSON value
@@ -108,6 +127,16 @@ func (jv Value) BigDecimal(v *big.Float) {
 	jv.w.Write([]byte(v.Text('e', -1)))
 }
 
+// BigIntegerValue encodes v as a JSON number
+func (jv Value) BigIntegerValue(v smithy.BigInteger) {
+	jv.w.WriteString(v.String())
+}
+
+// BigDecimalValue encodes v as a JSON number, with all of its digits
+func (jv Value) BigDecimalValue(v smithy.BigDecimal) {
+	jv.w.WriteString(v.String())
+}
+
 // Based on encoding/json encodeByteSlice from the Go Standard Library
 // https://golang.org/src/encoding/json/encode.go
 func encodeByteSlice(w *bytes.Buffer, scratch []byte, v []byte) {
@@ -6,6 +6,8 @@ import (
 	"math/big"
 	"strconv"
 	"testing"
+
+	smithy "github.com/aws/smithy-go"
 )
 
 var (
@@ -138,6 +140,26 @@ func TestValue(t *testing.T) {
 			},
 			expected: "9223372036854775807",
 		},
+		"smithy bigInteger": {
+			setter: func(value Value) {
+				v, _ := smithy.ParseBigInteger("-123456789012345678901234567890")
+				value.BigIntegerValue(v)
+			},
+			expected: "-123456789012345678901234567890",
+		},
+		"smithy bigDecimal": {
+			setter: func(value Value) {
+				v, _ := smithy.ParseBigDecimal("0.1000000000000000000000000001")
+				value.BigDecimalValue(v)
+			},
+			expected: "0.1000000000000000000000000001",
+		},
+		"smithy bigDecimal exponent": {
+			setter: func(value Value) {
+				value.BigDecimalValue(smithy.NewBigDecimal(big.NewInt(15), 11))
+			},
+			expected: "1.5E-10",
+		},
 	}
 	scratch := make([]byte, 64)
 
@@ -0,0 +1,201 @@
+// Package multipart provides a streaming encoder for multipart request
+// bodies, RFC 2046 and RFC 7578, such as multipart/form-data uploads.
+//
+// Parts are not buffered. The encoded body is the concatenation of each
+// part's headers and the part's body reader, so file parts are streamed
+// directly from their source when the request is sent. If every part's body
+// is seekable, the encoded body is seekable, so the request can be retried.
+package multipart
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net/textproto"
+	"sort"
+	"strings"
+
+	smithyio "github.com/aws/smithy-go/io"
+)
+
+// EncoderOptions provides the configuration of an Encoder.
+type EncoderOptions struct {
+	// MediaType is the multipart media type of the body. Defaults to
+	// "multipart/form-data".
+	MediaType string
+
+	// Boundary is the boundary which separates the body's parts. Defaults to
+	// a random boundary. A fixed boundary is useful for deterministic
+	// output in tests.
+	Boundary string
+}
+
+type part struct {
+	header textproto.MIMEHeader
+	body   io.Reader
+}
+
+// Encoder builds a multipart body from a sequence of parts.
+type Encoder struct {
+	mediaType string
+	boundary  string
+	parts     []part
+}
+
+// NewEncoder returns an Encoder configured by the functional options.
+// Returns an error if the boundary option is not a valid boundary.
+func NewEncoder(optFns ...func(*EncoderOptions)) (*Encoder, error) {
+	o := EncoderOptions{
+		MediaType: "multipart/form-data",
+	}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	if len(o.Boundary) == 0 {
+		o.Boundary = randomBoundary()
+	} else if err := validateBoundary(o.Boundary); err != nil {
+		return nil, err
+	}
+
+	return &Encoder{
+		mediaType: o.MediaType,
+		boundary:  o.Boundary,
+	}, nil
+}
+
+// Boundary returns the boundary which separates the body's parts.
+func (e *Encoder) Boundary() string {
+	return e.boundary
+}
+
+// ContentType returns the value of the Content-Type header of the encoded
+// body, including the boundary parameter.
+func (e *Encoder) ContentType() string {
+	b := e.boundary
+	if strings.ContainsAny(b, `()<>@,;:\"/[]?= `) {
+		b = `"` + b + `"`
+	}
+	return e.mediaType + "; boundary=" + b
+}
+
+// AddPart adds a part with the headers and body to the encoder. The body is
+// not read until the encoded body is read.
+func (e *Encoder) AddPart(header textproto.MIMEHeader, body io.Reader) {
+	h := make(textproto.MIMEHeader, len(header))
+	for k, v := range header {
+		h[k] = append([]string(nil), v...)
+	}
+	if body == nil {
+		body = strings.NewReader("")
+	}
+	e.parts = append(e.parts, part{header: h, body: body})
+}
+
+// AddField adds a form field part with the name and value.
+func (e *Encoder) AddField(name, value string) {
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", formDisposition(name, ""))
+	e.AddPart(h, strings.NewReader(value))
+}
+
+// AddFile adds a form file part with the field name, file name, and content
+// type, streaming the file's content from body. The content type defaults
+// to "application/octet-stream".
+func (e *Encoder) AddFile(fieldName, fileName, contentType string, body io.Reader) {
+	if len(contentType) == 0 {
+		contentType = "application/octet-stream"
+	}
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", formDisposition(fieldName, fileName))
+	h.Set("Content-Type", contentType)
+	e.AddPart(h, body)
+}
+
+// Encode returns the encoded body, and its length. The length is -1 if the
+// length of any part's body cannot be determined. If every part's body is
+// an io.ReadSeeker the returned body is an io.ReadSeeker.
+//
+// The parts' bodies are read as the returned body is read, so Encode must
+// only be called once.
+func (e *Encoder) Encode() (io.Reader, int64, error) {
+	readers := make([]io.Reader, 0, 2*len(e.parts)+1)
+	for i, p := range e.parts {
+		var buf bytes.Buffer
+		if i > 0 {
+			buf.WriteString("\r\n")
+		}
+		fmt.Fprintf(&buf, "--%s\r\n", e.boundary)
+		writeHeader(&buf, p.header)
+		buf.WriteString("\r\n")
+
+		readers = append(readers, bytes.NewReader(buf.Bytes()), p.body)
+	}
+
+	closing := fmt.Sprintf("--%s--\r\n", e.boundary)
+	if len(e.parts) > 0 {
+		closing = "\r\n" + closing
+	}
+	readers = append(readers, strings.NewReader(closing))
+
+	return smithyio.JoinReaders(readers...)
+}
+
+// writeHeader writes the header's fields sorted by key, for deterministic
+// output.
+func writeHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		for _, v := range header[k] {
+			fmt.Fprintf(buf, "%s: %s\r\n", k, v)
+		}
+	}
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"", "\r", "%0D", "\n", "%0A")
+
+func formDisposition(name, fileName string) string {
+	d := `form-data; name="` + quoteEscaper.Replace(name) + `"`
+	if len(fileName) != 0 {
+		d += `; filename="` + quoteEscaper.Replace(fileName) + `"`
+	}
+	return d
+}
+
+func randomBoundary() string {
+	var b [30]byte
+	if _, err := io.ReadFull(rand.Reader, b[:]); err != nil {
+		panic(fmt.Sprintf("failed to generate multipart boundary, %v", err))
+	}
+	return fmt.Sprintf("%x", b[:])
+}
+
+// validateBoundary validates the boundary against RFC 2046 section 5.1.1.
+func validateBoundary(boundary string) error {
+	if len(boundary) < 1 || len(boundary) > 70 {
+		return fmt.Errorf("invalid multipart boundary length %d", len(boundary))
+	}
+	end := len(boundary) - 1
+	for i, c := range boundary {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9':
+			continue
+		}
+		switch c {
+		case '\'', '(', ')', '+', '_', ',', '-', '.', '/', ':', '=', '?':
+			continue
+		case ' ':
+			if i != end {
+				continue
+			}
+		}
+		return fmt.Errorf("invalid multipart boundary character %q", c)
+	}
+	return nil
+}
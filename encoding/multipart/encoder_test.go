@@ -0,0 +1,192 @@
+package multipart
+
+import (
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+func TestEncoder(t *testing.T) {
+	e, err := NewEncoder(func(o *EncoderOptions) {
+		o.Boundary = "test-boundary"
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	e.AddField("name", "value")
+	e.AddFile("file", `my "file".txt`, "text/plain", strings.NewReader("file content"))
+	e.AddPart(textproto.MIMEHeader{"X-Custom": []string{"abc"}}, strings.NewReader("custom"))
+
+	body, length, err := e.Encode()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if _, ok := body.(io.ReadSeeker); !ok {
+		t.Errorf("expect seekable body, got %T", body)
+	}
+
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := int64(len(b)), length; e != a {
+		t.Errorf("expect %v length, got %v", e, a)
+	}
+
+	expect := "--test-boundary\r\n" +
+		"Content-Disposition: form-data; name=\"name\"\r\n" +
+		"\r\n" +
+		"value\r\n" +
+		"--test-boundary\r\n" +
+		"Content-Disposition: form-data; name=\"file\"; filename=\"my \\\"file\\\".txt\"\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"file content\r\n" +
+		"--test-boundary\r\n" +
+		"X-Custom: abc\r\n" +
+		"\r\n" +
+		"custom\r\n" +
+		"--test-boundary--\r\n"
+	if e, a := expect, string(b); e != a {
+		t.Errorf("expect body\n%q\ngot\n%q", e, a)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(e.ContentType())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "multipart/form-data", mediaType; e != a {
+		t.Errorf("expect %v media type, got %v", e, a)
+	}
+
+	r := multipart.NewReader(strings.NewReader(string(b)), params["boundary"])
+	var names []string
+	for {
+		p, err := r.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		names = append(names, p.FormName())
+	}
+	if e, a := "name,file,", strings.Join(names, ","); e != a {
+		t.Errorf("expect %v parts, got %v", e, a)
+	}
+}
+
+func TestEncoder_StreamingPart(t *testing.T) {
+	e, err := NewEncoder(func(o *EncoderOptions) {
+		o.Boundary = "b"
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write([]byte("streamed"))
+		pw.Close()
+	}()
+	e.AddFile("file", "f.bin", "", pr)
+
+	body, length, err := e.Encode()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := int64(-1), length; e != a {
+		t.Errorf("expect %v length, got %v", e, a)
+	}
+
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if !strings.Contains(string(b), "Content-Type: application/octet-stream\r\n\r\nstreamed\r\n--b--\r\n") {
+		t.Errorf("expect streamed part, got %q", b)
+	}
+}
+
+func TestEncoder_Empty(t *testing.T) {
+	e, err := NewEncoder(func(o *EncoderOptions) {
+		o.Boundary = "b"
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	body, _, err := e.Encode()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	b, _ := ioutil.ReadAll(body)
+	if e, a := "--b--\r\n", string(b); e != a {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+}
+
+func TestNewEncoder_Boundary(t *testing.T) {
+	cases := map[string]struct {
+		boundary    string
+		expectErr   bool
+		contentType string
+	}{
+		"simple": {
+			boundary:    "abc123",
+			contentType: "multipart/form-data; boundary=abc123",
+		},
+		"quoted": {
+			boundary:    "abc:123",
+			contentType: `multipart/form-data; boundary="abc:123"`,
+		},
+		"too long": {
+			boundary:  strings.Repeat("a", 71),
+			expectErr: true,
+		},
+		"invalid character": {
+			boundary:  "abc\r\n",
+			expectErr: true,
+		},
+		"trailing space": {
+			boundary:  "abc ",
+			expectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, err := NewEncoder(func(o *EncoderOptions) {
+				o.Boundary = c.boundary
+			})
+			if c.expectErr {
+				if err == nil {
+					t.Fatalf("expect error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.contentType, e.ContentType(); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestNewEncoder_RandomBoundary(t *testing.T) {
+	a, _ := NewEncoder()
+	b, _ := NewEncoder()
+	if a.Boundary() == b.Boundary() {
+		t.Errorf("expect random boundaries to differ")
+	}
+	if err := validateBoundary(a.Boundary()); err != nil {
+		t.Errorf("expect valid boundary, got %v", err)
+	}
+}
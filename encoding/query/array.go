@@ -0,0 +1,48 @@
+package query
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Array represents the encoding of a list. Members are keyed by their 1
+// based index.
+type Array struct {
+	values url.Values
+	prefix string
+
+	// flat arrays omit the member name from member keys.
+	flat       bool
+	memberName string
+
+	size int
+}
+
+func newArray(values url.Values, prefix string, flat bool, memberName string) *Array {
+	// An empty array is encoded as its key with an empty value. The key is
+	// removed once the first member is encoded.
+	values.Set(prefix, "")
+
+	return &Array{
+		values:     values,
+		prefix:     prefix,
+		flat:       flat,
+		memberName: memberName,
+	}
+}
+
+// Value returns the Value of the next member of the array.
+func (a *Array) Value() Value {
+	if a.size == 0 {
+		delete(a.values, a.prefix)
+	}
+	a.size++
+
+	prefix := a.prefix
+	if !a.flat {
+		prefix = joinKey(prefix, a.memberName)
+	}
+
+	// Query list indices start at 1.
+	return newValue(a.values, joinKey(prefix, strconv.Itoa(a.size)), a.flat)
+}
@@ -0,0 +1,84 @@
+package query
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestArray(t *testing.T) {
+	cases := map[string]struct {
+		encode func(*Object)
+		expect url.Values
+	}{
+		"wrapped": {
+			encode: func(o *Object) {
+				a := o.Key("ListArg").Array("member")
+				a.Value().String("apple")
+				a.Value().String("tree")
+			},
+			expect: url.Values{
+				"ListArg.member.1": []string{"apple"},
+				"ListArg.member.2": []string{"tree"},
+			},
+		},
+		"flattened": {
+			encode: func(o *Object) {
+				a := o.FlatKey("ListArg").Array("member")
+				a.Value().String("apple")
+				a.Value().String("tree")
+			},
+			expect: url.Values{
+				"ListArg.1": []string{"apple"},
+				"ListArg.2": []string{"tree"},
+			},
+		},
+		"custom member name": {
+			encode: func(o *Object) {
+				a := o.Key("ListArg").Array("item")
+				a.Value().Integer(1)
+			},
+			expect: url.Values{
+				"ListArg.item.1": []string{"1"},
+			},
+		},
+		"empty": {
+			encode: func(o *Object) {
+				o.Key("ListArg").Array("member")
+			},
+			expect: url.Values{
+				"ListArg": []string{""},
+			},
+		},
+		"nested structures": {
+			encode: func(o *Object) {
+				a := o.Key("ListArg").Array("member")
+				a.Value().Object().Key("Name").String("a")
+				a.Value().Object().Key("Name").String("b")
+			},
+			expect: url.Values{
+				"ListArg.member.1.Name": []string{"a"},
+				"ListArg.member.2.Name": []string{"b"},
+			},
+		},
+		"nested arrays": {
+			encode: func(o *Object) {
+				a := o.Key("ListArg").Array("member")
+				a.Value().Array("member").Value().String("a")
+			},
+			expect: url.Values{
+				"ListArg.member.1.member.1": []string{"a"},
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			values := url.Values{}
+			c.encode(newObject(values, ""))
+			if e, a := c.expect, values; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package query
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Node is a node of a decoded form-encoded body. Keys of the body are split
+// on periods into a tree of nodes, so that structures, lists, and maps
+// encoded by the query protocols can be navigated by member name and index.
+//
+// A node may have both a value and members, such as an empty list followed
+// by list members.
+type Node struct {
+	value    string
+	hasValue bool
+	members  map[string]*Node
+}
+
+// Decode reads the form-encoded body from r and decodes it into a tree of
+// Nodes.
+func Decode(r io.Reader) (*Node, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read form body, %w", err)
+	}
+	return DecodeBytes(b)
+}
+
+// DecodeBytes decodes the form-encoded body into a tree of Nodes. If a key
+// occurs more than once, the last value is used.
+func DecodeBytes(b []byte) (*Node, error) {
+	values, err := url.ParseQuery(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode form body, %w", err)
+	}
+
+	root := &Node{}
+	for key, vs := range values {
+		if len(key) == 0 {
+			continue
+		}
+		n := root
+		for _, name := range strings.Split(key, ".") {
+			n = n.member(name)
+		}
+		n.value = vs[len(vs)-1]
+		n.hasValue = true
+	}
+	return root, nil
+}
+
+func (n *Node) member(name string) *Node {
+	if n.members == nil {
+		n.members = map[string]*Node{}
+	}
+	m, ok := n.members[name]
+	if !ok {
+		m = &Node{}
+		n.members[name] = m
+	}
+	return m
+}
+
+// Value returns the node's value, and whether the node has a value.
+func (n *Node) Value() (string, bool) {
+	return n.value, n.hasValue
+}
+
+// Member returns the member of the node with the name.
+func (n *Node) Member(name string) (*Node, bool) {
+	m, ok := n.members[name]
+	return m, ok
+}
+
+// Get returns the node at the period separated path relative to the node,
+// e.g. "Attributes.entry.1.key".
+func (n *Node) Get(path string) (*Node, bool) {
+	for _, name := range strings.Split(path, ".") {
+		var ok bool
+		if n, ok = n.Member(name); !ok {
+			return nil, false
+		}
+	}
+	return n, true
+}
+
+// Names returns the sorted names of the node's members. Numeric names are
+// sorted by their value, before other names.
+func (n *Node) Names() []string {
+	names := make([]string, 0, len(n.members))
+	for name := range n.members {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, aErr := strconv.Atoi(names[i])
+		b, bErr := strconv.Atoi(names[j])
+		switch {
+		case aErr == nil && bErr == nil:
+			return a < b
+		case aErr == nil || bErr == nil:
+			return aErr == nil
+		default:
+			return names[i] < names[j]
+		}
+	})
+	return names
+}
+
+// List returns the members of the list encoded at the node, in index order.
+// The member name wraps the list's members, usually "member", and is empty
+// for flattened lists.
+func (n *Node) List(memberName string) []*Node {
+	if len(memberName) != 0 {
+		var ok bool
+		if n, ok = n.Member(memberName); !ok {
+			return nil
+		}
+	}
+
+	var list []*Node
+	for _, name := range n.Names() {
+		if _, err := strconv.Atoi(name); err != nil {
+			break
+		}
+		list = append(list, n.members[name])
+	}
+	return list
+}
+
+// Map returns the entries of the map encoded at the node, keyed by the
+// value of each entry's key member. Entries are wrapped with "entry" unless
+// the map is flattened.
+func (n *Node) Map(keyName, valueName string, flat bool) map[string]*Node {
+	memberName := "entry"
+	if flat {
+		memberName = ""
+	}
+
+	entries := map[string]*Node{}
+	for _, entry := range n.List(memberName) {
+		k, ok := entry.Member(keyName)
+		if !ok {
+			continue
+		}
+		key, _ := k.Value()
+		v, ok := entry.Member(valueName)
+		if !ok {
+			v = &Node{}
+		}
+		entries[key] = v
+	}
+	return entries
+}
+
+// Equal returns whether the node and other have the same values and
+// members.
+func (n *Node) Equal(other *Node) bool {
+	if n == nil || other == nil {
+		return n == other
+	}
+	if n.hasValue != other.hasValue || n.value != other.value {
+		return false
+	}
+	if len(n.members) != len(other.members) {
+		return false
+	}
+	for name, m := range n.members {
+		om, ok := other.members[name]
+		if !ok || !m.Equal(om) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the form-encoded representation of the node and its
+// members, sorted by key.
+func (n *Node) String() string {
+	values := url.Values{}
+	n.flatten("", values)
+	return values.Encode()
+}
+
+func (n *Node) flatten(key string, values url.Values) {
+	if n.hasValue {
+		values.Set(key, n.value)
+	}
+	for name, m := range n.members {
+		m.flatten(joinKey(key, name), values)
+	}
+}
@@ -0,0 +1,139 @@
+package query
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	body := "Action=SetQueueAttributes&Version=2012-11-05" +
+		"&Attribute.entry.1.key=Policy&Attribute.entry.1.value=%7B%7D" +
+		"&Attribute.entry.2.key=DelaySeconds&Attribute.entry.2.value=10" +
+		"&Tags.member.2=b&Tags.member.1=a&Tags.member.10=j" +
+		"&Flat.1.Name=x&Flat.2.Name=y" +
+		"&Empty="
+
+	root, err := Decode(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	action, ok := root.Get("Action")
+	if !ok {
+		t.Fatalf("expect Action member")
+	}
+	if v, _ := action.Value(); v != "SetQueueAttributes" {
+		t.Errorf("expect action value, got %v", v)
+	}
+
+	if _, ok := root.Get("Attribute.entry.3"); ok {
+		t.Errorf("expect no third entry")
+	}
+
+	attrs := root.member("Attribute").Map("key", "value", false)
+	if e, a := 2, len(attrs); e != a {
+		t.Fatalf("expect %v entries, got %v", e, a)
+	}
+	if v, _ := attrs["Policy"].Value(); v != "{}" {
+		t.Errorf("expect policy value, got %v", v)
+	}
+
+	tags := root.member("Tags").List("member")
+	var actual []string
+	for _, tag := range tags {
+		v, _ := tag.Value()
+		actual = append(actual, v)
+	}
+	if e, a := "a,b,j", strings.Join(actual, ","); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	flat := root.member("Flat").List("")
+	if e, a := 2, len(flat); e != a {
+		t.Fatalf("expect %v flat members, got %v", e, a)
+	}
+	if v, _ := flat[1].member("Name").Value(); v != "y" {
+		t.Errorf("expect y, got %v", v)
+	}
+
+	empty, _ := root.Member("Empty")
+	if v, ok := empty.Value(); !ok || len(v) != 0 {
+		t.Errorf("expect empty value, got %q, %v", v, ok)
+	}
+	if e, a := 0, len(empty.List("member")); e != a {
+		t.Errorf("expect %v members, got %v", e, a)
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	if _, err := DecodeBytes([]byte("a=%zz")); err == nil {
+		t.Errorf("expect error for invalid escape")
+	}
+}
+
+func TestNode_Equal(t *testing.T) {
+	cases := map[string]struct {
+		a, b   string
+		expect bool
+	}{
+		"reordered": {
+			a:      "A=1&B.member.1=x&B.member.2=y",
+			b:      "B.member.2=y&A=1&B.member.1=x",
+			expect: true,
+		},
+		"different value": {
+			a: "A=1",
+			b: "A=2",
+		},
+		"missing member": {
+			a: "A=1&B=2",
+			b: "A=1",
+		},
+		"value and members": {
+			a: "A=&A.1=x",
+			b: "A.1=x",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			a, _ := DecodeBytes([]byte(c.a))
+			b, _ := DecodeBytes([]byte(c.b))
+			if e, act := c.expect, a.Equal(b); e != act {
+				t.Errorf("expect equal %v, got %v", e, act)
+			}
+		})
+	}
+}
+
+func TestDecode_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	o := e.Object()
+	o.Key("Action").String("Example")
+	l := o.Key("List").Array("member")
+	l.Value().String("a b")
+	l.Value().String("c&d")
+	m := o.FlatKey("Map").Map("key", "value")
+	m.Key("k").Integer(1)
+	if err := e.Encode(); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	root, err := DecodeBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := buf.String(), root.String(); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	list := root.member("List").List("member")
+	if v, _ := list[1].Value(); v != "c&d" {
+		t.Errorf("expect c&d, got %v", v)
+	}
+	if v, _ := root.member("Map").Map("key", "value", true)["k"].Value(); v != "1" {
+		t.Errorf("expect 1, got %v", v)
+	}
+}
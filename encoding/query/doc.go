@@ -0,0 +1,44 @@
+/*
+Package query provides the encoder and decoder for the awsQuery and ec2Query protocols,
+which serialize operation input as an application/x-www-form-urlencoded
+request body.
+
+Members are encoded as key value pairs, where the key is the path of the
+member from the top level object, with each path segment separated by a
+period.
+
+	Action=CreateQueue&QueueName=example&Attribute.1.Name=Policy
+
+# Array
+
+Arrays are encoded with a 1 based index appended to the key of the array.
+By default the array's members are wrapped with the member's location name,
+usually "member". Flattened arrays omit the member name.
+
+	Wrapped:   ListArg.member.1=apple&ListArg.member.2=tree
+	Flattened: ListArg.1=apple&ListArg.2=tree
+
+An empty array is encoded as the array's key with an empty value.
+
+# Map
+
+Maps are encoded as entries of a 1 based index, each with a key and value
+member. By default entries are wrapped with "entry". Flattened maps omit
+the entry wrapper.
+
+	Wrapped:   MapArg.entry.1.key=k&MapArg.entry.1.value=v
+	Flattened: MapArg.1.key=k&MapArg.1.value=v
+
+The encoded body is sorted by key, for consistent output.
+
+# Decoding
+
+Decode parses a form-encoded body into a tree of Nodes, split on the periods
+of each key, so that decoded structures, lists, and maps can be navigated
+and compared structurally.
+
+	root, err := query.Decode(r)
+	tags, _ := root.Get("Tags")
+	for _, tag := range tags.List("member") { ... }
+*/
+package query
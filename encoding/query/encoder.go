@@ -0,0 +1,33 @@
+package query
+
+import (
+	"io"
+	"net/url"
+)
+
+// Encoder is a query protocol encoder for a form-encoded request body.
+type Encoder struct {
+	writer io.Writer
+	values url.Values
+}
+
+// NewEncoder returns a new Encoder which writes its encoded body to the
+// writer when Encode is called.
+func NewEncoder(writer io.Writer) *Encoder {
+	return &Encoder{
+		writer: writer,
+		values: url.Values{},
+	}
+}
+
+// Encode writes the encoded key value pairs to the encoder's writer, sorted
+// by key.
+func (e Encoder) Encode() error {
+	_, err := io.WriteString(e.writer, e.values.Encode())
+	return err
+}
+
+// Object returns the top level object of the query body.
+func (e *Encoder) Object() *Object {
+	return newObject(e.values, "")
+}
@@ -0,0 +1,41 @@
+package query
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+
+	o := e.Object()
+	o.Key("Action").String("CreateQueue")
+	o.Key("Version").String("2012-11-05")
+	o.Key("QueueName").String("a queue&name")
+
+	nested := o.Key("Tags").Object()
+	nested.Key("Name").String("value")
+
+	if err := e.Encode(); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := "Action=CreateQueue&QueueName=a+queue%26name&Tags.Name=value&Version=2012-11-05"
+	if e, a := expect, buf.String(); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestEncoder_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	e.Object()
+
+	if err := e.Encode(); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "", buf.String(); e != a {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+}
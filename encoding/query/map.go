@@ -0,0 +1,47 @@
+package query
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Map represents the encoding of a map. Entries are keyed by their 1 based
+// index, each with a key and value member.
+type Map struct {
+	values url.Values
+	prefix string
+
+	// flat maps omit the "entry" wrapper from entry keys.
+	flat              bool
+	keyLocationName   string
+	valueLocationName string
+
+	size int
+}
+
+func newMap(values url.Values, prefix string, flat bool, keyLocationName, valueLocationName string) *Map {
+	return &Map{
+		values:            values,
+		prefix:            prefix,
+		flat:              flat,
+		keyLocationName:   keyLocationName,
+		valueLocationName: valueLocationName,
+	}
+}
+
+// Key encodes the key of the next map entry, and returns the Value of the
+// entry's value.
+//
+// Map keys should be encoded in sorted order for consistent output.
+func (m *Map) Key(name string) Value {
+	m.size++
+
+	prefix := m.prefix
+	if !m.flat {
+		prefix = joinKey(prefix, "entry")
+	}
+	prefix = joinKey(prefix, strconv.Itoa(m.size))
+
+	m.values.Set(joinKey(prefix, m.keyLocationName), name)
+	return newValue(m.values, joinKey(prefix, m.valueLocationName), m.flat)
+}
@@ -0,0 +1,68 @@
+package query
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	cases := map[string]struct {
+		encode func(*Object)
+		expect url.Values
+	}{
+		"wrapped": {
+			encode: func(o *Object) {
+				m := o.Key("MapArg").Map("key", "value")
+				m.Key("abc").String("123")
+				m.Key("def").String("456")
+			},
+			expect: url.Values{
+				"MapArg.entry.1.key":   []string{"abc"},
+				"MapArg.entry.1.value": []string{"123"},
+				"MapArg.entry.2.key":   []string{"def"},
+				"MapArg.entry.2.value": []string{"456"},
+			},
+		},
+		"flattened": {
+			encode: func(o *Object) {
+				m := o.FlatKey("MapArg").Map("key", "value")
+				m.Key("abc").String("123")
+			},
+			expect: url.Values{
+				"MapArg.1.key":   []string{"abc"},
+				"MapArg.1.value": []string{"123"},
+			},
+		},
+		"custom location names": {
+			encode: func(o *Object) {
+				m := o.Key("Attributes").Map("Name", "Value")
+				m.Key("Policy").String("{}")
+			},
+			expect: url.Values{
+				"Attributes.entry.1.Name":  []string{"Policy"},
+				"Attributes.entry.1.Value": []string{"{}"},
+			},
+		},
+		"nested structure value": {
+			encode: func(o *Object) {
+				m := o.Key("MapArg").Map("key", "value")
+				m.Key("abc").Object().Key("Name").String("a")
+			},
+			expect: url.Values{
+				"MapArg.entry.1.key":        []string{"abc"},
+				"MapArg.entry.1.value.Name": []string{"a"},
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			values := url.Values{}
+			c.encode(newObject(values, ""))
+			if e, a := c.expect, values; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
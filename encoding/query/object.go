@@ -0,0 +1,42 @@
+package query
+
+import (
+	"net/url"
+)
+
+// Object represents the encoding of a structure, whose members are keyed
+// relative to the object's prefix.
+type Object struct {
+	values url.Values
+	prefix string
+}
+
+func newObject(values url.Values, prefix string) *Object {
+	return &Object{
+		values: values,
+		prefix: prefix,
+	}
+}
+
+// Key returns the Value of the member with the name.
+func (o *Object) Key(name string) Value {
+	return o.key(name, false)
+}
+
+// FlatKey returns the Value of the member with the name, whose arrays and
+// maps are flattened. Used for members with the xmlFlattened trait, and for
+// all lists of the ec2Query protocol.
+func (o *Object) FlatKey(name string) Value {
+	return o.key(name, true)
+}
+
+func (o *Object) key(name string, flat bool) Value {
+	return newValue(o.values, joinKey(o.prefix, name), flat)
+}
+
+func joinKey(prefix, name string) string {
+	if len(prefix) == 0 {
+		return name
+	}
+	return prefix + "." + name
+}
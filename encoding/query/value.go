@@ -0,0 +1,131 @@
+package query
+
+import (
+	"encoding/base64"
+	"math"
+	"math/big"
+	"net/url"
+	"strconv"
+	"time"
+
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+// Value represents a query value, keyed by its path from the top level
+// object.
+type Value struct {
+	values url.Values
+	key    string
+	flat   bool
+}
+
+func newValue(values url.Values, key string, flat bool) Value {
+	return Value{
+		values: values,
+		key:    key,
+		flat:   flat,
+	}
+}
+
+// Array returns a new Array encoder. Members of a wrapped array are keyed
+// with the location name, usually "member".
+func (qv Value) Array(locationName string) *Array {
+	return newArray(qv.values, qv.key, qv.flat, locationName)
+}
+
+// Object returns a new Object encoder for the members of a structure.
+func (qv Value) Object() *Object {
+	return newObject(qv.values, qv.key)
+}
+
+// Map returns a new Map encoder, whose entries have key and value members
+// with the location names provided, usually "key" and "value".
+func (qv Value) Map(keyLocationName, valueLocationName string) *Map {
+	return newMap(qv.values, qv.key, qv.flat, keyLocationName, valueLocationName)
+}
+
+// String encodes v as a query string value.
+func (qv Value) String(v string) {
+	qv.values.Set(qv.key, v)
+}
+
+// Byte encodes v as a query number value.
+func (qv Value) Byte(v int8) {
+	qv.Long(int64(v))
+}
+
+// Short encodes v as a query number value.
+func (qv Value) Short(v int16) {
+	qv.Long(int64(v))
+}
+
+// Integer encodes v as a query number value.
+func (qv Value) Integer(v int32) {
+	qv.Long(int64(v))
+}
+
+// Long encodes v as a query number value.
+func (qv Value) Long(v int64) {
+	qv.values.Set(qv.key, strconv.FormatInt(v, 10))
+}
+
+// Float encodes v as a query number value.
+func (qv Value) Float(v float32) {
+	qv.float(float64(v), 32)
+}
+
+// Double encodes v as a query number value.
+func (qv Value) Double(v float64) {
+	qv.float(v, 64)
+}
+
+func (qv Value) float(v float64, bits int) {
+	var s string
+	switch {
+	case math.IsNaN(v):
+		s = "NaN"
+	case math.IsInf(v, 1):
+		s = "Infinity"
+	case math.IsInf(v, -1):
+		s = "-Infinity"
+	default:
+		s = strconv.FormatFloat(v, 'f', -1, bits)
+	}
+	qv.values.Set(qv.key, s)
+}
+
+// Boolean encodes v as a query boolean value.
+func (qv Value) Boolean(v bool) {
+	qv.values.Set(qv.key, strconv.FormatBool(v))
+}
+
+// Base64EncodeBytes encodes v as a base64 query string value.
+func (qv Value) Base64EncodeBytes(v []byte) {
+	qv.values.Set(qv.key, base64.StdEncoding.EncodeToString(v))
+}
+
+// BigInteger encodes v as a query number value.
+func (qv Value) BigInteger(v *big.Int) {
+	qv.values.Set(qv.key, v.String())
+}
+
+// BigDecimal encodes v as a query number value.
+func (qv Value) BigDecimal(v *big.Float) {
+	if i, accuracy := v.Int64(); accuracy == big.Exact {
+		qv.Long(i)
+		return
+	}
+	qv.values.Set(qv.key, v.Text('e', -1))
+}
+
+// Timestamp encodes v as a query timestamp value in the format, which is
+// date-time for query protocols unless the member has a timestampFormat
+// trait.
+func (qv Value) Timestamp(v time.Time, format smithytime.Format) error {
+	s, err := smithytime.FormatTimestamp(format, v)
+	if err != nil {
+		return err
+	}
+	qv.values.Set(qv.key, s)
+	return nil
+}
@@ -0,0 +1,70 @@
+package query
+
+import (
+	"math"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+func TestValue(t *testing.T) {
+	cases := map[string]struct {
+		encode func(Value)
+		expect string
+	}{
+		"string":        {func(v Value) { v.String("abc") }, "abc"},
+		"byte":          {func(v Value) { v.Byte(-1) }, "-1"},
+		"short":         {func(v Value) { v.Short(256) }, "256"},
+		"integer":       {func(v Value) { v.Integer(123) }, "123"},
+		"long":          {func(v Value) { v.Long(math.MaxInt64) }, "9223372036854775807"},
+		"float":         {func(v Value) { v.Float(1.5) }, "1.5"},
+		"double":        {func(v Value) { v.Double(1e21) }, "1000000000000000000000"},
+		"NaN":           {func(v Value) { v.Double(math.NaN()) }, "NaN"},
+		"infinity":      {func(v Value) { v.Double(math.Inf(1)) }, "Infinity"},
+		"neg infinity":  {func(v Value) { v.Float(float32(math.Inf(-1))) }, "-Infinity"},
+		"boolean":       {func(v Value) { v.Boolean(true) }, "true"},
+		"blob":          {func(v Value) { v.Base64EncodeBytes([]byte("abc")) }, "YWJj"},
+		"big integer":   {func(v Value) { v.BigInteger(new(big.Int).Lsh(big.NewInt(1), 70)) }, "1180591620717411303424"},
+		"big decimal":   {func(v Value) { v.BigDecimal(big.NewFloat(1.25)) }, "1.25e+00"},
+		"exact decimal": {func(v Value) { v.BigDecimal(big.NewFloat(42)) }, "42"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			values := url.Values{}
+			c.encode(newValue(values, "Key", false))
+			if e, a := c.expect, values.Get("Key"); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestValue_Timestamp(t *testing.T) {
+	ts := time.Date(2014, 4, 29, 18, 30, 38, 0, time.UTC)
+
+	cases := map[smithytime.Format]string{
+		smithytime.DateTime:     "2014-04-29T18:30:38Z",
+		smithytime.HTTPDate:     "Tue, 29 Apr 2014 18:30:38 GMT",
+		smithytime.EpochSeconds: "1398796238",
+	}
+
+	for format, expect := range cases {
+		t.Run(string(format), func(t *testing.T) {
+			values := url.Values{}
+			if err := newValue(values, "Key", false).Timestamp(ts, format); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := expect, values.Get("Key"); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+
+	if err := newValue(url.Values{}, "Key", false).Timestamp(ts, "unknown"); err == nil {
+		t.Errorf("expect error for unknown format")
+	}
+}
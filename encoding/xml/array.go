@@ -1,5 +1,7 @@
 package xml
 
+import "github.com/aws/smithy-go/encoding"
+
 // arrayMemberWrapper is the default member wrapper tag name for XML Array type
 var arrayMemberWrapper = StartElement{
 	Name: Name{Local: "member"},
@@ -15,6 +17,12 @@ type Array struct {
 
 	// isFlattened indicates if the array is a flattened array.
 	isFlattened bool
+
+	// sortMapEntries indicates if the entries of maps nested in the array's
+	// members are sorted by key.
+	sortMapEntries bool
+
+	floatFormat *encoding.FloatFormat
 }
 
 // newArray returns an array encoder.
@@ -45,5 +53,21 @@ func newArray(w writer, scratch *[]byte, memberStartElement StartElement, arrayS
 func (a *Array) Member() Value {
 	v := newValue(a.w, a.scratch, a.memberStartElement)
 	v.isFlattened = a.isFlattened
+	v.sortMapEntries = a.sortMapEntries
+	v.floatFormat = a.floatFormat
+	return v
+}
+
+// MemberWithAttributes adds a new member to the XML array, whose member
+// wrapper element carries the attributes in addition to the wrapper's own.
+// It returns a Value encoder.
+//
+// A wrapped array member with attribute `id="1"` is represented as
+// `<member id="1">value1</member>`.
+func (a *Array) MemberWithAttributes(attrs ...Attr) Value {
+	v := newValue(a.w, a.scratch, a.memberStartElement.WithAttributes(attrs...))
+	v.isFlattened = a.isFlattened
+	v.sortMapEntries = a.sortMapEntries
+	v.floatFormat = a.floatFormat
 	return v
 }
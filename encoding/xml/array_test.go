@@ -50,3 +50,37 @@ func TestFlattenedArray(t *testing.T) {
 		t.Errorf("expected %+q, but got %+q", e, a)
 	}
 }
+
+func TestArrayMemberWithAttributes(t *testing.T) {
+	cases := map[string]struct {
+		Flattened bool
+		Expect    string
+	}{
+		"wrapped": {
+			Expect: `<member id="1">bar</member><member>baz</member>`,
+		},
+		"flattened": {
+			Flattened: true,
+			Expect:    `<array xmlns="https://example.com" id="1">bar</array><array xmlns="https://example.com">baz</array>`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			buffer := bytes.NewBuffer(nil)
+			scratch := make([]byte, 64)
+
+			root := StartElement{
+				Name: Name{Local: "array"},
+				Attr: []Attr{NewNamespaceAttribute("", "https://example.com")},
+			}
+			a := newArray(buffer, &scratch, arrayMemberWrapper, root, c.Flattened)
+			a.MemberWithAttributes(NewAttribute("id", "1")).String("bar")
+			a.Member().String("baz")
+
+			if e, a := c.Expect, buffer.String(); e != a {
+				t.Errorf("expected %+q, but got %+q", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package xml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// Default limits of DecodeDocument.
+const (
+	DefaultDecodeMaxBytes  = 1 << 20
+	DefaultDecodeMaxDepth  = 64
+	DefaultDecodeMaxTokens = 100000
+)
+
+// DecodeLimits bounds the resources used by DecodeDocument. A zero limit
+// uses its default.
+type DecodeLimits struct {
+	// MaxBytes is the maximum size of the document. Defaults to
+	// DefaultDecodeMaxBytes.
+	MaxBytes int
+
+	// MaxDepth is the maximum nesting depth of elements. Defaults to
+	// DefaultDecodeMaxDepth.
+	MaxDepth int
+
+	// MaxTokens is the maximum number of tokens of the document, including
+	// character data, and comments. Defaults to DefaultDecodeMaxTokens.
+	MaxTokens int
+}
+
+func (l DecodeLimits) withDefaults() DecodeLimits {
+	if l.MaxBytes == 0 {
+		l.MaxBytes = DefaultDecodeMaxBytes
+	}
+	if l.MaxDepth == 0 {
+		l.MaxDepth = DefaultDecodeMaxDepth
+	}
+	if l.MaxTokens == 0 {
+		l.MaxTokens = DefaultDecodeMaxTokens
+	}
+	return l
+}
+
+// DecodeLimitError is returned by DecodeDocument when the document exceeds
+// one of its DecodeLimits.
+type DecodeLimitError struct {
+	// Limit is the name of the limit exceeded, e.g. "MaxDepth".
+	Limit string
+	Max   int
+}
+
+func (e *DecodeLimitError) Error() string {
+	return fmt.Sprintf("XML document exceeds %s limit of %d", e.Limit, e.Max)
+}
+
+// DecodeDocument decodes every element of the XML document with a
+// NodeDecoder, the same way deserializers walk a response document,
+// discarding the decoded values. Returns an error if the document is not
+// well formed, has more than one root element, or a *DecodeLimitError if the
+// document exceeds the limits, bounding the memory used to decode untrusted
+// documents.
+//
+// DecodeDocument uses no global state, and is suitable as the target of
+// fuzz tests of protocols decoding XML documents.
+func DecodeDocument(data []byte, limits DecodeLimits) error {
+	limits = limits.withDefaults()
+	if len(data) > limits.MaxBytes {
+		return &DecodeLimitError{Limit: "MaxBytes", Max: limits.MaxBytes}
+	}
+
+	decoder := xml.NewTokenDecoder(&limitTokenReader{
+		reader: xml.NewDecoder(bytes.NewReader(data)),
+		limits: limits,
+	})
+
+	root, err := FetchRootElement(decoder)
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+	if err := decodeNode(WrapNodeDecoder(decoder, root)); err != nil {
+		return err
+	}
+
+	for {
+		t, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := t.(xml.StartElement); ok {
+			return fmt.Errorf("invalid XML, unexpected element after root element")
+		}
+	}
+}
+
+func decodeNode(d NodeDecoder) error {
+	for {
+		t, done, err := d.Token()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		if err := decodeNode(WrapNodeDecoder(d.Decoder, t)); err != nil {
+			return err
+		}
+	}
+}
+
+// limitTokenReader enforces the limits of the tokens read from the
+// underlying decoder.
+type limitTokenReader struct {
+	reader *xml.Decoder
+	limits DecodeLimits
+	tokens int
+	depth  int
+}
+
+func (r *limitTokenReader) Token() (xml.Token, error) {
+	r.tokens++
+	if r.tokens > r.limits.MaxTokens {
+		return nil, &DecodeLimitError{Limit: "MaxTokens", Max: r.limits.MaxTokens}
+	}
+
+	t, err := r.reader.RawToken()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.(type) {
+	case xml.StartElement:
+		if r.depth++; r.depth > r.limits.MaxDepth {
+			return nil, &DecodeLimitError{Limit: "MaxDepth", Max: r.limits.MaxDepth}
+		}
+	case xml.EndElement:
+		r.depth--
+	}
+	return t, nil
+}
@@ -0,0 +1,75 @@
+package xml
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeDocument(t *testing.T) {
+	cases := map[string]struct {
+		Data        string
+		Limits      DecodeLimits
+		ExpectErr   bool
+		ExpectLimit string
+	}{
+		"document": {
+			Data: `<?xml version="1.0"?><Root xmlns="https://example.com/"><!-- comment --><a>1</a><b x="y"/><c><d>é</d></c></Root>`,
+		},
+		"namespaced attributes": {
+			Data: `<Root xmlns:n="https://example.com/"><a n:x="1">v</a></Root>`,
+		},
+		"empty":             {Data: ``, ExpectErr: true},
+		"multiple roots":    {Data: `<a/><b/>`, ExpectErr: true},
+		"mismatched close":  {Data: `<a><b></a>`, ExpectErr: true},
+		"unterminated root": {Data: `<a><b/>`, ExpectErr: true},
+		"max bytes": {
+			Data:        `<a><b/></a>`,
+			Limits:      DecodeLimits{MaxBytes: 4},
+			ExpectLimit: "MaxBytes",
+		},
+		"max depth": {
+			Data:        `<a><b><c/></b></a>`,
+			Limits:      DecodeLimits{MaxDepth: 2},
+			ExpectLimit: "MaxDepth",
+		},
+		"max tokens": {
+			Data:        `<a><b/><c/></a>`,
+			Limits:      DecodeLimits{MaxTokens: 3},
+			ExpectLimit: "MaxTokens",
+		},
+		"default max depth": {
+			Data:        strings.Repeat("<a>", DefaultDecodeMaxDepth+1) + strings.Repeat("</a>", DefaultDecodeMaxDepth+1),
+			ExpectLimit: "MaxDepth",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := DecodeDocument([]byte(c.Data), c.Limits)
+			if len(c.ExpectLimit) != 0 {
+				var limitErr *DecodeLimitError
+				if !errors.As(err, &limitErr) {
+					t.Fatalf("expect %T error, got %v", limitErr, err)
+				}
+				if e, a := c.ExpectLimit, limitErr.Limit; e != a {
+					t.Errorf("expect %v limit, got %v", e, a)
+				}
+				return
+			}
+			if c.ExpectErr != (err != nil) {
+				t.Errorf("expect error %v, got %v", c.ExpectErr, err)
+			}
+		})
+	}
+}
+
+func FuzzDecodeDocument(f *testing.F) {
+	f.Add([]byte(`<?xml version="1.0"?><Root xmlns="https://example.com/"><a>1</a><b x="y"/></Root>`))
+	f.Add([]byte(`<ErrorResponse><Error><Code>Throttling</Code><Message>slow down</Message></Error></ErrorResponse>`))
+	f.Add([]byte(`<Root xmlns:n="https://example.com/"><a n:x="1">&lt;v&gt;</a></Root>`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		DecodeDocument(data, DecodeLimits{MaxBytes: 4096, MaxDepth: 16, MaxTokens: 1024})
+	})
+}
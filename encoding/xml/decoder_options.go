@@ -0,0 +1,134 @@
+package xml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// UnknownElementMode is how a NodeDecoder handles elements which do not
+// match a member of the node being decoded.
+type UnknownElementMode int
+
+// Modes of handling unknown elements.
+const (
+	// IgnoreUnknownElements skips unknown elements. This is the default, as
+	// services frequently add members that clients do not yet model.
+	IgnoreUnknownElements UnknownElementMode = iota
+
+	// DisallowUnknownElements fails decoding with an *UnknownElementError.
+	DisallowUnknownElements
+
+	// CollectUnknownElements skips unknown elements, collecting their raw
+	// content, retrieved with the NodeDecoder's UnknownElements.
+	CollectUnknownElements
+)
+
+// DecoderOptions provides the tolerance options of a NodeDecoder.
+type DecoderOptions struct {
+	// UnknownElements is how elements which do not match a member of the
+	// node are handled. Defaults to IgnoreUnknownElements.
+	UnknownElements UnknownElementMode
+
+	// StripNamespaces matches member names by their local name, ignoring the
+	// namespace prefix of member names, e.g. "xsi:type" matches a "type"
+	// element regardless of its namespace.
+	StripNamespaces bool
+}
+
+// UnknownElementError is returned by a NodeDecoder which disallows unknown
+// elements when an element does not match a member of the node.
+type UnknownElementError struct {
+	// Parent is the name of the node being decoded.
+	Parent xml.Name
+
+	// Name is the name of the unknown element.
+	Name xml.Name
+}
+
+func (e *UnknownElementError) Error() string {
+	return fmt.Sprintf("unknown element %s in %s", e.Name.Local, e.Parent.Local)
+}
+
+// UnknownElement is the raw content of an unknown element collected by a
+// NodeDecoder.
+type UnknownElement struct {
+	// Name is the name of the element.
+	Name xml.Name
+
+	// Attr are the attributes of the element.
+	Attr []xml.Attr
+
+	// InnerXML is the raw XML content of the element.
+	InnerXML []byte
+}
+
+// MatchName returns whether the element is the member of the name. Names
+// are matched case insensitively by the element's local name. If the
+// decoder strips namespaces, the namespace prefix of the name is ignored.
+func (d NodeDecoder) MatchName(t xml.StartElement, name string) bool {
+	if d.options.StripNamespaces {
+		if i := strings.LastIndex(name, ":"); i != -1 {
+			name = name[i+1:]
+		}
+		return strings.EqualFold(name, t.Name.Local)
+	}
+	if strings.EqualFold(name, t.Name.Local) {
+		return true
+	}
+	return len(t.Name.Space) != 0 && strings.EqualFold(name, t.Name.Space+":"+t.Name.Local)
+}
+
+// HandleUnknown handles the element which did not match a member of the
+// node being decoded, per the decoder's UnknownElementMode. The element is
+// skipped, collected, or an *UnknownElementError is returned.
+func (d NodeDecoder) HandleUnknown(t xml.StartElement) error {
+	switch d.options.UnknownElements {
+	case DisallowUnknownElements:
+		return &UnknownElementError{Parent: d.StartEl.Name, Name: t.Name}
+	case CollectUnknownElements:
+		var raw struct {
+			InnerXML []byte `xml:",innerxml"`
+		}
+		if err := d.Decoder.DecodeElement(&raw, &t); err != nil {
+			return err
+		}
+		if d.unknown != nil {
+			*d.unknown = append(*d.unknown, UnknownElement{
+				Name:     t.Name,
+				Attr:     t.Attr,
+				InnerXML: raw.InnerXML,
+			})
+		}
+		return nil
+	default:
+		return d.Decoder.Skip()
+	}
+}
+
+// UnknownElements returns the unknown elements of the node collected by a
+// decoder which collects unknown elements.
+func (d NodeDecoder) UnknownElements() []UnknownElement {
+	if d.unknown == nil {
+		return nil
+	}
+	return *d.unknown
+}
+
+// Child returns the decoder of the member element of the node, with the
+// decoder's options.
+func (d NodeDecoder) Child(t xml.StartElement) NodeDecoder {
+	return newNodeDecoder(d.Decoder, t, d.options)
+}
+
+func newNodeDecoder(decoder *xml.Decoder, startEl xml.StartElement, o DecoderOptions) NodeDecoder {
+	d := NodeDecoder{
+		Decoder: decoder,
+		StartEl: startEl,
+		options: o,
+	}
+	if o.UnknownElements == CollectUnknownElements {
+		d.unknown = &[]UnknownElement{}
+	}
+	return d
+}
@@ -0,0 +1,134 @@
+package xml
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type mockDecodeShape struct {
+	Name  string
+	Type  string
+	Inner string
+}
+
+// decodeMockShape decodes the members of the node the way generated
+// deserializers do.
+func decodeMockShape(decoder NodeDecoder) (mockDecodeShape, error) {
+	var v mockDecodeShape
+	for {
+		t, done, err := decoder.Token()
+		if err != nil {
+			return v, err
+		}
+		if done {
+			return v, nil
+		}
+		switch {
+		case decoder.MatchName(t, "Name"):
+			b, err := decoder.Child(t).Value()
+			if err != nil {
+				return v, err
+			}
+			v.Name = string(b)
+		case decoder.MatchName(t, "xsi:type"):
+			b, err := decoder.Child(t).Value()
+			if err != nil {
+				return v, err
+			}
+			v.Type = string(b)
+		case decoder.MatchName(t, "Inner"):
+			inner, err := decodeMockShape(decoder.Child(t))
+			if err != nil {
+				return v, err
+			}
+			v.Inner = inner.Name
+		default:
+			if err := decoder.HandleUnknown(t); err != nil {
+				return v, err
+			}
+		}
+	}
+}
+
+func TestNodeDecoder_Options(t *testing.T) {
+	const doc = `<Shape xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+		`<Name>abc</Name>` +
+		`<Added><Nested>1</Nested></Added>` +
+		`<xsi:type>Canonical</xsi:type>` +
+		`<Inner><Name>def</Name><Extra>x</Extra></Inner>` +
+		`</Shape>`
+
+	cases := map[string]struct {
+		Options       func(*DecoderOptions)
+		Expect        mockDecodeShape
+		ExpectUnknown []string
+		ExpectErr     string
+	}{
+		"ignore unknown": {
+			Options: func(o *DecoderOptions) {},
+			Expect:  mockDecodeShape{Name: "abc", Inner: "def"},
+		},
+		"strip namespaces": {
+			Options: func(o *DecoderOptions) { o.StripNamespaces = true },
+			Expect:  mockDecodeShape{Name: "abc", Type: "Canonical", Inner: "def"},
+		},
+		"disallow unknown": {
+			Options: func(o *DecoderOptions) {
+				o.UnknownElements = DisallowUnknownElements
+			},
+			ExpectErr: "unknown element Added in Shape",
+		},
+		"collect unknown": {
+			Options: func(o *DecoderOptions) {
+				o.UnknownElements = CollectUnknownElements
+				o.StripNamespaces = true
+			},
+			Expect:        mockDecodeShape{Name: "abc", Type: "Canonical", Inner: "def"},
+			ExpectUnknown: []string{"Added:<Nested>1</Nested>"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			decoder := xml.NewDecoder(strings.NewReader(doc))
+			root, err := FetchRootElement(decoder)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			node := WrapNodeDecoder(decoder, root, c.Options)
+
+			v, err := decodeMockShape(node)
+			if len(c.ExpectErr) != 0 {
+				var unknownErr *UnknownElementError
+				if !errors.As(err, &unknownErr) {
+					t.Fatalf("expect *UnknownElementError, got %T, %v", err, err)
+				}
+				if e, a := c.ExpectErr, err.Error(); e != a {
+					t.Errorf("expect %q error, got %q", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Expect, v; e != a {
+				t.Errorf("expect %+v, got %+v", e, a)
+			}
+
+			var unknown []string
+			for _, u := range node.UnknownElements() {
+				unknown = append(unknown, u.Name.Local+":"+string(u.InnerXML))
+			}
+			if e, a := len(c.ExpectUnknown), len(unknown); e != a {
+				t.Fatalf("expect %v unknown elements, got %v", c.ExpectUnknown, unknown)
+			}
+			for i := range c.ExpectUnknown {
+				if e, a := c.ExpectUnknown[i], unknown[i]; e != a {
+					t.Errorf("expect %q unknown element, got %q", e, a)
+				}
+			}
+		})
+	}
+}
@@ -45,5 +45,25 @@ Flattened map rely on Value being marked as flattened.
 If a shape is marked as flattened, Map() will use the shape element name as wrapper for map entry elements.
 
 	<flattenedMap><Key>apple</Key><Value>tree</Value></flattenedMap><flattenedMap><Key>snow</Key><Value>ice</Value></flattenedMap>
+
+Map entries are written in the order they are encoded. An encoder created with the SortMapEntries option buffers the
+entries, and writes them sorted by key when the map is closed.
+
+Attributes
+
+Attributes are encoded from the Attr of the start element a Value is created with. Array members and map entries
+carry attributes with the MemberWithAttributes() and EntryWithAttributes() methods, and union members with the
+attributes of the start element passed to MemberElement(). StartElement's WithAttributes() adds attributes to a copy
+of a start element. Attribute values are escaped, including quotes and newlines.
+
+	<wrappedArray><member id="1">apple</member></wrappedArray>
+	<union><stringValue lang="en">apple</stringValue></union>
+
+Decoding
+
+NodeDecoder decodes the member elements of a node. Members are matched with MatchName, and elements which match no
+member are passed to HandleUnknown, which skips them by default, as services frequently add members clients do not yet
+model. The DecoderOptions of WrapNodeDecoder may instead disallow unknown elements, or collect their raw content to be
+retrieved with UnknownElements, and strip the namespace prefix of member names when matching.
 */
 package xml
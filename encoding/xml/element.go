@@ -70,6 +70,14 @@ func (e StartElement) Copy() StartElement {
 	return e
 }
 
+// WithAttributes returns a copy of StartElement with the attributes appended
+// to its attributes. The StartElement's attributes are not modified.
+func (e StartElement) WithAttributes(attrs ...Attr) StartElement {
+	c := e.Copy()
+	c.Attr = append(c.Attr, attrs...)
+	return c
+}
+
 // End returns the corresponding XML end element.
 func (e StartElement) End() EndElement {
 	return EndElement{e.Name}
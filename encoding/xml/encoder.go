@@ -1,5 +1,12 @@
 package xml
 
+import (
+	"bytes"
+
+	"github.com/aws/smithy-go/encoding"
+	"github.com/aws/smithy-go/sync/bufferpool"
+)
+
 // writer interface used by the xml encoder to write an encoded xml
 // document in a writer.
 type writer interface {
@@ -25,13 +32,66 @@ type writer interface {
 type Encoder struct {
 	w       writer
 	scratch *[]byte
+	pooled  *bytes.Buffer
+	options EncoderOptions
+
+	// floatFormat is the format of encoded floats, nil for the default
+	// format
+	floatFormat *encoding.FloatFormat
+}
+
+// EncoderOptions is the set of options that can be configured for an Encoder.
+type EncoderOptions struct {
+	// SortMapEntries writes the entries of maps in sorted key order, for
+	// reproducible documents, e.g. stable signatures and snapshot tests.
+	// Entries are buffered until their map is closed, so the maps' Close must
+	// be called. Defaults to false, writing entries in the order they are
+	// encoded.
+	SortMapEntries bool
+
+	// FloatFormat is the format of encoded float values, such as a fixed
+	// precision, or never using scientific notation. Defaults to the
+	// shortest representation that round-trips, see encoding.FloatFormat.
+	FloatFormat encoding.FloatFormat
 }
 
 // NewEncoder returns an XML encoder
-func NewEncoder(w writer) *Encoder {
+func NewEncoder(w writer, optFns ...func(*EncoderOptions)) *Encoder {
+	scratch := make([]byte, 64)
+
+	return newEncoder(&Encoder{w: w, scratch: &scratch}, optFns)
+}
+
+// NewPooledEncoder returns an XML encoder writing to a buffer retrieved from
+// the shared buffer pool. Release must be called to return the buffer to the
+// pool once the encoded bytes are no longer used.
+func NewPooledEncoder(optFns ...func(*EncoderOptions)) *Encoder {
+	buf := bufferpool.Get(0)
 	scratch := make([]byte, 64)
 
-	return &Encoder{w: w, scratch: &scratch}
+	return newEncoder(&Encoder{w: buf, scratch: &scratch, pooled: buf}, optFns)
+}
+
+func newEncoder(e *Encoder, optFns []func(*EncoderOptions)) *Encoder {
+	for _, fn := range optFns {
+		fn(&e.options)
+	}
+	if e.options.FloatFormat != (encoding.FloatFormat{}) {
+		e.floatFormat = &e.options.FloatFormat
+	}
+	return e
+}
+
+// Release returns the encoder's buffer to the shared buffer pool, if the
+// encoder was created with NewPooledEncoder. The encoder, and the slice
+// returned by Bytes, must not be used after the encoder is released.
+func (e *Encoder) Release() {
+	if e.pooled == nil {
+		return
+	}
+	bufferpool.Put(e.pooled)
+	e.pooled = nil
+	e.w = nil
 }
 
 // String returns the string output of the XML encoder
@@ -47,5 +107,8 @@ func (e Encoder) Bytes() []byte {
 // RootElement builds a root element encoding
 // It writes it's start element tag. The value should be closed.
 func (e Encoder) RootElement(element StartElement) Value {
-	return newValue(e.w, e.scratch, element)
+	v := newValue(e.w, e.scratch, element)
+	v.sortMapEntries = e.options.SortMapEntries
+	v.floatFormat = e.floatFormat
+	return v
 }
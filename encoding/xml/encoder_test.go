@@ -6,6 +6,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/aws/smithy-go/encoding"
 	"github.com/aws/smithy-go/encoding/xml"
 )
 
@@ -195,6 +196,83 @@ func TestEncodeMapFlatten(t *testing.T) {
 	verify(t, encoder, ex)
 }
 
+func TestEncodeMapSorted(t *testing.T) {
+	cases := map[string]struct {
+		Flattened bool
+		Sort      bool
+		Expect    string
+	}{
+		"insertion order": {
+			Expect: `<root><mapstr><entry><key>b</key><value>2</value></entry><entry><key>a&amp;</key><value>1</value></entry><entry><key>a</key><value><entry><key>y</key><value>4</value></entry><entry><key>x</key><value>3</value></entry></value></entry></mapstr></root>`,
+		},
+		"sorted": {
+			Sort:   true,
+			Expect: `<root><mapstr><entry><key>a</key><value><entry><key>x</key><value>3</value></entry><entry><key>y</key><value>4</value></entry></value></entry><entry><key>a&amp;</key><value>1</value></entry><entry><key>b</key><value>2</value></entry></mapstr></root>`,
+		},
+		"sorted flattened": {
+			Flattened: true,
+			Sort:      true,
+			Expect:    `<root><mapstr><key>a</key><value><entry><key>x</key><value>3</value></entry><entry><key>y</key><value>4</value></entry></value></mapstr><mapstr><key>a&amp;</key><value>1</value></mapstr><mapstr><key>b</key><value>2</value></mapstr></root>`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := bytes.NewBuffer(nil)
+			encoder := xml.NewEncoder(b, func(o *xml.EncoderOptions) {
+				o.SortMapEntries = c.Sort
+			})
+
+			func() {
+				r := encoder.RootElement(root)
+				defer r.Close()
+
+				mapstr := xml.StartElement{Name: xml.Name{Local: "mapstr"}}
+				key := xml.StartElement{Name: xml.Name{Local: "key"}}
+				value := xml.StartElement{Name: xml.Name{Local: "value"}}
+
+				var m *xml.Map
+				if c.Flattened {
+					m = r.FlattenedElement(mapstr).Map()
+				} else {
+					mapElement := r.MemberElement(mapstr)
+					defer mapElement.Close()
+					m = mapElement.Map()
+				}
+				defer m.Close()
+
+				e := m.Entry()
+				e.MemberElement(key).String("b")
+				e.MemberElement(value).Integer(2)
+				e.Close()
+
+				e = m.Entry()
+				e.MemberElement(key).String("a&")
+				e.MemberElement(value).Integer(1)
+				e.Close()
+
+				e = m.Entry()
+				e.MemberElement(key).String("a")
+				nested := e.MemberElement(value)
+				nm := nested.Map()
+				ne := nm.Entry()
+				ne.MemberElement(key).String("y")
+				ne.MemberElement(value).Integer(4)
+				ne.Close()
+				ne = nm.Entry()
+				ne.MemberElement(key).String("x")
+				ne.MemberElement(value).Integer(3)
+				ne.Close()
+				nm.Close()
+				nested.Close()
+				e.Close()
+			}()
+
+			verify(t, encoder, []byte(c.Expect))
+		})
+	}
+}
+
 func TestEncodeMapNamed(t *testing.T) {
 	b := bytes.NewBuffer(nil)
 	encoder := xml.NewEncoder(b)
@@ -558,3 +636,70 @@ func ExampleEncoder() {
 	m2.MemberElement(value).Integer(123)
 	m2.Close()
 }
+
+func TestPooledEncoder(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		encoder := xml.NewPooledEncoder()
+
+		func() {
+			r := encoder.RootElement(root)
+			defer r.Close()
+			r.MemberElement(xml.StartElement{Name: xml.Name{Local: "key"}}).String("value")
+		}()
+
+		if e, a := `<root><key>value</key></root>`, encoder.String(); e != a {
+			t.Errorf("expect %v, got %v", e, a)
+		}
+		encoder.Release()
+	}
+}
+
+func TestEncoderFloatFormat(t *testing.T) {
+	cases := map[string]struct {
+		Format encoding.FloatFormat
+		Expect string
+	}{
+		"default": {
+			Expect: `<root><a>1e+21</a><list><member>0.3333333333333333</member></list><map><entry><key>c</key><value>1.5e-7</value></entry></map></root>`,
+		},
+		"no exponent": {
+			Format: encoding.FloatFormat{DisableExponent: true},
+			Expect: `<root><a>1000000000000000000000</a><list><member>0.3333333333333333</member></list><map><entry><key>c</key><value>0.00000015</value></entry></map></root>`,
+		},
+		"fixed precision": {
+			Format: encoding.FloatFormat{FixedPrecision: true, Precision: 2},
+			Expect: `<root><a>1.00e+21</a><list><member>0.33</member></list><map><entry><key>c</key><value>1.50e-7</value></entry></map></root>`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := bytes.NewBuffer(nil)
+			encoder := xml.NewEncoder(b, func(o *xml.EncoderOptions) {
+				o.FloatFormat = c.Format
+			})
+
+			func() {
+				r := encoder.RootElement(root)
+				defer r.Close()
+
+				r.MemberElement(xml.StartElement{Name: xml.Name{Local: "a"}}).Double(1e21)
+
+				list := r.MemberElement(xml.StartElement{Name: xml.Name{Local: "list"}})
+				list.Array().Member().Double(1.0 / 3)
+				list.Close()
+
+				mv := r.MemberElement(xml.StartElement{Name: xml.Name{Local: "map"}})
+				entry := mv.Map().Entry()
+				entry.MemberElement(xml.StartElement{Name: xml.Name{Local: "key"}}).String("c")
+				entry.MemberElement(xml.StartElement{Name: xml.Name{Local: "value"}}).Float(1.5e-7)
+				entry.Close()
+				mv.Close()
+			}()
+
+			if e, a := c.Expect, encoder.String(); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
@@ -1,5 +1,13 @@
 package xml
 
+import (
+	"bytes"
+	"html"
+	"sort"
+
+	"github.com/aws/smithy-go/encoding"
+)
+
 // mapEntryWrapper is the default member wrapper start element for XML Map entry
 var mapEntryWrapper = StartElement{
 	Name: Name{Local: "entry"},
@@ -15,6 +23,13 @@ type Map struct {
 
 	// isFlattened returns true if the map is a flattened map
 	isFlattened bool
+
+	// sortEntries indicates if the entries are buffered, and written in
+	// sorted key order when the map is closed.
+	sortEntries bool
+	entries     []*bytes.Buffer
+
+	floatFormat *encoding.FloatFormat
 }
 
 // newMap returns a map encoder which sets the default map
@@ -47,7 +62,75 @@ func newFlattenedMap(w writer, scratch *[]byte, memberWrapper StartElement) *Map
 // Entry returns a Value encoder with map's element.
 // It writes the member wrapper start tag for each entry.
 func (m *Map) Entry() Value {
-	v := newValue(m.w, m.scratch, m.memberStartElement)
+	v := newValue(m.entryWriter(), m.scratch, m.memberStartElement)
 	v.isFlattened = m.isFlattened
+	v.sortMapEntries = m.sortEntries
+	v.floatFormat = m.floatFormat
 	return v
 }
+
+// EntryWithAttributes returns a Value encoder with map's element, whose entry
+// wrapper element carries the attributes in addition to the wrapper's own.
+// It writes the member wrapper start tag for each entry.
+//
+// A wrapped map entry with attribute `id="1"` is represented as
+// `<entry id="1"><key>abc<key><value>123</value></entry>`.
+func (m *Map) EntryWithAttributes(attrs ...Attr) Value {
+	v := newValue(m.entryWriter(), m.scratch, m.memberStartElement.WithAttributes(attrs...))
+	v.isFlattened = m.isFlattened
+	v.sortMapEntries = m.sortEntries
+	v.floatFormat = m.floatFormat
+	return v
+}
+
+// Close writes the map's entries sorted by key, if the encoder was created
+// with SortMapEntries. The entries are sorted by the text of the first element
+// of each entry, its key. Otherwise the entries are already written, and
+// Close does nothing.
+func (m *Map) Close() {
+	if len(m.entries) == 0 {
+		return
+	}
+
+	keys := make(map[*bytes.Buffer]string, len(m.entries))
+	for _, entry := range m.entries {
+		keys[entry] = mapEntryKey(entry.Bytes())
+	}
+	sort.SliceStable(m.entries, func(i, j int) bool {
+		return keys[m.entries[i]] < keys[m.entries[j]]
+	})
+
+	for _, entry := range m.entries {
+		m.w.Write(entry.Bytes())
+	}
+	m.entries = nil
+}
+
+// entryWriter returns the writer of a new entry, buffering the entry if
+// entries are sorted.
+func (m *Map) entryWriter() writer {
+	if !m.sortEntries {
+		return m.w
+	}
+
+	entry := bytes.NewBuffer(nil)
+	m.entries = append(m.entries, entry)
+	return entry
+}
+
+// mapEntryKey returns the unescaped text of the key element, the first
+// element nested in the encoded entry.
+func mapEntryKey(entry []byte) string {
+	// skip the entry wrapper, and key start elements.
+	for i := 0; i < 2; i++ {
+		n := bytes.IndexByte(entry, rightAngleBracket)
+		if n < 0 {
+			return ""
+		}
+		entry = entry[n+1:]
+	}
+	if n := bytes.IndexByte(entry, leftAngleBracket); n >= 0 {
+		entry = entry[:n]
+	}
+	return html.UnescapeString(string(entry))
+}
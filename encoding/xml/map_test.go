@@ -75,3 +75,45 @@ func TestFlattenedMapWithCustomName(t *testing.T) {
 		t.Errorf("expected %+q, but got %+q", ex, a)
 	}
 }
+
+func TestMapEntryWithAttributes(t *testing.T) {
+	cases := map[string]struct {
+		Map    func(w writer, scratch *[]byte) *Map
+		Expect string
+	}{
+		"wrapped": {
+			Map: newMap,
+			Expect: `<entry note="say &#34;hi&#34;&#xA;twice"><key>abc</key></entry>` +
+				`<entry><key>def</key></entry>`,
+		},
+		"flattened": {
+			Map: func(w writer, scratch *[]byte) *Map {
+				return newFlattenedMap(w, scratch, StartElement{Name: Name{Local: "someMap"}})
+			},
+			Expect: `<someMap note="say &#34;hi&#34;&#xA;twice"><key>abc</key></someMap>` +
+				`<someMap><key>def</key></someMap>`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			buffer := bytes.NewBuffer(nil)
+			scratch := make([]byte, 64)
+
+			m := c.Map(buffer, &scratch)
+			key := StartElement{Name: Name{Local: "key"}}
+
+			e := m.EntryWithAttributes(NewAttribute("note", "say \"hi\"\ntwice"))
+			e.MemberElement(key).String("abc")
+			e.Close()
+
+			e = m.Entry()
+			e.MemberElement(key).String("def")
+			e.Close()
+
+			if e, a := c.Expect, buffer.String(); e != a {
+				t.Errorf("expected %+q, but got %+q", e, a)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strconv"
 
+	smithy "github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/encoding"
 )
 
@@ -20,6 +21,13 @@ type Value struct {
 
 	// indicates if the Value represents a flattened shape
 	isFlattened bool
+
+	// indicates if the entries of maps nested in the Value are sorted by key
+	sortMapEntries bool
+
+	// floatFormat is the format of floats nested in the Value, nil for the
+	// default format
+	floatFormat *encoding.FloatFormat
 }
 
 // newFlattenedValue returns a Value encoder. newFlattenedValue does NOT write the start element tag
@@ -150,7 +158,11 @@ func (xv Value) Double(v float64) {
 }
 
 func (xv Value) float(v float64, bits int) {
-	*xv.scratch = encoding.EncodeFloat((*xv.scratch)[:0], v, bits)
+	if xv.floatFormat != nil {
+		*xv.scratch = encoding.EncodeFloatFormat((*xv.scratch)[:0], v, bits, *xv.floatFormat)
+	} else {
+		*xv.scratch = encoding.EncodeFloat((*xv.scratch)[:0], v, bits)
+	}
 	xv.w.Write(*xv.scratch)
 }
 
@@ -189,6 +201,20 @@ func (xv Value) BigDecimal(v *big.Float) {
 	xv.Close()
 }
 
+// BigIntegerValue encodes v as XML value.
+// It will auto close the parent xml element tag.
+func (xv Value) BigIntegerValue(v smithy.BigInteger) {
+	xv.w.WriteString(v.String())
+	xv.Close()
+}
+
+// BigDecimalValue encodes v as XML value, with all of its digits.
+// It will auto close the parent xml element tag.
+func (xv Value) BigDecimalValue(v smithy.BigDecimal) {
+	xv.w.WriteString(v.String())
+	xv.Close()
+}
+
 // Write writes v directly to the xml document
 // if escapeXMLText is set to true, write will escape text.
 // It will auto close the parent xml element tag.
@@ -210,7 +236,10 @@ func (xv Value) Write(v []byte, escapeXMLText bool) {
 // A call to MemberElement will write nested element tags directly using the
 // provided start element. The value returned by MemberElement should be closed.
 func (xv Value) MemberElement(element StartElement) Value {
-	return newValue(xv.w, xv.scratch, element)
+	v := newValue(xv.w, xv.scratch, element)
+	v.sortMapEntries = xv.sortMapEntries
+	v.floatFormat = xv.floatFormat
+	return v
 }
 
 // FlattenedElement returns flattened element encoding. It returns a Value.
@@ -223,6 +252,8 @@ func (xv Value) MemberElement(element StartElement) Value {
 func (xv Value) FlattenedElement(element StartElement) Value {
 	v := newFlattenedValue(xv.w, xv.scratch, element)
 	v.isFlattened = true
+	v.sortMapEntries = xv.sortMapEntries
+	v.floatFormat = xv.floatFormat
 	return v
 }
 
@@ -231,7 +262,10 @@ func (xv Value) FlattenedElement(element StartElement) Value {
 // If value is marked as flattened, the start element is used to wrap the members instead of
 // the `<member>` element.
 func (xv Value) Array() *Array {
-	return newArray(xv.w, xv.scratch, arrayMemberWrapper, xv.startElement, xv.isFlattened)
+	a := newArray(xv.w, xv.scratch, arrayMemberWrapper, xv.startElement, xv.isFlattened)
+	a.sortMapEntries = xv.sortMapEntries
+	a.floatFormat = xv.floatFormat
+	return a
 }
 
 /*
@@ -242,7 +276,10 @@ for eg, `<someList><customName>entry1</customName></someList>`
 Here `customName` named start element will be wrapped on each array member.
 */
 func (xv Value) ArrayWithCustomName(element StartElement) *Array {
-	return newArray(xv.w, xv.scratch, element, xv.startElement, xv.isFlattened)
+	a := newArray(xv.w, xv.scratch, element, xv.startElement, xv.isFlattened)
+	a.sortMapEntries = xv.sortMapEntries
+	a.floatFormat = xv.floatFormat
+	return a
 }
 
 /*
@@ -251,15 +288,22 @@ wrapped with `<entry>` element tag.
 
 If value is marked as flattened, the start element is used to wrap the entry instead of
 the `<member>` element.
+
+If the encoder was created with SortMapEntries, the map must be closed after
+its entries are encoded.
 */
 func (xv Value) Map() *Map {
-	// flattened map
+	var m *Map
 	if xv.isFlattened {
-		return newFlattenedMap(xv.w, xv.scratch, xv.startElement)
+		// flattened map
+		m = newFlattenedMap(xv.w, xv.scratch, xv.startElement)
+	} else {
+		// un-flattened map
+		m = newMap(xv.w, xv.scratch)
 	}
-
-	// un-flattened map
-	return newMap(xv.w, xv.scratch)
+	m.sortEntries = xv.sortMapEntries
+	m.floatFormat = xv.floatFormat
+	return m
 }
 
 // encodeByteSlice is modified copy of json encoder's encodeByteSlice.
@@ -12,14 +12,20 @@ import (
 type NodeDecoder struct {
 	Decoder *xml.Decoder
 	StartEl xml.StartElement
+
+	options DecoderOptions
+	unknown *[]UnknownElement
 }
 
-// WrapNodeDecoder returns an initialized XMLNodeDecoder
-func WrapNodeDecoder(decoder *xml.Decoder, startEl xml.StartElement) NodeDecoder {
-	return NodeDecoder{
-		Decoder: decoder,
-		StartEl: startEl,
+// WrapNodeDecoder returns an initialized XMLNodeDecoder. The options
+// configure the decoder's tolerance of unknown elements, and namespaces, see
+// DecoderOptions.
+func WrapNodeDecoder(decoder *xml.Decoder, startEl xml.StartElement, optFns ...func(*DecoderOptions)) NodeDecoder {
+	var o DecoderOptions
+	for _, fn := range optFns {
+		fn(&o)
 	}
+	return newNodeDecoder(decoder, startEl, o)
 }
 
 // Token on a Node Decoder returns a xml StartElement. It returns a boolean that indicates the
@@ -0,0 +1,127 @@
+package smithy
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Enum is the constraint of the types of Smithy enum, and intEnum shapes.
+// Generated enum types are string, or int32 types whose values not known by
+// the client are preserved as is, so they round-trip unchanged.
+type Enum interface {
+	~string | ~int32
+}
+
+var (
+	enumTablesMu sync.RWMutex
+	enumTables   = map[interface{}]interface{}{}
+
+	strictEnums int32
+)
+
+// enumKey is the key of the table of the enum type T. Each instantiation is
+// a distinct type, so lookups do not use reflection.
+type enumKey[T Enum] struct{}
+
+type enumTable[T Enum] struct {
+	values []T
+	known  map[T]struct{}
+}
+
+// RegisterEnum registers the values of the enum type T, for the lookups of
+// EnumValues, IsEnumValue, and ParseEnum. Called by generated clients when
+// their package is initialized. Registering the values of a type again
+// replaces its values.
+func RegisterEnum[T Enum](values ...T) {
+	t := enumTable[T]{
+		values: append([]T(nil), values...),
+		known:  make(map[T]struct{}, len(values)),
+	}
+	for _, v := range values {
+		t.known[v] = struct{}{}
+	}
+
+	enumTablesMu.Lock()
+	defer enumTablesMu.Unlock()
+	enumTables[enumKey[T]{}] = t
+}
+
+func getEnumTable[T Enum]() (enumTable[T], bool) {
+	enumTablesMu.RLock()
+	defer enumTablesMu.RUnlock()
+
+	t, ok := enumTables[enumKey[T]{}].(enumTable[T])
+	return t, ok
+}
+
+// EnumValues returns the registered values of the enum type T, or nil if
+// none are registered.
+func EnumValues[T Enum]() []T {
+	t, ok := getEnumTable[T]()
+	if !ok {
+		return nil
+	}
+	return append([]T(nil), t.values...)
+}
+
+// IsEnumValue returns whether v is a registered value of its enum type.
+// Returns false for values not known by the client, and values of types with
+// no registered values.
+func IsEnumValue[T Enum](v T) bool {
+	t, ok := getEnumTable[T]()
+	if !ok {
+		return false
+	}
+	_, ok = t.known[v]
+	return ok
+}
+
+// SetStrictEnums sets whether ParseEnum returns an error for enum values not
+// known by the client. Disabled by default, preserving unknown values.
+func SetStrictEnums(strict bool) {
+	var v int32
+	if strict {
+		v = 1
+	}
+	atomic.StoreInt32(&strictEnums, v)
+}
+
+// IsStrictEnums returns whether strict enum deserialization is enabled, see
+// SetStrictEnums.
+func IsStrictEnums() bool {
+	return atomic.LoadInt32(&strictEnums) == 1
+}
+
+// ParseEnum returns the deserialized enum value v. If strict enum
+// deserialization is enabled, returns an UnknownEnumValueError if v is not a
+// registered value of its type. Otherwise, and for types with no registered
+// values, v is returned as is.
+func ParseEnum[T Enum](v T) (T, error) {
+	if !IsStrictEnums() {
+		return v, nil
+	}
+
+	t, ok := getEnumTable[T]()
+	if !ok {
+		return v, nil
+	}
+	if _, ok := t.known[v]; !ok {
+		return v, &UnknownEnumValueError{Enum: fmt.Sprintf("%T", v), Value: fmt.Sprint(v)}
+	}
+	return v, nil
+}
+
+// UnknownEnumValueError is returned by ParseEnum for enum values not known by
+// the client, when strict enum deserialization is enabled.
+type UnknownEnumValueError struct {
+	// Enum is the name of the enum type.
+	Enum string
+
+	// Value is the unknown value.
+	Value string
+}
+
+func (e *UnknownEnumValueError) Error() string {
+	return fmt.Sprintf("unknown %s enum value %q", e.Enum, e.Value)
+}
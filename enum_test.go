@@ -0,0 +1,106 @@
+package smithy
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type mockEnum string
+
+const (
+	mockEnumA mockEnum = "A"
+	mockEnumB mockEnum = "B"
+)
+
+type mockIntEnum int32
+
+type mockUnregisteredEnum string
+
+func TestEnumValues(t *testing.T) {
+	RegisterEnum(mockEnumA, mockEnumB)
+	RegisterEnum[mockIntEnum](1, 2)
+
+	if e, a := []mockEnum{mockEnumA, mockEnumB}, EnumValues[mockEnum](); !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v values, got %v", e, a)
+	}
+	if e, a := []mockIntEnum{1, 2}, EnumValues[mockIntEnum](); !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v values, got %v", e, a)
+	}
+	if a := EnumValues[mockUnregisteredEnum](); a != nil {
+		t.Errorf("expect no values, got %v", a)
+	}
+
+	cases := map[string]struct {
+		Valid  bool
+		Expect bool
+	}{
+		"known":        {Valid: IsEnumValue(mockEnumB), Expect: true},
+		"unknown":      {Valid: IsEnumValue(mockEnum("C"))},
+		"known int":    {Valid: IsEnumValue(mockIntEnum(2)), Expect: true},
+		"unknown int":  {Valid: IsEnumValue(mockIntEnum(3))},
+		"unregistered": {Valid: IsEnumValue(mockUnregisteredEnum("A"))},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := c.Expect, c.Valid; e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestParseEnum(t *testing.T) {
+	RegisterEnum(mockEnumA, mockEnumB)
+	defer SetStrictEnums(false)
+
+	cases := map[string]struct {
+		Strict    bool
+		Value     mockEnum
+		ExpectErr bool
+	}{
+		"known": {
+			Value: mockEnumA,
+		},
+		"unknown preserved": {
+			Value: "C",
+		},
+		"strict known": {
+			Strict: true,
+			Value:  mockEnumA,
+		},
+		"strict unknown": {
+			Strict:    true,
+			Value:     "C",
+			ExpectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			SetStrictEnums(c.Strict)
+
+			v, err := ParseEnum(c.Value)
+			if e, a := c.Value, v; e != a {
+				t.Errorf("expect %v value, got %v", e, a)
+			}
+			if !c.ExpectErr {
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				return
+			}
+
+			var unknownErr *UnknownEnumValueError
+			if !errors.As(err, &unknownErr) {
+				t.Fatalf("expect unknown enum value error, got %v", err)
+			}
+			if e, a := "smithy.mockEnum", unknownErr.Enum; e != a {
+				t.Errorf("expect %v enum, got %v", e, a)
+			}
+			if e, a := "C", unknownErr.Value; e != a {
+				t.Errorf("expect %v value, got %v", e, a)
+			}
+		})
+	}
+}
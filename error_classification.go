@@ -0,0 +1,89 @@
+package smithy
+
+import "errors"
+
+// RetryableError is an optional interface an error may implement to indicate
+// whether the request that failed with the error may be retried. Modeled
+// errors with the Smithy retryable trait implement this interface.
+type RetryableError interface {
+	error
+	RetryableError() bool
+}
+
+// ThrottleError is an optional interface an error may implement to indicate
+// whether the error was the result of the request being throttled.
+type ThrottleError interface {
+	error
+	ThrottleError() bool
+}
+
+// ErrorMetadata is the transport metadata of the response an error was
+// deserialized from.
+type ErrorMetadata struct {
+	// RequestID is the identifier assigned to the request by the service, if
+	// any.
+	RequestID string
+
+	// StatusCode is the raw status code of the response, e.g. the HTTP status
+	// code. Zero if not known.
+	StatusCode int
+}
+
+// MetadataError is an optional interface an error may implement to expose
+// the metadata of the response it was deserialized from.
+type MetadataError interface {
+	error
+	ErrorMetadata() ErrorMetadata
+}
+
+// GetErrorCode returns the error code of the first APIError in err's chain,
+// and whether one was found.
+func GetErrorCode(err error) (string, bool) {
+	var apiErr APIError
+	if !errors.As(err, &apiErr) {
+		return "", false
+	}
+	return apiErr.ErrorCode(), true
+}
+
+// GetErrorFault returns the fault of the first APIError in err's chain. If
+// err does not contain an APIError, FaultUnknown is returned.
+func GetErrorFault(err error) ErrorFault {
+	var apiErr APIError
+	if !errors.As(err, &apiErr) {
+		return FaultUnknown
+	}
+	return apiErr.ErrorFault()
+}
+
+// IsErrorRetryable returns whether the first RetryableError in err's chain
+// indicates the request may be retried. The ok value is false if no error in
+// the chain provides a retryability hint, in which case the caller should
+// fall back to its own classification.
+func IsErrorRetryable(err error) (retryable, ok bool) {
+	var v RetryableError
+	if !errors.As(err, &v) {
+		return false, false
+	}
+	return v.RetryableError(), true
+}
+
+// IsErrorThrottle returns whether the first ThrottleError in err's chain
+// indicates the request was throttled.
+func IsErrorThrottle(err error) bool {
+	var v ThrottleError
+	if !errors.As(err, &v) {
+		return false
+	}
+	return v.ThrottleError()
+}
+
+// GetErrorMetadata returns the response metadata of the first MetadataError
+// in err's chain, and whether one was found.
+func GetErrorMetadata(err error) (ErrorMetadata, bool) {
+	var v MetadataError
+	if !errors.As(err, &v) {
+		return ErrorMetadata{}, false
+	}
+	return v.ErrorMetadata(), true
+}
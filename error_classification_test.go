@@ -0,0 +1,66 @@
+package smithy
+
+import (
+	"fmt"
+	"testing"
+)
+
+type mockRetryableError struct {
+	retryable bool
+	throttle  bool
+}
+
+func (e mockRetryableError) Error() string        { return "mock error" }
+func (e mockRetryableError) RetryableError() bool { return e.retryable }
+func (e mockRetryableError) ThrottleError() bool  { return e.throttle }
+
+func TestErrorClassification(t *testing.T) {
+	apiErr := &GenericAPIError{
+		Code:  "FooException",
+		Fault: FaultServer,
+		Metadata: ErrorMetadata{
+			RequestID:  "abc123",
+			StatusCode: 500,
+		},
+	}
+	err := &OperationError{
+		ServiceID:     "Foo",
+		OperationName: "GetFoo",
+		Err:           fmt.Errorf("wrapped, %w", apiErr),
+	}
+
+	if code, ok := GetErrorCode(err); !ok || code != "FooException" {
+		t.Errorf("expect FooException code, got %v, %v", code, ok)
+	}
+	if e, a := FaultServer, GetErrorFault(err); e != a {
+		t.Errorf("expect %v fault, got %v", e, a)
+	}
+	if md, ok := GetErrorMetadata(err); !ok {
+		t.Errorf("expect metadata to be found")
+	} else if e, a := (ErrorMetadata{RequestID: "abc123", StatusCode: 500}), md; e != a {
+		t.Errorf("expect %v metadata, got %v", e, a)
+	}
+	if _, ok := IsErrorRetryable(err); ok {
+		t.Errorf("expect no retryability hint")
+	}
+	if IsErrorThrottle(err) {
+		t.Errorf("expect not throttle error")
+	}
+}
+
+func TestErrorClassification_Retryable(t *testing.T) {
+	err := fmt.Errorf("wrapped, %w", mockRetryableError{retryable: true, throttle: true})
+
+	if retryable, ok := IsErrorRetryable(err); !ok || !retryable {
+		t.Errorf("expect retryable, got %v, %v", retryable, ok)
+	}
+	if !IsErrorThrottle(err) {
+		t.Errorf("expect throttle error")
+	}
+	if _, ok := GetErrorCode(err); ok {
+		t.Errorf("expect no error code")
+	}
+	if e, a := FaultUnknown, GetErrorFault(err); e != a {
+		t.Errorf("expect %v fault, got %v", e, a)
+	}
+}
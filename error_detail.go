@@ -0,0 +1,140 @@
+package smithy
+
+import (
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// DefaultErrorDetailMaxBytes is the default maximum number of bytes of a
+// payload embedded in an error.
+const DefaultErrorDetailMaxBytes = 4096
+
+// binaryDetectionBytes is the number of bytes of a payload inspected to
+// detect binary payloads.
+const binaryDetectionBytes = 512
+
+// redactMarginBytes is the number of bytes of a payload beyond the limit
+// passed to the Redact option, so that data which is redacted to a shorter
+// length makes room for the bytes following it.
+const redactMarginBytes = 1024
+
+// ErrorDetailOptions controls how much of a raw payload, such as the body of
+// an error response, is embedded in errors, e.g. the Snapshot and Snippet of
+// a DeserializationError. Errors are often written to logs, and crash
+// reports, so the payloads embedded in them are bounded.
+type ErrorDetailOptions struct {
+	// MaxBytes is the maximum number of bytes of a payload embedded in an
+	// error. Payloads are truncated to the limit. A negative value removes
+	// the limit. Defaults to DefaultErrorDetailMaxBytes.
+	MaxBytes int
+
+	// MaxSnippetBytes is the maximum number of bytes of the snippet of a
+	// payload surrounding the location of a deserialization error. Defaults
+	// to MaxDeserializationSnippetSize.
+	MaxSnippetBytes int
+
+	// DetectBinary is whether payloads which are not text, such as
+	// compressed or binary encoded payloads, are omitted from errors.
+	// Defaults to true.
+	DetectBinary bool
+
+	// Redact returns the payload with sensitive data removed, before the
+	// payload is truncated. Optional.
+	Redact func([]byte) []byte
+}
+
+var errorDetailOptions atomic.Value
+
+func init() {
+	errorDetailOptions.Store(ErrorDetailOptions{
+		MaxBytes:        DefaultErrorDetailMaxBytes,
+		MaxSnippetBytes: MaxDeserializationSnippetSize,
+		DetectBinary:    true,
+	})
+}
+
+// SetErrorDetailOptions applies the functional options to the process wide
+// ErrorDetailOptions, starting from the current options. Errors created
+// afterwards use the updated options.
+func SetErrorDetailOptions(optFns ...func(*ErrorDetailOptions)) {
+	o := GetErrorDetailOptions()
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	errorDetailOptions.Store(o)
+}
+
+// GetErrorDetailOptions returns the process wide ErrorDetailOptions.
+func GetErrorDetailOptions() ErrorDetailOptions {
+	return errorDetailOptions.Load().(ErrorDetailOptions)
+}
+
+// ErrorDetail returns the copy of the payload to embed in an error, limited
+// by the ErrorDetailOptions, and whether any of the payload was omitted. A
+// binary payload is omitted entirely, if binary detection is enabled.
+func ErrorDetail(payload []byte) (detail []byte, truncated bool) {
+	return errorDetail(payload, GetErrorDetailOptions().MaxBytes, false)
+}
+
+// errorDetail returns the copy of the payload to embed in an error,
+// truncated to the limit. If partial the payload is a slice of a larger
+// payload, which may start, or end, in the middle of a multi-byte rune.
+func errorDetail(payload []byte, limit int, partial bool) (detail []byte, truncated bool) {
+	if payload == nil {
+		return nil, false
+	}
+
+	o := GetErrorDetailOptions()
+	if o.DetectBinary && isBinary(payload, partial) {
+		return nil, len(payload) != 0
+	}
+
+	// only the bytes which may be embedded are copied, so a large payload is
+	// not copied, or retained by the detail.
+	window := payload
+	if limit >= 0 {
+		n := limit
+		if o.Redact != nil {
+			n += redactMarginBytes
+		}
+		if len(window) > n {
+			window, truncated = window[:n], true
+		}
+	}
+
+	detail = append([]byte{}, window...)
+	if o.Redact != nil {
+		detail = o.Redact(detail)
+	}
+	if limit >= 0 && len(detail) > limit {
+		return detail[:limit], true
+	}
+	return detail, truncated
+}
+
+// isBinary returns whether the prefix of the payload contains NUL, or
+// control bytes, or is not valid UTF-8.
+func isBinary(payload []byte, partial bool) bool {
+	p := payload
+	if len(p) > binaryDetectionBytes {
+		p = p[:binaryDetectionBytes]
+		partial = true
+	}
+
+	for _, b := range p {
+		if b < 0x20 && b != '\t' && b != '\n' && b != '\r' && b != '\f' {
+			return true
+		}
+	}
+	if !partial {
+		return !utf8.Valid(p)
+	}
+
+	for i := 1; i < utf8.UTFMax && len(p) != 0 && !utf8.RuneStart(p[0]); i++ {
+		p = p[1:]
+	}
+	for i := 1; i < utf8.UTFMax && len(p) != 0 && !utf8.Valid(p); i++ {
+		p = p[:len(p)-1]
+	}
+	return !utf8.Valid(p)
+}
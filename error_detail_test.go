@@ -0,0 +1,140 @@
+package smithy
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestErrorDetail(t *testing.T) {
+	defaults := GetErrorDetailOptions()
+	defer SetErrorDetailOptions(func(o *ErrorDetailOptions) { *o = defaults })
+
+	cases := map[string]struct {
+		Options         func(*ErrorDetailOptions)
+		Payload         []byte
+		Expect          []byte
+		ExpectTruncated bool
+		ExpectMaxCap    int
+	}{
+		"nil": {},
+		"text": {
+			Payload: []byte(`{"message":"héllo"}`),
+			Expect:  []byte(`{"message":"héllo"}`),
+		},
+		"truncated": {
+			Options: func(o *ErrorDetailOptions) { o.MaxBytes = 4 },
+			Payload: []byte("<Error/>"),
+			Expect:  []byte("<Err"),
+
+			ExpectTruncated: true,
+		},
+		"unlimited": {
+			Options: func(o *ErrorDetailOptions) { o.MaxBytes = -1 },
+			Payload: bytes.Repeat([]byte("a"), 2*DefaultErrorDetailMaxBytes),
+			Expect:  bytes.Repeat([]byte("a"), 2*DefaultErrorDetailMaxBytes),
+		},
+		"binary": {
+			Payload:         []byte{0x1f, 0x8b, 0x08, 0x00},
+			ExpectTruncated: true,
+		},
+		"invalid utf8": {
+			Payload:         []byte{'a', 0xff, 'b'},
+			ExpectTruncated: true,
+		},
+		"binary detection disabled": {
+			Options: func(o *ErrorDetailOptions) { o.DetectBinary = false },
+			Payload: []byte{0x1f, 0x8b, 0x08, 0x00},
+			Expect:  []byte{0x1f, 0x8b, 0x08, 0x00},
+		},
+		"redacted": {
+			Options: func(o *ErrorDetailOptions) {
+				o.Redact = func(b []byte) []byte {
+					return bytes.ReplaceAll(b, []byte("secret"), []byte("***"))
+				}
+			},
+			Payload: []byte(`{"token":"secret"}`),
+			Expect:  []byte(`{"token":"***"}`),
+		},
+		"redacted truncated": {
+			Options: func(o *ErrorDetailOptions) {
+				o.MaxBytes = 8
+				o.Redact = func(b []byte) []byte {
+					if e, a := 8+redactMarginBytes, len(b); e != a {
+						t.Errorf("expect %v bytes redacted, got %v", e, a)
+					}
+					return bytes.ReplaceAll(b, []byte("secret"), []byte("*"))
+				}
+			},
+			Payload: append([]byte("secretsecretab"), bytes.Repeat([]byte("a"), 1<<20)...),
+			Expect:  []byte("**abaaaa"),
+
+			ExpectTruncated: true,
+		},
+		"truncated large payload": {
+			Payload: bytes.Repeat([]byte("a"), 1<<20),
+			Expect:  bytes.Repeat([]byte("a"), DefaultErrorDetailMaxBytes),
+
+			ExpectTruncated: true,
+			ExpectMaxCap:    2 * DefaultErrorDetailMaxBytes,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			SetErrorDetailOptions(func(o *ErrorDetailOptions) { *o = defaults })
+			if c.Options != nil {
+				SetErrorDetailOptions(c.Options)
+			}
+
+			detail, truncated := ErrorDetail(c.Payload)
+			if e, a := c.Expect, detail; !bytes.Equal(e, a) {
+				t.Errorf("expect %q, got %q", e, a)
+			}
+			if e, a := c.ExpectTruncated, truncated; e != a {
+				t.Errorf("expect truncated %v, got %v", e, a)
+			}
+			if c.ExpectMaxCap != 0 && cap(detail) > c.ExpectMaxCap {
+				t.Errorf("expect detail capacity at most %v, got %v", c.ExpectMaxCap, cap(detail))
+			}
+		})
+	}
+}
+
+func TestDeserializationErrorDetailLimits(t *testing.T) {
+	defaults := GetErrorDetailOptions()
+	defer SetErrorDetailOptions(func(o *ErrorDetailOptions) { *o = defaults })
+
+	SetErrorDetailOptions(func(o *ErrorDetailOptions) {
+		o.MaxBytes = 16
+		o.MaxSnippetBytes = 8
+	})
+
+	payload := []byte(`{"items":["ünïcödé", 1, 2, 3, 4]}`)
+	err := NewDeserializationError(fmt.Errorf("invalid"), "Shape", payload)
+	err.SetOffset(payload, 14)
+
+	if e, a := 16, len(err.Snapshot); e != a {
+		t.Errorf("expect snapshot of %v bytes, got %v", e, a)
+	}
+	if !err.SnapshotTruncated {
+		t.Errorf("expect snapshot truncated")
+	}
+	if err.Snippet == nil {
+		t.Fatalf("expect snippet of partial runes")
+	}
+	if e, a := 8, len(err.Snippet); e != a {
+		t.Errorf("expect snippet of %v bytes, got %v", e, a)
+	}
+
+	binary := append([]byte{0x00, 0x01}, payload...)
+	err = NewDeserializationError(fmt.Errorf("invalid"), "Shape", binary)
+	err.SetOffset(binary, 1)
+	if err.Snapshot != nil || err.Snippet != nil {
+		t.Errorf("expect binary payload omitted, got %q, %q", err.Snapshot, err.Snippet)
+	}
+	if strings.Contains(err.Error(), "\x00") {
+		t.Errorf("expect no binary in error message, got %q", err.Error())
+	}
+}
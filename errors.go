@@ -1,6 +1,14 @@
 package smithy
 
-import "fmt"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // APIError provides the generic API and protocol agnostic error type all SDK
 // generated exception types will implement.
@@ -22,6 +30,10 @@ type GenericAPIError struct {
 	Code    string
 	Message string
 	Fault   ErrorFault
+
+	// Metadata is the metadata of the response the error was deserialized
+	// from, if known.
+	Metadata ErrorMetadata
 }
 
 // ErrorCode returns the error code for the API exception.
@@ -33,18 +45,36 @@ func (e *GenericAPIError) ErrorMessage() string { return e.Message }
 // ErrorFault returns the fault for the API exception.
 func (e *GenericAPIError) ErrorFault() ErrorFault { return e.Fault }
 
+// ErrorMetadata returns the metadata of the response the error was
+// deserialized from.
+func (e *GenericAPIError) ErrorMetadata() ErrorMetadata { return e.Metadata }
+
 func (e *GenericAPIError) Error() string {
 	return fmt.Sprintf("api error %s: %s", e.Code, e.Message)
 }
 
 var _ APIError = (*GenericAPIError)(nil)
+var _ MetadataError = (*GenericAPIError)(nil)
 
 // OperationError decorates an underlying error which occurred while invoking
 // an operation with names of the operation and API.
+//
+// When built with Go 1.21 or later, OperationError implements slog.LogValuer,
+// logging its fields as a group of attributes.
 type OperationError struct {
 	ServiceID     string
 	OperationName string
 	Err           error
+
+	// Phase is the phase of the operation invocation the error occurred in,
+	// e.g. the name of the middleware stack step "Serialize". Empty if not
+	// known.
+	Phase string
+
+	// Attempt is the number of the request attempt the error occurred in,
+	// starting at 1. Zero if not known, or the error did not occur within an
+	// attempt.
+	Attempt int
 }
 
 // Service returns the name of the API service the error occurred with.
@@ -61,19 +91,150 @@ func (e *OperationError) Error() string {
 }
 
 // DeserializationError provides a wrapper for and error that occurs during
-// deserialization.
+// deserialization. Snapshot is a copy of the payload that failed to be
+// deserialized, if any, limited by the ErrorDetailOptions.
 type DeserializationError struct {
 	Err      error //  original error
 	Snapshot []byte
+
+	// SnapshotTruncated is whether the Snapshot omits any of the payload,
+	// see ErrorDetailOptions.
+	SnapshotTruncated bool
+
+	// Shape is the name of the shape being deserialized, if known.
+	Shape string
+
+	// Path is the path of the member being deserialized when the error
+	// occurred, e.g. "Items[2].Name", if known.
+	Path string
+
+	// Offset is the byte offset of the payload where deserialization
+	// failed. Only valid if Snippet is set.
+	Offset int64
+
+	// Snippet is the raw payload surrounding Offset, bounded to the
+	// MaxSnippetBytes of the ErrorDetailOptions. Nil if the payload is
+	// binary.
+	Snippet []byte
+}
+
+// MaxDeserializationSnippetSize is the default maximum size of the Snippet
+// of a DeserializationError, see ErrorDetailOptions.
+const MaxDeserializationSnippetSize = 64
+
+// DecodeErrorLocation is implemented by decoder errors reporting the member
+// path, and byte offset of the payload, where decoding failed.
+type DecodeErrorLocation interface {
+	error
+	DecodePath() string
+	DecodeOffset() int64
+}
+
+// NewDeserializationError returns a DeserializationError for the error
+// deserializing the shape from the payload. The byte offset, and member path,
+// where deserialization failed are read from the error if it is, or wraps, a
+// DecodeErrorLocation, *json.SyntaxError, *json.UnmarshalTypeError, or
+// *xml.SyntaxError.
+func NewDeserializationError(err error, shape string, payload []byte) *DeserializationError {
+	e := &DeserializationError{
+		Err:   err,
+		Shape: shape,
+	}
+	e.Snapshot, e.SnapshotTruncated = ErrorDetail(payload)
+
+	var location DecodeErrorLocation
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var xmlErr *xml.SyntaxError
+	switch {
+	case errors.As(err, &location):
+		e.Path = location.DecodePath()
+		e.SetOffset(payload, location.DecodeOffset())
+	case errors.As(err, &syntaxErr):
+		e.SetOffset(payload, syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		e.Path = typeErr.Field
+		e.SetOffset(payload, typeErr.Offset)
+	case errors.As(err, &xmlErr):
+		e.SetOffset(payload, lineOffset(payload, xmlErr.Line))
+	}
+	return e
+}
+
+// SetOffset sets the byte offset of the payload where deserialization
+// failed, and the snippet of the payload surrounding it. For use by
+// deserializers reading the payload with a decoder, e.g. with the decoder's
+// InputOffset.
+func (e *DeserializationError) SetOffset(payload []byte, offset int64) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > int64(len(payload)) {
+		offset = int64(len(payload))
+	}
+
+	size := int64(GetErrorDetailOptions().MaxSnippetBytes)
+	if size < 0 {
+		size = 0
+	}
+	start := offset - size/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + size
+	if end > int64(len(payload)) {
+		end = int64(len(payload))
+	}
+
+	e.Offset = offset
+	e.Snippet, _ = errorDetail(payload[start:end], -1, true)
+}
+
+// AddNestedPath prepends the member name, or list or map index, e.g. "[2]",
+// to the error's member path. Called by deserializers of nested shapes as
+// the error is returned.
+func (e *DeserializationError) AddNestedPath(member string) {
+	switch {
+	case len(e.Path) == 0:
+		e.Path = member
+	case e.Path[0] == '[':
+		e.Path = member + e.Path
+	default:
+		e.Path = member + "." + e.Path
+	}
+}
+
+// lineOffset returns the byte offset of the start of the 1-based line of the
+// payload.
+func lineOffset(payload []byte, line int) int64 {
+	var offset int
+	for n := 1; n < line; n++ {
+		i := bytes.IndexByte(payload[offset:], '\n')
+		if i == -1 {
+			break
+		}
+		offset += i + 1
+	}
+	return int64(offset)
 }
 
 // Error returns a formatted error for DeserializationError
 func (e *DeserializationError) Error() string {
-	const msg = "deserialization failed"
-	if e.Err == nil {
-		return msg
+	var sb strings.Builder
+	sb.WriteString("deserialization failed")
+	if len(e.Shape) != 0 {
+		fmt.Fprintf(&sb, ", shape %s", e.Shape)
 	}
-	return fmt.Sprintf("%s, %v", msg, e.Err)
+	if len(e.Path) != 0 {
+		fmt.Fprintf(&sb, ", member %s", e.Path)
+	}
+	if e.Snippet != nil {
+		fmt.Fprintf(&sb, ", offset %d near %q", e.Offset, e.Snippet)
+	}
+	if e.Err != nil {
+		fmt.Fprintf(&sb, ", %v", e.Err)
+	}
+	return sb.String()
 }
 
 // Unwrap returns the underlying Error in DeserializationError
@@ -120,8 +281,18 @@ func (e *SerializationError) Unwrap() error { return e.Err }
 // CanceledError is the error that will be returned by an API request that was
 // canceled. API operations given a Context may return this error when
 // canceled.
+//
+// Err is the context's error, context.Canceled or context.DeadlineExceeded,
+// so that errors.Is can be used to test for either. OriginalErr is the error
+// the canceled request failed with, e.g. the transport error caused by the
+// cancellation. It is not unwrapped, so that it is not mistaken for a
+// retryable error by classifiers inspecting the error chain.
 type CanceledError struct {
 	Err error
+
+	// OriginalErr is the error the request failed with when it was canceled,
+	// if any.
+	OriginalErr error
 }
 
 // CanceledError returns true to satisfy interfaces checking for canceled errors.
@@ -135,3 +306,31 @@ func (e *CanceledError) Unwrap() error {
 func (e *CanceledError) Error() string {
 	return fmt.Sprintf("canceled, %v", e.Err)
 }
+
+// ClassifyCanceled returns a CanceledError wrapping err if the context was
+// canceled or its deadline exceeded, distinguishing a request that failed
+// because of the caller's context from a genuine failure. The err is returned
+// unmodified if it is nil, already a canceled error, or the context is not
+// done.
+func ClassifyCanceled(ctx context.Context, err error) error {
+	if err == nil || IsCanceledError(err) {
+		return err
+	}
+
+	ctxErr := ctx.Err()
+	if ctxErr == nil {
+		return err
+	}
+	return &CanceledError{Err: ctxErr, OriginalErr: err}
+}
+
+// IsCanceledError returns whether an error in err's chain indicates the
+// request was canceled by its context. Errors indicate this by implementing a
+// CanceledError() bool method, as CanceledError does.
+func IsCanceledError(err error) bool {
+	var v interface{ CanceledError() bool }
+	if !errors.As(err, &v) {
+		return false
+	}
+	return v.CanceledError()
+}
@@ -0,0 +1,105 @@
+//go:build go1.21
+// +build go1.21
+
+package smithy
+
+import (
+	"errors"
+	"log/slog"
+)
+
+var (
+	_ slog.LogValuer = (*OperationError)(nil)
+	_ slog.LogValuer = (*SerializationError)(nil)
+	_ slog.LogValuer = (*DeserializationError)(nil)
+	_ slog.LogValuer = (*GenericAPIError)(nil)
+)
+
+// LogValue returns the fields of the error as a group of attributes,
+// implementing slog.LogValuer. The API error code, fault, and request ID of
+// the underlying error are included if present.
+func (e *OperationError) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("service", e.ServiceID),
+		slog.String("operation", e.OperationName),
+	}
+	if len(e.Phase) != 0 {
+		attrs = append(attrs, slog.String("phase", e.Phase))
+	}
+	if e.Attempt != 0 {
+		attrs = append(attrs, slog.Int("attempt", e.Attempt))
+	}
+
+	var apiErr APIError
+	if errors.As(e.Err, &apiErr) {
+		attrs = append(attrs,
+			slog.String("code", apiErr.ErrorCode()),
+			slog.String("fault", apiErr.ErrorFault().String()),
+		)
+	}
+	if id, ok := GetRequestID(e.Err); ok {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+
+	return slog.GroupValue(append(attrs, errorAttr(e.Err))...)
+}
+
+// LogValue returns the fields of the error as a group of attributes,
+// implementing slog.LogValuer.
+func (e *SerializationError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("kind", "serialization"),
+		errorAttr(e.Err),
+	)
+}
+
+// LogValue returns the fields of the error as a group of attributes,
+// implementing slog.LogValuer. The snapshot is logged as its size.
+func (e *DeserializationError) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("kind", "deserialization"),
+		slog.Int("snapshot_size", len(e.Snapshot)),
+	}
+	if len(e.Shape) != 0 {
+		attrs = append(attrs, slog.String("shape", e.Shape))
+	}
+	if len(e.Path) != 0 {
+		attrs = append(attrs, slog.String("path", e.Path))
+	}
+	if e.Snippet != nil {
+		attrs = append(attrs,
+			slog.Int64("offset", e.Offset),
+			slog.String("snippet", string(e.Snippet)),
+		)
+	}
+	return slog.GroupValue(append(attrs, errorAttr(e.Err))...)
+}
+
+// LogValue returns the fields of the error as a group of attributes,
+// implementing slog.LogValuer.
+func (e *GenericAPIError) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("code", e.Code),
+		slog.String("message", e.Message),
+		slog.String("fault", e.Fault.String()),
+	}
+	if len(e.Metadata.RequestID) != 0 {
+		attrs = append(attrs, slog.String("request_id", e.Metadata.RequestID))
+	}
+	if e.Metadata.StatusCode != 0 {
+		attrs = append(attrs, slog.Int("status_code", e.Metadata.StatusCode))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// errorAttr returns the attribute for the wrapped error, logging errors that
+// implement slog.LogValuer as their value, and others as their message.
+func errorAttr(err error) slog.Attr {
+	if err == nil {
+		return slog.Attr{}
+	}
+	if v, ok := err.(slog.LogValuer); ok {
+		return slog.Any("error", v)
+	}
+	return slog.String("error", err.Error())
+}
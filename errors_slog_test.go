@@ -0,0 +1,79 @@
+//go:build go1.21
+// +build go1.21
+
+package smithy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func TestOperationError_LogValue(t *testing.T) {
+	err := &OperationError{
+		ServiceID:     "FooService",
+		OperationName: "GetFoo",
+		Phase:         "Deserialize",
+		Attempt:       2,
+		Err: fmt.Errorf("wrapped, %w", &GenericAPIError{
+			Code:     "FooError",
+			Message:  "foo failed",
+			Fault:    FaultClient,
+			Metadata: ErrorMetadata{RequestID: "abc123", StatusCode: 400},
+		}),
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Error("request failed", "err", err)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := map[string]interface{}{
+		"service":    "FooService",
+		"operation":  "GetFoo",
+		"phase":      "Deserialize",
+		"attempt":    float64(2),
+		"code":       "FooError",
+		"fault":      "client",
+		"request_id": "abc123",
+		"error":      "wrapped, api error FooError: foo failed",
+	}
+	if e, a := expect, entry["err"]; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestDeserializationError_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Error("failed", "err", &OperationError{
+		ServiceID:     "FooService",
+		OperationName: "GetFoo",
+		Err:           &DeserializationError{Err: fmt.Errorf("bad json"), Snapshot: []byte("{")},
+	})
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := map[string]interface{}{
+		"service":   "FooService",
+		"operation": "GetFoo",
+		"error": map[string]interface{}{
+			"kind":          "deserialization",
+			"snapshot_size": float64(1),
+			"error":         "bad json",
+		},
+	}
+	if e, a := expect, entry["err"]; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
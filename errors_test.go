@@ -0,0 +1,188 @@
+package smithy
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestClassifyCanceled(t *testing.T) {
+	sendErr := fmt.Errorf("connection reset")
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	deadlineCtx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	cases := map[string]struct {
+		Ctx            context.Context
+		Err            error
+		ExpectCanceled bool
+		ExpectIs       error
+	}{
+		"nil error": {
+			Ctx: canceledCtx,
+		},
+		"not canceled": {
+			Ctx:      context.Background(),
+			Err:      sendErr,
+			ExpectIs: sendErr,
+		},
+		"canceled": {
+			Ctx:            canceledCtx,
+			Err:            sendErr,
+			ExpectCanceled: true,
+			ExpectIs:       context.Canceled,
+		},
+		"deadline exceeded": {
+			Ctx:            deadlineCtx,
+			Err:            sendErr,
+			ExpectCanceled: true,
+			ExpectIs:       context.DeadlineExceeded,
+		},
+		"already canceled error": {
+			Ctx:            canceledCtx,
+			Err:            fmt.Errorf("wrapped, %w", &CanceledError{Err: context.DeadlineExceeded}),
+			ExpectCanceled: true,
+			ExpectIs:       context.DeadlineExceeded,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ClassifyCanceled(c.Ctx, c.Err)
+			if c.Err == nil {
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				return
+			}
+
+			if e, a := c.ExpectCanceled, IsCanceledError(err); e != a {
+				t.Errorf("expect canceled %v, got %v", e, a)
+			}
+			if !errors.Is(err, c.ExpectIs) {
+				t.Errorf("expect error to be %v, got %v", c.ExpectIs, err)
+			}
+
+			var canceledErr *CanceledError
+			if errors.As(err, &canceledErr) && canceledErr.OriginalErr == nil && c.Err == sendErr {
+				t.Errorf("expect original error retained")
+			}
+			if c.ExpectCanceled && errors.Is(err, sendErr) {
+				t.Errorf("expect original error not to be unwrapped")
+			}
+		})
+	}
+}
+
+type mockDecodeLocationError struct{}
+
+func (mockDecodeLocationError) Error() string       { return "decode failed" }
+func (mockDecodeLocationError) DecodePath() string  { return "items[1]" }
+func (mockDecodeLocationError) DecodeOffset() int64 { return 9 }
+
+func TestNewDeserializationError(t *testing.T) {
+	longPayload := []byte(`{"padding":"` + strings.Repeat("a", 100) + `","value":x}`)
+
+	cases := map[string]struct {
+		Payload       []byte
+		Unmarshal     func(payload []byte) error
+		Err           error
+		ExpectPath    string
+		ExpectOffset  int64
+		ExpectSnippet string
+		ExpectMessage string
+	}{
+		"json syntax error": {
+			Payload:       []byte(`{"a": 1,}`),
+			Unmarshal:     func(b []byte) error { return json.Unmarshal(b, new(interface{})) },
+			ExpectOffset:  9,
+			ExpectSnippet: `{"a": 1,}`,
+			ExpectMessage: `deserialization failed, shape Foo, offset 9 near "{\"a\": 1,}", wrapped, invalid character '}' looking for beginning of object key string`,
+		},
+		"json type error": {
+			Payload: []byte(`{"a": "b"}`),
+			Unmarshal: func(b []byte) error {
+				return json.Unmarshal(b, &struct{ A int }{})
+			},
+			ExpectPath:    "a",
+			ExpectOffset:  9,
+			ExpectSnippet: `{"a": "b"}`,
+		},
+		"xml syntax error": {
+			Payload: []byte("<a>\n<b></c>\n</a>"),
+			Unmarshal: func(b []byte) error {
+				return xml.Unmarshal(b, new(struct{}))
+			},
+			ExpectOffset:  4,
+			ExpectSnippet: "<a>\n<b></c>\n</a>",
+		},
+		"decode location": {
+			Payload:       []byte(`{"items":[1,"x"]}`),
+			Err:           mockDecodeLocationError{},
+			ExpectPath:    "items[1]",
+			ExpectOffset:  9,
+			ExpectSnippet: `{"items":[1,"x"]}`,
+			ExpectMessage: `deserialization failed, shape Foo, member items[1], offset 9 near "{\"items\":[1,\"x\"]}", decode failed`,
+		},
+		"bounded snippet": {
+			Payload:       longPayload,
+			Unmarshal:     func(b []byte) error { return json.Unmarshal(b, new(interface{})) },
+			ExpectOffset:  int64(len(longPayload) - 1),
+			ExpectSnippet: string(longPayload[len(longPayload)-1-MaxDeserializationSnippetSize/2:]),
+		},
+		"no location": {
+			Payload:       []byte(`{}`),
+			Err:           fmt.Errorf("bad value"),
+			ExpectMessage: "deserialization failed, shape Foo, bad value",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := c.Err
+			if c.Unmarshal != nil {
+				err = fmt.Errorf("wrapped, %w", c.Unmarshal(c.Payload))
+			}
+
+			e := NewDeserializationError(err, "Foo", c.Payload)
+			if !errors.Is(e, err) {
+				t.Errorf("expect error to wrap %v", err)
+			}
+			if e, a := c.ExpectPath, e.Path; e != a {
+				t.Errorf("expect %q path, got %q", e, a)
+			}
+			if e, a := c.ExpectOffset, e.Offset; e != a {
+				t.Errorf("expect %v offset, got %v", e, a)
+			}
+			if e, a := c.ExpectSnippet, string(e.Snippet); e != a {
+				t.Errorf("expect %q snippet, got %q", e, a)
+			}
+			if len(c.ExpectMessage) != 0 {
+				if e, a := c.ExpectMessage, e.Error(); e != a {
+					t.Errorf("expect %q message, got %q", e, a)
+				}
+			}
+		})
+	}
+}
+
+func TestDeserializationError_AddNestedPath(t *testing.T) {
+	e := &DeserializationError{}
+	e.AddNestedPath("name")
+	e.AddNestedPath("[2]")
+	e.AddNestedPath("Items")
+
+	if e, a := "Items[2].name", e.Path; e != a {
+		t.Errorf("expect %q path, got %q", e, a)
+	}
+	if e, a := "deserialization failed, member Items[2].name", e.Error(); e != a {
+		t.Errorf("expect %q message, got %q", e, a)
+	}
+}
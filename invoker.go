@@ -0,0 +1,19 @@
+package smithy
+
+import "context"
+
+// Invoker invokes an operation with input I, returning the operation's output
+// O. Invoker allows frameworks, such as generic caching layers and test
+// harnesses, to call operations dynamically without referencing each
+// generated client method.
+type Invoker[I, O any] interface {
+	Invoke(ctx context.Context, input I) (O, error)
+}
+
+// InvokerFunc is a function which satisfies the Invoker interface.
+type InvokerFunc[I, O any] func(ctx context.Context, input I) (O, error)
+
+// Invoke calls the wrapped function.
+func (fn InvokerFunc[I, O]) Invoke(ctx context.Context, input I) (O, error) {
+	return fn(ctx, input)
+}
@@ -0,0 +1,21 @@
+package smithy
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+func TestInvokerFunc(t *testing.T) {
+	var invoker Invoker[int, string] = InvokerFunc[int, string](func(ctx context.Context, input int) (string, error) {
+		return strconv.Itoa(input), nil
+	})
+
+	out, err := invoker.Invoke(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "42", out; e != a {
+		t.Errorf("expect %v output, got %v", e, a)
+	}
+}
@@ -0,0 +1,172 @@
+package io
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ReaderLen returns the number of unread bytes of r, if it can be
+// determined. The length is known for readers with a Len method, such as
+// bytes.Reader and strings.Reader, and for io.Seekers.
+func ReaderLen(r io.Reader) (int64, bool) {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len()), true
+	case io.Seeker:
+		cur, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, false
+		}
+		if _, err := v.Seek(cur, io.SeekStart); err != nil {
+			return 0, false
+		}
+		return end - cur, true
+	default:
+		return 0, false
+	}
+}
+
+// JoinReaders returns a reader that is the logical concatenation of the
+// readers provided, and the aggregate length of their unread bytes. The
+// length is -1 if the length of any reader cannot be determined.
+//
+// If every reader is an io.ReadSeeker the returned reader is a
+// MultiReadSeeker, so a request body built from it can be rewound for
+// retries.
+func JoinReaders(readers ...io.Reader) (io.Reader, int64, error) {
+	seekers := make([]io.ReadSeeker, 0, len(readers))
+	for _, r := range readers {
+		s, ok := r.(io.ReadSeeker)
+		if !ok {
+			break
+		}
+		seekers = append(seekers, s)
+	}
+	if len(seekers) == len(readers) {
+		r, err := NewMultiReadSeeker(seekers...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return r, r.Size(), nil
+	}
+
+	var size int64
+	for _, r := range readers {
+		n, ok := ReaderLen(r)
+		if !ok {
+			size = -1
+			break
+		}
+		size += n
+	}
+	return io.MultiReader(readers...), size, nil
+}
+
+// MultiReadSeeker is the logical concatenation of io.ReadSeekers. Each
+// reader's content starts at its position when the MultiReadSeeker was
+// created.
+type MultiReadSeeker struct {
+	parts []multiPart
+	size  int64
+	pos   int64
+}
+
+type multiPart struct {
+	reader io.ReadSeeker
+	start  int64 // position of the part's content within the part's reader
+	offset int64 // offset of the part's content within the MultiReadSeeker
+	length int64
+}
+
+// NewMultiReadSeeker returns a MultiReadSeeker that concatenates the
+// unread content of the readers provided. Returns an error if the length of
+// a reader cannot be determined.
+func NewMultiReadSeeker(readers ...io.ReadSeeker) (*MultiReadSeeker, error) {
+	m := &MultiReadSeeker{
+		parts: make([]multiPart, 0, len(readers)),
+	}
+	for i, r := range readers {
+		start, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get position of reader %d, %w", i, err)
+		}
+		n, ok := ReaderLen(r)
+		if !ok {
+			return nil, fmt.Errorf("failed to get length of reader %d", i)
+		}
+		m.parts = append(m.parts, multiPart{
+			reader: r,
+			start:  start,
+			offset: m.size,
+			length: n,
+		})
+		m.size += n
+	}
+	return m, nil
+}
+
+// Size returns the aggregate length of the readers.
+func (m *MultiReadSeeker) Size() int64 {
+	return m.size
+}
+
+// Len returns the number of unread bytes.
+func (m *MultiReadSeeker) Len() int {
+	if m.pos >= m.size {
+		return 0
+	}
+	return int(m.size - m.pos)
+}
+
+// Read reads from the part containing the current position, moving to the
+// next part when a part is exhausted.
+func (m *MultiReadSeeker) Read(p []byte) (int, error) {
+	for _, part := range m.parts {
+		if m.pos >= part.offset+part.length {
+			continue
+		}
+		rel := m.pos - part.offset
+		if _, err := part.reader.Seek(part.start+rel, io.SeekStart); err != nil {
+			return 0, err
+		}
+
+		remain := part.length - rel
+		if int64(len(p)) > remain {
+			p = p[:remain]
+		}
+		n, err := part.reader.Read(p)
+		m.pos += int64(n)
+		if err == io.EOF {
+			if int64(n) < remain {
+				return n, io.ErrUnexpectedEOF
+			}
+			err = nil
+		}
+		return n, err
+	}
+	return 0, io.EOF
+}
+
+// Seek sets the position of the next Read, relative to the start of the
+// concatenated content.
+func (m *MultiReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += m.pos
+	case io.SeekEnd:
+		offset += m.size
+	default:
+		return 0, fmt.Errorf("invalid whence, %d", whence)
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	m.pos = offset
+	return offset, nil
+}
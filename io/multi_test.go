@@ -0,0 +1,113 @@
+package io
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+type readOnly struct {
+	io.Reader
+}
+
+func TestJoinReaders(t *testing.T) {
+	cases := map[string]struct {
+		readers    []io.Reader
+		expectSize int64
+		expectSeek bool
+	}{
+		"seekable": {
+			readers: []io.Reader{
+				strings.NewReader("hello "),
+				bytes.NewReader([]byte("world")),
+			},
+			expectSize: 11,
+			expectSeek: true,
+		},
+		"unseekable with len": {
+			readers: []io.Reader{
+				strings.NewReader("hello "),
+				bytes.NewBufferString("world"),
+			},
+			expectSize: 11,
+		},
+		"unknown length": {
+			readers: []io.Reader{
+				strings.NewReader("hello "),
+				readOnly{strings.NewReader("world")},
+			},
+			expectSize: -1,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, size, err := JoinReaders(c.readers...)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.expectSize, size; e != a {
+				t.Errorf("expect size %v, got %v", e, a)
+			}
+			if _, ok := r.(io.Seeker); ok != c.expectSeek {
+				t.Errorf("expect seekable %v, got %v", c.expectSeek, ok)
+			}
+
+			b, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := "hello world", string(b); e != a {
+				t.Errorf("expect %q, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestMultiReadSeeker(t *testing.T) {
+	first := strings.NewReader("xxhello ")
+	first.Seek(2, io.SeekStart)
+
+	m, err := NewMultiReadSeeker(first, strings.NewReader(""), strings.NewReader("world"))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := int64(11), m.Size(); e != a {
+		t.Fatalf("expect size %v, got %v", e, a)
+	}
+
+	b, _ := ioutil.ReadAll(m)
+	if e, a := "hello world", string(b); e != a {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+	if e, a := 0, m.Len(); e != a {
+		t.Errorf("expect %v unread, got %v", e, a)
+	}
+
+	if _, err := m.Seek(4, io.SeekStart); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	p := make([]byte, 5)
+	n, err := io.ReadFull(m, p)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "o wor", string(p[:n]); e != a {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+
+	pos, _ := m.Seek(-2, io.SeekEnd)
+	if e, a := int64(9), pos; e != a {
+		t.Errorf("expect position %v, got %v", e, a)
+	}
+	b, _ = ioutil.ReadAll(m)
+	if e, a := "ld", string(b); e != a {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+
+	if _, err := m.Seek(-1, io.SeekStart); err == nil {
+		t.Errorf("expect error for negative position")
+	}
+}
@@ -0,0 +1,103 @@
+package io
+
+import (
+	"io"
+)
+
+// PrefixBuffer is an io.Writer that retains only the first N bytes written
+// to it, and counts the total number of bytes written. It can be used to
+// capture the beginning of a streamed payload for logging or error
+// reporting without retaining the whole payload.
+type PrefixBuffer struct {
+	buf   []byte
+	limit int
+	total int64
+}
+
+// NewPrefixBuffer returns a PrefixBuffer that retains at most limit bytes.
+func NewPrefixBuffer(limit int) *PrefixBuffer {
+	if limit < 0 {
+		limit = 0
+	}
+	return &PrefixBuffer{
+		limit: limit,
+	}
+}
+
+// Write retains the bytes of p up to the buffer's limit. Always returns
+// len(p), and a nil error.
+func (b *PrefixBuffer) Write(p []byte) (int, error) {
+	if remain := b.limit - len(b.buf); remain > 0 {
+		n := len(p)
+		if n > remain {
+			n = remain
+		}
+		b.buf = append(b.buf, p[:n]...)
+	}
+	b.total += int64(len(p))
+	return len(p), nil
+}
+
+// Bytes returns the retained prefix. The slice is only valid until the next
+// call to Write or Reset.
+func (b *PrefixBuffer) Bytes() []byte {
+	return b.buf
+}
+
+// Written returns the total number of bytes written to the buffer,
+// including those not retained.
+func (b *PrefixBuffer) Written() int64 {
+	return b.total
+}
+
+// Truncated returns whether more bytes were written to the buffer than were
+// retained.
+func (b *PrefixBuffer) Truncated() bool {
+	return b.total > int64(len(b.buf))
+}
+
+// Reset discards the retained prefix and written count, so the buffer can
+// be reused.
+func (b *PrefixBuffer) Reset() {
+	b.buf = b.buf[:0]
+	b.total = 0
+}
+
+// PrefixCaptureReader wraps an io.Reader, capturing the first N bytes read
+// from the underlying reader into a PrefixBuffer.
+type PrefixCaptureReader struct {
+	reader io.Reader
+	prefix *PrefixBuffer
+}
+
+// NewPrefixCaptureReader returns a PrefixCaptureReader that captures at
+// most limit bytes read from r.
+func NewPrefixCaptureReader(r io.Reader, limit int) *PrefixCaptureReader {
+	return &PrefixCaptureReader{
+		reader: r,
+		prefix: NewPrefixBuffer(limit),
+	}
+}
+
+// Read reads from the underlying reader, capturing the bytes read up to the
+// prefix limit.
+func (r *PrefixCaptureReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.prefix.Write(p[:n])
+	}
+	return n, err
+}
+
+// Close closes the underlying reader if it is an io.Closer.
+func (r *PrefixCaptureReader) Close() error {
+	if c, ok := r.reader.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Prefix returns the PrefixBuffer holding the bytes captured so far.
+func (r *PrefixCaptureReader) Prefix() *PrefixBuffer {
+	return r.prefix
+}
@@ -0,0 +1,115 @@
+package io
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestPrefixBuffer(t *testing.T) {
+	cases := map[string]struct {
+		limit     int
+		writes    []string
+		expect    string
+		written   int64
+		truncated bool
+	}{
+		"under limit": {
+			limit:   10,
+			writes:  []string{"abc", "def"},
+			expect:  "abcdef",
+			written: 6,
+		},
+		"exactly limit": {
+			limit:   6,
+			writes:  []string{"abc", "def"},
+			expect:  "abcdef",
+			written: 6,
+		},
+		"over limit": {
+			limit:     4,
+			writes:    []string{"abc", "def", "ghi"},
+			expect:    "abcd",
+			written:   9,
+			truncated: true,
+		},
+		"zero limit": {
+			limit:     0,
+			writes:    []string{"abc"},
+			expect:    "",
+			written:   3,
+			truncated: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			buf := NewPrefixBuffer(c.limit)
+			for _, w := range c.writes {
+				n, err := buf.Write([]byte(w))
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				if e, a := len(w), n; e != a {
+					t.Errorf("expect %v written, got %v", e, a)
+				}
+			}
+			if e, a := c.expect, string(buf.Bytes()); e != a {
+				t.Errorf("expect %q, got %q", e, a)
+			}
+			if e, a := c.written, buf.Written(); e != a {
+				t.Errorf("expect %v total, got %v", e, a)
+			}
+			if e, a := c.truncated, buf.Truncated(); e != a {
+				t.Errorf("expect truncated %v, got %v", e, a)
+			}
+
+			buf.Reset()
+			if len(buf.Bytes()) != 0 || buf.Written() != 0 {
+				t.Errorf("expect empty buffer after reset")
+			}
+		})
+	}
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestPrefixCaptureReader(t *testing.T) {
+	body := &closeRecorder{Reader: strings.NewReader("hello world")}
+	r := NewPrefixCaptureReader(body, 5)
+
+	var out bytes.Buffer
+	if _, err := io.Copy(&out, r); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "hello world", out.String(); e != a {
+		t.Errorf("expect body %q, got %q", e, a)
+	}
+	if e, a := "hello", string(r.Prefix().Bytes()); e != a {
+		t.Errorf("expect prefix %q, got %q", e, a)
+	}
+	if !r.Prefix().Truncated() {
+		t.Errorf("expect prefix truncated")
+	}
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if !body.closed {
+		t.Errorf("expect underlying reader closed")
+	}
+
+	if err := NewPrefixCaptureReader(ioutil.NopCloser(nil), 1).Close(); err != nil {
+		t.Errorf("expect no error, got %v", err)
+	}
+}
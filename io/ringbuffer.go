@@ -74,6 +74,35 @@ func (r *RingBuffer) Read(p []byte) (int, error) {
 	return readCount, nil
 }
 
+// Peek copies the unread data on the ring buffer into the byte slice
+// provided, without consuming it. Returns the number of bytes copied, and
+// io.EOF if the ring buffer is empty.
+func (r *RingBuffer) Peek(p []byte) (int, error) {
+	if r.size == 0 {
+		return 0, io.EOF
+	}
+
+	n := r.size
+	if len(p) < n {
+		n = len(p)
+	}
+	start := r.start
+	for j := 0; j < n; j++ {
+		if start == len(r.slice) {
+			start = 0
+		}
+		p[j] = r.slice[start]
+		start++
+	}
+	return n, nil
+}
+
+// Cap returns the capacity of the ring buffer, the maximum number of bytes
+// it retains.
+func (r *RingBuffer) Cap() int {
+	return len(r.slice)
+}
+
 // Len returns the number of unread bytes in the buffer.
 func (r *RingBuffer) Len() int {
 	return r.size
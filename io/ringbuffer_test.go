@@ -463,3 +463,39 @@ func TestRingBufferWriteRead(t *testing.T) {
 		})
 	}
 }
+
+func TestRingBuffer_Peek(t *testing.T) {
+	ringBuffer := NewRingBuffer(make([]byte, 5))
+
+	p := make([]byte, 5)
+	if _, err := ringBuffer.Peek(p); err != io.EOF {
+		t.Errorf("expect EOF for empty buffer, got %v", err)
+	}
+
+	ringBuffer.Write([]byte("hello world"))
+
+	n, err := ringBuffer.Peek(p[:3])
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "wor", string(p[:n]); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	n, _ = ringBuffer.Peek(make([]byte, 10))
+	if e, a := 5, n; e != a {
+		t.Errorf("expect %v bytes peeked, got %v", e, a)
+	}
+
+	if e, a := 5, ringBuffer.Len(); e != a {
+		t.Errorf("expect peek to not consume, got %v unread", a)
+	}
+	if e, a := 5, ringBuffer.Cap(); e != a {
+		t.Errorf("expect %v capacity, got %v", e, a)
+	}
+
+	b, _ := ioutil.ReadAll(ringBuffer)
+	if e, a := "world", string(b); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
@@ -0,0 +1,165 @@
+package io
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Segment is a window of a larger body, identified by its offset and
+// length in bytes.
+type Segment struct {
+	Offset int64
+	Length int64
+}
+
+// SplitSegments splits a body of size bytes into segments of segmentSize
+// bytes. Every segment starts at a multiple of segmentSize, and only the
+// last segment may be shorter. An empty body has no segments.
+func SplitSegments(size, segmentSize int64) ([]Segment, error) {
+	if size < 0 {
+		return nil, fmt.Errorf("invalid body size, %d", size)
+	}
+	if segmentSize <= 0 {
+		return nil, fmt.Errorf("invalid segment size, %d", segmentSize)
+	}
+
+	segments := make([]Segment, 0, (size+segmentSize-1)/segmentSize)
+	for offset := int64(0); offset < size; offset += segmentSize {
+		length := segmentSize
+		if remain := size - offset; remain < length {
+			length = remain
+		}
+		segments = append(segments, Segment{Offset: offset, Length: length})
+	}
+	return segments, nil
+}
+
+// AlignedSegmentSize returns the smallest segment size, of at least
+// minSize and a multiple of align, that splits a body of size bytes into no
+// more than maxSegments segments.
+func AlignedSegmentSize(size, minSize int64, maxSegments int, align int64) (int64, error) {
+	if maxSegments <= 0 {
+		return 0, fmt.Errorf("invalid max segments, %d", maxSegments)
+	}
+	if align <= 0 {
+		return 0, fmt.Errorf("invalid alignment, %d", align)
+	}
+
+	segmentSize := minSize
+	if n := (size + int64(maxSegments) - 1) / int64(maxSegments); n > segmentSize {
+		segmentSize = n
+	}
+	if rem := segmentSize % align; rem != 0 || segmentSize == 0 {
+		segmentSize += align - rem
+	}
+	return segmentSize, nil
+}
+
+// SegmentBody splits the unread content of a seekable body into segments
+// of segmentSize bytes. Segment offsets are positions within the body, with
+// the first segment starting at the body's current position.
+func SegmentBody(body io.ReadSeeker, segmentSize int64) ([]Segment, error) {
+	start, err := body.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get position of body, %w", err)
+	}
+	size, ok := ReaderLen(body)
+	if !ok {
+		return nil, errors.New("failed to get length of body")
+	}
+
+	segments, err := SplitSegments(size, segmentSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range segments {
+		segments[i].Offset += start
+	}
+	return segments, nil
+}
+
+// SegmentReader is an io.ReadSeeker limited to a segment of an underlying
+// body. Positions are relative to the start of the segment.
+//
+// If the body is an io.ReaderAt, reads use ReadAt and segment readers of
+// the same body may be read concurrently. Otherwise the body is seeked
+// before each read, and segment readers of the same body must not be read
+// concurrently.
+type SegmentReader struct {
+	body    io.ReadSeeker
+	segment Segment
+	pos     int64
+}
+
+// NewSegmentReader returns a SegmentReader for the segment of body. The
+// segment offset is a position within the body.
+func NewSegmentReader(body io.ReadSeeker, segment Segment) *SegmentReader {
+	return &SegmentReader{
+		body:    body,
+		segment: segment,
+	}
+}
+
+// Segment returns the segment of the body the reader is limited to.
+func (r *SegmentReader) Segment() Segment {
+	return r.segment
+}
+
+// Len returns the number of unread bytes in the segment.
+func (r *SegmentReader) Len() int {
+	if r.pos >= r.segment.Length {
+		return 0
+	}
+	return int(r.segment.Length - r.pos)
+}
+
+// Read reads from the segment of the body.
+func (r *SegmentReader) Read(p []byte) (int, error) {
+	if r.pos >= r.segment.Length {
+		return 0, io.EOF
+	}
+	if remain := r.segment.Length - r.pos; int64(len(p)) > remain {
+		p = p[:remain]
+	}
+
+	off := r.segment.Offset + r.pos
+	var n int
+	var err error
+	if ra, ok := r.body.(io.ReaderAt); ok {
+		n, err = ra.ReadAt(p, off)
+	} else {
+		if _, err = r.body.Seek(off, io.SeekStart); err != nil {
+			return 0, err
+		}
+		n, err = r.body.Read(p)
+	}
+	r.pos += int64(n)
+
+	if err == io.EOF && r.pos < r.segment.Length {
+		return n, io.ErrUnexpectedEOF
+	}
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+// Seek sets the position of the next Read, relative to the start of the
+// segment.
+func (r *SegmentReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.pos
+	case io.SeekEnd:
+		offset += r.segment.Length
+	default:
+		return 0, fmt.Errorf("invalid whence, %d", whence)
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	r.pos = offset
+	return offset, nil
+}
@@ -0,0 +1,171 @@
+package io
+
+import (
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitSegments(t *testing.T) {
+	cases := map[string]struct {
+		size, segmentSize int64
+		expect            []Segment
+		expectErr         bool
+	}{
+		"even": {
+			size: 10, segmentSize: 5,
+			expect: []Segment{{0, 5}, {5, 5}},
+		},
+		"remainder": {
+			size: 11, segmentSize: 5,
+			expect: []Segment{{0, 5}, {5, 5}, {10, 1}},
+		},
+		"smaller than segment": {
+			size: 3, segmentSize: 5,
+			expect: []Segment{{0, 3}},
+		},
+		"empty": {
+			size: 0, segmentSize: 5,
+			expect: []Segment{},
+		},
+		"invalid segment size": {
+			size: 10, segmentSize: 0,
+			expectErr: true,
+		},
+		"invalid size": {
+			size: -1, segmentSize: 5,
+			expectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := SplitSegments(c.size, c.segmentSize)
+			if c.expectErr {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.expect, actual; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestAlignedSegmentSize(t *testing.T) {
+	const mib = 1024 * 1024
+
+	cases := map[string]struct {
+		size, minSize int64
+		maxSegments   int
+		align         int64
+		expect        int64
+	}{
+		"min size": {
+			size: 10 * mib, minSize: 5 * mib, maxSegments: 10000, align: mib,
+			expect: 5 * mib,
+		},
+		"max segments": {
+			size: 100000 * mib, minSize: 5 * mib, maxSegments: 10000, align: mib,
+			expect: 10 * mib,
+		},
+		"rounded to alignment": {
+			size: 100001 * mib, minSize: 5 * mib, maxSegments: 10000, align: mib,
+			expect: 11 * mib,
+		},
+		"empty": {
+			size: 0, minSize: 0, maxSegments: 1, align: 4,
+			expect: 4,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := AlignedSegmentSize(c.size, c.minSize, c.maxSegments, c.align)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.expect, actual; e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+// seekOnly hides the io.ReaderAt implementation of the underlying reader.
+type seekOnly struct {
+	io.ReadSeeker
+}
+
+func TestSegmentReader(t *testing.T) {
+	cases := map[string]func() io.ReadSeeker{
+		"reader at": func() io.ReadSeeker {
+			return strings.NewReader("xxhello world")
+		},
+		"seeker": func() io.ReadSeeker {
+			return seekOnly{strings.NewReader("xxhello world")}
+		},
+	}
+
+	for name, newBody := range cases {
+		t.Run(name, func(t *testing.T) {
+			body := newBody()
+			body.Seek(2, io.SeekStart)
+
+			segments, err := SegmentBody(body, 4)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			var parts []string
+			for _, seg := range segments {
+				r := NewSegmentReader(body, seg)
+				if e, a := int(seg.Length), r.Len(); e != a {
+					t.Errorf("expect %v unread, got %v", e, a)
+				}
+				b, err := ioutil.ReadAll(r)
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				parts = append(parts, string(b))
+			}
+
+			if e, a := int64(2), segments[0].Offset; e != a {
+				t.Errorf("expect first segment offset %v, got %v", e, a)
+			}
+			if e, a := []string{"hell", "o wo", "rld"}, parts; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestSegmentReader_Seek(t *testing.T) {
+	body := strings.NewReader("hello world")
+	r := NewSegmentReader(body, Segment{Offset: 6, Length: 5})
+
+	ioutil.ReadAll(r)
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	b, _ := ioutil.ReadAll(r)
+	if e, a := "world", string(b); e != a {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+}
+
+func TestSegmentReader_ShortBody(t *testing.T) {
+	body := seekOnly{strings.NewReader("hello")}
+	r := NewSegmentReader(body, Segment{Offset: 2, Length: 10})
+
+	if _, err := ioutil.ReadAll(r); err != io.ErrUnexpectedEOF {
+		t.Errorf("expect unexpected EOF, got %v", err)
+	}
+}
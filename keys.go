@@ -0,0 +1,137 @@
+package smithy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/aws/smithy-go/logging"
+)
+
+// keyRegistry is the set of namespace qualified names of the registered
+// property and context keys.
+var keyRegistry = struct {
+	mu    sync.Mutex
+	names map[string]string
+}{names: map[string]string{}}
+
+// registerKey adds the name of the key to the registry, panicking if the name
+// is already registered.
+func registerKey(kind, name string) {
+	keyRegistry.mu.Lock()
+	defer keyRegistry.mu.Unlock()
+
+	if other, ok := keyRegistry.names[name]; ok {
+		panic(fmt.Sprintf("smithy: %s key %q already registered as a %s key", kind, name, other))
+	}
+	keyRegistry.names[name] = kind
+}
+
+// RegisteredKeys returns the namespace qualified names of the property and
+// context keys registered with RegisterPropertyKey, and RegisterContextKey,
+// sorted.
+func RegisteredKeys() []string {
+	keyRegistry.mu.Lock()
+	defer keyRegistry.mu.Unlock()
+
+	names := make([]string, 0, len(keyRegistry.names))
+	for name := range keyRegistry.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RegisterPropertyKey returns a PropertyKey for the name within the
+// namespace, see NewPropertyKey, registering the key's name. Panics if a
+// property, or context, key of the same name is already registered, so
+// packages declaring keys at init time detect collisions with the keys of
+// other packages when the program starts.
+//
+//	var regionKey = smithy.RegisterPropertyKey[string]("example.auth", "region")
+func RegisterPropertyKey[T any](namespace, name string) PropertyKey[T] {
+	k := NewPropertyKey[T](namespace, name)
+	registerKey("property", k.String())
+	return k
+}
+
+// ContextKey is a typed key for a value of a context.Context, scoped to a
+// namespace. Unlike the unexported struct types typically used as context
+// keys, a ContextKey carries the type of its value, so storing, or reading,
+// a value of the wrong type fails to compile, and its name identifies the
+// owner of the key when diagnosing collisions, see SetKeyDebugLogger.
+type ContextKey[T any] struct {
+	namespace string
+	name      string
+}
+
+// NewContextKey returns a ContextKey for the name within the namespace. The
+// namespace should identify the feature that owns the key, e.g.
+// "smithy.retry". Use RegisterContextKey to detect collisions with the keys
+// of other packages.
+func NewContextKey[T any](namespace, name string) ContextKey[T] {
+	return ContextKey[T]{namespace: namespace, name: name}
+}
+
+// RegisterContextKey returns a ContextKey for the name within the
+// namespace, registering the key's name. Panics if a property, or context,
+// key of the same name is already registered.
+func RegisterContextKey[T any](namespace, name string) ContextKey[T] {
+	k := NewContextKey[T](namespace, name)
+	registerKey("context", k.String())
+	return k
+}
+
+// String returns the namespace qualified name of the key.
+func (k ContextKey[T]) String() string {
+	if len(k.namespace) == 0 {
+		return k.name
+	}
+	return k.namespace + "." + k.name
+}
+
+// WithValue returns a copy of the context with the value stored at the key.
+// If key debugging is enabled, logs when the context already has a value of
+// the key, see SetKeyDebugLogger.
+func (k ContextKey[T]) WithValue(ctx context.Context, v T) context.Context {
+	if logger := getKeyDebugLogger(); logger != nil {
+		if prev := ctx.Value(k); prev != nil {
+			logger.Logf(logging.Debug, "context key %s overwritten, %v replaced by %v", k, prev, v)
+		}
+	}
+	return context.WithValue(ctx, k, v)
+}
+
+// Value returns the value of the key in the context, and whether a value of
+// the key's type was present.
+func (k ContextKey[T]) Value(ctx context.Context) (v T, ok bool) {
+	v, ok = ctx.Value(k).(T)
+	return v, ok
+}
+
+type keyDebug struct {
+	logger logging.Logger
+}
+
+var keyDebugLogger atomic.Value
+
+func init() {
+	keyDebugLogger.Store(keyDebug{})
+}
+
+// SetKeyDebugLogger enables key debugging, logging to the logger when the
+// value of an existing key is overwritten, in a Properties by Set, or in a
+// context by ContextKey.WithValue, to diagnose collisions between the keys of
+// different middleware. A nil logger disables key debugging, the default.
+//
+// Key debugging adds overhead to every Set, and should not be enabled in
+// production.
+func SetKeyDebugLogger(logger logging.Logger) {
+	keyDebugLogger.Store(keyDebug{logger: logger})
+}
+
+func getKeyDebugLogger() logging.Logger {
+	return keyDebugLogger.Load().(keyDebug).logger
+}
@@ -0,0 +1,114 @@
+package smithy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/logging"
+)
+
+var (
+	testRegisteredPropertyKey = RegisterPropertyKey[string]("smithy.test.register", "region")
+	testRegisteredContextKey  = RegisterContextKey[int]("smithy.test.register", "attempt")
+)
+
+func TestRegisterKey(t *testing.T) {
+	cases := map[string]struct {
+		Register  func()
+		ExpectErr string
+	}{
+		"duplicate property key": {
+			Register: func() {
+				RegisterPropertyKey[string]("smithy.test.register", "region")
+			},
+			ExpectErr: `property key "smithy.test.register.region" already registered as a property key`,
+		},
+		"property key of different type": {
+			Register: func() {
+				RegisterPropertyKey[int]("smithy.test.register", "region")
+			},
+			ExpectErr: `property key "smithy.test.register.region" already registered`,
+		},
+		"context key of property key name": {
+			Register: func() {
+				RegisterContextKey[string]("smithy.test.register", "region")
+			},
+			ExpectErr: `context key "smithy.test.register.region" already registered as a property key`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var err string
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Sprint(r)
+					}
+				}()
+				c.Register()
+			}()
+
+			if e, a := c.ExpectErr, err; !strings.Contains(a, e) {
+				t.Errorf("expect %q panic, got %q", e, a)
+			}
+		})
+	}
+
+	names := strings.Join(RegisteredKeys(), ",")
+	for _, e := range []string{testRegisteredContextKey.String(), testRegisteredPropertyKey.String()} {
+		if !strings.Contains(names, e) {
+			t.Errorf("expect %v registered, got %v", e, names)
+		}
+	}
+}
+
+func TestContextKey(t *testing.T) {
+	attemptKey := NewContextKey[int]("smithy.test", "attempt")
+	otherKey := NewContextKey[int]("smithy.test.other", "attempt")
+
+	ctx := attemptKey.WithValue(context.Background(), 2)
+
+	if v, ok := attemptKey.Value(ctx); !ok || v != 2 {
+		t.Errorf("expect 2 attempt, got %v, %v", v, ok)
+	}
+	if _, ok := otherKey.Value(ctx); ok {
+		t.Errorf("expect keys of different namespace to not collide")
+	}
+	if e, a := "smithy.test.attempt", attemptKey.String(); e != a {
+		t.Errorf("expect %v key name, got %v", e, a)
+	}
+}
+
+func TestKeyDebugLogger(t *testing.T) {
+	var logs []string
+	SetKeyDebugLogger(logging.LoggerFunc(func(_ logging.Classification, format string, v ...interface{}) {
+		logs = append(logs, fmt.Sprintf(format, v...))
+	}))
+	defer SetKeyDebugLogger(nil)
+
+	regionKey := NewPropertyKey[string]("smithy.test", "region")
+	var p Properties
+	regionKey.Set(&p, "us-west-2")
+	regionKey.Set(&p, "eu-west-1")
+
+	attemptKey := NewContextKey[int]("smithy.test", "attempt")
+	ctx := attemptKey.WithValue(context.Background(), 1)
+	attemptKey.WithValue(ctx, 2)
+
+	expect := []string{
+		"property key smithy.test.region overwritten, us-west-2 replaced by eu-west-1",
+		"context key smithy.test.attempt overwritten, 1 replaced by 2",
+	}
+	if e, a := strings.Join(expect, "\n"), strings.Join(logs, "\n"); e != a {
+		t.Errorf("expect logs\n%v\ngot\n%v", e, a)
+	}
+
+	SetKeyDebugLogger(nil)
+	regionKey.Set(&p, "us-east-1")
+	if e, a := 2, len(logs); e != a {
+		t.Errorf("expect %v logs after disabled, got %v", e, a)
+	}
+}
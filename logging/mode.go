@@ -0,0 +1,74 @@
+package logging
+
+// Mode is a bit-field of log categories that may be individually enabled for a
+// client. Middleware read the Mode to determine whether a given category of
+// information should be logged.
+type Mode uint64
+
+// Set of log categories that may be enabled on a Mode.
+const (
+	// LogRequestBody enables logging of serialized request bodies.
+	LogRequestBody Mode = 1 << iota
+
+	// LogResponseBody enables logging of raw response bodies.
+	LogResponseBody
+
+	// LogRetries enables logging of retry attempts and their cause.
+	LogRetries
+
+	// LogSigning enables logging of request signing details.
+	LogSigning
+
+	// LogOperationInput enables logging of operation input parameters, with
+	// sensitive members redacted.
+	LogOperationInput
+
+	// LogOperationOutput enables logging of operation results, with
+	// sensitive members redacted.
+	LogOperationOutput
+)
+
+// IsRequestBody returns whether request body logging is enabled.
+func (m Mode) IsRequestBody() bool {
+	return m.Has(LogRequestBody)
+}
+
+// IsResponseBody returns whether response body logging is enabled.
+func (m Mode) IsResponseBody() bool {
+	return m.Has(LogResponseBody)
+}
+
+// IsRetries returns whether retry logging is enabled.
+func (m Mode) IsRetries() bool {
+	return m.Has(LogRetries)
+}
+
+// IsSigning returns whether signing logging is enabled.
+func (m Mode) IsSigning() bool {
+	return m.Has(LogSigning)
+}
+
+// IsOperationInput returns whether operation input logging is enabled.
+func (m Mode) IsOperationInput() bool {
+	return m.Has(LogOperationInput)
+}
+
+// IsOperationOutput returns whether operation output logging is enabled.
+func (m Mode) IsOperationOutput() bool {
+	return m.Has(LogOperationOutput)
+}
+
+// Has returns whether all categories in flags are enabled.
+func (m Mode) Has(flags Mode) bool {
+	return m&flags == flags
+}
+
+// Set returns a copy of the Mode with the categories in flags enabled.
+func (m Mode) Set(flags Mode) Mode {
+	return m | flags
+}
+
+// Clear returns a copy of the Mode with the categories in flags disabled.
+func (m Mode) Clear(flags Mode) Mode {
+	return m &^ flags
+}
@@ -0,0 +1,41 @@
+package logging_test
+
+import (
+	"testing"
+
+	"github.com/aws/smithy-go/logging"
+)
+
+func TestMode(t *testing.T) {
+	var mode logging.Mode
+	if mode.IsRequestBody() || mode.IsResponseBody() || mode.IsRetries() || mode.IsSigning() {
+		t.Fatal("expect zero mode to have all categories disabled")
+	}
+
+	mode = mode.Set(logging.LogRetries | logging.LogSigning)
+	if !mode.IsRetries() {
+		t.Error("expect retries enabled")
+	}
+	if !mode.IsSigning() {
+		t.Error("expect signing enabled")
+	}
+	if mode.IsRequestBody() {
+		t.Error("expect request body disabled")
+	}
+
+	mode = mode.Clear(logging.LogSigning)
+	if mode.IsSigning() {
+		t.Error("expect signing disabled")
+	}
+	if !mode.Has(logging.LogRetries) {
+		t.Error("expect retries enabled")
+	}
+
+	mode = mode.Set(logging.LogOperationInput)
+	if !mode.IsOperationInput() {
+		t.Error("expect operation input enabled")
+	}
+	if mode.IsOperationOutput() {
+		t.Error("expect operation output disabled")
+	}
+}
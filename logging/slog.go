@@ -0,0 +1,154 @@
+//go:build go1.21
+// +build go1.21
+
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// SlogLogger is a Logger implementation that delegates log entries to a
+// log/slog Logger. Classifications are mapped to slog levels, with Warn
+// mapping to slog.LevelWarn, Debug mapping to slog.LevelDebug, and all other
+// classifications mapping to slog.LevelInfo.
+type SlogLogger struct {
+	Logger *slog.Logger
+
+	ctx context.Context
+}
+
+// NewSlogLogger returns a Logger that writes log entries to the provided slog
+// Logger. If logger is nil, slog.Default is used.
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogLogger{Logger: logger}
+}
+
+// Logf formats the message and logs it to the underlying slog Logger at the
+// level mapped from the classification.
+func (s *SlogLogger) Logf(classification Classification, format string, v ...interface{}) {
+	ctx := s.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	level := slogLevel(classification)
+	if !s.Logger.Enabled(ctx, level) {
+		return
+	}
+
+	s.Logger.Log(ctx, level, fmt.Sprintf(format, v...))
+}
+
+// WithContext returns a copy of the logger that passes ctx to the underlying
+// slog Logger for each log entry.
+func (s *SlogLogger) WithContext(ctx context.Context) Logger {
+	return &SlogLogger{Logger: s.Logger, ctx: ctx}
+}
+
+func slogLevel(classification Classification) slog.Level {
+	switch classification {
+	case Warn:
+		return slog.LevelWarn
+	case Debug:
+		return slog.LevelDebug
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// SlogHandler is a log/slog Handler implementation that writes records to a
+// Logger. Record levels are mapped to classifications, with records at or
+// above slog.LevelWarn classified as Warn, and records below slog.LevelInfo
+// classified as Debug. Record attributes are appended to the message as
+// key=value pairs.
+type SlogHandler struct {
+	Logger Logger
+
+	attrs  []slog.Attr
+	groups []string
+}
+
+// NewSlogHandler returns a slog Handler that writes records to the provided
+// Logger.
+func NewSlogHandler(logger Logger) *SlogHandler {
+	return &SlogHandler{Logger: logger}
+}
+
+// Enabled always returns true, filtering is left to the underlying Logger.
+func (h *SlogHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle formats the record and writes it to the underlying Logger.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	msg := r.Message
+	for _, a := range h.attrs {
+		msg += " " + a.String()
+	}
+	prefix := h.groupPrefix()
+	r.Attrs(func(a slog.Attr) bool {
+		a.Key = prefix + a.Key
+		msg += " " + a.String()
+		return true
+	})
+
+	WithContext(ctx, h.Logger).Logf(classification(r.Level), "%s", msg)
+	return nil
+}
+
+// WithAttrs returns a copy of the handler that includes attrs in each record
+// written.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	prefix := h.groupPrefix()
+
+	nh := h.clone()
+	for _, a := range attrs {
+		a.Key = prefix + a.Key
+		nh.attrs = append(nh.attrs, a)
+	}
+	return nh
+}
+
+// WithGroup returns a copy of the handler that qualifies subsequent
+// attribute keys with the group name.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if len(name) == 0 {
+		return h
+	}
+
+	nh := h.clone()
+	nh.groups = append(nh.groups, name)
+	return nh
+}
+
+func (h *SlogHandler) clone() *SlogHandler {
+	return &SlogHandler{
+		Logger: h.Logger,
+		attrs:  append([]slog.Attr(nil), h.attrs...),
+		groups: append([]string(nil), h.groups...),
+	}
+}
+
+func (h *SlogHandler) groupPrefix() string {
+	var prefix string
+	for _, g := range h.groups {
+		prefix += g + "."
+	}
+	return prefix
+}
+
+func classification(level slog.Level) Classification {
+	switch {
+	case level >= slog.LevelWarn:
+		return Warn
+	case level < slog.LevelInfo:
+		return Debug
+	default:
+		return ""
+	}
+}
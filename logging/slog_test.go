@@ -0,0 +1,57 @@
+//go:build go1.21
+// +build go1.21
+
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/logging"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	})))
+
+	logger.Logf(logging.Warn, "foo %s", "bar")
+	if e, a := "level=WARN msg=\"foo bar\"", buf.String(); !strings.Contains(a, e) {
+		t.Errorf("expect %q in %q", e, a)
+	}
+
+	buf.Reset()
+	logging.WithContext(context.Background(), logger).Logf(logging.Debug, "baz")
+	if e, a := "level=DEBUG msg=baz", buf.String(); !strings.Contains(a, e) {
+		t.Errorf("expect %q in %q", e, a)
+	}
+}
+
+func TestSlogHandler(t *testing.T) {
+	var entries []string
+	var classes []logging.Classification
+	logger := logging.LoggerFunc(func(c logging.Classification, format string, v ...interface{}) {
+		classes = append(classes, c)
+		entries = append(entries, v[0].(string))
+	})
+
+	l := slog.New(logging.NewSlogHandler(logger)).With("a", 1).WithGroup("g")
+	l.Warn("first", "b", "two")
+	l.Debug("second")
+	l.Info("third")
+
+	expectEntries := []string{"first a=1 g.b=two", "second a=1", "third a=1"}
+	expectClasses := []logging.Classification{logging.Warn, logging.Debug, ""}
+	for i := range expectEntries {
+		if e, a := expectEntries[i], entries[i]; e != a {
+			t.Errorf("%d, expect %q entry, got %q", i, e, a)
+		}
+		if e, a := expectClasses[i], classes[i]; e != a {
+			t.Errorf("%d, expect %q classification, got %q", i, e, a)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package smithyzaplogging
+
+import (
+	"fmt"
+
+	"github.com/aws/smithy-go/logging"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Logger is a logging.Logger implementation that delegates log entries to a
+// zap Logger. Classifications are mapped to zap levels, with Warn mapping to
+// zap.WarnLevel, Debug mapping to zap.DebugLevel, and all other
+// classifications mapping to zap.InfoLevel.
+type Logger struct {
+	Logger *zap.Logger
+}
+
+var _ logging.Logger = (*Logger)(nil)
+
+// NewLogger returns a Logger that writes log entries to the provided zap
+// Logger. If logger is nil, zap.L is used.
+func NewLogger(logger *zap.Logger) *Logger {
+	if logger == nil {
+		logger = zap.L()
+	}
+	return &Logger{Logger: logger}
+}
+
+// Logf formats the message and logs it to the underlying zap Logger at the
+// level mapped from the classification.
+func (l *Logger) Logf(classification logging.Classification, format string, v ...interface{}) {
+	ce := l.Logger.Check(zapLevel(classification), "")
+	if ce == nil {
+		return
+	}
+
+	ce.Message = fmt.Sprintf(format, v...)
+	ce.Write()
+}
+
+func zapLevel(classification logging.Classification) zapcore.Level {
+	switch classification {
+	case logging.Warn:
+		return zapcore.WarnLevel
+	case logging.Debug:
+		return zapcore.DebugLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+// Core is a zapcore.Core implementation that writes entries to a
+// logging.Logger. Entry levels are mapped to classifications, with entries at
+// or above zap.WarnLevel classified as Warn, and entries below zap.InfoLevel
+// classified as Debug. Entry fields are appended to the message as key=value
+// pairs.
+type Core struct {
+	Logger logging.Logger
+
+	fields []zapcore.Field
+}
+
+var _ zapcore.Core = (*Core)(nil)
+
+// NewCore returns a zapcore.Core that writes entries to the provided Logger.
+// Wrap it with zap.New to log with a zap Logger.
+func NewCore(logger logging.Logger) *Core {
+	return &Core{Logger: logger}
+}
+
+// Enabled always returns true, filtering is left to the underlying Logger.
+func (c *Core) Enabled(zapcore.Level) bool {
+	return true
+}
+
+// With returns a copy of the core that includes fields in each entry written.
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	return &Core{
+		Logger: c.Logger,
+		fields: append(append([]zapcore.Field(nil), c.fields...), fields...),
+	}
+}
+
+// Check adds the core to the checked entry.
+func (c *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(ent, c)
+}
+
+// Write formats the entry and writes it to the underlying Logger.
+func (c *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	msg := ent.Message
+	for _, f := range c.fields {
+		msg += " " + formatField(f)
+	}
+	for _, f := range fields {
+		msg += " " + formatField(f)
+	}
+
+	c.Logger.Logf(classification(ent.Level), "%s", msg)
+	return nil
+}
+
+// Sync is a no-op, the underlying Logger is not buffered.
+func (c *Core) Sync() error {
+	return nil
+}
+
+func formatField(f zapcore.Field) string {
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	return fmt.Sprintf("%s=%v", f.Key, enc.Fields[f.Key])
+}
+
+func classification(level zapcore.Level) logging.Classification {
+	switch {
+	case level >= zapcore.WarnLevel:
+		return logging.Warn
+	case level < zapcore.InfoLevel:
+		return logging.Debug
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,63 @@
+package smithyzaplogging
+
+import (
+	"testing"
+
+	"github.com/aws/smithy-go/logging"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := NewLogger(zap.New(core))
+
+	logger.Logf(logging.Warn, "foo %s", "bar")
+	logger.Logf(logging.Debug, "filtered")
+	logger.Logf("", "baz")
+
+	entries := logs.AllUntimed()
+	if e, a := 2, len(entries); e != a {
+		t.Fatalf("expect %v entries, got %v", e, a)
+	}
+
+	expectMessages := []string{"foo bar", "baz"}
+	expectLevels := []zapcore.Level{zapcore.WarnLevel, zapcore.InfoLevel}
+	for i, entry := range entries {
+		if e, a := expectMessages[i], entry.Message; e != a {
+			t.Errorf("%d, expect %q message, got %q", i, e, a)
+		}
+		if e, a := expectLevels[i], entry.Level; e != a {
+			t.Errorf("%d, expect %v level, got %v", i, e, a)
+		}
+	}
+}
+
+func TestCore(t *testing.T) {
+	var entries []string
+	var classes []logging.Classification
+	logger := logging.LoggerFunc(func(c logging.Classification, format string, v ...interface{}) {
+		classes = append(classes, c)
+		entries = append(entries, v[0].(string))
+	})
+
+	l := zap.New(NewCore(logger)).With(zap.Int("a", 1))
+	l.Warn("first", zap.String("b", "two"))
+	l.Debug("second")
+	l.Info("third")
+
+	expectEntries := []string{"first a=1 b=two", "second a=1", "third a=1"}
+	expectClasses := []logging.Classification{logging.Warn, logging.Debug, ""}
+	if e, a := len(expectEntries), len(entries); e != a {
+		t.Fatalf("expect %v entries, got %v", e, a)
+	}
+	for i := range expectEntries {
+		if e, a := expectEntries[i], entries[i]; e != a {
+			t.Errorf("%d, expect %q entry, got %q", i, e, a)
+		}
+		if e, a := expectClasses[i], classes[i]; e != a {
+			t.Errorf("%d, expect %q classification, got %q", i, e, a)
+		}
+	}
+}
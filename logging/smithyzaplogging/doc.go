@@ -0,0 +1,6 @@
+// Package smithyzaplogging implements a Smithy client logging adapter for the
+// zap logging library.
+//
+// The adapter is provided as a separate module so that clients which do not
+// use zap are not required to depend on it.
+package smithyzaplogging
@@ -0,0 +1,12 @@
+module github.com/aws/smithy-go/logging/smithyzaplogging
+
+go 1.22
+
+require (
+	github.com/aws/smithy-go v1.6.0
+	go.uber.org/zap v1.27.0
+)
+
+require go.uber.org/multierr v1.10.0 // indirect
+
+replace github.com/aws/smithy-go => ../../
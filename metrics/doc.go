@@ -0,0 +1,7 @@
+// Package metrics defines the interfaces used by clients and middleware to
+// publish measurements. The interfaces are modeled after the OpenTelemetry
+// metrics API, so that a MeterProvider may be bridged directly to an
+// OpenTelemetry SDK implementation.
+//
+// Clients default to NopMeterProvider, which discards all measurements.
+package metrics
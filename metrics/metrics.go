@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/aws/smithy-go"
+)
+
+// MeterProvider is the entry point for creating a Meter.
+type MeterProvider interface {
+	Meter(scope string, opts ...MeterOption) Meter
+}
+
+// MeterOption applies configuration to a Meter.
+type MeterOption func(o *MeterOptions)
+
+// MeterOptions represent configuration for a Meter.
+type MeterOptions struct {
+	Properties smithy.Properties
+}
+
+// Meter is the entry point for creation of measurement instruments.
+type Meter interface {
+	Int64Counter(name string, opts ...InstrumentOption) (Int64Counter, error)
+	Int64UpDownCounter(name string, opts ...InstrumentOption) (Int64UpDownCounter, error)
+	Int64Histogram(name string, opts ...InstrumentOption) (Int64Histogram, error)
+
+	Float64Counter(name string, opts ...InstrumentOption) (Float64Counter, error)
+	Float64UpDownCounter(name string, opts ...InstrumentOption) (Float64UpDownCounter, error)
+	Float64Histogram(name string, opts ...InstrumentOption) (Float64Histogram, error)
+}
+
+// InstrumentOption applies configuration to an instrument.
+type InstrumentOption func(o *InstrumentOptions)
+
+// InstrumentOptions represents configuration for an instrument.
+type InstrumentOptions struct {
+	UnitLabel   string
+	Description string
+}
+
+// WithUnit sets the unit label of an instrument.
+func WithUnit(unit string) InstrumentOption {
+	return func(o *InstrumentOptions) {
+		o.UnitLabel = unit
+	}
+}
+
+// WithDescription sets the description of an instrument.
+func WithDescription(description string) InstrumentOption {
+	return func(o *InstrumentOptions) {
+		o.Description = description
+	}
+}
+
+// Int64Counter is a monotonically increasing counter of int64 values.
+type Int64Counter interface {
+	Add(context.Context, int64, ...RecordMetricOption)
+}
+
+// Int64UpDownCounter is a counter of int64 values that may increase or
+// decrease.
+type Int64UpDownCounter interface {
+	Add(context.Context, int64, ...RecordMetricOption)
+}
+
+// Int64Histogram records a distribution of int64 values.
+type Int64Histogram interface {
+	Record(context.Context, int64, ...RecordMetricOption)
+}
+
+// Float64Counter is a monotonically increasing counter of float64 values.
+type Float64Counter interface {
+	Add(context.Context, float64, ...RecordMetricOption)
+}
+
+// Float64UpDownCounter is a counter of float64 values that may increase or
+// decrease.
+type Float64UpDownCounter interface {
+	Add(context.Context, float64, ...RecordMetricOption)
+}
+
+// Float64Histogram records a distribution of float64 values.
+type Float64Histogram interface {
+	Record(context.Context, float64, ...RecordMetricOption)
+}
+
+// RecordMetricOption applies configuration to a recorded measurement.
+type RecordMetricOption func(o *RecordMetricOptions)
+
+// RecordMetricOptions represents configuration for a recorded measurement.
+type RecordMetricOptions struct {
+	// Properties are the attributes associated with the measurement.
+	Properties smithy.Properties
+}
+
+// WithProperties sets the attributes associated with a measurement.
+func WithProperties(props smithy.Properties) RecordMetricOption {
+	return func(o *RecordMetricOptions) {
+		o.Properties = props
+	}
+}
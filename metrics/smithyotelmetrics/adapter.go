@@ -0,0 +1,198 @@
+package smithyotelmetrics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+// Adapt wraps a concrete OpenTelemetry SDK MeterProvider for use with Smithy
+// clients.
+//
+// Adapt can be called multiple times on a single MeterProvider.
+func Adapt(mp otelmetric.MeterProvider) metrics.MeterProvider {
+	return &meterProvider{otel: mp}
+}
+
+type meterProvider struct {
+	otel otelmetric.MeterProvider
+}
+
+var _ metrics.MeterProvider = (*meterProvider)(nil)
+
+func (p *meterProvider) Meter(scope string, opts ...metrics.MeterOption) metrics.Meter {
+	var o metrics.MeterOptions
+	for _, fn := range opts {
+		fn(&o)
+	}
+
+	return &meter{otel: p.otel.Meter(scope, otelmetric.WithInstrumentationAttributes(
+		toOTELKeyValues(o.Properties)...,
+	))}
+}
+
+type meter struct {
+	otel otelmetric.Meter
+}
+
+var _ metrics.Meter = (*meter)(nil)
+
+func (m *meter) Int64Counter(name string, opts ...metrics.InstrumentOption) (metrics.Int64Counter, error) {
+	unit, desc := resolveInstrumentOptions(opts)
+	i, err := m.otel.Int64Counter(name, otelmetric.WithUnit(unit), otelmetric.WithDescription(desc))
+	if err != nil {
+		return nil, err
+	}
+	return &int64Counter{otel: i}, nil
+}
+
+func (m *meter) Int64UpDownCounter(name string, opts ...metrics.InstrumentOption) (metrics.Int64UpDownCounter, error) {
+	unit, desc := resolveInstrumentOptions(opts)
+	i, err := m.otel.Int64UpDownCounter(name, otelmetric.WithUnit(unit), otelmetric.WithDescription(desc))
+	if err != nil {
+		return nil, err
+	}
+	return &int64UpDownCounter{otel: i}, nil
+}
+
+func (m *meter) Int64Histogram(name string, opts ...metrics.InstrumentOption) (metrics.Int64Histogram, error) {
+	unit, desc := resolveInstrumentOptions(opts)
+	i, err := m.otel.Int64Histogram(name, otelmetric.WithUnit(unit), otelmetric.WithDescription(desc))
+	if err != nil {
+		return nil, err
+	}
+	return &int64Histogram{otel: i}, nil
+}
+
+func (m *meter) Float64Counter(name string, opts ...metrics.InstrumentOption) (metrics.Float64Counter, error) {
+	unit, desc := resolveInstrumentOptions(opts)
+	i, err := m.otel.Float64Counter(name, otelmetric.WithUnit(unit), otelmetric.WithDescription(desc))
+	if err != nil {
+		return nil, err
+	}
+	return &float64Counter{otel: i}, nil
+}
+
+func (m *meter) Float64UpDownCounter(name string, opts ...metrics.InstrumentOption) (metrics.Float64UpDownCounter, error) {
+	unit, desc := resolveInstrumentOptions(opts)
+	i, err := m.otel.Float64UpDownCounter(name, otelmetric.WithUnit(unit), otelmetric.WithDescription(desc))
+	if err != nil {
+		return nil, err
+	}
+	return &float64UpDownCounter{otel: i}, nil
+}
+
+func (m *meter) Float64Histogram(name string, opts ...metrics.InstrumentOption) (metrics.Float64Histogram, error) {
+	unit, desc := resolveInstrumentOptions(opts)
+	i, err := m.otel.Float64Histogram(name, otelmetric.WithUnit(unit), otelmetric.WithDescription(desc))
+	if err != nil {
+		return nil, err
+	}
+	return &float64Histogram{otel: i}, nil
+}
+
+type int64Counter struct {
+	otel otelmetric.Int64Counter
+}
+
+func (i *int64Counter) Add(ctx context.Context, v int64, opts ...metrics.RecordMetricOption) {
+	i.otel.Add(ctx, v, otelmetric.WithAttributes(resolveRecordAttributes(opts)...))
+}
+
+type int64UpDownCounter struct {
+	otel otelmetric.Int64UpDownCounter
+}
+
+func (i *int64UpDownCounter) Add(ctx context.Context, v int64, opts ...metrics.RecordMetricOption) {
+	i.otel.Add(ctx, v, otelmetric.WithAttributes(resolveRecordAttributes(opts)...))
+}
+
+type int64Histogram struct {
+	otel otelmetric.Int64Histogram
+}
+
+func (i *int64Histogram) Record(ctx context.Context, v int64, opts ...metrics.RecordMetricOption) {
+	i.otel.Record(ctx, v, otelmetric.WithAttributes(resolveRecordAttributes(opts)...))
+}
+
+type float64Counter struct {
+	otel otelmetric.Float64Counter
+}
+
+func (i *float64Counter) Add(ctx context.Context, v float64, opts ...metrics.RecordMetricOption) {
+	i.otel.Add(ctx, v, otelmetric.WithAttributes(resolveRecordAttributes(opts)...))
+}
+
+type float64UpDownCounter struct {
+	otel otelmetric.Float64UpDownCounter
+}
+
+func (i *float64UpDownCounter) Add(ctx context.Context, v float64, opts ...metrics.RecordMetricOption) {
+	i.otel.Add(ctx, v, otelmetric.WithAttributes(resolveRecordAttributes(opts)...))
+}
+
+type float64Histogram struct {
+	otel otelmetric.Float64Histogram
+}
+
+func (i *float64Histogram) Record(ctx context.Context, v float64, opts ...metrics.RecordMetricOption) {
+	i.otel.Record(ctx, v, otelmetric.WithAttributes(resolveRecordAttributes(opts)...))
+}
+
+func resolveInstrumentOptions(opts []metrics.InstrumentOption) (unit, description string) {
+	var o metrics.InstrumentOptions
+	for _, fn := range opts {
+		fn(&o)
+	}
+	return o.UnitLabel, o.Description
+}
+
+func resolveRecordAttributes(opts []metrics.RecordMetricOption) []attribute.KeyValue {
+	var o metrics.RecordMetricOptions
+	for _, fn := range opts {
+		fn(&o)
+	}
+	return toOTELKeyValues(o.Properties)
+}
+
+// toOTELKeyValues converts properties to OTEL attributes. Keys are formatted
+// with fmt.Sprint, and values of unsupported types are formatted as strings.
+func toOTELKeyValues(props smithy.Properties) []attribute.KeyValue {
+	values := props.Values()
+	kvs := make([]attribute.KeyValue, 0, len(values))
+	for k, v := range values {
+		kvs = append(kvs, toOTELKeyValue(fmt.Sprint(k), v))
+	}
+	return kvs
+}
+
+func toOTELKeyValue(k string, v interface{}) attribute.KeyValue {
+	switch vv := v.(type) {
+	case bool:
+		return attribute.Bool(k, vv)
+	case []bool:
+		return attribute.BoolSlice(k, vv)
+	case int:
+		return attribute.Int(k, vv)
+	case []int:
+		return attribute.IntSlice(k, vv)
+	case int64:
+		return attribute.Int64(k, vv)
+	case []int64:
+		return attribute.Int64Slice(k, vv)
+	case float64:
+		return attribute.Float64(k, vv)
+	case []float64:
+		return attribute.Float64Slice(k, vv)
+	case string:
+		return attribute.String(k, vv)
+	case []string:
+		return attribute.StringSlice(k, vv)
+	default:
+		return attribute.String(k, fmt.Sprint(vv))
+	}
+}
@@ -0,0 +1,50 @@
+package smithyotelmetrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func TestAdapt(t *testing.T) {
+	meter := Adapt(noop.NewMeterProvider()).Meter("scope")
+
+	counter, err := meter.Int64Counter("counter", metrics.WithUnit("1"))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var props smithy.Properties
+	props.Set("key", "value")
+	counter.Add(context.Background(), 1, metrics.WithProperties(props))
+
+	histogram, err := meter.Float64Histogram("histogram", metrics.WithUnit("s"))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	histogram.Record(context.Background(), 1.5)
+}
+
+func TestToOTELKeyValues(t *testing.T) {
+	var props smithy.Properties
+	props.Set("string", "v")
+	props.Set("int", 1)
+	props.Set("bool", true)
+
+	kvs := toOTELKeyValues(props)
+	set := attribute.NewSet(kvs...)
+
+	if v, ok := set.Value("string"); !ok || v.AsString() != "v" {
+		t.Errorf("expect string attribute, got %v", v)
+	}
+	if v, ok := set.Value("int"); !ok || v.AsInt64() != 1 {
+		t.Errorf("expect int attribute, got %v", v)
+	}
+	if v, ok := set.Value("bool"); !ok || !v.AsBool() {
+		t.Errorf("expect bool attribute, got %v", v)
+	}
+}
@@ -0,0 +1,6 @@
+// Package smithyotelmetrics implements a Smithy client metrics adapter for the
+// OTEL Go SDK.
+//
+// The adapter is provided as a separate module so that clients which do not
+// use OpenTelemetry are not required to depend on it.
+package smithyotelmetrics
@@ -0,0 +1,11 @@
+module github.com/aws/smithy-go/metrics/smithyotelmetrics
+
+go 1.22
+
+require (
+	github.com/aws/smithy-go v1.6.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/metric v1.28.0
+)
+
+replace github.com/aws/smithy-go => ../../
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"time"
+)
+
+// AttemptInfo describes the request attempt of an operation being handled by
+// the middleware within a retry loop. Retry middleware set the AttemptInfo of
+// each attempt with WithAttemptInfo, to be read by the middleware that follow
+// it, e.g. signing, logging, and metrics.
+type AttemptInfo struct {
+	// Attempt is the number of the attempt, starting at 1 for the initial
+	// attempt.
+	Attempt int
+
+	// Start is the time the operation's first attempt started.
+	Start time.Time
+
+	// Delay is the total time the retry middleware has waited between the
+	// attempts made so far.
+	Delay time.Duration
+
+	// PreviousError is the error of the previous attempt, nil for the
+	// initial attempt.
+	PreviousError error
+}
+
+// IsRetry returns whether the attempt is a retry of a previous attempt.
+func (a AttemptInfo) IsRetry() bool {
+	return a.Attempt > 1
+}
+
+// Elapsed returns the time elapsed since the operation's first attempt
+// started, relative to now.
+func (a AttemptInfo) Elapsed(now time.Time) time.Duration {
+	if a.Start.IsZero() {
+		return 0
+	}
+	return now.Sub(a.Start)
+}
+
+type attemptInfoKey struct{}
+
+// WithAttemptInfo adds the AttemptInfo of the current attempt to the context,
+// scoped to middleware stack values.
+//
+// This API is called by retry middleware for each attempt it makes.
+func WithAttemptInfo(parent context.Context, info AttemptInfo) context.Context {
+	return WithStackValue(parent, attemptInfoKey{}, info)
+}
+
+// GetAttemptInfo retrieves the AttemptInfo of the current attempt from the
+// context. Returns false if the operation is not handled within a retry
+// middleware that sets the attempt info.
+func GetAttemptInfo(ctx context.Context) (info AttemptInfo, ok bool) {
+	info, ok = GetStackValue(ctx, attemptInfoKey{}).(AttemptInfo)
+	return info, ok
+}
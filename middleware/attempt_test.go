@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestAttemptInfo(t *testing.T) {
+	if _, ok := GetAttemptInfo(context.Background()); ok {
+		t.Fatalf("expect no attempt info")
+	}
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	prevErr := fmt.Errorf("throttled")
+
+	ctx := WithAttemptInfo(context.Background(), AttemptInfo{Attempt: 1, Start: start})
+	info, ok := GetAttemptInfo(ctx)
+	if !ok {
+		t.Fatalf("expect attempt info")
+	}
+	if info.IsRetry() {
+		t.Errorf("expect initial attempt not to be retry")
+	}
+
+	ctx = WithAttemptInfo(ctx, AttemptInfo{
+		Attempt:       2,
+		Start:         start,
+		Delay:         time.Second,
+		PreviousError: prevErr,
+	})
+	info, _ = GetAttemptInfo(ctx)
+	if !info.IsRetry() {
+		t.Errorf("expect attempt to be retry")
+	}
+	if e, a := prevErr, info.PreviousError; e != a {
+		t.Errorf("expect %v previous error, got %v", e, a)
+	}
+	if e, a := 3*time.Second, info.Elapsed(start.Add(3*time.Second)); e != a {
+		t.Errorf("expect %v elapsed, got %v", e, a)
+	}
+	if e, a := time.Duration(0), (AttemptInfo{}).Elapsed(start); e != a {
+		t.Errorf("expect %v elapsed, got %v", e, a)
+	}
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/smithy-go"
+)
+
+// CallOptions is the set of options for a single operation call, attached to
+// the context the operation is invoked with by WithCallOptions. Clients apply
+// the options to the operation's stack with ApplyCallOptions.
+type CallOptions struct {
+	// Timeout is the maximum duration of the operation call, including all
+	// attempts. Zero means no timeout.
+	Timeout time.Duration
+
+	// StackMutations are applied to the operation's stack before it is
+	// invoked, e.g. to add middleware for the single call.
+	StackMutations []func(*Stack) error
+
+	// Properties are additional options read by the operation's middleware.
+	Properties smithy.Properties
+}
+
+// CallOption applies configuration to the CallOptions of an operation call.
+type CallOption func(*CallOptions)
+
+// WithTimeout returns a CallOption that sets the operation call's timeout.
+func WithTimeout(d time.Duration) CallOption {
+	return func(o *CallOptions) {
+		o.Timeout = d
+	}
+}
+
+// WithStackMutation returns a CallOption that applies the mutation to the
+// operation's stack, e.g. adding middleware for only the single call.
+func WithStackMutation(fn func(*Stack) error) CallOption {
+	return func(o *CallOptions) {
+		o.StackMutations = append(o.StackMutations, fn)
+	}
+}
+
+// WithCallProperty returns a CallOption that sets the value on the call's
+// Properties.
+func WithCallProperty(key, value interface{}) CallOption {
+	return func(o *CallOptions) {
+		o.Properties.Set(key, value)
+	}
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns a context with the call options applied on top of
+// any call options already attached to the parent context. The options apply
+// to the operation calls made with the returned context.
+func WithCallOptions(parent context.Context, optFns ...CallOption) context.Context {
+	current := GetCallOptions(parent)
+
+	o := CallOptions{
+		Timeout:        current.Timeout,
+		StackMutations: append([]func(*Stack) error{}, current.StackMutations...),
+	}
+	o.Properties.SetAll(&current.Properties)
+
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	return context.WithValue(parent, callOptionsKey{}, o)
+}
+
+// GetCallOptions returns the CallOptions attached to the context, or the zero
+// value if none were attached.
+func GetCallOptions(ctx context.Context) CallOptions {
+	o, _ := ctx.Value(callOptionsKey{}).(CallOptions)
+	return o
+}
+
+// ApplyCallOptions applies the CallOptions of the context to the operation's
+// stack. Clients call this once the operation's stack is built, before it is
+// invoked.
+func ApplyCallOptions(ctx context.Context, stack *Stack) error {
+	o := GetCallOptions(ctx)
+
+	for _, fn := range o.StackMutations {
+		if err := fn(stack); err != nil {
+			return err
+		}
+	}
+
+	if o.Timeout > 0 {
+		return stack.Initialize.Add(&callTimeout{timeout: o.Timeout}, Before)
+	}
+	return nil
+}
+
+type callTimeout struct {
+	timeout time.Duration
+}
+
+func (*callTimeout) ID() string { return "CallTimeout" }
+
+func (m *callTimeout) HandleInitialize(ctx context.Context, in InitializeInput, next InitializeHandler) (
+	out InitializeOutput, metadata Metadata, err error,
+) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	return next.HandleInitialize(ctx, in)
+}
@@ -0,0 +1,73 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+type mockCallMiddleware struct{}
+
+func (mockCallMiddleware) ID() string { return "mockCallMiddleware" }
+
+func (mockCallMiddleware) HandleInitialize(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+	out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+) {
+	return next.HandleInitialize(ctx, in)
+}
+
+func TestApplyCallOptions(t *testing.T) {
+	ctx := middleware.WithCallOptions(context.Background(),
+		middleware.WithTimeout(time.Minute),
+		middleware.WithCallProperty("a", 1),
+	)
+	ctx = middleware.WithCallOptions(ctx,
+		middleware.WithStackMutation(func(s *middleware.Stack) error {
+			return s.Initialize.Add(mockCallMiddleware{}, middleware.After)
+		}),
+		middleware.WithCallProperty("b", 2),
+	)
+
+	o := middleware.GetCallOptions(ctx)
+	if e, a := 1, o.Properties.Get("a"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := 2, o.Properties.Get("b"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	stack := middleware.NewStack("test", func() interface{} { return struct{}{} })
+	if err := middleware.ApplyCallOptions(ctx, stack); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if _, ok := stack.Initialize.Get("mockCallMiddleware"); !ok {
+		t.Errorf("expect stack mutation applied")
+	}
+
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Errorf("expect call deadline")
+			}
+			if remaining := time.Until(deadline); remaining > time.Minute {
+				t.Errorf("expect deadline within timeout, got %v", remaining)
+			}
+			return nil, middleware.Metadata{}, nil
+		}), stack)
+	if _, _, err := handler.Handle(ctx, struct{}{}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+}
+
+func TestApplyCallOptions_None(t *testing.T) {
+	stack := middleware.NewStack("test", func() interface{} { return struct{}{} })
+	if err := middleware.ApplyCallOptions(context.Background(), stack); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := 0, len(stack.Initialize.List()); e != a {
+		t.Errorf("expect %v middleware, got %v", e, a)
+	}
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/smithy-go/sync/singleflight"
+)
+
+// CoalesceOptions provides the configuration of a Coalescer.
+type CoalesceOptions struct {
+	// KeyFunc returns the key identifying the operation input, and false if
+	// the input must not be coalesced. Calls of the same operation with the
+	// same key are coalesced while one is in-flight. Required.
+	KeyFunc func(ctx context.Context, input interface{}) (string, bool)
+
+	// DisabledOperations are the names of the operations which are never
+	// coalesced, such as operations which are not idempotent.
+	DisabledOperations []string
+}
+
+// Coalescer coalesces concurrent identical operation calls into a single
+// in-flight call, whose result is returned to each caller. A Coalescer is
+// shared by the stacks of a client's operation calls, and is safe for
+// concurrent use.
+type Coalescer struct {
+	keyFunc  func(context.Context, interface{}) (string, bool)
+	disabled map[string]struct{}
+	group    singleflight.Group
+}
+
+// NewCoalescer returns a Coalescer configured by the functional options.
+// Returns an error if the KeyFunc option is not set.
+func NewCoalescer(optFns ...func(*CoalesceOptions)) (*Coalescer, error) {
+	var o CoalesceOptions
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	if o.KeyFunc == nil {
+		return nil, fmt.Errorf("coalescer KeyFunc must be set")
+	}
+
+	c := &Coalescer{
+		keyFunc:  o.KeyFunc,
+		disabled: make(map[string]struct{}, len(o.DisabledOperations)),
+	}
+	for _, op := range o.DisabledOperations {
+		c.disabled[op] = struct{}{}
+	}
+	return c, nil
+}
+
+type coalescedKey struct{}
+
+// IsCoalesced returns whether the operation's result is from a coalesced
+// call, and was shared by concurrent callers.
+func IsCoalesced(metadata Metadata) bool {
+	v, _ := metadata.Get(coalescedKey{}).(bool)
+	return v
+}
+
+// AddCoalesceMiddleware adds the Initialize step middleware which coalesces
+// concurrent calls of the operation with the same key. The first caller's
+// call is invoked, and its result, metadata, and error are returned to every
+// caller with the same key which arrives while the call is in-flight.
+//
+// The coalesced call is invoked with the first caller's context, so its
+// cancellation fails the call for every caller. Other callers stop waiting
+// when their own context is canceled. The shared result must not be
+// modified.
+//
+// The middleware must only be added to the stacks of idempotent operations.
+// The middleware should be added after the operation's metadata is set on
+// the context, as the key includes the service ID and operation name.
+func AddCoalesceMiddleware(stack *Stack, c *Coalescer) error {
+	return stack.Initialize.Add(&coalesce{coalescer: c}, After)
+}
+
+type coalesce struct {
+	coalescer *Coalescer
+}
+
+func (*coalesce) ID() string { return "Coalesce" }
+
+type coalescedResult struct {
+	out      InitializeOutput
+	metadata Metadata
+}
+
+func (m *coalesce) HandleInitialize(ctx context.Context, in InitializeInput, next InitializeHandler) (
+	out InitializeOutput, metadata Metadata, err error,
+) {
+	op := GetOperationName(ctx)
+	if _, ok := m.coalescer.disabled[op]; ok {
+		return next.HandleInitialize(ctx, in)
+	}
+	key, ok := m.coalescer.keyFunc(ctx, in.Parameters)
+	if !ok {
+		return next.HandleInitialize(ctx, in)
+	}
+	key = GetServiceID(ctx) + "." + op + "#" + key
+
+	ch := m.coalescer.group.DoChan(key, func() (interface{}, error) {
+		out, metadata, err := next.HandleInitialize(ctx, in)
+		return coalescedResult{out: out, metadata: metadata}, err
+	})
+
+	select {
+	case res := <-ch:
+		r, _ := res.Val.(coalescedResult)
+		metadata = r.metadata
+		if res.Shared {
+			// Each caller receives its own copy of the shared metadata.
+			metadata = metadata.Clone()
+			metadata.Set(coalescedKey{}, true)
+		}
+		return r.out, metadata, res.Err
+	case <-ctx.Done():
+		return out, metadata, ctx.Err()
+	}
+}
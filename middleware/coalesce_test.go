@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalesceMiddleware(t *testing.T) {
+	cases := map[string]struct {
+		Operation    string
+		Inputs       []string
+		ExpectCalls  int32
+		ExpectShared bool
+	}{
+		"same key coalesced": {
+			Operation:    "GetObject",
+			Inputs:       []string{"a", "a", "a"},
+			ExpectCalls:  1,
+			ExpectShared: true,
+		},
+		"different keys": {
+			Operation:   "GetObject",
+			Inputs:      []string{"a", "b", "c"},
+			ExpectCalls: 3,
+		},
+		"not coalescable": {
+			Operation:   "GetObject",
+			Inputs:      []string{"skip", "skip"},
+			ExpectCalls: 2,
+		},
+		"disabled operation": {
+			Operation:   "PutObject",
+			Inputs:      []string{"a", "a"},
+			ExpectCalls: 2,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			coalescer, err := NewCoalescer(func(o *CoalesceOptions) {
+				o.KeyFunc = func(ctx context.Context, input interface{}) (string, bool) {
+					return input.(string), input.(string) != "skip"
+				}
+				o.DisabledOperations = []string{"PutObject"}
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			var calls int32
+			release := make(chan struct{})
+			started := make(chan struct{}, len(c.Inputs))
+
+			ctx := WithOperationName(context.Background(), c.Operation)
+
+			var wg sync.WaitGroup
+			results := make([]interface{}, len(c.Inputs))
+			shared := make([]bool, len(c.Inputs))
+			for i, input := range c.Inputs {
+				stack := NewStack("test", func() interface{} { return struct{}{} })
+				if err := AddCoalesceMiddleware(stack, coalescer); err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				stack.Initialize.Add(InitializeMiddlewareFunc("started",
+					func(ctx context.Context, in InitializeInput, next InitializeHandler) (
+						InitializeOutput, Metadata, error,
+					) {
+						started <- struct{}{}
+						return next.HandleInitialize(ctx, in)
+					}), After)
+
+				handler := DecorateHandler(HandlerFunc(
+					func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+						n := atomic.AddInt32(&calls, 1)
+						<-release
+						return fmt.Sprintf("result-%d", n), Metadata{}, nil
+					}), stack)
+
+				wg.Add(1)
+				go func(i int, input string) {
+					defer wg.Done()
+					result, metadata, err := handler.Handle(ctx, input)
+					if err != nil {
+						t.Errorf("expect no error, got %v", err)
+					}
+					results[i] = result
+					shared[i] = IsCoalesced(metadata)
+				}(i, input)
+
+				// Wait for the call to be in-flight, or joined, before
+				// starting the next.
+				if i == 0 || c.ExpectCalls > 1 {
+					<-started
+				} else {
+					time.Sleep(10 * time.Millisecond)
+				}
+			}
+
+			close(release)
+			wg.Wait()
+
+			if e, a := c.ExpectCalls, atomic.LoadInt32(&calls); e != a {
+				t.Errorf("expect %v calls, got %v", e, a)
+			}
+			for i := range c.Inputs {
+				if e, a := c.ExpectShared, shared[i]; e != a {
+					t.Errorf("%d, expect %v shared, got %v", i, e, a)
+				}
+				if c.ExpectShared {
+					if e, a := results[0], results[i]; e != a {
+						t.Errorf("%d, expect %v result, got %v", i, e, a)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCoalesceMiddlewareCallerCanceled(t *testing.T) {
+	coalescer, err := NewCoalescer(func(o *CoalesceOptions) {
+		o.KeyFunc = func(ctx context.Context, input interface{}) (string, bool) {
+			return "key", true
+		}
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+
+	stack := NewStack("test", func() interface{} { return struct{}{} })
+	AddCoalesceMiddleware(stack, coalescer)
+	handler := DecorateHandler(HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+			<-release
+			return nil, Metadata{}, nil
+		}), stack)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, err := handler.Handle(ctx, "input"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expect %v error, got %v", context.Canceled, err)
+	}
+}
+
+func TestNewCoalescerNoKeyFunc(t *testing.T) {
+	if _, err := NewCoalescer(); err == nil {
+		t.Errorf("expect error")
+	}
+}
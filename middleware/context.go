@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/aws/smithy-go"
+)
+
+type (
+	serviceIDKey     struct{}
+	operationNameKey struct{}
+)
+
+// WithServiceID adds a service ID to the context, scoped to middleware stack
+// values.
+//
+// This API is called in the client runtime when bootstrapping an operation
+// and should not typically be used directly.
+func WithServiceID(parent context.Context, id string) context.Context {
+	return WithStackValue(parent, serviceIDKey{}, id)
+}
+
+// GetServiceID retrieves the service ID from the context.
+//
+// This is typically the service shape's name from its Smithy model. Service
+// clients for specific systems (e.g. AWS SDK) may use an alternate designated
+// value.
+func GetServiceID(ctx context.Context) string {
+	id, _ := GetStackValue(ctx, serviceIDKey{}).(string)
+	return id
+}
+
+// WithOperationName adds the operation name to the context, scoped to
+// middleware stack values.
+//
+// This API is called in the client runtime when bootstrapping an operation
+// and should not typically be used directly.
+func WithOperationName(parent context.Context, id string) context.Context {
+	return WithStackValue(parent, operationNameKey{}, id)
+}
+
+// GetOperationName retrieves the operation name from the context.
+//
+// This is typically the operation shape's name from its Smithy model.
+func GetOperationName(ctx context.Context) string {
+	name, _ := GetStackValue(ctx, operationNameKey{}).(string)
+	return name
+}
+
+// WithOperationProperty adds a typed property of the operation, such as the
+// region the operation is invoked in, to the context, scoped to middleware
+// stack values.
+func WithOperationProperty[T any](parent context.Context, key smithy.PropertyKey[T], v T) context.Context {
+	return WithStackValue(parent, key, v)
+}
+
+// GetOperationProperty retrieves the typed property of the operation from the
+// context. Returns false if the property was not set.
+func GetOperationProperty[T any](ctx context.Context, key smithy.PropertyKey[T]) (v T, ok bool) {
+	v, ok = GetStackValue(ctx, key).(T)
+	return v, ok
+}
+
+// OperationMetadataProperties returns the service ID and operation name of
+// the context as properties, for use as the attributes of the operation's
+// metrics and spans. Properties for values that were not set are omitted.
+func OperationMetadataProperties(ctx context.Context) smithy.Properties {
+	var props smithy.Properties
+	if id := GetServiceID(ctx); len(id) != 0 {
+		props.Set("rpc.service", id)
+	}
+	if name := GetOperationName(ctx); len(name) != 0 {
+		props.Set("rpc.method", name)
+	}
+	return props
+}
+
+// AddOperationMetadataMiddleware adds a middleware to the stack's Initialize
+// step that sets the service ID and operation name on the context, to be read
+// by all subsequent middleware, loggers, and metrics with GetServiceID and
+// GetOperationName.
+func AddOperationMetadataMiddleware(stack *Stack, serviceID, operationName string) error {
+	return stack.Initialize.Add(&setOperationMetadata{
+		serviceID:     serviceID,
+		operationName: operationName,
+	}, Before)
+}
+
+type setOperationMetadata struct {
+	serviceID     string
+	operationName string
+}
+
+func (*setOperationMetadata) ID() string { return "SetOperationMetadata" }
+
+func (m *setOperationMetadata) HandleInitialize(ctx context.Context, in InitializeInput, next InitializeHandler) (
+	out InitializeOutput, metadata Metadata, err error,
+) {
+	ctx = WithServiceID(ctx, m.serviceID)
+	ctx = WithOperationName(ctx, m.operationName)
+	return next.HandleInitialize(ctx, in)
+}
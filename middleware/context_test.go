@@ -0,0 +1,67 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestOperationMetadata(t *testing.T) {
+	regionKey := smithy.NewPropertyKey[string]("test", "region")
+
+	stack := middleware.NewStack("test", func() interface{} { return struct{}{} })
+	if err := middleware.AddOperationMetadataMiddleware(stack, "TestService", "TestOperation"); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var called bool
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+			called = true
+			if e, a := "TestService", middleware.GetServiceID(ctx); e != a {
+				t.Errorf("expect %v service ID, got %v", e, a)
+			}
+			if e, a := "TestOperation", middleware.GetOperationName(ctx); e != a {
+				t.Errorf("expect %v operation name, got %v", e, a)
+			}
+			if v, ok := middleware.GetOperationProperty(ctx, regionKey); !ok || v != "us-west-2" {
+				t.Errorf("expect us-west-2 region, got %v, %v", v, ok)
+			}
+
+			props := middleware.OperationMetadataProperties(ctx)
+			if e, a := "TestService", props.Get("rpc.service"); e != a {
+				t.Errorf("expect %v rpc.service, got %v", e, a)
+			}
+			if e, a := "TestOperation", props.Get("rpc.method"); e != a {
+				t.Errorf("expect %v rpc.method, got %v", e, a)
+			}
+			return nil, middleware.Metadata{}, nil
+		}), stack)
+
+	ctx := middleware.WithOperationProperty(context.Background(), regionKey, "us-west-2")
+	if _, _, err := handler.Handle(ctx, struct{}{}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if !called {
+		t.Fatalf("expect handler called")
+	}
+}
+
+func TestOperationMetadata_Unset(t *testing.T) {
+	ctx := context.Background()
+	if v := middleware.GetServiceID(ctx); len(v) != 0 {
+		t.Errorf("expect no service ID, got %v", v)
+	}
+	if v := middleware.GetOperationName(ctx); len(v) != 0 {
+		t.Errorf("expect no operation name, got %v", v)
+	}
+	if _, ok := middleware.GetOperationProperty(ctx, smithy.NewPropertyKey[int]("test", "n")); ok {
+		t.Errorf("expect property not set")
+	}
+	props := middleware.OperationMetadataProperties(ctx)
+	if e, a := 0, len(props.Values()); e != a {
+		t.Errorf("expect %v properties, got %v", e, a)
+	}
+}
@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/smithy-go"
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+// DeadlineBudget is the time budget of an operation call, shared by the
+// call's attempts. The time remaining before the call's deadline is split
+// across the remaining attempts, so a retry is not left with too little time
+// to succeed. Retry middleware use the budget to limit the timeout of each
+// attempt, and to not retry when the budget remaining after the backoff
+// delay is too short for an attempt.
+type DeadlineBudget struct {
+	// Deadline is the time the operation call, including all attempts, must
+	// complete by.
+	Deadline time.Time
+
+	// MaxAttempts is the maximum number of attempts of the operation call,
+	// including the initial attempt.
+	MaxAttempts int
+
+	// ExpectedLatency is the expected duration of an attempt. An attempt is
+	// given at least the expected latency, if the budget allows, and no
+	// retry is made if less than the expected latency remains.
+	ExpectedLatency time.Duration
+}
+
+// Remaining returns the time remaining before the budget's deadline,
+// relative to now. Zero if the deadline has passed.
+func (b DeadlineBudget) Remaining(now time.Time) time.Duration {
+	if d := b.Deadline.Sub(now); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// AttemptTimeout returns the timeout of the attempt, numbered from 1 for the
+// initial attempt, relative to now. The remaining time is split evenly
+// across the attempt and those that may follow it, with the attempt given at
+// least the expected latency. The final attempt is given all of the
+// remaining time.
+func (b DeadlineBudget) AttemptTimeout(now time.Time, attempt int) time.Duration {
+	remaining := b.Remaining(now)
+
+	attemptsLeft := b.MaxAttempts - attempt + 1
+	if attemptsLeft <= 1 {
+		return remaining
+	}
+
+	timeout := remaining / time.Duration(attemptsLeft)
+	if timeout < b.ExpectedLatency {
+		timeout = b.ExpectedLatency
+	}
+	if timeout > remaining {
+		timeout = remaining
+	}
+	return timeout
+}
+
+// CanRetry returns whether an attempt made after the backoff delay, relative
+// to now, would have at least the expected latency remaining in the budget.
+// Retry middleware should not retry the operation if the budget does not
+// allow it, instead of making an attempt that cannot complete in time.
+func (b DeadlineBudget) CanRetry(now time.Time, delay time.Duration) bool {
+	remaining := b.Remaining(now) - delay
+	return remaining > 0 && remaining >= b.ExpectedLatency
+}
+
+type deadlineBudgetKey struct{}
+
+// WithDeadlineBudget adds the DeadlineBudget of the operation call to the
+// context, scoped to middleware stack values.
+//
+// This API is called by the deadline budget middleware, see
+// AddDeadlineBudgetMiddleware.
+func WithDeadlineBudget(parent context.Context, budget DeadlineBudget) context.Context {
+	return WithStackValue(parent, deadlineBudgetKey{}, budget)
+}
+
+// GetDeadlineBudget retrieves the DeadlineBudget of the operation call from
+// the context. Returns false if the operation call has no deadline budget.
+func GetDeadlineBudget(ctx context.Context) (budget DeadlineBudget, ok bool) {
+	budget, ok = GetStackValue(ctx, deadlineBudgetKey{}).(DeadlineBudget)
+	return budget, ok
+}
+
+var streamingOutputKey = smithy.RegisterPropertyKey[bool]("smithy", "streamingOutput")
+
+// WithStreamingOutput returns a context with the operation property that the
+// operation's output is a stream, read by the caller after the operation
+// call returns. The deadline budget middleware does not apply its timeouts
+// to the context of operations with streaming output, see
+// AddDeadlineBudgetMiddleware.
+func WithStreamingOutput(ctx context.Context, streaming bool) context.Context {
+	return WithOperationProperty(ctx, streamingOutputKey, streaming)
+}
+
+// IsStreamingOutput returns whether the operation's output is a stream, see
+// WithStreamingOutput.
+func IsStreamingOutput(ctx context.Context) bool {
+	v, _ := GetOperationProperty(ctx, streamingOutputKey)
+	return v
+}
+
+// DeadlineBudgetOptions provides the configuration of the deadline budget
+// middleware.
+type DeadlineBudgetOptions struct {
+	// Timeout is the maximum duration of the operation call, including all
+	// attempts. If zero, the deadline of the operation call's context is
+	// used, and no budget is applied if the context has no deadline.
+	Timeout time.Duration
+
+	// MaxAttempts is the maximum number of attempts of the operation call,
+	// including the initial attempt. Defaults to 3.
+	MaxAttempts int
+
+	// ExpectedLatency is the expected duration of an attempt, see
+	// DeadlineBudget.
+	ExpectedLatency time.Duration
+}
+
+// AddDeadlineBudgetMiddleware adds the middleware applying a DeadlineBudget
+// to the operation call. The budget is added to the context in the stack's
+// Initialize step, retrieved with GetDeadlineBudget by the retry middleware.
+// The timeout of each attempt is applied in the stack's Finalize step, after
+// the retry middleware, from the budget remaining and the attempt's
+// AttemptInfo.
+//
+// The timeouts are not applied to operations with streaming output, see
+// WithStreamingOutput, as canceling the context when the operation call
+// returns would fail the caller's reads of the output stream. The budget
+// still limits the retries of those operations.
+func AddDeadlineBudgetMiddleware(stack *Stack, optFns ...func(*DeadlineBudgetOptions)) error {
+	o := DeadlineBudgetOptions{
+		MaxAttempts: 3,
+	}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	if err := stack.Initialize.Add(&deadlineBudget{options: o}, Before); err != nil {
+		return err
+	}
+	return stack.Finalize.Add(&attemptTimeout{}, After)
+}
+
+type deadlineBudget struct {
+	options DeadlineBudgetOptions
+}
+
+func (*deadlineBudget) ID() string { return "DeadlineBudget" }
+
+func (m *deadlineBudget) HandleInitialize(ctx context.Context, in InitializeInput, next InitializeHandler) (
+	out InitializeOutput, metadata Metadata, err error,
+) {
+	budget := DeadlineBudget{
+		MaxAttempts:     m.options.MaxAttempts,
+		ExpectedLatency: m.options.ExpectedLatency,
+	}
+
+	if m.options.Timeout > 0 {
+		budget.Deadline = smithytime.GetClock(ctx).Now().Add(m.options.Timeout)
+
+		if !IsStreamingOutput(ctx) {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, m.options.Timeout)
+			defer cancel()
+		}
+	} else if deadline, ok := ctx.Deadline(); ok {
+		budget.Deadline = deadline
+	} else {
+		return next.HandleInitialize(ctx, in)
+	}
+
+	return next.HandleInitialize(WithDeadlineBudget(ctx, budget), in)
+}
+
+// attemptTimeout applies the timeout of the attempt derived from the
+// operation call's deadline budget.
+type attemptTimeout struct{}
+
+func (*attemptTimeout) ID() string { return "AttemptTimeout" }
+
+func (m *attemptTimeout) HandleFinalize(ctx context.Context, in FinalizeInput, next FinalizeHandler) (
+	out FinalizeOutput, metadata Metadata, err error,
+) {
+	budget, ok := GetDeadlineBudget(ctx)
+	if !ok || IsStreamingOutput(ctx) {
+		return next.HandleFinalize(ctx, in)
+	}
+
+	attempt := 1
+	if info, ok := GetAttemptInfo(ctx); ok {
+		attempt = info.Attempt
+	}
+
+	timeout := budget.AttemptTimeout(smithytime.GetClock(ctx).Now(), attempt)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return next.HandleFinalize(ctx, in)
+}
@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDeadlineBudgetAttemptTimeout(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		Budget  DeadlineBudget
+		Attempt int
+		Expect  time.Duration
+	}{
+		"split across attempts": {
+			Budget:  DeadlineBudget{Deadline: now.Add(9 * time.Second), MaxAttempts: 3},
+			Attempt: 1,
+			Expect:  3 * time.Second,
+		},
+		"final attempt gets remaining": {
+			Budget:  DeadlineBudget{Deadline: now.Add(9 * time.Second), MaxAttempts: 3},
+			Attempt: 3,
+			Expect:  9 * time.Second,
+		},
+		"at least expected latency": {
+			Budget: DeadlineBudget{
+				Deadline:        now.Add(9 * time.Second),
+				MaxAttempts:     3,
+				ExpectedLatency: 5 * time.Second,
+			},
+			Attempt: 1,
+			Expect:  5 * time.Second,
+		},
+		"expected latency capped at remaining": {
+			Budget: DeadlineBudget{
+				Deadline:        now.Add(4 * time.Second),
+				MaxAttempts:     3,
+				ExpectedLatency: 5 * time.Second,
+			},
+			Attempt: 1,
+			Expect:  4 * time.Second,
+		},
+		"deadline passed": {
+			Budget:  DeadlineBudget{Deadline: now.Add(-time.Second), MaxAttempts: 3},
+			Attempt: 1,
+			Expect:  0,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := c.Expect, c.Budget.AttemptTimeout(now, c.Attempt); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestDeadlineBudgetCanRetry(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	budget := DeadlineBudget{
+		Deadline:        now.Add(10 * time.Second),
+		MaxAttempts:     3,
+		ExpectedLatency: 2 * time.Second,
+	}
+
+	cases := map[string]struct {
+		Delay  time.Duration
+		Expect bool
+	}{
+		"enough remaining": {Delay: time.Second, Expect: true},
+		"exactly expected": {Delay: 8 * time.Second, Expect: true},
+		"too short":        {Delay: 9 * time.Second, Expect: false},
+		"past deadline":    {Delay: 11 * time.Second, Expect: false},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := c.Expect, budget.CanRetry(now, c.Delay); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+type mockRetryMiddleware struct {
+	attempts int
+}
+
+func (*mockRetryMiddleware) ID() string { return "mockRetry" }
+
+func (m *mockRetryMiddleware) HandleFinalize(ctx context.Context, in FinalizeInput, next FinalizeHandler) (
+	out FinalizeOutput, metadata Metadata, err error,
+) {
+	for i := 1; i <= m.attempts; i++ {
+		out, metadata, err = next.HandleFinalize(WithAttemptInfo(ctx, AttemptInfo{Attempt: i}), in)
+	}
+	return out, metadata, err
+}
+
+func TestDeadlineBudgetMiddleware(t *testing.T) {
+	stack := NewStack("test", func() interface{} { return struct{}{} })
+	if err := stack.Finalize.Add(&mockRetryMiddleware{attempts: 3}, Before); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := AddDeadlineBudgetMiddleware(stack, func(o *DeadlineBudgetOptions) {
+		o.Timeout = 90 * time.Second
+	}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var timeouts []time.Duration
+	handler := DecorateHandler(HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+			if _, ok := GetDeadlineBudget(ctx); !ok {
+				t.Errorf("expect deadline budget")
+			}
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Fatalf("expect attempt deadline")
+			}
+			timeouts = append(timeouts, time.Until(deadline))
+			return nil, Metadata{}, nil
+		}), stack)
+	if _, _, err := handler.Handle(context.Background(), struct{}{}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := []time.Duration{30 * time.Second, 45 * time.Second, 90 * time.Second}
+	if e, a := len(expect), len(timeouts); e != a {
+		t.Fatalf("expect %v attempts, got %v", e, a)
+	}
+	for i, e := range expect {
+		if a := timeouts[i]; a > e || a < e-time.Second {
+			t.Errorf("expect attempt %d timeout of %v, got %v", i+1, e, a)
+		}
+	}
+}
+
+func TestDeadlineBudgetMiddlewareNoDeadline(t *testing.T) {
+	stack := NewStack("test", func() interface{} { return struct{}{} })
+	if err := AddDeadlineBudgetMiddleware(stack); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	handler := DecorateHandler(HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+			if _, ok := GetDeadlineBudget(ctx); ok {
+				t.Errorf("expect no deadline budget")
+			}
+			if _, ok := ctx.Deadline(); ok {
+				t.Errorf("expect no deadline")
+			}
+			return nil, Metadata{}, nil
+		}), stack)
+	if _, _, err := handler.Handle(context.Background(), struct{}{}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+}
+
+func TestDeadlineBudgetMiddlewareStreamingOutput(t *testing.T) {
+	stack := NewStack("test", func() interface{} { return struct{}{} })
+	if err := AddDeadlineBudgetMiddleware(stack, func(o *DeadlineBudgetOptions) {
+		o.Timeout = time.Minute
+	}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var outputCtx context.Context
+	handler := DecorateHandler(HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+			if _, ok := GetDeadlineBudget(ctx); !ok {
+				t.Errorf("expect deadline budget")
+			}
+			outputCtx = ctx
+			return nil, Metadata{}, nil
+		}), stack)
+
+	ctx := WithStreamingOutput(context.Background(), true)
+	if _, _, err := handler.Handle(ctx, struct{}{}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	// output stream read by the caller after the operation call returns.
+	if err := outputCtx.Err(); err != nil {
+		t.Errorf("expect context not canceled after return, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/aws/smithy-go/logging"
+)
+
+// Deprecation describes a deprecated middleware ID of a step, and the ID of
+// the middleware, or slot, which replaces it. Deprecations are registered by
+// the generated client when a middleware of its stack is renamed, so that
+// user code referencing the old ID keeps working while it is migrated.
+type Deprecation struct {
+	// ID is the deprecated middleware ID.
+	ID string
+
+	// Replacement is the ID of the middleware or slot replacing the
+	// deprecated ID.
+	Replacement string
+
+	// Message is an optional description of the migration.
+	Message string
+}
+
+// DeprecationWarning is the warning logged when a step's Get, Swap, or Remove
+// is called with a deprecated middleware ID.
+type DeprecationWarning struct {
+	Deprecation
+
+	// Step is the name of the step, e.g. "Finalize".
+	Step string
+
+	// Operation is the step method called with the deprecated ID, e.g.
+	// "Swap".
+	Operation string
+
+	// Redirected is true if the deprecated ID was not present in the step,
+	// and the operation was applied to the replacement instead.
+	Redirected bool
+}
+
+func (w DeprecationWarning) String() string {
+	s := fmt.Sprintf("deprecated middleware ID used, step=%s operation=%s id=%q replacement=%q redirected=%t",
+		w.Step, w.Operation, w.ID, w.Replacement, w.Redirected)
+	if len(w.Message) != 0 {
+		s += fmt.Sprintf(" message=%q", w.Message)
+	}
+	return s
+}
+
+// SetLogger sets the logger the stack's steps log warnings to, such as the
+// use of a deprecated middleware ID. Defaults to a logger which does not log.
+func (s *Stack) SetLogger(logger logging.Logger) {
+	for _, ids := range []*orderedIDs{
+		s.Initialize.ids,
+		s.Serialize.ids,
+		s.Build.ids,
+		s.Finalize.ids,
+		s.Deserialize.ids,
+	} {
+		ids.logger = logger
+	}
+}
+
+// deprecations tracks the deprecated IDs of a step.
+type deprecations struct {
+	step   string
+	byID   map[string]Deprecation
+	warned map[string]bool
+}
+
+// Deprecate registers the deprecation, replacing any previous deprecation of
+// the same ID.
+func (g *orderedIDs) Deprecate(step string, d Deprecation) error {
+	if len(d.ID) == 0 {
+		return fmt.Errorf("deprecated ID must not be empty")
+	}
+	if len(d.Replacement) == 0 {
+		return fmt.Errorf("replacement ID must not be empty")
+	}
+	if d.ID == d.Replacement {
+		return fmt.Errorf("deprecated ID must not be its own replacement, %v", d.ID)
+	}
+
+	if g.deprecations.byID == nil {
+		g.deprecations.byID = map[string]Deprecation{}
+		g.deprecations.warned = map[string]bool{}
+	}
+	g.deprecations.step = step
+	g.deprecations.byID[d.ID] = d
+	return nil
+}
+
+// resolveDeprecated returns the ID the operation should be applied to. If id
+// is deprecated a warning is logged, once per ID, and if the deprecated ID is
+// not present in the step, the replacement's ID is returned.
+func (g *orderedIDs) resolveDeprecated(op, id string) string {
+	d, ok := g.deprecations.byID[id]
+	if !ok {
+		return id
+	}
+
+	_, present := g.items[id]
+	if !g.deprecations.warned[id] {
+		g.deprecations.warned[id] = true
+		if g.logger != nil {
+			g.logger.Logf(logging.Warn, "%v", DeprecationWarning{
+				Deprecation: d,
+				Step:        g.deprecations.step,
+				Operation:   op,
+				Redirected:  !present,
+			})
+		}
+	}
+
+	if present {
+		return id
+	}
+	return d.Replacement
+}
@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/logging"
+)
+
+func TestStepDeprecation(t *testing.T) {
+	noop := func(id string) FinalizeMiddleware {
+		return FinalizeMiddlewareFunc(id, func(ctx context.Context, in FinalizeInput, next FinalizeHandler) (
+			FinalizeOutput, Metadata, error,
+		) {
+			return next.HandleFinalize(ctx, in)
+		})
+	}
+
+	cases := map[string]struct {
+		Present      []string
+		Call         func(*FinalizeStep) error
+		ExpectList   []string
+		ExpectWarn   string
+		ExpectNoWarn bool
+	}{
+		"get redirected": {
+			Present: []string{"NewRetry"},
+			Call: func(s *FinalizeStep) error {
+				if m, ok := s.Get("OldRetry"); !ok || m.ID() != "NewRetry" {
+					return fmt.Errorf("expect replacement, got %v, %v", m, ok)
+				}
+				return nil
+			},
+			ExpectList: []string{"NewRetry"},
+			ExpectWarn: `step=Finalize operation=Get id="OldRetry" replacement="NewRetry" redirected=true message="renamed"`,
+		},
+		"swap redirected": {
+			Present: []string{"first", "NewRetry", "last"},
+			Call: func(s *FinalizeStep) error {
+				_, err := s.Swap("OldRetry", noop("custom"))
+				return err
+			},
+			ExpectList: []string{"first", "custom", "last"},
+			ExpectWarn: `operation=Swap id="OldRetry" replacement="NewRetry" redirected=true`,
+		},
+		"remove redirected": {
+			Present: []string{"first", "NewRetry"},
+			Call: func(s *FinalizeStep) error {
+				_, err := s.Remove("OldRetry")
+				return err
+			},
+			ExpectList: []string{"first"},
+			ExpectWarn: `operation=Remove id="OldRetry" replacement="NewRetry" redirected=true`,
+		},
+		"deprecated present": {
+			Present: []string{"OldRetry", "NewRetry"},
+			Call: func(s *FinalizeStep) error {
+				_, err := s.Remove("OldRetry")
+				return err
+			},
+			ExpectList: []string{"NewRetry"},
+			ExpectWarn: `operation=Remove id="OldRetry" replacement="NewRetry" redirected=false`,
+		},
+		"not deprecated": {
+			Present: []string{"NewRetry"},
+			Call: func(s *FinalizeStep) error {
+				_, err := s.Remove("NewRetry")
+				return err
+			},
+			ExpectList:   []string{},
+			ExpectNoWarn: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var warnings []string
+			stack := NewStack("test", func() interface{} { return struct{}{} })
+			stack.SetLogger(logging.LoggerFunc(func(c logging.Classification, format string, v ...interface{}) {
+				if c != logging.Warn {
+					t.Errorf("expect %v classification, got %v", logging.Warn, c)
+				}
+				warnings = append(warnings, fmt.Sprintf(format, v...))
+			}))
+
+			for _, id := range c.Present {
+				if err := stack.Finalize.Add(noop(id), After); err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+			}
+			err := stack.Finalize.Deprecate(Deprecation{
+				ID:          "OldRetry",
+				Replacement: "NewRetry",
+				Message:     "renamed",
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if err := c.Call(stack.Finalize); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := strings.Join(c.ExpectList, ","), strings.Join(stack.Finalize.List(), ","); e != a {
+				t.Errorf("expect %v middleware, got %v", e, a)
+			}
+			if c.ExpectNoWarn {
+				if len(warnings) != 0 {
+					t.Errorf("expect no warnings, got %v", warnings)
+				}
+				return
+			}
+			if e, a := 1, len(warnings); e != a {
+				t.Fatalf("expect %v warnings, got %v", e, a)
+			}
+			if e, a := c.ExpectWarn, warnings[0]; !strings.Contains(a, e) {
+				t.Errorf("expect warning to contain %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestStepDeprecationWarnsOnce(t *testing.T) {
+	var count int
+	stack := NewStack("test", func() interface{} { return struct{}{} })
+	stack.SetLogger(logging.LoggerFunc(func(logging.Classification, string, ...interface{}) {
+		count++
+	}))
+
+	if err := stack.Build.Deprecate(Deprecation{ID: "OldChecksum", Replacement: SlotRequestChecksum}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, ok := stack.Build.Get("OldChecksum"); ok {
+			t.Errorf("expect unfilled slot not returned")
+		}
+	}
+	if e, a := 1, count; e != a {
+		t.Errorf("expect %v warnings, got %v", e, a)
+	}
+}
+
+func TestStepDeprecationInvalid(t *testing.T) {
+	cases := map[string]Deprecation{
+		"empty id":          {Replacement: "b"},
+		"empty replacement": {ID: "a"},
+		"self replacement":  {ID: "a", Replacement: "a"},
+	}
+
+	for name, d := range cases {
+		t.Run(name, func(t *testing.T) {
+			stack := NewStack("test", func() interface{} { return struct{}{} })
+			if err := stack.Initialize.Deprecate(d); err == nil {
+				t.Errorf("expect error")
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/smithy-go/rand"
+)
+
+// IdempotencyTokenMember is a callback supplied by generated code that
+// returns a pointer to the member of the operation input with the Smithy
+// idempotencyToken trait. Returns false if the input is not of the type the
+// callback was generated for.
+type IdempotencyTokenMember func(input interface{}) (member **string, ok bool)
+
+// IdempotencyTokenAutoFill is an initialize middleware that fills empty
+// idempotency token members of the operation input with tokens from the
+// Provider. If Provider is nil, random UUID tokens are read from the
+// context's random source, see rand.GetEntropySource.
+type IdempotencyTokenAutoFill struct {
+	Provider rand.IdempotencyTokenProvider
+	Members  []IdempotencyTokenMember
+}
+
+// AddIdempotencyTokenMiddleware adds the IdempotencyTokenAutoFill middleware
+// to the stack's Initialize step, filling the members with tokens from the
+// provider.
+func AddIdempotencyTokenMiddleware(stack *Stack, provider rand.IdempotencyTokenProvider, members ...IdempotencyTokenMember) error {
+	return stack.Initialize.Add(&IdempotencyTokenAutoFill{
+		Provider: provider,
+		Members:  members,
+	}, Before)
+}
+
+// ID returns the middleware identifier.
+func (*IdempotencyTokenAutoFill) ID() string {
+	return "OperationIdempotencyTokenAutoFill"
+}
+
+// HandleInitialize fills the empty idempotency token members of the input
+// parameters.
+func (m *IdempotencyTokenAutoFill) HandleInitialize(ctx context.Context, in InitializeInput, next InitializeHandler) (
+	out InitializeOutput, metadata Metadata, err error,
+) {
+	for _, member := range m.Members {
+		v, ok := member(in.Parameters)
+		if !ok || v == nil || *v != nil {
+			continue
+		}
+
+		provider := m.Provider
+		if provider == nil {
+			provider = rand.NewUUIDIdempotencyToken(rand.GetEntropySource(ctx))
+		}
+		token, err := provider.GetIdempotencyToken()
+		if err != nil {
+			return out, metadata, fmt.Errorf("failed to get idempotency token, %w", err)
+		}
+		*v = &token
+	}
+
+	return next.HandleInitialize(ctx, in)
+}
@@ -0,0 +1,94 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/aws/smithy-go/rand"
+)
+
+type mockTokenInput struct {
+	ClientToken *string
+}
+
+func mockTokenMember(input interface{}) (**string, bool) {
+	v, ok := input.(*mockTokenInput)
+	if !ok {
+		return nil, false
+	}
+	return &v.ClientToken, true
+}
+
+func TestIdempotencyTokenAutoFill(t *testing.T) {
+	cases := map[string]struct {
+		Input  interface{}
+		Expect *string
+	}{
+		"empty": {
+			Input:  &mockTokenInput{},
+			Expect: ptr.String("00000000-0000-4000-8000-000000000001"),
+		},
+		"set": {
+			Input:  &mockTokenInput{ClientToken: ptr.String("caller-token")},
+			Expect: ptr.String("caller-token"),
+		},
+		"other type": {
+			Input: struct{}{},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			stack := middleware.NewStack("test", func() interface{} { return struct{}{} })
+			err := middleware.AddIdempotencyTokenMiddleware(stack,
+				&rand.DeterministicIdempotencyToken{}, mockTokenMember)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			handler := middleware.DecorateHandler(middleware.HandlerFunc(
+				func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+					return nil, middleware.Metadata{}, nil
+				}), stack)
+
+			if _, _, err := handler.Handle(context.Background(), c.Input); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			in, ok := c.Input.(*mockTokenInput)
+			if !ok {
+				return
+			}
+			if e, a := ptr.ToString(c.Expect), ptr.ToString(in.ClientToken); e != a {
+				t.Errorf("expect %v token, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestIdempotencyTokenAutoFill_ContextEntropySource(t *testing.T) {
+	stack := middleware.NewStack("test", func() interface{} { return struct{}{} })
+	if err := middleware.AddIdempotencyTokenMiddleware(stack, nil, mockTokenMember); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := middleware.AddSetEntropySourceMiddleware(stack, bytes.NewReader(make([]byte, 16))); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+			return nil, middleware.Metadata{}, nil
+		}), stack)
+
+	in := &mockTokenInput{}
+	if _, _, err := handler.Handle(context.Background(), in); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := "00000000-0000-4000-8000-000000000000", ptr.ToString(in.ClientToken); e != a {
+		t.Errorf("expect %v token, got %v", e, a)
+	}
+}
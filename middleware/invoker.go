@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/smithy-go"
+)
+
+// StackInvoker is a smithy.Invoker which invokes an operation by running its
+// middleware stack, with the operation's serializer and deserializer, around
+// the client's handler.
+type StackInvoker[I, O any] struct {
+	newStack     func() *Stack
+	serializer   SerializeMiddleware
+	deserializer DeserializeMiddleware
+	handler      Handler
+	options      StackInvokerOptions
+}
+
+// StackInvokerOptions provides the configuration of a StackInvoker.
+type StackInvokerOptions struct {
+	// APIOptions are applied to the stack of each invocation, after the
+	// operation's serializer and deserializer are added. An error returned
+	// by an APIOption fails the invocation.
+	APIOptions []func(*Stack) error
+
+	// Serializer, if set, replaces the operation's serializer, for testing,
+	// or experimenting with alternative codecs. See SetOperationSerializer.
+	Serializer OperationSerializer
+
+	// Deserializer, if set, replaces the operation's deserializer. See
+	// SetOperationDeserializer.
+	Deserializer OperationDeserializer
+
+	// LifecycleSubscribers receive the lifecycle events of each invocation,
+	// see AddLifecycleEventsMiddleware. The events middleware is added after
+	// APIOptions are applied.
+	LifecycleSubscribers []LifecycleSubscriber
+}
+
+var _ smithy.Invoker[struct{}, struct{}] = (*StackInvoker[struct{}, struct{}])(nil)
+
+// NewStackInvoker returns a StackInvoker for the operation. newStack is
+// called for each invocation, and returns the operation's stack with the
+// client's middleware. The serializer and deserializer are added to the end
+// of the stack's Serialize and Deserialize steps, and the stack decorates
+// handler, which sends the request.
+func NewStackInvoker[I, O any](newStack func() *Stack, serializer SerializeMiddleware, deserializer DeserializeMiddleware, handler Handler, optFns ...func(*StackInvokerOptions)) *StackInvoker[I, O] {
+	var o StackInvokerOptions
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	return &StackInvoker[I, O]{
+		newStack:     newStack,
+		serializer:   serializer,
+		deserializer: deserializer,
+		handler:      handler,
+		options:      o,
+	}
+}
+
+// Invoke invokes the operation with the input, returning its output.
+func (s *StackInvoker[I, O]) Invoke(ctx context.Context, input I) (O, error) {
+	out, _, err := s.InvokeWithMetadata(ctx, input)
+	return out, err
+}
+
+// InvokeWithMetadata invokes the operation with the input, returning its
+// output and the metadata of the result.
+func (s *StackInvoker[I, O]) InvokeWithMetadata(ctx context.Context, input I) (out O, metadata Metadata, err error) {
+	stack := s.newStack()
+	if err := stack.Serialize.Add(s.serializer, After); err != nil {
+		return out, metadata, err
+	}
+	if err := stack.Deserialize.Add(s.deserializer, After); err != nil {
+		return out, metadata, err
+	}
+	if s.options.Serializer != nil {
+		if err := SetOperationSerializer(stack, s.options.Serializer); err != nil {
+			return out, metadata, err
+		}
+	}
+	if s.options.Deserializer != nil {
+		if err := SetOperationDeserializer(stack, s.options.Deserializer); err != nil {
+			return out, metadata, err
+		}
+	}
+	for _, fn := range s.options.APIOptions {
+		if err := fn(stack); err != nil {
+			return out, metadata, err
+		}
+	}
+	if err := AddLifecycleEventsMiddleware(stack, s.options.LifecycleSubscribers...); err != nil {
+		return out, metadata, err
+	}
+
+	result, metadata, err := DecorateHandler(s.handler, stack).Handle(ctx, input)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	out, ok := result.(O)
+	if !ok {
+		return out, metadata, fmt.Errorf("unexpected operation result type %T, expected %T", result, out)
+	}
+	return out, metadata, nil
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type mockInvokerOutput struct {
+	Value string
+}
+
+func TestStackInvoker(t *testing.T) {
+	serializer := SerializeMiddlewareFunc("OperationSerializer", func(ctx context.Context, in SerializeInput, next SerializeHandler) (
+		SerializeOutput, Metadata, error,
+	) {
+		in.Request = "request:" + in.Parameters.(string)
+		return next.HandleSerialize(ctx, in)
+	})
+
+	cases := map[string]struct {
+		Deserialize  func(raw interface{}) interface{}
+		HandlerErr   error
+		ExpectOutput string
+		ExpectErr    string
+	}{
+		"success": {
+			Deserialize: func(raw interface{}) interface{} {
+				return &mockInvokerOutput{Value: raw.(string)}
+			},
+			ExpectOutput: "response:request:input",
+		},
+		"handler error": {
+			Deserialize: func(raw interface{}) interface{} { return nil },
+			HandlerErr:  fmt.Errorf("send failed"),
+			ExpectErr:   "send failed",
+		},
+		"unexpected result type": {
+			Deserialize: func(raw interface{}) interface{} { return raw },
+			ExpectErr:   "unexpected operation result type string",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			deserializer := DeserializeMiddlewareFunc("OperationDeserializer", func(ctx context.Context, in DeserializeInput, next DeserializeHandler) (
+				out DeserializeOutput, metadata Metadata, err error,
+			) {
+				out, metadata, err = next.HandleDeserialize(ctx, in)
+				if err != nil {
+					return out, metadata, err
+				}
+				out.Result = c.Deserialize(out.RawResponse)
+				return out, metadata, err
+			})
+
+			var stacks int
+			invoker := NewStackInvoker[string, *mockInvokerOutput](
+				func() *Stack {
+					stacks++
+					return NewStack("Operation", func() interface{} { return nil })
+				},
+				serializer, deserializer,
+				HandlerFunc(func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+					var metadata Metadata
+					metadata.Set("handled", true)
+					if c.HandlerErr != nil {
+						return nil, metadata, c.HandlerErr
+					}
+					return "response:" + input.(string), metadata, nil
+				}),
+			)
+
+			for i := 0; i < 2; i++ {
+				out, metadata, err := invoker.InvokeWithMetadata(context.Background(), "input")
+				if len(c.ExpectErr) != 0 {
+					if err == nil {
+						t.Fatalf("expect error")
+					}
+					if e, a := c.ExpectErr, err.Error(); !strings.Contains(a, e) {
+						t.Errorf("expect error to contain %v, got %v", e, a)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				if e, a := c.ExpectOutput, out.Value; e != a {
+					t.Errorf("expect %v output, got %v", e, a)
+				}
+				if !metadata.Has("handled") {
+					t.Errorf("expect handler metadata")
+				}
+			}
+
+			if e, a := 2, stacks; e != a {
+				t.Errorf("expect %v stacks, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+// LifecycleEventType is the type of an operation lifecycle event.
+type LifecycleEventType string
+
+// Operation lifecycle event types.
+const (
+	// OperationStartEvent is published when the operation call starts.
+	OperationStartEvent LifecycleEventType = "OperationStart"
+
+	// AttemptStartEvent is published when a request attempt of the
+	// operation starts.
+	AttemptStartEvent LifecycleEventType = "AttemptStart"
+
+	// AttemptCompleteEvent is published when a request attempt of the
+	// operation completes.
+	AttemptCompleteEvent LifecycleEventType = "AttemptComplete"
+
+	// OperationCompleteEvent is published when the operation call completes,
+	// after all of its attempts.
+	OperationCompleteEvent LifecycleEventType = "OperationComplete"
+)
+
+// LifecycleEvent is an event of the lifecycle of an operation call.
+type LifecycleEvent struct {
+	// Type is the type of the event.
+	Type LifecycleEventType
+
+	// ServiceID, and OperationName, identify the operation, if set, see
+	// AddOperationMetadataMiddleware.
+	ServiceID     string
+	OperationName string
+
+	// Time is the time the event occurred.
+	Time time.Time
+
+	// Attempt is the number of the attempt, starting at 1, of attempt
+	// events, and the number of attempts made of OperationCompleteEvent.
+	Attempt int
+
+	// Duration is the duration of the attempt, or operation call, of
+	// complete events.
+	Duration time.Duration
+
+	// Err is the error of the attempt, or operation call, of complete events,
+	// nil if it succeeded.
+	Err error
+
+	// RetryClassification is the classification of the error of
+	// AttemptCompleteEvent, if the attempt failed, and its error was
+	// classified, see AddRetryClassificationMiddleware.
+	RetryClassification *RetryClassification
+}
+
+// LifecycleSubscriber receives the lifecycle events of operation calls, e.g.
+// to build dashboards without integrating metrics or tracing. Events are
+// published synchronously, in the goroutine of the operation call, so
+// subscribers should not block.
+type LifecycleSubscriber interface {
+	OnLifecycleEvent(ctx context.Context, event LifecycleEvent)
+}
+
+// LifecycleSubscriberFunc is a function type that implements the
+// LifecycleSubscriber interface.
+type LifecycleSubscriberFunc func(ctx context.Context, event LifecycleEvent)
+
+// OnLifecycleEvent delegates to the wrapped function.
+func (fn LifecycleSubscriberFunc) OnLifecycleEvent(ctx context.Context, event LifecycleEvent) {
+	fn(ctx, event)
+}
+
+// AddLifecycleEventsMiddleware adds the middleware publishing the lifecycle
+// events of the operation call to the subscribers. The operation events are
+// published by the Initialize step, after the operation metadata is set, and
+// the attempt events by the end of the Finalize step, for each attempt of
+// retry middleware which precede it.
+//
+// Attempt events include the classification of the attempt's error if the
+// retry classification middleware is added to the stack, see
+// AddRetryClassificationMiddleware.
+func AddLifecycleEventsMiddleware(stack *Stack, subscribers ...LifecycleSubscriber) error {
+	if len(subscribers) == 0 {
+		return nil
+	}
+	p := lifecyclePublisher(subscribers)
+
+	op := &operationLifecycleEvents{publish: p}
+	var err error
+	if _, ok := stack.Initialize.Get("SetOperationMetadata"); ok {
+		err = stack.Initialize.Insert(op, "SetOperationMetadata", After)
+	} else {
+		err = stack.Initialize.Add(op, Before)
+	}
+	if err != nil {
+		return err
+	}
+
+	attempt := &attemptLifecycleEvents{publish: p}
+	if _, ok := stack.Finalize.Get("RetryClassification"); ok {
+		return stack.Finalize.Insert(attempt, "RetryClassification", Before)
+	}
+	return stack.Finalize.Add(attempt, After)
+}
+
+type lifecyclePublisher []LifecycleSubscriber
+
+func (p lifecyclePublisher) publish(ctx context.Context, event LifecycleEvent) {
+	event.ServiceID = GetServiceID(ctx)
+	event.OperationName = GetOperationName(ctx)
+	for _, s := range p {
+		s.OnLifecycleEvent(ctx, event)
+	}
+}
+
+// lifecycleAttemptsKey is the context value key of the number of attempts
+// made of the operation call.
+type lifecycleAttemptsKey struct{}
+
+type operationLifecycleEvents struct {
+	publish lifecyclePublisher
+}
+
+func (*operationLifecycleEvents) ID() string { return "OperationLifecycleEvents" }
+
+func (m *operationLifecycleEvents) HandleInitialize(ctx context.Context, in InitializeInput, next InitializeHandler) (
+	out InitializeOutput, metadata Metadata, err error,
+) {
+	clock := smithytime.GetClock(ctx)
+	start := clock.Now()
+
+	var attempts int64
+	ctx = WithStackValue(ctx, lifecycleAttemptsKey{}, &attempts)
+
+	m.publish.publish(ctx, LifecycleEvent{Type: OperationStartEvent, Time: start})
+
+	out, metadata, err = next.HandleInitialize(ctx, in)
+
+	end := clock.Now()
+	m.publish.publish(ctx, LifecycleEvent{
+		Type:     OperationCompleteEvent,
+		Time:     end,
+		Attempt:  int(atomic.LoadInt64(&attempts)),
+		Duration: end.Sub(start),
+		Err:      err,
+	})
+	return out, metadata, err
+}
+
+type attemptLifecycleEvents struct {
+	publish lifecyclePublisher
+}
+
+func (*attemptLifecycleEvents) ID() string { return "AttemptLifecycleEvents" }
+
+func (m *attemptLifecycleEvents) HandleFinalize(ctx context.Context, in FinalizeInput, next FinalizeHandler) (
+	out FinalizeOutput, metadata Metadata, err error,
+) {
+	var attempt int
+	if attempts, ok := GetStackValue(ctx, lifecycleAttemptsKey{}).(*int64); ok {
+		attempt = int(atomic.AddInt64(attempts, 1))
+	}
+	if info, ok := GetAttemptInfo(ctx); ok {
+		attempt = info.Attempt
+	}
+
+	clock := smithytime.GetClock(ctx)
+	start := clock.Now()
+	m.publish.publish(ctx, LifecycleEvent{Type: AttemptStartEvent, Time: start, Attempt: attempt})
+
+	out, metadata, err = next.HandleFinalize(ctx, in)
+
+	end := clock.Now()
+	event := LifecycleEvent{
+		Type:     AttemptCompleteEvent,
+		Time:     end,
+		Attempt:  attempt,
+		Duration: end.Sub(start),
+		Err:      err,
+	}
+	if c, ok := GetRetryClassification(metadata); ok {
+		event.RetryClassification = &c
+	}
+	m.publish.publish(ctx, event)
+
+	return out, metadata, err
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestLifecycleEventsMiddleware(t *testing.T) {
+	stack := NewStack("test", func() interface{} { return struct{}{} })
+	if err := AddOperationMetadataMiddleware(stack, "Service", "Operation"); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	// retry middleware making two attempts.
+	stack.Finalize.Add(FinalizeMiddlewareFunc("Retry", func(ctx context.Context, in FinalizeInput, next FinalizeHandler) (
+		out FinalizeOutput, metadata Metadata, err error,
+	) {
+		for attempt := 1; attempt <= 2; attempt++ {
+			out, metadata, err = next.HandleFinalize(WithAttemptInfo(ctx, AttemptInfo{Attempt: attempt}), in)
+			if err == nil {
+				break
+			}
+		}
+		return out, metadata, err
+	}), Before)
+
+	if err := AddRetryClassificationMiddleware(stack, NewRetryClassifiers(
+		RetryErrorCodeClassifier("Throttle",
+			RetryClassification{Retryable: true, Throttle: true, Reason: "throttled"},
+			"ThrottlingException"),
+	)); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var events []LifecycleEvent
+	if err := AddLifecycleEventsMiddleware(stack, LifecycleSubscriberFunc(func(ctx context.Context, event LifecycleEvent) {
+		events = append(events, event)
+	})); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var calls int
+	handler := DecorateHandler(HandlerFunc(func(ctx context.Context, input interface{}) (
+		interface{}, Metadata, error,
+	) {
+		calls++
+		if calls == 1 {
+			return nil, Metadata{}, &smithy.GenericAPIError{Code: "ThrottlingException"}
+		}
+		return "response", Metadata{}, nil
+	}), stack)
+
+	if _, _, err := handler.Handle(context.Background(), struct{}{}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	type summary struct {
+		Type      LifecycleEventType
+		Operation string
+		Attempt   int
+		Failed    bool
+		Throttle  bool
+	}
+	var actual []summary
+	for _, e := range events {
+		s := summary{
+			Type:      e.Type,
+			Operation: e.ServiceID + "." + e.OperationName,
+			Attempt:   e.Attempt,
+			Failed:    e.Err != nil,
+		}
+		if e.RetryClassification != nil {
+			s.Throttle = e.RetryClassification.Throttle
+		}
+		actual = append(actual, s)
+	}
+
+	expect := []summary{
+		{Type: OperationStartEvent, Operation: "Service.Operation"},
+		{Type: AttemptStartEvent, Operation: "Service.Operation", Attempt: 1},
+		{Type: AttemptCompleteEvent, Operation: "Service.Operation", Attempt: 1, Failed: true, Throttle: true},
+		{Type: AttemptStartEvent, Operation: "Service.Operation", Attempt: 2},
+		{Type: AttemptCompleteEvent, Operation: "Service.Operation", Attempt: 2},
+		{Type: OperationCompleteEvent, Operation: "Service.Operation", Attempt: 2},
+	}
+	if !reflect.DeepEqual(expect, actual) {
+		t.Errorf("expect events\n%+v\ngot\n%+v", expect, actual)
+	}
+}
+
+func TestStackInvoker_LifecycleSubscribers(t *testing.T) {
+	var types []LifecycleEventType
+	invoker := NewStackInvoker[string, string](
+		func() *Stack { return NewStack("test", func() interface{} { return struct{}{} }) },
+		SerializeMiddlewareFunc("OperationSerializer", func(ctx context.Context, in SerializeInput, next SerializeHandler) (
+			SerializeOutput, Metadata, error,
+		) {
+			return next.HandleSerialize(ctx, in)
+		}),
+		DeserializeMiddlewareFunc("OperationDeserializer", func(ctx context.Context, in DeserializeInput, next DeserializeHandler) (
+			DeserializeOutput, Metadata, error,
+		) {
+			return next.HandleDeserialize(ctx, in)
+		}),
+		HandlerFunc(func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+			return nil, Metadata{}, fmt.Errorf("send failed")
+		}),
+		func(o *StackInvokerOptions) {
+			o.LifecycleSubscribers = append(o.LifecycleSubscribers, LifecycleSubscriberFunc(
+				func(ctx context.Context, event LifecycleEvent) {
+					types = append(types, event.Type)
+				}))
+		},
+	)
+
+	if _, err := invoker.Invoke(context.Background(), "input"); err == nil {
+		t.Fatalf("expect error")
+	}
+
+	expect := []LifecycleEventType{
+		OperationStartEvent, AttemptStartEvent, AttemptCompleteEvent, OperationCompleteEvent,
+	}
+	if !reflect.DeepEqual(expect, types) {
+		t.Errorf("expect %v events, got %v", expect, types)
+	}
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 
+	"github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/logging"
 )
 
@@ -44,3 +45,74 @@ func (a *setLogger) HandleInitialize(ctx context.Context, in InitializeInput, ne
 ) {
 	return next.HandleInitialize(SetLogger(ctx, a.Logger), in)
 }
+
+// logModeKey is the context value key for which the log mode is associated with.
+type logModeKey struct{}
+
+// GetLogMode retrieves the logging.Mode from the context. If no mode is
+// present the zero value is returned, with all optional log categories
+// disabled.
+func GetLogMode(ctx context.Context) logging.Mode {
+	mode, _ := ctx.Value(logModeKey{}).(logging.Mode)
+	return mode
+}
+
+// SetLogMode sets the provided log mode value on the provided ctx.
+func SetLogMode(ctx context.Context, mode logging.Mode) context.Context {
+	return context.WithValue(ctx, logModeKey{}, mode)
+}
+
+type setLogMode struct {
+	Mode logging.Mode
+}
+
+// AddSetLogModeMiddleware adds a middleware that will add the provided log
+// mode to the middleware context.
+func AddSetLogModeMiddleware(stack *Stack, mode logging.Mode) error {
+	return stack.Initialize.Add(&setLogMode{Mode: mode}, After)
+}
+
+func (a *setLogMode) ID() string {
+	return "SetLogMode"
+}
+
+func (a *setLogMode) HandleInitialize(ctx context.Context, in InitializeInput, next InitializeHandler) (
+	out InitializeOutput, metadata Metadata, err error,
+) {
+	return next.HandleInitialize(SetLogMode(ctx, a.Mode), in)
+}
+
+// AddOperationParametersLoggingMiddleware adds a middleware to the stack's
+// Initialize step that logs the operation's input parameters and result,
+// if enabled by the logging.LogOperationInput and
+// logging.LogOperationOutput categories of the context's log mode. Values
+// are rendered with smithy.Redact, so members with the Smithy sensitive
+// trait are not logged.
+func AddOperationParametersLoggingMiddleware(stack *Stack) error {
+	return stack.Initialize.Add(&operationParametersLogger{}, After)
+}
+
+type operationParametersLogger struct{}
+
+func (*operationParametersLogger) ID() string {
+	return "OperationParametersLogger"
+}
+
+func (*operationParametersLogger) HandleInitialize(ctx context.Context, in InitializeInput, next InitializeHandler) (
+	out InitializeOutput, metadata Metadata, err error,
+) {
+	mode := GetLogMode(ctx)
+	logger := GetLogger(ctx)
+
+	if mode.IsOperationInput() {
+		logger.Logf(logging.Debug, "Operation input\n%s", smithy.Redact(in.Parameters))
+	}
+
+	out, metadata, err = next.HandleInitialize(ctx, in)
+
+	if err == nil && mode.IsOperationOutput() {
+		logger.Logf(logging.Debug, "Operation output\n%s", smithy.Redact(out.Result))
+	}
+
+	return out, metadata, err
+}
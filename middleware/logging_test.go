@@ -2,11 +2,15 @@ package middleware_test
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
+	"reflect"
 	"testing"
 
+	"github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/logging"
 	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/ptr"
 )
 
 type mockWithContextLogger struct {
@@ -45,3 +49,92 @@ func TestGetLogger(t *testing.T) {
 		t.Error("expect logger context to match")
 	}
 }
+
+func TestGetLogMode(t *testing.T) {
+	if mode := middleware.GetLogMode(context.Background()); mode != 0 {
+		t.Errorf("expect zero mode, got %v", mode)
+	}
+
+	expect := logging.LogRequestBody | logging.LogRetries
+	ctx := middleware.SetLogMode(context.Background(), expect)
+	mode := middleware.GetLogMode(ctx)
+	if e, a := expect, mode; e != a {
+		t.Errorf("expect %v mode, got %v", e, a)
+	}
+	if !mode.IsRequestBody() {
+		t.Error("expect request body logging enabled")
+	}
+	if mode.IsResponseBody() {
+		t.Error("expect response body logging disabled")
+	}
+}
+
+type mockSensitiveInput struct {
+	Name     *string
+	Password *string
+}
+
+func init() {
+	smithy.RegisterSensitiveMembers(mockSensitiveInput{}, "Password")
+}
+
+func TestOperationParametersLogging(t *testing.T) {
+	cases := map[string]struct {
+		Mode   logging.Mode
+		Expect []string
+	}{
+		"disabled": {},
+		"input": {
+			Mode:   logging.LogOperationInput,
+			Expect: []string{"Operation input\n{Name:\"gopher\" Password:***}"},
+		},
+		"input and output": {
+			Mode: logging.LogOperationInput | logging.LogOperationOutput,
+			Expect: []string{
+				"Operation input\n{Name:\"gopher\" Password:***}",
+				"Operation output\n{Name:\"gopher\" Password:***}",
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var logs []string
+			logger := logging.LoggerFunc(func(classification logging.Classification, format string, v ...interface{}) {
+				logs = append(logs, fmt.Sprintf(format, v...))
+			})
+
+			stack := middleware.NewStack("test", func() interface{} { return struct{}{} })
+			if err := middleware.AddSetLoggerMiddleware(stack, logger); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if err := middleware.AddSetLogModeMiddleware(stack, c.Mode); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if err := middleware.AddOperationParametersLoggingMiddleware(stack); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("result",
+				func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+					out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+				) {
+					out, metadata, err = next.HandleDeserialize(ctx, in)
+					out.Result = &mockSensitiveInput{Name: ptr.String("gopher"), Password: ptr.String("hunter2")}
+					return out, metadata, err
+				}), middleware.After)
+
+			input := &mockSensitiveInput{Name: ptr.String("gopher"), Password: ptr.String("hunter2")}
+			handler := middleware.DecorateHandler(middleware.HandlerFunc(
+				func(ctx context.Context, in interface{}) (interface{}, middleware.Metadata, error) {
+					return nil, middleware.Metadata{}, nil
+				}), stack)
+			if _, _, err := handler.Handle(context.Background(), input); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := c.Expect, logs; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %q logs, got %q", e, a)
+			}
+		})
+	}
+}
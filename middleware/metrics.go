@@ -0,0 +1,253 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/smithy-go/metrics"
+)
+
+// OperationMetrics is the set of built-in instruments recorded by the
+// middleware stack for an operation invocation.
+type OperationMetrics struct {
+	// Duration is the overall duration of the operation call, including
+	// retries, in seconds.
+	Duration metrics.Float64Histogram
+
+	// AttemptDuration is the duration of a single request attempt, in seconds.
+	AttemptDuration metrics.Float64Histogram
+
+	// SerializeDuration is the time taken to serialize the operation input,
+	// in seconds.
+	SerializeDuration metrics.Float64Histogram
+
+	// DeserializeDuration is the time taken to deserialize a response, in
+	// seconds.
+	DeserializeDuration metrics.Float64Histogram
+
+	// RequestSize is the size of the serialized request payload, in bytes.
+	RequestSize metrics.Int64Histogram
+
+	// ResponseSize is the size of the response payload, in bytes.
+	ResponseSize metrics.Int64Histogram
+}
+
+// NewOperationMetrics creates the built-in operation instruments from the
+// provided meter.
+func NewOperationMetrics(meter metrics.Meter) (*OperationMetrics, error) {
+	var om OperationMetrics
+	var err error
+
+	durations := []struct {
+		Name        string
+		Description string
+		Instrument  *metrics.Float64Histogram
+	}{
+		{"client.call.duration", "Overall call duration, including retries", &om.Duration},
+		{"client.call.attempt_duration", "Duration of a single request attempt", &om.AttemptDuration},
+		{"client.call.serialization_duration", "Time taken to serialize the request", &om.SerializeDuration},
+		{"client.call.deserialization_duration", "Time taken to deserialize the response", &om.DeserializeDuration},
+	}
+	for _, d := range durations {
+		*d.Instrument, err = meter.Float64Histogram(d.Name,
+			metrics.WithUnit("s"), metrics.WithDescription(d.Description))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sizes := []struct {
+		Name        string
+		Description string
+		Instrument  *metrics.Int64Histogram
+	}{
+		{"client.call.request_size", "Size of the serialized request payload", &om.RequestSize},
+		{"client.call.response_size", "Size of the response payload", &om.ResponseSize},
+	}
+	for _, s := range sizes {
+		*s.Instrument, err = meter.Int64Histogram(s.Name,
+			metrics.WithUnit("By"), metrics.WithDescription(s.Description))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &om, nil
+}
+
+// operationMetricsKey is the context value key for which the operation
+// metrics are associated with.
+type operationMetricsKey struct{}
+
+// GetOperationMetrics returns the OperationMetrics stored on the context, or
+// nil if no metrics are being recorded for the operation.
+func GetOperationMetrics(ctx context.Context) *OperationMetrics {
+	om, _ := ctx.Value(operationMetricsKey{}).(*OperationMetrics)
+	return om
+}
+
+// SetOperationMetrics sets the provided OperationMetrics on the provided ctx.
+func SetOperationMetrics(ctx context.Context, om *OperationMetrics) context.Context {
+	return context.WithValue(ctx, operationMetricsKey{}, om)
+}
+
+// AddOperationMetricsMiddleware adds middleware to the stack that record the
+// built-in operation instruments using a Meter for the given scope created by
+// the provider. The call duration is recorded by the Initialize step,
+// serialization time between the Serialize and Build steps, attempt duration
+// at the end of the Finalize step, and deserialization time by the Deserialize
+// step.
+//
+// If provider is nil, metrics.NopMeterProvider is used.
+func AddOperationMetricsMiddleware(stack *Stack, provider metrics.MeterProvider, scope string) error {
+	if provider == nil {
+		provider = metrics.NopMeterProvider{}
+	}
+
+	om, err := NewOperationMetrics(provider.Meter(scope))
+	if err != nil {
+		return err
+	}
+
+	if err := stack.Initialize.Add(&callMetrics{metrics: om}, Before); err != nil {
+		return err
+	}
+	if err := stack.Serialize.Add(serializeMetricsStart{}, Before); err != nil {
+		return err
+	}
+	if err := stack.Build.Add(serializeMetricsEnd{}, Before); err != nil {
+		return err
+	}
+	if err := stack.Finalize.Add(attemptMetrics{}, After); err != nil {
+		return err
+	}
+	if err := stack.Deserialize.Add(deserializeMetricsStart{}, Before); err != nil {
+		return err
+	}
+	return stack.Deserialize.Add(deserializeMetricsEnd{}, After)
+}
+
+// operationTimingKey is the context value key for the timestamps shared
+// between the operation metrics middleware.
+type operationTimingKey struct{}
+
+type operationTiming struct {
+	serializeStart   time.Time
+	responseReceived time.Time
+}
+
+func getOperationTiming(ctx context.Context) *operationTiming {
+	t, _ := ctx.Value(operationTimingKey{}).(*operationTiming)
+	return t
+}
+
+func elapsedSeconds(start time.Time) float64 {
+	return float64(time.Since(start)) / float64(time.Second)
+}
+
+type callMetrics struct {
+	metrics *OperationMetrics
+}
+
+func (*callMetrics) ID() string { return "OperationMetrics" }
+
+func (m *callMetrics) HandleInitialize(ctx context.Context, in InitializeInput, next InitializeHandler) (
+	out InitializeOutput, metadata Metadata, err error,
+) {
+	ctx = SetOperationMetrics(ctx, m.metrics)
+	ctx = context.WithValue(ctx, operationTimingKey{}, &operationTiming{})
+
+	start := time.Now()
+	out, metadata, err = next.HandleInitialize(ctx, in)
+	m.metrics.Duration.Record(ctx, elapsedSeconds(start), withOperationMetadata(ctx))
+
+	return out, metadata, err
+}
+
+type serializeMetricsStart struct{}
+
+func (serializeMetricsStart) ID() string { return "SerializeMetricsStart" }
+
+func (serializeMetricsStart) HandleSerialize(ctx context.Context, in SerializeInput, next SerializeHandler) (
+	out SerializeOutput, metadata Metadata, err error,
+) {
+	if t := getOperationTiming(ctx); t != nil {
+		t.serializeStart = time.Now()
+	}
+	return next.HandleSerialize(ctx, in)
+}
+
+type serializeMetricsEnd struct{}
+
+func (serializeMetricsEnd) ID() string { return "SerializeMetricsEnd" }
+
+func (serializeMetricsEnd) HandleBuild(ctx context.Context, in BuildInput, next BuildHandler) (
+	out BuildOutput, metadata Metadata, err error,
+) {
+	if t, om := getOperationTiming(ctx), GetOperationMetrics(ctx); t != nil && om != nil {
+		om.SerializeDuration.Record(ctx, elapsedSeconds(t.serializeStart), withOperationMetadata(ctx))
+	}
+	return next.HandleBuild(ctx, in)
+}
+
+type attemptMetrics struct{}
+
+func (attemptMetrics) ID() string { return "AttemptMetrics" }
+
+func (attemptMetrics) HandleFinalize(ctx context.Context, in FinalizeInput, next FinalizeHandler) (
+	out FinalizeOutput, metadata Metadata, err error,
+) {
+	om := GetOperationMetrics(ctx)
+	if om == nil {
+		return next.HandleFinalize(ctx, in)
+	}
+
+	start := time.Now()
+	out, metadata, err = next.HandleFinalize(ctx, in)
+	om.AttemptDuration.Record(ctx, elapsedSeconds(start), withOperationMetadata(ctx))
+
+	return out, metadata, err
+}
+
+type deserializeMetricsStart struct{}
+
+func (deserializeMetricsStart) ID() string { return "DeserializeMetricsStart" }
+
+func (deserializeMetricsStart) HandleDeserialize(ctx context.Context, in DeserializeInput, next DeserializeHandler) (
+	out DeserializeOutput, metadata Metadata, err error,
+) {
+	om := GetOperationMetrics(ctx)
+	if om == nil {
+		return next.HandleDeserialize(ctx, in)
+	}
+
+	// timing is scoped to the attempt, so that the response received
+	// timestamp of a previous attempt is not used.
+	t := &operationTiming{}
+	out, metadata, err = next.HandleDeserialize(context.WithValue(ctx, operationTimingKey{}, t), in)
+	if !t.responseReceived.IsZero() {
+		om.DeserializeDuration.Record(ctx, elapsedSeconds(t.responseReceived), withOperationMetadata(ctx))
+	}
+
+	return out, metadata, err
+}
+
+type deserializeMetricsEnd struct{}
+
+func (deserializeMetricsEnd) ID() string { return "DeserializeMetricsEnd" }
+
+func (deserializeMetricsEnd) HandleDeserialize(ctx context.Context, in DeserializeInput, next DeserializeHandler) (
+	out DeserializeOutput, metadata Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if t := getOperationTiming(ctx); t != nil {
+		t.responseReceived = time.Now()
+	}
+	return out, metadata, err
+}
+
+// withOperationMetadata returns the record option setting the operation
+// metadata of the context as the measurement's attributes.
+func withOperationMetadata(ctx context.Context) metrics.RecordMetricOption {
+	return metrics.WithProperties(OperationMetadataProperties(ctx))
+}
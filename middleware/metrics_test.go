@@ -0,0 +1,125 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/smithy-go/metrics"
+	"github.com/aws/smithy-go/middleware"
+)
+
+type mockMeterProvider struct {
+	Scope    string
+	Recorded map[string]int
+}
+
+func (p *mockMeterProvider) Meter(scope string, _ ...metrics.MeterOption) metrics.Meter {
+	p.Scope = scope
+	return mockMeter{provider: p}
+}
+
+type mockMeter struct {
+	provider *mockMeterProvider
+}
+
+func (m mockMeter) instrument(name string) mockInstrument {
+	return mockInstrument{name: name, provider: m.provider}
+}
+
+func (m mockMeter) Int64Counter(name string, _ ...metrics.InstrumentOption) (metrics.Int64Counter, error) {
+	return m.instrument(name), nil
+}
+func (m mockMeter) Int64UpDownCounter(name string, _ ...metrics.InstrumentOption) (metrics.Int64UpDownCounter, error) {
+	return m.instrument(name), nil
+}
+func (m mockMeter) Int64Histogram(name string, _ ...metrics.InstrumentOption) (metrics.Int64Histogram, error) {
+	return m.instrument(name), nil
+}
+func (m mockMeter) Float64Counter(name string, _ ...metrics.InstrumentOption) (metrics.Float64Counter, error) {
+	return mockFloatInstrument{m.instrument(name)}, nil
+}
+func (m mockMeter) Float64UpDownCounter(name string, _ ...metrics.InstrumentOption) (metrics.Float64UpDownCounter, error) {
+	return mockFloatInstrument{m.instrument(name)}, nil
+}
+func (m mockMeter) Float64Histogram(name string, _ ...metrics.InstrumentOption) (metrics.Float64Histogram, error) {
+	return mockFloatInstrument{m.instrument(name)}, nil
+}
+
+type mockInstrument struct {
+	name     string
+	provider *mockMeterProvider
+}
+
+func (i mockInstrument) record() {
+	if i.provider.Recorded == nil {
+		i.provider.Recorded = map[string]int{}
+	}
+	i.provider.Recorded[i.name]++
+}
+
+func (i mockInstrument) Add(context.Context, int64, ...metrics.RecordMetricOption)    { i.record() }
+func (i mockInstrument) Record(context.Context, int64, ...metrics.RecordMetricOption) { i.record() }
+
+type mockFloatInstrument struct {
+	mockInstrument
+}
+
+func (i mockFloatInstrument) Add(context.Context, float64, ...metrics.RecordMetricOption) {
+	i.record()
+}
+func (i mockFloatInstrument) Record(context.Context, float64, ...metrics.RecordMetricOption) {
+	i.record()
+}
+
+func TestAddOperationMetricsMiddleware(t *testing.T) {
+	provider := &mockMeterProvider{}
+
+	stack := middleware.NewStack("test", func() interface{} { return struct{}{} })
+	if err := middleware.AddOperationMetricsMiddleware(stack, provider, "test-scope"); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var foundMetrics bool
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+			foundMetrics = middleware.GetOperationMetrics(ctx) != nil
+			return nil, middleware.Metadata{}, nil
+		}), stack)
+
+	if _, _, err := handler.Handle(context.Background(), struct{}{}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if !foundMetrics {
+		t.Error("expect operation metrics on context")
+	}
+	if e, a := "test-scope", provider.Scope; e != a {
+		t.Errorf("expect %v scope, got %v", e, a)
+	}
+	for _, name := range []string{
+		"client.call.duration",
+		"client.call.attempt_duration",
+		"client.call.serialization_duration",
+		"client.call.deserialization_duration",
+	} {
+		if e, a := 1, provider.Recorded[name]; e != a {
+			t.Errorf("expect %v %s recording, got %v", e, name, a)
+		}
+	}
+}
+
+func TestAddOperationMetricsMiddleware_Nop(t *testing.T) {
+	stack := middleware.NewStack("test", func() interface{} { return struct{}{} })
+	if err := middleware.AddOperationMetricsMiddleware(stack, nil, "test-scope"); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+			return nil, middleware.Metadata{}, nil
+		}), stack)
+
+	if _, _, err := handler.Handle(context.Background(), struct{}{}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+}
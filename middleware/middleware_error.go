@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aws/smithy-go"
+)
+
+// HandlerStep is the Step of a MiddlewareError for an error returned by the
+// handler a stack decorates, such as the transport's send of the request,
+// rather than by one of the stack's middleware.
+const HandlerStep = "Handler"
+
+// MiddlewareError is the error returned by a stack which records middleware
+// errors, see Stack.EnableMiddlewareErrors, for an error returned by one of
+// its middleware, or the handler it decorates, recording the provenance of
+// the error. The error message is that of the underlying error, which is
+// available with errors.Unwrap, errors.Is, and errors.As.
+type MiddlewareError struct {
+	// Step is the name of the step of the middleware that returned the
+	// error, e.g. "Serialize", or HandlerStep if the error was returned by
+	// the handler the stack decorates.
+	Step string
+
+	// ID is the ID of the middleware that returned the error. Empty if the
+	// error was returned by a handler without an ID.
+	ID string
+
+	// Trail is the ordered list of the middleware that were invoked up to and
+	// including the middleware, or handler, that returned the error, across
+	// all the stack's steps. Each entry is of the form "Step/ID".
+	Trail []string
+
+	// Attempt is the number of the request attempt the error was returned
+	// in, see GetAttemptInfo. Zero if not within an attempt.
+	Attempt int
+
+	// Err is the error returned by the middleware.
+	Err error
+}
+
+// Error returns the message of the underlying error.
+func (e *MiddlewareError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *MiddlewareError) Unwrap() error {
+	return e.Err
+}
+
+// TrailString returns the middleware trail of the error joined by " -> ".
+func (e *MiddlewareError) TrailString() string {
+	return strings.Join(e.Trail, " -> ")
+}
+
+type middlewareErrorsKey struct{}
+
+type middlewareTrailKey struct{}
+
+// middlewareErrorsEnabled returns whether the stack invoking the step
+// records middleware errors.
+func middlewareErrorsEnabled(ctx context.Context) bool {
+	v, _ := ctx.Value(middlewareErrorsKey{}).(bool)
+	return v
+}
+
+// withMiddlewareTrail returns a context with the trail of the middleware
+// invoked before the next step, for the errors of the subsequent steps.
+func withMiddlewareTrail(ctx context.Context, step string, order []interface{}) context.Context {
+	prev, _ := ctx.Value(middlewareTrailKey{}).([]string)
+	trail := make([]string, 0, len(prev)+len(order))
+	trail = append(trail, prev...)
+	trail = append(trail, middlewareTrail(step, order)...)
+	return context.WithValue(ctx, middlewareTrailKey{}, trail)
+}
+
+// wrapMiddlewareError wraps the error returned by the last of the executed
+// middleware of the step in a MiddlewareError. Errors already containing a
+// MiddlewareError were returned by a middleware, or the handler, further
+// down the stack and are returned unmodified.
+func wrapMiddlewareError(ctx context.Context, err error, step string, executed []interface{}) error {
+	var mwErr *MiddlewareError
+	if errors.As(err, &mwErr) {
+		return err
+	}
+
+	prev, _ := ctx.Value(middlewareTrailKey{}).([]string)
+	trail := make([]string, 0, len(prev)+len(executed))
+	trail = append(trail, prev...)
+	trail = append(trail, middlewareTrail(step, executed)...)
+
+	attempt, _ := GetAttemptInfo(ctx)
+	return &MiddlewareError{
+		Step:    step,
+		ID:      executed[len(executed)-1].(ider).ID(),
+		Trail:   trail,
+		Attempt: attempt.Attempt,
+		Err:     err,
+	}
+}
+
+func middlewareTrail(step string, order []interface{}) []string {
+	trail := make([]string, len(order))
+	for i, m := range order {
+		trail[i] = step + "/" + m.(ider).ID()
+	}
+	return trail
+}
+
+// middlewareErrorHandler wraps the errors returned by the handler a stack
+// decorates in a MiddlewareError attributed to the handler.
+type middlewareErrorHandler struct {
+	Next Handler
+}
+
+func (h middlewareErrorHandler) Handle(ctx context.Context, input interface{}) (
+	output interface{}, metadata Metadata, err error,
+) {
+	output, metadata, err = h.Next.Handle(ctx, input)
+	if err == nil {
+		return output, metadata, err
+	}
+
+	var mwErr *MiddlewareError
+	if errors.As(err, &mwErr) {
+		return output, metadata, err
+	}
+
+	var id string
+	if v, ok := h.Next.(ider); ok {
+		id = v.ID()
+	}
+	entry := HandlerStep
+	if len(id) != 0 {
+		entry += "/" + id
+	}
+
+	prev, _ := ctx.Value(middlewareTrailKey{}).([]string)
+	trail := make([]string, 0, len(prev)+1)
+	trail = append(trail, prev...)
+	trail = append(trail, entry)
+
+	attempt, _ := GetAttemptInfo(ctx)
+	return output, metadata, &MiddlewareError{
+		Step:    HandlerStep,
+		ID:      id,
+		Trail:   trail,
+		Attempt: attempt.Attempt,
+		Err:     err,
+	}
+}
+
+// NewOperationError returns a smithy.OperationError for the error returned by
+// invoking the operation's stack. The phase and attempt of the error are
+// taken from the MiddlewareError in err's chain, if any, see
+// Stack.EnableMiddlewareErrors.
+func NewOperationError(serviceID, operationName string, err error) *smithy.OperationError {
+	opErr := &smithy.OperationError{
+		ServiceID:     serviceID,
+		OperationName: operationName,
+		Err:           err,
+	}
+
+	var mwErr *MiddlewareError
+	if errors.As(err, &mwErr) {
+		opErr.Phase = mwErr.Step
+		opErr.Attempt = mwErr.Attempt
+	}
+	return opErr
+}
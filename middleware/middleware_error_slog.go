@@ -0,0 +1,27 @@
+//go:build go1.21
+// +build go1.21
+
+package middleware
+
+import "log/slog"
+
+var _ slog.LogValuer = (*MiddlewareError)(nil)
+
+// LogValue returns the provenance of the error and the underlying error as a
+// group of attributes, implementing slog.LogValuer.
+func (e *MiddlewareError) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("step", e.Step),
+		slog.String("middleware", e.ID),
+	}
+	if e.Attempt != 0 {
+		attrs = append(attrs, slog.Int("attempt", e.Attempt))
+	}
+
+	if v, ok := e.Err.(slog.LogValuer); ok {
+		attrs = append(attrs, slog.Any("error", v))
+	} else {
+		attrs = append(attrs, slog.String("error", e.Err.Error()))
+	}
+	return slog.GroupValue(attrs...)
+}
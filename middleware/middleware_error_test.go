@@ -0,0 +1,197 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestMiddlewareError(t *testing.T) {
+	errFailed := fmt.Errorf("build failed")
+
+	stack := NewStack("test", func() interface{} { return struct{}{} })
+	stack.EnableMiddlewareErrors()
+	stack.Initialize.Add(InitializeMiddlewareFunc("initA",
+		func(ctx context.Context, in InitializeInput, next InitializeHandler) (InitializeOutput, Metadata, error) {
+			out, md, err := next.HandleInitialize(ctx, in)
+			if err != nil {
+				return out, md, fmt.Errorf("initA, %w", err)
+			}
+			return out, md, err
+		}), After)
+	stack.Serialize.Add(SerializeMiddlewareFunc("serializeA",
+		func(ctx context.Context, in SerializeInput, next SerializeHandler) (SerializeOutput, Metadata, error) {
+			return next.HandleSerialize(ctx, in)
+		}), After)
+	stack.Build.Add(BuildMiddlewareFunc("buildA",
+		func(ctx context.Context, in BuildInput, next BuildHandler) (BuildOutput, Metadata, error) {
+			return next.HandleBuild(ctx, in)
+		}), After)
+	stack.Build.Add(BuildMiddlewareFunc("buildB",
+		func(ctx context.Context, in BuildInput, next BuildHandler) (BuildOutput, Metadata, error) {
+			return BuildOutput{}, Metadata{}, errFailed
+		}), After)
+	stack.Build.Add(BuildMiddlewareFunc("buildC",
+		func(ctx context.Context, in BuildInput, next BuildHandler) (BuildOutput, Metadata, error) {
+			t.Errorf("expect buildC not to be called")
+			return next.HandleBuild(ctx, in)
+		}), After)
+
+	handler := DecorateHandler(HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+			t.Errorf("expect handler not to be called")
+			return nil, Metadata{}, nil
+		}), stack)
+
+	_, _, err := handler.Handle(context.Background(), struct{}{})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if e, a := "initA, build failed", err.Error(); e != a {
+		t.Errorf("expect %q error, got %q", e, a)
+	}
+	if !errors.Is(err, errFailed) {
+		t.Errorf("expect error to be %v", errFailed)
+	}
+
+	var mwErr *MiddlewareError
+	if !errors.As(err, &mwErr) {
+		t.Fatalf("expect middleware error, got %T", err)
+	}
+	if e, a := "Build", mwErr.Step; e != a {
+		t.Errorf("expect %v step, got %v", e, a)
+	}
+	if e, a := "buildB", mwErr.ID; e != a {
+		t.Errorf("expect %v ID, got %v", e, a)
+	}
+	expectTrail := []string{"Initialize/initA", "Serialize/serializeA", "Build/buildA", "Build/buildB"}
+	if e, a := expectTrail, mwErr.Trail; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v trail, got %v", e, a)
+	}
+	if e, a := "Initialize/initA -> Serialize/serializeA -> Build/buildA -> Build/buildB", mwErr.TrailString(); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestMiddlewareError_Handler(t *testing.T) {
+	errFailed := fmt.Errorf("send failed")
+
+	stack := NewStack("test", func() interface{} { return struct{}{} })
+	stack.EnableMiddlewareErrors()
+	stack.Deserialize.Add(DeserializeMiddlewareFunc("deserializeA",
+		func(ctx context.Context, in DeserializeInput, next DeserializeHandler) (DeserializeOutput, Metadata, error) {
+			return next.HandleDeserialize(ctx, in)
+		}), After)
+
+	handler := DecorateHandler(HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+			return nil, Metadata{}, errFailed
+		}), stack)
+
+	_, _, err := handler.Handle(context.Background(), struct{}{})
+
+	var mwErr *MiddlewareError
+	if !errors.As(err, &mwErr) {
+		t.Fatalf("expect middleware error, got %T", err)
+	}
+	if e, a := HandlerStep, mwErr.Step; e != a {
+		t.Errorf("expect %v step, got %v", e, a)
+	}
+	if e, a := "", mwErr.ID; e != a {
+		t.Errorf("expect %q ID, got %q", e, a)
+	}
+	expectTrail := []string{"Deserialize/deserializeA", "Handler"}
+	if e, a := expectTrail, mwErr.Trail; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v trail, got %v", e, a)
+	}
+	if e, a := errFailed, mwErr.Err; e != a {
+		t.Errorf("expect %v error, got %v", e, a)
+	}
+}
+
+func TestMiddlewareError_Disabled(t *testing.T) {
+	errFailed := fmt.Errorf("send failed")
+
+	stack := NewStack("test", func() interface{} { return struct{}{} })
+	stack.Build.Add(BuildMiddlewareFunc("buildA",
+		func(ctx context.Context, in BuildInput, next BuildHandler) (BuildOutput, Metadata, error) {
+			return next.HandleBuild(ctx, in)
+		}), After)
+
+	handler := DecorateHandler(HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+			return nil, Metadata{}, errFailed
+		}), stack)
+
+	_, _, err := handler.Handle(context.Background(), struct{}{})
+	if e, a := errFailed, err; e != a {
+		t.Errorf("expect error returned unmodified, got %T, %v", a, a)
+	}
+}
+
+func TestMiddlewareError_Repeated(t *testing.T) {
+	stack := NewStack("test", func() interface{} { return struct{}{} })
+	stack.EnableMiddlewareErrors()
+	stack.Initialize.Add(InitializeMiddlewareFunc("initA",
+		func(ctx context.Context, in InitializeInput, next InitializeHandler) (InitializeOutput, Metadata, error) {
+			return next.HandleInitialize(ctx, in)
+		}), After)
+	stack.Finalize.Add(FinalizeMiddlewareFunc("retry",
+		func(ctx context.Context, in FinalizeInput, next FinalizeHandler) (out FinalizeOutput, md Metadata, err error) {
+			for i := 0; i < 2; i++ {
+				out, md, err = next.HandleFinalize(ctx, in)
+			}
+			return out, md, err
+		}), After)
+
+	handler := DecorateHandler(HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+			return nil, Metadata{}, fmt.Errorf("send failed")
+		}), stack)
+
+	for i := 0; i < 2; i++ {
+		_, _, err := handler.Handle(context.Background(), struct{}{})
+
+		var mwErr *MiddlewareError
+		if !errors.As(err, &mwErr) {
+			t.Fatalf("expect middleware error, got %T", err)
+		}
+		expectTrail := []string{"Initialize/initA", "Finalize/retry", "Handler"}
+		if e, a := expectTrail, mwErr.Trail; !reflect.DeepEqual(e, a) {
+			t.Errorf("%d, expect %v trail, got %v", i, e, a)
+		}
+	}
+}
+
+func TestNewOperationError(t *testing.T) {
+	stack := NewStack("test", func() interface{} { return struct{}{} })
+	stack.EnableMiddlewareErrors()
+	stack.Finalize.Add(FinalizeMiddlewareFunc("retry",
+		func(ctx context.Context, in FinalizeInput, next FinalizeHandler) (FinalizeOutput, Metadata, error) {
+			ctx = WithAttemptInfo(ctx, AttemptInfo{Attempt: 3})
+			return next.HandleFinalize(ctx, in)
+		}), After)
+	stack.Finalize.Add(FinalizeMiddlewareFunc("send",
+		func(ctx context.Context, in FinalizeInput, next FinalizeHandler) (FinalizeOutput, Metadata, error) {
+			return FinalizeOutput{}, Metadata{}, fmt.Errorf("send failed")
+		}), After)
+
+	handler := DecorateHandler(HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+			return nil, Metadata{}, nil
+		}), stack)
+	_, _, err := handler.Handle(context.Background(), struct{}{})
+
+	opErr := NewOperationError("FooService", "GetFoo", err)
+	if e, a := "Finalize", opErr.Phase; e != a {
+		t.Errorf("expect %v phase, got %v", e, a)
+	}
+	if e, a := 3, opErr.Attempt; e != a {
+		t.Errorf("expect %v attempt, got %v", e, a)
+	}
+	if e, a := "operation error FooService: GetFoo, send failed", opErr.Error(); e != a {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+}
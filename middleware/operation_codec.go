@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/smithy-go"
+)
+
+// OperationSerializer serializes the input parameters of an operation into
+// its transport request. Invoked by the stack from the
+// SlotOperationSerializer slot of the Serialize step, decoupling the codec of
+// an operation from the middleware generated for it, so that an alternative
+// codec, e.g. hand-written, reflection based, or driven by a model loaded at
+// runtime, can replace it.
+type OperationSerializer interface {
+	// SerializeOperation serializes the input into the request, returning
+	// the updated request.
+	SerializeOperation(ctx context.Context, input interface{}, request interface{}) (interface{}, error)
+}
+
+// OperationSerializerFunc is a function that satisfies OperationSerializer.
+type OperationSerializerFunc func(ctx context.Context, input interface{}, request interface{}) (interface{}, error)
+
+// SerializeOperation calls the wrapped function.
+func (fn OperationSerializerFunc) SerializeOperation(ctx context.Context, input interface{}, request interface{}) (interface{}, error) {
+	return fn(ctx, input, request)
+}
+
+// OperationDeserializer deserializes the transport response of an operation
+// into its result. Invoked by the stack from the SlotOperationDeserializer
+// slot of the Deserialize step, see OperationSerializer.
+type OperationDeserializer interface {
+	// DeserializeOperation deserializes the response into the operation's
+	// result, or returns the operation's error. Metadata of the result, such
+	// as the request ID, may be added to metadata.
+	DeserializeOperation(ctx context.Context, response interface{}, metadata *Metadata) (interface{}, error)
+}
+
+// OperationDeserializerFunc is a function that satisfies
+// OperationDeserializer.
+type OperationDeserializerFunc func(ctx context.Context, response interface{}, metadata *Metadata) (interface{}, error)
+
+// DeserializeOperation calls the wrapped function.
+func (fn OperationDeserializerFunc) DeserializeOperation(ctx context.Context, response interface{}, metadata *Metadata) (interface{}, error) {
+	return fn(ctx, response, metadata)
+}
+
+// NewOperationSerializerMiddleware returns the Serialize step middleware
+// invoking the serializer, with the ID of the SlotOperationSerializer slot.
+// Errors returned by the serializer are wrapped in a
+// smithy.SerializationError, if not already.
+func NewOperationSerializerMiddleware(s OperationSerializer) SerializeMiddleware {
+	return &operationSerializer{serializer: s}
+}
+
+type operationSerializer struct {
+	serializer OperationSerializer
+}
+
+func (*operationSerializer) ID() string { return SlotOperationSerializer }
+
+func (m *operationSerializer) HandleSerialize(ctx context.Context, in SerializeInput, next SerializeHandler) (
+	out SerializeOutput, metadata Metadata, err error,
+) {
+	request, err := m.serializer.SerializeOperation(ctx, in.Parameters, in.Request)
+	if err != nil {
+		var serErr *smithy.SerializationError
+		if !errors.As(err, &serErr) {
+			err = &smithy.SerializationError{Err: err}
+		}
+		return out, metadata, err
+	}
+	in.Request = request
+
+	return next.HandleSerialize(ctx, in)
+}
+
+// NewOperationDeserializerMiddleware returns the Deserialize step middleware
+// invoking the deserializer with the raw response of the next handler, with
+// the ID of the SlotOperationDeserializer slot.
+func NewOperationDeserializerMiddleware(d OperationDeserializer) DeserializeMiddleware {
+	return &operationDeserializer{deserializer: d}
+}
+
+type operationDeserializer struct {
+	deserializer OperationDeserializer
+}
+
+func (*operationDeserializer) ID() string { return SlotOperationDeserializer }
+
+func (m *operationDeserializer) HandleDeserialize(ctx context.Context, in DeserializeInput, next DeserializeHandler) (
+	out DeserializeOutput, metadata Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	result, err := m.deserializer.DeserializeOperation(ctx, out.RawResponse, &metadata)
+	if err != nil {
+		return out, metadata, err
+	}
+	out.Result = result
+
+	return out, metadata, nil
+}
+
+// SetOperationSerializer sets the serializer of the stack's operation,
+// replacing the middleware in the SlotOperationSerializer slot, such as the
+// operation's generated serializer, if present. Otherwise the serializer is
+// added to the end of the Serialize step.
+func SetOperationSerializer(stack *Stack, s OperationSerializer) error {
+	m := NewOperationSerializerMiddleware(s)
+	if _, ok := stack.Serialize.ids.items[SlotOperationSerializer]; ok {
+		_, err := stack.Serialize.Swap(SlotOperationSerializer, m)
+		return err
+	}
+	return stack.Serialize.Add(m, After)
+}
+
+// SetOperationDeserializer sets the deserializer of the stack's operation,
+// replacing the middleware in the SlotOperationDeserializer slot, such as the
+// operation's generated deserializer, if present. Otherwise the deserializer
+// is added to the end of the Deserialize step.
+func SetOperationDeserializer(stack *Stack, d OperationDeserializer) error {
+	m := NewOperationDeserializerMiddleware(d)
+	if _, ok := stack.Deserialize.ids.items[SlotOperationDeserializer]; ok {
+		_, err := stack.Deserialize.Swap(SlotOperationDeserializer, m)
+		return err
+	}
+	return stack.Deserialize.Add(m, After)
+}
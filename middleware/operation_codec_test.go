@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSetOperationCodec(t *testing.T) {
+	s := NewStack("fooStack", func() interface{} { return "" })
+
+	// slots of the operation codec are positioned before being filled.
+	if err := s.Deserialize.Insert(mockDeserializeMiddleware("beforeDeserializer"), SlotOperationDeserializer, Before); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	// generated codec, replaced below.
+	if err := s.Serialize.Add(mockSerializeMiddleware(SlotOperationSerializer), After); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := s.Serialize.Add(mockSerializeMiddleware("last"), After); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	err := SetOperationSerializer(s, OperationSerializerFunc(func(ctx context.Context, input interface{}, request interface{}) (interface{}, error) {
+		return fmt.Sprintf("request:%v", input), nil
+	}))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	err = SetOperationDeserializer(s, OperationDeserializerFunc(func(ctx context.Context, response interface{}, metadata *Metadata) (interface{}, error) {
+		metadata.Set("deserialized", true)
+		return fmt.Sprintf("result:%v", response), nil
+	}))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if diff := cmp.Diff([]string{SlotOperationSerializer, "last"}, s.Serialize.List()); len(diff) != 0 {
+		t.Errorf("expect and actual serialize list differ\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"beforeDeserializer", SlotOperationDeserializer}, s.Deserialize.List()); len(diff) != 0 {
+		t.Errorf("expect and actual deserialize list differ\n%s", diff)
+	}
+
+	handler := HandlerFunc(func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+		return fmt.Sprintf("response:%v", input), Metadata{}, nil
+	})
+	result, metadata, err := DecorateHandler(handler, s).Handle(context.Background(), "input")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "result:response:request:input", result; e != a {
+		t.Errorf("expect %v result, got %v", e, a)
+	}
+	if !metadata.Has("deserialized") {
+		t.Errorf("expect deserializer metadata")
+	}
+}
+
+func TestOperationSerializerError(t *testing.T) {
+	cases := map[string]struct {
+		Err error
+	}{
+		"unwrapped": {
+			Err: fmt.Errorf("invalid input"),
+		},
+		"serialization error": {
+			Err: &smithy.SerializationError{Err: fmt.Errorf("invalid input")},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := NewStack("fooStack", func() interface{} { return "" })
+			err := SetOperationSerializer(s, OperationSerializerFunc(func(context.Context, interface{}, interface{}) (interface{}, error) {
+				return nil, c.Err
+			}))
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			_, _, err = DecorateHandler(HandlerFunc(func(context.Context, interface{}) (interface{}, Metadata, error) {
+				t.Fatalf("expect handler not called")
+				return nil, Metadata{}, nil
+			}), s).Handle(context.Background(), "input")
+
+			var serErr *smithy.SerializationError
+			if !errors.As(err, &serErr) {
+				t.Fatalf("expect *smithy.SerializationError, got %T, %v", err, err)
+			}
+			if errors.As(serErr.Err, new(*smithy.SerializationError)) {
+				t.Errorf("expect serialization error not wrapped twice, got %v", err)
+			}
+		})
+	}
+}
+
+func TestStackInvokerOperationCodec(t *testing.T) {
+	generated := SerializeMiddlewareFunc(SlotOperationSerializer, func(ctx context.Context, in SerializeInput, next SerializeHandler) (
+		SerializeOutput, Metadata, error,
+	) {
+		t.Errorf("expect generated serializer to be replaced")
+		return next.HandleSerialize(ctx, in)
+	})
+	generatedDeserializer := DeserializeMiddlewareFunc(SlotOperationDeserializer, func(ctx context.Context, in DeserializeInput, next DeserializeHandler) (
+		DeserializeOutput, Metadata, error,
+	) {
+		t.Errorf("expect generated deserializer to be replaced")
+		return next.HandleDeserialize(ctx, in)
+	})
+
+	invoker := NewStackInvoker[string, string](
+		func() *Stack { return NewStack("Operation", func() interface{} { return nil }) },
+		generated, generatedDeserializer,
+		HandlerFunc(func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+			return fmt.Sprintf("response:%v", input), Metadata{}, nil
+		}),
+		func(o *StackInvokerOptions) {
+			o.Serializer = OperationSerializerFunc(func(ctx context.Context, input interface{}, request interface{}) (interface{}, error) {
+				return fmt.Sprintf("request:%v", input), nil
+			})
+			o.Deserializer = OperationDeserializerFunc(func(ctx context.Context, response interface{}, metadata *Metadata) (interface{}, error) {
+				return fmt.Sprintf("result:%v", response), nil
+			})
+		},
+	)
+
+	out, err := invoker.Invoke(context.Background(), "input")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "result:response:request:input", out; e != a {
+		t.Errorf("expect %v output, got %v", e, a)
+	}
+}
@@ -1,6 +1,10 @@
 package middleware
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/aws/smithy-go/logging"
+)
 
 // RelativePosition provides specifying the relative position of a middleware
 // in an ordered group.
@@ -21,6 +25,9 @@ type ider interface {
 type orderedIDs struct {
 	order *relativeOrder
 	items map[string]ider
+
+	deprecations deprecations
+	logger       logging.Logger
 }
 
 const baseOrderedItems = 5
@@ -33,12 +40,17 @@ func newOrderedIDs() *orderedIDs {
 }
 
 // Add injects the item to the relative position of the item group. Returns an
-// error if the item already exists.
+// error if the item already exists. An unfilled slot with the item's ID is
+// filled by the item in place, ignoring the relative position.
 func (g *orderedIDs) Add(m ider, pos RelativePosition) error {
 	id := m.ID()
 	if len(id) == 0 {
 		return fmt.Errorf("empty ID, ID must not be empty")
 	}
+	if g.isSlot(id) {
+		g.items[id] = m
+		return nil
+	}
 
 	if err := g.order.Add(pos, id); err != nil {
 		return err
@@ -50,6 +62,8 @@ func (g *orderedIDs) Add(m ider, pos RelativePosition) error {
 
 // Insert injects the item relative to an existing item id.  Return error if
 // the original item does not exist, or the item being added already exists.
+// An unfilled slot with the item's ID is filled by the item in place,
+// ignoring the relative position.
 func (g *orderedIDs) Insert(m ider, relativeTo string, pos RelativePosition) error {
 	if len(m.ID()) == 0 {
 		return fmt.Errorf("insert ID must not be empty")
@@ -57,6 +71,10 @@ func (g *orderedIDs) Insert(m ider, relativeTo string, pos RelativePosition) err
 	if len(relativeTo) == 0 {
 		return fmt.Errorf("relative to ID must not be empty")
 	}
+	if g.isSlot(m.ID()) {
+		g.items[m.ID()] = m
+		return nil
+	}
 
 	if err := g.order.Insert(relativeTo, pos, m.ID()); err != nil {
 		return err
@@ -66,12 +84,30 @@ func (g *orderedIDs) Insert(m ider, relativeTo string, pos RelativePosition) err
 	return nil
 }
 
-// Get returns the ider identified by id. If ider is not present, returns false
+// Get returns the ider identified by id. If ider is not present, or is an
+// unfilled slot, returns false
 func (g *orderedIDs) Get(id string) (ider, bool) {
 	v, ok := g.items[id]
+	if _, isSlot := v.(*slot); isSlot {
+		return nil, false
+	}
 	return v, ok
 }
 
+// AddSlot adds an unfilled slot with the id to the relative position of the
+// item group, if no item or slot with the id exists.
+func (g *orderedIDs) AddSlot(id string, pos RelativePosition) error {
+	if _, ok := g.items[id]; ok {
+		return nil
+	}
+	return g.Add(&slot{id: id}, pos)
+}
+
+func (g *orderedIDs) isSlot(id string) bool {
+	_, ok := g.items[id].(*slot)
+	return ok
+}
+
 // Swap removes the item by id, replacing it with the new item. Returns error
 // if the original item doesn't exist.
 func (g *orderedIDs) Swap(id string, m ider) (ider, error) {
@@ -97,11 +133,14 @@ func (g *orderedIDs) Swap(id string, m ider) (ider, error) {
 }
 
 // Remove removes the item by id. Returns error if the item
-// doesn't exist.
+// doesn't exist, or is an unfilled slot.
 func (g *orderedIDs) Remove(id string) (ider, error) {
 	if len(id) == 0 {
 		return nil, fmt.Errorf("remove ID must not be empty")
 	}
+	if g.isSlot(id) {
+		return nil, fmt.Errorf("not found, %v", id)
+	}
 
 	if err := g.order.Remove(id); err != nil {
 		return nil, err
@@ -112,10 +151,15 @@ func (g *orderedIDs) Remove(id string) (ider, error) {
 	return removed, nil
 }
 
+// List returns the IDs of the items in order, excluding unfilled slots.
 func (g *orderedIDs) List() []string {
 	items := g.order.List()
-	order := make([]string, len(items))
-	copy(order, items)
+	order := make([]string, 0, len(items))
+	for _, id := range items {
+		if !g.isSlot(id) {
+			order = append(order, id)
+		}
+	}
 	return order
 }
 
@@ -125,12 +169,16 @@ func (g *orderedIDs) Clear() {
 	g.items = map[string]ider{}
 }
 
-// GetOrder returns the item in the order it should be invoked in.
+// GetOrder returns the item in the order it should be invoked in. Unfilled
+// slots are not invoked.
 func (g *orderedIDs) GetOrder() []interface{} {
 	order := g.order.List()
-	ordered := make([]interface{}, len(order))
+	ordered := make([]interface{}, 0, len(order))
 	for i := 0; i < len(order); i++ {
-		ordered[i] = g.items[order[i]]
+		if g.isSlot(order[i]) {
+			continue
+		}
+		ordered = append(ordered, g.items[order[i]])
 	}
 
 	return ordered
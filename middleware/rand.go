@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/aws/smithy-go/rand"
+)
+
+type setEntropySource struct {
+	Source rand.EntropySource
+}
+
+// AddSetEntropySourceMiddleware adds a middleware that will set the provided
+// random source on the context, to be used by the operation's middleware for
+// values such as retry jitter and idempotency tokens. Clients use this to
+// apply a random source configured as a client option.
+func AddSetEntropySourceMiddleware(stack *Stack, src rand.EntropySource) error {
+	return stack.Initialize.Add(&setEntropySource{Source: src}, Before)
+}
+
+func (*setEntropySource) ID() string {
+	return "SetEntropySource"
+}
+
+func (m *setEntropySource) HandleInitialize(ctx context.Context, in InitializeInput, next InitializeHandler) (
+	out InitializeOutput, metadata Metadata, err error,
+) {
+	return next.HandleInitialize(rand.WithEntropySource(ctx, m.Source), in)
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import "github.com/aws/smithy-go"
+
+// GetRequestIDMetadata retrieves the request ID from the metadata, returning
+// the request ID and whether it was present.
+//
+// Equivalent to smithy.GetMetadataRequestID.
+func GetRequestIDMetadata(metadata MetadataReader) (string, bool) {
+	return smithy.GetMetadataRequestID(metadata)
+}
+
+// SetRequestIDMetadata sets the provided request ID on the metadata.
+//
+// Equivalent to smithy.SetMetadataRequestID.
+func SetRequestIDMetadata(metadata *Metadata, id string) {
+	smithy.SetMetadataRequestID(metadata, id)
+}
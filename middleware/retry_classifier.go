@@ -0,0 +1,262 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/aws/smithy-go"
+)
+
+// RetryClassification is the decision of whether the request attempt that
+// failed with an error may be retried.
+type RetryClassification struct {
+	// Retryable is whether the attempt may be retried.
+	Retryable bool
+
+	// Throttle is whether the attempt failed because it was throttled.
+	Throttle bool
+
+	// Reason describes why the error was classified, for observability.
+	Reason string
+
+	// Classifier is the ID of the classifier which decided the
+	// classification. Empty if no classifier decided it.
+	Classifier string
+}
+
+// RetryClassifier classifies the errors of request attempts. Returns false if
+// the classifier does not decide the classification of the error, deferring
+// to the classifiers that follow it.
+type RetryClassifier interface {
+	ID() string
+	ClassifyRetry(err error) (RetryClassification, bool)
+}
+
+type retryClassifierFunc struct {
+	id string
+	fn func(error) (RetryClassification, bool)
+}
+
+func (c retryClassifierFunc) ID() string { return c.id }
+
+func (c retryClassifierFunc) ClassifyRetry(err error) (RetryClassification, bool) {
+	return c.fn(err)
+}
+
+// RetryClassifierFunc returns a RetryClassifier with the id, which classifies
+// errors with the function.
+func RetryClassifierFunc(id string, fn func(error) (RetryClassification, bool)) RetryClassifier {
+	return retryClassifierFunc{id: id, fn: fn}
+}
+
+// RetryPredicateClassifier returns a RetryClassifier with the id, which
+// classifies the errors matched by the predicate as the classification.
+func RetryPredicateClassifier(id string, c RetryClassification, predicate func(error) bool) RetryClassifier {
+	return RetryClassifierFunc(id, func(err error) (RetryClassification, bool) {
+		if !predicate(err) {
+			return RetryClassification{}, false
+		}
+		return c, true
+	})
+}
+
+// RetryErrorTypeClassifier returns a RetryClassifier with the id, which
+// classifies the errors with an error of type T in their chain as the
+// classification.
+func RetryErrorTypeClassifier[T error](id string, c RetryClassification) RetryClassifier {
+	return RetryPredicateClassifier(id, c, func(err error) bool {
+		var v T
+		return errors.As(err, &v)
+	})
+}
+
+// RetryErrorCodeClassifier returns a RetryClassifier with the id, which
+// classifies the errors with one of the error codes, see smithy.GetErrorCode,
+// as the classification.
+func RetryErrorCodeClassifier(id string, c RetryClassification, codes ...string) RetryClassifier {
+	return RetryPredicateClassifier(id, c, func(err error) bool {
+		code, ok := smithy.GetErrorCode(err)
+		if !ok {
+			return false
+		}
+		for _, v := range codes {
+			if code == v {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// RetryStatusCodeClassifier returns a RetryClassifier with the id, which
+// classifies the errors of responses with one of the status codes as the
+// classification. The status code is read from the smithy.ErrorMetadata of
+// the error, or from an error in its chain with an HTTPStatusCode method.
+func RetryStatusCodeClassifier(id string, c RetryClassification, statusCodes ...int) RetryClassifier {
+	return RetryPredicateClassifier(id, c, func(err error) bool {
+		status := errorStatusCode(err)
+		if status == 0 {
+			return false
+		}
+		for _, v := range statusCodes {
+			if status == v {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func errorStatusCode(err error) int {
+	if md, ok := smithy.GetErrorMetadata(err); ok && md.StatusCode != 0 {
+		return md.StatusCode
+	}
+	var v interface{ HTTPStatusCode() int }
+	if errors.As(err, &v) {
+		return v.HTTPStatusCode()
+	}
+	return 0
+}
+
+// RetryClassifiers is an ordered registry of RetryClassifiers, identified by
+// their IDs. Errors are classified by the first classifier, in order, which
+// decides their classification.
+//
+// RetryClassifiers is safe for concurrent use.
+type RetryClassifiers struct {
+	mu          sync.RWMutex
+	classifiers *orderedIDs
+}
+
+// NewRetryClassifiers returns a registry of the classifiers, in order.
+func NewRetryClassifiers(classifiers ...RetryClassifier) *RetryClassifiers {
+	r := &RetryClassifiers{classifiers: newOrderedIDs()}
+	for _, c := range classifiers {
+		r.classifiers.Add(c, After)
+	}
+	return r
+}
+
+// Add adds the classifier to the start, or end, of the registry. Returns an
+// error if a classifier with the same ID already exists.
+func (r *RetryClassifiers) Add(c RetryClassifier, pos RelativePosition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.classifiers.Add(c, pos)
+}
+
+// Insert adds the classifier before, or after, the classifier identified by
+// relativeTo. Returns an error if the relativeTo classifier does not exist,
+// or a classifier with the same ID already exists.
+func (r *RetryClassifiers) Insert(c RetryClassifier, relativeTo string, pos RelativePosition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.classifiers.Insert(c, relativeTo, pos)
+}
+
+// Remove removes the classifier identified by id. Returns an error if the
+// classifier does not exist.
+func (r *RetryClassifiers) Remove(id string) (RetryClassifier, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	removed, err := r.classifiers.Remove(id)
+	if err != nil {
+		return nil, err
+	}
+	return removed.(RetryClassifier), nil
+}
+
+// List returns the IDs of the classifiers in order.
+func (r *RetryClassifiers) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.classifiers.List()
+}
+
+// Classify returns the classification of the error by the first classifier
+// which decides it. If no classifier decides the classification, the error
+// is classified by its own hints, see smithy.IsErrorRetryable, and
+// smithy.IsErrorThrottle, and is not retryable if it provides none. A nil
+// error is not retryable.
+func (r *RetryClassifiers) Classify(err error) RetryClassification {
+	if err == nil {
+		return RetryClassification{Reason: "no error"}
+	}
+
+	r.mu.RLock()
+	ordered := r.classifiers.GetOrder()
+	r.mu.RUnlock()
+
+	for _, v := range ordered {
+		c := v.(RetryClassifier)
+		if rc, ok := c.ClassifyRetry(err); ok {
+			rc.Classifier = c.ID()
+			return rc
+		}
+	}
+
+	throttle := smithy.IsErrorThrottle(err)
+	if retryable, ok := smithy.IsErrorRetryable(err); ok {
+		return RetryClassification{
+			Retryable: retryable,
+			Throttle:  throttle,
+			Reason:    "error retryable hint",
+		}
+	}
+	if throttle {
+		return RetryClassification{
+			Retryable: true,
+			Throttle:  true,
+			Reason:    "error throttle hint",
+		}
+	}
+	return RetryClassification{Reason: "unclassified error"}
+}
+
+type retryClassificationKey struct{}
+
+// GetRetryClassification returns the classification of the attempt's error
+// from the attempt's result metadata, and whether it was set.
+func GetRetryClassification(metadata MetadataReader) (RetryClassification, bool) {
+	v, ok := metadata.Get(retryClassificationKey{}).(RetryClassification)
+	return v, ok
+}
+
+// SetRetryClassification sets the classification of the attempt's error on
+// the attempt's result metadata.
+func SetRetryClassification(metadata *Metadata, c RetryClassification) {
+	metadata.Set(retryClassificationKey{}, c)
+}
+
+// AddRetryClassificationMiddleware adds the Finalize step middleware which
+// classifies the error of each request attempt with the classifiers. The
+// classification is set on the attempt's result metadata, retrieved with
+// GetRetryClassification, for retry middleware to decide whether to retry
+// the attempt, and for observability of the decision.
+//
+// The middleware is added to the end of the Finalize step, so it is invoked
+// for each attempt of retry middleware which precede it.
+func AddRetryClassificationMiddleware(stack *Stack, classifiers *RetryClassifiers) error {
+	if classifiers == nil {
+		return fmt.Errorf("retry classifiers must not be nil")
+	}
+	return stack.Finalize.Add(&retryClassification{classifiers: classifiers}, After)
+}
+
+type retryClassification struct {
+	classifiers *RetryClassifiers
+}
+
+func (*retryClassification) ID() string { return "RetryClassification" }
+
+func (m *retryClassification) HandleFinalize(ctx context.Context, in FinalizeInput, next FinalizeHandler) (
+	out FinalizeOutput, metadata Metadata, err error,
+) {
+	out, metadata, err = next.HandleFinalize(ctx, in)
+	if err != nil {
+		SetRetryClassification(&metadata, m.classifiers.Classify(err))
+	}
+	return out, metadata, err
+}
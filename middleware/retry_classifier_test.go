@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+type mockRetryableError struct{ retryable bool }
+
+func (e *mockRetryableError) Error() string        { return "retryable error" }
+func (e *mockRetryableError) RetryableError() bool { return e.retryable }
+
+type mockStatusError struct{ status int }
+
+func (e *mockStatusError) Error() string       { return "status error" }
+func (e *mockStatusError) HTTPStatusCode() int { return e.status }
+
+func TestRetryClassifiers_Classify(t *testing.T) {
+	classifiers := NewRetryClassifiers(
+		RetryErrorCodeClassifier("ThrottleCodes",
+			RetryClassification{Retryable: true, Throttle: true, Reason: "throttle code"},
+			"ThrottlingException", "SlowDown"),
+		RetryStatusCodeClassifier("ServerStatus",
+			RetryClassification{Retryable: true, Reason: "server error"},
+			500, 503),
+		RetryErrorTypeClassifier[*mockStatusError]("StatusErrorType",
+			RetryClassification{Reason: "status error type"}),
+		RetryPredicateClassifier("Predicate",
+			RetryClassification{Retryable: true, Reason: "predicate"},
+			func(err error) bool { return err.Error() == "transient" }),
+	)
+
+	cases := map[string]struct {
+		Err    error
+		Expect RetryClassification
+	}{
+		"nil error": {
+			Expect: RetryClassification{Reason: "no error"},
+		},
+		"error code": {
+			Err: fmt.Errorf("wrapped, %w", &smithy.GenericAPIError{Code: "SlowDown"}),
+			Expect: RetryClassification{
+				Retryable: true, Throttle: true, Reason: "throttle code", Classifier: "ThrottleCodes",
+			},
+		},
+		"metadata status code": {
+			Err: &smithy.GenericAPIError{Code: "InternalError", Metadata: smithy.ErrorMetadata{StatusCode: 503}},
+			Expect: RetryClassification{
+				Retryable: true, Reason: "server error", Classifier: "ServerStatus",
+			},
+		},
+		"http status code": {
+			Err: &mockStatusError{status: 500},
+			Expect: RetryClassification{
+				Retryable: true, Reason: "server error", Classifier: "ServerStatus",
+			},
+		},
+		"error type": {
+			Err:    &mockStatusError{status: 400},
+			Expect: RetryClassification{Reason: "status error type", Classifier: "StatusErrorType"},
+		},
+		"predicate": {
+			Err:    fmt.Errorf("transient"),
+			Expect: RetryClassification{Retryable: true, Reason: "predicate", Classifier: "Predicate"},
+		},
+		"error hint": {
+			Err:    &mockRetryableError{retryable: true},
+			Expect: RetryClassification{Retryable: true, Reason: "error retryable hint"},
+		},
+		"unclassified": {
+			Err:    fmt.Errorf("unknown"),
+			Expect: RetryClassification{Reason: "unclassified error"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := c.Expect, classifiers.Classify(c.Err); e != a {
+				t.Errorf("expect %+v, got %+v", e, a)
+			}
+		})
+	}
+}
+
+func TestRetryClassifiers_Order(t *testing.T) {
+	classifiers := NewRetryClassifiers(
+		RetryStatusCodeClassifier("ServerStatus", RetryClassification{Retryable: true}, 500),
+	)
+
+	notRetryable := RetryErrorTypeClassifier[*mockStatusError]("Override", RetryClassification{Reason: "override"})
+	if err := classifiers.Insert(notRetryable, "ServerStatus", Before); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := classifiers.Add(RetryPredicateClassifier("Last", RetryClassification{},
+		func(error) bool { return false }), After); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := classifiers.Add(notRetryable, Before); err == nil {
+		t.Errorf("expect error adding duplicate classifier")
+	}
+
+	if e, a := []string{"Override", "ServerStatus", "Last"}, classifiers.List(); !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v order, got %v", e, a)
+	}
+
+	if e, a := "Override", classifiers.Classify(&mockStatusError{status: 500}).Classifier; e != a {
+		t.Errorf("expect %v classifier, got %v", e, a)
+	}
+
+	if _, err := classifiers.Remove("Override"); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "ServerStatus", classifiers.Classify(&mockStatusError{status: 500}).Classifier; e != a {
+		t.Errorf("expect %v classifier, got %v", e, a)
+	}
+}
+
+func TestRetryClassificationMiddleware(t *testing.T) {
+	stack := NewStack("test", func() interface{} { return struct{}{} })
+	err := AddRetryClassificationMiddleware(stack, NewRetryClassifiers(
+		RetryErrorCodeClassifier("Throttle",
+			RetryClassification{Retryable: true, Throttle: true, Reason: "throttled"},
+			"ThrottlingException"),
+	))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	handler := DecorateHandler(HandlerFunc(func(ctx context.Context, input interface{}) (
+		interface{}, Metadata, error,
+	) {
+		return nil, Metadata{}, &smithy.GenericAPIError{Code: "ThrottlingException"}
+	}), stack)
+
+	_, metadata, err := handler.Handle(context.Background(), struct{}{})
+	if err == nil {
+		t.Fatalf("expect error")
+	}
+	c, ok := GetRetryClassification(metadata)
+	if !ok {
+		t.Fatalf("expect retry classification")
+	}
+	if e, a := (RetryClassification{
+		Retryable: true, Throttle: true, Reason: "throttled", Classifier: "Throttle",
+	}), c; e != a {
+		t.Errorf("expect %+v, got %+v", e, a)
+	}
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+)
+
+// Well-known slot IDs of the stack. Slots are placeholders registered on
+// every stack by NewStack at canonical positions, so that middleware can be
+// positioned relative to an extension point with Insert, without depending
+// on the IDs of the middleware a client version adds to the stack.
+//
+// A slot is filled when middleware with the slot's ID is added to the step,
+// replacing the placeholder at the slot's position, regardless of the
+// position it is added with. Unfilled slots are not invoked, are not
+// returned by Get or Remove, and are not included in the step's List.
+const (
+	// SlotOperationSerializer is the Serialize step slot for serializing the
+	// operation's input, see OperationSerializer.
+	SlotOperationSerializer = "OperationSerializer"
+
+	// SlotRequestChecksum is the Build step slot for computing the request
+	// payload's checksum.
+	SlotRequestChecksum = "RequestChecksum"
+
+	// SlotRetry is the Finalize step slot for retrying the operation's
+	// request attempts.
+	SlotRetry = "Retry"
+
+	// SlotResolveEndpoint is the Finalize step slot for resolving the
+	// endpoint of a request attempt, after Retry.
+	SlotResolveEndpoint = "ResolveEndpoint"
+
+	// SlotSigning is the Finalize step slot for signing a request attempt,
+	// after ResolveEndpoint.
+	SlotSigning = "Signing"
+
+	// SlotOperationDeserializer is the Deserialize step slot for
+	// deserializing the operation's response, see OperationDeserializer.
+	SlotOperationDeserializer = "OperationDeserializer"
+
+	// SlotResponseValidation is the Deserialize step slot for validating the
+	// response, such as its checksum, before it is deserialized, after
+	// OperationDeserializer.
+	SlotResponseValidation = "ResponseValidation"
+)
+
+// RegisterSlots registers the well-known slots on the stack, if not already
+// present. Slots are registered at the end of their step. Calling
+// RegisterSlots more than once has no effect.
+func RegisterSlots(stack *Stack) error {
+	if err := stack.Serialize.ids.AddSlot(SlotOperationSerializer, After); err != nil {
+		return err
+	}
+	if err := stack.Build.ids.AddSlot(SlotRequestChecksum, After); err != nil {
+		return err
+	}
+	for _, id := range []string{SlotRetry, SlotResolveEndpoint, SlotSigning} {
+		if err := stack.Finalize.ids.AddSlot(id, After); err != nil {
+			return err
+		}
+	}
+	for _, id := range []string{SlotOperationDeserializer, SlotResponseValidation} {
+		if err := stack.Deserialize.ids.AddSlot(id, After); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// slot is the placeholder of an unfilled slot. Satisfies each step's
+// middleware interface so that it can be swapped out or removed, but is
+// never invoked.
+type slot struct {
+	id string
+}
+
+func (s *slot) ID() string { return s.id }
+
+func (s *slot) HandleInitialize(ctx context.Context, in InitializeInput, next InitializeHandler) (
+	InitializeOutput, Metadata, error,
+) {
+	return next.HandleInitialize(ctx, in)
+}
+
+func (s *slot) HandleSerialize(ctx context.Context, in SerializeInput, next SerializeHandler) (
+	SerializeOutput, Metadata, error,
+) {
+	return next.HandleSerialize(ctx, in)
+}
+
+func (s *slot) HandleBuild(ctx context.Context, in BuildInput, next BuildHandler) (
+	BuildOutput, Metadata, error,
+) {
+	return next.HandleBuild(ctx, in)
+}
+
+func (s *slot) HandleFinalize(ctx context.Context, in FinalizeInput, next FinalizeHandler) (
+	FinalizeOutput, Metadata, error,
+) {
+	return next.HandleFinalize(ctx, in)
+}
+
+func (s *slot) HandleDeserialize(ctx context.Context, in DeserializeInput, next DeserializeHandler) (
+	DeserializeOutput, Metadata, error,
+) {
+	return next.HandleDeserialize(ctx, in)
+}
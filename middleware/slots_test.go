@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStackSlots(t *testing.T) {
+	s := NewStack("fooStack", func() interface{} { return struct{}{} })
+
+	if e, a := 0, len(s.Finalize.List()); e != a {
+		t.Errorf("expect unfilled slots not listed, got %v", s.Finalize.List())
+	}
+	if _, ok := s.Finalize.Get(SlotSigning); ok {
+		t.Errorf("expect unfilled slot not returned")
+	}
+
+	// Registering slots again has no effect.
+	if err := RegisterSlots(s); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var order []string
+	record := func(id string) FinalizeMiddleware {
+		return FinalizeMiddlewareFunc(id, func(ctx context.Context, in FinalizeInput, next FinalizeHandler) (
+			FinalizeOutput, Metadata, error,
+		) {
+			order = append(order, id)
+			return next.HandleFinalize(ctx, in)
+		})
+	}
+
+	// Third party middleware positioned relative to slots.
+	if err := s.Finalize.Insert(record("beforeSigning"), SlotSigning, Before); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := s.Finalize.Insert(record("afterRetry"), SlotRetry, After); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	// Fill the signing and retry slots, in place.
+	if err := s.Finalize.Insert(record(SlotSigning), SlotSigning, After); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := s.Finalize.Add(record(SlotRetry), Before); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := s.Finalize.Add(record("last"), After); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := []string{SlotRetry, "afterRetry", "beforeSigning", SlotSigning, "last"}
+	if diff := cmp.Diff(expect, s.Finalize.List()); len(diff) != 0 {
+		t.Errorf("expect and actual list differ\n%s", diff)
+	}
+
+	if _, ok := s.Finalize.Get(SlotSigning); !ok {
+		t.Errorf("expect filled slot to be returned")
+	}
+	if err := s.Finalize.Add(record(SlotSigning), After); err == nil {
+		t.Errorf("expect error adding duplicate of filled slot")
+	}
+
+	handler := DecorateHandler(HandlerFunc(func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+		return nil, Metadata{}, nil
+	}), s)
+	if _, _, err := handler.Handle(context.Background(), struct{}{}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if diff := cmp.Diff(expect, order); len(diff) != 0 {
+		t.Errorf("expect and actual invoke order differ\n%s", diff)
+	}
+}
+
+func TestStackSlots_Swap(t *testing.T) {
+	s := NewStack("fooStack", func() interface{} { return struct{}{} })
+
+	removed, err := s.Build.Swap(SlotRequestChecksum, mockBuildMiddleware(SlotRequestChecksum))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := SlotRequestChecksum, removed.ID(); e != a {
+		t.Errorf("expect %v removed, got %v", e, a)
+	}
+	if diff := cmp.Diff([]string{SlotRequestChecksum}, s.Build.List()); len(diff) != 0 {
+		t.Errorf("expect and actual list differ\n%s", diff)
+	}
+
+	if _, err := s.Deserialize.Remove(SlotResponseValidation); err == nil {
+		t.Errorf("expect error removing unfilled slot")
+	}
+	if err := s.Deserialize.Add(mockDeserializeMiddleware(SlotResponseValidation), After); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if _, err := s.Deserialize.Remove(SlotResponseValidation); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := s.Deserialize.Insert(mockDeserializeMiddleware("foo"), SlotResponseValidation, After); err == nil {
+		t.Errorf("expect error inserting relative to removed slot")
+	}
+}
+
+func TestStackSlots_FillInPlace(t *testing.T) {
+	s := NewStack("fooStack", func() interface{} { return struct{}{} })
+
+	if err := s.Build.Add(mockBuildMiddleware("first"), Before); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := s.Build.Add(mockBuildMiddleware("last"), After); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if err := s.Build.Add(mockBuildMiddleware(SlotRequestChecksum), Before); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	expect := []string{"first", SlotRequestChecksum, "last"}
+	if diff := cmp.Diff(expect, s.Build.List()); len(diff) != 0 {
+		t.Errorf("expect and actual list differ\n%s", diff)
+	}
+
+	if err := s.Finalize.Add(mockFinalizeMiddleware("first"), Before); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := s.Finalize.Insert(mockFinalizeMiddleware(SlotSigning), "first", Before); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	expect = []string{"first", SlotSigning}
+	if diff := cmp.Diff(expect, s.Finalize.List()); len(diff) != 0 {
+		t.Errorf("expect and actual list differ\n%s", diff)
+	}
+}
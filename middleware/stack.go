@@ -70,11 +70,19 @@ type Stack struct {
 	Deserialize *DeserializeStep
 
 	id string
+
+	// stats records the statistics of the stack's middleware, if enabled.
+	stats *StackStats
+
+	// middlewareErrors is whether errors are wrapped in a MiddlewareError,
+	// see EnableMiddlewareErrors.
+	middlewareErrors bool
 }
 
-// NewStack returns an initialize empty stack.
+// NewStack returns an initialize empty stack, with the well-known slots
+// registered. See RegisterSlots.
 func NewStack(id string, newRequestFn func() interface{}) *Stack {
-	return &Stack{
+	s := &Stack{
 		id:          id,
 		Initialize:  NewInitializeStep(),
 		Serialize:   NewSerializeStep(newRequestFn),
@@ -82,6 +90,8 @@ func NewStack(id string, newRequestFn func() interface{}) *Stack {
 		Finalize:    NewFinalizeStep(),
 		Deserialize: NewDeserializeStep(),
 	}
+	RegisterSlots(s)
+	return s
 }
 
 // ID returns the unique ID for the stack as a middleware.
@@ -98,6 +108,23 @@ func (s *Stack) ID() string { return s.id }
 func (s *Stack) HandleMiddleware(ctx context.Context, input interface{}, next Handler) (
 	output interface{}, metadata Metadata, err error,
 ) {
+	if s.stats != nil {
+		ctx = context.WithValue(ctx, stackStatsKey{}, s.stats)
+		next = statsHandler{Next: next, stats: s.stats}
+	} else if getStackStats(ctx) != nil {
+		// nested stacks record only if enabled on the stack itself.
+		ctx = context.WithValue(ctx, stackStatsKey{}, (*StackStats)(nil))
+	}
+
+	if s.middlewareErrors {
+		ctx = context.WithValue(ctx, middlewareErrorsKey{}, true)
+		ctx = context.WithValue(ctx, middlewareTrailKey{}, []string(nil))
+		next = middlewareErrorHandler{Next: next}
+	} else if middlewareErrorsEnabled(ctx) {
+		// nested stacks record only if enabled on the stack itself.
+		ctx = context.WithValue(ctx, middlewareErrorsKey{}, false)
+	}
+
 	h := DecorateHandler(next,
 		s.Initialize,
 		s.Serialize,
@@ -109,6 +136,38 @@ func (s *Stack) HandleMiddleware(ctx context.Context, input interface{}, next Ha
 	return h.Handle(ctx, input)
 }
 
+// EnableStats enables recording the time spent in, and the number of calls
+// of, each middleware of the stack into stats, for identifying expensive
+// middleware without an external profiler. If stats is nil, a new
+// StackStats is used. Recording adds overhead to every middleware call, and
+// is disabled by default.
+func (s *Stack) EnableStats(stats *StackStats) {
+	if stats == nil {
+		stats = NewStackStats()
+	}
+	s.stats = stats
+}
+
+// EnableMiddlewareErrors enables wrapping the errors returned by the stack's
+// middleware, and the handler it decorates, in a *MiddlewareError recording
+// the step, middleware, and attempt the error was returned by, and the trail
+// of middleware invoked before it. The error returned by the stack is then a
+// *MiddlewareError, wrapping the original error, so callers must use
+// errors.As, rather than type assertions, to inspect the error. Disabled by
+// default.
+func (s *Stack) EnableMiddlewareErrors() {
+	s.middlewareErrors = true
+}
+
+// Stats returns a snapshot of the statistics recorded for the stack's
+// middleware. Returns nil if statistics are not enabled, see EnableStats.
+func (s *Stack) Stats() []MiddlewareStats {
+	if s.stats == nil {
+		return nil
+	}
+	return s.stats.Snapshot()
+}
+
 // List returns a list of all middleware in the stack by step.
 func (s *Stack) List() []string {
 	var l []string
@@ -132,6 +191,29 @@ func (s *Stack) List() []string {
 	return l
 }
 
+// GetAnyStep retrieves the middleware identified by id from the first step
+// of the stack it is present in, in step order. Returns the middleware, and
+// the name of the step it was found in, e.g. "Finalize". If the middleware is
+// not present in any step, returns false.
+func (s *Stack) GetAnyStep(id string) (m interface{}, step string, ok bool) {
+	if m, ok := s.Initialize.Get(id); ok {
+		return m, "Initialize", true
+	}
+	if m, ok := s.Serialize.Get(id); ok {
+		return m, "Serialize", true
+	}
+	if m, ok := s.Build.Get(id); ok {
+		return m, "Build", true
+	}
+	if m, ok := s.Finalize.Get(id); ok {
+		return m, "Finalize", true
+	}
+	if m, ok := s.Deserialize.Get(id); ok {
+		return m, "Deserialize", true
+	}
+	return nil, "", false
+}
+
 func (s *Stack) String() string {
 	var b strings.Builder
 
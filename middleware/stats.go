@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MiddlewareStats is the time spent in, and the number of calls of, a
+// middleware of a stack, recorded by StackStats.
+type MiddlewareStats struct {
+	// Step is the name of the step of the middleware, e.g. "Finalize", or
+	// "Handler" for the handler the stack decorates.
+	Step string
+
+	// ID is the ID of the middleware.
+	ID string
+
+	// Calls is the number of times the middleware was invoked.
+	Calls int64
+
+	// Duration is the cumulative time spent in the middleware, including the
+	// time spent in the next handlers it invoked.
+	Duration time.Duration
+
+	// SelfDuration is the cumulative time spent in the middleware, excluding
+	// the time spent in the next handlers it invoked. SelfDuration identifies
+	// the middleware that is itself expensive.
+	SelfDuration time.Duration
+}
+
+// StackStats records the time spent in, and the number of calls of, each
+// middleware of the stacks it is enabled on, see Stack.EnableStats. A
+// StackStats is safe for concurrent use, and may be shared by the stacks of
+// many operation calls to aggregate their statistics.
+type StackStats struct {
+	mu    sync.Mutex
+	stats []*MiddlewareStats
+	index map[statsKey]*MiddlewareStats
+}
+
+type statsKey struct {
+	step, id string
+}
+
+// NewStackStats returns an empty StackStats.
+func NewStackStats() *StackStats {
+	return &StackStats{
+		index: map[statsKey]*MiddlewareStats{},
+	}
+}
+
+// Snapshot returns a copy of the statistics recorded, in the order the
+// middleware were first invoked.
+func (s *StackStats) Snapshot() []MiddlewareStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := make([]MiddlewareStats, 0, len(s.stats))
+	for _, v := range s.stats {
+		snapshot = append(snapshot, *v)
+	}
+	return snapshot
+}
+
+// Reset discards the statistics recorded.
+func (s *StackStats) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats = nil
+	s.index = map[statsKey]*MiddlewareStats{}
+}
+
+// start records the start of a call of the middleware, returning the context
+// for the middleware's call, and the func to record its end.
+func (s *StackStats) start(ctx context.Context, step, id string) (context.Context, func()) {
+	key := statsKey{step: step, id: id}
+
+	s.mu.Lock()
+	stats, ok := s.index[key]
+	if !ok {
+		stats = &MiddlewareStats{Step: step, ID: id}
+		s.index[key] = stats
+		s.stats = append(s.stats, stats)
+	}
+	s.mu.Unlock()
+
+	// The time spent in the next handlers is accumulated by their calls into
+	// the counter of this call, to be excluded from its self duration.
+	parent, _ := ctx.Value(statsCallKey{}).(*int64)
+	next := new(int64)
+	ctx = context.WithValue(ctx, statsCallKey{}, next)
+
+	start := time.Now()
+	return ctx, func() {
+		d := time.Since(start)
+		if parent != nil {
+			atomic.AddInt64(parent, int64(d))
+		}
+
+		s.mu.Lock()
+		stats.Calls++
+		stats.Duration += d
+		stats.SelfDuration += d - time.Duration(atomic.LoadInt64(next))
+		s.mu.Unlock()
+	}
+}
+
+type stackStatsKey struct{}
+
+type statsCallKey struct{}
+
+// getStackStats returns the StackStats of the stack invoking the step, nil
+// if the stack has no statistics enabled.
+func getStackStats(ctx context.Context) *StackStats {
+	v, _ := ctx.Value(stackStatsKey{}).(*StackStats)
+	return v
+}
+
+// statsHandler records the statistics of the handler a stack decorates.
+type statsHandler struct {
+	Next  Handler
+	stats *StackStats
+}
+
+func (h statsHandler) Handle(ctx context.Context, input interface{}) (
+	output interface{}, metadata Metadata, err error,
+) {
+	ctx, done := h.stats.start(ctx, "Handler", "Handler")
+	defer done()
+	return h.Next.Handle(ctx, input)
+}
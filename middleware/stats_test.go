@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStackStats(t *testing.T) {
+	const delay = 20 * time.Millisecond
+
+	s := NewStack("fooStack", func() interface{} { return struct{}{} })
+	s.Initialize.Add(mockInitializeMiddleware("first"), After)
+	s.Serialize.Add(SerializeMiddlewareFunc("slow", func(
+		ctx context.Context, in SerializeInput, next SerializeHandler,
+	) (
+		out SerializeOutput, metadata Metadata, err error,
+	) {
+		time.Sleep(delay)
+		return next.HandleSerialize(ctx, in)
+	}), After)
+	s.Finalize.Add(FinalizeMiddlewareFunc("retry", func(
+		ctx context.Context, in FinalizeInput, next FinalizeHandler,
+	) (
+		out FinalizeOutput, metadata Metadata, err error,
+	) {
+		for i := 0; i < 2; i++ {
+			out, metadata, err = next.HandleFinalize(ctx, in)
+		}
+		return out, metadata, err
+	}), After)
+	s.Deserialize.Add(mockDeserializeMiddleware("deserialize"), After)
+
+	if s.Stats() != nil {
+		t.Errorf("expect no stats before enabled")
+	}
+	s.EnableStats(nil)
+
+	handler := HandlerFunc(func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+		time.Sleep(delay)
+		return nil, Metadata{}, nil
+	})
+	for i := 0; i < 2; i++ {
+		_, _, err := s.HandleMiddleware(context.Background(), struct{}{}, handler)
+		noError(t, err)
+	}
+
+	stats := s.Stats()
+
+	expect := []MiddlewareStats{
+		{Step: "Initialize", ID: "first", Calls: 2},
+		{Step: "Serialize", ID: "slow", Calls: 2},
+		{Step: "Finalize", ID: "retry", Calls: 2},
+		{Step: "Deserialize", ID: "deserialize", Calls: 4},
+		{Step: "Handler", ID: "Handler", Calls: 4},
+	}
+	if e, a := len(expect), len(stats); e != a {
+		t.Fatalf("expect %v stats, got %v, %v", e, a, stats)
+	}
+	for i, e := range expect {
+		a := stats[i]
+		if e.Step != a.Step || e.ID != a.ID || e.Calls != a.Calls {
+			t.Errorf("%d, expect %v %v %v calls, got %v %v %v calls",
+				i, e.Step, e.ID, e.Calls, a.Step, a.ID, a.Calls)
+		}
+		if a.SelfDuration > a.Duration {
+			t.Errorf("%d, expect self duration %v within duration %v", i, a.SelfDuration, a.Duration)
+		}
+	}
+
+	// first, and retry, only forward to the next handler, their time is
+	// spent in the slow middleware, and the handler.
+	if e, a := 6*delay, stats[0].Duration; a < e {
+		t.Errorf("expect duration at least %v, got %v", e, a)
+	}
+	if e, a := 2*delay, stats[0].SelfDuration; a >= e {
+		t.Errorf("expect self duration less than %v, got %v", e, a)
+	}
+	if e, a := 2*delay, stats[1].SelfDuration; a < e {
+		t.Errorf("expect self duration at least %v, got %v", e, a)
+	}
+	if e, a := 4*delay, stats[4].SelfDuration; a < e {
+		t.Errorf("expect self duration at least %v, got %v", e, a)
+	}
+}
+
+func TestStackStatsShared(t *testing.T) {
+	stats := NewStackStats()
+
+	for i := 0; i < 3; i++ {
+		s := NewStack("fooStack", func() interface{} { return struct{}{} })
+		s.Build.Add(mockBuildMiddleware("build"), After)
+		s.EnableStats(stats)
+
+		_, _, err := s.HandleMiddleware(context.Background(), struct{}{},
+			HandlerFunc(func(ctx context.Context, input interface{}) (interface{}, Metadata, error) {
+				// nested stacks without stats enabled are not recorded.
+				nested := NewStack("nested", func() interface{} { return struct{}{} })
+				nested.Build.Add(mockBuildMiddleware("nested"), After)
+				return nested.HandleMiddleware(ctx, input, HandlerFunc(
+					func(context.Context, interface{}) (interface{}, Metadata, error) {
+						return nil, Metadata{}, nil
+					}))
+			}))
+		noError(t, err)
+	}
+
+	snapshot := stats.Snapshot()
+	if e, a := 2, len(snapshot); e != a {
+		t.Fatalf("expect %v stats, got %v, %v", e, a, snapshot)
+	}
+	if e, a := int64(3), snapshot[0].Calls; e != a {
+		t.Errorf("expect %v calls, got %v", e, a)
+	}
+	if e, a := "build", snapshot[0].ID; e != a {
+		t.Errorf("expect %v middleware, got %v", e, a)
+	}
+
+	stats.Reset()
+	if e, a := 0, len(stats.Snapshot()); e != a {
+		t.Errorf("expect %v stats after reset, got %v", e, a)
+	}
+}
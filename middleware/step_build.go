@@ -98,12 +98,23 @@ func (s *BuildStep) HandleMiddleware(ctx context.Context, in interface{}, next H
 	out interface{}, metadata Metadata, err error,
 ) {
 	order := s.ids.GetOrder()
+	stats := getStackStats(ctx)
 
-	var h BuildHandler = buildWrapHandler{Next: next}
+	// the step's middleware are located for the errors they return only if
+	// the stack records middleware errors.
+	var errOrder []interface{}
+	if middlewareErrorsEnabled(ctx) {
+		errOrder = order
+	}
+
+	var h BuildHandler = buildWrapHandler{Next: next, order: errOrder}
 	for i := len(order) - 1; i >= 0; i-- {
 		h = decoratedBuildHandler{
-			Next: h,
-			With: order[i].(BuildMiddleware),
+			Next:  h,
+			With:  order[i].(BuildMiddleware),
+			order: errOrder,
+			index: i,
+			stats: stats,
 		}
 	}
 
@@ -117,7 +128,7 @@ func (s *BuildStep) HandleMiddleware(ctx context.Context, in interface{}, next H
 
 // Get retrieves the middleware identified by id. If the middleware is not present, returns false.
 func (s *BuildStep) Get(id string) (BuildMiddleware, bool) {
-	get, ok := s.ids.Get(id)
+	get, ok := s.ids.Get(s.ids.resolveDeprecated("Get", id))
 	if !ok {
 		return nil, false
 	}
@@ -141,7 +152,7 @@ func (s *BuildStep) Insert(m BuildMiddleware, relativeTo string, pos RelativePos
 // Returns the middleware removed, or error if the middleware to be removed
 // doesn't exist.
 func (s *BuildStep) Swap(id string, m BuildMiddleware) (BuildMiddleware, error) {
-	removed, err := s.ids.Swap(id, m)
+	removed, err := s.ids.Swap(s.ids.resolveDeprecated("Swap", id), m)
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +163,7 @@ func (s *BuildStep) Swap(id string, m BuildMiddleware) (BuildMiddleware, error)
 // Remove removes the middleware by id. Returns error if the middleware
 // doesn't exist.
 func (s *BuildStep) Remove(id string) (BuildMiddleware, error) {
-	removed, err := s.ids.Remove(id)
+	removed, err := s.ids.Remove(s.ids.resolveDeprecated("Remove", id))
 	if err != nil {
 		return nil, err
 	}
@@ -160,6 +171,14 @@ func (s *BuildStep) Remove(id string) (BuildMiddleware, error) {
 	return removed.(BuildMiddleware), nil
 }
 
+// Deprecate registers the middleware ID as deprecated in favor of its
+// replacement. Get, Swap, and Remove called with the deprecated ID log a
+// warning to the stack's logger, and are applied to the replacement if the
+// deprecated ID is not present in the step.
+func (s *BuildStep) Deprecate(d Deprecation) error {
+	return s.ids.Deprecate("Build", d)
+}
+
 // List returns a list of the middleware in the step.
 func (s *BuildStep) List() []string {
 	return s.ids.List()
@@ -172,6 +191,11 @@ func (s *BuildStep) Clear() {
 
 type buildWrapHandler struct {
 	Next Handler
+
+	// order is the step's middleware, for the trail of errors returned by
+	// the subsequent steps. Nil if the stack does not record middleware
+	// errors.
+	order []interface{}
 }
 
 var _ BuildHandler = (*buildWrapHandler)(nil)
@@ -181,6 +205,9 @@ var _ BuildHandler = (*buildWrapHandler)(nil)
 func (w buildWrapHandler) HandleBuild(ctx context.Context, in BuildInput) (
 	out BuildOutput, metadata Metadata, err error,
 ) {
+	if w.order != nil {
+		ctx = withMiddlewareTrail(ctx, "Build", w.order)
+	}
 	res, metadata, err := w.Next.Handle(ctx, in.Request)
 	return BuildOutput{
 		Result: res,
@@ -190,6 +217,15 @@ func (w buildWrapHandler) HandleBuild(ctx context.Context, in BuildInput) (
 type decoratedBuildHandler struct {
 	Next BuildHandler
 	With BuildMiddleware
+
+	// order and index locate the middleware within the step, for the trail
+	// of errors returned by the middleware. Nil if the stack does not record
+	// middleware errors.
+	order []interface{}
+	index int
+
+	// stats records the statistics of the middleware, if enabled.
+	stats *StackStats
 }
 
 var _ BuildHandler = (*decoratedBuildHandler)(nil)
@@ -197,7 +233,17 @@ var _ BuildHandler = (*decoratedBuildHandler)(nil)
 func (h decoratedBuildHandler) HandleBuild(ctx context.Context, in BuildInput) (
 	out BuildOutput, metadata Metadata, err error,
 ) {
-	return h.With.HandleBuild(ctx, in, h.Next)
+	if h.stats != nil {
+		var done func()
+		ctx, done = h.stats.start(ctx, "Build", h.With.ID())
+		defer done()
+	}
+
+	out, metadata, err = h.With.HandleBuild(ctx, in, h.Next)
+	if err != nil && h.order != nil {
+		err = wrapMiddlewareError(ctx, err, "Build", h.order[:h.index+1])
+	}
+	return out, metadata, err
 }
 
 // BuildHandlerFunc provides a wrapper around a function to be used as a build middleware handler.
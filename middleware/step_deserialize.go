@@ -104,12 +104,23 @@ func (s *DeserializeStep) HandleMiddleware(ctx context.Context, in interface{},
 	out interface{}, metadata Metadata, err error,
 ) {
 	order := s.ids.GetOrder()
+	stats := getStackStats(ctx)
 
-	var h DeserializeHandler = deserializeWrapHandler{Next: next}
+	// the step's middleware are located for the errors they return only if
+	// the stack records middleware errors.
+	var errOrder []interface{}
+	if middlewareErrorsEnabled(ctx) {
+		errOrder = order
+	}
+
+	var h DeserializeHandler = deserializeWrapHandler{Next: next, order: errOrder}
 	for i := len(order) - 1; i >= 0; i-- {
 		h = decoratedDeserializeHandler{
-			Next: h,
-			With: order[i].(DeserializeMiddleware),
+			Next:  h,
+			With:  order[i].(DeserializeMiddleware),
+			order: errOrder,
+			index: i,
+			stats: stats,
 		}
 	}
 
@@ -123,7 +134,7 @@ func (s *DeserializeStep) HandleMiddleware(ctx context.Context, in interface{},
 
 // Get retrieves the middleware identified by id. If the middleware is not present, returns false.
 func (s *DeserializeStep) Get(id string) (DeserializeMiddleware, bool) {
-	get, ok := s.ids.Get(id)
+	get, ok := s.ids.Get(s.ids.resolveDeprecated("Get", id))
 	if !ok {
 		return nil, false
 	}
@@ -147,7 +158,7 @@ func (s *DeserializeStep) Insert(m DeserializeMiddleware, relativeTo string, pos
 // Returns the middleware removed, or error if the middleware to be removed
 // doesn't exist.
 func (s *DeserializeStep) Swap(id string, m DeserializeMiddleware) (DeserializeMiddleware, error) {
-	removed, err := s.ids.Swap(id, m)
+	removed, err := s.ids.Swap(s.ids.resolveDeprecated("Swap", id), m)
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +169,7 @@ func (s *DeserializeStep) Swap(id string, m DeserializeMiddleware) (DeserializeM
 // Remove removes the middleware by id. Returns error if the middleware
 // doesn't exist.
 func (s *DeserializeStep) Remove(id string) (DeserializeMiddleware, error) {
-	removed, err := s.ids.Remove(id)
+	removed, err := s.ids.Remove(s.ids.resolveDeprecated("Remove", id))
 	if err != nil {
 		return nil, err
 	}
@@ -166,6 +177,14 @@ func (s *DeserializeStep) Remove(id string) (DeserializeMiddleware, error) {
 	return removed.(DeserializeMiddleware), nil
 }
 
+// Deprecate registers the middleware ID as deprecated in favor of its
+// replacement. Get, Swap, and Remove called with the deprecated ID log a
+// warning to the stack's logger, and are applied to the replacement if the
+// deprecated ID is not present in the step.
+func (s *DeserializeStep) Deprecate(d Deprecation) error {
+	return s.ids.Deprecate("Deserialize", d)
+}
+
 // List returns a list of the middleware in the step.
 func (s *DeserializeStep) List() []string {
 	return s.ids.List()
@@ -178,6 +197,11 @@ func (s *DeserializeStep) Clear() {
 
 type deserializeWrapHandler struct {
 	Next Handler
+
+	// order is the step's middleware, for the trail of errors returned by
+	// the subsequent steps. Nil if the stack does not record middleware
+	// errors.
+	order []interface{}
 }
 
 var _ DeserializeHandler = (*deserializeWrapHandler)(nil)
@@ -187,6 +211,9 @@ var _ DeserializeHandler = (*deserializeWrapHandler)(nil)
 func (w deserializeWrapHandler) HandleDeserialize(ctx context.Context, in DeserializeInput) (
 	out DeserializeOutput, metadata Metadata, err error,
 ) {
+	if w.order != nil {
+		ctx = withMiddlewareTrail(ctx, "Deserialize", w.order)
+	}
 	resp, metadata, err := w.Next.Handle(ctx, in.Request)
 	return DeserializeOutput{
 		RawResponse: resp,
@@ -196,6 +223,15 @@ func (w deserializeWrapHandler) HandleDeserialize(ctx context.Context, in Deseri
 type decoratedDeserializeHandler struct {
 	Next DeserializeHandler
 	With DeserializeMiddleware
+
+	// order and index locate the middleware within the step, for the trail
+	// of errors returned by the middleware. Nil if the stack does not record
+	// middleware errors.
+	order []interface{}
+	index int
+
+	// stats records the statistics of the middleware, if enabled.
+	stats *StackStats
 }
 
 var _ DeserializeHandler = (*decoratedDeserializeHandler)(nil)
@@ -203,7 +239,17 @@ var _ DeserializeHandler = (*decoratedDeserializeHandler)(nil)
 func (h decoratedDeserializeHandler) HandleDeserialize(ctx context.Context, in DeserializeInput) (
 	out DeserializeOutput, metadata Metadata, err error,
 ) {
-	return h.With.HandleDeserialize(ctx, in, h.Next)
+	if h.stats != nil {
+		var done func()
+		ctx, done = h.stats.start(ctx, "Deserialize", h.With.ID())
+		defer done()
+	}
+
+	out, metadata, err = h.With.HandleDeserialize(ctx, in, h.Next)
+	if err != nil && h.order != nil {
+		err = wrapMiddlewareError(ctx, err, "Deserialize", h.order[:h.index+1])
+	}
+	return out, metadata, err
 }
 
 // DeserializeHandlerFunc provides a wrapper around a function to be used as a deserialize middleware handler.
@@ -0,0 +1,108 @@
+package middleware
+
+import "fmt"
+
+// editableStep is the set of methods shared by the stack's steps, for the
+// step's middleware type M.
+type editableStep[M ider] interface {
+	Get(id string) (M, bool)
+	Add(m M, pos RelativePosition) error
+	Insert(m M, relativeTo string, pos RelativePosition) error
+	Swap(id string, m M) (M, error)
+	Remove(id string) (M, error)
+}
+
+// StepEditor chains modifications of a step's middleware. Each method is a
+// no-op once a modification has failed, and the first error is returned by
+// Err, so a sequence of modifications can be written without checking the
+// error of each.
+//
+//	err := stack.Finalize.Edit().
+//		Remove("OldMiddleware").
+//		Insert(m, "Retry", middleware.After).
+//		Err()
+type StepEditor[M ider] struct {
+	step editableStep[M]
+	err  error
+}
+
+// Add injects the middleware to the relative position of the step. See the
+// step's Add method.
+func (e *StepEditor[M]) Add(m M, pos RelativePosition) *StepEditor[M] {
+	if e.err == nil {
+		e.err = e.step.Add(m, pos)
+	}
+	return e
+}
+
+// Insert injects the middleware relative to an existing middleware id. See
+// the step's Insert method.
+func (e *StepEditor[M]) Insert(m M, relativeTo string, pos RelativePosition) *StepEditor[M] {
+	if e.err == nil {
+		e.err = e.step.Insert(m, relativeTo, pos)
+	}
+	return e
+}
+
+// Swap replaces the middleware identified by id. See the step's Swap method.
+func (e *StepEditor[M]) Swap(id string, m M) *StepEditor[M] {
+	if e.err == nil {
+		_, e.err = e.step.Swap(id, m)
+	}
+	return e
+}
+
+// Remove removes the middleware identified by id. See the step's Remove
+// method.
+func (e *StepEditor[M]) Remove(id string) *StepEditor[M] {
+	if e.err == nil {
+		_, e.err = e.step.Remove(id)
+	}
+	return e
+}
+
+// Update replaces the middleware identified by id with the middleware
+// returned by fn, which is called with the existing middleware. Fails if the
+// middleware is not present in the step.
+func (e *StepEditor[M]) Update(id string, fn func(M) M) *StepEditor[M] {
+	if e.err != nil {
+		return e
+	}
+	m, ok := e.step.Get(id)
+	if !ok {
+		e.err = fmt.Errorf("not found, %v", id)
+		return e
+	}
+	_, e.err = e.step.Swap(id, fn(m))
+	return e
+}
+
+// Err returns the error of the first modification which failed, or nil.
+func (e *StepEditor[M]) Err() error {
+	return e.err
+}
+
+// Edit returns a StepEditor for chaining modifications of the step.
+func (s *InitializeStep) Edit() *StepEditor[InitializeMiddleware] {
+	return &StepEditor[InitializeMiddleware]{step: s}
+}
+
+// Edit returns a StepEditor for chaining modifications of the step.
+func (s *SerializeStep) Edit() *StepEditor[SerializeMiddleware] {
+	return &StepEditor[SerializeMiddleware]{step: s}
+}
+
+// Edit returns a StepEditor for chaining modifications of the step.
+func (s *BuildStep) Edit() *StepEditor[BuildMiddleware] {
+	return &StepEditor[BuildMiddleware]{step: s}
+}
+
+// Edit returns a StepEditor for chaining modifications of the step.
+func (s *FinalizeStep) Edit() *StepEditor[FinalizeMiddleware] {
+	return &StepEditor[FinalizeMiddleware]{step: s}
+}
+
+// Edit returns a StepEditor for chaining modifications of the step.
+func (s *DeserializeStep) Edit() *StepEditor[DeserializeMiddleware] {
+	return &StepEditor[DeserializeMiddleware]{step: s}
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestStepEditor(t *testing.T) {
+	noop := func(id string) FinalizeMiddleware {
+		return FinalizeMiddlewareFunc(id, func(ctx context.Context, in FinalizeInput, next FinalizeHandler) (
+			FinalizeOutput, Metadata, error,
+		) {
+			return next.HandleFinalize(ctx, in)
+		})
+	}
+
+	cases := map[string]struct {
+		Edit       func(*StepEditor[FinalizeMiddleware]) *StepEditor[FinalizeMiddleware]
+		ExpectList []string
+		ExpectErr  string
+	}{
+		"chain": {
+			Edit: func(e *StepEditor[FinalizeMiddleware]) *StepEditor[FinalizeMiddleware] {
+				return e.Add(noop("a"), After).
+					Add(noop("c"), After).
+					Insert(noop("b"), "c", Before).
+					Swap("c", noop("d")).
+					Update("a", func(m FinalizeMiddleware) FinalizeMiddleware {
+						return noop(m.ID())
+					})
+			},
+			ExpectList: []string{"a", "b", "d"},
+		},
+		"stops at first error": {
+			Edit: func(e *StepEditor[FinalizeMiddleware]) *StepEditor[FinalizeMiddleware] {
+				return e.Add(noop("a"), After).
+					Remove("missing").
+					Add(noop("b"), After)
+			},
+			ExpectList: []string{"a"},
+			ExpectErr:  "not found, missing",
+		},
+		"update missing": {
+			Edit: func(e *StepEditor[FinalizeMiddleware]) *StepEditor[FinalizeMiddleware] {
+				return e.Update("missing", func(m FinalizeMiddleware) FinalizeMiddleware {
+					t.Errorf("expect update not called")
+					return m
+				})
+			},
+			ExpectList: []string{},
+			ExpectErr:  "not found, missing",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			stack := NewStack("test", func() interface{} { return struct{}{} })
+
+			err := c.Edit(stack.Finalize.Edit()).Err()
+			if len(c.ExpectErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error")
+				}
+				if e, a := c.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %v, got %v", e, a)
+				}
+			} else if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := strings.Join(c.ExpectList, ","), strings.Join(stack.Finalize.List(), ","); e != a {
+				t.Errorf("expect %v middleware, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestStackGetAnyStep(t *testing.T) {
+	stack := NewStack("test", func() interface{} { return struct{}{} })
+	stack.Initialize.Add(mockInitializeMiddleware("first"), After)
+	stack.Deserialize.Add(mockDeserializeMiddleware("last"), After)
+
+	cases := map[string]struct {
+		ID         string
+		ExpectStep string
+		ExpectOK   bool
+	}{
+		"initialize":    {ID: "first", ExpectStep: "Initialize", ExpectOK: true},
+		"deserialize":   {ID: "last", ExpectStep: "Deserialize", ExpectOK: true},
+		"missing":       {ID: "missing"},
+		"unfilled slot": {ID: SlotSigning},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			m, step, ok := stack.GetAnyStep(c.ID)
+			if e, a := c.ExpectOK, ok; e != a {
+				t.Fatalf("expect %v found, got %v", e, a)
+			}
+			if e, a := c.ExpectStep, step; e != a {
+				t.Errorf("expect %v step, got %v", e, a)
+			}
+			if !ok {
+				return
+			}
+			if e, a := c.ID, m.(ider).ID(); e != a {
+				t.Errorf("expect %v middleware, got %v", e, a)
+			}
+		})
+	}
+}
@@ -98,12 +98,23 @@ func (s *InitializeStep) HandleMiddleware(ctx context.Context, in interface{}, n
 	out interface{}, metadata Metadata, err error,
 ) {
 	order := s.ids.GetOrder()
+	stats := getStackStats(ctx)
 
-	var h InitializeHandler = initializeWrapHandler{Next: next}
+	// the step's middleware are located for the errors they return only if
+	// the stack records middleware errors.
+	var errOrder []interface{}
+	if middlewareErrorsEnabled(ctx) {
+		errOrder = order
+	}
+
+	var h InitializeHandler = initializeWrapHandler{Next: next, order: errOrder}
 	for i := len(order) - 1; i >= 0; i-- {
 		h = decoratedInitializeHandler{
-			Next: h,
-			With: order[i].(InitializeMiddleware),
+			Next:  h,
+			With:  order[i].(InitializeMiddleware),
+			order: errOrder,
+			index: i,
+			stats: stats,
 		}
 	}
 
@@ -117,7 +128,7 @@ func (s *InitializeStep) HandleMiddleware(ctx context.Context, in interface{}, n
 
 // Get retrieves the middleware identified by id. If the middleware is not present, returns false.
 func (s *InitializeStep) Get(id string) (InitializeMiddleware, bool) {
-	get, ok := s.ids.Get(id)
+	get, ok := s.ids.Get(s.ids.resolveDeprecated("Get", id))
 	if !ok {
 		return nil, false
 	}
@@ -141,7 +152,7 @@ func (s *InitializeStep) Insert(m InitializeMiddleware, relativeTo string, pos R
 // Returns the middleware removed, or error if the middleware to be removed
 // doesn't exist.
 func (s *InitializeStep) Swap(id string, m InitializeMiddleware) (InitializeMiddleware, error) {
-	removed, err := s.ids.Swap(id, m)
+	removed, err := s.ids.Swap(s.ids.resolveDeprecated("Swap", id), m)
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +163,7 @@ func (s *InitializeStep) Swap(id string, m InitializeMiddleware) (InitializeMidd
 // Remove removes the middleware by id. Returns error if the middleware
 // doesn't exist.
 func (s *InitializeStep) Remove(id string) (InitializeMiddleware, error) {
-	removed, err := s.ids.Remove(id)
+	removed, err := s.ids.Remove(s.ids.resolveDeprecated("Remove", id))
 	if err != nil {
 		return nil, err
 	}
@@ -160,6 +171,14 @@ func (s *InitializeStep) Remove(id string) (InitializeMiddleware, error) {
 	return removed.(InitializeMiddleware), nil
 }
 
+// Deprecate registers the middleware ID as deprecated in favor of its
+// replacement. Get, Swap, and Remove called with the deprecated ID log a
+// warning to the stack's logger, and are applied to the replacement if the
+// deprecated ID is not present in the step.
+func (s *InitializeStep) Deprecate(d Deprecation) error {
+	return s.ids.Deprecate("Initialize", d)
+}
+
 // List returns a list of the middleware in the step.
 func (s *InitializeStep) List() []string {
 	return s.ids.List()
@@ -172,6 +191,11 @@ func (s *InitializeStep) Clear() {
 
 type initializeWrapHandler struct {
 	Next Handler
+
+	// order is the step's middleware, for the trail of errors returned by
+	// the subsequent steps. Nil if the stack does not record middleware
+	// errors.
+	order []interface{}
 }
 
 var _ InitializeHandler = (*initializeWrapHandler)(nil)
@@ -181,6 +205,9 @@ var _ InitializeHandler = (*initializeWrapHandler)(nil)
 func (w initializeWrapHandler) HandleInitialize(ctx context.Context, in InitializeInput) (
 	out InitializeOutput, metadata Metadata, err error,
 ) {
+	if w.order != nil {
+		ctx = withMiddlewareTrail(ctx, "Initialize", w.order)
+	}
 	res, metadata, err := w.Next.Handle(ctx, in.Parameters)
 	return InitializeOutput{
 		Result: res,
@@ -190,6 +217,15 @@ func (w initializeWrapHandler) HandleInitialize(ctx context.Context, in Initiali
 type decoratedInitializeHandler struct {
 	Next InitializeHandler
 	With InitializeMiddleware
+
+	// order and index locate the middleware within the step, for the trail
+	// of errors returned by the middleware. Nil if the stack does not record
+	// middleware errors.
+	order []interface{}
+	index int
+
+	// stats records the statistics of the middleware, if enabled.
+	stats *StackStats
 }
 
 var _ InitializeHandler = (*decoratedInitializeHandler)(nil)
@@ -197,7 +233,17 @@ var _ InitializeHandler = (*decoratedInitializeHandler)(nil)
 func (h decoratedInitializeHandler) HandleInitialize(ctx context.Context, in InitializeInput) (
 	out InitializeOutput, metadata Metadata, err error,
 ) {
-	return h.With.HandleInitialize(ctx, in, h.Next)
+	if h.stats != nil {
+		var done func()
+		ctx, done = h.stats.start(ctx, "Initialize", h.With.ID())
+		defer done()
+	}
+
+	out, metadata, err = h.With.HandleInitialize(ctx, in, h.Next)
+	if err != nil && h.order != nil {
+		err = wrapMiddlewareError(ctx, err, "Initialize", h.order[:h.index+1])
+	}
+	return out, metadata, err
 }
 
 // InitializeHandlerFunc provides a wrapper around a function to be used as an initialize middleware handler.
@@ -105,12 +105,23 @@ func (s *SerializeStep) HandleMiddleware(ctx context.Context, in interface{}, ne
 	out interface{}, metadata Metadata, err error,
 ) {
 	order := s.ids.GetOrder()
+	stats := getStackStats(ctx)
 
-	var h SerializeHandler = serializeWrapHandler{Next: next}
+	// the step's middleware are located for the errors they return only if
+	// the stack records middleware errors.
+	var errOrder []interface{}
+	if middlewareErrorsEnabled(ctx) {
+		errOrder = order
+	}
+
+	var h SerializeHandler = serializeWrapHandler{Next: next, order: errOrder}
 	for i := len(order) - 1; i >= 0; i-- {
 		h = decoratedSerializeHandler{
-			Next: h,
-			With: order[i].(SerializeMiddleware),
+			Next:  h,
+			With:  order[i].(SerializeMiddleware),
+			order: errOrder,
+			index: i,
+			stats: stats,
 		}
 	}
 
@@ -125,7 +136,7 @@ func (s *SerializeStep) HandleMiddleware(ctx context.Context, in interface{}, ne
 
 // Get retrieves the middleware identified by id. If the middleware is not present, returns false.
 func (s *SerializeStep) Get(id string) (SerializeMiddleware, bool) {
-	get, ok := s.ids.Get(id)
+	get, ok := s.ids.Get(s.ids.resolveDeprecated("Get", id))
 	if !ok {
 		return nil, false
 	}
@@ -149,7 +160,7 @@ func (s *SerializeStep) Insert(m SerializeMiddleware, relativeTo string, pos Rel
 // Returns the middleware removed, or error if the middleware to be removed
 // doesn't exist.
 func (s *SerializeStep) Swap(id string, m SerializeMiddleware) (SerializeMiddleware, error) {
-	removed, err := s.ids.Swap(id, m)
+	removed, err := s.ids.Swap(s.ids.resolveDeprecated("Swap", id), m)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +171,7 @@ func (s *SerializeStep) Swap(id string, m SerializeMiddleware) (SerializeMiddlew
 // Remove removes the middleware by id. Returns error if the middleware
 // doesn't exist.
 func (s *SerializeStep) Remove(id string) (SerializeMiddleware, error) {
-	removed, err := s.ids.Remove(id)
+	removed, err := s.ids.Remove(s.ids.resolveDeprecated("Remove", id))
 	if err != nil {
 		return nil, err
 	}
@@ -168,6 +179,14 @@ func (s *SerializeStep) Remove(id string) (SerializeMiddleware, error) {
 	return removed.(SerializeMiddleware), nil
 }
 
+// Deprecate registers the middleware ID as deprecated in favor of its
+// replacement. Get, Swap, and Remove called with the deprecated ID log a
+// warning to the stack's logger, and are applied to the replacement if the
+// deprecated ID is not present in the step.
+func (s *SerializeStep) Deprecate(d Deprecation) error {
+	return s.ids.Deprecate("Serialize", d)
+}
+
 // List returns a list of the middleware in the step.
 func (s *SerializeStep) List() []string {
 	return s.ids.List()
@@ -180,6 +199,11 @@ func (s *SerializeStep) Clear() {
 
 type serializeWrapHandler struct {
 	Next Handler
+
+	// order is the step's middleware, for the trail of errors returned by
+	// the subsequent steps. Nil if the stack does not record middleware
+	// errors.
+	order []interface{}
 }
 
 var _ SerializeHandler = (*serializeWrapHandler)(nil)
@@ -189,6 +213,9 @@ var _ SerializeHandler = (*serializeWrapHandler)(nil)
 func (w serializeWrapHandler) HandleSerialize(ctx context.Context, in SerializeInput) (
 	out SerializeOutput, metadata Metadata, err error,
 ) {
+	if w.order != nil {
+		ctx = withMiddlewareTrail(ctx, "Serialize", w.order)
+	}
 	res, metadata, err := w.Next.Handle(ctx, in.Request)
 	return SerializeOutput{
 		Result: res,
@@ -198,6 +225,15 @@ func (w serializeWrapHandler) HandleSerialize(ctx context.Context, in SerializeI
 type decoratedSerializeHandler struct {
 	Next SerializeHandler
 	With SerializeMiddleware
+
+	// order and index locate the middleware within the step, for the trail
+	// of errors returned by the middleware. Nil if the stack does not record
+	// middleware errors.
+	order []interface{}
+	index int
+
+	// stats records the statistics of the middleware, if enabled.
+	stats *StackStats
 }
 
 var _ SerializeHandler = (*decoratedSerializeHandler)(nil)
@@ -205,7 +241,17 @@ var _ SerializeHandler = (*decoratedSerializeHandler)(nil)
 func (h decoratedSerializeHandler) HandleSerialize(ctx context.Context, in SerializeInput) (
 	out SerializeOutput, metadata Metadata, err error,
 ) {
-	return h.With.HandleSerialize(ctx, in, h.Next)
+	if h.stats != nil {
+		var done func()
+		ctx, done = h.stats.start(ctx, "Serialize", h.With.ID())
+		defer done()
+	}
+
+	out, metadata, err = h.With.HandleSerialize(ctx, in, h.Next)
+	if err != nil && h.order != nil {
+		err = wrapMiddlewareError(ctx, err, "Serialize", h.order[:h.index+1])
+	}
+	return out, metadata, err
 }
 
 // SerializeHandlerFunc provides a wrapper around a function to be used as a serialize middleware handler.
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/aws/smithy-go/tracing"
+)
+
+// AddOperationTracingMiddleware adds middleware to the stack that record a
+// span for the operation invocation, with child spans for each of the stack's
+// steps, and each request attempt made within the Finalize step. Spans are
+// created by a Tracer for the given scope from the provider. The operation
+// span is named after the stack's ID, and records the service ID and
+// operation name of the context if set, see AddOperationMetadataMiddleware.
+//
+// Spans whose step returns an error are marked with the error status. The
+// operation span records the request ID of the response if set with
+// SetRequestIDMetadata.
+//
+// If provider is nil, tracing.NopTracerProvider is used.
+func AddOperationTracingMiddleware(stack *Stack, provider tracing.TracerProvider, scope string) error {
+	if provider == nil {
+		provider = tracing.NopTracerProvider{}
+	}
+
+	err := stack.Initialize.Add(&operationTracing{
+		tracer: provider.Tracer(scope),
+		name:   stack.ID(),
+	}, Before)
+	if err != nil {
+		return err
+	}
+	if err := stack.Serialize.Add(stepTracing{name: "Serialize"}, Before); err != nil {
+		return err
+	}
+	if err := stack.Build.Add(stepTracing{name: "Build"}, Before); err != nil {
+		return err
+	}
+	if err := stack.Finalize.Add(stepTracing{name: "Finalize"}, Before); err != nil {
+		return err
+	}
+	if err := stack.Finalize.Add(stepTracing{name: "Attempt"}, After); err != nil {
+		return err
+	}
+	return stack.Deserialize.Add(stepTracing{name: "Deserialize"}, Before)
+}
+
+// endSpan marks the span's status from err, and ends the span.
+func endSpan(span tracing.Span, metadata Metadata, err error) {
+	if id, ok := GetRequestIDMetadata(metadata); ok {
+		span.SetProperty("rpc.request_id", id)
+	}
+	if err != nil {
+		span.SetProperty("exception.message", err.Error())
+		span.SetStatus(tracing.SpanStatusError)
+	} else {
+		span.SetStatus(tracing.SpanStatusOK)
+	}
+	span.End()
+}
+
+type operationTracing struct {
+	tracer tracing.Tracer
+	name   string
+}
+
+func (*operationTracing) ID() string { return "OperationTracing" }
+
+func (m *operationTracing) HandleInitialize(ctx context.Context, in InitializeInput, next InitializeHandler) (
+	out InitializeOutput, metadata Metadata, err error,
+) {
+	ctx = tracing.WithOperationTracer(ctx, m.tracer)
+	ctx, span := m.tracer.StartSpan(ctx, m.name, tracing.WithSpanKind(tracing.SpanKindClient))
+	defer func() { endSpan(span, metadata, err) }()
+
+	props := OperationMetadataProperties(ctx)
+	for k, v := range props.Values() {
+		span.SetProperty(k, v)
+	}
+
+	return next.HandleInitialize(ctx, in)
+}
+
+// stepTracing records a span for the portion of the stack it decorates.
+type stepTracing struct {
+	name string
+}
+
+func (m stepTracing) ID() string { return m.name + "Tracing" }
+
+func (m stepTracing) HandleSerialize(ctx context.Context, in SerializeInput, next SerializeHandler) (
+	out SerializeOutput, metadata Metadata, err error,
+) {
+	ctx, span := tracing.StartSpan(ctx, m.name)
+	defer func() { endSpan(span, metadata, err) }()
+
+	return next.HandleSerialize(ctx, in)
+}
+
+func (m stepTracing) HandleBuild(ctx context.Context, in BuildInput, next BuildHandler) (
+	out BuildOutput, metadata Metadata, err error,
+) {
+	ctx, span := tracing.StartSpan(ctx, m.name)
+	defer func() { endSpan(span, metadata, err) }()
+
+	return next.HandleBuild(ctx, in)
+}
+
+func (m stepTracing) HandleFinalize(ctx context.Context, in FinalizeInput, next FinalizeHandler) (
+	out FinalizeOutput, metadata Metadata, err error,
+) {
+	ctx, span := tracing.StartSpan(ctx, m.name)
+	defer func() { endSpan(span, metadata, err) }()
+
+	return next.HandleFinalize(ctx, in)
+}
+
+func (m stepTracing) HandleDeserialize(ctx context.Context, in DeserializeInput, next DeserializeHandler) (
+	out DeserializeOutput, metadata Metadata, err error,
+) {
+	ctx, span := tracing.StartSpan(ctx, m.name)
+	defer func() { endSpan(span, metadata, err) }()
+
+	return next.HandleDeserialize(ctx, in)
+}
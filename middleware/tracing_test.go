@@ -0,0 +1,90 @@
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/tracing"
+	"github.com/google/go-cmp/cmp"
+)
+
+type mockTracer struct {
+	Spans []*mockSpan
+}
+
+func (t *mockTracer) Tracer(string, ...tracing.TracerOption) tracing.Tracer {
+	return t
+}
+
+func (t *mockTracer) StartSpan(ctx context.Context, name string, _ ...tracing.SpanOption) (context.Context, tracing.Span) {
+	span := &mockSpan{name: name, properties: map[interface{}]interface{}{}}
+	if parent, ok := tracing.GetSpan(ctx); ok {
+		span.parent = parent.Name()
+	}
+	t.Spans = append(t.Spans, span)
+	return tracing.WithSpan(ctx, span), span
+}
+
+type mockSpan struct {
+	name       string
+	parent     string
+	status     tracing.SpanStatus
+	properties map[interface{}]interface{}
+	ended      bool
+}
+
+func (s *mockSpan) Name() string                            { return s.name }
+func (s *mockSpan) Context() tracing.SpanContext            { return tracing.SpanContext{} }
+func (s *mockSpan) AddEvent(string, ...tracing.EventOption) {}
+func (s *mockSpan) SetStatus(status tracing.SpanStatus)     { s.status = status }
+func (s *mockSpan) SetProperty(k, v interface{})            { s.properties[k] = v }
+func (s *mockSpan) End()                                    { s.ended = true }
+
+func TestAddOperationTracingMiddleware(t *testing.T) {
+	tracer := &mockTracer{}
+
+	stack := middleware.NewStack("GetFoo", func() interface{} { return struct{}{} })
+	if err := middleware.AddOperationTracingMiddleware(stack, tracer, "test-scope"); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+			var metadata middleware.Metadata
+			middleware.SetRequestIDMetadata(&metadata, "abc123")
+			return nil, metadata, fmt.Errorf("handler error")
+		}), stack)
+
+	if _, _, err := handler.Handle(context.Background(), struct{}{}); err == nil {
+		t.Fatalf("expect error, got none")
+	}
+
+	var actual [][2]string
+	for _, span := range tracer.Spans {
+		actual = append(actual, [2]string{span.name, span.parent})
+		if !span.ended {
+			t.Errorf("expect %s span to be ended", span.name)
+		}
+		if e, a := tracing.SpanStatusError, span.status; e != a {
+			t.Errorf("expect %s span %v status, got %v", span.name, e, a)
+		}
+	}
+
+	expect := [][2]string{
+		{"GetFoo", ""},
+		{"Serialize", "GetFoo"},
+		{"Build", "Serialize"},
+		{"Finalize", "Build"},
+		{"Attempt", "Finalize"},
+		{"Deserialize", "Attempt"},
+	}
+	if diff := cmp.Diff(expect, actual); len(diff) != 0 {
+		t.Errorf("expect spans to match\n%s", diff)
+	}
+
+	if e, a := "abc123", tracer.Spans[0].properties["rpc.request_id"]; e != a {
+		t.Errorf("expect %v request ID, got %v", e, a)
+	}
+}
@@ -0,0 +1,403 @@
+// Package model provides a loader of Smithy models from their JSON AST
+// representation, such as the model.json output of a Smithy build, into
+// typed Go structures, for consumers of models at runtime such as dynamic
+// clients, server routers, and tooling.
+//
+//	m, err := model.Load(f)
+//	if err != nil {
+//		return err
+//	}
+//	ops, err := m.ServiceOperations("example.weather#Weather")
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ShapeID is an absolute shape ID, e.g. "example.weather#City", or the ID of
+// a member, e.g. "example.weather#City$name".
+type ShapeID string
+
+// Namespace returns the namespace of the shape ID.
+func (id ShapeID) Namespace() string {
+	if i := strings.Index(string(id), "#"); i != -1 {
+		return string(id)[:i]
+	}
+	return ""
+}
+
+// Name returns the name of the shape ID, without its namespace or member.
+func (id ShapeID) Name() string {
+	s := string(id)
+	if i := strings.Index(s, "#"); i != -1 {
+		s = s[i+1:]
+	}
+	if i := strings.Index(s, "$"); i != -1 {
+		s = s[:i]
+	}
+	return s
+}
+
+// Member returns the member name of the shape ID, or an empty string if the
+// shape ID is not a member's.
+func (id ShapeID) Member() string {
+	if i := strings.Index(string(id), "$"); i != -1 {
+		return string(id)[i+1:]
+	}
+	return ""
+}
+
+// ShapeType is the type of a shape.
+type ShapeType string
+
+// Shape types of the Smithy IDL.
+const (
+	TypeBlob       ShapeType = "blob"
+	TypeBoolean    ShapeType = "boolean"
+	TypeString     ShapeType = "string"
+	TypeEnum       ShapeType = "enum"
+	TypeByte       ShapeType = "byte"
+	TypeShort      ShapeType = "short"
+	TypeInteger    ShapeType = "integer"
+	TypeIntEnum    ShapeType = "intEnum"
+	TypeLong       ShapeType = "long"
+	TypeFloat      ShapeType = "float"
+	TypeDouble     ShapeType = "double"
+	TypeBigInteger ShapeType = "bigInteger"
+	TypeBigDecimal ShapeType = "bigDecimal"
+	TypeTimestamp  ShapeType = "timestamp"
+	TypeDocument   ShapeType = "document"
+	TypeList       ShapeType = "list"
+	TypeSet        ShapeType = "set"
+	TypeMap        ShapeType = "map"
+	TypeStructure  ShapeType = "structure"
+	TypeUnion      ShapeType = "union"
+	TypeService    ShapeType = "service"
+	TypeResource   ShapeType = "resource"
+	TypeOperation  ShapeType = "operation"
+
+	// typeApply is the type of the JSON AST's apply statements, which are
+	// merged into their target when the model is loaded.
+	typeApply ShapeType = "apply"
+)
+
+// Model is a Smithy model.
+type Model struct {
+	// Version is the Smithy IDL version of the model, e.g. "2.0".
+	Version string
+
+	// Metadata is the model's metadata by key.
+	Metadata map[string]json.RawMessage
+
+	// Shapes are the model's shapes by absolute shape ID.
+	Shapes map[ShapeID]*Shape
+}
+
+// Shape is a shape of the model.
+type Shape struct {
+	// ID is the absolute shape ID of the shape.
+	ID ShapeID `json:"-"`
+
+	// Type is the shape's type.
+	Type ShapeType `json:"type"`
+
+	// Members are the members of a structure, union, enum, or intEnum
+	// shape.
+	Members map[string]*Member `json:"members,omitempty"`
+
+	// Member is the member of a list or set shape.
+	Member *Member `json:"member,omitempty"`
+
+	// Key and Value are the members of a map shape.
+	Key   *Member `json:"key,omitempty"`
+	Value *Member `json:"value,omitempty"`
+
+	// Mixins are the mixins of the shape. Mixins are flattened into the
+	// shape when the model is loaded.
+	Mixins []Reference `json:"mixins,omitempty"`
+
+	// Version of a service shape.
+	Version string `json:"version,omitempty"`
+
+	// Input, Output, and Errors are the shapes referenced by an operation
+	// shape. Errors are also the common errors of a service shape.
+	Input  *Reference  `json:"input,omitempty"`
+	Output *Reference  `json:"output,omitempty"`
+	Errors []Reference `json:"errors,omitempty"`
+
+	// Operations and Resources are the shapes bound to a service or
+	// resource shape.
+	Operations []Reference `json:"operations,omitempty"`
+	Resources  []Reference `json:"resources,omitempty"`
+
+	// Identifiers and lifecycle operations of a resource shape.
+	Identifiers          map[string]Reference `json:"identifiers,omitempty"`
+	Create               *Reference           `json:"create,omitempty"`
+	Put                  *Reference           `json:"put,omitempty"`
+	Read                 *Reference           `json:"read,omitempty"`
+	Update               *Reference           `json:"update,omitempty"`
+	Delete               *Reference           `json:"delete,omitempty"`
+	List                 *Reference           `json:"list,omitempty"`
+	CollectionOperations []Reference          `json:"collectionOperations,omitempty"`
+
+	// Traits are the shape's traits.
+	Traits Traits `json:"traits,omitempty"`
+}
+
+// Member is a member of an aggregate shape.
+type Member struct {
+	// Target is the absolute shape ID of the member's target.
+	Target ShapeID `json:"target"`
+
+	// Traits are the member's traits.
+	Traits Traits `json:"traits,omitempty"`
+}
+
+// Reference is a reference to a shape.
+type Reference struct {
+	Target ShapeID `json:"target"`
+}
+
+// Load reads a model from its JSON AST representation. Apply statements are
+// merged into their targets, and mixins are flattened into the shapes which
+// use them.
+func Load(r io.Reader) (*Model, error) {
+	var ast struct {
+		Version  string                     `json:"smithy"`
+		Metadata map[string]json.RawMessage `json:"metadata"`
+		Shapes   map[ShapeID]*Shape         `json:"shapes"`
+	}
+	if err := json.NewDecoder(r).Decode(&ast); err != nil {
+		return nil, fmt.Errorf("failed to decode model, %w", err)
+	}
+	if !strings.HasPrefix(ast.Version, "1.") && !strings.HasPrefix(ast.Version, "2.") {
+		return nil, fmt.Errorf("unsupported model version %q", ast.Version)
+	}
+
+	m := &Model{
+		Version:  ast.Version,
+		Metadata: ast.Metadata,
+		Shapes:   make(map[ShapeID]*Shape, len(ast.Shapes)),
+	}
+	var applies []*Shape
+	for id, s := range ast.Shapes {
+		if s == nil {
+			return nil, fmt.Errorf("shape %s has no definition", id)
+		}
+		s.ID = id
+		if s.Type == typeApply {
+			applies = append(applies, s)
+			continue
+		}
+		m.Shapes[id] = s
+	}
+
+	for _, a := range applies {
+		if err := m.apply(a); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range m.Shapes {
+		if err := m.flattenMixins(s, map[ShapeID]bool{}); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// apply merges the traits of the apply statement into its target shape or
+// member.
+func (m *Model) apply(a *Shape) error {
+	target, ok := m.Shapes[ShapeID(strings.SplitN(string(a.ID), "$", 2)[0])]
+	if !ok {
+		return fmt.Errorf("apply target %s not found", a.ID)
+	}
+
+	traits := &target.Traits
+	if name := a.ID.Member(); len(name) != 0 {
+		member, ok := target.Members[name]
+		if !ok {
+			return fmt.Errorf("apply target %s not found", a.ID)
+		}
+		traits = &member.Traits
+	}
+
+	if *traits == nil {
+		*traits = Traits{}
+	}
+	for id, v := range a.Traits {
+		(*traits)[id] = v
+	}
+	return nil
+}
+
+// flattenMixins copies the members and traits of the shape's mixins into the
+// shape. The shape's own members and traits take precedence.
+func (m *Model) flattenMixins(s *Shape, visiting map[ShapeID]bool) error {
+	if len(s.Mixins) == 0 {
+		return nil
+	}
+	if visiting[s.ID] {
+		return fmt.Errorf("mixin cycle at %s", s.ID)
+	}
+	visiting[s.ID] = true
+	defer delete(visiting, s.ID)
+
+	for _, ref := range s.Mixins {
+		mixin, ok := m.Shapes[ref.Target]
+		if !ok {
+			return fmt.Errorf("mixin %s of %s not found", ref.Target, s.ID)
+		}
+		if err := m.flattenMixins(mixin, visiting); err != nil {
+			return err
+		}
+
+		for name, member := range mixin.Members {
+			if _, ok := s.Members[name]; ok {
+				continue
+			}
+			if s.Members == nil {
+				s.Members = map[string]*Member{}
+			}
+			copied := *member
+			copied.Traits = member.Traits.clone()
+			s.Members[name] = &copied
+		}
+		for id, v := range mixin.Traits {
+			if id == TraitMixin {
+				continue
+			}
+			if _, ok := s.Traits[id]; ok {
+				continue
+			}
+			if s.Traits == nil {
+				s.Traits = Traits{}
+			}
+			s.Traits[id] = v
+		}
+	}
+	s.Mixins = nil
+	return nil
+}
+
+// Shape returns the shape with the absolute shape ID. Shapes of the prelude,
+// e.g. "smithy.api#String", are returned even if not defined by the model.
+func (m *Model) Shape(id ShapeID) (*Shape, bool) {
+	if s, ok := m.Shapes[id]; ok {
+		return s, true
+	}
+	return preludeShape(id)
+}
+
+// ShapesOfType returns the IDs of the model's shapes of the type, sorted.
+func (m *Model) ShapesOfType(t ShapeType) []ShapeID {
+	var ids []ShapeID
+	for id, s := range m.Shapes {
+		if s.Type == t {
+			ids = append(ids, id)
+		}
+	}
+	sortShapeIDs(ids)
+	return ids
+}
+
+// ServiceOperations returns the IDs of the operations bound to the service,
+// directly or through its resources, sorted.
+func (m *Model) ServiceOperations(service ShapeID) ([]ShapeID, error) {
+	s, ok := m.Shapes[service]
+	if !ok || s.Type != TypeService {
+		return nil, fmt.Errorf("service %s not found in model", service)
+	}
+
+	ops := map[ShapeID]struct{}{}
+	m.collectOperations(s, ops, map[ShapeID]bool{})
+
+	ids := make([]ShapeID, 0, len(ops))
+	for id := range ops {
+		ids = append(ids, id)
+	}
+	sortShapeIDs(ids)
+	return ids, nil
+}
+
+// ServiceResources returns the IDs of the resources bound to the service,
+// directly or through other resources, sorted.
+func (m *Model) ServiceResources(service ShapeID) ([]ShapeID, error) {
+	s, ok := m.Shapes[service]
+	if !ok || s.Type != TypeService {
+		return nil, fmt.Errorf("service %s not found in model", service)
+	}
+
+	visited := map[ShapeID]bool{}
+	m.collectOperations(s, map[ShapeID]struct{}{}, visited)
+	delete(visited, service)
+
+	ids := make([]ShapeID, 0, len(visited))
+	for id := range visited {
+		ids = append(ids, id)
+	}
+	sortShapeIDs(ids)
+	return ids, nil
+}
+
+func (m *Model) collectOperations(s *Shape, ops map[ShapeID]struct{}, visited map[ShapeID]bool) {
+	if visited[s.ID] {
+		return
+	}
+	visited[s.ID] = true
+
+	refs := append([]Reference(nil), s.Operations...)
+	refs = append(refs, s.CollectionOperations...)
+	for _, ref := range []*Reference{s.Create, s.Put, s.Read, s.Update, s.Delete, s.List} {
+		if ref != nil {
+			refs = append(refs, *ref)
+		}
+	}
+	for _, ref := range refs {
+		ops[ref.Target] = struct{}{}
+	}
+
+	for _, ref := range s.Resources {
+		if r, ok := m.Shapes[ref.Target]; ok {
+			m.collectOperations(r, ops, visited)
+		}
+	}
+}
+
+func sortShapeIDs(ids []ShapeID) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+}
+
+var preludeTypes = map[string]ShapeType{
+	"String":     TypeString,
+	"Blob":       TypeBlob,
+	"Boolean":    TypeBoolean,
+	"Byte":       TypeByte,
+	"Short":      TypeShort,
+	"Integer":    TypeInteger,
+	"Long":       TypeLong,
+	"Float":      TypeFloat,
+	"Double":     TypeDouble,
+	"BigInteger": TypeBigInteger,
+	"BigDecimal": TypeBigDecimal,
+	"Timestamp":  TypeTimestamp,
+	"Document":   TypeDocument,
+	"Unit":       TypeStructure,
+}
+
+// preludeShape returns the simple shape of the prelude shape ID, including
+// the primitive shapes of IDL 1.0, e.g. "smithy.api#PrimitiveInteger".
+func preludeShape(id ShapeID) (*Shape, bool) {
+	if id.Namespace() != "smithy.api" {
+		return nil, false
+	}
+	t, ok := preludeTypes[strings.TrimPrefix(id.Name(), "Primitive")]
+	if !ok {
+		return nil, false
+	}
+	return &Shape{ID: id, Type: t}, true
+}
@@ -0,0 +1,259 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testModel = `{
+	"smithy": "2.0",
+	"metadata": {"suppressions": []},
+	"shapes": {
+		"example.weather#Weather": {
+			"type": "service",
+			"version": "2006-03-01",
+			"operations": [{"target": "example.weather#GetCurrentTime"}],
+			"resources": [{"target": "example.weather#City"}],
+			"traits": {"aws.protocols#awsJson1_0": {}}
+		},
+		"example.weather#City": {
+			"type": "resource",
+			"identifiers": {"cityId": {"target": "example.weather#CityId"}},
+			"read": {"target": "example.weather#GetCity"},
+			"list": {"target": "example.weather#ListCities"},
+			"resources": [{"target": "example.weather#Forecast"}]
+		},
+		"example.weather#Forecast": {
+			"type": "resource",
+			"read": {"target": "example.weather#GetForecast"},
+			"resources": [{"target": "example.weather#City"}]
+		},
+		"example.weather#CityId": {"type": "string"},
+		"example.weather#GetCurrentTime": {"type": "operation"},
+		"example.weather#GetCity": {
+			"type": "operation",
+			"input": {"target": "example.weather#GetCityInput"}
+		},
+		"example.weather#GetForecast": {"type": "operation"},
+		"example.weather#ListCities": {"type": "operation"},
+		"example.weather#CityIdMixin": {
+			"type": "structure",
+			"members": {
+				"cityId": {"target": "example.weather#CityId", "traits": {"smithy.api#required": {}}}
+			},
+			"traits": {
+				"smithy.api#mixin": {},
+				"smithy.api#documentation": "Identifies a city."
+			}
+		},
+		"example.weather#GetCityInput": {
+			"type": "structure",
+			"mixins": [{"target": "example.weather#CityIdMixin"}],
+			"members": {
+				"locale": {"target": "smithy.api#String"}
+			}
+		},
+		"example.weather#GetCityInput$locale": {
+			"type": "apply",
+			"traits": {"smithy.api#documentation": "The locale of the city's name."}
+		}
+	}
+}`
+
+func loadTestModel(t *testing.T) *Model {
+	t.Helper()
+	m, err := Load(strings.NewReader(testModel))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	return m
+}
+
+func TestLoad(t *testing.T) {
+	m := loadTestModel(t)
+
+	if e, a := "2.0", m.Version; e != a {
+		t.Errorf("expect %v version, got %v", e, a)
+	}
+	if _, ok := m.Metadata["suppressions"]; !ok {
+		t.Errorf("expect suppressions metadata")
+	}
+	if _, ok := m.Shapes["example.weather#GetCityInput$locale"]; ok {
+		t.Errorf("expect apply statement not to be a shape")
+	}
+
+	s, ok := m.Shape("example.weather#GetCityInput")
+	if !ok {
+		t.Fatalf("expect shape to be found")
+	}
+	if e, a := ShapeID("example.weather#GetCityInput"), s.ID; e != a {
+		t.Errorf("expect %v ID, got %v", e, a)
+	}
+	if len(s.Mixins) != 0 {
+		t.Errorf("expect mixins to be flattened, got %v", s.Mixins)
+	}
+
+	cityID, ok := s.Members["cityId"]
+	if !ok {
+		t.Fatalf("expect mixin member to be flattened into shape")
+	}
+	if !cityID.Traits.Has(TraitRequired) {
+		t.Errorf("expect mixin member traits to be flattened into shape")
+	}
+	if v, _ := s.Traits.String(TraitDocumentation); v != "Identifies a city." {
+		t.Errorf("expect mixin traits to be flattened into shape, got %q", v)
+	}
+	if s.Traits.Has(TraitMixin) {
+		t.Errorf("expect mixin trait not to be flattened into shape")
+	}
+
+	if v, _ := s.Members["locale"].Traits.String(TraitDocumentation); v != "The locale of the city's name." {
+		t.Errorf("expect applied trait on member, got %q", v)
+	}
+
+	city := m.Shapes["example.weather#City"]
+	if e, a := ShapeID("example.weather#CityId"), city.Identifiers["cityId"].Target; e != a {
+		t.Errorf("expect %v identifier, got %v", e, a)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	cases := map[string]string{
+		"invalid json":        `{`,
+		"unsupported version": `{"smithy": "3.0", "shapes": {}}`,
+		"null shape":          `{"smithy": "2.0", "shapes": {"a#B": null}}`,
+		"apply unknown shape": `{"smithy": "2.0", "shapes": {"a#B": {"type": "apply", "traits": {}}}}`,
+		"apply unknown member": `{"smithy": "2.0", "shapes": {
+			"a#B": {"type": "structure"},
+			"a#B$c": {"type": "apply", "traits": {}}
+		}}`,
+		"unknown mixin": `{"smithy": "2.0", "shapes": {
+			"a#B": {"type": "structure", "mixins": [{"target": "a#C"}]}
+		}}`,
+		"mixin cycle": `{"smithy": "2.0", "shapes": {
+			"a#B": {"type": "structure", "mixins": [{"target": "a#C"}]},
+			"a#C": {"type": "structure", "mixins": [{"target": "a#B"}]}
+		}}`,
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Load(strings.NewReader(c)); err == nil {
+				t.Errorf("expect error")
+			}
+		})
+	}
+}
+
+func TestModelShapePrelude(t *testing.T) {
+	m := loadTestModel(t)
+
+	cases := map[ShapeID]struct {
+		Type  ShapeType
+		Found bool
+	}{
+		"smithy.api#String":           {Type: TypeString, Found: true},
+		"smithy.api#PrimitiveInteger": {Type: TypeInteger, Found: true},
+		"smithy.api#Unit":             {Type: TypeStructure, Found: true},
+		"smithy.api#Unknown":          {},
+		"example.weather#String":      {},
+	}
+
+	for id, c := range cases {
+		t.Run(string(id), func(t *testing.T) {
+			s, ok := m.Shape(id)
+			if e, a := c.Found, ok; e != a {
+				t.Fatalf("expect found %v, got %v", e, a)
+			}
+			if !ok {
+				return
+			}
+			if e, a := c.Type, s.Type; e != a {
+				t.Errorf("expect %v type, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestModelServiceOperations(t *testing.T) {
+	m := loadTestModel(t)
+
+	ops, err := m.ServiceOperations("example.weather#Weather")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	expect := []ShapeID{
+		"example.weather#GetCity",
+		"example.weather#GetCurrentTime",
+		"example.weather#GetForecast",
+		"example.weather#ListCities",
+	}
+	if e, a := expect, ops; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v operations, got %v", e, a)
+	}
+
+	resources, err := m.ServiceResources("example.weather#Weather")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	expect = []ShapeID{
+		"example.weather#City",
+		"example.weather#Forecast",
+	}
+	if e, a := expect, resources; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v resources, got %v", e, a)
+	}
+
+	if _, err := m.ServiceOperations("example.weather#City"); err == nil {
+		t.Errorf("expect error for non-service shape")
+	}
+	if _, err := m.ServiceResources("example.weather#Unknown"); err == nil {
+		t.Errorf("expect error for unknown shape")
+	}
+}
+
+func TestModelShapesOfType(t *testing.T) {
+	m := loadTestModel(t)
+
+	expect := []ShapeID{
+		"example.weather#City",
+		"example.weather#Forecast",
+	}
+	if e, a := expect, m.ShapesOfType(TypeResource); !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v shapes, got %v", e, a)
+	}
+}
+
+func TestShapeID(t *testing.T) {
+	cases := map[ShapeID]struct {
+		Namespace, Name, Member string
+	}{
+		"example.weather#City": {
+			Namespace: "example.weather",
+			Name:      "City",
+		},
+		"example.weather#City$name": {
+			Namespace: "example.weather",
+			Name:      "City",
+			Member:    "name",
+		},
+		"City": {
+			Name: "City",
+		},
+	}
+
+	for id, c := range cases {
+		t.Run(string(id), func(t *testing.T) {
+			if e, a := c.Namespace, id.Namespace(); e != a {
+				t.Errorf("expect %q namespace, got %q", e, a)
+			}
+			if e, a := c.Name, id.Name(); e != a {
+				t.Errorf("expect %q name, got %q", e, a)
+			}
+			if e, a := c.Member, id.Member(); e != a {
+				t.Errorf("expect %q member, got %q", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Shape IDs of prelude traits.
+const (
+	TraitRequired        ShapeID = "smithy.api#required"
+	TraitDefault         ShapeID = "smithy.api#default"
+	TraitDocumentation   ShapeID = "smithy.api#documentation"
+	TraitError           ShapeID = "smithy.api#error"
+	TraitRetryable       ShapeID = "smithy.api#retryable"
+	TraitTimestampFormat ShapeID = "smithy.api#timestampFormat"
+	TraitEnumValue       ShapeID = "smithy.api#enumValue"
+	TraitJSONName        ShapeID = "smithy.api#jsonName"
+	TraitHTTP            ShapeID = "smithy.api#http"
+	TraitHTTPError       ShapeID = "smithy.api#httpError"
+	TraitIdempotent      ShapeID = "smithy.api#idempotent"
+	TraitReadonly        ShapeID = "smithy.api#readonly"
+	TraitSensitive       ShapeID = "smithy.api#sensitive"
+	TraitDeprecated      ShapeID = "smithy.api#deprecated"
+	TraitMixin           ShapeID = "smithy.api#mixin"
+)
+
+// Traits are the traits applied to a shape or member, by the absolute shape
+// ID of the trait, with the trait's JSON AST value.
+type Traits map[ShapeID]json.RawMessage
+
+// Has returns whether the trait is applied.
+func (t Traits) Has(id ShapeID) bool {
+	_, ok := t[id]
+	return ok
+}
+
+// Decode decodes the value of the trait into v, returning false if the trait
+// is not applied.
+func (t Traits) Decode(id ShapeID, v interface{}) (bool, error) {
+	raw, ok := t[id]
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return true, fmt.Errorf("failed to decode trait %s, %w", id, err)
+	}
+	return true, nil
+}
+
+// String returns the value of a string valued trait, e.g. the
+// documentation trait. Returns false if the trait is not applied, or its
+// value is not a string.
+func (t Traits) String(id ShapeID) (string, bool) {
+	var v string
+	if ok, err := t.Decode(id, &v); !ok || err != nil {
+		return "", false
+	}
+	return v, true
+}
+
+// HTTPTrait is the value of the http trait of an operation.
+type HTTPTrait struct {
+	Method string `json:"method"`
+	URI    string `json:"uri"`
+	Code   int    `json:"code"`
+}
+
+// HTTP returns the value of the http trait, with the code defaulted to 200.
+// Returns false if the trait is not applied.
+func (t Traits) HTTP() (HTTPTrait, bool, error) {
+	v := HTTPTrait{Code: 200}
+	ok, err := t.Decode(TraitHTTP, &v)
+	return v, ok, err
+}
+
+func (t Traits) clone() Traits {
+	if t == nil {
+		return nil
+	}
+	c := make(Traits, len(t))
+	for k, v := range t {
+		c[k] = v
+	}
+	return c
+}
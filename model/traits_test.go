@@ -0,0 +1,47 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTraits(t *testing.T) {
+	traits := Traits{
+		TraitDocumentation: json.RawMessage(`"A city."`),
+		TraitRequired:      json.RawMessage(`{}`),
+		TraitHTTP:          json.RawMessage(`{"method": "GET", "uri": "/cities/{cityId}"}`),
+		TraitError:         json.RawMessage(`{`),
+	}
+
+	if !traits.Has(TraitRequired) {
+		t.Errorf("expect required trait")
+	}
+	if traits.Has(TraitSensitive) {
+		t.Errorf("expect no sensitive trait")
+	}
+
+	if v, ok := traits.String(TraitDocumentation); !ok || v != "A city." {
+		t.Errorf("expect documentation trait, got %q, %v", v, ok)
+	}
+	if _, ok := traits.String(TraitRequired); ok {
+		t.Errorf("expect non-string trait not to be returned")
+	}
+	if _, ok := traits.String(TraitSensitive); ok {
+		t.Errorf("expect missing trait not to be returned")
+	}
+
+	if ok, err := traits.Decode(TraitError, new(string)); !ok || err == nil {
+		t.Errorf("expect decode error for invalid trait value, got %v, %v", ok, err)
+	}
+
+	http, ok, err := traits.HTTP()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if !ok {
+		t.Fatalf("expect http trait")
+	}
+	if e, a := (HTTPTrait{Method: "GET", URI: "/cities/{cityId}", Code: 200}), http; e != a {
+		t.Errorf("expect %v http trait, got %v", e, a)
+	}
+}
@@ -0,0 +1,164 @@
+package smithy
+
+import "github.com/aws/smithy-go/logging"
+
+// PropertiesReader provides an interface for reading properties from the
+// underlying properties container.
+type PropertiesReader interface {
+	Get(key interface{}) interface{}
+	Has(key interface{}) bool
+}
+
+// Properties provides storing and reading arbitrary key/value pairs. Keys may
+// be any comparable value type. Get and Set will panic if key is not a
+// comparable value type.
+//
+// Features sharing a Properties bag should use PropertyKey values, which are
+// scoped to a namespace, to avoid collisions between keys of the same name.
+// Keys declared by packages should be registered with RegisterPropertyKey to
+// detect collisions with the keys of other packages.
+//
+// Properties uses lazy initialization, and Set method must be called as an
+// addressable value, or pointer. Properties is not safe for concurrent use.
+type Properties struct {
+	values map[interface{}]interface{}
+}
+
+var _ PropertiesReader = (*Properties)(nil)
+
+// Get attempts to retrieve the value the key points to. Returns nil if the
+// key was not found.
+func (p *Properties) Get(key interface{}) interface{} {
+	return p.values[key]
+}
+
+// Set stores the value pointed to by the key. If a value already exists at
+// that key it will be replaced with the new value. If key debugging is
+// enabled, logs the replacement, see SetKeyDebugLogger.
+func (p *Properties) Set(key, value interface{}) {
+	if p.values == nil {
+		p.values = map[interface{}]interface{}{}
+	}
+	if logger := getKeyDebugLogger(); logger != nil {
+		if prev, ok := p.values[key]; ok {
+			logger.Logf(logging.Debug, "property key %v overwritten, %v replaced by %v", key, prev, value)
+		}
+	}
+	p.values[key] = value
+}
+
+// Has returns if the key exists in the properties.
+func (p *Properties) Has(key interface{}) bool {
+	_, ok := p.values[key]
+	return ok
+}
+
+// Values returns a shallow copy of the key/value pairs in the properties.
+func (p *Properties) Values() map[interface{}]interface{} {
+	vs := make(map[interface{}]interface{}, len(p.values))
+	for k, v := range p.values {
+		vs[k] = v
+	}
+	return vs
+}
+
+// SetAll merges the key/value pairs of other into the properties. Values in
+// other replace existing values of the same key. A nil other is ignored.
+func (p *Properties) SetAll(other *Properties) {
+	if other == nil {
+		return
+	}
+	for k, v := range other.values {
+		p.Set(k, v)
+	}
+}
+
+// SetAllMissing merges the key/value pairs of other into the properties,
+// retaining existing values of the same key. A nil other is ignored.
+func (p *Properties) SetAllMissing(other *Properties) {
+	if other == nil {
+		return
+	}
+	for k, v := range other.values {
+		if !p.Has(k) {
+			p.Set(k, v)
+		}
+	}
+}
+
+// Snapshot returns an immutable copy of the properties. Subsequent changes to
+// the properties are not reflected in the snapshot.
+func (p *Properties) Snapshot() PropertiesSnapshot {
+	return PropertiesSnapshot{values: p.Values()}
+}
+
+// PropertiesSnapshot is an immutable, read-only view of a Properties bag. It is
+// safe for concurrent use, and is intended to be passed to resolvers which
+// must not modify the properties they are given.
+type PropertiesSnapshot struct {
+	values map[interface{}]interface{}
+}
+
+var _ PropertiesReader = PropertiesSnapshot{}
+
+// Get attempts to retrieve the value the key points to. Returns nil if the
+// key was not found.
+func (s PropertiesSnapshot) Get(key interface{}) interface{} {
+	return s.values[key]
+}
+
+// Has returns if the key exists in the snapshot.
+func (s PropertiesSnapshot) Has(key interface{}) bool {
+	_, ok := s.values[key]
+	return ok
+}
+
+// Properties returns a mutable copy of the snapshot.
+func (s PropertiesSnapshot) Properties() Properties {
+	p := Properties{values: make(map[interface{}]interface{}, len(s.values))}
+	for k, v := range s.values {
+		p.values[k] = v
+	}
+	return p
+}
+
+// PropertyKey is a typed key for a value in a Properties bag, scoped to a
+// namespace. Two keys of the same name in different namespaces, or of
+// different value types, do not collide.
+type PropertyKey[T any] struct {
+	namespace string
+	name      string
+}
+
+// NewPropertyKey returns a PropertyKey for the name within the namespace.
+// The namespace should identify the feature that owns the key, e.g.
+// "smithy.auth".
+func NewPropertyKey[T any](namespace, name string) PropertyKey[T] {
+	return PropertyKey[T]{namespace: namespace, name: name}
+}
+
+// String returns the namespace qualified name of the key.
+func (k PropertyKey[T]) String() string {
+	if len(k.namespace) == 0 {
+		return k.name
+	}
+	return k.namespace + "." + k.name
+}
+
+// Get returns the value of the key in the properties, and whether a value of
+// the key's type was present.
+func (k PropertyKey[T]) Get(p PropertiesReader) (v T, ok bool) {
+	return GetProperty[T](p, k)
+}
+
+// Set stores the value at the key in the properties.
+func (k PropertyKey[T]) Set(p *Properties, v T) {
+	p.Set(k, v)
+}
+
+// GetProperty returns the value of key in the properties as type T, and
+// whether a value of that type was present.
+func GetProperty[T any](p PropertiesReader, key interface{}) (v T, ok bool) {
+	v, ok = p.Get(key).(T)
+	return v, ok
+}
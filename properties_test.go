@@ -0,0 +1,88 @@
+package smithy
+
+import "testing"
+
+func TestPropertyKey(t *testing.T) {
+	authKey := NewPropertyKey[string]("smithy.auth", "region")
+	endpointKey := NewPropertyKey[string]("smithy.endpoint", "region")
+	countKey := NewPropertyKey[int]("smithy.auth", "region")
+
+	var p Properties
+	authKey.Set(&p, "us-west-2")
+	endpointKey.Set(&p, "eu-west-1")
+
+	if v, ok := authKey.Get(&p); !ok || v != "us-west-2" {
+		t.Errorf("expect us-west-2 auth region, got %v, %v", v, ok)
+	}
+	if v, ok := endpointKey.Get(&p); !ok || v != "eu-west-1" {
+		t.Errorf("expect eu-west-1 endpoint region, got %v, %v", v, ok)
+	}
+	if _, ok := countKey.Get(&p); ok {
+		t.Errorf("expect keys of different type to not collide")
+	}
+	if e, a := "smithy.auth.region", authKey.String(); e != a {
+		t.Errorf("expect %v key name, got %v", e, a)
+	}
+}
+
+func TestGetProperty(t *testing.T) {
+	var p Properties
+	p.Set("foo", 1)
+
+	if v, ok := GetProperty[int](&p, "foo"); !ok || v != 1 {
+		t.Errorf("expect 1, got %v, %v", v, ok)
+	}
+	if v, ok := GetProperty[string](&p, "foo"); ok {
+		t.Errorf("expect type mismatch to not be ok, got %v", v)
+	}
+	if _, ok := GetProperty[int](&p, "bar"); ok {
+		t.Errorf("expect missing key to not be ok")
+	}
+}
+
+func TestPropertiesMerge(t *testing.T) {
+	var a, b Properties
+	a.Set("shared", "a")
+	a.Set("onlyA", "a")
+	b.Set("shared", "b")
+	b.Set("onlyB", "b")
+
+	all := a.Snapshot().Properties()
+	all.SetAll(&b)
+	if e, a := "b", all.Get("shared"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	missing := a.Snapshot().Properties()
+	missing.SetAllMissing(&b)
+	if e, a := "a", missing.Get("shared"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	for _, p := range []*Properties{&all, &missing} {
+		if !p.Has("onlyA") || !p.Has("onlyB") {
+			t.Errorf("expect merged properties to have all keys, got %v", p.Values())
+		}
+	}
+	all.SetAll(nil)
+	missing.SetAllMissing(nil)
+	if e, a := 3, len(all.Values()); e != a {
+		t.Errorf("expect %v properties after nil merge, got %v", e, a)
+	}
+}
+
+func TestPropertiesSnapshot(t *testing.T) {
+	var p Properties
+	p.Set("foo", "bar")
+
+	snapshot := p.Snapshot()
+	p.Set("foo", "baz")
+	p.Set("qux", "quux")
+
+	if e, a := "bar", snapshot.Get("foo"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if snapshot.Has("qux") {
+		t.Errorf("expect snapshot to not reflect later changes")
+	}
+}
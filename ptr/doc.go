@@ -1,4 +1,8 @@
 // Package ptr provides utilities for converting scalar literal type values to and from pointers inline.
+//
+// The generic To, Deref, and DerefOr functions work with values of any type.
+// The per-type helpers, e.g. String and ToString, are wrappers of the generic
+// functions kept for compatibility.
 package ptr
 
 //go:generate go run -tags codegen generate.go
@@ -1,3 +1,4 @@
+//go:build ignore
 // +build ignore
 
 package main
@@ -71,7 +72,7 @@ var ptrTmpl = template.Must(template.New("ptrTmpl").Parse(`
 {{- define "to pointer func" }}
 	// {{ $.Name }} returns a pointer value for the {{ $.Symbol }} value passed in.
 	func {{ $.Name }}(v {{ $.Symbol }}) *{{ $.Symbol }} {
-		return &v
+		return To(v)
 	}
 {{- end }}
 
@@ -79,25 +80,13 @@ var ptrTmpl = template.Must(template.New("ptrTmpl").Parse(`
 	// {{ $.Name }}Slice returns a slice of {{ $.Symbol }} pointers from the values
 	// passed in.
 	func {{ $.Name }}Slice(vs []{{ $.Symbol }}) []*{{ $.Symbol }} {
-		ps := make([]*{{ $.Symbol }}, len(vs))
-		for i, v := range vs {
-			vv := v
-			ps[i] = &vv
-		}
-
-		return ps
+		return ToSlice(vs)
 	}
 
 	// {{ $.Name }}Map returns a map of {{ $.Symbol }} pointers from the values
 	// passed in.
 	func {{ $.Name }}Map(vs map[string]{{ $.Symbol }}) map[string]*{{ $.Symbol }} {
-		ps := make(map[string]*{{ $.Symbol }}, len(vs))
-		for k, v := range vs {
-			vv := v
-			ps[k] = &vv
-		}
-
-		return ps
+		return ToMap(vs)
 	}
 {{- end }}
 
@@ -105,12 +94,8 @@ var ptrTmpl = template.Must(template.New("ptrTmpl").Parse(`
 	// To{{ $.Name }} returns {{ $.Symbol }} value dereferenced if the passed
 	// in pointer was not nil. Returns a {{ $.Symbol }} zero value if the
 	// pointer was nil.
-	func To{{ $.Name }}(p *{{ $.Symbol }}) (v {{ $.Symbol }}) {
-		if p == nil {
-			return v
-		}
-			
-		return *p
+	func To{{ $.Name }}(p *{{ $.Symbol }}) {{ $.Symbol }} {
+		return Deref(p)
 	}
 {{- end }}
 
@@ -119,24 +104,14 @@ var ptrTmpl = template.Must(template.New("ptrTmpl").Parse(`
 	// dereferenced if the passed in pointer was not nil. Returns a {{ $.Symbol }}
 	// zero value if the pointer was nil.
 	func To{{ $.Name }}Slice(vs []*{{ $.Symbol }}) []{{ $.Symbol }} {
-		ps := make([]{{ $.Symbol }}, len(vs))
-		for i, v := range vs {
-			ps[i] = To{{ $.Name }}(v)
-		}
-
-		return ps
+		return DerefSlice(vs)
 	}
 
 	// To{{ $.Name }}Map returns a map of {{ $.Symbol }} values, that are
 	// dereferenced if the passed in pointer was not nil. The {{ $.Symbol }}
 	// zero value is used if the pointer was nil.
 	func To{{ $.Name }}Map(vs map[string]*{{ $.Symbol }}) map[string]{{ $.Symbol }} {
-		ps := make(map[string]{{ $.Symbol }}, len(vs))
-		for k, v := range vs {
-			ps[k] = To{{ $.Name }}(v)
-		}
-
-		return ps
+		return DerefMap(vs)
 	}
 {{- end }}
 `))
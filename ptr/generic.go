@@ -0,0 +1,80 @@
+package ptr
+
+// To returns a pointer value for the value passed in.
+func To[T any](v T) *T {
+	return &v
+}
+
+// ToOrNil returns a pointer value for the value passed in, or nil if the
+// value is the zero value of its type.
+func ToOrNil[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+// Deref returns the value dereferenced if the passed in pointer was not nil.
+// Returns the zero value of the type if the pointer was nil.
+func Deref[T any](p *T) (v T) {
+	if p == nil {
+		return v
+	}
+	return *p
+}
+
+// DerefOr returns the value dereferenced if the passed in pointer was not
+// nil. Returns the default value passed in if the pointer was nil.
+func DerefOr[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// ToSlice returns a slice of pointers from the values passed in.
+func ToSlice[T any](vs []T) []*T {
+	ps := make([]*T, len(vs))
+	for i, v := range vs {
+		vv := v
+		ps[i] = &vv
+	}
+
+	return ps
+}
+
+// DerefSlice returns a slice of values, that are dereferenced if the passed
+// in pointer was not nil. Returns the zero value of the type if the pointer
+// was nil.
+func DerefSlice[T any](vs []*T) []T {
+	ps := make([]T, len(vs))
+	for i, v := range vs {
+		ps[i] = Deref(v)
+	}
+
+	return ps
+}
+
+// ToMap returns a map of pointers from the values passed in.
+func ToMap[K comparable, T any](vs map[K]T) map[K]*T {
+	ps := make(map[K]*T, len(vs))
+	for k, v := range vs {
+		vv := v
+		ps[k] = &vv
+	}
+
+	return ps
+}
+
+// DerefMap returns a map of values, that are dereferenced if the passed in
+// pointer was not nil. The zero value of the type is used if the pointer was
+// nil.
+func DerefMap[K comparable, T any](vs map[K]*T) map[K]T {
+	ps := make(map[K]T, len(vs))
+	for k, v := range vs {
+		ps[k] = Deref(v)
+	}
+
+	return ps
+}
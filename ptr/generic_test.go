@@ -0,0 +1,67 @@
+package ptr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTo(t *testing.T) {
+	if e, a := 1, *To(1); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := "abc", *To("abc"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestToOrNil(t *testing.T) {
+	if v := ToOrNil(0); v != nil {
+		t.Errorf("expect nil, got %v", *v)
+	}
+	if v := ToOrNil(""); v != nil {
+		t.Errorf("expect nil, got %v", *v)
+	}
+	if v := ToOrNil(2); v == nil || *v != 2 {
+		t.Errorf("expect 2, got %v", v)
+	}
+}
+
+func TestDeref(t *testing.T) {
+	if e, a := 0, Deref[int](nil); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := 3, Deref(To(3)); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := "default", DerefOr(nil, "default"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := "value", DerefOr(To("value"), "default"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestSlice(t *testing.T) {
+	ps := ToSlice([]string{"a", "b"})
+	if e, a := 2, len(ps); e != a {
+		t.Fatalf("expect %v pointers, got %v", e, a)
+	}
+	if ps[0] == ps[1] {
+		t.Errorf("expect distinct pointers")
+	}
+
+	vs := DerefSlice(append(ps, nil))
+	if e, a := []string{"a", "b", ""}, vs; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestMap(t *testing.T) {
+	ps := ToMap(map[string]int{"a": 1, "b": 2})
+	ps["c"] = nil
+
+	vs := DerefMap(ps)
+	if e, a := map[string]int{"a": 1, "b": 2, "c": 0}, vs; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
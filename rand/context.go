@@ -0,0 +1,53 @@
+package rand
+
+import (
+	"context"
+	mathrand "math/rand"
+	"sync"
+)
+
+type entropySourceKey struct{}
+
+// WithEntropySource returns a context with the random source that should be
+// used by operations invoked with the context, e.g. for retry jitter, waiter
+// delays, and idempotency tokens.
+func WithEntropySource(ctx context.Context, src EntropySource) context.Context {
+	return context.WithValue(ctx, entropySourceKey{}, src)
+}
+
+// GetEntropySource returns the random source set on the context with
+// WithEntropySource. If none was set, Reader is returned.
+func GetEntropySource(ctx context.Context) EntropySource {
+	if src, ok := ctx.Value(entropySourceKey{}).(EntropySource); ok && src != nil {
+		return src
+	}
+	return Reader
+}
+
+// ContextInt63n returns a int64 between zero and value of max, read from the
+// random source of the context.
+func ContextInt63n(ctx context.Context, max int64) (int64, error) {
+	return Int63n(GetEntropySource(ctx), max)
+}
+
+// NewSeededReader returns a deterministic, non-cryptographic random source
+// that produces the same sequence of bytes for the same seed. Intended for
+// tests of retry and waiter behavior that must be reproducible.
+//
+// The returned reader is safe for concurrent use, though concurrent readers
+// will observe a nondeterministic interleaving of the sequence.
+func NewSeededReader(seed int64) EntropySource {
+	return &seededReader{rand: mathrand.New(mathrand.NewSource(seed))}
+}
+
+type seededReader struct {
+	mu   sync.Mutex
+	rand *mathrand.Rand
+}
+
+func (r *seededReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.rand.Read(p)
+}
@@ -0,0 +1,43 @@
+package rand_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/aws/smithy-go/rand"
+)
+
+func TestGetEntropySource(t *testing.T) {
+	if e, a := rand.Reader, rand.GetEntropySource(context.Background()); e != a {
+		t.Errorf("expect default %v reader, got %v", e, a)
+	}
+
+	src := bytes.NewReader(make([]byte, 8))
+	ctx := rand.WithEntropySource(context.Background(), src)
+	if e, a := rand.EntropySource(src), rand.GetEntropySource(ctx); e != a {
+		t.Errorf("expect %v reader, got %v", e, a)
+	}
+}
+
+func TestSeededReader(t *testing.T) {
+	read := func(seed int64) []int64 {
+		ctx := rand.WithEntropySource(context.Background(), rand.NewSeededReader(seed))
+		var vs []int64
+		for i := 0; i < 5; i++ {
+			v, err := rand.ContextInt63n(ctx, 1000)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			vs = append(vs, v)
+		}
+		return vs
+	}
+
+	a, b := read(42), read(42)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("expect same sequence for same seed, got %v and %v", a, b)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package rand
+
+import (
+	"bufio"
+	"crypto/rand"
+	"io"
+	"sync"
+)
+
+// EntropySource is a source of random bytes that UUIDs, idempotency tokens,
+// and random integers are generated from. Any io.Reader may be used as an
+// EntropySource, e.g. to provide a deterministic source in tests.
+type EntropySource = io.Reader
+
+// defaultPoolBufferSize is the size of the buffers used by the pooled crypto
+// random reader, enough for 256 UUIDs per read of the underlying source.
+const defaultPoolBufferSize = 4096
+
+var pooledCryptoReader = NewPooledReader(rand.Reader, defaultPoolBufferSize)
+
+// NewPooledReader returns a reader that reads from src in chunks of size
+// bytes, buffering the remainder for subsequent reads. Buffers are pooled so
+// that concurrent readers do not contend on a single buffer, reducing the
+// number of reads of src, and allocations, for callers that read many small
+// values, e.g. UUIDs.
+//
+// The returned reader is safe for concurrent use if src is.
+func NewPooledReader(src EntropySource, size int) io.Reader {
+	return &pooledReader{
+		pool: sync.Pool{
+			New: func() interface{} {
+				return bufio.NewReaderSize(src, size)
+			},
+		},
+	}
+}
+
+type pooledReader struct {
+	pool sync.Pool
+}
+
+func (p *pooledReader) Read(b []byte) (int, error) {
+	r := p.pool.Get().(*bufio.Reader)
+	defer p.pool.Put(r)
+
+	return io.ReadFull(r, b)
+}
+
+// defaultEntropySource returns the pooled crypto random reader if Reader has
+// not been replaced, otherwise Reader.
+func defaultEntropySource() EntropySource {
+	if Reader == rand.Reader {
+		return pooledCryptoReader
+	}
+	return Reader
+}
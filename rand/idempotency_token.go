@@ -0,0 +1,44 @@
+package rand
+
+import (
+	"encoding/binary"
+	"sync/atomic"
+)
+
+// IdempotencyTokenProvider provides the tokens used to fill operation input
+// members with the Smithy idempotencyToken trait that were not set by the
+// caller.
+type IdempotencyTokenProvider interface {
+	GetIdempotencyToken() (string, error)
+}
+
+var _ IdempotencyTokenProvider = (*UUIDIdempotencyToken)(nil)
+
+// NewDefaultIdempotencyToken returns the default idempotency token provider,
+// returning random UUID version 4 tokens read from Reader. If Reader is the
+// crypto/rand reader, reads are buffered with a pooled reader.
+func NewDefaultIdempotencyToken() *UUIDIdempotencyToken {
+	return NewUUIDIdempotencyToken(defaultEntropySource())
+}
+
+// DeterministicIdempotencyToken is an idempotency token provider returning a
+// predictable sequence of tokens in the UUID format, for use in tests. The
+// token sequence is derived from the Seed, with the first token of a zero
+// Seed being "00000000-0000-4000-8000-000000000001".
+//
+// DeterministicIdempotencyToken is safe for concurrent use.
+type DeterministicIdempotencyToken struct {
+	Seed uint64
+
+	counter uint64
+}
+
+// GetIdempotencyToken returns the next token in the sequence.
+func (d *DeterministicIdempotencyToken) GetIdempotencyToken() (string, error) {
+	n := atomic.AddUint64(&d.counter, 1)
+
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[0:8], d.Seed)
+	binary.BigEndian.PutUint64(b[8:16], n)
+	return uuidVersion4(b), nil
+}
@@ -3,6 +3,7 @@ package rand
 import (
 	"encoding/hex"
 	"io"
+	"time"
 )
 
 const dash byte = '-'
@@ -24,6 +25,17 @@ func (u UUIDIdempotencyToken) GetIdempotencyToken() (string, error) {
 	return u.uuid.GetUUID()
 }
 
+// UUIDSource is implemented by types that provide UUID string values, e.g.
+// UUID and UUIDv7.
+type UUIDSource interface {
+	GetUUID() (string, error)
+}
+
+var (
+	_ UUIDSource = (*UUID)(nil)
+	_ UUIDSource = (*UUIDv7)(nil)
+)
+
 // UUID provides computing random UUID version 4 values from a random source
 // reader.
 type UUID struct {
@@ -47,6 +59,59 @@ func (r *UUID) GetUUID() (string, error) {
 	return uuidVersion4(b), nil
 }
 
+// UUIDv7 provides computing time-ordered UUID version 7 values from a random
+// source reader. UUIDs generated within the same millisecond are ordered by
+// their random bits, and are not guaranteed to be monotonic.
+type UUIDv7 struct {
+	randSrc io.Reader
+
+	// Now returns the current time. Defaults to time.Now if nil.
+	Now func() time.Time
+}
+
+// NewUUIDv7 returns an initialized UUIDv7 value that can be used to retrieve
+// time-ordered UUID values.
+func NewUUIDv7(r io.Reader) *UUIDv7 {
+	return &UUIDv7{randSrc: r}
+}
+
+// GetUUID returns a UUID version 7 string with the current unix time in
+// milliseconds, and random bits sourced from the random reader the UUIDv7 was
+// created with. Returns an error if unable to compute the UUID.
+func (r *UUIDv7) GetUUID() (string, error) {
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+
+	var b [16]byte
+	if _, err := io.ReadFull(r.randSrc, b[6:]); err != nil {
+		return "", err
+	}
+
+	return uuidVersion7(b, now()), nil
+}
+
+// uuidVersion7 returns a UUID version 7 from the time and random bytes in
+// the byte slice provided.
+func uuidVersion7(u [16]byte, t time.Time) string {
+	// https://www.rfc-editor.org/rfc/rfc9562#name-uuid-version-7
+
+	// 48 bit big-endian unix timestamp in milliseconds
+	ms := uint64(t.UnixNano() / int64(time.Millisecond))
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+
+	u[6] = (u[6] & 0x0f) | 0x70 // Version 7
+	u[8] = (u[8] & 0x3f) | 0x80 // Variant is 10
+
+	return formatUUID(u)
+}
+
 // uuidVersion4 returns a random UUID version 4 from the byte slice provided.
 func uuidVersion4(u [16]byte) string {
 	// https://en.wikipedia.org/wiki/Universally_unique_identifier#Version_4_.28random.29
@@ -56,6 +121,11 @@ func uuidVersion4(u [16]byte) string {
 	// 17th character is "8", "9", "a", or "b"
 	u[8] = (u[8] & 0x3f) | 0x80 // Variant is 10
 
+	return formatUUID(u)
+}
+
+// formatUUID returns the canonical string format of the UUID bytes.
+func formatUUID(u [16]byte) string {
 	var scratch [36]byte
 
 	hex.Encode(scratch[:8], u[0:4])
@@ -2,7 +2,10 @@ package rand_test
 
 import (
 	"bytes"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/aws/smithy-go/rand"
 )
@@ -31,3 +34,105 @@ func TestUUID(t *testing.T) {
 		t.Errorf("expect %v, got %v", e, a)
 	}
 }
+
+func TestDeterministicIdempotencyToken(t *testing.T) {
+	provider := &rand.DeterministicIdempotencyToken{Seed: 1}
+
+	for _, expect := range []string{
+		"00000000-0000-4001-8000-000000000001",
+		"00000000-0000-4001-8000-000000000002",
+	} {
+		v, err := provider.GetIdempotencyToken()
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		if e, a := expect, v; e != a {
+			t.Errorf("expect %v, got %v", e, a)
+		}
+	}
+}
+
+func TestUUIDv7(t *testing.T) {
+	randSrc := make([]byte, 20)
+	for i := 10; i < len(randSrc); i++ {
+		randSrc[i] = 0xff
+	}
+
+	uuid := rand.NewUUIDv7(bytes.NewReader(randSrc))
+	uuid.Now = func() time.Time {
+		return time.Unix(0, 0x017F22E279B0*int64(time.Millisecond))
+	}
+
+	v, err := uuid.GetUUID()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := `017f22e2-79b0-7000-8000-000000000000`, v; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	v, err = uuid.GetUUID()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := `017f22e2-79b0-7fff-bfff-ffffffffffff`, v; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestUUIDv7_Ordered(t *testing.T) {
+	uuid := rand.NewUUIDv7(rand.Reader)
+
+	start := time.Now()
+	uuid.Now = func() time.Time { return start }
+	first, err := uuid.GetUUID()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	uuid.Now = func() time.Time { return start.Add(time.Millisecond) }
+	second, err := uuid.GetUUID()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if first >= second {
+		t.Errorf("expect %v to sort before %v", first, second)
+	}
+}
+
+func TestPooledReader(t *testing.T) {
+	src := &countingReader{}
+	r := rand.NewPooledReader(src, 64)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			uuid := rand.NewUUID(r)
+			for j := 0; j < 16; j++ {
+				if _, err := uuid.GetUUID(); err != nil {
+					t.Errorf("expect no error, got %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reads := atomic.LoadInt64(&src.reads); reads >= 8*16 {
+		t.Errorf("expect fewer source reads than UUIDs, got %v", reads)
+	}
+}
+
+type countingReader struct {
+	reads int64
+}
+
+func (r *countingReader) Read(b []byte) (int, error) {
+	atomic.AddInt64(&r.reads, 1)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	return len(b), nil
+}
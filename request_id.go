@@ -0,0 +1,44 @@
+package smithy
+
+import "errors"
+
+// requestIDKey is the metadata key for the request ID of an operation's
+// response.
+type requestIDKey struct{}
+
+// MetadataGetter is the interface of the metadata types, e.g. the middleware
+// Metadata, that values can be retrieved from by key.
+type MetadataGetter interface {
+	Get(key interface{}) interface{}
+}
+
+// MetadataSetter is the interface of the metadata types, e.g. the middleware
+// Metadata, that values can be set on by key.
+type MetadataSetter interface {
+	Set(key, value interface{})
+}
+
+// GetRequestID returns the request ID of the response the first
+// MetadataError in err's chain was deserialized from, and whether one was
+// found.
+func GetRequestID(err error) (string, bool) {
+	var v MetadataError
+	if !errors.As(err, &v) {
+		return "", false
+	}
+	id := v.ErrorMetadata().RequestID
+	return id, len(id) != 0
+}
+
+// GetMetadataRequestID retrieves the request ID from the operation's result
+// metadata, returning the request ID and whether it was present.
+func GetMetadataRequestID(metadata MetadataGetter) (string, bool) {
+	v, ok := metadata.Get(requestIDKey{}).(string)
+	return v, ok
+}
+
+// SetMetadataRequestID sets the request ID on the operation's result
+// metadata.
+func SetMetadataRequestID(metadata MetadataSetter, id string) {
+	metadata.Set(requestIDKey{}, id)
+}
@@ -0,0 +1,40 @@
+package smithy
+
+import (
+	"fmt"
+	"testing"
+)
+
+type mockMetadata map[interface{}]interface{}
+
+func (m mockMetadata) Get(key interface{}) interface{} { return m[key] }
+func (m mockMetadata) Set(key, value interface{})      { m[key] = value }
+
+func TestGetRequestID(t *testing.T) {
+	err := fmt.Errorf("wrapped, %w", &GenericAPIError{
+		Code:     "FooError",
+		Metadata: ErrorMetadata{RequestID: "abc123"},
+	})
+	if id, ok := GetRequestID(err); !ok || id != "abc123" {
+		t.Errorf("expect abc123 request ID, got %q, %v", id, ok)
+	}
+
+	if _, ok := GetRequestID(&GenericAPIError{Code: "FooError"}); ok {
+		t.Errorf("expect no request ID")
+	}
+	if _, ok := GetRequestID(fmt.Errorf("other")); ok {
+		t.Errorf("expect no request ID")
+	}
+}
+
+func TestMetadataRequestID(t *testing.T) {
+	md := mockMetadata{}
+	if _, ok := GetMetadataRequestID(md); ok {
+		t.Errorf("expect no request ID")
+	}
+
+	SetMetadataRequestID(md, "abc123")
+	if id, ok := GetMetadataRequestID(md); !ok || id != "abc123" {
+		t.Errorf("expect abc123 request ID, got %q, %v", id, ok)
+	}
+}
@@ -0,0 +1,32 @@
+package smithy
+
+import "time"
+
+// RetryHint is the guidance a service provided in its response about
+// whether, and when, the request may be retried. Retry strategies may use the
+// hint to honor the service's requested delay instead of their own backoff.
+type RetryHint struct {
+	// RetryAfter is the minimum delay the service requested before the
+	// request is retried. Zero if the service did not request a delay.
+	RetryAfter time.Duration
+
+	// Throttle is whether the response indicates the request was throttled.
+	Throttle bool
+}
+
+// retryHintKey is the metadata key for the retry hint of an operation's
+// response.
+type retryHintKey struct{}
+
+// GetMetadataRetryHint retrieves the retry hint from the operation's result
+// metadata, returning the hint and whether it was present.
+func GetMetadataRetryHint(metadata MetadataGetter) (RetryHint, bool) {
+	v, ok := metadata.Get(retryHintKey{}).(RetryHint)
+	return v, ok
+}
+
+// SetMetadataRetryHint sets the retry hint on the operation's result
+// metadata.
+func SetMetadataRetryHint(metadata MetadataSetter, hint RetryHint) {
+	metadata.Set(retryHintKey{}, hint)
+}
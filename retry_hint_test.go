@@ -0,0 +1,25 @@
+package smithy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMetadataRetryHint(t *testing.T) {
+	md := mockMetadata{}
+	if _, ok := GetMetadataRetryHint(md); ok {
+		t.Errorf("expect no retry hint")
+	}
+
+	SetMetadataRetryHint(md, RetryHint{RetryAfter: 2 * time.Second, Throttle: true})
+	hint, ok := GetMetadataRetryHint(md)
+	if !ok {
+		t.Fatalf("expect retry hint")
+	}
+	if e, a := 2*time.Second, hint.RetryAfter; e != a {
+		t.Errorf("expect %v retry after, got %v", e, a)
+	}
+	if !hint.Throttle {
+		t.Errorf("expect throttle hint")
+	}
+}
@@ -0,0 +1,246 @@
+package smithy
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// RedactedValue is the rendering of values of members, and shapes, with the
+// Smithy sensitive trait.
+const RedactedValue = "***"
+
+// DisableRedactionEnvVar is the environment variable which, when set to
+// "true", disables redaction of sensitive values by Redact. Intended only
+// for trusted debugging environments.
+const DisableRedactionEnvVar = "SMITHY_GO_DISABLE_REDACTION"
+
+var redactionDisabled int32
+
+func init() {
+	if strings.EqualFold(os.Getenv(DisableRedactionEnvVar), "true") {
+		redactionDisabled = 1
+	}
+}
+
+// SetRedactionDisabled sets whether redaction of sensitive values is
+// disabled, overriding DisableRedactionEnvVar. Sensitive values will be
+// rendered as is in String output, logs, and error messages when disabled,
+// so redaction should only be disabled in trusted debugging environments.
+func SetRedactionDisabled(disabled bool) {
+	var v int32
+	if disabled {
+		v = 1
+	}
+	atomic.StoreInt32(&redactionDisabled, v)
+}
+
+// IsRedactionDisabled returns whether redaction of sensitive values is
+// disabled.
+func IsRedactionDisabled() bool {
+	return atomic.LoadInt32(&redactionDisabled) == 1
+}
+
+type sensitiveType struct {
+	all    bool
+	fields map[string]bool
+}
+
+var sensitiveRegistry = struct {
+	sync.RWMutex
+	types map[reflect.Type]sensitiveType
+}{
+	types: map[reflect.Type]sensitiveType{},
+}
+
+// RegisterSensitiveMembers registers the struct fields of the type of v
+// which are members with the sensitive trait, or targeting a shape with the
+// sensitive trait. Called by generated code for each type with sensitive
+// members. v may be a value or pointer of the type.
+func RegisterSensitiveMembers(v interface{}, fields ...string) {
+	t := indirectType(reflect.TypeOf(v))
+
+	sensitiveRegistry.Lock()
+	defer sensitiveRegistry.Unlock()
+
+	s := sensitiveRegistry.types[t]
+	if s.fields == nil {
+		s.fields = map[string]bool{}
+	}
+	for _, f := range fields {
+		s.fields[f] = true
+	}
+	sensitiveRegistry.types[t] = s
+}
+
+// RegisterSensitiveShape registers the type of v as the type of a shape with
+// the sensitive trait, whose values are redacted entirely. Called by
+// generated code. v may be a value or pointer of the type.
+func RegisterSensitiveShape(v interface{}) {
+	t := indirectType(reflect.TypeOf(v))
+
+	sensitiveRegistry.Lock()
+	defer sensitiveRegistry.Unlock()
+
+	s := sensitiveRegistry.types[t]
+	s.all = true
+	sensitiveRegistry.types[t] = s
+}
+
+func lookupSensitive(t reflect.Type) (sensitiveType, bool) {
+	sensitiveRegistry.RLock()
+	defer sensitiveRegistry.RUnlock()
+
+	s, ok := sensitiveRegistry.types[t]
+	return s, ok
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// Redact returns the rendering of v, similar to the %+v verb of fmt, with
+// the values of registered sensitive members and shapes replaced by
+// RedactedValue. Pointers are rendered as the value they point to.
+//
+// Generated types with sensitive members implement fmt.Stringer with
+// Redact, and it may be used to log operation inputs and outputs.
+func Redact(v interface{}) string {
+	var sb strings.Builder
+	redactValue(&sb, reflect.ValueOf(v), !IsRedactionDisabled(), 0)
+	return sb.String()
+}
+
+// RedactString returns RedactedValue, or s if redaction is disabled. For
+// rendering a single sensitive value, e.g. in an error message.
+func RedactString(s string) string {
+	if IsRedactionDisabled() {
+		return s
+	}
+	return RedactedValue
+}
+
+// maxRedactDepth bounds the rendering of cyclic values.
+const maxRedactDepth = 32
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+func redactValue(sb *strings.Builder, v reflect.Value, redact bool, depth int) {
+	if !v.IsValid() {
+		sb.WriteString("<nil>")
+		return
+	}
+	if depth > maxRedactDepth {
+		sb.WriteString("...")
+		return
+	}
+
+	sensitive, registered := lookupSensitive(indirectType(v.Type()))
+	if redact && sensitive.all {
+		sb.WriteString(RedactedValue)
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			sb.WriteString("<nil>")
+			return
+		}
+		redactValue(sb, v.Elem(), redact, depth+1)
+
+	case reflect.Struct:
+		// Unregistered types rendering themselves, e.g. time.Time, are
+		// rendered as is. Registered types may implement fmt.Stringer with
+		// Redact, so are always rendered here.
+		if !registered && v.CanInterface() && v.Type().Implements(stringerType) {
+			sb.WriteString(v.Interface().(fmt.Stringer).String())
+			return
+		}
+
+		sb.WriteByte('{')
+		n := 0
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if len(f.PkgPath) != 0 {
+				continue
+			}
+			if n > 0 {
+				sb.WriteByte(' ')
+			}
+			n++
+			sb.WriteString(f.Name)
+			sb.WriteByte(':')
+			if redact && sensitive.fields[f.Name] && !isNilValue(v.Field(i)) {
+				sb.WriteString(RedactedValue)
+				continue
+			}
+			redactValue(sb, v.Field(i), redact, depth+1)
+		}
+		sb.WriteByte('}')
+
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			fmt.Fprintf(sb, "%v", v.Interface())
+			return
+		}
+		sb.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			redactValue(sb, v.Index(i), redact, depth+1)
+		}
+		sb.WriteByte(']')
+
+	case reflect.Map:
+		type entry struct{ key, value string }
+		entries := make([]entry, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			var k, e strings.Builder
+			redactValue(&k, iter.Key(), redact, depth+1)
+			redactValue(&e, iter.Value(), redact, depth+1)
+			entries = append(entries, entry{key: k.String(), value: e.String()})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+		sb.WriteString("map[")
+		for i, e := range entries {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(e.key)
+			sb.WriteByte(':')
+			sb.WriteString(e.value)
+		}
+		sb.WriteByte(']')
+
+	case reflect.String:
+		// Strings are quoted to distinguish them from RedactedValue.
+		sb.WriteString(strconv.Quote(v.String()))
+
+	default:
+		if v.CanInterface() {
+			fmt.Fprintf(sb, "%v", v.Interface())
+			return
+		}
+		sb.WriteString(v.String())
+	}
+}
+
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return v.IsNil()
+	}
+	return false
+}
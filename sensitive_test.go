@@ -0,0 +1,109 @@
+package smithy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type mockCredentials struct {
+	Username *string
+	Password *string
+	Tokens   []string
+}
+
+func (v mockCredentials) String() string { return Redact(v) }
+
+type mockSecret string
+
+type mockLoginInput struct {
+	Credentials *mockCredentials
+	Secrets     map[string]mockSecret
+	Time        time.Time
+	Attempts    int32
+	Payload     []byte
+	Nested      []*mockCredentials
+	hidden      string
+}
+
+func init() {
+	RegisterSensitiveMembers(&mockCredentials{}, "Password", "Tokens")
+	RegisterSensitiveShape(mockSecret(""))
+}
+
+func TestRedact(t *testing.T) {
+	username, password := "gopher", "hunter2"
+	input := &mockLoginInput{
+		Credentials: &mockCredentials{Username: &username, Password: &password, Tokens: []string{"abc"}},
+		Secrets:     map[string]mockSecret{"b": "secret", "a": "secret"},
+		Time:        time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Attempts:    3,
+		Payload:     []byte("hi"),
+		Nested:      []*mockCredentials{{Username: &username}, nil},
+		hidden:      "hidden",
+	}
+
+	cases := map[string]struct {
+		Value    interface{}
+		Disabled bool
+		Expect   string
+	}{
+		"nil": {
+			Expect: "<nil>",
+		},
+		"redacted": {
+			Value: input,
+			Expect: `{Credentials:{Username:"gopher" Password:*** Tokens:***} ` +
+				`Secrets:map["a":*** "b":***] Time:2020-01-02 03:04:05 +0000 UTC Attempts:3 Payload:[104 105] ` +
+				`Nested:[{Username:"gopher" Password:<nil> Tokens:[]} <nil>]}`,
+		},
+		"stringer": {
+			Value:  mockCredentials{Username: &username, Password: &password},
+			Expect: `{Username:"gopher" Password:*** Tokens:[]}`,
+		},
+		"sensitive shape": {
+			Value:  mockSecret("secret"),
+			Expect: RedactedValue,
+		},
+		"disabled": {
+			Value:    input.Credentials,
+			Disabled: true,
+			Expect:   `{Username:"gopher" Password:"hunter2" Tokens:["abc"]}`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			SetRedactionDisabled(c.Disabled)
+			defer SetRedactionDisabled(false)
+
+			if e, a := c.Expect, Redact(c.Value); e != a {
+				t.Errorf("expect\n%s\ngot\n%s", e, a)
+			}
+		})
+	}
+}
+
+func TestRedactStringer(t *testing.T) {
+	password := "hunter2"
+	v := mockCredentials{Password: &password}
+
+	if e, a := `{Username:<nil> Password:*** Tokens:[]}`, fmt.Sprint(v); e != a {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+}
+
+func TestRedactString(t *testing.T) {
+	if e, a := RedactedValue, RedactString("hunter2"); e != a {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+
+	SetRedactionDisabled(true)
+	defer SetRedactionDisabled(false)
+	if e, a := "hunter2", RedactString("hunter2"); e != a {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+	if !IsRedactionDisabled() {
+		t.Errorf("expect redaction disabled")
+	}
+}
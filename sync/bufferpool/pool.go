@@ -0,0 +1,210 @@
+// Package bufferpool provides a pool of reusable byte buffers, grouped by
+// size class, shared by the encoders and middleware which buffer serialized
+// request payloads. Reusing buffers across requests reduces the allocations,
+// and garbage collection pressure, of high throughput clients.
+//
+// A buffer must not be used, nor any slice of its contents retained, after
+// it is returned to the pool with Put.
+package bufferpool
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/metrics"
+)
+
+// DefaultSizeClasses are the capacities, in bytes, of the buffers of the
+// default pool.
+var DefaultSizeClasses = []int{
+	512,
+	4 << 10,
+	32 << 10,
+	256 << 10,
+	1 << 20,
+}
+
+// Options provides the configuration of a Pool.
+type Options struct {
+	// SizeClasses are the capacities of the pooled buffers. Buffers larger
+	// than the largest size class, or smaller than the smallest, are not
+	// returned to the pool. Defaults to DefaultSizeClasses.
+	SizeClasses []int
+
+	// MeterProvider is used to record the pool's get and put counts, with
+	// whether a get was served from the pool. Optional.
+	MeterProvider metrics.MeterProvider
+}
+
+// Stats are the usage counts of a Pool.
+type Stats struct {
+	// Hits is the number of gets served with a pooled buffer.
+	Hits uint64
+
+	// Misses is the number of gets which allocated a new buffer.
+	Misses uint64
+
+	// Puts is the number of buffers returned to the pool.
+	Puts uint64
+
+	// Discards is the number of buffers not returned to the pool because
+	// they were outside of the pool's size classes.
+	Discards uint64
+}
+
+// HitRate returns the fraction of gets served with a pooled buffer, zero if
+// there were no gets.
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+type sizeClass struct {
+	size int
+	pool sync.Pool
+}
+
+// Pool is a pool of byte buffers grouped by size class. A Pool is safe for
+// concurrent use.
+type Pool struct {
+	classes []*sizeClass
+
+	hits, misses, puts, discards uint64
+
+	getCounter metrics.Int64Counter
+	putCounter metrics.Int64Counter
+}
+
+// New returns a Pool configured by the functional options.
+func New(optFns ...func(*Options)) *Pool {
+	o := Options{
+		SizeClasses: DefaultSizeClasses,
+	}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	sizes := append([]int(nil), o.SizeClasses...)
+	sort.Ints(sizes)
+
+	p := &Pool{}
+	for _, size := range sizes {
+		if size > 0 {
+			p.classes = append(p.classes, &sizeClass{size: size})
+		}
+	}
+
+	if o.MeterProvider != nil {
+		meter := o.MeterProvider.Meter("github.com/aws/smithy-go/sync/bufferpool")
+		p.getCounter, _ = meter.Int64Counter("smithy.bufferpool.gets",
+			metrics.WithDescription("The number of buffers retrieved from the pool."))
+		p.putCounter, _ = meter.Int64Counter("smithy.bufferpool.puts",
+			metrics.WithDescription("The number of buffers returned to the pool."))
+	}
+	return p
+}
+
+// Get returns an empty buffer with a capacity of at least sizeHint bytes,
+// reusing a pooled buffer if one is available. Buffers larger than the
+// largest size class are allocated, and not pooled.
+func (p *Pool) Get(sizeHint int) *bytes.Buffer {
+	class := p.classFor(sizeHint)
+	if class == nil {
+		p.record(p.getCounter, "hit", false)
+		atomic.AddUint64(&p.misses, 1)
+		return bytes.NewBuffer(make([]byte, 0, sizeHint))
+	}
+
+	if b, ok := class.pool.Get().(*bytes.Buffer); ok {
+		p.record(p.getCounter, "hit", true)
+		atomic.AddUint64(&p.hits, 1)
+		return b
+	}
+
+	p.record(p.getCounter, "hit", false)
+	atomic.AddUint64(&p.misses, 1)
+	return bytes.NewBuffer(make([]byte, 0, class.size))
+}
+
+// Put resets the buffer and returns it to the pool, in the largest size
+// class its capacity satisfies.
+func (p *Pool) Put(b *bytes.Buffer) {
+	if b == nil {
+		return
+	}
+
+	c := b.Cap()
+	var class *sizeClass
+	for _, sc := range p.classes {
+		if sc.size > c {
+			break
+		}
+		class = sc
+	}
+	if class == nil || c > p.classes[len(p.classes)-1].size {
+		p.record(p.putCounter, "pooled", false)
+		atomic.AddUint64(&p.discards, 1)
+		return
+	}
+
+	b.Reset()
+	p.record(p.putCounter, "pooled", true)
+	atomic.AddUint64(&p.puts, 1)
+	class.pool.Put(b)
+}
+
+// Stats returns the usage counts of the pool.
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Hits:     atomic.LoadUint64(&p.hits),
+		Misses:   atomic.LoadUint64(&p.misses),
+		Puts:     atomic.LoadUint64(&p.puts),
+		Discards: atomic.LoadUint64(&p.discards),
+	}
+}
+
+// classFor returns the smallest size class of at least size bytes, or nil
+// if size is larger than the largest size class.
+func (p *Pool) classFor(size int) *sizeClass {
+	for _, sc := range p.classes {
+		if sc.size >= size {
+			return sc
+		}
+	}
+	return nil
+}
+
+func (p *Pool) record(counter metrics.Int64Counter, attr string, value bool) {
+	if counter == nil {
+		return
+	}
+	var props smithy.Properties
+	props.Set(attr, value)
+	counter.Add(context.Background(), 1, metrics.WithProperties(props))
+}
+
+var defaultPool = New()
+
+// Default returns the pool shared by the encoders and middleware of the
+// module.
+func Default() *Pool {
+	return defaultPool
+}
+
+// Get returns an empty buffer with a capacity of at least sizeHint bytes
+// from the default pool.
+func Get(sizeHint int) *bytes.Buffer {
+	return defaultPool.Get(sizeHint)
+}
+
+// Put returns the buffer to the default pool.
+func Put(b *bytes.Buffer) {
+	defaultPool.Put(b)
+}
@@ -0,0 +1,80 @@
+package bufferpool
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	p := New(func(o *Options) {
+		o.SizeClasses = []int{1024, 64}
+	})
+
+	b := p.Get(10)
+	if e, a := 64, b.Cap(); a < e {
+		t.Errorf("expect capacity of at least %v, got %v", e, a)
+	}
+	b.WriteString("hello")
+	p.Put(b)
+
+	b = p.Get(64)
+	if e, a := 0, b.Len(); e != a {
+		t.Errorf("expect pooled buffer to be reset, got %v length", a)
+	}
+	p.Put(b)
+
+	large := p.Get(4096)
+	if e, a := 4096, large.Cap(); a < e {
+		t.Errorf("expect capacity of at least %v, got %v", e, a)
+	}
+	p.Put(large)
+	p.Put(bytes.NewBuffer(make([]byte, 0, 10)))
+
+	stats := p.Stats()
+	if stats.Hits+stats.Misses != 3 {
+		t.Errorf("expect 3 gets, got %+v", stats)
+	}
+	if e, a := uint64(2), stats.Discards; e != a {
+		t.Errorf("expect %v discards, got %v", e, a)
+	}
+	if e, a := uint64(2), stats.Puts; e != a {
+		t.Errorf("expect %v puts, got %v", e, a)
+	}
+}
+
+func TestStatsHitRate(t *testing.T) {
+	cases := map[string]struct {
+		stats  Stats
+		expect float64
+	}{
+		"no gets": {},
+		"all hits": {
+			stats:  Stats{Hits: 4},
+			expect: 1,
+		},
+		"half hits": {
+			stats:  Stats{Hits: 2, Misses: 2},
+			expect: 0.5,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := c.expect, c.stats.HitRate(); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func BenchmarkPool(b *testing.B) {
+	p := New()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			buf := p.Get(1024)
+			buf.WriteString("hello world")
+			p.Put(buf)
+		}
+	})
+}
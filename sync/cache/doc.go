@@ -0,0 +1,14 @@
+// Package cache provides expiring value caches with read-through refresh,
+// for resolvers such as token providers and endpoint discovery whose
+// results are valid for a limited time.
+//
+// Concurrent retrievals of the same value are deduplicated so that only one
+// caller invokes the underlying load function. Expiration may be moved
+// earlier by a fixed window and a random jitter, spreading refreshes of
+// many cached values over time. Values may also be refreshed in the
+// background before they expire, so callers are not blocked on the refresh.
+//
+// StaleWhileRevalidate decorates a resolver with a cache of its values by
+// parameters, returning stale values while they are revalidated in the
+// background.
+package cache
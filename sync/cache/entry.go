@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// entry is a cached value and the time at which it is considered expired.
+// A zero expires time never expires.
+type entry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+func (e *entry[T]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+func (e *entry[T]) shouldRefresh(now time.Time, window time.Duration) bool {
+	return window > 0 && !e.expires.IsZero() && !now.Before(e.expires.Add(-window))
+}
+
+// load invokes fn and builds the entry for its result.
+func load[T any](ctx context.Context, o Options, fn func(context.Context) (T, time.Time, error)) (*entry[T], error) {
+	v, expires, err := fn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	expires, err = o.expiresAt(ctx, expires)
+	if err != nil {
+		return nil, err
+	}
+	return &entry[T]{value: v, expires: expires}, nil
+}
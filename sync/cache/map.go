@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/smithy-go/sync/singleflight"
+)
+
+// KeyedLoadFunc retrieves the value to be cached for a key, and the time at
+// which the value expires. A zero expiration time indicates the value never
+// expires.
+type KeyedLoadFunc[T any] func(ctx context.Context, key string) (T, time.Time, error)
+
+// Map is a read-through cache of expiring values by key, such as discovered
+// endpoints.
+//
+// Map must be created with NewMap, and is safe for concurrent use.
+type Map[T any] struct {
+	options Options
+	load    KeyedLoadFunc[T]
+
+	mu      sync.RWMutex
+	entries map[string]*entry[T]
+	group   singleflight.Group
+
+	// loadIDs identifies the in-flight load by key whose result is cached.
+	// Invalidated loads are removed. lastLoadID is the ID of the most
+	// recently started load.
+	loadIDs    map[string]uint64
+	lastLoadID uint64
+}
+
+// NewMap returns a Map cache which retrieves values with load.
+func NewMap[T any](load KeyedLoadFunc[T], optFns ...func(*Options)) *Map[T] {
+	var o Options
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	return &Map[T]{
+		options: o,
+		load:    load,
+		entries: map[string]*entry[T]{},
+		loadIDs: map[string]uint64{},
+	}
+}
+
+// Get returns the cached value for key if it has not expired, otherwise the
+// value is loaded and cached. Concurrent callers for the same key share a
+// single load. If the value is within the refresh window, it is returned and
+// refreshed in the background.
+//
+// The load is invoked with the values of the context of the caller which
+// started it, but is not canceled with it, so callers sharing the load are not
+// failed by the first caller's cancellation. Get returns when ctx is done,
+// without waiting for the load.
+func (c *Map[T]) Get(ctx context.Context, key string) (T, error) {
+	now := c.options.clock(ctx).Now()
+
+	c.mu.RLock()
+	e := c.entries[key]
+	c.mu.RUnlock()
+
+	if e != nil && !e.expired(now) {
+		if e.shouldRefresh(now, c.options.RefreshWindow) {
+			c.group.DoChan(key, c.refreshFunc(detachedContext{ctx}, key))
+		}
+		return e.value, nil
+	}
+
+	ch := c.group.DoChan(key, c.refreshFunc(detachedContext{ctx}, key))
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			var zero T
+			return zero, res.Err
+		}
+		return res.Val.(*entry[T]).value, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Invalidate discards the cached value for key, so the next call to Get for
+// key loads the value. A load for key in-flight when the value is invalidated
+// is not shared with later callers, and its result is not cached.
+func (c *Map[T]) Invalidate(key string) {
+	c.mu.Lock()
+	delete(c.entries, key)
+	delete(c.loadIDs, key)
+	c.group.Forget(key)
+	c.mu.Unlock()
+}
+
+// Len returns the number of values in the cache, including values which
+// have expired but not yet been replaced.
+func (c *Map[T]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// Prune removes all expired values from the cache.
+func (c *Map[T]) Prune(ctx context.Context) {
+	now := c.options.clock(ctx).Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if e.expired(now) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+func (c *Map[T]) refreshFunc(ctx context.Context, key string) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		c.mu.Lock()
+		c.lastLoadID++
+		id := c.lastLoadID
+		c.loadIDs[key] = id
+		c.mu.Unlock()
+
+		e, err := load(ctx, c.options, func(ctx context.Context) (T, time.Time, error) {
+			return c.load(ctx, key)
+		})
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.loadIDs[key] == id {
+			delete(c.loadIDs, key)
+			if err == nil {
+				c.entries[key] = e
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+}
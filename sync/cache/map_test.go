@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+func TestMapGet(t *testing.T) {
+	clock := smithytime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := smithytime.WithClock(context.Background(), clock)
+
+	calls := map[string]int{}
+	c := NewMap(func(ctx context.Context, key string) (string, time.Time, error) {
+		calls[key]++
+		ttl := time.Minute
+		if key == "long" {
+			ttl = time.Hour
+		}
+		return key + "-endpoint", clock.Now().Add(ttl), nil
+	})
+
+	for _, key := range []string{"short", "long", "short", "long"} {
+		v, err := c.Get(ctx, key)
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		if e, a := key+"-endpoint", v; e != a {
+			t.Errorf("expect %v, got %v", e, a)
+		}
+	}
+	if e, a := 1, calls["short"]; e != a {
+		t.Errorf("expect %v short calls, got %v", e, a)
+	}
+
+	clock.Advance(2 * time.Minute)
+	c.Get(ctx, "short")
+	c.Get(ctx, "long")
+	if e, a := 2, calls["short"]; e != a {
+		t.Errorf("expect %v short calls, got %v", e, a)
+	}
+	if e, a := 1, calls["long"]; e != a {
+		t.Errorf("expect %v long calls, got %v", e, a)
+	}
+
+	c.Invalidate("long")
+	c.Get(ctx, "long")
+	if e, a := 2, calls["long"]; e != a {
+		t.Errorf("expect %v long calls, got %v", e, a)
+	}
+}
+
+func TestMapPrune(t *testing.T) {
+	clock := smithytime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	c := NewMap(func(ctx context.Context, key string) (int, time.Time, error) {
+		if key == "forever" {
+			return 0, time.Time{}, nil
+		}
+		return 0, clock.Now().Add(time.Minute), nil
+	}, func(o *Options) {
+		o.Clock = clock
+	})
+
+	c.Get(context.Background(), "a")
+	c.Get(context.Background(), "b")
+	c.Get(context.Background(), "forever")
+	if e, a := 3, c.Len(); e != a {
+		t.Fatalf("expect %v entries, got %v", e, a)
+	}
+
+	clock.Advance(time.Minute)
+	c.Prune(context.Background())
+	if e, a := 1, c.Len(); e != a {
+		t.Errorf("expect %v entries, got %v", e, a)
+	}
+}
+
+func TestMapInvalidateInFlight(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	c := NewMap(func(ctx context.Context, key string) (int32, time.Time, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			<-release
+		}
+		return n, time.Time{}, nil
+	})
+
+	stale := make(chan int32)
+	go func() {
+		v, _ := c.Get(context.Background(), "key")
+		stale <- v
+	}()
+	for atomic.LoadInt32(&calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	c.Invalidate("key")
+	if v, err := c.Get(context.Background(), "key"); err != nil || v != 2 {
+		t.Errorf("expect 2 loaded after invalidate, got %v, %v", v, err)
+	}
+
+	close(release)
+	if e, a := int32(1), <-stale; e != a {
+		t.Errorf("expect %v from in-flight load, got %v", e, a)
+	}
+	if v, err := c.Get(context.Background(), "key"); err != nil || v != 2 {
+		t.Errorf("expect 2 cached, got %v, %v", v, err)
+	}
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/smithy-go/rand"
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+// Options provides the configuration of a cache.
+type Options struct {
+	// ExpiryWindow is the duration before a value's expiration time at which
+	// the value is considered expired. Defaults to zero.
+	ExpiryWindow time.Duration
+
+	// Jitter is the maximum random duration, in addition to ExpiryWindow,
+	// before a value's expiration time at which the value is considered
+	// expired. The jitter is chosen each time a value is loaded, using the
+	// rand.EntropySource of the loading context. Defaults to zero.
+	Jitter time.Duration
+
+	// RefreshWindow is the duration before a value is considered expired at
+	// which the value is refreshed in the background. The cached value is
+	// returned to the caller while the refresh is in-flight. If zero, values
+	// are only refreshed when they have expired.
+	RefreshWindow time.Duration
+
+	// Clock is the clock used to determine if a value has expired. If nil,
+	// the clock of the calling context is used.
+	Clock smithytime.Clock
+}
+
+func (o Options) clock(ctx context.Context) smithytime.Clock {
+	if o.Clock != nil {
+		return o.Clock
+	}
+	return smithytime.GetClock(ctx)
+}
+
+// expiresAt returns the time at which a value with the given expiration is
+// considered expired, adjusted by the expiry window and jitter.
+func (o Options) expiresAt(ctx context.Context, expires time.Time) (time.Time, error) {
+	if expires.IsZero() {
+		return expires, nil
+	}
+
+	expires = expires.Add(-o.ExpiryWindow)
+	if o.Jitter > 0 {
+		jitter, err := rand.ContextInt63n(ctx, int64(o.Jitter))
+		if err != nil {
+			return time.Time{}, err
+		}
+		expires = expires.Add(-time.Duration(jitter))
+	}
+	return expires, nil
+}
+
+// detachedContext is a context that retains the values of its parent, but is
+// not canceled when the parent is. Used for background refreshes which may
+// outlive the caller that triggered them.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+// ResolveFunc resolves the value for the parameters, such as the endpoint of
+// an operation, or a bearer token.
+type ResolveFunc[P, T any] func(ctx context.Context, params P) (T, error)
+
+// StaleWhileRevalidateOptions provides the configuration of a
+// StaleWhileRevalidate resolver.
+type StaleWhileRevalidateOptions[P, T any] struct {
+	// SoftTTL is the duration after a value is resolved at which it is
+	// stale. Stale values are returned immediately, and revalidated in the
+	// background. Required.
+	SoftTTL time.Duration
+
+	// HardTTL is the duration after a value is resolved at which it has
+	// expired, and callers are blocked on resolving the value again. Must
+	// not be less than SoftTTL. Required.
+	HardTTL time.Duration
+
+	// Key returns the cache key of the parameters. Parameters resolving to
+	// the same value must have the same key, such as the key of the
+	// parameters' field values, rather than of a pointer to the parameters.
+	// Required.
+	Key func(P) string
+
+	// Expiration returns the time at which the value expires, if earlier
+	// than HardTTL, such as the expiration of a bearer token. A zero time
+	// means the value expires after HardTTL. The value is stale for the
+	// same duration before it expires as values expiring after HardTTL.
+	Expiration func(T) time.Time
+
+	// Clock is the clock used to determine if a value is stale, or has
+	// expired. If nil, the clock of the calling context is used.
+	Clock smithytime.Clock
+}
+
+// StaleWhileRevalidate decorates a resolver, caching its values by
+// parameters. Once stale, cached values are returned immediately while they
+// are refreshed in the background, removing the latency spikes of resolving
+// values when they expire.
+//
+// StaleWhileRevalidate must be created with NewStaleWhileRevalidate, and is
+// safe for concurrent use.
+type StaleWhileRevalidate[P, T any] struct {
+	options StaleWhileRevalidateOptions[P, T]
+	resolve ResolveFunc[P, T]
+	cache   *Map[T]
+}
+
+// resolveParamsKey is the context key of the parameters a value is resolved
+// for, so the load of the cache can resolve them.
+type resolveParamsKey[P any] struct{}
+
+// NewStaleWhileRevalidate returns a StaleWhileRevalidate resolver caching
+// the values of resolve. Returns an error if SoftTTL or HardTTL are not
+// valid, or Key is not set.
+func NewStaleWhileRevalidate[P, T any](resolve ResolveFunc[P, T], optFns ...func(*StaleWhileRevalidateOptions[P, T])) (
+	*StaleWhileRevalidate[P, T], error,
+) {
+	var o StaleWhileRevalidateOptions[P, T]
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	if o.SoftTTL <= 0 {
+		return nil, fmt.Errorf("stale while revalidate SoftTTL must be greater than 0, got %v", o.SoftTTL)
+	}
+	if o.HardTTL < o.SoftTTL {
+		return nil, fmt.Errorf("stale while revalidate HardTTL must not be less than SoftTTL %v, got %v",
+			o.SoftTTL, o.HardTTL)
+	}
+	if o.Key == nil {
+		return nil, fmt.Errorf("stale while revalidate Key must be set")
+	}
+
+	r := &StaleWhileRevalidate[P, T]{
+		options: o,
+		resolve: resolve,
+	}
+	r.cache = NewMap(r.load, func(co *Options) {
+		co.RefreshWindow = o.HardTTL - o.SoftTTL
+		co.Clock = o.Clock
+	})
+	return r, nil
+}
+
+// Resolve returns the cached value for the parameters if it has not expired,
+// otherwise the value is resolved and cached. Stale values are returned, and
+// revalidated in the background. Concurrent callers for the same parameters
+// share a single resolve.
+func (r *StaleWhileRevalidate[P, T]) Resolve(ctx context.Context, params P) (T, error) {
+	ctx = context.WithValue(ctx, resolveParamsKey[P]{}, params)
+	return r.cache.Get(ctx, r.options.Key(params))
+}
+
+// Invalidate discards the cached value for the parameters, so the next
+// call to Resolve resolves the value.
+func (r *StaleWhileRevalidate[P, T]) Invalidate(params P) {
+	r.cache.Invalidate(r.options.Key(params))
+}
+
+func (r *StaleWhileRevalidate[P, T]) load(ctx context.Context, _ string) (T, time.Time, error) {
+	params, _ := ctx.Value(resolveParamsKey[P]{}).(P)
+
+	v, err := r.resolve(ctx, params)
+	if err != nil {
+		return v, time.Time{}, err
+	}
+
+	clock := r.options.Clock
+	if clock == nil {
+		clock = smithytime.GetClock(ctx)
+	}
+	expires := clock.Now().Add(r.options.HardTTL)
+	if r.options.Expiration != nil {
+		if exp := r.options.Expiration(v); !exp.IsZero() && exp.Before(expires) {
+			expires = exp
+		}
+	}
+	return v, expires, nil
+}
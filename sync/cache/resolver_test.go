@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+func TestNewStaleWhileRevalidate(t *testing.T) {
+	cases := map[string]struct {
+		SoftTTL   time.Duration
+		HardTTL   time.Duration
+		NoKey     bool
+		ExpectErr bool
+	}{
+		"valid":            {SoftTTL: time.Minute, HardTTL: time.Hour},
+		"equal":            {SoftTTL: time.Minute, HardTTL: time.Minute},
+		"missing soft ttl": {HardTTL: time.Hour, ExpectErr: true},
+		"hard before soft": {SoftTTL: time.Hour, HardTTL: time.Minute, ExpectErr: true},
+		"missing key":      {SoftTTL: time.Minute, HardTTL: time.Hour, NoKey: true, ExpectErr: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewStaleWhileRevalidate(func(context.Context, string) (string, error) {
+				return "", nil
+			}, func(o *StaleWhileRevalidateOptions[string, string]) {
+				o.SoftTTL = c.SoftTTL
+				o.HardTTL = c.HardTTL
+				if !c.NoKey {
+					o.Key = func(params string) string { return params }
+				}
+			})
+			if c.ExpectErr != (err != nil) {
+				t.Errorf("expect error %v, got %v", c.ExpectErr, err)
+			}
+		})
+	}
+}
+
+type mockEndpointParams struct {
+	Region string
+}
+
+func TestStaleWhileRevalidateResolve(t *testing.T) {
+	clock := smithytime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := smithytime.WithClock(context.Background(), clock)
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+	resolved := make(chan struct{}, 1)
+	r, err := NewStaleWhileRevalidate(func(ctx context.Context, params mockEndpointParams) (string, error) {
+		mu.Lock()
+		calls[params.Region]++
+		n := calls[params.Region]
+		mu.Unlock()
+		if n > 1 {
+			resolved <- struct{}{}
+		}
+		return fmt.Sprintf("%s-%d", params.Region, n), nil
+	}, func(o *StaleWhileRevalidateOptions[mockEndpointParams, string]) {
+		o.SoftTTL = time.Minute
+		o.HardTTL = 10 * time.Minute
+		o.Key = func(params mockEndpointParams) string { return params.Region }
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	resolve := func(region string) string {
+		v, err := r.Resolve(ctx, mockEndpointParams{Region: region})
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		return v
+	}
+
+	if e, a := "us-east-1-1", resolve("us-east-1"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := "us-west-2-1", resolve("us-west-2"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	// stale value is returned, and revalidated in the background.
+	clock.Advance(2 * time.Minute)
+	if e, a := "us-east-1-1", resolve("us-east-1"); e != a {
+		t.Errorf("expect stale %v, got %v", e, a)
+	}
+	select {
+	case <-resolved:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expect background revalidation")
+	}
+	for {
+		r.cache.mu.RLock()
+		e := r.cache.entries["us-east-1"]
+		r.cache.mu.RUnlock()
+		if e.value == "us-east-1-2" {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if e, a := "us-east-1-2", resolve("us-east-1"); e != a {
+		t.Errorf("expect revalidated %v, got %v", e, a)
+	}
+
+	// expired value blocks on resolve.
+	clock.Advance(20 * time.Minute)
+	if e, a := "us-west-2-2", resolve("us-west-2"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	<-resolved
+}
+
+func TestStaleWhileRevalidateExpiration(t *testing.T) {
+	clock := smithytime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := smithytime.WithClock(context.Background(), clock)
+
+	r, err := NewStaleWhileRevalidate(func(ctx context.Context, _ string) (time.Time, error) {
+		return clock.Now().Add(5 * time.Minute), nil
+	}, func(o *StaleWhileRevalidateOptions[string, time.Time]) {
+		o.SoftTTL = 50 * time.Minute
+		o.HardTTL = time.Hour
+		o.Key = func(params string) string { return params }
+		o.Expiration = func(v time.Time) time.Time { return v }
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	token, err := r.Resolve(ctx, "token")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	r.cache.mu.RLock()
+	e := r.cache.entries["token"]
+	r.cache.mu.RUnlock()
+	if !e.expires.Equal(token) {
+		t.Errorf("expect value to expire at %v, got %v", token, e.expires)
+	}
+}
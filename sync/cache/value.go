@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/smithy-go/sync/singleflight"
+)
+
+// LoadFunc retrieves a value to be cached, and the time at which the value
+// expires. A zero expiration time indicates the value never expires.
+type LoadFunc[T any] func(context.Context) (T, time.Time, error)
+
+// Value is a read-through cache of a single expiring value, such as an
+// authentication token.
+//
+// Value must be created with NewValue, and is safe for concurrent use.
+type Value[T any] struct {
+	options Options
+	load    LoadFunc[T]
+
+	mu    sync.RWMutex
+	entry *entry[T]
+	group singleflight.Group
+
+	// loadID identifies the in-flight load whose result is cached, zero if
+	// the in-flight load was invalidated. lastLoadID is the ID of the most
+	// recently started load.
+	loadID, lastLoadID uint64
+}
+
+// NewValue returns a Value cache which retrieves its value with load.
+func NewValue[T any](load LoadFunc[T], optFns ...func(*Options)) *Value[T] {
+	var o Options
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	return &Value[T]{
+		options: o,
+		load:    load,
+	}
+}
+
+// Get returns the cached value if it has not expired, otherwise the value is
+// loaded and cached. Concurrent callers share a single load. If the value is
+// within the refresh window, it is returned and refreshed in the background.
+//
+// The load is invoked with the values of the context of the caller which
+// started it, but is not canceled with it, so callers sharing the load are not
+// failed by the first caller's cancellation. Get returns when ctx is done,
+// without waiting for the load.
+func (c *Value[T]) Get(ctx context.Context) (T, error) {
+	now := c.options.clock(ctx).Now()
+
+	c.mu.RLock()
+	e := c.entry
+	c.mu.RUnlock()
+
+	if e != nil && !e.expired(now) {
+		if e.shouldRefresh(now, c.options.RefreshWindow) {
+			c.group.DoChan("", c.refreshFunc(detachedContext{ctx}))
+		}
+		return e.value, nil
+	}
+
+	ch := c.group.DoChan("", c.refreshFunc(detachedContext{ctx}))
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			var zero T
+			return zero, res.Err
+		}
+		return res.Val.(*entry[T]).value, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Invalidate discards the cached value, so the next call to Get loads the
+// value. A load in-flight when the value is invalidated is not shared with
+// later callers, and its result is not cached.
+func (c *Value[T]) Invalidate() {
+	c.mu.Lock()
+	c.entry = nil
+	c.loadID = 0
+	c.group.Forget("")
+	c.mu.Unlock()
+}
+
+func (c *Value[T]) refreshFunc(ctx context.Context) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		c.mu.Lock()
+		c.lastLoadID++
+		id := c.lastLoadID
+		c.loadID = id
+		c.mu.Unlock()
+
+		e, err := load(ctx, c.options, c.load)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.loadID == id {
+			c.loadID = 0
+			if err == nil {
+				c.entry = e
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+}
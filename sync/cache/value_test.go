@@ -0,0 +1,294 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/rand"
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+func TestValueGet(t *testing.T) {
+	clock := smithytime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := smithytime.WithClock(context.Background(), clock)
+
+	var calls int
+	c := NewValue(func(ctx context.Context) (int, time.Time, error) {
+		calls++
+		return calls, clock.Now().Add(10 * time.Minute), nil
+	}, func(o *Options) {
+		o.ExpiryWindow = time.Minute
+	})
+
+	cases := []struct {
+		advance time.Duration
+		expect  int
+	}{
+		{0, 1},
+		{8 * time.Minute, 1},
+		{time.Minute, 2},
+		{time.Minute, 2},
+	}
+
+	for i, tt := range cases {
+		clock.Advance(tt.advance)
+		v, err := c.Get(ctx)
+		if err != nil {
+			t.Fatalf("%d, expect no error, got %v", i, err)
+		}
+		if e, a := tt.expect, v; e != a {
+			t.Errorf("%d, expect %v, got %v", i, e, a)
+		}
+	}
+}
+
+func TestValueNeverExpires(t *testing.T) {
+	clock := smithytime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	var calls int
+	c := NewValue(func(ctx context.Context) (int, time.Time, error) {
+		calls++
+		return calls, time.Time{}, nil
+	}, func(o *Options) {
+		o.Clock = clock
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Get(context.Background()); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		clock.Advance(24 * time.Hour)
+	}
+	if e, a := 1, calls; e != a {
+		t.Errorf("expect %v calls, got %v", e, a)
+	}
+
+	c.Invalidate()
+	v, _ := c.Get(context.Background())
+	if e, a := 2, v; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestValueError(t *testing.T) {
+	loadErr := errors.New("load failed")
+	fail := true
+	c := NewValue(func(ctx context.Context) (string, time.Time, error) {
+		if fail {
+			return "", time.Time{}, loadErr
+		}
+		return "ok", time.Time{}, nil
+	})
+
+	if _, err := c.Get(context.Background()); err != loadErr {
+		t.Errorf("expect %v error, got %v", loadErr, err)
+	}
+
+	fail = false
+	v, err := c.Get(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "ok", v; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestValueJitter(t *testing.T) {
+	clock := smithytime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := smithytime.WithClock(context.Background(), clock)
+	ctx = rand.WithEntropySource(ctx, rand.NewSeededReader(1))
+
+	expires := clock.Now().Add(10 * time.Minute)
+	c := NewValue(func(ctx context.Context) (int, time.Time, error) {
+		return 1, expires, nil
+	}, func(o *Options) {
+		o.ExpiryWindow = time.Minute
+		o.Jitter = time.Minute
+	})
+
+	if _, err := c.Get(ctx); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	e := c.entry
+	if min, max := expires.Add(-2*time.Minute), expires.Add(-time.Minute); e.expires.Before(min) || !e.expires.Before(max) {
+		t.Errorf("expect expiry in [%v, %v), got %v", min, max, e.expires)
+	}
+}
+
+func TestValueRefreshWindow(t *testing.T) {
+	clock := smithytime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := smithytime.WithClock(context.Background(), clock)
+
+	var calls int32
+	refreshed := make(chan struct{}, 1)
+	c := NewValue(func(ctx context.Context) (int32, time.Time, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n > 1 {
+			refreshed <- struct{}{}
+		}
+		return n, clock.Now().Add(10 * time.Minute), nil
+	}, func(o *Options) {
+		o.RefreshWindow = 2 * time.Minute
+	})
+
+	if v, _ := c.Get(ctx); v != 1 {
+		t.Fatalf("expect 1, got %v", v)
+	}
+
+	clock.Advance(9 * time.Minute)
+	cancelCtx, cancel := context.WithCancel(ctx)
+	v, err := c.Get(cancelCtx)
+	cancel()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := int32(1), v; e != a {
+		t.Errorf("expect cached %v, got %v", e, a)
+	}
+
+	select {
+	case <-refreshed:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expect background refresh")
+	}
+
+	for {
+		c.mu.RLock()
+		e := c.entry
+		c.mu.RUnlock()
+		if e.value == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if v, _ := c.Get(ctx); v != 2 {
+		t.Errorf("expect refreshed 2, got %v", v)
+	}
+}
+
+func TestValueConcurrentLoad(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	c := NewValue(func(ctx context.Context) (int32, time.Time, error) {
+		<-release
+		return atomic.AddInt32(&calls, 1), time.Time{}, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := c.Get(context.Background()); err != nil || v != 1 {
+				t.Errorf("expect 1, got %v, %v", v, err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if e, a := int32(1), atomic.LoadInt32(&calls); e != a {
+		t.Errorf("expect %v calls, got %v", e, a)
+	}
+}
+
+func TestValueGetCanceled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	c := NewValue(func(ctx context.Context) (int, time.Time, error) {
+		<-release
+		return 1, time.Time{}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Get(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expect canceled error, got %v", err)
+	}
+}
+
+func TestValueGetCanceledSharedLoad(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	c := NewValue(func(ctx context.Context) (int, time.Time, error) {
+		close(started)
+		<-release
+		if err := ctx.Err(); err != nil {
+			return 0, time.Time{}, err
+		}
+		return 1, time.Time{}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := c.Get(ctx)
+		firstErr <- err
+	}()
+	<-started
+
+	type result struct {
+		v   int
+		err error
+	}
+	second := make(chan result)
+	go func() {
+		v, err := c.Get(context.Background())
+		second <- result{v, err}
+	}()
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expect canceled error, got %v", err)
+	}
+
+	close(release)
+	res := <-second
+	if res.err != nil {
+		t.Fatalf("expect no error, got %v", res.err)
+	}
+	if e, a := 1, res.v; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestValueInvalidateInFlight(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	c := NewValue(func(ctx context.Context) (int32, time.Time, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			<-release
+		}
+		return n, time.Time{}, nil
+	})
+
+	stale := make(chan int32)
+	go func() {
+		v, _ := c.Get(context.Background())
+		stale <- v
+	}()
+	for atomic.LoadInt32(&calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	c.Invalidate()
+	if v, err := c.Get(context.Background()); err != nil || v != 2 {
+		t.Errorf("expect 2 loaded after invalidate, got %v, %v", v, err)
+	}
+
+	close(release)
+	if e, a := int32(1), <-stale; e != a {
+		t.Errorf("expect %v from in-flight load, got %v", e, a)
+	}
+	if v, err := c.Get(context.Background()); err != nil || v != 2 {
+		t.Errorf("expect 2 cached, got %v, %v", v, err)
+	}
+}
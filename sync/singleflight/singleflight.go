@@ -0,0 +1,131 @@
+// Package singleflight provides a duplicate function call suppression
+// mechanism, so that concurrent callers retrieving the same value, e.g. a
+// token or endpoint being refreshed, share the result of a single call.
+package singleflight
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// call is an in-flight or completed Do call.
+type call struct {
+	wg sync.WaitGroup
+
+	// val and err are written once before the WaitGroup is done, and only
+	// read after the WaitGroup is done.
+	val interface{}
+	err error
+
+	dups  int
+	chans []chan<- Result
+}
+
+// Group represents a class of work and forms a namespace in which units of
+// work can be executed with duplicate suppression.
+//
+// The zero value is ready to use, and must not be copied after first use.
+type Group struct {
+	mu sync.Mutex
+	m  map[string]*call
+}
+
+// Result holds the results of Do, so they can be passed on a channel.
+type Result struct {
+	Val    interface{}
+	Err    error
+	Shared bool
+}
+
+// PanicError is the error returned to the callers of Do, if the function
+// panicked.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("singleflight function panicked, %v\n\n%s", p.Value, p.Stack)
+}
+
+// Do executes and returns the results of the given function, making sure
+// that only one execution is in-flight for a given key at a time. If a
+// duplicate comes in, the duplicate caller waits for the original to complete
+// and receives the same results. The return value shared reports whether v
+// was given to multiple callers.
+func (g *Group) Do(key string, fn func() (interface{}, error)) (v interface{}, err error, shared bool) {
+	g.mu.Lock()
+	if g.m == nil {
+		g.m = make(map[string]*call)
+	}
+	if c, ok := g.m[key]; ok {
+		c.dups++
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err, true
+	}
+	c := new(call)
+	c.wg.Add(1)
+	g.m[key] = c
+	g.mu.Unlock()
+
+	g.doCall(c, key, fn)
+	return c.val, c.err, c.dups > 0
+}
+
+// DoChan is like Do but returns a channel that will receive the results when
+// they are ready. The returned channel will not be closed.
+func (g *Group) DoChan(key string, fn func() (interface{}, error)) <-chan Result {
+	ch := make(chan Result, 1)
+
+	g.mu.Lock()
+	if g.m == nil {
+		g.m = make(map[string]*call)
+	}
+	if c, ok := g.m[key]; ok {
+		c.dups++
+		c.chans = append(c.chans, ch)
+		g.mu.Unlock()
+		return ch
+	}
+	c := &call{chans: []chan<- Result{ch}}
+	c.wg.Add(1)
+	g.m[key] = c
+	g.mu.Unlock()
+
+	go g.doCall(c, key, fn)
+
+	return ch
+}
+
+// doCall handles the single call for a key.
+func (g *Group) doCall(c *call, key string, fn func() (interface{}, error)) {
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				c.err = &PanicError{Value: r, Stack: debug.Stack()}
+			}
+		}()
+		c.val, c.err = fn()
+	}()
+
+	g.mu.Lock()
+	c.wg.Done()
+	if g.m[key] == c {
+		delete(g.m, key)
+	}
+	for _, ch := range c.chans {
+		ch <- Result{Val: c.val, Err: c.err, Shared: c.dups > 0}
+	}
+	g.mu.Unlock()
+}
+
+// Forget tells the singleflight to forget about a key. Future calls to Do
+// for this key will call the function rather than waiting for an earlier
+// call to complete.
+func (g *Group) Forget(key string) {
+	g.mu.Lock()
+	delete(g.m, key)
+	g.mu.Unlock()
+}
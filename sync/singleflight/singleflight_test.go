@@ -0,0 +1,124 @@
+package singleflight
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDo(t *testing.T) {
+	var g Group
+	v, err, _ := g.Do("key", func() (interface{}, error) {
+		return "bar", nil
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "bar", v; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestDoErr(t *testing.T) {
+	var g Group
+	someErr := errors.New("some error")
+	v, err, _ := g.Do("key", func() (interface{}, error) {
+		return nil, someErr
+	})
+	if e, a := someErr, err; e != a {
+		t.Errorf("expect %v error, got %v", e, a)
+	}
+	if v != nil {
+		t.Errorf("expect nil value, got %v", v)
+	}
+}
+
+func TestDoDupSuppress(t *testing.T) {
+	var g Group
+	var calls int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	fn := func() (interface{}, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+		}
+		<-release
+		return "bar", nil
+	}
+
+	const n = 10
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		g.Do("key", fn)
+	}()
+	<-started
+
+	for i := 0; i < n-1; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err, shared := g.Do("key", fn)
+			if err != nil || v != "bar" || !shared {
+				t.Errorf("expect shared bar, got %v, %v, %v", v, err, shared)
+			}
+		}()
+	}
+
+	// Wait for the duplicate callers to block on the in-flight call.
+	for {
+		g.mu.Lock()
+		dups := g.m["key"].dups
+		g.mu.Unlock()
+		if dups == n-1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if e, a := int32(1), atomic.LoadInt32(&calls); e != a {
+		t.Errorf("expect %v calls, got %v", e, a)
+	}
+}
+
+func TestDoChan(t *testing.T) {
+	var g Group
+	ch := g.DoChan("key", func() (interface{}, error) {
+		return "bar", nil
+	})
+
+	res := <-ch
+	if res.Err != nil {
+		t.Fatalf("expect no error, got %v", res.Err)
+	}
+	if e, a := "bar", res.Val; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestDoPanic(t *testing.T) {
+	var g Group
+	_, err, _ := g.Do("key", func() (interface{}, error) {
+		panic("boom")
+	})
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("expect panic error, got %v", err)
+	}
+	if e, a := "boom", panicErr.Value; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	// The key is released after the panic.
+	v, _, _ := g.Do("key", func() (interface{}, error) { return "ok", nil })
+	if e, a := "ok", v; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
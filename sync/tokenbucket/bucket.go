@@ -0,0 +1,167 @@
+// Package tokenbucket provides a token bucket limiting the retry attempts of
+// clients. A Bucket is safe for concurrent use, and may be shared by the
+// retry strategies of multiple clients, so the clients of a process
+// coordinate a single retry budget. A retry attempt acquires tokens from the
+// bucket, and is not made if the bucket has too few tokens remaining.
+//
+// The Bucket's GetToken, and AddTokens methods match the rate limiter of
+// retry strategies, so a Bucket can be injected as their rate limiter.
+package tokenbucket
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/smithy-go/metrics"
+)
+
+// DefaultCapacity is the capacity of a Bucket if not configured.
+const DefaultCapacity uint = 500
+
+// Options provides the configuration of a Bucket.
+type Options struct {
+	// Capacity is the maximum number of tokens in the bucket. The bucket is
+	// created full. Defaults to DefaultCapacity.
+	Capacity uint
+
+	// MeterProvider is used to record the number of tokens acquired from the
+	// bucket, and the number of acquisitions rejected because the bucket
+	// was exhausted. Optional.
+	MeterProvider metrics.MeterProvider
+
+	// OnExhausted is called when an acquisition is rejected because the
+	// bucket has too few tokens remaining. It is called synchronously, and
+	// must not block. Optional.
+	OnExhausted func(context.Context, ExhaustedEvent)
+}
+
+// ExhaustedEvent describes an acquisition rejected because the bucket had
+// too few tokens remaining.
+type ExhaustedEvent struct {
+	// Cost is the number of tokens requested.
+	Cost uint
+
+	// Remaining is the number of tokens in the bucket.
+	Remaining uint
+
+	// Capacity is the capacity of the bucket.
+	Capacity uint
+}
+
+// QuotaExceededError is returned by GetToken when the bucket has too few
+// tokens remaining for the requested cost.
+type QuotaExceededError struct {
+	Cost      uint
+	Remaining uint
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("retry quota exceeded, %d tokens requested, %d remaining",
+		e.Cost, e.Remaining)
+}
+
+// Bucket is a token bucket limiting retry attempts. A Bucket is safe for
+// concurrent use, and may be shared by multiple clients.
+type Bucket struct {
+	mu        sync.Mutex
+	capacity  uint
+	remaining uint
+
+	onExhausted func(context.Context, ExhaustedEvent)
+
+	acquiredCounter  metrics.Int64Counter
+	exhaustedCounter metrics.Int64Counter
+}
+
+// New returns a full Bucket configured by the functional options.
+func New(optFns ...func(*Options)) *Bucket {
+	o := Options{
+		Capacity: DefaultCapacity,
+	}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	b := &Bucket{
+		capacity:    o.Capacity,
+		remaining:   o.Capacity,
+		onExhausted: o.OnExhausted,
+	}
+
+	if o.MeterProvider != nil {
+		meter := o.MeterProvider.Meter("github.com/aws/smithy-go/sync/tokenbucket")
+		b.acquiredCounter, _ = meter.Int64Counter("smithy.retry.tokens.acquired",
+			metrics.WithDescription("The number of retry tokens acquired from the bucket."))
+		b.exhaustedCounter, _ = meter.Int64Counter("smithy.retry.tokens.exhausted",
+			metrics.WithDescription("The number of retry token acquisitions rejected because the bucket was exhausted."))
+	}
+	return b
+}
+
+// GetToken acquires cost tokens from the bucket, returning a function which
+// releases the tokens back into the bucket, such as when the retried
+// attempt succeeds. The release function only releases the tokens once.
+//
+// Returns a QuotaExceededError if the bucket has fewer than cost tokens
+// remaining, calling the bucket's OnExhausted hook.
+func (b *Bucket) GetToken(ctx context.Context, cost uint) (release func() error, err error) {
+	b.mu.Lock()
+	remaining := b.remaining
+	if remaining < cost {
+		b.mu.Unlock()
+		b.exhausted(ctx, cost, remaining)
+		return nil, &QuotaExceededError{Cost: cost, Remaining: remaining}
+	}
+	b.remaining -= cost
+	b.mu.Unlock()
+
+	if b.acquiredCounter != nil {
+		b.acquiredCounter.Add(ctx, int64(cost))
+	}
+
+	var once sync.Once
+	return func() error {
+		once.Do(func() { b.AddTokens(cost) })
+		return nil
+	}, nil
+}
+
+// AddTokens adds n tokens to the bucket, up to the bucket's capacity, such
+// as when an attempt succeeds without retrying.
+func (b *Bucket) AddTokens(n uint) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if n > b.capacity-b.remaining {
+		b.remaining = b.capacity
+	} else {
+		b.remaining += n
+	}
+	return nil
+}
+
+// Remaining returns the number of tokens in the bucket.
+func (b *Bucket) Remaining() uint {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.remaining
+}
+
+// Capacity returns the maximum number of tokens in the bucket.
+func (b *Bucket) Capacity() uint {
+	return b.capacity
+}
+
+func (b *Bucket) exhausted(ctx context.Context, cost, remaining uint) {
+	if b.exhaustedCounter != nil {
+		b.exhaustedCounter.Add(ctx, 1)
+	}
+	if b.onExhausted != nil {
+		b.onExhausted(ctx, ExhaustedEvent{
+			Cost:      cost,
+			Remaining: remaining,
+			Capacity:  b.capacity,
+		})
+	}
+}
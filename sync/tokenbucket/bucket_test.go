@@ -0,0 +1,95 @@
+package tokenbucket
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestBucket(t *testing.T) {
+	var events []ExhaustedEvent
+	b := New(func(o *Options) {
+		o.Capacity = 10
+		o.OnExhausted = func(_ context.Context, e ExhaustedEvent) {
+			events = append(events, e)
+		}
+	})
+
+	release, err := b.GetToken(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if _, err := b.GetToken(context.Background(), 5); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := uint(0), b.Remaining(); e != a {
+		t.Errorf("expect %v remaining, got %v", e, a)
+	}
+
+	_, err = b.GetToken(context.Background(), 1)
+	var qe *QuotaExceededError
+	if !errors.As(err, &qe) {
+		t.Fatalf("expect %T error, got %v", qe, err)
+	}
+	if e, a := uint(1), qe.Cost; e != a {
+		t.Errorf("expect %v cost, got %v", e, a)
+	}
+	if e, a := 1, len(events); e != a {
+		t.Fatalf("expect %v exhausted events, got %v", e, a)
+	}
+	if e, a := (ExhaustedEvent{Cost: 1, Remaining: 0, Capacity: 10}), events[0]; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	// tokens are only released once.
+	release()
+	release()
+	if e, a := uint(5), b.Remaining(); e != a {
+		t.Errorf("expect %v remaining, got %v", e, a)
+	}
+
+	b.AddTokens(100)
+	if e, a := uint(10), b.Remaining(); e != a {
+		t.Errorf("expect remaining capped at %v, got %v", e, a)
+	}
+}
+
+func TestBucketDefaultCapacity(t *testing.T) {
+	b := New()
+	if e, a := DefaultCapacity, b.Capacity(); e != a {
+		t.Errorf("expect %v capacity, got %v", e, a)
+	}
+	if e, a := DefaultCapacity, b.Remaining(); e != a {
+		t.Errorf("expect %v remaining, got %v", e, a)
+	}
+}
+
+func TestBucketShared(t *testing.T) {
+	b := New(func(o *Options) {
+		o.Capacity = 100
+	})
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var acquired int
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := b.GetToken(context.Background(), 5); err == nil {
+				mu.Lock()
+				acquired++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if e, a := 20, acquired; e != a {
+		t.Errorf("expect %v acquisitions, got %v", e, a)
+	}
+	if e, a := uint(0), b.Remaining(); e != a {
+		t.Errorf("expect %v remaining, got %v", e, a)
+	}
+}
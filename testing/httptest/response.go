@@ -0,0 +1,102 @@
+// Package httptest provides utilities for testing operations with canned
+// HTTP responses, in place of sending requests.
+package httptest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// CannedResponse is an HTTP response returned to an operation by a
+// ResponseHandler, in place of sending the request.
+type CannedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// NewCannedResponse returns a CannedResponse with the status code and body.
+func NewCannedResponse(statusCode int, body []byte) CannedResponse {
+	return CannedResponse{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       body,
+	}
+}
+
+// NewJSONResponse returns a CannedResponse with the status code and body,
+// and the content-type header of the media type.
+func NewJSONResponse(statusCode int, mediaType string, body []byte) CannedResponse {
+	r := NewCannedResponse(statusCode, body)
+	r.Header.Set("Content-Type", mediaType)
+	return r
+}
+
+// NewXMLResponse returns a CannedResponse with the status code and body,
+// and the content-type header of application/xml.
+func NewXMLResponse(statusCode int, body []byte) CannedResponse {
+	r := NewCannedResponse(statusCode, body)
+	r.Header.Set("Content-Type", "application/xml")
+	return r
+}
+
+// Response returns a new smithyhttp.Response of the canned response. Each
+// response has its own copy of the header and body.
+func (c CannedResponse) Response() *smithyhttp.Response {
+	header := c.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &smithyhttp.Response{
+		Response: &http.Response{
+			StatusCode:    c.StatusCode,
+			Status:        http.StatusText(c.StatusCode),
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			ContentLength: int64(len(c.Body)),
+			Body:          ioutil.NopCloser(bytes.NewReader(c.Body)),
+		},
+	}
+}
+
+// ResponseHandler is a middleware.Handler that captures the serialized
+// request, and returns a canned response for it. Used as the handler
+// decorated by an operation's stack in protocol tests.
+type ResponseHandler struct {
+	// Response is the canned response returned for every request.
+	Response CannedResponse
+
+	// Request is the last request handled, with its body read into
+	// RequestBody.
+	Request     *smithyhttp.Request
+	RequestBody []byte
+}
+
+// Handle captures the request, and returns the canned response.
+func (h *ResponseHandler) Handle(ctx context.Context, input interface{}) (
+	output interface{}, metadata middleware.Metadata, err error,
+) {
+	req, ok := input.(*smithyhttp.Request)
+	if !ok {
+		return nil, metadata, fmt.Errorf("unknown request type %T", input)
+	}
+
+	h.Request = req
+	h.RequestBody = nil
+	if stream := req.GetStream(); stream != nil {
+		if h.RequestBody, err = ioutil.ReadAll(stream); err != nil {
+			return nil, metadata, fmt.Errorf("failed to read request body, %v", err)
+		}
+	}
+
+	return h.Response.Response(), metadata, nil
+}
@@ -0,0 +1,48 @@
+package httptest
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	smithytesting "github.com/aws/smithy-go/testing"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+func TestResponseHandler(t *testing.T) {
+	h := &ResponseHandler{
+		Response: NewJSONResponse(200, "application/json", []byte(`{"greeting":"hi"}`)),
+	}
+
+	req := smithyhttp.NewStackRequest().(*smithyhttp.Request)
+	req.Header.Set("X-Example", "abc")
+	req, _ = req.SetStream(strings.NewReader(`{"name":"abc"}`))
+
+	for i := 0; i < 2; i++ {
+		result, _, err := h.Handle(context.Background(), req)
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		req.RewindStream()
+
+		resp := result.(*smithyhttp.Response)
+		if e, a := 200, resp.StatusCode; e != a {
+			t.Errorf("expect %v status, got %v", e, a)
+		}
+		if e, a := "application/json", resp.Header.Get("Content-Type"); e != a {
+			t.Errorf("expect %v content-type, got %v", e, a)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		smithytesting.AssertJSONEqual(t, []byte(`{"greeting":"hi"}`), body)
+	}
+
+	if e, a := "abc", h.Request.Header.Get("X-Example"); e != a {
+		t.Errorf("expect %v header, got %v", e, a)
+	}
+	smithytesting.AssertJSONEqual(t, []byte(`{"name":"abc"}`), h.RequestBody)
+
+	if _, _, err := h.Handle(context.Background(), struct{}{}); err == nil {
+		t.Errorf("expect error for unknown request type")
+	}
+}
@@ -0,0 +1,261 @@
+// Package miditest provides a harness for unit testing a single middleware
+// of any stack step. The middleware is run with a scripted next handler,
+// which records the context and input of each call, and returns scripted
+// responses, so tests assert on the middleware's mutation of its input, the
+// context values it sets, its metadata, and its propagation of errors,
+// without decorating handlers by hand.
+//
+//	next := &miditest.Next{
+//		Responses: []miditest.Response{{Err: errThrottled}, {}},
+//	}
+//	result := miditest.RunFinalize(t, retryer, req, next)
+//	result.AssertNoError(t)
+//	result.AssertCalls(t, 2)
+package miditest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/aws/smithy-go/middleware"
+	smithytesting "github.com/aws/smithy-go/testing"
+)
+
+// Response is a response of the scripted next handler.
+type Response struct {
+	// Result is the result returned by the next handler. For the
+	// Deserialize step, the raw response is returned in RawResponse.
+	Result interface{}
+
+	// RawResponse is the raw response returned by the next handler of the
+	// Deserialize step.
+	RawResponse interface{}
+
+	// Metadata is the metadata returned by the next handler.
+	Metadata middleware.Metadata
+
+	// Err is the error returned by the next handler.
+	Err error
+}
+
+// Call is the record of a call of the scripted next handler.
+type Call struct {
+	// Context is the context the next handler was called with.
+	Context context.Context
+
+	// Input is the input the next handler was called with, the parameters
+	// for the Initialize step, and the request for the other steps.
+	Input interface{}
+}
+
+// Next is the scripted next handler of the middleware under test. Each call
+// returns the next of the Responses, repeating the last response once all
+// are returned. If there are no responses, calls return an empty result.
+type Next struct {
+	Responses []Response
+
+	// Calls are the recorded calls of the next handler.
+	Calls []Call
+}
+
+func (n *Next) call(ctx context.Context, input interface{}) Response {
+	n.Calls = append(n.Calls, Call{Context: ctx, Input: input})
+
+	if len(n.Responses) == 0 {
+		return Response{}
+	}
+	i := len(n.Calls) - 1
+	if i >= len(n.Responses) {
+		i = len(n.Responses) - 1
+	}
+	return n.Responses[i]
+}
+
+// RunOptions provides the configuration of a middleware run.
+type RunOptions struct {
+	// Context is the context the middleware is called with. Defaults to
+	// context.Background.
+	Context context.Context
+}
+
+// Result is the result of running the middleware under test.
+type Result struct {
+	// Result is the result returned by the middleware. For the Deserialize
+	// step, the raw response is returned in RawResponse.
+	Result interface{}
+
+	// RawResponse is the raw response returned by the middleware of the
+	// Deserialize step.
+	RawResponse interface{}
+
+	Metadata middleware.Metadata
+	Err      error
+
+	// Calls are the recorded calls of the next handler.
+	Calls []Call
+}
+
+func newRunContext(optFns []func(*RunOptions)) context.Context {
+	o := RunOptions{
+		Context: context.Background(),
+	}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	return o.Context
+}
+
+// RunInitialize runs the Initialize step middleware with the parameters,
+// and the scripted next handler.
+func RunInitialize(t smithytesting.T, m middleware.InitializeMiddleware, params interface{}, next *Next, optFns ...func(*RunOptions)) Result {
+	t.Helper()
+
+	out, metadata, err := m.HandleInitialize(newRunContext(optFns), middleware.InitializeInput{Parameters: params},
+		middleware.InitializeHandlerFunc(func(ctx context.Context, in middleware.InitializeInput) (
+			middleware.InitializeOutput, middleware.Metadata, error,
+		) {
+			r := next.call(ctx, in.Parameters)
+			return middleware.InitializeOutput{Result: r.Result}, r.Metadata, r.Err
+		}))
+	return Result{Result: out.Result, Metadata: metadata, Err: err, Calls: next.Calls}
+}
+
+// RunSerialize runs the Serialize step middleware with the parameters, the
+// request, and the scripted next handler.
+func RunSerialize(t smithytesting.T, m middleware.SerializeMiddleware, params, req interface{}, next *Next, optFns ...func(*RunOptions)) Result {
+	t.Helper()
+
+	out, metadata, err := m.HandleSerialize(newRunContext(optFns), middleware.SerializeInput{Parameters: params, Request: req},
+		middleware.SerializeHandlerFunc(func(ctx context.Context, in middleware.SerializeInput) (
+			middleware.SerializeOutput, middleware.Metadata, error,
+		) {
+			r := next.call(ctx, in.Request)
+			return middleware.SerializeOutput{Result: r.Result}, r.Metadata, r.Err
+		}))
+	return Result{Result: out.Result, Metadata: metadata, Err: err, Calls: next.Calls}
+}
+
+// RunBuild runs the Build step middleware with the request, and the
+// scripted next handler.
+func RunBuild(t smithytesting.T, m middleware.BuildMiddleware, req interface{}, next *Next, optFns ...func(*RunOptions)) Result {
+	t.Helper()
+
+	out, metadata, err := m.HandleBuild(newRunContext(optFns), middleware.BuildInput{Request: req},
+		middleware.BuildHandlerFunc(func(ctx context.Context, in middleware.BuildInput) (
+			middleware.BuildOutput, middleware.Metadata, error,
+		) {
+			r := next.call(ctx, in.Request)
+			return middleware.BuildOutput{Result: r.Result}, r.Metadata, r.Err
+		}))
+	return Result{Result: out.Result, Metadata: metadata, Err: err, Calls: next.Calls}
+}
+
+// RunFinalize runs the Finalize step middleware with the request, and the
+// scripted next handler.
+func RunFinalize(t smithytesting.T, m middleware.FinalizeMiddleware, req interface{}, next *Next, optFns ...func(*RunOptions)) Result {
+	t.Helper()
+
+	out, metadata, err := m.HandleFinalize(newRunContext(optFns), middleware.FinalizeInput{Request: req},
+		middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (
+			middleware.FinalizeOutput, middleware.Metadata, error,
+		) {
+			r := next.call(ctx, in.Request)
+			return middleware.FinalizeOutput{Result: r.Result}, r.Metadata, r.Err
+		}))
+	return Result{Result: out.Result, Metadata: metadata, Err: err, Calls: next.Calls}
+}
+
+// RunDeserialize runs the Deserialize step middleware with the request, and
+// the scripted next handler.
+func RunDeserialize(t smithytesting.T, m middleware.DeserializeMiddleware, req interface{}, next *Next, optFns ...func(*RunOptions)) Result {
+	t.Helper()
+
+	out, metadata, err := m.HandleDeserialize(newRunContext(optFns), middleware.DeserializeInput{Request: req},
+		middleware.DeserializeHandlerFunc(func(ctx context.Context, in middleware.DeserializeInput) (
+			middleware.DeserializeOutput, middleware.Metadata, error,
+		) {
+			r := next.call(ctx, in.Request)
+			return middleware.DeserializeOutput{RawResponse: r.RawResponse, Result: r.Result}, r.Metadata, r.Err
+		}))
+	return Result{
+		Result:      out.Result,
+		RawResponse: out.RawResponse,
+		Metadata:    metadata,
+		Err:         err,
+		Calls:       next.Calls,
+	}
+}
+
+// AssertCalls asserts the next handler was called n times.
+func (r Result) AssertCalls(t smithytesting.T, n int) bool {
+	t.Helper()
+	if e, a := n, len(r.Calls); e != a {
+		t.Errorf("expect %d calls of next handler, got %d", e, a)
+		return false
+	}
+	return true
+}
+
+// AssertNoError asserts the middleware returned no error.
+func (r Result) AssertNoError(t smithytesting.T) bool {
+	t.Helper()
+	if r.Err != nil {
+		t.Errorf("expect no error, got %v", r.Err)
+		return false
+	}
+	return true
+}
+
+// AssertErrorIs asserts the middleware returned an error matching the
+// target, with errors.Is.
+func (r Result) AssertErrorIs(t smithytesting.T, target error) bool {
+	t.Helper()
+	if !errors.Is(r.Err, target) {
+		t.Errorf("expect error %v, got %v", target, r.Err)
+		return false
+	}
+	return true
+}
+
+// AssertErrorAs asserts the middleware returned an error of the target's
+// type, with errors.As. The target must be a non-nil pointer.
+func (r Result) AssertErrorAs(t smithytesting.T, target interface{}) bool {
+	t.Helper()
+	if r.Err == nil || !errors.As(r.Err, target) {
+		t.Errorf("expect %T error, got %v", target, r.Err)
+		return false
+	}
+	return true
+}
+
+// AssertMetadata asserts the middleware's metadata has the value for the
+// key.
+func (r Result) AssertMetadata(t smithytesting.T, key, expect interface{}) bool {
+	t.Helper()
+	if !r.Metadata.Has(key) {
+		t.Errorf("expect metadata %v to be set", key)
+		return false
+	}
+	if a := r.Metadata.Get(key); !reflect.DeepEqual(expect, a) {
+		t.Errorf("expect metadata %v to be %v, got %v", key, expect, a)
+		return false
+	}
+	return true
+}
+
+// AssertContextValue asserts the context of the i-th call of the next
+// handler has the value for the key, looked up with the lookup function,
+// such as middleware.GetStackValue.
+func (r Result) AssertContextValue(t smithytesting.T, i int, lookup func(context.Context) interface{}, expect interface{}) bool {
+	t.Helper()
+	if i < 0 || i >= len(r.Calls) {
+		t.Errorf("expect call %d of next handler, got %d calls", i, len(r.Calls))
+		return false
+	}
+	if a := lookup(r.Calls[i].Context); !reflect.DeepEqual(expect, a) {
+		t.Errorf("expect context value of call %d to be %v, got %v", i, expect, a)
+		return false
+	}
+	return true
+}
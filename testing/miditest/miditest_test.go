@@ -0,0 +1,144 @@
+package miditest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+type mockT struct {
+	errors []string
+}
+
+func (t *mockT) Error(args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprint(args...))
+}
+
+func (t *mockT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *mockT) Helper() {}
+
+var errMockThrottled = errors.New("throttled")
+
+type mockAttemptKey struct{}
+
+// mockRetry retries the next handler until it succeeds, or the attempts are
+// exhausted.
+type mockRetry struct {
+	attempts int
+}
+
+func (*mockRetry) ID() string { return "mockRetry" }
+
+func (m *mockRetry) HandleFinalize(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+	out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+) {
+	for i := 1; i <= m.attempts; i++ {
+		actx := middleware.WithStackValue(ctx, mockAttemptKey{}, i)
+		out, metadata, err = next.HandleFinalize(actx, in)
+		if err == nil {
+			break
+		}
+	}
+	metadata.Set(mockAttemptKey{}, len(fmt.Sprint(in.Request)))
+	return out, metadata, err
+}
+
+func TestRunFinalize(t *testing.T) {
+	next := &Next{
+		Responses: []Response{{Err: errMockThrottled}, {Result: "ok"}},
+	}
+	result := RunFinalize(t, &mockRetry{attempts: 3}, "req", next)
+
+	result.AssertNoError(t)
+	result.AssertCalls(t, 2)
+	result.AssertMetadata(t, mockAttemptKey{}, 3)
+	result.AssertContextValue(t, 1, func(ctx context.Context) interface{} {
+		return middleware.GetStackValue(ctx, mockAttemptKey{})
+	}, 2)
+	if e, a := "ok", result.Result; e != a {
+		t.Errorf("expect %v result, got %v", e, a)
+	}
+	if e, a := "req", result.Calls[0].Input; e != a {
+		t.Errorf("expect %v input, got %v", e, a)
+	}
+}
+
+func TestRunFinalizeError(t *testing.T) {
+	next := &Next{
+		Responses: []Response{{Err: errMockThrottled}},
+	}
+	result := RunFinalize(t, &mockRetry{attempts: 3}, "req", next)
+
+	result.AssertCalls(t, 3)
+	result.AssertErrorIs(t, errMockThrottled)
+
+	mt := &mockT{}
+	if result.AssertNoError(mt) {
+		t.Errorf("expect assertion to fail")
+	}
+	if result.AssertCalls(mt, 1) {
+		t.Errorf("expect assertion to fail")
+	}
+	if e, a := 2, len(mt.errors); e != a {
+		t.Errorf("expect %v failures, got %v", e, a)
+	}
+}
+
+type mockDeserialize struct{}
+
+func (mockDeserialize) ID() string { return "mockDeserialize" }
+
+func (mockDeserialize) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+	out.Result = fmt.Sprintf("deserialized %v", out.RawResponse)
+	return out, metadata, err
+}
+
+func TestRunDeserialize(t *testing.T) {
+	type ctxKey struct{}
+
+	next := &Next{
+		Responses: []Response{{RawResponse: "raw"}},
+	}
+	result := RunDeserialize(t, mockDeserialize{}, "req", next, func(o *RunOptions) {
+		o.Context = context.WithValue(context.Background(), ctxKey{}, "value")
+	})
+
+	result.AssertNoError(t)
+	result.AssertCalls(t, 1)
+	result.AssertContextValue(t, 0, func(ctx context.Context) interface{} {
+		return ctx.Value(ctxKey{})
+	}, "value")
+	if e, a := "deserialized raw", result.Result; e != a {
+		t.Errorf("expect %v result, got %v", e, a)
+	}
+	if e, a := "raw", result.RawResponse; e != a {
+		t.Errorf("expect %v raw response, got %v", e, a)
+	}
+}
+
+func TestRunInitialize(t *testing.T) {
+	m := middleware.InitializeMiddlewareFunc("mockInitialize", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+		middleware.InitializeOutput, middleware.Metadata, error,
+	) {
+		in.Parameters = fmt.Sprintf("%v-mutated", in.Parameters)
+		return next.HandleInitialize(ctx, in)
+	})
+
+	result := RunInitialize(t, m, "params", &Next{})
+	result.AssertNoError(t)
+	if e, a := "params-mutated", result.Calls[0].Input; e != a {
+		t.Errorf("expect %v input, got %v", e, a)
+	}
+}
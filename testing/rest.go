@@ -3,6 +3,8 @@ package testing
 import (
 	"fmt"
 	"net/http"
+	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -48,6 +50,77 @@ func AssertHasHeader(t T, expect, actual http.Header) bool {
 	return true
 }
 
+// HasHeaderList compares the header list values and identifies if the
+// actual header set includes all list values specified in the expect set.
+// Header list values are split on commas, respecting quoted strings, so a
+// list sent as repeated header fields is equal to the same list sent as a
+// single comma separated field. The order of list values is significant.
+// Returns an error if not.
+func HasHeaderList(expect, actual http.Header) error {
+	var errs errors
+	for key, es := range expect {
+		as := actual.Values(key)
+		if len(as) == 0 {
+			errs = append(errs, fmt.Errorf("expect %v header in %v",
+				key, actual))
+			continue
+		}
+
+		e, a := splitHeaderList(es), splitHeaderList(as)
+		if !reflect.DeepEqual(e, a) {
+			errs = append(errs, fmt.Errorf("expect %v=%q to match %q",
+				key, e, a))
+			continue
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// splitHeaderList splits the header field values on commas outside of
+// quoted strings, returning the trimmed list values.
+func splitHeaderList(vs []string) []string {
+	var list []string
+	for _, v := range vs {
+		var quoted, escaped bool
+		start := 0
+		for i := 0; i < len(v); i++ {
+			switch {
+			case escaped:
+				escaped = false
+			case v[i] == '\\' && quoted:
+				escaped = true
+			case v[i] == '"':
+				quoted = !quoted
+			case v[i] == ',' && !quoted:
+				list = append(list, strings.TrimSpace(v[start:i]))
+				start = i + 1
+			}
+		}
+		list = append(list, strings.TrimSpace(v[start:]))
+	}
+	return list
+}
+
+// AssertHasHeaderList compares the header list values and identifies if the
+// actual header set includes all list values specified in the expect set.
+// Emits a testing error, and returns false if the header lists are not
+// equal.
+func AssertHasHeaderList(t T, expect, actual http.Header) bool {
+	t.Helper()
+
+	if err := HasHeaderList(expect, actual); err != nil {
+		for _, e := range err.(errors) {
+			t.Error(e)
+		}
+		return false
+	}
+	return true
+}
+
 // HasHeaderKeys validates that header set contains all keys expected. Returns
 // an error if a header key is not in the header set.
 func HasHeaderKeys(keys []string, actual http.Header) error {
@@ -229,3 +302,36 @@ func AssertNotHaveQueryKeys(t T, keys []string, actual []QueryItem) bool {
 	}
 	return true
 }
+
+// QueryEqual compares two raw query strings and identifies if they contain
+// the same query items. The order of items with different keys is not
+// significant, but the order of values of a repeated key is. Returns an
+// error if the query strings are not equal.
+func QueryEqual(expect, actual string) error {
+	e, a := sortQueryItems(ParseRawQuery(expect)), sortQueryItems(ParseRawQuery(actual))
+	if !reflect.DeepEqual(e, a) {
+		return fmt.Errorf("expect query %v to match %v", e, a)
+	}
+	return nil
+}
+
+// AssertQueryEqual compares two raw query strings and identifies if they
+// contain the same query items. Emits a testing error, and returns false if
+// the query strings are not equal.
+func AssertQueryEqual(t T, expect, actual string) bool {
+	t.Helper()
+
+	if err := QueryEqual(expect, actual); err != nil {
+		t.Error(err)
+		return false
+	}
+	return true
+}
+
+func sortQueryItems(items []QueryItem) []QueryItem {
+	items = append([]QueryItem{}, items...)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Key < items[j].Key
+	})
+	return items
+}
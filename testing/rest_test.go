@@ -0,0 +1,84 @@
+package testing
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestHasHeaderList(t *testing.T) {
+	cases := map[string]struct {
+		expect, actual http.Header
+		expectErr      bool
+	}{
+		"single field": {
+			expect: http.Header{"X-List": []string{"a, b, c"}},
+			actual: http.Header{"X-List": []string{"a,b,c"}},
+		},
+		"repeated fields": {
+			expect: http.Header{"X-List": []string{"a, b, c"}},
+			actual: http.Header{"X-List": []string{"a", "b", "c"}},
+		},
+		"quoted values": {
+			expect: http.Header{"X-List": []string{`"b,c", "\"def\""`}},
+			actual: http.Header{"X-List": []string{`"b,c"`, `"\"def\""`}},
+		},
+		"order mismatch": {
+			expect:    http.Header{"X-List": []string{"a, b"}},
+			actual:    http.Header{"X-List": []string{"b, a"}},
+			expectErr: true,
+		},
+		"missing": {
+			expect:    http.Header{"X-List": []string{"a"}},
+			actual:    http.Header{},
+			expectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := HasHeaderList(c.expect, c.actual)
+			if e, a := c.expectErr, err != nil; e != a {
+				t.Errorf("expect error %v, got %v", e, err)
+			}
+		})
+	}
+}
+
+func TestQueryEqual(t *testing.T) {
+	cases := map[string]struct {
+		expect, actual string
+		expectErr      bool
+	}{
+		"reordered keys": {
+			expect: "a=1&b=2",
+			actual: "b=2&a=1",
+		},
+		"repeated key order": {
+			expect: "a=1&b=2&a=3",
+			actual: "a=1&a=3&b=2",
+		},
+		"repeated key reordered": {
+			expect:    "a=1&a=3",
+			actual:    "a=3&a=1",
+			expectErr: true,
+		},
+		"space escaping": {
+			expect: "a=b%20c",
+			actual: "a=b+c",
+		},
+		"missing item": {
+			expect:    "a=1&b=2",
+			actual:    "a=1",
+			expectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := QueryEqual(c.expect, c.actual)
+			if e, a := c.expectErr, err != nil; e != a {
+				t.Errorf("expect error %v, got %v", e, err)
+			}
+		})
+	}
+}
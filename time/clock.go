@@ -0,0 +1,198 @@
+package time
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers to time-dependent behavior such
+// as waiters and retries, so that the behavior can be tested without real
+// sleeps.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTimer returns a Timer that will send the current time on its
+	// channel after at least the duration.
+	NewTimer(d time.Duration) Timer
+
+	// Sleep waits for the duration to elapse, or the context to be canceled,
+	// whichever happens first. If the context is canceled the context's error
+	// will be returned.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// Timer is a single event timer created by a Clock.
+type Timer interface {
+	// C returns the channel the time is sent on when the timer fires.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing. Returns false if the timer
+	// already fired or was stopped.
+	Stop() bool
+}
+
+// SystemClock is a Clock backed by the system's time, using the monotonic
+// clock reading for durations.
+type SystemClock struct{}
+
+var _ Clock = SystemClock{}
+
+// Now returns the current system time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTimer returns a Timer backed by time.Timer.
+func (SystemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+// Sleep waits for the duration to elapse, or the context to be canceled.
+func (c SystemClock) Sleep(ctx context.Context, d time.Duration) error {
+	return sleep(ctx, c, d)
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// FakeClock is a Clock whose time only moves when advanced, for use in tests.
+// Timers created by the clock fire when the clock is advanced past their
+// deadline.
+//
+// FakeClock is safe for concurrent use.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+var _ Clock = (*FakeClock)(nil)
+
+// NewFakeClock returns a FakeClock set to the provided time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// NewTimer returns a Timer that fires when the clock is advanced by at least
+// the duration. A timer with a non-positive duration fires immediately.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{
+		clock:    c,
+		deadline: c.now.Add(d),
+		ch:       make(chan time.Time, 1),
+	}
+	if d <= 0 {
+		t.ch <- c.now
+		return t
+	}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Sleep waits for the clock to be advanced by the duration, or the context to
+// be canceled.
+func (c *FakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	return sleep(ctx, c, d)
+}
+
+// Advance moves the clock forward by the duration, firing all timers whose
+// deadline has been reached.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.deadline.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.ch <- c.now
+	}
+	c.timers = pending
+}
+
+// Timers returns the number of timers waiting to fire. Tests can use this to
+// wait for the code under test to start sleeping before advancing the clock.
+func (c *FakeClock) Timers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
+
+func (c *FakeClock) stop(t *fakeTimer) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, v := range c.timers {
+		if v == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	return t.clock.stop(t)
+}
+
+type clockKey struct{}
+
+// WithClock returns a context with the Clock that time-dependent behavior,
+// e.g. SleepWithContext, invoked with the context should use.
+func WithClock(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, clock)
+}
+
+// GetClock returns the Clock set on the context with WithClock. If none was
+// set, SystemClock is returned.
+func GetClock(ctx context.Context) Clock {
+	if c, ok := ctx.Value(clockKey{}).(Clock); ok && c != nil {
+		return c
+	}
+	return SystemClock{}
+}
+
+func sleep(ctx context.Context, clock Clock, d time.Duration) error {
+	t := clock.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,83 @@
+package time
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFakeClock_Advance(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	short := clock.NewTimer(time.Second)
+	long := clock.NewTimer(time.Minute)
+	stopped := clock.NewTimer(time.Second)
+	if !stopped.Stop() {
+		t.Fatalf("expect timer stopped")
+	}
+	if e, a := 2, clock.Timers(); e != a {
+		t.Fatalf("expect %v timers, got %v", e, a)
+	}
+
+	clock.Advance(2 * time.Second)
+	if e, a := start.Add(2*time.Second), clock.Now(); !e.Equal(a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	select {
+	case v := <-short.C():
+		if e, a := start.Add(2*time.Second), v; !e.Equal(a) {
+			t.Errorf("expect %v, got %v", e, a)
+		}
+	default:
+		t.Errorf("expect short timer fired")
+	}
+	select {
+	case <-long.C():
+		t.Errorf("expect long timer not fired")
+	case <-stopped.C():
+		t.Errorf("expect stopped timer not fired")
+	default:
+	}
+	if e, a := 1, clock.Timers(); e != a {
+		t.Errorf("expect %v timers, got %v", e, a)
+	}
+}
+
+func TestSleepWithContext_FakeClock(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	ctx := WithClock(context.Background(), clock)
+
+	done := make(chan error)
+	go func() {
+		done <- SleepWithContext(ctx, time.Hour)
+	}()
+
+	for clock.Timers() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	clock.Advance(time.Hour)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expect sleep to return after advance")
+	}
+}
+
+func TestSleepWithContext_Canceled(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	ctx, cancel := context.WithCancel(WithClock(context.Background(), clock))
+	cancel()
+
+	if e, a := context.Canceled, SleepWithContext(ctx, time.Hour); e != a {
+		t.Errorf("expect %v error, got %v", e, a)
+	}
+	if e, a := 0, clock.Timers(); e != a {
+		t.Errorf("expect %v timers, got %v", e, a)
+	}
+}
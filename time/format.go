@@ -0,0 +1,190 @@
+package time
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is a Smithy timestamp format, as modeled by the timestampFormat
+// trait.
+type Format string
+
+// Enumeration of the Smithy timestamp formats.
+const (
+	// DateTime is a RFC3339 section 5.6 date-time.
+	DateTime Format = "date-time"
+
+	// HTTPDate is a RFC 7231#section-7.1.1.1 IMF-fixdate http-date.
+	HTTPDate Format = "http-date"
+
+	// EpochSeconds is the number of seconds since the Unix epoch, with
+	// optional fractional precision.
+	EpochSeconds Format = "epoch-seconds"
+)
+
+// ParseOptions provides the options for parsing a timestamp with Parse.
+type ParseOptions struct {
+	// Lenient enables accepting common malformed timestamps that are not
+	// strictly of the expected format, e.g. a date-time without the trailing
+	// "Z", or a http-date with a two-digit year or without the day of week.
+	Lenient bool
+}
+
+const (
+	// httpDateFormatTwoDigitYearFractional is a http-date with a two-digit
+	// year and fractional seconds.
+	httpDateFormatTwoDigitYearFractional = "Mon, _2 Jan 06 15:04:05.999999999 GMT"
+	// httpDateFormatNoWeekday is a http-date without the day of week.
+	httpDateFormatNoWeekday = "_2 Jan 2006 15:04:05.999999999 GMT"
+	// httpDateFormatUTC is a http-date with a UTC zone instead of GMT.
+	httpDateFormatUTC = "Mon, _2 Jan 2006 15:04:05.999999999 UTC"
+
+	// dateTimeFormatNoOffset is a date-time missing the "Z" offset.
+	dateTimeFormatNoOffset = "2006-01-02T15:04:05.999999999"
+	// dateTimeFormatSpace is a date-time with a space separating the date
+	// and time.
+	dateTimeFormatSpace = "2006-01-02 15:04:05.999999999Z07:00"
+	// dateTimeFormatSpaceNoOffset is a date-time with a space separating the
+	// date and time, and missing the "Z" offset.
+	dateTimeFormatSpaceNoOffset = "2006-01-02 15:04:05.999999999"
+)
+
+// FormatTimestamp returns value formatted as the timestamp format. Epoch
+// seconds are formatted with up to nanosecond precision.
+//
+// Returns an error if the format is unknown.
+func FormatTimestamp(format Format, value time.Time) (string, error) {
+	switch format {
+	case DateTime:
+		return FormatDateTime(value), nil
+	case HTTPDate:
+		return FormatHTTPDate(value), nil
+	case EpochSeconds:
+		return FormatEpochSecondsString(value), nil
+	default:
+		return "", fmt.Errorf("unknown timestamp format, %q", format)
+	}
+}
+
+// ParseTimestamp parses value as the timestamp format. The returned time is
+// always in UTC.
+//
+// Returns an error if the format is unknown, or the value could not be
+// parsed as the format.
+func ParseTimestamp(format Format, value string, optFns ...func(*ParseOptions)) (time.Time, error) {
+	var options ParseOptions
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	var t time.Time
+	var err error
+	switch format {
+	case DateTime:
+		t, err = ParseDateTime(value)
+		if err != nil && options.Lenient {
+			t, err = tryParse(strings.ToUpper(value),
+				time.RFC3339Nano,
+				dateTimeFormatNoOffset,
+				dateTimeFormatSpace,
+				dateTimeFormatSpaceNoOffset,
+			)
+		}
+	case HTTPDate:
+		t, err = ParseHTTPDate(value)
+		if err != nil && options.Lenient {
+			t, err = tryParse(value,
+				httpDateFormatTwoDigitYearFractional,
+				httpDateFormatNoWeekday,
+				httpDateFormatUTC,
+				time.RFC1123Z,
+			)
+		}
+	case EpochSeconds:
+		t, err = ParseEpochSecondsString(value)
+	default:
+		return time.Time{}, fmt.Errorf("unknown timestamp format, %q", format)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.UTC(), nil
+}
+
+// FormatEpochSecondsString returns value as a Unix time in seconds, with up
+// to nanosecond precision. Trailing zeros of the fractional seconds are
+// omitted.
+//
+// Example: 1515531081.123456789
+func FormatEpochSecondsString(value time.Time) string {
+	sec, nsec := value.Unix(), value.Nanosecond()
+	if nsec == 0 {
+		return strconv.FormatInt(sec, 10)
+	}
+
+	// Unix seconds are floored, negative values with fractional seconds
+	// need to be offset to format as a decimal.
+	sign := ""
+	if sec < 0 {
+		sec, nsec = sec+1, 1e9-nsec
+		if sec == 0 {
+			sign = "-"
+		}
+	}
+
+	frac := strings.TrimRight(fmt.Sprintf("%09d", nsec), "0")
+	return sign + strconv.FormatInt(sec, 10) + "." + frac
+}
+
+// ParseEpochSecondsString parses value as a Unix time in seconds, with up to
+// nanosecond precision. Unlike ParseEpochSeconds the value is not rounded to
+// milliseconds.
+//
+// Example: 1515531081.123456789
+func ParseEpochSecondsString(value string) (time.Time, error) {
+	v := value
+	neg := strings.HasPrefix(v, "-")
+	if neg {
+		v = v[1:]
+	}
+
+	secPart, fracPart := v, ""
+	if i := strings.IndexByte(v, '.'); i >= 0 {
+		secPart, fracPart = v[:i], v[i+1:]
+	}
+	// strconv.ParseInt accepts a sign, so signs within the value are rejected
+	// before the parts are parsed.
+	if len(secPart) == 0 || len(fracPart) > 9 || !isDigits(secPart) || !isDigits(fracPart) {
+		return time.Time{}, fmt.Errorf("invalid epoch seconds, %q", value)
+	}
+
+	sec, err := strconv.ParseInt(secPart, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid epoch seconds, %q, %w", value, err)
+	}
+
+	var nsec int64
+	if len(fracPart) != 0 {
+		nsec, err = strconv.ParseInt(fracPart+strings.Repeat("0", 9-len(fracPart)), 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid epoch seconds, %q", value)
+		}
+	}
+
+	if neg {
+		sec, nsec = -sec, -nsec
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
+
+func isDigits(v string) bool {
+	for i := 0; i < len(v); i++ {
+		if v[i] < '0' || v[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,191 @@
+package time
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	lenient := func(o *ParseOptions) { o.Lenient = true }
+
+	cases := map[string]struct {
+		Format    Format
+		Value     string
+		Options   []func(*ParseOptions)
+		Expect    time.Time
+		ExpectErr bool
+	}{
+		"date-time": {
+			Format: DateTime,
+			Value:  "1985-04-12T23:20:50.52Z",
+			Expect: time.Date(1985, 4, 12, 23, 20, 50, 520e6, time.UTC),
+		},
+		"date-time offset": {
+			Format: DateTime,
+			Value:  "1985-04-12T23:20:50.52-07:00",
+			Expect: time.Date(1985, 4, 13, 6, 20, 50, 520e6, time.UTC),
+		},
+		"date-time missing Z": {
+			Format:    DateTime,
+			Value:     "1985-04-12T23:20:50.52",
+			ExpectErr: true,
+		},
+		"lenient date-time missing Z": {
+			Format:  DateTime,
+			Value:   "1985-04-12T23:20:50.52",
+			Options: []func(*ParseOptions){lenient},
+			Expect:  time.Date(1985, 4, 12, 23, 20, 50, 520e6, time.UTC),
+		},
+		"lenient date-time lowercase": {
+			Format:  DateTime,
+			Value:   "1985-04-12t23:20:50z",
+			Options: []func(*ParseOptions){lenient},
+			Expect:  time.Date(1985, 4, 12, 23, 20, 50, 0, time.UTC),
+		},
+		"lenient date-time space": {
+			Format:  DateTime,
+			Value:   "1985-04-12 23:20:50+01:00",
+			Options: []func(*ParseOptions){lenient},
+			Expect:  time.Date(1985, 4, 12, 22, 20, 50, 0, time.UTC),
+		},
+		"http-date": {
+			Format: HTTPDate,
+			Value:  "Tue, 29 Apr 2014 18:30:38 GMT",
+			Expect: time.Date(2014, 4, 29, 18, 30, 38, 0, time.UTC),
+		},
+		"http-date fractional": {
+			Format: HTTPDate,
+			Value:  "Tue, 29 Apr 2014 18:30:38.123 GMT",
+			Expect: time.Date(2014, 4, 29, 18, 30, 38, 123e6, time.UTC),
+		},
+		"http-date no weekday": {
+			Format:    HTTPDate,
+			Value:     "29 Apr 2014 18:30:38 GMT",
+			ExpectErr: true,
+		},
+		"lenient http-date no weekday": {
+			Format:  HTTPDate,
+			Value:   "29 Apr 2014 18:30:38 GMT",
+			Options: []func(*ParseOptions){lenient},
+			Expect:  time.Date(2014, 4, 29, 18, 30, 38, 0, time.UTC),
+		},
+		"lenient http-date two-digit year fractional": {
+			Format:  HTTPDate,
+			Value:   "Tue, 29 Apr 14 18:30:38.5 GMT",
+			Options: []func(*ParseOptions){lenient},
+			Expect:  time.Date(2014, 4, 29, 18, 30, 38, 500e6, time.UTC),
+		},
+		"lenient http-date numeric offset": {
+			Format:  HTTPDate,
+			Value:   "Tue, 29 Apr 2014 18:30:38 +0200",
+			Options: []func(*ParseOptions){lenient},
+			Expect:  time.Date(2014, 4, 29, 16, 30, 38, 0, time.UTC),
+		},
+		"epoch-seconds": {
+			Format: EpochSeconds,
+			Value:  "1515531081.123456789",
+			Expect: time.Date(2018, 1, 9, 20, 51, 21, 123456789, time.UTC),
+		},
+		"epoch-seconds negative": {
+			Format: EpochSeconds,
+			Value:  "-0.5",
+			Expect: time.Date(1969, 12, 31, 23, 59, 59, 500e6, time.UTC),
+		},
+		"epoch-seconds too precise": {
+			Format:    EpochSeconds,
+			Value:     "1.0123456789",
+			ExpectErr: true,
+		},
+		"epoch-seconds signed fraction": {
+			Format:    EpochSeconds,
+			Value:     "1.+5",
+			ExpectErr: true,
+		},
+		"epoch-seconds negative fraction": {
+			Format:    EpochSeconds,
+			Value:     "1.-5",
+			ExpectErr: true,
+		},
+		"epoch-seconds double sign": {
+			Format:    EpochSeconds,
+			Value:     "--1",
+			ExpectErr: true,
+		},
+		"epoch-seconds plus sign": {
+			Format:    EpochSeconds,
+			Value:     "+1.5",
+			ExpectErr: true,
+		},
+		"unknown format": {
+			Format:    Format("unknown"),
+			Value:     "1",
+			ExpectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, err := ParseTimestamp(c.Format, c.Value, c.Options...)
+			if c.ExpectErr {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Expect, v; !e.Equal(a) {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+			if e, a := time.UTC, v.Location(); e != a {
+				t.Errorf("expect %v location, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestFormatEpochSecondsString(t *testing.T) {
+	cases := map[string]struct {
+		Value  time.Time
+		Expect string
+	}{
+		"whole seconds": {
+			Value:  time.Unix(1515531081, 0),
+			Expect: "1515531081",
+		},
+		"nanoseconds": {
+			Value:  time.Unix(1515531081, 123456789),
+			Expect: "1515531081.123456789",
+		},
+		"trailing zeros": {
+			Value:  time.Unix(1515531081, 120e6),
+			Expect: "1515531081.12",
+		},
+		"negative fractional": {
+			Value:  time.Unix(-2, 500e6),
+			Expect: "-1.5",
+		},
+		"negative sub-second": {
+			Value:  time.Unix(-1, 750e6),
+			Expect: "-0.25",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := FormatEpochSecondsString(c.Value)
+			if e, a := c.Expect, s; e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+
+			v, err := ParseEpochSecondsString(s)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Value, v; !e.Equal(a) {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
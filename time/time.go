@@ -16,6 +16,8 @@ const (
 	// httpDateFormat is a date time defined by RFC 7231#section-7.1.1.1
 	// IMF-fixdate with no UTC offset.
 	httpDateFormat = "Mon, 02 Jan 2006 15:04:05 GMT"
+	// httpDateFormatFractional is a http-date with fractional seconds.
+	httpDateFormatFractional = "Mon, 02 Jan 2006 15:04:05.999999999 GMT"
 	// Additional formats needed for compatibility.
 	httpDateFormatSingleDigitDay             = "Mon, _2 Jan 2006 15:04:05 GMT"
 	httpDateFormatSingleDigitDayTwoDigitYear = "Mon, _2 Jan 06 15:04:05 GMT"
@@ -30,15 +32,20 @@ func FormatDateTime(value time.Time) string {
 	return value.UTC().Format(dateTimeFormatOutput)
 }
 
-// ParseDateTime parse a string as a date-time, (RFC3339 section 5.6)
+// ParseDateTime parse a string as a date-time, (RFC3339 section 5.6). Values
+// with a UTC offset are normalized to UTC.
 //
 // Example: 1985-04-12T23:20:50.52Z
 func ParseDateTime(value string) (time.Time, error) {
-	return tryParse(value,
+	t, err := tryParse(value,
 		dateTimeFormatInput,
 		time.RFC3339Nano,
 		time.RFC3339,
 	)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
 }
 
 // FormatHTTPDate format value as a http-date, (RFC 7231#section-7.1.1.1 IMF-fixdate)
@@ -49,11 +56,13 @@ func FormatHTTPDate(value time.Time) string {
 }
 
 // ParseHTTPDate parse a string as a http-date, (RFC 7231#section-7.1.1.1 IMF-fixdate)
+// with optional fractional seconds.
 //
 // Example: Tue, 29 Apr 2014 18:30:38 GMT
 func ParseHTTPDate(value string) (time.Time, error) {
 	return tryParse(value,
 		httpDateFormat,
+		httpDateFormatFractional,
 		httpDateFormatSingleDigitDay,
 		httpDateFormatSingleDigitDayTwoDigitYear,
 		time.RFC850,
@@ -116,17 +125,8 @@ type parseError struct {
 
 // SleepWithContext will wait for the timer duration to expire, or the context
 // is canceled. Which ever happens first. If the context is canceled the
-// Context's error will be returned.
+// Context's error will be returned. The timer is created by the context's
+// Clock, see WithClock.
 func SleepWithContext(ctx context.Context, dur time.Duration) error {
-	t := time.NewTimer(dur)
-	defer t.Stop()
-
-	select {
-	case <-t.C:
-		break
-	case <-ctx.Done():
-		return ctx.Err()
-	}
-
-	return nil
+	return GetClock(ctx).Sleep(ctx, dur)
 }
@@ -0,0 +1,48 @@
+package tracing
+
+import "context"
+
+type (
+	operationTracerKey struct{}
+	spanKey            struct{}
+)
+
+// GetSpan returns the active trace Span on the context.
+//
+// The boolean in the return indicates whether a Span was actually in the
+// context, but a no-op implementation will be returned if not, so callers
+// can generally disregard the boolean unless they wish to explicitly confirm
+// presence/absence of a Span.
+func GetSpan(ctx context.Context) (Span, bool) {
+	span, ok := ctx.Value(spanKey{}).(Span)
+	if !ok {
+		return nopSpan{}, false
+	}
+	return span, true
+}
+
+// WithSpan sets the active trace Span on the context.
+func WithSpan(parent context.Context, span Span) context.Context {
+	return context.WithValue(parent, spanKey{}, span)
+}
+
+// WithOperationTracer sets the tracer used to create spans for the operation
+// being invoked with the context.
+func WithOperationTracer(ctx context.Context, tracer Tracer) context.Context {
+	return context.WithValue(ctx, operationTracerKey{}, tracer)
+}
+
+// GetOperationTracer returns the tracer for the operation being invoked with
+// the context. A no-op tracer is returned if none was set.
+func GetOperationTracer(ctx context.Context) Tracer {
+	if t, ok := ctx.Value(operationTracerKey{}).(Tracer); ok {
+		return t
+	}
+	return nopTracer{}
+}
+
+// StartSpan is a convenience API for creating tracing Spans from a Context
+// using the operation tracer set on the context.
+func StartSpan(ctx context.Context, name string, opts ...SpanOption) (context.Context, Span) {
+	return GetOperationTracer(ctx).StartSpan(ctx, name, opts...)
+}
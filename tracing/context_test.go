@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetSpan(t *testing.T) {
+	span, ok := GetSpan(context.Background())
+	if ok {
+		t.Errorf("expect no span on context")
+	}
+	if span == nil {
+		t.Fatalf("expect no-op span, got nil")
+	}
+
+	expect := nopSpan{}
+	ctx := WithSpan(context.Background(), expect)
+	if span, ok := GetSpan(ctx); !ok {
+		t.Errorf("expect span on context")
+	} else if span != expect {
+		t.Errorf("expect %v span, got %v", expect, span)
+	}
+}
+
+func TestStartSpan(t *testing.T) {
+	ctx, span := StartSpan(context.Background(), "foo")
+	if _, ok := span.(nopSpan); !ok {
+		t.Errorf("expect no-op span without operation tracer, got %T", span)
+	}
+	if _, ok := GetSpan(ctx); ok {
+		t.Errorf("expect no-op tracer to not set span on context")
+	}
+}
@@ -0,0 +1,7 @@
+// Package tracing defines the interfaces used by clients and middleware to
+// record traces of an operation invocation. The interfaces are modeled after
+// the OpenTelemetry tracing API, so that a TracerProvider may be bridged
+// directly to an OpenTelemetry SDK implementation.
+//
+// Clients default to NopTracerProvider, which records nothing.
+package tracing
@@ -0,0 +1,131 @@
+package tracing
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// TextMapCarrier is the storage medium used by a Propagator to inject
+// cross-cutting concerns into an outgoing message, e.g. HTTP headers.
+type TextMapCarrier interface {
+	Get(key string) string
+	Set(key, value string)
+}
+
+// Propagator injects cross-cutting concerns from the context into a carrier.
+type Propagator interface {
+	Inject(ctx context.Context, carrier TextMapCarrier)
+}
+
+// PropagatorFunc is a function type that implements the Propagator
+// interface.
+type PropagatorFunc func(ctx context.Context, carrier TextMapCarrier)
+
+// Inject delegates to the wrapped function.
+func (fn PropagatorFunc) Inject(ctx context.Context, carrier TextMapCarrier) {
+	fn(ctx, carrier)
+}
+
+// CompositePropagator injects with each of its propagators in order.
+type CompositePropagator []Propagator
+
+// Inject delegates to each propagator in order.
+func (ps CompositePropagator) Inject(ctx context.Context, carrier TextMapCarrier) {
+	for _, p := range ps {
+		p.Inject(ctx, carrier)
+	}
+}
+
+// W3C Trace Context and Baggage header names.
+const (
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+	BaggageHeader     = "baggage"
+)
+
+// TraceContext is a Propagator that injects the active span's context in the
+// W3C Trace Context format, https://www.w3.org/TR/trace-context/.
+//
+// Nothing is injected if the context has no active span with a valid span
+// context.
+type TraceContext struct{}
+
+// Inject sets the traceparent, and if present tracestate, values of the
+// active span on the carrier.
+func (TraceContext) Inject(ctx context.Context, carrier TextMapCarrier) {
+	span, ok := GetSpan(ctx)
+	if !ok {
+		return
+	}
+
+	sc := span.Context()
+	if !sc.IsValid() {
+		return
+	}
+
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	carrier.Set(TraceParentHeader, "00-"+sc.TraceID+"-"+sc.SpanID+"-"+flags)
+
+	if len(sc.TraceState) != 0 {
+		carrier.Set(TraceStateHeader, sc.TraceState)
+	}
+}
+
+type baggageKey struct{}
+
+// WithBaggage returns a context with the key/value pair added to the baggage
+// propagated by the Baggage propagator. Baggage set on the parent context is
+// retained.
+func WithBaggage(ctx context.Context, key, value string) context.Context {
+	parent := GetBaggage(ctx)
+	baggage := make(map[string]string, len(parent)+1)
+	for k, v := range parent {
+		baggage[k] = v
+	}
+	baggage[key] = value
+
+	return context.WithValue(ctx, baggageKey{}, baggage)
+}
+
+// GetBaggage returns the baggage key/value pairs set on the context. The
+// returned map must not be modified.
+func GetBaggage(ctx context.Context) map[string]string {
+	v, _ := ctx.Value(baggageKey{}).(map[string]string)
+	return v
+}
+
+// Baggage is a Propagator that injects the baggage set on the context with
+// WithBaggage in the W3C Baggage format, https://www.w3.org/TR/baggage/.
+//
+// Members are sorted by key, and merged with any baggage already set on the
+// carrier.
+type Baggage struct{}
+
+// Inject sets the baggage value on the carrier.
+func (Baggage) Inject(ctx context.Context, carrier TextMapCarrier) {
+	baggage := GetBaggage(ctx)
+	if len(baggage) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(baggage))
+	for k := range baggage {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	members := make([]string, 0, len(keys)+1)
+	if existing := carrier.Get(BaggageHeader); len(existing) != 0 {
+		members = append(members, existing)
+	}
+	for _, k := range keys {
+		members = append(members, url.PathEscape(k)+"="+url.PathEscape(baggage[k]))
+	}
+
+	carrier.Set(BaggageHeader, strings.Join(members, ","))
+}
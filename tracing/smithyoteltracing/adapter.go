@@ -0,0 +1,167 @@
+package smithyoteltracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// Adapt wraps a concrete OpenTelemetry SDK TracerProvider for use with Smithy
+// clients.
+//
+// Adapt can be called multiple times on a single TracerProvider.
+func Adapt(tp oteltrace.TracerProvider) tracing.TracerProvider {
+	return &tracerProvider{otel: tp}
+}
+
+type tracerProvider struct {
+	otel oteltrace.TracerProvider
+}
+
+var _ tracing.TracerProvider = (*tracerProvider)(nil)
+
+func (p *tracerProvider) Tracer(scope string, opts ...tracing.TracerOption) tracing.Tracer {
+	var o tracing.TracerOptions
+	for _, fn := range opts {
+		fn(&o)
+	}
+
+	return &tracer{otel: p.otel.Tracer(scope, oteltrace.WithInstrumentationAttributes(
+		toOTELKeyValues(o.Properties)...,
+	))}
+}
+
+type tracer struct {
+	otel oteltrace.Tracer
+}
+
+var _ tracing.Tracer = (*tracer)(nil)
+
+func (t *tracer) StartSpan(ctx context.Context, name string, opts ...tracing.SpanOption) (
+	context.Context, tracing.Span,
+) {
+	var o tracing.SpanOptions
+	for _, fn := range opts {
+		fn(&o)
+	}
+
+	ctx, s := t.otel.Start(ctx, name,
+		oteltrace.WithSpanKind(toOTELSpanKind(o.Kind)),
+		oteltrace.WithAttributes(toOTELKeyValues(o.Properties)...),
+	)
+
+	sp := &span{name: name, otel: s}
+	return tracing.WithSpan(ctx, sp), sp
+}
+
+type span struct {
+	name string
+	otel oteltrace.Span
+}
+
+var _ tracing.Span = (*span)(nil)
+
+func (s *span) Name() string {
+	return s.name
+}
+
+func (s *span) Context() tracing.SpanContext {
+	ctx := s.otel.SpanContext()
+	return tracing.SpanContext{
+		TraceID:    ctx.TraceID().String(),
+		SpanID:     ctx.SpanID().String(),
+		IsRemote:   ctx.IsRemote(),
+		Sampled:    ctx.IsSampled(),
+		TraceState: ctx.TraceState().String(),
+	}
+}
+
+func (s *span) AddEvent(name string, opts ...tracing.EventOption) {
+	var o tracing.EventOptions
+	for _, fn := range opts {
+		fn(&o)
+	}
+
+	s.otel.AddEvent(name, oteltrace.WithAttributes(toOTELKeyValues(o.Properties)...))
+}
+
+func (s *span) SetStatus(status tracing.SpanStatus) {
+	s.otel.SetStatus(toOTELSpanStatus(status), "")
+}
+
+func (s *span) SetProperty(k, v interface{}) {
+	s.otel.SetAttributes(toOTELKeyValue(fmt.Sprint(k), v))
+}
+
+func (s *span) End() {
+	s.otel.End()
+}
+
+func toOTELSpanKind(kind tracing.SpanKind) oteltrace.SpanKind {
+	switch kind {
+	case tracing.SpanKindClient:
+		return oteltrace.SpanKindClient
+	case tracing.SpanKindServer:
+		return oteltrace.SpanKindServer
+	case tracing.SpanKindProducer:
+		return oteltrace.SpanKindProducer
+	case tracing.SpanKindConsumer:
+		return oteltrace.SpanKindConsumer
+	default:
+		return oteltrace.SpanKindInternal
+	}
+}
+
+func toOTELSpanStatus(status tracing.SpanStatus) otelcodes.Code {
+	switch status {
+	case tracing.SpanStatusOK:
+		return otelcodes.Ok
+	case tracing.SpanStatusError:
+		return otelcodes.Error
+	default:
+		return otelcodes.Unset
+	}
+}
+
+// toOTELKeyValues converts properties to OTEL attributes. Keys are formatted
+// with fmt.Sprint, and values of unsupported types are formatted as strings.
+func toOTELKeyValues(props smithy.Properties) []attribute.KeyValue {
+	values := props.Values()
+	kvs := make([]attribute.KeyValue, 0, len(values))
+	for k, v := range values {
+		kvs = append(kvs, toOTELKeyValue(fmt.Sprint(k), v))
+	}
+	return kvs
+}
+
+func toOTELKeyValue(k string, v interface{}) attribute.KeyValue {
+	switch vv := v.(type) {
+	case bool:
+		return attribute.Bool(k, vv)
+	case []bool:
+		return attribute.BoolSlice(k, vv)
+	case int:
+		return attribute.Int(k, vv)
+	case []int:
+		return attribute.IntSlice(k, vv)
+	case int64:
+		return attribute.Int64(k, vv)
+	case []int64:
+		return attribute.Int64Slice(k, vv)
+	case float64:
+		return attribute.Float64(k, vv)
+	case []float64:
+		return attribute.Float64Slice(k, vv)
+	case string:
+		return attribute.String(k, vv)
+	case []string:
+		return attribute.StringSlice(k, vv)
+	default:
+		return attribute.String(k, fmt.Sprint(vv))
+	}
+}
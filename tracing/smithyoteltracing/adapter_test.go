@@ -0,0 +1,47 @@
+package smithyoteltracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/smithy-go/tracing"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestAdapt(t *testing.T) {
+	tr := Adapt(noop.NewTracerProvider()).Tracer("scope")
+
+	ctx, span := tr.StartSpan(context.Background(), "foo", tracing.WithSpanKind(tracing.SpanKindClient))
+	if e, a := "foo", span.Name(); e != a {
+		t.Errorf("expect %v name, got %v", e, a)
+	}
+
+	active, ok := tracing.GetSpan(ctx)
+	if !ok {
+		t.Fatalf("expect span on context")
+	}
+	if active != span {
+		t.Errorf("expect active span to be started span")
+	}
+
+	span.SetProperty("key", "value")
+	span.SetStatus(tracing.SpanStatusOK)
+	span.End()
+}
+
+func TestToOTELConversions(t *testing.T) {
+	if e, a := oteltrace.SpanKindClient, toOTELSpanKind(tracing.SpanKindClient); e != a {
+		t.Errorf("expect %v kind, got %v", e, a)
+	}
+	if e, a := oteltrace.SpanKindInternal, toOTELSpanKind(tracing.SpanKindInternal); e != a {
+		t.Errorf("expect %v kind, got %v", e, a)
+	}
+	if e, a := otelcodes.Error, toOTELSpanStatus(tracing.SpanStatusError); e != a {
+		t.Errorf("expect %v status, got %v", e, a)
+	}
+	if e, a := otelcodes.Unset, toOTELSpanStatus(tracing.SpanStatusUnset); e != a {
+		t.Errorf("expect %v status, got %v", e, a)
+	}
+}
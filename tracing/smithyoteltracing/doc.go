@@ -0,0 +1,6 @@
+// Package smithyoteltracing implements a Smithy client tracing adapter for
+// the OTEL Go SDK.
+//
+// The adapter is provided as a separate module so that clients which do not
+// use OpenTelemetry are not required to depend on it.
+package smithyoteltracing
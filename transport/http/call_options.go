@@ -0,0 +1,59 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// WithHeader returns a call option that adds the header value to the HTTP
+// request of the operation call. Appends to any existing values if present.
+func WithHeader(header, value string) middleware.CallOption {
+	return middleware.WithStackMutation(AddHeaderValue(header, value))
+}
+
+// WithEndpointOverride returns a call option that sends the HTTP request of
+// the operation call to the endpoint, instead of the endpoint resolved by the
+// client. The endpoint's path is prefixed to the request's path.
+func WithEndpointOverride(endpoint string) middleware.CallOption {
+	return middleware.WithStackMutation(func(stack *middleware.Stack) error {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid endpoint override %q, %w", endpoint, err)
+		}
+		if len(u.Scheme) == 0 || len(u.Host) == 0 {
+			return fmt.Errorf("invalid endpoint override %q, scheme and host required", endpoint)
+		}
+		return stack.Build.Add(&endpointOverride{endpoint: u}, middleware.After)
+	})
+}
+
+type endpointOverride struct {
+	endpoint *url.URL
+}
+
+func (*endpointOverride) ID() string {
+	return "CallEndpointOverride"
+}
+
+func (m *endpointOverride) HandleBuild(ctx context.Context, in middleware.BuildInput, next middleware.BuildHandler) (
+	out middleware.BuildOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type %T", in.Request)
+	}
+
+	req.URL.Scheme = m.endpoint.Scheme
+	req.URL.Host = m.endpoint.Host
+	req.URL.Path = JoinPath(m.endpoint.Path, req.URL.Path)
+	if len(req.URL.RawPath) != 0 {
+		req.URL.RawPath = JoinPath(m.endpoint.EscapedPath(), req.URL.RawPath)
+	}
+	req.URL.RawQuery = JoinRawQuery(m.endpoint.RawQuery, req.URL.RawQuery)
+	req.Host = ""
+
+	return next.HandleBuild(ctx, in)
+}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestCallOptions(t *testing.T) {
+	ctx := middleware.WithCallOptions(context.Background(),
+		WithHeader("X-Custom", "abc"),
+		WithEndpointOverride("https://override.example.com/prefix?a=1"),
+	)
+
+	stack := middleware.NewStack("test", NewStackRequest)
+	if err := middleware.ApplyCallOptions(ctx, stack); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	err := stack.Serialize.Add(middleware.SerializeMiddlewareFunc("path",
+		func(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+			middleware.SerializeOutput, middleware.Metadata, error,
+		) {
+			req := in.Request.(*Request)
+			req.URL, _ = url.Parse("http://default.example.com/op?b=2")
+			return next.HandleSerialize(ctx, in)
+		}), middleware.After)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+			req := input.(*Request)
+			if e, a := "https://override.example.com/prefix/op?a=1&b=2", req.URL.String(); e != a {
+				t.Errorf("expect %v URL, got %v", e, a)
+			}
+			if e, a := "abc", req.Header.Get("X-Custom"); e != a {
+				t.Errorf("expect %v header, got %v", e, a)
+			}
+			return nil, middleware.Metadata{}, nil
+		}), stack)
+
+	if _, _, err := handler.Handle(ctx, struct{}{}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+}
+
+func TestWithEndpointOverride_Invalid(t *testing.T) {
+	ctx := middleware.WithCallOptions(context.Background(), WithEndpointOverride("no-scheme"))
+
+	stack := middleware.NewStack("test", NewStackRequest)
+	if err := middleware.ApplyCallOptions(ctx, stack); err == nil {
+		t.Fatalf("expect error, got none")
+	}
+}
@@ -1,65 +1,245 @@
 package http
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
+	"encoding/base64"
 	"fmt"
+	"hash"
+	"io"
 
+	smithy "github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/sync/bufferpool"
 )
 
 const contentMD5Header = "Content-Md5"
 
+// DefaultChecksumMaxBufferSize is the default maximum size of an unseekable
+// request stream buffered into memory to compute its checksum.
+const DefaultChecksumMaxBufferSize = 1 << 20
+
+// ChecksumAlgorithm is an algorithm of the checksum header of a request
+// payload.
+type ChecksumAlgorithm struct {
+	// Header is the name of the header the base64 encoded checksum is set
+	// to, e.g. "Content-MD5".
+	Header string
+
+	// NewHash returns the hash computing the checksum.
+	NewHash func() hash.Hash
+}
+
+// ChecksumAlgorithmMD5 is the Content-MD5 checksum of a request payload, the
+// checksum of operations with the httpChecksumRequired trait.
+var ChecksumAlgorithmMD5 = ChecksumAlgorithm{
+	Header:  contentMD5Header,
+	NewHash: md5.New,
+}
+
+var checksumRequiredKey = smithy.RegisterPropertyKey[bool]("smithy.http", "httpChecksumRequired")
+
+// WithChecksumRequired returns a context with the operation property that
+// the operation requires a checksum of its request payload, the Smithy
+// httpChecksumRequired trait, read by the middleware added by
+// AddContentChecksumMiddleware.
+func WithChecksumRequired(ctx context.Context, required bool) context.Context {
+	return middleware.WithOperationProperty(ctx, checksumRequiredKey, required)
+}
+
+// IsChecksumRequired returns whether the operation requires a checksum of its
+// request payload, see WithChecksumRequired.
+func IsChecksumRequired(ctx context.Context) bool {
+	v, _ := middleware.GetOperationProperty(ctx, checksumRequiredKey)
+	return v
+}
+
+// ContentChecksumOptions provides the configuration of the content checksum
+// middleware.
+type ContentChecksumOptions struct {
+	// Algorithm is the algorithm of the checksum header. Defaults to
+	// ChecksumAlgorithmMD5.
+	Algorithm ChecksumAlgorithm
+
+	// RequiredOnly computes the checksum only for operations which require
+	// it, see WithChecksumRequired, so the middleware may be added to the
+	// stacks of all operations of a client.
+	RequiredOnly bool
+
+	// BufferUnseekableStream buffers a request stream which is not seekable
+	// into memory while its checksum is computed, so the stream can be sent,
+	// and rewound for retries, after it has been read. Streams larger than
+	// MaxBufferSize fail with an *UnseekableStreamError.
+	BufferUnseekableStream bool
+
+	// MaxBufferSize is the maximum size of an unseekable request stream
+	// buffered into memory. Defaults to DefaultChecksumMaxBufferSize.
+	MaxBufferSize int64
+}
+
+// UnseekableStreamError is returned by the content checksum middleware when
+// the request stream is not seekable, and is larger than the maximum size
+// buffered to compute its checksum. The request's payload must be a
+// seekable stream, such as an io.ReadSeeker, for its checksum to be
+// computed.
+type UnseekableStreamError struct {
+	// Header is the header of the checksum that was required.
+	Header string
+
+	// MaxBufferSize is the maximum size of an unseekable stream buffered.
+	MaxBufferSize int64
+}
+
+func (e *UnseekableStreamError) Error() string {
+	return fmt.Sprintf("unable to compute %s checksum, request stream is not seekable, "+
+		"and is larger than the %d bytes buffered, use a seekable request stream", e.Header, e.MaxBufferSize)
+}
+
 // contentMD5Checksum provides a middleware to compute and set
 // content-md5 checksum for a http request
 type contentMD5Checksum struct {
+	options ContentChecksumOptions
 }
 
 // AddContentChecksumMiddleware adds checksum middleware to middleware's
-// build step.
-func AddContentChecksumMiddleware(stack *middleware.Stack) error {
+// build step. The middleware fills the middleware.SlotRequestChecksum slot
+// of the stack, so the checksum is computed before the request is signed.
+//
+// The checksum is computed once, before the Finalize step's retries, over the
+// whole payload from the stream's start position. The stream is rewound
+// after it is read, so every request attempt sends the same payload the
+// checksum was computed for. A checksum header already set on the request is
+// not replaced. Requests whose stream is set with a StreamProducer fail, as
+// their payload is not available until the request is sent.
+func AddContentChecksumMiddleware(stack *middleware.Stack, optFns ...func(*ContentChecksumOptions)) error {
+	var o ContentChecksumOptions
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	if o.Algorithm.NewHash == nil {
+		o.Algorithm = ChecksumAlgorithmMD5
+	}
+	if o.MaxBufferSize <= 0 {
+		o.MaxBufferSize = DefaultChecksumMaxBufferSize
+	}
+
 	// This middleware must be executed before request body is set.
-	return stack.Build.Add(&contentMD5Checksum{}, middleware.Before)
+	return stack.Build.Add(&contentMD5Checksum{options: o}, middleware.Before)
 }
 
 // ID the identifier for the checksum middleware
-func (m *contentMD5Checksum) ID() string { return "ContentChecksum" }
+func (m *contentMD5Checksum) ID() string { return middleware.SlotRequestChecksum }
 
-// HandleBuild adds behavior to compute md5 checksum and add content-md5 header
-// on http request
+// HandleBuild adds behavior to compute the checksum and add its header on
+// http request
 func (m *contentMD5Checksum) HandleBuild(
 	ctx context.Context, in middleware.BuildInput, next middleware.BuildHandler,
 ) (
 	out middleware.BuildOutput, metadata middleware.Metadata, err error,
 ) {
+	if m.options.RequiredOnly && !IsChecksumRequired(ctx) {
+		return next.HandleBuild(ctx, in)
+	}
+
 	req, ok := in.Request.(*Request)
 	if !ok {
 		return out, metadata, fmt.Errorf("unknown request type %T", req)
 	}
 
-	// if Content-MD5 header is already present, return
-	if v := req.Header.Get(contentMD5Header); len(v) != 0 {
+	algorithm := m.options.Algorithm
+	if algorithm.NewHash == nil {
+		algorithm = ChecksumAlgorithmMD5
+	}
+	header := algorithm.Header
+
+	// if the checksum header is already present, return
+	if v := req.Header.Get(header); len(v) != 0 {
 		return next.HandleBuild(ctx, in)
 	}
 
-	// fetch the request stream.
-	stream := req.GetStream()
-	// compute checksum if payload is explicit
-	if stream != nil {
-		v, err := computeMD5Checksum(stream)
-		if err != nil {
-			return out, metadata, fmt.Errorf("error computing md5 checksum, %w", err)
+	h := algorithm.NewHash()
+	switch stream := req.GetStream(); {
+	case req.GetStreamProducer() != nil:
+		err = fmt.Errorf("request stream set with a StreamProducer")
+	case stream == nil:
+		// Only operations which require a checksum have the checksum of the
+		// empty payload set.
+		if !IsChecksumRequired(ctx) {
+			return next.HandleBuild(ctx, in)
+		}
+	case req.IsStreamSeekable():
+		err = seekableStreamChecksum(req, h)
+	case m.options.BufferUnseekableStream:
+		req, err = bufferStreamChecksum(req, h, header, m.maxBufferSize())
+		if err == nil {
+			in.Request = req
+		}
+	default:
+		if err := copyChecksum(h, stream); err != nil {
+			return out, metadata, fmt.Errorf("error computing %s checksum, %w", header, err)
 		}
-
-		// reset the request stream
 		if err := req.RewindStream(); err != nil {
 			return out, metadata, fmt.Errorf(
-				"error rewinding request stream after computing md5 checksum, %w", err)
+				"error rewinding request stream after computing %s checksum, %w", header, err)
 		}
-
-		// set the 'Content-MD5' header
-		req.Header.Set(contentMD5Header, string(v))
+	}
+	if err != nil {
+		return out, metadata, fmt.Errorf("error computing %s checksum, %w", header, err)
 	}
 
-	// set md5 header value
+	req.Header.Set(header, base64.StdEncoding.EncodeToString(h.Sum(nil)))
 	return next.HandleBuild(ctx, in)
 }
+
+func (m *contentMD5Checksum) maxBufferSize() int64 {
+	if m.options.MaxBufferSize <= 0 {
+		return DefaultChecksumMaxBufferSize
+	}
+	return m.options.MaxBufferSize
+}
+
+// seekableStreamChecksum writes the request's seekable stream from its start
+// position to the hash, rewinding the stream afterwards.
+func seekableStreamChecksum(req *Request, h hash.Hash) error {
+	if err := req.RewindStream(); err != nil {
+		return err
+	}
+	if err := copyChecksum(h, req.GetStream()); err != nil {
+		return err
+	}
+	return req.RewindStream()
+}
+
+// bufferStreamChecksum reads the request's unseekable stream into memory
+// while writing it to the hash, returning a request with the buffered
+// stream. Returns an *UnseekableStreamError for the checksum header if the
+// stream is larger than max.
+func bufferStreamChecksum(req *Request, h hash.Hash, header string, max int64) (*Request, error) {
+	if req.ContentLength > max {
+		return nil, &UnseekableStreamError{Header: header, MaxBufferSize: max}
+	}
+
+	var buf bytes.Buffer
+	n, err := io.Copy(h, io.TeeReader(io.LimitReader(req.GetStream(), max+1), &buf))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	if n > max {
+		return nil, &UnseekableStreamError{Header: header, MaxBufferSize: max}
+	}
+
+	return req.SetStream(bytes.NewReader(buf.Bytes()))
+}
+
+// copyChecksum writes the stream to the hash.
+func copyChecksum(h hash.Hash, stream io.Reader) error {
+	buf := bufferpool.Get(32 << 10)
+	defer bufferpool.Put(buf)
+
+	// copy errors may be assumed to be from the body.
+	if _, err := io.CopyBuffer(h, stream, buf.Bytes()[:buf.Cap()]); err != nil {
+		return fmt.Errorf("failed to read body: %w", err)
+	}
+	return nil
+}
@@ -3,7 +3,10 @@ package http
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"errors"
 	"io"
+	"io/ioutil"
 	"strings"
 	"testing"
 
@@ -82,3 +85,196 @@ func TestChecksumMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestContentChecksumMiddleware_Options(t *testing.T) {
+	cases := map[string]struct {
+		payload       io.Reader
+		producer      StreamProducer
+		readPrefix    int
+		contentLength int64
+		required      bool
+		header        string
+		options       ContentChecksumOptions
+		expectHeader  string
+		expectSum     string
+		expectBody    string
+		expectErr     string
+		expectTypeErr bool
+	}{
+		"seekable": {
+			payload:    strings.NewReader("abc"),
+			expectSum:  "kAFQmDzST7DWlj99KOF/cg==",
+			expectBody: "abc",
+		},
+		"seekable partially read": {
+			payload:    strings.NewReader("abc"),
+			readPrefix: 2,
+			expectSum:  "kAFQmDzST7DWlj99KOF/cg==",
+			expectBody: "abc",
+		},
+		"no payload": {},
+		"no payload required": {
+			required:  true,
+			expectSum: "1B2M2Y8AsgTpgAmY7PhCfg==",
+		},
+		"required only": {
+			payload:    strings.NewReader("abc"),
+			required:   true,
+			options:    ContentChecksumOptions{RequiredOnly: true},
+			expectSum:  "kAFQmDzST7DWlj99KOF/cg==",
+			expectBody: "abc",
+		},
+		"required only not required": {
+			payload:    strings.NewReader("abc"),
+			options:    ContentChecksumOptions{RequiredOnly: true},
+			expectBody: "abc",
+		},
+		"stream producer": {
+			producer: func(ctx context.Context, w io.Writer) error {
+				_, err := io.WriteString(w, "hello world")
+				return err
+			},
+			expectErr: "StreamProducer",
+		},
+		"header already set": {
+			payload:    strings.NewReader("abc"),
+			header:     "preset",
+			expectSum:  "preset",
+			expectBody: "abc",
+		},
+		"unseekable": {
+			payload:   ioutil.NopCloser(strings.NewReader("abc")),
+			expectErr: "request stream is not seekable",
+		},
+		"unseekable buffered": {
+			payload:    ioutil.NopCloser(strings.NewReader("abc")),
+			options:    ContentChecksumOptions{BufferUnseekableStream: true},
+			expectSum:  "kAFQmDzST7DWlj99KOF/cg==",
+			expectBody: "abc",
+		},
+		"unseekable too large": {
+			payload: ioutil.NopCloser(strings.NewReader("abcd")),
+			options: ContentChecksumOptions{
+				BufferUnseekableStream: true,
+				MaxBufferSize:          3,
+			},
+			expectErr:     "request stream is not seekable",
+			expectTypeErr: true,
+		},
+		"unseekable larger than default max": {
+			payload:       ioutil.NopCloser(bytes.NewReader(make([]byte, DefaultChecksumMaxBufferSize+1))),
+			options:       ContentChecksumOptions{BufferUnseekableStream: true},
+			expectErr:     "request stream is not seekable",
+			expectTypeErr: true,
+		},
+		"unseekable content length too large": {
+			payload:       ioutil.NopCloser(strings.NewReader("abc")),
+			contentLength: 4 << 20,
+			options:       ContentChecksumOptions{BufferUnseekableStream: true},
+			expectErr:     "request stream is not seekable",
+			expectTypeErr: true,
+		},
+		"configured algorithm": {
+			payload: bytes.NewReader([]byte("abc")),
+			options: ContentChecksumOptions{
+				Algorithm: ChecksumAlgorithm{Header: "X-Checksum-Sha256", NewHash: sha256.New},
+			},
+			expectHeader: "X-Checksum-Sha256",
+			expectSum:    "ungWv48Bz+pBQUDeXa4iI7ADYaOWF3qctBD/YfIAFa0=",
+			expectBody:   "abc",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			header := c.expectHeader
+			if len(header) == 0 {
+				header = contentMD5Header
+			}
+
+			stack := middleware.NewStack("test", NewStackRequest)
+			stack.Serialize.Add(middleware.SerializeMiddlewareFunc("serialize",
+				func(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+					middleware.SerializeOutput, middleware.Metadata, error,
+				) {
+					req := in.Request.(*Request)
+					if len(c.header) != 0 {
+						req.Header.Set(header, c.header)
+					}
+					if c.payload != nil {
+						var err error
+						if req, err = req.SetStream(c.payload); err != nil {
+							return middleware.SerializeOutput{}, middleware.Metadata{}, err
+						}
+						if c.readPrefix > 0 {
+							io.ReadFull(c.payload, make([]byte, c.readPrefix))
+						}
+					}
+					if c.contentLength != 0 {
+						req.ContentLength = c.contentLength
+					}
+					if c.producer != nil {
+						req = req.SetStreamProducer(c.producer)
+					}
+					in.Request = req
+					return next.HandleSerialize(ctx, in)
+				}), middleware.After)
+			if err := AddContentChecksumMiddleware(stack, func(o *ContentChecksumOptions) {
+				*o = c.options
+			}); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			handler := middleware.DecorateHandler(middleware.HandlerFunc(
+				func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+					req := input.(*Request)
+					if e, a := c.expectSum, req.Header.Get(header); e != a {
+						t.Errorf("expect %q %v, got %q", e, header, a)
+					}
+					if req.GetStream() == nil {
+						return nil, middleware.Metadata{}, nil
+					}
+					// Simulate retried attempts rewinding and re-reading the stream.
+					for i := 0; i < 2; i++ {
+						if err := req.RewindStream(); err != nil {
+							t.Fatalf("expect no rewind error, got %v", err)
+						}
+						b, _ := ioutil.ReadAll(req.GetStream())
+						if e, a := c.expectBody, string(b); e != a {
+							t.Errorf("expect %q body, got %q", e, a)
+						}
+					}
+					return nil, middleware.Metadata{}, nil
+				}), stack)
+
+			ctx := WithChecksumRequired(context.Background(), c.required)
+			_, _, err := handler.Handle(ctx, struct{}{})
+			if len(c.expectErr) != 0 {
+				if err == nil || !strings.Contains(err.Error(), c.expectErr) {
+					t.Fatalf("expect error containing %q, got %v", c.expectErr, err)
+				}
+				var streamErr *UnseekableStreamError
+				if e, a := c.expectTypeErr, errors.As(err, &streamErr); e != a {
+					t.Errorf("expect *UnseekableStreamError %v, got %v", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestContentChecksumMiddleware_Slot(t *testing.T) {
+	stack := middleware.NewStack("test", NewStackRequest)
+	if err := AddContentChecksumMiddleware(stack); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if _, ok := stack.Build.Get(middleware.SlotRequestChecksum); !ok {
+		t.Errorf("expect checksum slot filled")
+	}
+	if err := AddContentChecksumMiddleware(stack); err == nil {
+		t.Errorf("expect error adding checksum middleware twice")
+	}
+}
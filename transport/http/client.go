@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	smithy "github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/middleware"
@@ -26,7 +27,25 @@ func (fn ClientDoFunc) Do(r *http.Request) (*http.Response, error) {
 // ClientHandler wraps a client that implements the HTTP Do method. Standard
 // implementation is http.Client.
 type ClientHandler struct {
-	client ClientDo
+	client  ClientDo
+	options ClientHandlerOptions
+}
+
+// ClientHandlerOptions provides the configuration of a ClientHandler.
+type ClientHandlerOptions struct {
+	// ReadIdleTimeout is the maximum duration a read of the response body
+	// may block without receiving data. When exceeded, the response body is
+	// closed and the read returns a *TimeoutError. Zero means no timeout.
+	//
+	// Unlike net/http's ResponseHeaderTimeout, the timeout applies to the
+	// whole response body, independent of the request's overall deadline.
+	ReadIdleTimeout time.Duration
+
+	// WriteIdleTimeout is the maximum duration the client may stall sending
+	// the request body to the service. When exceeded, the request is
+	// canceled and fails with a *RequestSendError wrapping a *TimeoutError.
+	// Zero means no timeout.
+	WriteIdleTimeout time.Duration
 }
 
 // NewClientHandler returns an initialized middleware handler for the client.
@@ -36,6 +55,19 @@ func NewClientHandler(client ClientDo) ClientHandler {
 	}
 }
 
+// NewClientHandlerWithOptions returns an initialized middleware handler for
+// the client, configured by the functional options.
+func NewClientHandlerWithOptions(client ClientDo, optFns ...func(*ClientHandlerOptions)) ClientHandler {
+	var o ClientHandlerOptions
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	return ClientHandler{
+		client:  client,
+		options: o,
+	}
+}
+
 // Handle implements the middleware Handler interface, that will invoke the
 // underlying HTTP client. Requires the input to be an Smithy *Request. Returns
 // a smithy *Response, or error if the request failed.
@@ -47,11 +79,22 @@ func (c ClientHandler) Handle(ctx context.Context, input interface{}) (
 		return nil, metadata, fmt.Errorf("expect Smithy http.Request value as input, got unsupported type %T", input)
 	}
 
-	builtRequest := req.Build(ctx)
+	sendCtx, cancel := c.sendContext(ctx)
+	builtRequest := req.Build(sendCtx)
 	if err := ValidateEndpointHost(builtRequest.Host); err != nil {
+		cancel()
 		return nil, metadata, err
 	}
 
+	var writeWatch *idleWatch
+	if c.options.WriteIdleTimeout > 0 && builtRequest.Body != nil && builtRequest.Body != http.NoBody {
+		writeWatch = newIdleWatch("write", c.options.WriteIdleTimeout, cancel)
+		builtRequest.Body = &requestIdleTimeoutBody{
+			body:  builtRequest.Body,
+			watch: writeWatch,
+		}
+	}
+
 	resp, err := c.client.Do(builtRequest)
 	if resp == nil {
 		// Ensure a http response value is always present to prevent unexpected
@@ -62,14 +105,25 @@ func (c ClientHandler) Handle(ctx context.Context, input interface{}) (
 		}
 	}
 	if err != nil {
-		err = &RequestSendError{Err: err}
-
-		// Override the error with a context canceled error, if that was canceled.
-		select {
-		case <-ctx.Done():
-			err = &smithy.CanceledError{Err: ctx.Err()}
-		default:
+		if writeWatch != nil {
+			if terr := writeWatch.Err(); terr != nil {
+				err = terr
+			}
+		}
+		// Classify the error as a context canceled error, if that was
+		// canceled, instead of a retryable send error.
+		err = smithy.ClassifyCanceled(ctx, &RequestSendError{Err: ClassifyTransportError(err)})
+		cancel()
+	} else {
+		if c.options.ReadIdleTimeout > 0 || c.options.WriteIdleTimeout > 0 {
+			// The request's context must not be canceled until the response
+			// body is done being read.
+			resp.Body = &responseBodyCloser{
+				ReadCloser: NewReadIdleTimeoutBody(resp.Body, c.options.ReadIdleTimeout),
+				onClose:    cancel,
+			}
 		}
+		resp.Body = &bodyReadErrorReader{ReadCloser: resp.Body}
 	}
 
 	// HTTP RoundTripper *should* close the request body. But this may not happen in a timely manner.
@@ -82,6 +136,16 @@ func (c ClientHandler) Handle(ctx context.Context, input interface{}) (
 	return &Response{Response: resp}, metadata, err
 }
 
+// sendContext returns the context the request is sent with. When a write
+// idle timeout is configured, the context can be canceled to abort a request
+// whose body has stalled.
+func (c ClientHandler) sendContext(ctx context.Context) (context.Context, func()) {
+	if c.options.WriteIdleTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithCancel(ctx)
+}
+
 // RequestSendError provides a generic request transport error. This error
 // should wrap errors making HTTP client requests.
 //
@@ -0,0 +1,274 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/metrics"
+)
+
+// ConnectionPoolOptions provides the configuration of the connection pool of
+// a PooledClient.
+type ConnectionPoolOptions struct {
+	// MaxIdleConns is the maximum number of idle connections across all
+	// hosts. Defaults to 100. Negative means no limit.
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept per
+	// host. Defaults to net/http's DefaultMaxIdleConnsPerHost.
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost is the maximum number of connections per host,
+	// including connections being dialed, in use, and idle. Requests wait
+	// for a connection when the limit is reached. Zero means no limit.
+	MaxConnsPerHost int
+
+	// IdleConnTimeout is the maximum duration an idle connection is kept
+	// before it is closed. Defaults to 90 seconds. Negative means no
+	// timeout.
+	IdleConnTimeout time.Duration
+
+	// MeterProvider is the provider of the meter the connection pool's
+	// metrics are recorded with. Defaults to metrics.NopMeterProvider.
+	MeterProvider metrics.MeterProvider
+
+	// TransportOptions are applied to the client's transport, a clone of
+	// net/http's DefaultTransport, after the connection pool options.
+	TransportOptions []func(*http.Transport)
+}
+
+// ConnectionPoolStats is a snapshot of the connections of a PooledClient.
+type ConnectionPoolStats struct {
+	// Open is the number of open connections.
+	Open int64
+
+	// InUse is the number of connections in use by a request.
+	InUse int64
+
+	// Idle is the number of open connections not in use.
+	Idle int64
+}
+
+// PooledClient is an HTTP client whose connection pool is configured by
+// ConnectionPoolOptions, recording the pool's metrics:
+//
+//	client.http.connections.usage             the number of connections, by
+//	                                          state "idle" or "acquired"
+//	client.http.connections.acquire_duration  the time requests wait for a
+//	                                          connection, in seconds
+type PooledClient struct {
+	client    *http.Client
+	transport *http.Transport
+
+	stats           *connectionPoolStats
+	acquireDuration metrics.Float64Histogram
+}
+
+var _ ClientDo = (*PooledClient)(nil)
+
+// NewPooledClient returns a PooledClient configured by the functional
+// options. Returns an error if the metrics instruments cannot be created.
+func NewPooledClient(optFns ...func(*ConnectionPoolOptions)) (*PooledClient, error) {
+	o := ConnectionPoolOptions{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: http.DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	if o.MeterProvider == nil {
+		o.MeterProvider = metrics.NopMeterProvider{}
+	}
+
+	meter := o.MeterProvider.Meter("github.com/aws/smithy-go/transport/http")
+	usage, err := meter.Int64UpDownCounter("client.http.connections.usage",
+		metrics.WithUnit("{connection}"),
+		metrics.WithDescription("Number of connections of the client's connection pool, by state"))
+	if err != nil {
+		return nil, err
+	}
+	acquireDuration, err := meter.Float64Histogram("client.http.connections.acquire_duration",
+		metrics.WithUnit("s"),
+		metrics.WithDescription("Time a request waits to acquire a connection"))
+	if err != nil {
+		return nil, err
+	}
+
+	c := &PooledClient{
+		stats:           &connectionPoolStats{usage: usage},
+		acquireDuration: acquireDuration,
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.MaxIdleConns = o.MaxIdleConns
+	if o.MaxIdleConns < 0 {
+		tr.MaxIdleConns = 0
+	}
+	tr.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+	tr.MaxConnsPerHost = o.MaxConnsPerHost
+	tr.IdleConnTimeout = o.IdleConnTimeout
+	if o.IdleConnTimeout < 0 {
+		tr.IdleConnTimeout = 0
+	}
+	for _, fn := range o.TransportOptions {
+		fn(tr)
+	}
+
+	dial := tr.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	tr.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		c.stats.update(ctx, 1, 0)
+		return &pooledConn{Conn: conn, onClose: func() {
+			c.stats.update(context.Background(), -1, 0)
+		}}, nil
+	}
+
+	c.transport = tr
+	c.client = &http.Client{Transport: tr}
+	return c, nil
+}
+
+// Do sends the request, recording the time waited to acquire a connection.
+// The connection is released when the response body is read to EOF, or
+// closed.
+func (c *PooledClient) Do(r *http.Request) (*http.Response, error) {
+	ctx := r.Context()
+
+	var start time.Time
+	var acquired int32
+	trace := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			start = time.Now()
+		},
+		GotConn: func(httptrace.GotConnInfo) {
+			if !atomic.CompareAndSwapInt32(&acquired, 0, 1) {
+				return
+			}
+			c.acquireDuration.Record(ctx, time.Since(start).Seconds())
+			c.stats.update(ctx, 0, 1)
+		},
+	}
+
+	resp, err := c.client.Do(r.WithContext(httptrace.WithClientTrace(ctx, trace)))
+	if atomic.LoadInt32(&acquired) == 0 {
+		return resp, err
+	}
+
+	release := func() { c.stats.update(ctx, 0, -1) }
+	if err != nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// Stats returns a snapshot of the client's connections.
+func (c *PooledClient) Stats() ConnectionPoolStats {
+	return c.stats.snapshot()
+}
+
+// CloseIdleConnections closes the client's idle connections.
+func (c *PooledClient) CloseIdleConnections() {
+	c.client.CloseIdleConnections()
+}
+
+// connectionPoolStats tracks the open and in use connections of the pool,
+// recording the change of the number of idle and acquired connections.
+type connectionPoolStats struct {
+	usage metrics.Int64UpDownCounter
+
+	mu    sync.Mutex
+	open  int64
+	inUse int64
+
+	recordedIdle  int64
+	recordedInUse int64
+}
+
+func (s *connectionPoolStats) update(ctx context.Context, open, inUse int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.open += open
+	s.inUse += inUse
+
+	idle, acquired := s.idle(), s.inUse
+	if d := idle - s.recordedIdle; d != 0 {
+		s.usage.Add(ctx, d, metrics.WithProperties(connectionState("idle")))
+	}
+	if d := acquired - s.recordedInUse; d != 0 {
+		s.usage.Add(ctx, d, metrics.WithProperties(connectionState("acquired")))
+	}
+	s.recordedIdle, s.recordedInUse = idle, acquired
+}
+
+// idle returns the number of idle connections. Connections may be released
+// after they are closed, so the number is bounded to zero.
+func (s *connectionPoolStats) idle() int64 {
+	if idle := s.open - s.inUse; idle > 0 {
+		return idle
+	}
+	return 0
+}
+
+func (s *connectionPoolStats) snapshot() ConnectionPoolStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return ConnectionPoolStats{
+		Open:  s.open,
+		InUse: s.inUse,
+		Idle:  s.idle(),
+	}
+}
+
+func connectionState(state string) smithy.Properties {
+	var props smithy.Properties
+	props.Set("state", state)
+	return props
+}
+
+type pooledConn struct {
+	net.Conn
+	once    sync.Once
+	onClose func()
+}
+
+func (c *pooledConn) Close() error {
+	c.once.Do(c.onClose)
+	return c.Conn.Close()
+}
+
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.once.Do(b.release)
+	}
+	return n, err
+}
+
+func (b *releaseBody) Close() error {
+	b.once.Do(b.release)
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/metrics"
+)
+
+type mockPoolMeterProvider struct {
+	mu        sync.Mutex
+	usage     map[string]int64
+	durations int
+}
+
+func (p *mockPoolMeterProvider) Meter(string, ...metrics.MeterOption) metrics.Meter {
+	return mockPoolMeter{metrics.NopMeterProvider{}.Meter(""), p}
+}
+
+type mockPoolMeter struct {
+	metrics.Meter
+	provider *mockPoolMeterProvider
+}
+
+func (m mockPoolMeter) Int64UpDownCounter(string, ...metrics.InstrumentOption) (metrics.Int64UpDownCounter, error) {
+	return mockPoolUsage{m.provider}, nil
+}
+
+func (m mockPoolMeter) Float64Histogram(string, ...metrics.InstrumentOption) (metrics.Float64Histogram, error) {
+	return mockPoolDuration{m.provider}, nil
+}
+
+type mockPoolUsage struct{ provider *mockPoolMeterProvider }
+
+func (u mockPoolUsage) Add(_ context.Context, v int64, opts ...metrics.RecordMetricOption) {
+	var o metrics.RecordMetricOptions
+	for _, fn := range opts {
+		fn(&o)
+	}
+	u.provider.mu.Lock()
+	defer u.provider.mu.Unlock()
+	if u.provider.usage == nil {
+		u.provider.usage = map[string]int64{}
+	}
+	u.provider.usage[o.Properties.Get("state").(string)] += v
+}
+
+type mockPoolDuration struct{ provider *mockPoolMeterProvider }
+
+func (d mockPoolDuration) Record(context.Context, float64, ...metrics.RecordMetricOption) {
+	d.provider.mu.Lock()
+	defer d.provider.mu.Unlock()
+	d.provider.durations++
+}
+
+func TestNewPooledClientOptions(t *testing.T) {
+	cases := map[string]struct {
+		Options              func(*ConnectionPoolOptions)
+		ExpectMaxIdle        int
+		ExpectMaxIdlePerHost int
+		ExpectMaxPerHost     int
+		ExpectIdleTimeout    time.Duration
+	}{
+		"defaults": {
+			Options:              func(*ConnectionPoolOptions) {},
+			ExpectMaxIdle:        100,
+			ExpectMaxIdlePerHost: http.DefaultMaxIdleConnsPerHost,
+			ExpectIdleTimeout:    90 * time.Second,
+		},
+		"custom": {
+			Options: func(o *ConnectionPoolOptions) {
+				o.MaxIdleConns = -1
+				o.MaxIdleConnsPerHost = 20
+				o.MaxConnsPerHost = 50
+				o.IdleConnTimeout = -1
+			},
+			ExpectMaxIdlePerHost: 20,
+			ExpectMaxPerHost:     50,
+		},
+		"transport options": {
+			Options: func(o *ConnectionPoolOptions) {
+				o.TransportOptions = append(o.TransportOptions, func(tr *http.Transport) {
+					tr.MaxIdleConnsPerHost = 5
+				})
+			},
+			ExpectMaxIdle:        100,
+			ExpectMaxIdlePerHost: 5,
+			ExpectIdleTimeout:    90 * time.Second,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client, err := NewPooledClient(c.Options)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			tr := client.transport
+			if e, a := c.ExpectMaxIdle, tr.MaxIdleConns; e != a {
+				t.Errorf("expect %v max idle conns, got %v", e, a)
+			}
+			if e, a := c.ExpectMaxIdlePerHost, tr.MaxIdleConnsPerHost; e != a {
+				t.Errorf("expect %v max idle conns per host, got %v", e, a)
+			}
+			if e, a := c.ExpectMaxPerHost, tr.MaxConnsPerHost; e != a {
+				t.Errorf("expect %v max conns per host, got %v", e, a)
+			}
+			if e, a := c.ExpectIdleTimeout, tr.IdleConnTimeout; e != a {
+				t.Errorf("expect %v idle conn timeout, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestPooledClientMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	provider := &mockPoolMeterProvider{}
+	client, err := NewPooledClient(func(o *ConnectionPoolOptions) {
+		o.MeterProvider = provider
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := (ConnectionPoolStats{Open: 1, InUse: 1}), client.Stats(); e != a {
+		t.Errorf("expect %+v stats, got %+v", e, a)
+	}
+	ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if e, a := (ConnectionPoolStats{Open: 1, Idle: 1}), client.Stats(); e != a {
+		t.Errorf("expect %+v stats, got %+v", e, a)
+	}
+
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	provider.mu.Lock()
+	if e, a := 2, provider.durations; e != a {
+		t.Errorf("expect %v acquire durations, got %v", e, a)
+	}
+	if e, a := int64(1), provider.usage["idle"]; e != a {
+		t.Errorf("expect %v idle connections, got %v", e, a)
+	}
+	if e, a := int64(0), provider.usage["acquired"]; e != a {
+		t.Errorf("expect %v acquired connections, got %v", e, a)
+	}
+	provider.mu.Unlock()
+
+	// Connections are returned to the pool asynchronously after the
+	// response body is read.
+	deadline := time.Now().Add(time.Second)
+	for client.Stats().Open != 0 && time.Now().Before(deadline) {
+		client.CloseIdleConnections()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if e, a := (ConnectionPoolStats{}), client.Stats(); e != a {
+		t.Errorf("expect %+v stats, got %+v", e, a)
+	}
+}
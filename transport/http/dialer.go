@@ -0,0 +1,77 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// DialContextFunc dials the connection of a request, matching the signature
+// of net/http Transport's DialContext.
+type DialContextFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// unixSocketHost is the host of requests sent over a unix domain socket. The
+// host is not used to dial the connection, but is required by net/http.
+const unixSocketHost = "localhost"
+
+// NewDialerClient returns an HTTP client which dials the connections of its
+// requests with dial, instead of dialing the request's host. The client's
+// transport is a clone of net/http's DefaultTransport, with proxies
+// disabled.
+func NewDialerClient(dial DialContextFunc, optFns ...func(*http.Transport)) *http.Client {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.Proxy = nil
+	tr.DialContext = dial
+	for _, fn := range optFns {
+		fn(tr)
+	}
+	return &http.Client{Transport: tr}
+}
+
+// UnixSocketDialer returns a DialContextFunc which dials the unix domain
+// socket at the path, regardless of the request's host.
+func UnixSocketDialer(socketPath string) DialContextFunc {
+	var d net.Dialer
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return d.DialContext(ctx, "unix", socketPath)
+	}
+}
+
+// ParseUnixSocketEndpoint returns the socket path of an endpoint of the form
+// unix:///path/to/socket. Returns an error if the endpoint is not a unix
+// domain socket endpoint.
+func ParseUnixSocketEndpoint(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid unix socket endpoint %q, %w", endpoint, err)
+	}
+	if u.Scheme != "unix" {
+		return "", fmt.Errorf("invalid unix socket endpoint %q, scheme must be unix", endpoint)
+	}
+	if len(u.Host) != 0 || len(u.Path) == 0 {
+		return "", fmt.Errorf("invalid unix socket endpoint %q, expect unix:///path/to/socket", endpoint)
+	}
+	return u.Path, nil
+}
+
+// NewUnixSocketClient returns a ClientDo which sends requests over the unix
+// domain socket of the endpoint, of the form unix:///path/to/socket, for
+// services such as local daemons and sidecars. The scheme and host of each
+// request are replaced with http://localhost, and the request's path and
+// query are preserved.
+func NewUnixSocketClient(endpoint string, optFns ...func(*http.Transport)) (ClientDo, error) {
+	socketPath, err := ParseUnixSocketEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	client := NewDialerClient(UnixSocketDialer(socketPath), optFns...)
+	return ClientDoFunc(func(r *http.Request) (*http.Response, error) {
+		r.URL.Scheme = "http"
+		r.URL.Host = unixSocketHost
+		r.Host = unixSocketHost
+		return client.Do(r)
+	}), nil
+}
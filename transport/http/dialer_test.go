@@ -0,0 +1,89 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseUnixSocketEndpoint(t *testing.T) {
+	cases := map[string]struct {
+		endpoint  string
+		expect    string
+		expectErr bool
+	}{
+		"socket path": {
+			endpoint: "unix:///var/run/agent.sock",
+			expect:   "/var/run/agent.sock",
+		},
+		"http scheme": {
+			endpoint:  "http://localhost/agent.sock",
+			expectErr: true,
+		},
+		"host": {
+			endpoint:  "unix://var/run/agent.sock",
+			expectErr: true,
+		},
+		"no path": {
+			endpoint:  "unix://",
+			expectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := ParseUnixSocketEndpoint(c.endpoint)
+			if c.expectErr {
+				if err == nil {
+					t.Fatalf("expect error, got %v", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.expect, actual; e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestNewUnixSocketClient(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Skipf("unix domain sockets not supported, %v", err)
+	}
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host + " " + r.URL.RequestURI()))
+	})}
+	go server.Serve(l)
+	defer server.Close()
+
+	client, err := NewUnixSocketClient("unix://" + socketPath)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	req := NewStackRequest().(*Request)
+	req.URL, _ = url.Parse("https://service.example.com/op?a=1")
+	req.Method = http.MethodGet
+
+	result, _, err := NewClientHandler(client).Handle(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	resp := result.(*Response)
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if e, a := "localhost /op?a=1", string(body); e != a {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+}
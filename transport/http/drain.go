@@ -0,0 +1,133 @@
+package http
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Drainer gracefully shuts down a client, for clean rollouts of services
+// using long-lived clients. Once shut down, the client's new operations are
+// rejected, and the in-flight operations, including all of their request
+// attempts, are waited for before the client's idle connections are closed.
+//
+// A Drainer is shared between the stacks of the client's operations, see
+// AddDrainMiddleware.
+type Drainer struct {
+	client ClientDo
+
+	mu       sync.Mutex
+	inflight int
+	shutdown bool
+	closed   bool
+	drained  chan struct{}
+}
+
+// NewDrainer returns a Drainer of the client. The client's idle connections
+// are closed on shutdown if it implements CloseIdleConnections, as
+// http.Client and PooledClient do.
+func NewDrainer(client ClientDo) *Drainer {
+	return &Drainer{
+		client:  client,
+		drained: make(chan struct{}),
+	}
+}
+
+// AddDrainMiddleware adds the middleware of the Drainer to the Initialize
+// step of the stack. The middleware rejects new operations with a
+// ClientShutdownError once the client is shut down, and tracks the accepted
+// operations until they complete, so retries of an operation accepted before
+// shutdown are waited for.
+func AddDrainMiddleware(stack *middleware.Stack, d *Drainer) error {
+	return stack.Initialize.Add(&drainGate{drainer: d}, middleware.Before)
+}
+
+// Shutdown rejects new operations, and waits for the in-flight operations to
+// complete, or the context to be done, before closing the client's idle
+// connections. Returns the context's error if the operations did not
+// complete before the context was done.
+func (d *Drainer) Shutdown(ctx context.Context) error {
+	d.mu.Lock()
+	d.shutdown = true
+	d.closeDrained()
+	d.mu.Unlock()
+
+	var err error
+	select {
+	case <-d.drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if c, ok := d.client.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+	return err
+}
+
+// Inflight returns the number of in-flight operations.
+func (d *Drainer) Inflight() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.inflight
+}
+
+// start tracks a new operation as in-flight. Returns false if the client is
+// shut down, and the operation must be rejected.
+func (d *Drainer) start() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.shutdown {
+		return false
+	}
+	d.inflight++
+	return true
+}
+
+func (d *Drainer) done() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.inflight--
+	if d.shutdown {
+		d.closeDrained()
+	}
+}
+
+// closeDrained closes the drained channel once there are no in-flight
+// operations. Must be called with mu held.
+func (d *Drainer) closeDrained() {
+	if d.closed || d.inflight != 0 {
+		return
+	}
+	d.closed = true
+	close(d.drained)
+}
+
+type drainGate struct {
+	drainer *Drainer
+}
+
+func (*drainGate) ID() string {
+	return "DrainGate"
+}
+
+func (m *drainGate) HandleInitialize(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+	out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+) {
+	if !m.drainer.start() {
+		return out, metadata, &ClientShutdownError{}
+	}
+	defer m.drainer.done()
+
+	return next.HandleInitialize(ctx, in)
+}
+
+// ClientShutdownError is returned for operations invoked after the client
+// was shut down by its Drainer.
+type ClientShutdownError struct{}
+
+func (*ClientShutdownError) Error() string {
+	return "client is shut down, operation rejected"
+}
@@ -0,0 +1,178 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+type mockIdleCloserClient struct {
+	ClientDo
+	closed int32
+}
+
+func (c *mockIdleCloserClient) CloseIdleConnections() {
+	atomic.AddInt32(&c.closed, 1)
+}
+
+func TestDrainerShutdown(t *testing.T) {
+	cases := map[string]struct {
+		Inflight  bool
+		Timeout   time.Duration
+		Unblock   time.Duration
+		ExpectErr error
+	}{
+		"no inflight": {
+			Timeout: time.Second,
+		},
+		"inflight completes": {
+			Inflight: true,
+			Timeout:  time.Second,
+			Unblock:  10 * time.Millisecond,
+		},
+		"deadline exceeded": {
+			Inflight:  true,
+			Timeout:   10 * time.Millisecond,
+			Unblock:   time.Second,
+			ExpectErr: context.DeadlineExceeded,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := &mockIdleCloserClient{}
+			drainer := NewDrainer(client)
+
+			unblock := make(chan struct{})
+			started := make(chan struct{})
+			invoke := func(block bool) error {
+				stack := middleware.NewStack("op", NewStackRequest)
+				if err := AddDrainMiddleware(stack, drainer); err != nil {
+					return err
+				}
+				handler := middleware.DecorateHandler(middleware.HandlerFunc(
+					func(ctx context.Context, in interface{}) (interface{}, middleware.Metadata, error) {
+						if block {
+							close(started)
+							<-unblock
+						}
+						return &Response{Response: &http.Response{}}, middleware.Metadata{}, nil
+					}), stack)
+				_, _, err := handler.Handle(context.Background(), struct{}{})
+				return err
+			}
+
+			first := make(chan error, 1)
+			if c.Inflight {
+				go func() { first <- invoke(true) }()
+				<-started
+				if e, a := 1, drainer.Inflight(); e != a {
+					t.Errorf("expect %v in-flight, got %v", e, a)
+				}
+				timer := time.AfterFunc(c.Unblock, func() { close(unblock) })
+				defer timer.Stop()
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+			defer cancel()
+			err := drainer.Shutdown(ctx)
+			if !errors.Is(err, c.ExpectErr) {
+				t.Errorf("expect %v error, got %v", c.ExpectErr, err)
+			}
+			if e, a := int32(1), atomic.LoadInt32(&client.closed); e != a {
+				t.Errorf("expect idle connections closed %v times, got %v", e, a)
+			}
+
+			var shutdownErr *ClientShutdownError
+			if err := invoke(false); !errors.As(err, &shutdownErr) {
+				t.Errorf("expect client shutdown error, got %v", err)
+			}
+
+			if c.Inflight && c.ExpectErr == nil {
+				if err := <-first; err != nil {
+					t.Errorf("expect in-flight operation to complete, got %v", err)
+				}
+				if e, a := 0, drainer.Inflight(); e != a {
+					t.Errorf("expect %v in-flight, got %v", e, a)
+				}
+			}
+		})
+	}
+}
+
+func TestDrainerShutdownRepeated(t *testing.T) {
+	drainer := NewDrainer(&mockIdleCloserClient{})
+	for i := 0; i < 2; i++ {
+		if err := drainer.Shutdown(context.Background()); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	}
+}
+
+func TestDrainerShutdownBetweenRetries(t *testing.T) {
+	drainer := NewDrainer(&mockIdleCloserClient{})
+
+	stack := middleware.NewStack("op", NewStackRequest)
+	if err := AddDrainMiddleware(stack, drainer); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	// retry middleware making a second attempt once the client is shut down.
+	firstAttempt := make(chan struct{})
+	retry := make(chan struct{})
+	stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("retry", func(
+		ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler,
+	) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		if _, _, err := next.HandleFinalize(ctx, in); err != nil {
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+		}
+		close(firstAttempt)
+		<-retry
+		return next.HandleFinalize(ctx, in)
+	}), middleware.After)
+
+	var attempts int32
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(ctx context.Context, in interface{}) (interface{}, middleware.Metadata, error) {
+			atomic.AddInt32(&attempts, 1)
+			return &Response{Response: &http.Response{}}, middleware.Metadata{}, nil
+		}), stack)
+
+	opErr := make(chan error, 1)
+	go func() {
+		_, _, err := handler.Handle(context.Background(), struct{}{})
+		opErr <- err
+	}()
+	<-firstAttempt
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- drainer.Shutdown(context.Background()) }()
+
+	select {
+	case err := <-shutdown:
+		t.Fatalf("expect shutdown to wait for the in-flight operation, returned %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	if e, a := 1, drainer.Inflight(); e != a {
+		t.Errorf("expect %v in-flight, got %v", e, a)
+	}
+
+	close(retry)
+	if err := <-opErr; err != nil {
+		t.Fatalf("expect no operation error, got %v", err)
+	}
+	if e, a := int32(2), atomic.LoadInt32(&attempts); e != a {
+		t.Errorf("expect %v attempts, got %v", e, a)
+	}
+	if err := <-shutdown; err != nil {
+		t.Fatalf("expect no shutdown error, got %v", err)
+	}
+	if e, a := 0, drainer.Inflight(); e != a {
+		t.Errorf("expect %v in-flight, got %v", e, a)
+	}
+}
@@ -0,0 +1,221 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// ErrorResponseDeserializer deserializes the body of an error response into
+// a modeled error.
+type ErrorResponseDeserializer func(resp *Response, body io.Reader) error
+
+// ErrorRegistry maps error codes to the deserializers of their modeled error
+// shapes. Protocols register the errors of a service when a client is
+// created, and users may register deserializers for custom error shapes.
+//
+// ErrorRegistry is safe for concurrent use.
+type ErrorRegistry struct {
+	mu            sync.RWMutex
+	deserializers map[string]ErrorResponseDeserializer
+}
+
+// NewErrorRegistry returns an empty ErrorRegistry.
+func NewErrorRegistry() *ErrorRegistry {
+	return &ErrorRegistry{
+		deserializers: map[string]ErrorResponseDeserializer{},
+	}
+}
+
+// Register associates the deserializer with the error code, replacing any
+// deserializer previously registered for the code. Error codes are matched
+// case-insensitively.
+func (r *ErrorRegistry) Register(code string, fn ErrorResponseDeserializer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deserializers[strings.ToLower(code)] = fn
+}
+
+// Lookup returns the deserializer registered for the error code.
+func (r *ErrorRegistry) Lookup(code string) (ErrorResponseDeserializer, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fn, ok := r.deserializers[strings.ToLower(code)]
+	return fn, ok
+}
+
+// ErrorCodeResolver resolves the error code of an error response. The body is
+// the buffered error response body.
+type ErrorCodeResolver interface {
+	ResolveErrorCode(resp *Response, body []byte) (string, bool)
+}
+
+// ErrorCodeResolverFunc is a function type that implements the
+// ErrorCodeResolver interface.
+type ErrorCodeResolverFunc func(resp *Response, body []byte) (string, bool)
+
+// ResolveErrorCode delegates to the wrapped function.
+func (fn ErrorCodeResolverFunc) ResolveErrorCode(resp *Response, body []byte) (string, bool) {
+	return fn(resp, body)
+}
+
+// ErrorCodeFromHeader returns an ErrorCodeResolver that reads the error code
+// from the response header.
+func ErrorCodeFromHeader(name string) ErrorCodeResolver {
+	return ErrorCodeResolverFunc(func(resp *Response, _ []byte) (string, bool) {
+		v := resp.Header.Get(name)
+		return SanitizeErrorCode(v), len(v) != 0
+	})
+}
+
+// ErrorCodeFromJSONField returns an ErrorCodeResolver that reads the error
+// code from the first of the top level fields present in a JSON response
+// body.
+func ErrorCodeFromJSONField(fields ...string) ErrorCodeResolver {
+	return ErrorCodeResolverFunc(func(_ *Response, body []byte) (string, bool) {
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return "", false
+		}
+
+		for _, field := range fields {
+			var v string
+			if raw, ok := doc[field]; ok && json.Unmarshal(raw, &v) == nil && len(v) != 0 {
+				return SanitizeErrorCode(v), true
+			}
+		}
+		return "", false
+	})
+}
+
+// ErrorCodeFromStatus returns an ErrorCodeResolver that maps the response
+// status code to an error code.
+func ErrorCodeFromStatus(codes map[int]string) ErrorCodeResolver {
+	return ErrorCodeResolverFunc(func(resp *Response, _ []byte) (string, bool) {
+		v, ok := codes[resp.StatusCode]
+		return v, ok
+	})
+}
+
+// SanitizeErrorCode removes the namespace and any trailing metadata from an
+// error code, e.g. "smithy.example#FooError:http://internal.example.com/"
+// is sanitized to "FooError".
+func SanitizeErrorCode(code string) string {
+	if i := strings.Index(code, ":"); i != -1 {
+		code = code[:i]
+	}
+	if i := strings.Index(code, "#"); i != -1 {
+		code = code[i+1:]
+	}
+	return code
+}
+
+// ErrorResponseRouter is a deserialize middleware that routes error responses
+// to the ErrorResponseDeserializer registered for the error's code. The error
+// code is resolved by the first of the Resolvers that finds one. Error
+// responses whose code cannot be resolved, or has no registered deserializer,
+// are returned as a smithy.GenericAPIError.
+//
+// Responses with a status code less than 300 are passed through unmodified.
+type ErrorResponseRouter struct {
+	Registry  *ErrorRegistry
+	Resolvers []ErrorCodeResolver
+}
+
+// AddErrorResponseRouterMiddleware adds the ErrorResponseRouter middleware to
+// the stack's Deserialize step, after the operation's deserializer.
+func AddErrorResponseRouterMiddleware(stack *middleware.Stack, registry *ErrorRegistry, resolvers ...ErrorCodeResolver) error {
+	return stack.Deserialize.Add(&ErrorResponseRouter{
+		Registry:  registry,
+		Resolvers: resolvers,
+	}, middleware.After)
+}
+
+// ID returns the middleware identifier.
+func (m *ErrorResponseRouter) ID() string {
+	return "ErrorResponseRouter"
+}
+
+// HandleDeserialize deserializes error responses returned by the next
+// handler.
+func (m *ErrorResponseRouter) HandleDeserialize(
+	ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler,
+) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	resp, ok := out.RawResponse.(*Response)
+	if !ok || resp == nil || resp.StatusCode < 300 {
+		return out, metadata, err
+	}
+
+	return out, metadata, &ResponseError{Response: resp, Err: m.deserializeError(resp, metadata)}
+}
+
+func (m *ErrorResponseRouter) deserializeError(resp *Response, metadata middleware.Metadata) error {
+	var body []byte
+	if resp.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return &smithy.DeserializationError{
+				Err: fmt.Errorf("failed to read error response body, %w", err),
+			}
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	var code string
+	for _, r := range m.Resolvers {
+		if v, ok := r.ResolveErrorCode(resp, body); ok {
+			code = v
+			break
+		}
+	}
+
+	if m.Registry != nil && len(code) != 0 {
+		if fn, ok := m.Registry.Lookup(code); ok {
+			return fn(resp, bytes.NewReader(body))
+		}
+	}
+
+	if len(code) == 0 {
+		code = "UnknownError"
+	}
+
+	fault := smithy.FaultUnknown
+	switch {
+	case resp.StatusCode >= 500:
+		fault = smithy.FaultServer
+	case resp.StatusCode >= 400:
+		fault = smithy.FaultClient
+	}
+
+	requestID, _ := middleware.GetRequestIDMetadata(metadata)
+
+	return &smithy.GenericAPIError{
+		Code:    code,
+		Message: http.StatusText(resp.StatusCode),
+		Fault:   fault,
+		Metadata: smithy.ErrorMetadata{
+			RequestID:  requestID,
+			StatusCode: resp.StatusCode,
+		},
+	}
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+type mockFooError struct {
+	Body string
+}
+
+func (e *mockFooError) Error() string { return "foo error: " + e.Body }
+
+func TestErrorResponseRouter(t *testing.T) {
+	registry := NewErrorRegistry()
+	registry.Register("FooError", func(resp *Response, body io.Reader) error {
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		return &mockFooError{Body: string(b)}
+	})
+
+	resolvers := []ErrorCodeResolver{
+		ErrorCodeFromHeader("X-Error-Type"),
+		ErrorCodeFromJSONField("__type", "code"),
+		ErrorCodeFromStatus(map[int]string{404: "NotFound"}),
+	}
+
+	cases := map[string]struct {
+		StatusCode     int
+		Header         http.Header
+		Body           string
+		ExpectFooError string
+		ExpectCode     string
+		ExpectFault    smithy.ErrorFault
+		ExpectNoError  bool
+	}{
+		"success": {
+			StatusCode:    200,
+			ExpectNoError: true,
+		},
+		"header": {
+			StatusCode:     400,
+			Header:         http.Header{"X-Error-Type": []string{"FooError:http://internal.example.com/"}},
+			Body:           `{"message":"bad"}`,
+			ExpectFooError: `{"message":"bad"}`,
+		},
+		"json field": {
+			StatusCode:     400,
+			Body:           `{"__type":"smithy.example#FooError"}`,
+			ExpectFooError: `{"__type":"smithy.example#FooError"}`,
+		},
+		"status": {
+			StatusCode:  404,
+			ExpectCode:  "NotFound",
+			ExpectFault: smithy.FaultClient,
+		},
+		"unregistered": {
+			StatusCode:  503,
+			Body:        `{"code":"BarError"}`,
+			ExpectCode:  "BarError",
+			ExpectFault: smithy.FaultServer,
+		},
+		"unknown": {
+			StatusCode:  500,
+			ExpectCode:  "UnknownError",
+			ExpectFault: smithy.FaultServer,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &ErrorResponseRouter{Registry: registry, Resolvers: resolvers}
+
+			header := c.Header
+			if header == nil {
+				header = http.Header{}
+			}
+			_, _, err := m.HandleDeserialize(context.Background(), middleware.DeserializeInput{},
+				middleware.DeserializeHandlerFunc(func(ctx context.Context, in middleware.DeserializeInput) (
+					out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+				) {
+					out.RawResponse = &Response{Response: &http.Response{
+						StatusCode: c.StatusCode,
+						Header:     header,
+						Body:       ioutil.NopCloser(strings.NewReader(c.Body)),
+					}}
+					return out, metadata, nil
+				}),
+			)
+
+			if c.ExpectNoError {
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				return
+			}
+
+			var respErr *ResponseError
+			if !errors.As(err, &respErr) {
+				t.Fatalf("expect response error, got %T", err)
+			}
+
+			if len(c.ExpectFooError) != 0 {
+				var fooErr *mockFooError
+				if !errors.As(err, &fooErr) {
+					t.Fatalf("expect foo error, got %v", err)
+				}
+				if e, a := c.ExpectFooError, fooErr.Body; e != a {
+					t.Errorf("expect %q body, got %q", e, a)
+				}
+				return
+			}
+
+			var apiErr *smithy.GenericAPIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expect generic API error, got %v", err)
+			}
+			if e, a := c.ExpectCode, apiErr.Code; e != a {
+				t.Errorf("expect %v code, got %v", e, a)
+			}
+			if e, a := c.ExpectFault, apiErr.Fault; e != a {
+				t.Errorf("expect %v fault, got %v", e, a)
+			}
+			if e, a := c.StatusCode, apiErr.Metadata.StatusCode; e != a {
+				t.Errorf("expect %v status code, got %v", e, a)
+			}
+		})
+	}
+}
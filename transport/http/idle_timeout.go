@@ -0,0 +1,216 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// TimeoutError is returned when a request or response body is idle, with no
+// data transferred, for longer than the configured idle timeout.
+type TimeoutError struct {
+	// Op is the body operation which timed out, "read" or "write".
+	Op string
+
+	// Timeout is the idle timeout which was exceeded.
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s idle timeout, no data transferred for more than %v", e.Op, e.Timeout)
+}
+
+// ConnectionError returns that the error is related to the connection to the
+// service stalling.
+func (e *TimeoutError) ConnectionError() bool {
+	return true
+}
+
+// idleWatch calls onTimeout if it is armed for longer than the timeout,
+// recording a TimeoutError for the op.
+type idleWatch struct {
+	op        string
+	timeout   time.Duration
+	onTimeout func()
+
+	mu    sync.Mutex
+	timer *time.Timer
+	err   error
+}
+
+func newIdleWatch(op string, timeout time.Duration, onTimeout func()) *idleWatch {
+	return &idleWatch{
+		op:        op,
+		timeout:   timeout,
+		onTimeout: onTimeout,
+	}
+}
+
+// arm starts the idle timer, returning the timeout error if the watch has
+// already timed out.
+func (w *idleWatch) arm() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil {
+		return w.err
+	}
+	if w.timer == nil {
+		w.timer = time.AfterFunc(w.timeout, w.fire)
+	} else {
+		w.timer.Reset(w.timeout)
+	}
+	return nil
+}
+
+// disarm stops the idle timer, returning the timeout error if the watch timed
+// out while it was armed.
+func (w *idleWatch) disarm() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	return w.err
+}
+
+// Err returns the timeout error if the watch has timed out.
+func (w *idleWatch) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+func (w *idleWatch) fire() {
+	w.mu.Lock()
+	if w.err != nil {
+		w.mu.Unlock()
+		return
+	}
+	w.err = &TimeoutError{Op: w.op, Timeout: w.timeout}
+	w.mu.Unlock()
+
+	if w.onTimeout != nil {
+		w.onTimeout()
+	}
+}
+
+// NewReadIdleTimeoutBody returns a wrapper around the body which enforces a
+// per-read inactivity timeout. If a single read blocks for longer than the
+// timeout without returning, the body is closed, unblocking the read, and
+// the read returns a *TimeoutError. All subsequent reads also return the
+// error. A timeout of zero returns the body unmodified.
+//
+// The returned body does not support concurrent reads.
+func NewReadIdleTimeoutBody(body io.ReadCloser, timeout time.Duration) io.ReadCloser {
+	if timeout <= 0 || body == nil {
+		return body
+	}
+
+	r := &readIdleTimeoutBody{body: body}
+	r.watch = newIdleWatch("read", timeout, func() {
+		_ = body.Close()
+	})
+	return r
+}
+
+type readIdleTimeoutBody struct {
+	body  io.ReadCloser
+	watch *idleWatch
+}
+
+func (r *readIdleTimeoutBody) Read(p []byte) (int, error) {
+	if err := r.watch.arm(); err != nil {
+		return 0, err
+	}
+	n, err := r.body.Read(p)
+	if terr := r.watch.disarm(); terr != nil {
+		return n, terr
+	}
+	return n, err
+}
+
+func (r *readIdleTimeoutBody) Close() error {
+	r.watch.disarm()
+	return r.body.Close()
+}
+
+// NewWriteIdleTimeoutWriter returns a wrapper around the writer which
+// enforces a per-write inactivity timeout. If a single write blocks for
+// longer than the timeout without returning, the writer is closed if it
+// implements io.Closer, and the write returns a *TimeoutError. All subsequent
+// writes also return the error. A timeout of zero returns the writer
+// unmodified.
+//
+// The returned writer does not support concurrent writes.
+func NewWriteIdleTimeoutWriter(w io.Writer, timeout time.Duration) io.Writer {
+	if timeout <= 0 || w == nil {
+		return w
+	}
+
+	var onTimeout func()
+	if c, ok := w.(io.Closer); ok {
+		onTimeout = func() { _ = c.Close() }
+	}
+	return &writeIdleTimeoutWriter{
+		writer: w,
+		watch:  newIdleWatch("write", timeout, onTimeout),
+	}
+}
+
+type writeIdleTimeoutWriter struct {
+	writer io.Writer
+	watch  *idleWatch
+}
+
+func (w *writeIdleTimeoutWriter) Write(p []byte) (int, error) {
+	if err := w.watch.arm(); err != nil {
+		return 0, err
+	}
+	n, err := w.writer.Write(p)
+	if terr := w.watch.disarm(); terr != nil {
+		return n, terr
+	}
+	return n, err
+}
+
+// requestIdleTimeoutBody wraps a request body being sent by the HTTP client.
+// The client reads from the body, then writes what it read to the
+// connection, so the watch is armed between reads, while the client is
+// blocked writing to the connection.
+type requestIdleTimeoutBody struct {
+	body  io.ReadCloser
+	watch *idleWatch
+}
+
+func (r *requestIdleTimeoutBody) Read(p []byte) (int, error) {
+	if err := r.watch.disarm(); err != nil {
+		return 0, err
+	}
+	n, err := r.body.Read(p)
+	if err == nil {
+		if terr := r.watch.arm(); terr != nil {
+			return n, terr
+		}
+	}
+	return n, err
+}
+
+func (r *requestIdleTimeoutBody) Close() error {
+	r.watch.disarm()
+	return r.body.Close()
+}
+
+// responseBodyCloser calls onClose after the response body is closed.
+type responseBodyCloser struct {
+	io.ReadCloser
+	onClose func()
+}
+
+func (c *responseBodyCloser) Close() error {
+	err := c.ReadCloser.Close()
+	c.onClose()
+	return err
+}
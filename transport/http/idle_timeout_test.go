@@ -0,0 +1,153 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadIdleTimeoutBody(t *testing.T) {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write([]byte("hello"))
+	}()
+
+	body := NewReadIdleTimeoutBody(pr, 50*time.Millisecond)
+
+	p := make([]byte, 10)
+	n, err := body.Read(p)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "hello", string(p[:n]); e != a {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+
+	_, err = body.Read(p)
+	var terr *TimeoutError
+	if !errors.As(err, &terr) {
+		t.Fatalf("expect %T error, got %v", terr, err)
+	}
+	if e, a := "read", terr.Op; e != a {
+		t.Errorf("expect %v op, got %v", e, a)
+	}
+	if e, a := 50*time.Millisecond, terr.Timeout; e != a {
+		t.Errorf("expect %v timeout, got %v", e, a)
+	}
+
+	if _, err = body.Read(p); !errors.As(err, &terr) {
+		t.Errorf("expect subsequent read %T error, got %v", terr, err)
+	}
+}
+
+func TestReadIdleTimeoutBody_NoTimeout(t *testing.T) {
+	body := ioutil.NopCloser(strings.NewReader("hello"))
+	if e, a := body, NewReadIdleTimeoutBody(body, 0); e != a {
+		t.Errorf("expect body to be unmodified")
+	}
+}
+
+func TestWriteIdleTimeoutWriter(t *testing.T) {
+	pr, pw := io.Pipe()
+	go func() {
+		p := make([]byte, 5)
+		io.ReadFull(pr, p)
+	}()
+
+	w := NewWriteIdleTimeoutWriter(pw, 50*time.Millisecond)
+	if _, err := w.Write([]byte("hello")); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	_, err := w.Write([]byte("world"))
+	var terr *TimeoutError
+	if !errors.As(err, &terr) {
+		t.Fatalf("expect %T error, got %v", terr, err)
+	}
+	if e, a := "write", terr.Op; e != a {
+		t.Errorf("expect %v op, got %v", e, a)
+	}
+}
+
+func TestClientHandler_ReadIdleTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		w.Write([]byte("hello"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	handler := NewClientHandlerWithOptions(server.Client(), func(o *ClientHandlerOptions) {
+		o.ReadIdleTimeout = 50 * time.Millisecond
+	})
+
+	req := NewStackRequest().(*Request)
+	req.URL, _ = req.URL.Parse(server.URL)
+
+	out, _, err := handler.Handle(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	resp := out.(*Response)
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	var terr *TimeoutError
+	if !errors.As(err, &terr) {
+		t.Fatalf("expect %T error, got %v", terr, err)
+	}
+	if e, a := "hello", string(b); e != a {
+		t.Errorf("expect %q body, got %q", e, a)
+	}
+}
+
+func TestClientHandler_WriteIdleTimeout(t *testing.T) {
+	client := ClientDoFunc(func(r *http.Request) (*http.Response, error) {
+		p := make([]byte, 5)
+		if _, err := io.ReadFull(r.Body, p); err != nil {
+			return nil, err
+		}
+		// Stall sending the rest of the body until the request is canceled.
+		select {
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		case <-time.After(5 * time.Second):
+			return nil, errors.New("request not canceled")
+		}
+	})
+
+	handler := NewClientHandlerWithOptions(client, func(o *ClientHandlerOptions) {
+		o.WriteIdleTimeout = 50 * time.Millisecond
+	})
+
+	req := NewStackRequest().(*Request)
+	req.URL, _ = req.URL.Parse("https://example.com")
+	req, err := req.SetStream(bytes.NewReader([]byte("hello world")))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	_, _, err = handler.Handle(context.Background(), req)
+	var sendErr *RequestSendError
+	if !errors.As(err, &sendErr) {
+		t.Fatalf("expect %T error, got %v", sendErr, err)
+	}
+	var terr *TimeoutError
+	if !errors.As(err, &terr) {
+		t.Fatalf("expect %T error, got %v", terr, err)
+	}
+	if e, a := "write", terr.Op; e != a {
+		t.Errorf("expect %v op, got %v", e, a)
+	}
+}
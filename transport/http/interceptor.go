@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+)
+
+// InterceptorContext is the state of an operation invocation that is passed
+// to interceptor hooks. Hooks may read and replace the fields available at
+// their hook point, e.g. a BeforeSerialization hook may replace the Input.
+type InterceptorContext struct {
+	// Input is the operation input. Available to all hooks.
+	Input interface{}
+
+	// Request is the serialized HTTP request. Available from the
+	// AfterSerialization hook onward.
+	Request *Request
+
+	// Response is the raw HTTP response. Available from the AfterTransmit
+	// hook onward, if a response was received.
+	Response *Response
+
+	// Output is the deserialized operation output. Available to the
+	// AfterExecution hook, if the operation succeeded.
+	Output interface{}
+}
+
+// BeforeExecutionInterceptor runs before anything else in the operation
+// lifecycle, once per operation invocation.
+type BeforeExecutionInterceptor interface {
+	BeforeExecution(ctx context.Context, in *InterceptorContext) error
+}
+
+// BeforeSerializationInterceptor runs before the operation input is
+// serialized.
+type BeforeSerializationInterceptor interface {
+	BeforeSerialization(ctx context.Context, in *InterceptorContext) error
+}
+
+// AfterSerializationInterceptor runs after the operation input is serialized
+// into the HTTP request.
+type AfterSerializationInterceptor interface {
+	AfterSerialization(ctx context.Context, in *InterceptorContext) error
+}
+
+// BeforeTransmitInterceptor runs immediately before the HTTP request is
+// sent, once per request attempt, after the request has been signed.
+type BeforeTransmitInterceptor interface {
+	BeforeTransmit(ctx context.Context, in *InterceptorContext) error
+}
+
+// AfterTransmitInterceptor runs immediately after the HTTP response is
+// received, once per request attempt.
+type AfterTransmitInterceptor interface {
+	AfterTransmit(ctx context.Context, in *InterceptorContext) error
+}
+
+// BeforeDeserializationInterceptor runs before the HTTP response is
+// deserialized, once per request attempt.
+type BeforeDeserializationInterceptor interface {
+	BeforeDeserialization(ctx context.Context, in *InterceptorContext) error
+}
+
+// AfterExecutionInterceptor runs after the operation invocation completes,
+// successfully or not. The error of the invocation, if any, is passed to the
+// hook. The error returned by the hook replaces the invocation's error.
+type AfterExecutionInterceptor interface {
+	AfterExecution(ctx context.Context, in *InterceptorContext, err error) error
+}
+
+// InterceptorRegistry holds the interceptors registered for each hook point.
+// Interceptors of a hook are invoked in the order they were registered.
+//
+// A client's InterceptorRegistry should be copied with Copy before being
+// modified for a single operation invocation.
+type InterceptorRegistry struct {
+	BeforeExecution       []BeforeExecutionInterceptor
+	BeforeSerialization   []BeforeSerializationInterceptor
+	AfterSerialization    []AfterSerializationInterceptor
+	BeforeTransmit        []BeforeTransmitInterceptor
+	AfterTransmit         []AfterTransmitInterceptor
+	BeforeDeserialization []BeforeDeserializationInterceptor
+	AfterExecution        []AfterExecutionInterceptor
+}
+
+// Add registers the interceptor for every hook interface it implements.
+// Returns false if the value does not implement any hook interface.
+func (r *InterceptorRegistry) Add(interceptor interface{}) bool {
+	var ok bool
+	if v, is := interceptor.(BeforeExecutionInterceptor); is {
+		r.BeforeExecution, ok = append(r.BeforeExecution, v), true
+	}
+	if v, is := interceptor.(BeforeSerializationInterceptor); is {
+		r.BeforeSerialization, ok = append(r.BeforeSerialization, v), true
+	}
+	if v, is := interceptor.(AfterSerializationInterceptor); is {
+		r.AfterSerialization, ok = append(r.AfterSerialization, v), true
+	}
+	if v, is := interceptor.(BeforeTransmitInterceptor); is {
+		r.BeforeTransmit, ok = append(r.BeforeTransmit, v), true
+	}
+	if v, is := interceptor.(AfterTransmitInterceptor); is {
+		r.AfterTransmit, ok = append(r.AfterTransmit, v), true
+	}
+	if v, is := interceptor.(BeforeDeserializationInterceptor); is {
+		r.BeforeDeserialization, ok = append(r.BeforeDeserialization, v), true
+	}
+	if v, is := interceptor.(AfterExecutionInterceptor); is {
+		r.AfterExecution, ok = append(r.AfterExecution, v), true
+	}
+	return ok
+}
+
+// Copy returns a copy of the registry, which may be modified without
+// affecting the original.
+func (r InterceptorRegistry) Copy() InterceptorRegistry {
+	return InterceptorRegistry{
+		BeforeExecution:       append([]BeforeExecutionInterceptor(nil), r.BeforeExecution...),
+		BeforeSerialization:   append([]BeforeSerializationInterceptor(nil), r.BeforeSerialization...),
+		AfterSerialization:    append([]AfterSerializationInterceptor(nil), r.AfterSerialization...),
+		BeforeTransmit:        append([]BeforeTransmitInterceptor(nil), r.BeforeTransmit...),
+		AfterTransmit:         append([]AfterTransmitInterceptor(nil), r.AfterTransmit...),
+		BeforeDeserialization: append([]BeforeDeserializationInterceptor(nil), r.BeforeDeserialization...),
+		AfterExecution:        append([]AfterExecutionInterceptor(nil), r.AfterExecution...),
+	}
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"context"
+
+	smithyio "github.com/aws/smithy-go/io"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// addCaptureResponseBodyMiddleware adds the middleware capturing the prefix
+// of the response body, up to limit bytes, read by the operation's
+// deserializer. The middleware is added after the deserializer, and the
+// captured prefix retrieved by a middleware preceding it with
+// getCapturedResponseBody with the same ID.
+func addCaptureResponseBodyMiddleware(stack *middleware.Stack, id string, limit int) error {
+	return stack.Deserialize.Add(&captureResponseBody{id: id, limit: limit}, middleware.After)
+}
+
+// getCapturedResponseBody returns a copy of the prefix of the response body
+// captured by the middleware with the ID, and whether the body was longer
+// than the prefix. Returns false if no body was captured.
+func getCapturedResponseBody(metadata middleware.Metadata, id string) (body []byte, truncated bool, ok bool) {
+	capture, ok := metadata.Get(capturedResponseBodyKey{id: id}).(*smithyio.PrefixCaptureReader)
+	if !ok {
+		return nil, false, false
+	}
+	prefix := capture.Prefix()
+	return append([]byte(nil), prefix.Bytes()...), prefix.Truncated(), true
+}
+
+// capturedResponseBodyKey is the metadata key of the body captured by the
+// middleware with the ID, so middleware capturing the body with different
+// limits can be added to the same stack.
+type capturedResponseBodyKey struct {
+	id string
+}
+
+// captureResponseBody wraps the raw response body so the prefix read by the
+// deserializer can be retained.
+type captureResponseBody struct {
+	id    string
+	limit int
+}
+
+func (m *captureResponseBody) ID() string { return m.id }
+
+func (m *captureResponseBody) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	resp, ok := out.RawResponse.(*Response)
+	if !ok || resp.Response == nil || resp.Body == nil {
+		return out, metadata, err
+	}
+
+	capture := smithyio.NewPrefixCaptureReader(resp.Body, m.limit)
+	resp.Body = capture
+	metadata.Set(capturedResponseBodyKey{id: m.id}, capture)
+	return out, metadata, err
+}
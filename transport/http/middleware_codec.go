@@ -0,0 +1,147 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/codec"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// CodecSerialize is a serialize middleware that marshals the operation's
+// input parameters as the request body, using the codec resolved for the
+// operation.
+type CodecSerialize struct{}
+
+// AddCodecSerializeMiddleware adds CodecSerialize to the stack's serialize
+// step.
+func AddCodecSerializeMiddleware(stack *middleware.Stack) error {
+	return stack.Serialize.Add(&CodecSerialize{}, middleware.After)
+}
+
+// ID returns the middleware identifier.
+func (*CodecSerialize) ID() string { return "CodecSerialize" }
+
+// HandleSerialize marshals the input parameters with the operation's codec,
+// and sets the request's body and content-type header.
+func (m *CodecSerialize) HandleSerialize(
+	ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler,
+) (
+	out middleware.SerializeOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown request type %T", in.Request)
+	}
+
+	c := codec.GetCodec(ctx)
+	if c == nil {
+		return out, metadata, fmt.Errorf("no codec resolved for operation")
+	}
+
+	body, err := c.Marshal(in.Parameters)
+	if err != nil {
+		return out, metadata, &smithy.SerializationError{Err: err}
+	}
+
+	req.Header.Set("Content-Type", c.MediaType())
+	if req, err = req.SetStream(bytes.NewReader(body)); err != nil {
+		return out, metadata, &smithy.SerializationError{Err: err}
+	}
+	in.Request = req
+
+	return next.HandleSerialize(ctx, in)
+}
+
+// CodecDeserialize is a deserialize middleware that unmarshals the response
+// body as the operation's output, using the codec resolved for the
+// operation.
+type CodecDeserialize struct {
+	// NewOutput returns a pointer to the operation's output value the
+	// response body is unmarshaled into.
+	NewOutput func() interface{}
+}
+
+// AddCodecDeserializeMiddleware adds CodecDeserialize to the stack's
+// deserialize step.
+func AddCodecDeserializeMiddleware(stack *middleware.Stack, newOutput func() interface{}) error {
+	return stack.Deserialize.Add(&CodecDeserialize{NewOutput: newOutput}, middleware.After)
+}
+
+// ID returns the middleware identifier.
+func (*CodecDeserialize) ID() string { return "CodecDeserialize" }
+
+// HandleDeserialize unmarshals a successful response body with the codec
+// negotiated for the response, or the operation's codec if none was
+// negotiated. Responses with a non-2xx status code are returned as a
+// ResponseError.
+func (m *CodecDeserialize) HandleDeserialize(
+	ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler,
+) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	resp, ok := out.RawResponse.(*Response)
+	if !ok {
+		return out, metadata, &smithy.DeserializationError{
+			Err: fmt.Errorf("unknown transport type %T", out.RawResponse),
+		}
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return out, metadata, &smithy.DeserializationError{
+			Err: fmt.Errorf("failed to read response body, %w", err),
+		}
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return out, metadata, &ResponseError{
+			Response: resp,
+			Err: &smithy.GenericAPIError{
+				Code:    fmt.Sprintf("%d", resp.StatusCode),
+				Message: "unexpected response status",
+			},
+		}
+	}
+
+	c, ok := codec.GetResponseCodec(metadata)
+	if !ok {
+		c = codec.GetCodec(ctx)
+	}
+	if c == nil {
+		return out, metadata, fmt.Errorf("no codec resolved for operation")
+	}
+
+	output := m.NewOutput()
+	if len(bytes.TrimSpace(body)) != 0 {
+		if err := c.Unmarshal(body, output); err != nil {
+			return out, metadata, smithy.NewDeserializationError(
+				fmt.Errorf("failed to unmarshal response body, %w", err), shapeName(output), body)
+		}
+	}
+	out.Result = output
+
+	return out, metadata, nil
+}
+
+// shapeName returns the name of the type of the shape's value, without its
+// package.
+func shapeName(v interface{}) string {
+	name := reflect.TypeOf(v).String()
+	if i := strings.LastIndex(name, "."); i != -1 {
+		return name[i+1:]
+	}
+	return strings.TrimLeft(name, "*")
+}
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/codec"
+	"github.com/aws/smithy-go/middleware"
+)
+
+type codecTestInput struct {
+	Name string `document:"name"`
+}
+
+type codecTestOutput struct {
+	Greeting string `document:"greeting"`
+}
+
+func TestCodecMiddleware(t *testing.T) {
+	registry := codec.NewRegistry(
+		codec.NewJSONCodec(codec.ProtocolAWSJSON10, "application/x-amz-json-1.0"),
+		codec.NewJSONCodec(codec.ProtocolAWSJSON11, "application/x-amz-json-1.1"),
+	)
+
+	cases := map[string]struct {
+		ctx             context.Context
+		status          int
+		body            string
+		expectType      string
+		expectGreeting  string
+		expectStatusErr bool
+		expectDeserErr  string
+	}{
+		"default protocol": {
+			ctx:            context.Background(),
+			status:         200,
+			body:           `{"greeting":"hello abc"}`,
+			expectType:     "application/x-amz-json-1.0",
+			expectGreeting: "hello abc",
+		},
+		"switched protocol": {
+			ctx:            codec.WithProtocol(context.Background(), codec.ProtocolAWSJSON11),
+			status:         200,
+			body:           `{"greeting":"hi"}`,
+			expectType:     "application/x-amz-json-1.1",
+			expectGreeting: "hi",
+		},
+		"empty body": {
+			ctx:        context.Background(),
+			status:     200,
+			expectType: "application/x-amz-json-1.0",
+		},
+		"error status": {
+			ctx:             context.Background(),
+			status:          500,
+			body:            `{"message":"failed"}`,
+			expectType:      "application/x-amz-json-1.0",
+			expectStatusErr: true,
+		},
+		"invalid body": {
+			ctx:            context.Background(),
+			status:         200,
+			body:           `{"greeting": 1}`,
+			expectType:     "application/x-amz-json-1.0",
+			expectDeserErr: "greeting",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			stack := middleware.NewStack("test", NewStackRequest)
+			codec.AddResolveCodecMiddleware(stack, registry, codec.ProtocolAWSJSON10)
+			AddCodecSerializeMiddleware(stack)
+			AddCodecDeserializeMiddleware(stack, func() interface{} { return &codecTestOutput{} })
+
+			var contentType, reqBody string
+			handler := middleware.DecorateHandler(middleware.HandlerFunc(
+				func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+					req := input.(*Request)
+					contentType = req.Header.Get("Content-Type")
+					b, _ := ioutil.ReadAll(req.GetStream())
+					reqBody = string(b)
+
+					return &Response{Response: &http.Response{
+						StatusCode: c.status,
+						Header:     http.Header{},
+						Body:       ioutil.NopCloser(strings.NewReader(c.body)),
+					}}, middleware.Metadata{}, nil
+				}), stack)
+
+			result, _, err := handler.Handle(c.ctx, &codecTestInput{Name: "abc"})
+			if e, a := c.expectType, contentType; e != a {
+				t.Errorf("expect %v content-type, got %v", e, a)
+			}
+			if e, a := `{"name":"abc"}`, reqBody; e != a {
+				t.Errorf("expect %v request body, got %v", e, a)
+			}
+
+			if c.expectStatusErr {
+				var respErr *ResponseError
+				if !errors.As(err, &respErr) {
+					t.Fatalf("expect response error, got %v", err)
+				}
+				if e, a := c.status, respErr.HTTPStatusCode(); e != a {
+					t.Errorf("expect %v status, got %v", e, a)
+				}
+				return
+			}
+			if len(c.expectDeserErr) != 0 {
+				var deserErr *smithy.DeserializationError
+				if !errors.As(err, &deserErr) {
+					t.Fatalf("expect deserialization error, got %v", err)
+				}
+				if e, a := "codecTestOutput", deserErr.Shape; e != a {
+					t.Errorf("expect %v shape, got %v", e, a)
+				}
+				if e, a := c.expectDeserErr, deserErr.Path; e != a {
+					t.Errorf("expect %v path, got %v", e, a)
+				}
+				if e, a := c.body, string(deserErr.Snippet); e != a {
+					t.Errorf("expect %v snippet, got %v", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			output, ok := result.(*codecTestOutput)
+			if !ok {
+				t.Fatalf("expect output type, got %T", result)
+			}
+			if e, a := c.expectGreeting, output.Greeting; e != a {
+				t.Errorf("expect %v greeting, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// ConcurrencyLimitScope is the scope of the buckets of in-flight requests a
+// ConcurrencyLimiter limits.
+type ConcurrencyLimitScope int
+
+// Enumeration of ConcurrencyLimitScope values.
+const (
+	// ConcurrencyLimitGlobal limits all requests with a single bucket.
+	ConcurrencyLimitGlobal ConcurrencyLimitScope = iota
+
+	// ConcurrencyLimitPerHost limits the requests to each host.
+	ConcurrencyLimitPerHost
+
+	// ConcurrencyLimitPerOperation limits the requests of each operation.
+	ConcurrencyLimitPerOperation
+)
+
+// ConcurrencyLimiterOptions provides the configuration of a
+// ConcurrencyLimiter.
+type ConcurrencyLimiterOptions struct {
+	// MaxInflight is the maximum number of in-flight requests of each
+	// bucket. Required.
+	MaxInflight int
+
+	// Scope is the scope of the buckets. Defaults to ConcurrencyLimitGlobal.
+	Scope ConcurrencyLimitScope
+
+	// QueueSize is the maximum number of requests of each bucket waiting
+	// for a request to complete when MaxInflight is reached. Zero means
+	// requests are not queued, and fail immediately. Negative means no
+	// limit.
+	QueueSize int
+
+	// QueueTimeout is the maximum duration a request waits in the queue.
+	// Zero means requests wait until their context is done.
+	QueueTimeout time.Duration
+}
+
+// ConcurrencyLimiter limits the number of concurrent in-flight requests,
+// protecting services from overload by the client. A ConcurrencyLimiter is
+// safe for concurrent use, and is shared between the stacks of the
+// operations it limits.
+type ConcurrencyLimiter struct {
+	options ConcurrencyLimiterOptions
+
+	mu      sync.Mutex
+	buckets map[string]*inflightBucket
+}
+
+// inflightBucket is the in-flight requests of a bucket. refs is the number
+// of requests holding, or waiting for, a slot of the bucket. Buckets are
+// removed from the limiter once no request refers to them, so buckets of
+// hosts, or operations, no longer in use are not retained.
+type inflightBucket struct {
+	slots   chan struct{}
+	waiting int
+	refs    int
+}
+
+// NewConcurrencyLimiter returns a ConcurrencyLimiter configured by the
+// functional options. Returns an error if MaxInflight is not set.
+func NewConcurrencyLimiter(optFns ...func(*ConcurrencyLimiterOptions)) (*ConcurrencyLimiter, error) {
+	var o ConcurrencyLimiterOptions
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	if o.MaxInflight <= 0 {
+		return nil, fmt.Errorf("concurrency limiter MaxInflight must be greater than 0, got %d", o.MaxInflight)
+	}
+
+	return &ConcurrencyLimiter{
+		options: o,
+		buckets: map[string]*inflightBucket{},
+	}, nil
+}
+
+// AddConcurrencyLimitMiddleware adds a middleware to the stack's Finalize
+// step, after retries, limiting the in-flight request attempts with the
+// limiter. A request holds its slot until its response is deserialized, so
+// the slots of operations with streaming outputs are released before the
+// output's stream is read.
+func AddConcurrencyLimitMiddleware(stack *middleware.Stack, limiter *ConcurrencyLimiter) error {
+	return stack.Finalize.Add(&concurrencyLimit{limiter: limiter}, middleware.After)
+}
+
+type concurrencyLimit struct {
+	limiter *ConcurrencyLimiter
+}
+
+func (*concurrencyLimit) ID() string {
+	return "ConcurrencyLimit"
+}
+
+func (m *concurrencyLimit) HandleFinalize(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+	out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+) {
+	release, err := m.limiter.acquire(ctx, m.limiter.bucketKey(ctx, in.Request))
+	if err != nil {
+		return out, metadata, err
+	}
+	defer release()
+
+	return next.HandleFinalize(ctx, in)
+}
+
+// bucketKey returns the key of the bucket of the request, per the limiter's
+// scope.
+func (l *ConcurrencyLimiter) bucketKey(ctx context.Context, request interface{}) string {
+	switch l.options.Scope {
+	case ConcurrencyLimitPerHost:
+		if req, ok := request.(*Request); ok && req.URL != nil {
+			return req.URL.Host
+		}
+	case ConcurrencyLimitPerOperation:
+		return middleware.GetServiceID(ctx) + "." + middleware.GetOperationName(ctx)
+	}
+	return ""
+}
+
+// acquire acquires a slot of the bucket, waiting in the bucket's queue if
+// enabled. Returns the function releasing the slot.
+func (l *ConcurrencyLimiter) acquire(ctx context.Context, key string) (func(), error) {
+	b := l.bucket(key)
+	release := func() {
+		<-b.slots
+		l.releaseBucket(key, b)
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	if !l.enqueue(b) {
+		l.releaseBucket(key, b)
+		return nil, &TooManyInflightError{Bucket: key, MaxInflight: l.options.MaxInflight}
+	}
+	defer l.dequeue(b)
+
+	var timeout <-chan time.Time
+	if l.options.QueueTimeout > 0 {
+		timer := time.NewTimer(l.options.QueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+		return release, nil
+	case <-timeout:
+		l.releaseBucket(key, b)
+		return nil, &TooManyInflightError{Bucket: key, MaxInflight: l.options.MaxInflight, QueueTimeout: true}
+	case <-ctx.Done():
+		l.releaseBucket(key, b)
+		return nil, &smithy.CanceledError{Err: ctx.Err()}
+	}
+}
+
+// bucket returns the bucket of the key, creating it if needed, and adds a
+// reference to it. The reference must be released with releaseBucket.
+func (l *ConcurrencyLimiter) bucket(key string) *inflightBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &inflightBucket{slots: make(chan struct{}, l.options.MaxInflight)}
+		l.buckets[key] = b
+	}
+	b.refs++
+	return b
+}
+
+// releaseBucket releases a reference to the bucket of the key, removing the
+// bucket once it is idle.
+func (l *ConcurrencyLimiter) releaseBucket(key string, b *inflightBucket) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b.refs--
+	if b.refs == 0 {
+		delete(l.buckets, key)
+	}
+}
+
+func (l *ConcurrencyLimiter) enqueue(b *inflightBucket) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.options.QueueSize >= 0 && b.waiting >= l.options.QueueSize {
+		return false
+	}
+	b.waiting++
+	return true
+}
+
+func (l *ConcurrencyLimiter) dequeue(b *inflightBucket) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b.waiting--
+}
+
+// TooManyInflightError is returned when a request is rejected by a
+// ConcurrencyLimiter, as its bucket's in-flight requests, and queue, are
+// full, or the request timed out waiting in the queue.
+type TooManyInflightError struct {
+	// Bucket is the key of the bucket of the request, the host or the
+	// operation per the limiter's scope. Empty for the global scope.
+	Bucket string
+
+	// MaxInflight is the maximum number of in-flight requests of the
+	// bucket.
+	MaxInflight int
+
+	// QueueTimeout is whether the request timed out waiting in the queue.
+	QueueTimeout bool
+}
+
+func (e *TooManyInflightError) Error() string {
+	msg := fmt.Sprintf("too many in-flight requests, limit %d", e.MaxInflight)
+	if len(e.Bucket) != 0 {
+		msg += fmt.Sprintf(" for %s", e.Bucket)
+	}
+	if e.QueueTimeout {
+		msg += ", timed out waiting in queue"
+	}
+	return msg
+}
@@ -0,0 +1,297 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestNewConcurrencyLimiter(t *testing.T) {
+	if _, err := NewConcurrencyLimiter(); err == nil {
+		t.Errorf("expect error for missing MaxInflight")
+	}
+}
+
+func TestConcurrencyLimiterAcquire(t *testing.T) {
+	cases := map[string]struct {
+		Options   func(*ConcurrencyLimiterOptions)
+		Ctx       func() (context.Context, context.CancelFunc)
+		Release   bool
+		ExpectErr interface{}
+	}{
+		"no queue": {
+			Options:   func(o *ConcurrencyLimiterOptions) {},
+			ExpectErr: &TooManyInflightError{},
+		},
+		"queued until release": {
+			Options: func(o *ConcurrencyLimiterOptions) {
+				o.QueueSize = 1
+			},
+			Release: true,
+		},
+		"unbounded queue": {
+			Options: func(o *ConcurrencyLimiterOptions) {
+				o.QueueSize = -1
+			},
+			Release: true,
+		},
+		"queue timeout": {
+			Options: func(o *ConcurrencyLimiterOptions) {
+				o.QueueSize = 1
+				o.QueueTimeout = 10 * time.Millisecond
+			},
+			ExpectErr: &TooManyInflightError{},
+		},
+		"canceled": {
+			Options: func(o *ConcurrencyLimiterOptions) {
+				o.QueueSize = 1
+			},
+			Ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+			ExpectErr: &smithy.CanceledError{},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			limiter, err := NewConcurrencyLimiter(func(o *ConcurrencyLimiterOptions) {
+				o.MaxInflight = 1
+				c.Options(o)
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			ctx, cancel := context.Background(), func() {}
+			if c.Ctx != nil {
+				ctx, cancel = c.Ctx()
+			}
+			defer cancel()
+
+			release, err := limiter.acquire(ctx, "")
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if c.Release {
+				time.AfterFunc(10*time.Millisecond, release)
+			}
+
+			release, err = limiter.acquire(ctx, "")
+			if c.ExpectErr != nil {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if target := reflect.New(reflect.TypeOf(c.ExpectErr)).Interface(); !errors.As(err, target) {
+					t.Errorf("expect %T error, got %T, %v", c.ExpectErr, err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			release()
+		})
+	}
+}
+
+func TestConcurrencyLimiterRemovesIdleBuckets(t *testing.T) {
+	limiter, err := NewConcurrencyLimiter(func(o *ConcurrencyLimiterOptions) {
+		o.MaxInflight = 1
+		o.QueueSize = 1
+		o.QueueTimeout = 10 * time.Millisecond
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	numBuckets := func() int {
+		limiter.mu.Lock()
+		defer limiter.mu.Unlock()
+		return len(limiter.buckets)
+	}
+
+	for _, host := range []string{"a.example.com", "b.example.com", "c.example.com"} {
+		release, err := limiter.acquire(context.Background(), host)
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		release()
+	}
+	if e, a := 0, numBuckets(); e != a {
+		t.Errorf("expect %v buckets, got %v", e, a)
+	}
+
+	release, err := limiter.acquire(context.Background(), "a.example.com")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if _, err := limiter.acquire(context.Background(), "a.example.com"); err == nil {
+		t.Fatalf("expect queue timeout error, got none")
+	}
+	if e, a := 1, numBuckets(); e != a {
+		t.Errorf("expect %v buckets while slot is held, got %v", e, a)
+	}
+
+	release()
+	if e, a := 0, numBuckets(); e != a {
+		t.Errorf("expect %v buckets, got %v", e, a)
+	}
+}
+
+func TestConcurrencyLimiterQueueFull(t *testing.T) {
+	limiter, err := NewConcurrencyLimiter(func(o *ConcurrencyLimiterOptions) {
+		o.MaxInflight = 1
+		o.QueueSize = 1
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	release, err := limiter.acquire(context.Background(), "")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	queued := make(chan error)
+	go func() {
+		release, err := limiter.acquire(context.Background(), "")
+		if err == nil {
+			release()
+		}
+		queued <- err
+	}()
+
+	// Wait for the request to be queued.
+	for {
+		limiter.mu.Lock()
+		waiting := limiter.buckets[""].waiting
+		limiter.mu.Unlock()
+		if waiting == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	var inflightErr *TooManyInflightError
+	if _, err := limiter.acquire(context.Background(), ""); !errors.As(err, &inflightErr) {
+		t.Errorf("expect too many in-flight error, got %v", err)
+	}
+
+	release()
+	if err := <-queued; err != nil {
+		t.Errorf("expect queued request to acquire slot, got %v", err)
+	}
+}
+
+func TestConcurrencyLimitMiddleware(t *testing.T) {
+	cases := map[string]struct {
+		Scope        ConcurrencyLimitScope
+		SecondHost   string
+		SecondOp     string
+		ExpectErr    bool
+		ExpectBucket string
+	}{
+		"global": {
+			Scope:      ConcurrencyLimitGlobal,
+			SecondHost: "b.example.com",
+			SecondOp:   "OpB",
+			ExpectErr:  true,
+		},
+		"per host same host": {
+			Scope:        ConcurrencyLimitPerHost,
+			SecondHost:   "a.example.com",
+			SecondOp:     "OpB",
+			ExpectErr:    true,
+			ExpectBucket: "a.example.com",
+		},
+		"per host other host": {
+			Scope:      ConcurrencyLimitPerHost,
+			SecondHost: "b.example.com",
+			SecondOp:   "OpA",
+		},
+		"per operation same operation": {
+			Scope:        ConcurrencyLimitPerOperation,
+			SecondHost:   "b.example.com",
+			SecondOp:     "OpA",
+			ExpectErr:    true,
+			ExpectBucket: "Service.OpA",
+		},
+		"per operation other operation": {
+			Scope:      ConcurrencyLimitPerOperation,
+			SecondHost: "a.example.com",
+			SecondOp:   "OpB",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			limiter, err := NewConcurrencyLimiter(func(o *ConcurrencyLimiterOptions) {
+				o.MaxInflight = 1
+				o.Scope = c.Scope
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			unblock := make(chan struct{})
+			started := make(chan struct{})
+			invoke := func(host, op string, block bool) error {
+				stack := middleware.NewStack(op, NewStackRequest)
+				if err := middleware.AddOperationMetadataMiddleware(stack, "Service", op); err != nil {
+					return err
+				}
+				stack.Serialize.Add(middleware.SerializeMiddlewareFunc("endpoint",
+					func(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+						middleware.SerializeOutput, middleware.Metadata, error,
+					) {
+						in.Request.(*Request).URL = &url.URL{Scheme: "https", Host: host}
+						return next.HandleSerialize(ctx, in)
+					}), middleware.After)
+				if err := AddConcurrencyLimitMiddleware(stack, limiter); err != nil {
+					return err
+				}
+
+				handler := middleware.DecorateHandler(middleware.HandlerFunc(
+					func(ctx context.Context, in interface{}) (interface{}, middleware.Metadata, error) {
+						if block {
+							close(started)
+							<-unblock
+						}
+						return &Response{}, middleware.Metadata{}, nil
+					}), stack)
+				_, _, err := handler.Handle(context.Background(), struct{}{})
+				return err
+			}
+
+			first := make(chan error)
+			go func() { first <- invoke("a.example.com", "OpA", true) }()
+			<-started
+
+			err = invoke(c.SecondHost, c.SecondOp, false)
+			close(unblock)
+			if err := <-first; err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if !c.ExpectErr {
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				return
+			}
+			var inflightErr *TooManyInflightError
+			if !errors.As(err, &inflightErr) {
+				t.Fatalf("expect too many in-flight error, got %v", err)
+			}
+			if e, a := c.ExpectBucket, inflightErr.Bucket; e != a {
+				t.Errorf("expect %q bucket, got %q", e, a)
+			}
+		})
+	}
+}
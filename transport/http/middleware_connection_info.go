@@ -0,0 +1,115 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// ConnectionInfo describes the connection a request attempt was sent over,
+// for debugging connection churn, and exhaustion of ephemeral ports, e.g.
+// behind NAT gateways.
+type ConnectionInfo struct {
+	// Reused is whether the connection was previously used for another
+	// request.
+	Reused bool
+
+	// WasIdle is whether the connection was obtained from the idle pool.
+	WasIdle bool
+
+	// IdleTime is the duration the connection was idle for, if WasIdle.
+	IdleTime time.Duration
+
+	// TLSResumed is whether the connection's TLS session was resumed from a
+	// previous session, instead of a full handshake.
+	TLSResumed bool
+
+	// RemoteAddr is the address of the remote end of the connection.
+	RemoteAddr string
+
+	// LocalAddr is the address of the local end of the connection.
+	LocalAddr string
+}
+
+type connectionInfoKey struct{}
+
+// GetConnectionInfo returns the ConnectionInfo of the request attempt, and
+// false if the attempt did not obtain a connection, or the stack has no
+// connection info middleware, see AddConnectionInfoMiddleware.
+//
+// The metadata is scoped to the attempt, the operation's result metadata
+// holds the ConnectionInfo of the last attempt.
+func GetConnectionInfo(metadata middleware.Metadata) (ConnectionInfo, bool) {
+	v, ok := metadata.Get(connectionInfoKey{}).(ConnectionInfo)
+	return v, ok
+}
+
+func setConnectionInfo(metadata *middleware.Metadata, info ConnectionInfo) {
+	metadata.Set(connectionInfoKey{}, info)
+}
+
+// AddConnectionInfoMiddleware adds a middleware to the stack's Deserialize
+// step recording the ConnectionInfo of each request attempt in the attempt's
+// metadata, retrieved with GetConnectionInfo. The connection is traced with
+// net/http/httptrace, so clients not using net/http do not record it.
+func AddConnectionInfoMiddleware(stack *middleware.Stack) error {
+	return stack.Deserialize.Add(&connectionInfo{}, middleware.After)
+}
+
+type connectionInfo struct{}
+
+func (*connectionInfo) ID() string {
+	return "ConnectionInfo"
+}
+
+func (*connectionInfo) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	var mu sync.Mutex
+	var info ConnectionInfo
+	var gotConn bool
+	trace := &httptrace.ClientTrace{
+		GotConn: func(conn httptrace.GotConnInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			gotConn = true
+			info.Reused = conn.Reused
+			info.WasIdle = conn.WasIdle
+			info.IdleTime = conn.IdleTime
+			if conn.Conn != nil {
+				info.RemoteAddr = conn.Conn.RemoteAddr().String()
+				info.LocalAddr = conn.Conn.LocalAddr().String()
+			}
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			info.TLSResumed = state.DidResume
+		},
+	}
+
+	out, metadata, err = next.HandleDeserialize(httptrace.WithClientTrace(ctx, trace), in)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !gotConn {
+		return out, metadata, err
+	}
+
+	// Reused connections do not handshake, the response has the state of
+	// the connection's TLS session.
+	if resp, ok := out.RawResponse.(*Response); ok && resp.Response != nil && resp.TLS != nil {
+		info.TLSResumed = resp.TLS.DidResume
+	}
+	setConnectionInfo(&metadata, info)
+
+	return out, metadata, err
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestConnectionInfoMiddleware(t *testing.T) {
+	cases := map[string]struct {
+		Server func(http.Handler) *httptest.Server
+	}{
+		"http":  {Server: httptest.NewServer},
+		"https": {Server: httptest.NewTLSServer},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := c.Server(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("ok"))
+			}))
+			defer server.Close()
+
+			serverURL, err := url.Parse(server.URL)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			client := server.Client()
+			defer client.CloseIdleConnections()
+
+			invoke := func() middleware.Metadata {
+				stack := middleware.NewStack("op", NewStackRequest)
+				stack.Serialize.Add(middleware.SerializeMiddlewareFunc("endpoint",
+					func(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+						middleware.SerializeOutput, middleware.Metadata, error,
+					) {
+						in.Request.(*Request).URL = serverURL
+						return next.HandleSerialize(ctx, in)
+					}), middleware.After)
+				stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("readBody",
+					func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+						middleware.DeserializeOutput, middleware.Metadata, error,
+					) {
+						out, metadata, err := next.HandleDeserialize(ctx, in)
+						if resp, ok := out.RawResponse.(*Response); ok {
+							io.Copy(ioutil.Discard, resp.Body)
+							resp.Body.Close()
+						}
+						return out, metadata, err
+					}), middleware.Before)
+				if err := AddConnectionInfoMiddleware(stack); err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+
+				handler := middleware.DecorateHandler(NewClientHandler(client), stack)
+				_, metadata, err := handler.Handle(context.Background(), struct{}{})
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				return metadata
+			}
+
+			for i, expectReused := range []bool{false, true} {
+				info, ok := GetConnectionInfo(invoke())
+				if !ok {
+					t.Fatalf("%d, expect connection info", i)
+				}
+				if e, a := expectReused, info.Reused; e != a {
+					t.Errorf("%d, expect reused %v, got %v", i, e, a)
+				}
+				if e, a := serverURL.Host, info.RemoteAddr; e != a {
+					t.Errorf("%d, expect %v remote address, got %v", i, e, a)
+				}
+				if len(info.LocalAddr) == 0 {
+					t.Errorf("%d, expect local address", i)
+				}
+				if info.TLSResumed {
+					t.Errorf("%d, expect TLS session not resumed", i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/smithy-go/codec"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// SmithyProtocolHeader is the header identifying the protocol of a request
+// or response, for protocols that cannot be identified by content-type
+// alone.
+const SmithyProtocolHeader = "Smithy-Protocol"
+
+// protocolRPCv2CBOR is the shape ID of the rpcv2Cbor protocol, whose codec is
+// provided by the caller.
+const protocolRPCv2CBOR = "smithy.protocols#rpcv2Cbor"
+
+// DefaultProtocolHeaderValues are the smithy-protocol header values of the
+// protocols which use the header.
+var DefaultProtocolHeaderValues = map[string]string{
+	protocolRPCv2CBOR: "rpc-v2-cbor",
+}
+
+// ContentNegotiationOptions provides the configuration of the content
+// negotiation middleware.
+type ContentNegotiationOptions struct {
+	// Registry is the set of codecs the client supports. The media types of
+	// the codecs are advertised in the request's Accept header.
+	Registry *codec.Registry
+
+	// ProtocolHeaderValues maps protocol shape IDs to their smithy-protocol
+	// header value. Defaults to DefaultProtocolHeaderValues.
+	ProtocolHeaderValues map[string]string
+}
+
+// AddContentNegotiationMiddleware adds the middleware which advertise the
+// client's supported protocols on the request, and select the codec of the
+// response based on its smithy-protocol or content-type header.
+//
+// The response codec is selected in the deserialize step after
+// CodecDeserialize, so must be added after it.
+func AddContentNegotiationMiddleware(stack *middleware.Stack, optFns ...func(*ContentNegotiationOptions)) error {
+	o := ContentNegotiationOptions{
+		ProtocolHeaderValues: DefaultProtocolHeaderValues,
+	}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	if o.Registry == nil {
+		return fmt.Errorf("content negotiation requires a codec registry")
+	}
+
+	if err := stack.Build.Add(&acceptProtocols{options: o}, middleware.After); err != nil {
+		return err
+	}
+	return stack.Deserialize.Add(&negotiateResponseCodec{options: o}, middleware.After)
+}
+
+// acceptProtocols sets the Accept header to the media types of the client's
+// supported protocols, and the smithy-protocol header of the request's
+// protocol.
+type acceptProtocols struct {
+	options ContentNegotiationOptions
+}
+
+func (*acceptProtocols) ID() string { return "AcceptProtocols" }
+
+func (m *acceptProtocols) HandleBuild(
+	ctx context.Context, in middleware.BuildInput, next middleware.BuildHandler,
+) (
+	out middleware.BuildOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown request type %T", in.Request)
+	}
+
+	// The operation's codec is preferred, followed by the other supported
+	// protocols in sorted order.
+	var mediaTypes []string
+	seen := map[string]struct{}{}
+	appendMediaType := func(mt string) {
+		if _, ok := seen[mt]; ok || len(mt) == 0 {
+			return
+		}
+		seen[mt] = struct{}{}
+		mediaTypes = append(mediaTypes, mt)
+	}
+
+	c := codec.GetCodec(ctx)
+	if c != nil {
+		appendMediaType(c.MediaType())
+	}
+	for _, p := range m.options.Registry.Protocols() {
+		pc, _ := m.options.Registry.Lookup(p)
+		appendMediaType(pc.MediaType())
+	}
+
+	if len(req.Header.Get("Accept")) == 0 && len(mediaTypes) != 0 {
+		req.Header.Set("Accept", strings.Join(mediaTypes, ", "))
+	}
+	if c != nil {
+		if v, ok := m.options.ProtocolHeaderValues[c.Protocol()]; ok {
+			req.Header.Set(SmithyProtocolHeader, v)
+		}
+	}
+
+	return next.HandleBuild(ctx, in)
+}
+
+// negotiateResponseCodec selects the codec of the response from its
+// smithy-protocol header, or its content-type if the header is not set.
+type negotiateResponseCodec struct {
+	options ContentNegotiationOptions
+}
+
+func (*negotiateResponseCodec) ID() string { return "NegotiateResponseCodec" }
+
+func (m *negotiateResponseCodec) HandleDeserialize(
+	ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler,
+) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	resp, ok := out.RawResponse.(*Response)
+	if !ok {
+		return out, metadata, nil
+	}
+
+	if v := resp.Header.Get(SmithyProtocolHeader); len(v) != 0 {
+		for protocol, hv := range m.options.ProtocolHeaderValues {
+			if !strings.EqualFold(hv, v) {
+				continue
+			}
+			if c, err := m.options.Registry.Lookup(protocol); err == nil {
+				codec.SetResponseCodec(&metadata, c)
+				return out, metadata, nil
+			}
+		}
+	}
+
+	if c, ok := m.options.Registry.LookupMediaType(resp.Header.Get("Content-Type")); ok {
+		codec.SetResponseCodec(&metadata, c)
+	}
+
+	return out, metadata, nil
+}
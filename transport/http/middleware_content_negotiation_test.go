@@ -0,0 +1,141 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/codec"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// rawCodec is a codec that marshals the greeting of the output as its raw
+// payload, standing in for a binary protocol.
+type rawCodec struct{}
+
+func (rawCodec) Protocol() string  { return protocolRPCv2CBOR }
+func (rawCodec) MediaType() string { return "application/cbor" }
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	return []byte(v.(*codecTestInput).Name), nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	v.(*codecTestOutput).Greeting = string(data)
+	return nil
+}
+
+func TestContentNegotiation(t *testing.T) {
+	registry := codec.NewRegistry(
+		codec.NewJSONCodec(codec.ProtocolAWSJSON10, "application/x-amz-json-1.0"),
+		codec.NewJSONCodec(codec.ProtocolAWSJSON11, "application/x-amz-json-1.1"),
+		rawCodec{},
+	)
+
+	cases := map[string]struct {
+		protocol       string
+		respHeader     http.Header
+		respBody       string
+		expectAccept   string
+		expectProtocol string
+		expectGreeting string
+	}{
+		"same protocol": {
+			protocol: codec.ProtocolAWSJSON10,
+			respHeader: http.Header{
+				"Content-Type": []string{"application/x-amz-json-1.0"},
+			},
+			respBody:       `{"greeting":"hello"}`,
+			expectAccept:   "application/x-amz-json-1.0, application/x-amz-json-1.1, application/cbor",
+			expectGreeting: "hello",
+		},
+		"smithy-protocol header": {
+			protocol: codec.ProtocolAWSJSON10,
+			respHeader: http.Header{
+				"Content-Type":    []string{"application/octet-stream"},
+				"Smithy-Protocol": []string{"rpc-v2-cbor"},
+			},
+			respBody:       `raw greeting`,
+			expectAccept:   "application/x-amz-json-1.0, application/x-amz-json-1.1, application/cbor",
+			expectGreeting: "raw greeting",
+		},
+		"content-type": {
+			protocol: codec.ProtocolAWSJSON10,
+			respHeader: http.Header{
+				"Content-Type": []string{"application/x-amz-json-1.1; charset=utf-8"},
+			},
+			respBody:       `{"greeting":"rest"}`,
+			expectAccept:   "application/x-amz-json-1.0, application/x-amz-json-1.1, application/cbor",
+			expectGreeting: "rest",
+		},
+		"request protocol header": {
+			protocol: protocolRPCv2CBOR,
+			respHeader: http.Header{
+				"Smithy-Protocol": []string{"rpc-v2-cbor"},
+			},
+			respBody:       `cbor`,
+			expectAccept:   "application/cbor, application/x-amz-json-1.0, application/x-amz-json-1.1",
+			expectProtocol: "rpc-v2-cbor",
+			expectGreeting: "cbor",
+		},
+		"unknown content-type": {
+			protocol: codec.ProtocolAWSJSON10,
+			respHeader: http.Header{
+				"Content-Type": []string{"text/plain"},
+			},
+			respBody:       `{"greeting":"fallback"}`,
+			expectAccept:   "application/x-amz-json-1.0, application/x-amz-json-1.1, application/cbor",
+			expectGreeting: "fallback",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			stack := middleware.NewStack("test", NewStackRequest)
+			codec.AddResolveCodecMiddleware(stack, registry, c.protocol)
+			AddCodecSerializeMiddleware(stack)
+			AddCodecDeserializeMiddleware(stack, func() interface{} { return &codecTestOutput{} })
+			err := AddContentNegotiationMiddleware(stack, func(o *ContentNegotiationOptions) {
+				o.Registry = registry
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			var reqHeader http.Header
+			handler := middleware.DecorateHandler(middleware.HandlerFunc(
+				func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+					reqHeader = input.(*Request).Header
+					return &Response{Response: &http.Response{
+						StatusCode: 200,
+						Header:     c.respHeader,
+						Body:       ioutil.NopCloser(strings.NewReader(c.respBody)),
+					}}, middleware.Metadata{}, nil
+				}), stack)
+
+			result, _, err := handler.Handle(context.Background(), &codecTestInput{Name: "abc"})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := c.expectAccept, reqHeader.Get("Accept"); e != a {
+				t.Errorf("expect %q accept, got %q", e, a)
+			}
+			if e, a := c.expectProtocol, reqHeader.Get(SmithyProtocolHeader); e != a {
+				t.Errorf("expect %q smithy-protocol, got %q", e, a)
+			}
+			if e, a := c.expectGreeting, result.(*codecTestOutput).Greeting; e != a {
+				t.Errorf("expect %q greeting, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestAddContentNegotiationMiddleware_NoRegistry(t *testing.T) {
+	stack := middleware.NewStack("test", NewStackRequest)
+	if err := AddContentNegotiationMiddleware(stack); err == nil {
+		t.Errorf("expect error without registry")
+	}
+}
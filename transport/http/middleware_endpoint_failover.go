@@ -0,0 +1,111 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// EndpointFailoverOptions provides the configuration of the endpoint
+// failover middleware.
+type EndpointFailoverOptions struct {
+	// Endpoints are the candidate endpoints of the operation, in the order
+	// they are tried. Required.
+	Endpoints []EndpointOverride
+
+	// ShouldFailover returns whether the request is retried with the next
+	// endpoint after failing with the error. Defaults to errors with a
+	// ConnectionError method returning true, such as RequestSendError.
+	ShouldFailover func(error) bool
+}
+
+type failoverEndpointKey struct{}
+
+// GetFailoverEndpoint returns the endpoint that served the successful
+// attempt of the operation, and false if the stack has no endpoint failover
+// middleware, or no attempt succeeded.
+func GetFailoverEndpoint(metadata middleware.Metadata) (EndpointOverride, bool) {
+	v, ok := metadata.Get(failoverEndpointKey{}).(EndpointOverride)
+	return v, ok
+}
+
+func setFailoverEndpoint(metadata *middleware.Metadata, endpoint EndpointOverride) {
+	metadata.Set(failoverEndpointKey{}, endpoint)
+}
+
+// AddEndpointFailoverMiddleware adds a middleware to the stack's Finalize
+// step, before the request is signed, that sends the request to each of the
+// candidate endpoints in order, until the request does not fail with a
+// connection error, within a single attempt of the operation. The endpoint
+// that served the request is retrieved from the result's metadata with
+// GetFailoverEndpoint.
+//
+// The request's stream must be seekable to be sent to more than one
+// endpoint. Returns an error if no endpoints are configured.
+func AddEndpointFailoverMiddleware(stack *middleware.Stack, optFns ...func(*EndpointFailoverOptions)) error {
+	var o EndpointFailoverOptions
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	if len(o.Endpoints) == 0 {
+		return fmt.Errorf("endpoint failover requires at least one endpoint")
+	}
+	if o.ShouldFailover == nil {
+		o.ShouldFailover = isConnectionError
+	}
+
+	m := &endpointFailover{options: o}
+	if err := stack.Finalize.Insert(m, middleware.SlotSigning, middleware.Before); err == nil {
+		return nil
+	}
+	return stack.Finalize.Add(m, middleware.After)
+}
+
+type endpointFailover struct {
+	options EndpointFailoverOptions
+}
+
+func (*endpointFailover) ID() string {
+	return "EndpointFailover"
+}
+
+func (m *endpointFailover) HandleFinalize(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+	out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type %T", in.Request)
+	}
+
+	for i, endpoint := range m.options.Endpoints {
+		if i > 0 {
+			if rerr := req.RewindStream(); rerr != nil {
+				return out, metadata, fmt.Errorf("failed to rewind request stream for endpoint failover, %w", rerr)
+			}
+		}
+
+		attemptReq := req.Clone()
+		if err := applyEndpoint(attemptReq, endpoint); err != nil {
+			return out, metadata, err
+		}
+		in.Request = attemptReq
+
+		out, metadata, err = next.HandleFinalize(ctx, in)
+		if err == nil {
+			setFailoverEndpoint(&metadata, endpoint)
+			return out, metadata, nil
+		}
+		if ctx.Err() != nil || !m.options.ShouldFailover(err) {
+			return out, metadata, err
+		}
+	}
+
+	return out, metadata, err
+}
+
+func isConnectionError(err error) bool {
+	var v interface{ ConnectionError() bool }
+	return errors.As(err, &v) && v.ConnectionError()
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestEndpointFailoverMiddleware(t *testing.T) {
+	endpoints := []EndpointOverride{
+		{Scheme: "https", Host: "a.example.com"},
+		{Scheme: "https", Host: "b.example.com", PathPrefix: "/prefix"},
+		{Scheme: "https", Host: "c.example.com"},
+	}
+
+	cases := map[string]struct {
+		Errors         map[string]error
+		Stream         string
+		ExpectHosts    []string
+		ExpectEndpoint int
+		ExpectErr      string
+	}{
+		"first endpoint": {
+			ExpectHosts:    []string{"a.example.com"},
+			ExpectEndpoint: 0,
+		},
+		"connection error fails over": {
+			Errors: map[string]error{
+				"a.example.com": &RequestSendError{Err: fmt.Errorf("connection refused")},
+			},
+			Stream:         "payload",
+			ExpectHosts:    []string{"a.example.com", "b.example.com"},
+			ExpectEndpoint: 1,
+		},
+		"other error does not fail over": {
+			Errors: map[string]error{
+				"a.example.com": fmt.Errorf("bad request"),
+			},
+			ExpectHosts: []string{"a.example.com"},
+			ExpectErr:   "bad request",
+		},
+		"all endpoints fail": {
+			Errors: map[string]error{
+				"a.example.com": &RequestSendError{Err: fmt.Errorf("a refused")},
+				"b.example.com": &RequestSendError{Err: fmt.Errorf("b refused")},
+				"c.example.com": &RequestSendError{Err: fmt.Errorf("c refused")},
+			},
+			ExpectHosts: []string{"a.example.com", "b.example.com", "c.example.com"},
+			ExpectErr:   "c refused",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			stack := middleware.NewStack("op", NewStackRequest)
+			if err := AddEndpointFailoverMiddleware(stack, func(o *EndpointFailoverOptions) {
+				o.Endpoints = endpoints
+			}); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			stack.Serialize.Add(middleware.SerializeMiddlewareFunc("stream",
+				func(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+					middleware.SerializeOutput, middleware.Metadata, error,
+				) {
+					req := in.Request.(*Request)
+					req.URL.Path = "/path"
+					req, err := req.SetStream(strings.NewReader(c.Stream))
+					if err != nil {
+						return middleware.SerializeOutput{}, middleware.Metadata{}, err
+					}
+					in.Request = req
+					return next.HandleSerialize(ctx, in)
+				}), middleware.After)
+
+			var hosts []string
+			handler := middleware.DecorateHandler(middleware.HandlerFunc(
+				func(ctx context.Context, in interface{}) (interface{}, middleware.Metadata, error) {
+					req := in.(*Request)
+					hosts = append(hosts, req.URL.Host)
+					if req.URL.Host == "b.example.com" {
+						if e, a := "/prefix/path", req.URL.Path; e != a {
+							t.Errorf("expect %v path, got %v", e, a)
+						}
+					}
+					body, _ := ioutil.ReadAll(req.GetStream())
+					if e, a := c.Stream, string(body); e != a {
+						t.Errorf("expect %q stream, got %q", e, a)
+					}
+					return &Response{}, middleware.Metadata{}, c.Errors[req.URL.Host]
+				}), stack)
+
+			_, metadata, err := handler.Handle(context.Background(), struct{}{})
+			if !reflect.DeepEqual(c.ExpectHosts, hosts) {
+				t.Errorf("expect %v hosts, got %v", c.ExpectHosts, hosts)
+			}
+			if len(c.ExpectErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := c.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %q, got %q", e, a)
+				}
+				if _, ok := GetFailoverEndpoint(metadata); ok {
+					t.Errorf("expect no failover endpoint")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			endpoint, ok := GetFailoverEndpoint(metadata)
+			if !ok {
+				t.Fatalf("expect failover endpoint")
+			}
+			if e, a := endpoints[c.ExpectEndpoint], endpoint; e != a {
+				t.Errorf("expect %v endpoint, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestAddEndpointFailoverMiddlewareNoEndpoints(t *testing.T) {
+	stack := middleware.NewStack("op", NewStackRequest)
+	if err := AddEndpointFailoverMiddleware(stack); err == nil {
+		t.Errorf("expect error for missing endpoints")
+	}
+}
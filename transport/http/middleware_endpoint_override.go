@@ -0,0 +1,155 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// EndpointOverride is the endpoint a request is sent to instead of the
+// endpoint resolved for the operation, such as a local emulator of the
+// service.
+type EndpointOverride struct {
+	// Scheme of the endpoint, "http" or "https".
+	Scheme string
+
+	// Host of the endpoint, with the port if not the scheme's default.
+	Host string
+
+	// PathPrefix is prefixed to the path of the request, if not empty.
+	PathPrefix string
+}
+
+// ParseEndpointOverride parses and validates the endpoint URL as an
+// EndpointOverride. The endpoint must have an http or https scheme and a
+// valid host, and must not have user info, a query, or a fragment.
+//
+// The endpoint is normalized so that the request's Host header is
+// consistent with how it is signed: the scheme and host are lower cased,
+// the scheme's default port is removed, and a trailing slash is removed
+// from the path.
+func ParseEndpointOverride(endpoint string) (EndpointOverride, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return EndpointOverride{}, fmt.Errorf("invalid endpoint override %q, %w", endpoint, err)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return EndpointOverride{}, fmt.Errorf("invalid endpoint override %q, scheme must be http or https", endpoint)
+	}
+	if len(u.Host) == 0 {
+		return EndpointOverride{}, fmt.Errorf("invalid endpoint override %q, host required", endpoint)
+	}
+	if u.User != nil || len(u.RawQuery) != 0 || len(u.Fragment) != 0 {
+		return EndpointOverride{}, fmt.Errorf(
+			"invalid endpoint override %q, user info, query, and fragment not supported", endpoint)
+	}
+
+	hostname, port := strings.ToLower(u.Hostname()), u.Port()
+	if ip := net.ParseIP(hostname); ip == nil {
+		if err := ValidateEndpointHost(hostname); err != nil {
+			return EndpointOverride{}, fmt.Errorf("invalid endpoint override %q, %w", endpoint, err)
+		}
+	}
+	if len(port) != 0 && !ValidPortNumber(port) {
+		return EndpointOverride{}, fmt.Errorf("invalid endpoint override %q, invalid port %v", endpoint, port)
+	}
+	if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		port = ""
+	}
+
+	host := hostname
+	if strings.Contains(hostname, ":") {
+		host = "[" + hostname + "]"
+	}
+	if len(port) != 0 {
+		host = host + ":" + port
+	}
+
+	return EndpointOverride{
+		Scheme:     scheme,
+		Host:       host,
+		PathPrefix: strings.TrimRight(u.EscapedPath(), "/"),
+	}, nil
+}
+
+type endpointOverrideKey struct{}
+
+// ContextWithEndpointOverride returns a context that sends the requests of
+// operations invoked with it to the endpoint override. The override is
+// applied by the middleware added with AddEndpointOverrideMiddleware.
+func ContextWithEndpointOverride(ctx context.Context, endpoint EndpointOverride) context.Context {
+	return context.WithValue(ctx, endpointOverrideKey{}, endpoint)
+}
+
+// GetEndpointOverride returns the endpoint override of the context, if any.
+func GetEndpointOverride(ctx context.Context) (EndpointOverride, bool) {
+	v, ok := ctx.Value(endpointOverrideKey{}).(EndpointOverride)
+	return v, ok
+}
+
+// EndpointOverrideMiddleware is a Finalize middleware that replaces the
+// scheme, host, and port of the request, and optionally prefixes its path,
+// with the endpoint override of the context.
+type EndpointOverrideMiddleware struct{}
+
+// AddEndpointOverrideMiddleware adds EndpointOverrideMiddleware to the
+// Finalize step, before the request is signed.
+func AddEndpointOverrideMiddleware(stack *middleware.Stack) error {
+	m := &EndpointOverrideMiddleware{}
+	if err := stack.Finalize.Insert(m, middleware.SlotSigning, middleware.Before); err == nil {
+		return nil
+	}
+	return stack.Finalize.Add(m, middleware.After)
+}
+
+// ID returns the middleware identifier.
+func (*EndpointOverrideMiddleware) ID() string { return "EndpointOverride" }
+
+// HandleFinalize applies the endpoint override of the context to the
+// request, if present.
+func (m *EndpointOverrideMiddleware) HandleFinalize(
+	ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler,
+) (
+	out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+) {
+	endpoint, ok := GetEndpointOverride(ctx)
+	if !ok {
+		return next.HandleFinalize(ctx, in)
+	}
+
+	req, ok := in.Request.(*Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type %T", in.Request)
+	}
+
+	if err := applyEndpoint(req, endpoint); err != nil {
+		return out, metadata, err
+	}
+
+	return next.HandleFinalize(ctx, in)
+}
+
+// applyEndpoint replaces the scheme, host, and port of the request, and
+// prefixes its path, with the endpoint.
+func applyEndpoint(req *Request, endpoint EndpointOverride) error {
+	req.URL.Scheme = endpoint.Scheme
+	req.URL.Host = endpoint.Host
+	if len(endpoint.PathPrefix) != 0 {
+		prefix, err := url.PathUnescape(endpoint.PathPrefix)
+		if err != nil {
+			return fmt.Errorf("invalid endpoint override path, %w", err)
+		}
+		if len(req.URL.RawPath) != 0 {
+			req.URL.RawPath = JoinPath(endpoint.PathPrefix, req.URL.RawPath)
+		}
+		req.URL.Path = JoinPath(prefix, req.URL.Path)
+	}
+	req.Host = ""
+	return nil
+}
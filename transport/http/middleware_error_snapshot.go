@@ -0,0 +1,233 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	smithy "github.com/aws/smithy-go"
+	smithyio "github.com/aws/smithy-go/io"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// DefaultSnapshotRedactHeaders are the headers removed from request and
+// response snapshots, if not otherwise configured.
+var DefaultSnapshotRedactHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Amz-Security-Token",
+}
+
+// DefaultSnapshotRedactQueryKeys are the query string keys removed from
+// request snapshot URLs, if not otherwise configured.
+var DefaultSnapshotRedactQueryKeys = []string{
+	"X-Amz-Credential",
+	"X-Amz-Security-Token",
+	"X-Amz-Signature",
+	"Signature",
+}
+
+// RequestSnapshot is a sanitized copy of the request an error occurred for.
+type RequestSnapshot struct {
+	Method string
+	URL    string
+	Header http.Header
+
+	// Body is the prefix of the request body, up to the configured limit.
+	// Nil if the request has no body, or its body is not seekable.
+	Body          []byte
+	BodyTruncated bool
+}
+
+// ResponseSnapshot is a sanitized copy of the response an error occurred
+// for.
+type ResponseSnapshot struct {
+	StatusCode int
+	Header     http.Header
+
+	// Body is the prefix of the response body read by the deserializer, up
+	// to the configured limit.
+	Body          []byte
+	BodyTruncated bool
+}
+
+// SnapshotError wraps the error of an operation with sanitized snapshots of
+// the request, and response if one was received, it occurred for.
+type SnapshotError struct {
+	Request  RequestSnapshot
+	Response *ResponseSnapshot
+	Err      error
+}
+
+// Unwrap returns the underlying error.
+func (e *SnapshotError) Unwrap() error { return e.Err }
+
+func (e *SnapshotError) Error() string {
+	if e.Response == nil {
+		return fmt.Sprintf("%v, request: %s %s", e.Err, e.Request.Method, e.Request.URL)
+	}
+	return fmt.Sprintf("%v, request: %s %s, response StatusCode: %d",
+		e.Err, e.Request.Method, e.Request.URL, e.Response.StatusCode)
+}
+
+// ErrorSnapshotOptions provides the configuration of the error snapshot
+// middleware.
+type ErrorSnapshotOptions struct {
+	// MaxBodyBytes is the maximum number of bytes of the request and
+	// response bodies included in the snapshots. Defaults to 1024. A
+	// negative value excludes the bodies.
+	MaxBodyBytes int
+
+	// RedactHeaders are the headers removed from the snapshots. Defaults to
+	// DefaultSnapshotRedactHeaders.
+	RedactHeaders []string
+
+	// RedactQueryKeys are the query string keys removed from the request
+	// snapshot's URL. Defaults to DefaultSnapshotRedactQueryKeys.
+	RedactQueryKeys []string
+}
+
+// AddErrorSnapshotMiddleware adds the middleware which wraps deserialization
+// errors, and errors of responses not modeled by the operation, with a
+// *SnapshotError. The snapshot includes the request's method, URL, headers,
+// and the prefix of its body, and the response's status code, headers, and
+// the prefix of its body.
+//
+// Snapshots are sanitized by removing credential headers and query string
+// keys, but may still contain sensitive data of the operation's input and
+// output, so the middleware must only be added to a stack by an explicit
+// client option. The middleware should be added after the operation's
+// deserializer, so the prefix of the response body read by the deserializer
+// can be captured.
+func AddErrorSnapshotMiddleware(stack *middleware.Stack, optFns ...func(*ErrorSnapshotOptions)) error {
+	o := ErrorSnapshotOptions{
+		MaxBodyBytes:    1024,
+		RedactHeaders:   DefaultSnapshotRedactHeaders,
+		RedactQueryKeys: DefaultSnapshotRedactQueryKeys,
+	}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	if err := stack.Deserialize.Add(&errorSnapshot{options: o}, middleware.Before); err != nil {
+		return err
+	}
+	if o.MaxBodyBytes < 0 {
+		return nil
+	}
+	return addCaptureResponseBodyMiddleware(stack, errorSnapshotCaptureID, o.MaxBodyBytes)
+}
+
+const errorSnapshotCaptureID = "ErrorSnapshotCaptureResponseBody"
+
+type errorSnapshot struct {
+	options ErrorSnapshotOptions
+}
+
+func (*errorSnapshot) ID() string { return "ErrorSnapshot" }
+
+func (m *errorSnapshot) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err == nil || !isSnapshotError(err) {
+		return out, metadata, err
+	}
+
+	req, ok := in.Request.(*Request)
+	if !ok {
+		return out, metadata, err
+	}
+
+	snapshotErr := &SnapshotError{
+		Request: m.requestSnapshot(req),
+		Err:     err,
+	}
+	if resp, ok := out.RawResponse.(*Response); ok && resp.Response != nil {
+		snapshot := &ResponseSnapshot{
+			StatusCode: resp.StatusCode,
+			Header:     m.redactHeader(resp.Header),
+		}
+		snapshot.Body, snapshot.BodyTruncated, _ = getCapturedResponseBody(metadata, errorSnapshotCaptureID)
+		snapshotErr.Response = snapshot
+	}
+
+	return out, metadata, snapshotErr
+}
+
+// isSnapshotError returns whether the error is a deserialization error, or
+// an error not modeled by the operation.
+func isSnapshotError(err error) bool {
+	var deserErr *smithy.DeserializationError
+	if errors.As(err, &deserErr) {
+		return true
+	}
+	var genericErr *smithy.GenericAPIError
+	return errors.As(err, &genericErr)
+}
+
+func (m *errorSnapshot) requestSnapshot(req *Request) RequestSnapshot {
+	snapshot := RequestSnapshot{
+		Method: req.Method,
+		Header: m.redactHeader(req.Header),
+	}
+	if req.URL != nil {
+		snapshot.URL = m.redactURL(req.URL)
+	}
+	if m.options.MaxBodyBytes >= 0 {
+		snapshot.Body, snapshot.BodyTruncated = readRequestBodyPrefix(req, m.options.MaxBodyBytes)
+	}
+	return snapshot
+}
+
+func (m *errorSnapshot) redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for _, h := range m.options.RedactHeaders {
+		header.Del(h)
+	}
+	return header
+}
+
+func (m *errorSnapshot) redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+
+	if len(redacted.RawQuery) != 0 && len(m.options.RedactQueryKeys) != 0 {
+		query := redacted.Query()
+		for _, k := range m.options.RedactQueryKeys {
+			query.Del(k)
+		}
+		redacted.RawQuery = query.Encode()
+	}
+	return redacted.String()
+}
+
+// readRequestBodyPrefix reads up to limit bytes from the start of the
+// request's seekable stream, restoring the stream's position afterwards.
+func readRequestBodyPrefix(req *Request, limit int) ([]byte, bool) {
+	if req.stream == nil || !req.isStreamSeekable {
+		return nil, false
+	}
+
+	s := req.stream.(io.Seeker)
+	pos, err := s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, false
+	}
+	defer s.Seek(pos, io.SeekStart)
+
+	if _, err := s.Seek(req.streamStartPos, io.SeekStart); err != nil {
+		return nil, false
+	}
+
+	buf := smithyio.NewPrefixBuffer(limit)
+	if _, err := io.Copy(buf, io.LimitReader(req.stream, int64(limit)+1)); err != nil {
+		return nil, false
+	}
+	return append([]byte(nil), buf.Bytes()...), buf.Truncated()
+}
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+// HeaderKey is the typed key of a response header captured into the result
+// metadata by the header capture middleware, such as rate limit headers. The
+// header's value is parsed with Parse when retrieved.
+type HeaderKey[T any] struct {
+	// Name is the name of the header.
+	Name string
+
+	// Parse parses the header's value.
+	Parse func(string) (T, error)
+}
+
+// StringHeader returns the HeaderKey of the header's value as is.
+func StringHeader(name string) HeaderKey[string] {
+	return HeaderKey[string]{
+		Name:  name,
+		Parse: func(v string) (string, error) { return v, nil },
+	}
+}
+
+// IntHeader returns the HeaderKey of the header's value as a base 10
+// integer.
+func IntHeader(name string) HeaderKey[int64] {
+	return HeaderKey[int64]{
+		Name: name,
+		Parse: func(v string) (int64, error) {
+			return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		},
+	}
+}
+
+// TimeHeader returns the HeaderKey of the header's value as an HTTP date,
+// such as the Date, and Expires headers.
+func TimeHeader(name string) HeaderKey[time.Time] {
+	return HeaderKey[time.Time]{
+		Name: name,
+		Parse: func(v string) (time.Time, error) {
+			return smithytime.ParseHTTPDate(strings.TrimSpace(v))
+		},
+	}
+}
+
+// Get returns the parsed first value of the header captured in the metadata,
+// and false if the header was not captured, or not present in the response.
+// Returns an error if the value cannot be parsed.
+func (k HeaderKey[T]) Get(metadata middleware.Metadata) (v T, ok bool, err error) {
+	values, ok := GetCapturedHeader(metadata, k.Name)
+	if !ok {
+		return v, false, nil
+	}
+	v, err = k.Parse(values[0])
+	return v, true, err
+}
+
+type capturedHeaderKey struct {
+	name string
+}
+
+// GetCapturedHeader returns the values of the response header captured in
+// the metadata, and false if the header was not captured, or not present in
+// the response, see AddHeaderCaptureMiddleware.
+func GetCapturedHeader(metadata middleware.Metadata, name string) ([]string, bool) {
+	v, ok := metadata.Get(capturedHeaderKey{name: http.CanonicalHeaderKey(name)}).([]string)
+	return v, ok
+}
+
+// AddHeaderCaptureMiddleware adds a middleware to the stack's Deserialize
+// step copying the values of the response headers into the result metadata,
+// retrieved with GetCapturedHeader, or a HeaderKey. Only the headers present
+// in the response are captured.
+func AddHeaderCaptureMiddleware(stack *middleware.Stack, headers ...string) error {
+	names := make([]string, len(headers))
+	for i, h := range headers {
+		names[i] = http.CanonicalHeaderKey(h)
+	}
+	return stack.Deserialize.Add(&headerCapture{headers: names}, middleware.After)
+}
+
+type headerCapture struct {
+	headers []string
+}
+
+func (*headerCapture) ID() string {
+	return "HeaderCapture"
+}
+
+func (m *headerCapture) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+
+	resp, ok := out.RawResponse.(*Response)
+	if !ok || resp.Response == nil {
+		return out, metadata, err
+	}
+
+	for _, name := range m.headers {
+		if values := resp.Header.Values(name); len(values) != 0 {
+			metadata.Set(capturedHeaderKey{name: name}, append([]string(nil), values...))
+		}
+	}
+
+	return out, metadata, err
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestHeaderCaptureMiddleware(t *testing.T) {
+	stack := middleware.NewStack("test", NewStackRequest)
+	if err := AddHeaderCaptureMiddleware(stack,
+		"x-ratelimit-remaining", "Retry-At", "Server-Timing", "X-Missing", "X-Invalid",
+	); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+			return &Response{Response: &http.Response{
+				StatusCode: 200,
+				Header: http.Header{
+					"X-Ratelimit-Remaining": {"42"},
+					"Retry-At":              {"Mon, 01 Jan 2024 00:00:00 GMT"},
+					"Server-Timing":         {"db;dur=53", "app;dur=47.2"},
+					"X-Invalid":             {"abc"},
+					"X-Other":               {"value"},
+				},
+			}}, middleware.Metadata{}, nil
+		}), stack)
+
+	_, metadata, err := handler.Handle(context.Background(), struct{}{})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	remaining, ok, err := IntHeader("X-RateLimit-Remaining").Get(metadata)
+	if err != nil || !ok {
+		t.Fatalf("expect captured header, got %v, %v", ok, err)
+	}
+	if e, a := int64(42), remaining; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	retryAt, ok, err := TimeHeader("Retry-At").Get(metadata)
+	if err != nil || !ok {
+		t.Fatalf("expect captured header, got %v, %v", ok, err)
+	}
+	if e, a := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), retryAt; !e.Equal(a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	if e, a := []string{"db;dur=53", "app;dur=47.2"}, mustCapturedHeader(t, metadata, "server-timing"); !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	if _, ok, _ := StringHeader("X-Missing").Get(metadata); ok {
+		t.Errorf("expect missing header not captured")
+	}
+	if _, ok := GetCapturedHeader(metadata, "X-Other"); ok {
+		t.Errorf("expect header not in allow list not captured")
+	}
+	if _, ok, err := IntHeader("X-Invalid").Get(metadata); !ok || err == nil {
+		t.Errorf("expect parse error for captured header, got %v, %v", ok, err)
+	}
+}
+
+func mustCapturedHeader(t *testing.T, metadata middleware.Metadata, name string) []string {
+	t.Helper()
+	v, ok := GetCapturedHeader(metadata, name)
+	if !ok {
+		t.Fatalf("expect %v header captured", name)
+	}
+	return v
+}
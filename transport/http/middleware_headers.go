@@ -3,28 +3,64 @@ package http
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/aws/smithy-go/middleware"
 )
 
+// HeaderHelperID is the ID of the Build step middleware that applies the
+// header and query values of the AddHeaderValue, SetHeaderValue,
+// RemoveHeaderValue, AddQueryValue, SetQueryValue, and RemoveQueryValue
+// stack mutators.
+//
+// The middleware is added to the end of the Build step when the first
+// mutator is applied to the stack. It runs after the request is serialized,
+// so its values take precedence over the serializer's, and before the
+// Finalize step, so its values are included when the request is signed.
+// Middleware that must observe or override the values can be inserted
+// relative to this ID.
+const HeaderHelperID = "HTTPHeaderHelper"
+
+type valueOp int
+
+const (
+	opAdd valueOp = iota
+	opSet
+	opRemove
+)
+
 type headerValue struct {
 	header string
 	value  string
-	append bool
+	op     valueOp
+}
+
+type queryValue struct {
+	key   string
+	value string
+	op    valueOp
 }
 
 type headerValueHelper struct {
 	headerValues []headerValue
+	queryValues  []queryValue
 }
 
 func (h *headerValueHelper) addHeaderValue(value headerValue) {
 	h.headerValues = append(h.headerValues, value)
 }
 
+func (h *headerValueHelper) addQueryValue(value queryValue) {
+	h.queryValues = append(h.queryValues, value)
+}
+
 func (h *headerValueHelper) ID() string {
-	return "HTTPHeaderHelper"
+	return HeaderHelperID
 }
 
+// HandleBuild applies the header and query values to the request in the
+// order the values were added to the stack, so later values take precedence
+// over earlier ones.
 func (h *headerValueHelper) HandleBuild(ctx context.Context, in middleware.BuildInput, next middleware.BuildHandler) (out middleware.BuildOutput, metadata middleware.Metadata, err error) {
 	req, ok := in.Request.(*Request)
 	if !ok {
@@ -32,13 +68,34 @@ func (h *headerValueHelper) HandleBuild(ctx context.Context, in middleware.Build
 	}
 
 	for _, value := range h.headerValues {
-		if value.append {
+		switch value.op {
+		case opAdd:
 			req.Header.Add(value.header, value.value)
-		} else {
+		case opSet:
 			req.Header.Set(value.header, value.value)
+		case opRemove:
+			req.Header.Del(value.header)
 		}
 	}
 
+	if len(h.queryValues) != 0 {
+		query, err := url.ParseQuery(req.URL.RawQuery)
+		if err != nil {
+			return out, metadata, fmt.Errorf("failed to parse request query, %w", err)
+		}
+		for _, value := range h.queryValues {
+			switch value.op {
+			case opAdd:
+				query.Add(value.key, value.value)
+			case opSet:
+				query.Set(value.key, value.value)
+			case opRemove:
+				query.Del(value.key)
+			}
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+
 	return next.HandleBuild(ctx, in)
 }
 
@@ -61,28 +118,61 @@ func getOrAddHeaderValueHelper(stack *middleware.Stack) (*headerValueHelper, err
 	return requestUserAgent, nil
 }
 
-// AddHeaderValue returns a stack mutator that adds the header value pair to header.
-// Appends to any existing values if present.
-func AddHeaderValue(header string, value string) func(stack *middleware.Stack) error {
+func headerValueMutator(value headerValue) func(stack *middleware.Stack) error {
 	return func(stack *middleware.Stack) error {
 		helper, err := getOrAddHeaderValueHelper(stack)
 		if err != nil {
 			return err
 		}
-		helper.addHeaderValue(headerValue{header: header, value: value, append: true})
+		helper.addHeaderValue(value)
 		return nil
 	}
 }
 
-// SetHeaderValue returns a stack mutator that adds the header value pair to header.
-// Replaces any existing values if present.
-func SetHeaderValue(header string, value string) func(stack *middleware.Stack) error {
+func queryValueMutator(value queryValue) func(stack *middleware.Stack) error {
 	return func(stack *middleware.Stack) error {
 		helper, err := getOrAddHeaderValueHelper(stack)
 		if err != nil {
 			return err
 		}
-		helper.addHeaderValue(headerValue{header: header, value: value, append: false})
+		helper.addQueryValue(value)
 		return nil
 	}
 }
+
+// AddHeaderValue returns a stack mutator that adds the header value pair to header.
+// Appends to any existing values if present.
+func AddHeaderValue(header string, value string) func(stack *middleware.Stack) error {
+	return headerValueMutator(headerValue{header: header, value: value, op: opAdd})
+}
+
+// SetHeaderValue returns a stack mutator that adds the header value pair to header.
+// Replaces any existing values if present.
+func SetHeaderValue(header string, value string) func(stack *middleware.Stack) error {
+	return headerValueMutator(headerValue{header: header, value: value, op: opSet})
+}
+
+// RemoveHeaderValue returns a stack mutator that removes all values of the
+// header, including those set by the request's serializer.
+func RemoveHeaderValue(header string) func(stack *middleware.Stack) error {
+	return headerValueMutator(headerValue{header: header, op: opRemove})
+}
+
+// AddQueryValue returns a stack mutator that adds the key value pair to the
+// request's query string. Appends to any existing values if present.
+func AddQueryValue(key string, value string) func(stack *middleware.Stack) error {
+	return queryValueMutator(queryValue{key: key, value: value, op: opAdd})
+}
+
+// SetQueryValue returns a stack mutator that adds the key value pair to the
+// request's query string. Replaces any existing values if present.
+func SetQueryValue(key string, value string) func(stack *middleware.Stack) error {
+	return queryValueMutator(queryValue{key: key, value: value, op: opSet})
+}
+
+// RemoveQueryValue returns a stack mutator that removes all values of the
+// key from the request's query string, including those set by the request's
+// serializer.
+func RemoveQueryValue(key string) func(stack *middleware.Stack) error {
+	return queryValueMutator(queryValue{key: key, op: opRemove})
+}
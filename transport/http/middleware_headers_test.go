@@ -66,3 +66,83 @@ func TestSetHeaderValue(t *testing.T) {
 		t.Fatalf("expect no error, got %v", err)
 	}
 }
+
+func TestRemoveHeaderValue(t *testing.T) {
+	stack := middleware.NewStack("stack", smithyhttp.NewStackRequest)
+	stack.Serialize.Add(middleware.SerializeMiddlewareFunc("serialize",
+		func(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+			middleware.SerializeOutput, middleware.Metadata, error,
+		) {
+			req := in.Request.(*smithyhttp.Request)
+			req.Header.Set("foo", "serialized")
+			req.Header.Set("bar", "serialized")
+			return next.HandleSerialize(ctx, in)
+		}), middleware.After)
+
+	mutators := []func(*middleware.Stack) error{
+		smithyhttp.RemoveHeaderValue("foo"),
+		smithyhttp.SetHeaderValue("baz", "first"),
+		smithyhttp.RemoveHeaderValue("baz"),
+		smithyhttp.AddHeaderValue("baz", "second"),
+	}
+	for _, fn := range mutators {
+		if err := fn(stack); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	}
+
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(func(ctx context.Context, input interface{}) (output interface{}, metadata middleware.Metadata, err error) {
+		req := input.(*smithyhttp.Request)
+		if diff := cmp.Diff(req.Header, http.Header{
+			"Bar": []string{"serialized"},
+			"Baz": []string{"second"},
+		}); len(diff) > 0 {
+			t.Errorf(diff)
+		}
+		return output, metadata, err
+	}), stack)
+	_, _, err := handler.Handle(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+}
+
+func TestQueryValue(t *testing.T) {
+	stack := middleware.NewStack("stack", smithyhttp.NewStackRequest)
+	stack.Serialize.Add(middleware.SerializeMiddlewareFunc("serialize",
+		func(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+			middleware.SerializeOutput, middleware.Metadata, error,
+		) {
+			req := in.Request.(*smithyhttp.Request)
+			req.URL.RawQuery = "a=1&b=2&c=3"
+			return next.HandleSerialize(ctx, in)
+		}), middleware.After)
+
+	mutators := []func(*middleware.Stack) error{
+		smithyhttp.AddQueryValue("a", "4"),
+		smithyhttp.SetQueryValue("b", "5"),
+		smithyhttp.RemoveQueryValue("c"),
+		smithyhttp.AddQueryValue("d", "x y"),
+	}
+	for _, fn := range mutators {
+		if err := fn(stack); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	}
+
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(func(ctx context.Context, input interface{}) (output interface{}, metadata middleware.Metadata, err error) {
+		req := input.(*smithyhttp.Request)
+		if e, a := "a=1&a=4&b=5&d=x+y", req.URL.RawQuery; e != a {
+			t.Errorf("expect %v query, got %v", e, a)
+		}
+		return output, metadata, err
+	}), stack)
+	_, _, err := handler.Handle(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if _, ok := stack.Build.Get(smithyhttp.HeaderHelperID); !ok {
+		t.Errorf("expect %v middleware in build step", smithyhttp.HeaderHelperID)
+	}
+}
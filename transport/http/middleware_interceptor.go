@@ -0,0 +1,179 @@
+package http
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// AddInterceptorMiddleware adds the middleware to the stack that invoke the
+// interceptors of the registry at their hook points:
+//
+//   - BeforeExecution and AfterExecution at the start of the Initialize step
+//   - BeforeSerialization at the start of the Serialize step
+//   - AfterSerialization at the start of the Build step
+//   - BeforeTransmit, AfterTransmit, and BeforeDeserialization at the end of
+//     the Deserialize step, around the transport handler
+//
+// An error returned by any hook other than AfterExecution stops the
+// invocation, and is returned to the caller after AfterExecution hooks run.
+func AddInterceptorMiddleware(stack *middleware.Stack, registry InterceptorRegistry) error {
+	if err := stack.Initialize.Add(&interceptExecution{registry: registry}, middleware.Before); err != nil {
+		return err
+	}
+	if err := stack.Serialize.Add(&interceptBeforeSerialization{registry: registry}, middleware.Before); err != nil {
+		return err
+	}
+	if err := stack.Build.Add(&interceptAfterSerialization{registry: registry}, middleware.Before); err != nil {
+		return err
+	}
+	return stack.Deserialize.Add(&interceptTransmit{registry: registry}, middleware.After)
+}
+
+type interceptorContextKey struct{}
+
+func getInterceptorContext(ctx context.Context) *InterceptorContext {
+	v, _ := ctx.Value(interceptorContextKey{}).(*InterceptorContext)
+	if v == nil {
+		return &InterceptorContext{}
+	}
+	return v
+}
+
+type interceptExecution struct {
+	registry InterceptorRegistry
+}
+
+func (*interceptExecution) ID() string { return "InterceptExecution" }
+
+func (m *interceptExecution) HandleInitialize(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+	out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+) {
+	ic := &InterceptorContext{Input: in.Parameters}
+	ctx = context.WithValue(ctx, interceptorContextKey{}, ic)
+
+	err = func() error {
+		for _, i := range m.registry.BeforeExecution {
+			if err := i.BeforeExecution(ctx, ic); err != nil {
+				return err
+			}
+		}
+
+		in.Parameters = ic.Input
+		out, metadata, err = next.HandleInitialize(ctx, in)
+		if err == nil {
+			ic.Output = out.Result
+		}
+		return err
+	}()
+
+	for _, i := range m.registry.AfterExecution {
+		err = i.AfterExecution(ctx, ic, err)
+	}
+	if err == nil {
+		out.Result = ic.Output
+	}
+
+	return out, metadata, err
+}
+
+type interceptBeforeSerialization struct {
+	registry InterceptorRegistry
+}
+
+func (*interceptBeforeSerialization) ID() string { return "InterceptBeforeSerialization" }
+
+func (m *interceptBeforeSerialization) HandleSerialize(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+	out middleware.SerializeOutput, metadata middleware.Metadata, err error,
+) {
+	ic := getInterceptorContext(ctx)
+	ic.Input = in.Parameters
+
+	for _, i := range m.registry.BeforeSerialization {
+		if err := i.BeforeSerialization(ctx, ic); err != nil {
+			return out, metadata, err
+		}
+	}
+
+	in.Parameters = ic.Input
+	return next.HandleSerialize(ctx, in)
+}
+
+type interceptAfterSerialization struct {
+	registry InterceptorRegistry
+}
+
+func (*interceptAfterSerialization) ID() string { return "InterceptAfterSerialization" }
+
+func (m *interceptAfterSerialization) HandleBuild(ctx context.Context, in middleware.BuildInput, next middleware.BuildHandler) (
+	out middleware.BuildOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type %T", in.Request)
+	}
+
+	ic := getInterceptorContext(ctx)
+	ic.Request = req
+
+	for _, i := range m.registry.AfterSerialization {
+		if err := i.AfterSerialization(ctx, ic); err != nil {
+			return out, metadata, err
+		}
+	}
+
+	in.Request = ic.Request
+	return next.HandleBuild(ctx, in)
+}
+
+type interceptTransmit struct {
+	registry InterceptorRegistry
+}
+
+func (*interceptTransmit) ID() string { return "InterceptTransmit" }
+
+func (m *interceptTransmit) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type %T", in.Request)
+	}
+
+	ic := getInterceptorContext(ctx)
+	ic.Request = req
+	ic.Response = nil
+
+	for _, i := range m.registry.BeforeTransmit {
+		if err := i.BeforeTransmit(ctx, ic); err != nil {
+			return out, metadata, err
+		}
+	}
+
+	in.Request = ic.Request
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	resp, ok := out.RawResponse.(*Response)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type %T", out.RawResponse)
+	}
+	ic.Response = resp
+
+	for _, i := range m.registry.AfterTransmit {
+		if err := i.AfterTransmit(ctx, ic); err != nil {
+			return out, metadata, err
+		}
+	}
+	for _, i := range m.registry.BeforeDeserialization {
+		if err := i.BeforeDeserialization(ctx, ic); err != nil {
+			return out, metadata, err
+		}
+	}
+
+	out.RawResponse = ic.Response
+	return out, metadata, err
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+type mockInterceptor struct {
+	order *[]string
+}
+
+func (m mockInterceptor) record(hook string, in *InterceptorContext) {
+	*m.order = append(*m.order, fmt.Sprintf("%s(%v)", hook, in.Input))
+}
+
+func (m mockInterceptor) BeforeExecution(ctx context.Context, in *InterceptorContext) error {
+	m.record("BeforeExecution", in)
+	return nil
+}
+
+func (m mockInterceptor) BeforeSerialization(ctx context.Context, in *InterceptorContext) error {
+	m.record("BeforeSerialization", in)
+	in.Input = "modified"
+	return nil
+}
+
+func (m mockInterceptor) AfterSerialization(ctx context.Context, in *InterceptorContext) error {
+	m.record("AfterSerialization", in)
+	in.Request.Header.Set("X-Intercepted", "true")
+	return nil
+}
+
+func (m mockInterceptor) BeforeTransmit(ctx context.Context, in *InterceptorContext) error {
+	m.record("BeforeTransmit", in)
+	return nil
+}
+
+func (m mockInterceptor) AfterTransmit(ctx context.Context, in *InterceptorContext) error {
+	m.record("AfterTransmit", in)
+	return nil
+}
+
+func (m mockInterceptor) BeforeDeserialization(ctx context.Context, in *InterceptorContext) error {
+	m.record("BeforeDeserialization", in)
+	return nil
+}
+
+func (m mockInterceptor) AfterExecution(ctx context.Context, in *InterceptorContext, err error) error {
+	m.record("AfterExecution", in)
+	in.Output = fmt.Sprintf("%v!", in.Output)
+	return err
+}
+
+func TestInterceptorMiddleware(t *testing.T) {
+	var order []string
+	var registry InterceptorRegistry
+	if !registry.Add(mockInterceptor{order: &order}) {
+		t.Fatalf("expect interceptor to be added")
+	}
+
+	stack := middleware.NewStack("test", NewStackRequest)
+	stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("OperationDeserializer",
+		func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+			out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+		) {
+			out, metadata, err = next.HandleDeserialize(ctx, in)
+			out.Result = "output"
+			return out, metadata, err
+		}), middleware.After)
+	if err := AddInterceptorMiddleware(stack, registry); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var sentHeader string
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+			sentHeader = input.(*Request).Header.Get("X-Intercepted")
+			return &Response{Response: &http.Response{StatusCode: 200}}, middleware.Metadata{}, nil
+		}), stack)
+
+	result, _, err := handler.Handle(context.Background(), "input")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := "output!", result; e != a {
+		t.Errorf("expect %v result, got %v", e, a)
+	}
+	if e, a := "true", sentHeader; e != a {
+		t.Errorf("expect %v header, got %v", e, a)
+	}
+
+	expect := []string{
+		"BeforeExecution(input)",
+		"BeforeSerialization(input)",
+		"AfterSerialization(modified)",
+		"BeforeTransmit(modified)",
+		"AfterTransmit(modified)",
+		"BeforeDeserialization(modified)",
+		"AfterExecution(modified)",
+	}
+	if e, a := strings.Join(expect, ","), strings.Join(order, ","); e != a {
+		t.Errorf("expect hooks\n%v\ngot\n%v", e, a)
+	}
+}
+
+type mockFailingInterceptor struct{}
+
+func (mockFailingInterceptor) BeforeSerialization(ctx context.Context, in *InterceptorContext) error {
+	return fmt.Errorf("hook failed")
+}
+
+func (mockFailingInterceptor) AfterExecution(ctx context.Context, in *InterceptorContext, err error) error {
+	return fmt.Errorf("after execution, %w", err)
+}
+
+func TestInterceptorMiddleware_Error(t *testing.T) {
+	var registry InterceptorRegistry
+	registry.Add(mockFailingInterceptor{})
+
+	stack := middleware.NewStack("test", NewStackRequest)
+	if err := AddInterceptorMiddleware(stack, registry); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var called bool
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+			called = true
+			return &Response{Response: &http.Response{}}, middleware.Metadata{}, nil
+		}), stack)
+
+	_, _, err := handler.Handle(context.Background(), "input")
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if e, a := "after execution, hook failed", err.Error(); e != a {
+		t.Errorf("expect %q error, got %q", e, a)
+	}
+	if called {
+		t.Errorf("expect handler to not be called")
+	}
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"context"
+
+	"github.com/aws/smithy-go/metrics"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// AddPayloadSizeMetricsMiddleware adds the middleware that records the HTTP
+// request and response payload sizes to the operation metrics retrieved by
+// middleware.GetOperationMetrics. Payloads with unknown length are not
+// recorded.
+func AddPayloadSizeMetricsMiddleware(stack *middleware.Stack) error {
+	return stack.Deserialize.Add(&payloadSizeMetrics{}, middleware.After)
+}
+
+type payloadSizeMetrics struct{}
+
+func (*payloadSizeMetrics) ID() string {
+	return "PayloadSizeMetrics"
+}
+
+func (m *payloadSizeMetrics) HandleDeserialize(
+	ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler,
+) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	om := middleware.GetOperationMetrics(ctx)
+	if om == nil {
+		return next.HandleDeserialize(ctx, in)
+	}
+
+	if req, ok := in.Request.(*Request); ok && req.ContentLength >= 0 {
+		om.RequestSize.Record(ctx, req.ContentLength,
+			metrics.WithProperties(middleware.OperationMetadataProperties(ctx)))
+	}
+
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+
+	if resp, ok := out.RawResponse.(*Response); ok && resp != nil && resp.ContentLength >= 0 {
+		om.ResponseSize.Record(ctx, resp.ContentLength,
+			metrics.WithProperties(middleware.OperationMetadataProperties(ctx)))
+	}
+
+	return out, metadata, err
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/smithy-go/metrics"
+	"github.com/aws/smithy-go/middleware"
+)
+
+type recordedSizes []int64
+
+func (r *recordedSizes) Record(_ context.Context, v int64, _ ...metrics.RecordMetricOption) {
+	*r = append(*r, v)
+}
+
+func TestPayloadSizeMetrics(t *testing.T) {
+	var reqSizes, respSizes recordedSizes
+	ctx := middleware.SetOperationMetrics(context.Background(), &middleware.OperationMetrics{
+		RequestSize:  &reqSizes,
+		ResponseSize: &respSizes,
+	})
+
+	req := NewStackRequest().(*Request)
+	req.ContentLength = 10
+
+	var m payloadSizeMetrics
+	_, _, err := m.HandleDeserialize(ctx, middleware.DeserializeInput{Request: req},
+		middleware.DeserializeHandlerFunc(func(ctx context.Context, in middleware.DeserializeInput) (
+			out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+		) {
+			out.RawResponse = &Response{Response: &http.Response{ContentLength: 20}}
+			return out, metadata, err
+		}),
+	)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := 1, len(reqSizes); e != a {
+		t.Fatalf("expect %v request sizes, got %v", e, a)
+	}
+	if e, a := int64(10), reqSizes[0]; e != a {
+		t.Errorf("expect %v request size, got %v", e, a)
+	}
+	if e, a := 1, len(respSizes); e != a {
+		t.Fatalf("expect %v response sizes, got %v", e, a)
+	}
+	if e, a := int64(20), respSizes[0]; e != a {
+		t.Errorf("expect %v response size, got %v", e, a)
+	}
+}
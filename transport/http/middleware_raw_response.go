@@ -0,0 +1,100 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// RawResponse is a copy of the raw HTTP response of an operation call, for
+// accessing the headers of the response not modeled by the operation's
+// output.
+type RawResponse struct {
+	StatusCode int
+	Header     http.Header
+
+	// Body is the prefix of the response body read by the operation's
+	// deserializer, up to the configured limit. Nil if the body is not
+	// retained. The body of an operation with a streaming output is read
+	// after the call returns, so its retained body is empty.
+	Body          []byte
+	BodyTruncated bool
+}
+
+// RawResponseOptions provides the configuration of the raw response
+// middleware.
+type RawResponseOptions struct {
+	// MaxBodyBytes is the maximum number of bytes of the response body
+	// retained. Defaults to zero, the body is not retained.
+	MaxBodyBytes int
+}
+
+type rawResponseKey struct{}
+
+// GetRawResponse returns the raw HTTP response retained in the metadata of
+// an operation call, and false if the call did not receive a response, or
+// the raw response was not retained, see WithRawResponse.
+func GetRawResponse(metadata middleware.Metadata) (*RawResponse, bool) {
+	v, ok := metadata.Get(rawResponseKey{}).(*RawResponse)
+	return v, ok
+}
+
+func setRawResponse(metadata *middleware.Metadata, resp *RawResponse) {
+	metadata.Set(rawResponseKey{}, resp)
+}
+
+// WithRawResponse returns a call option that retains the raw HTTP response
+// of the operation call in the call's result metadata, retrieved with
+// GetRawResponse.
+func WithRawResponse(optFns ...func(*RawResponseOptions)) middleware.CallOption {
+	return middleware.WithStackMutation(func(stack *middleware.Stack) error {
+		return AddRawResponseMiddleware(stack, optFns...)
+	})
+}
+
+// AddRawResponseMiddleware adds the middleware retaining the raw HTTP
+// response of the operation in the result metadata, retrieved with
+// GetRawResponse. The middleware should be added after the operation's
+// deserializer, so the prefix of the response body read by the deserializer
+// can be retained.
+func AddRawResponseMiddleware(stack *middleware.Stack, optFns ...func(*RawResponseOptions)) error {
+	var o RawResponseOptions
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	if err := stack.Deserialize.Add(&rawResponse{}, middleware.Before); err != nil {
+		return err
+	}
+	if o.MaxBodyBytes <= 0 {
+		return nil
+	}
+	return addCaptureResponseBodyMiddleware(stack, rawResponseCaptureID, o.MaxBodyBytes)
+}
+
+const rawResponseCaptureID = "RawResponseCaptureBody"
+
+type rawResponse struct{}
+
+func (*rawResponse) ID() string { return "RawResponse" }
+
+func (m *rawResponse) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+
+	resp, ok := out.RawResponse.(*Response)
+	if !ok || resp.Response == nil {
+		return out, metadata, err
+	}
+
+	raw := &RawResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+	}
+	raw.Body, raw.BodyTruncated, _ = getCapturedResponseBody(metadata, rawResponseCaptureID)
+	setRawResponse(&metadata, raw)
+
+	return out, metadata, err
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestWithRawResponse(t *testing.T) {
+	cases := map[string]struct {
+		MaxBodyBytes    int
+		ExpectBody      string
+		ExpectTruncated bool
+	}{
+		"no body": {},
+		"body": {
+			MaxBodyBytes: 64,
+			ExpectBody:   `{"value":"abc"}`,
+		},
+		"truncated body": {
+			MaxBodyBytes:    4,
+			ExpectBody:      `{"va`,
+			ExpectTruncated: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := middleware.WithCallOptions(context.Background(),
+				WithRawResponse(func(o *RawResponseOptions) {
+					o.MaxBodyBytes = c.MaxBodyBytes
+				}),
+			)
+
+			stack := middleware.NewStack("test", NewStackRequest)
+			stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("deserializer",
+				func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+					middleware.DeserializeOutput, middleware.Metadata, error,
+				) {
+					out, metadata, err := next.HandleDeserialize(ctx, in)
+					if err != nil {
+						return out, metadata, err
+					}
+					resp := out.RawResponse.(*Response)
+					body, err := ioutil.ReadAll(resp.Body)
+					if err != nil {
+						return out, metadata, err
+					}
+					out.Result = string(body)
+					return out, metadata, nil
+				}), middleware.After)
+			if err := middleware.ApplyCallOptions(ctx, stack); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			handler := middleware.DecorateHandler(middleware.HandlerFunc(
+				func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+					return &Response{Response: &http.Response{
+						StatusCode: 200,
+						Header:     http.Header{"X-Unmodeled": {"value"}},
+						Body:       ioutil.NopCloser(strings.NewReader(`{"value":"abc"}`)),
+					}}, middleware.Metadata{}, nil
+				}), stack)
+
+			result, metadata, err := handler.Handle(ctx, struct{}{})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := `{"value":"abc"}`, result; e != a {
+				t.Errorf("expect %v result, got %v", e, a)
+			}
+
+			raw, ok := GetRawResponse(metadata)
+			if !ok {
+				t.Fatalf("expect raw response")
+			}
+			if e, a := 200, raw.StatusCode; e != a {
+				t.Errorf("expect %v status code, got %v", e, a)
+			}
+			if e, a := "value", raw.Header.Get("X-Unmodeled"); e != a {
+				t.Errorf("expect %v header, got %v", e, a)
+			}
+			if e, a := c.ExpectBody, string(raw.Body); e != a {
+				t.Errorf("expect %q body, got %q", e, a)
+			}
+			if e, a := c.ExpectTruncated, raw.BodyTruncated; e != a {
+				t.Errorf("expect truncated %v, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// ResponseBodyTooLargeError is returned when a response body is larger than
+// the response body size limit.
+type ResponseBodyTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseBodyTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds size limit of %d bytes", e.Limit)
+}
+
+// ResponseBodyLimitOptions provides the configuration of the response body
+// size limit middleware.
+type ResponseBodyLimitOptions struct {
+	// Limit is the maximum number of bytes of the response body which may be
+	// read.
+	Limit int64
+
+	// ErrorResponsesOnly limits only the bodies of error responses, with a
+	// non-2xx status code. Use for operations with streaming output, whose
+	// successful response bodies are not buffered.
+	ErrorResponsesOnly bool
+}
+
+// AddResponseBodyLimitMiddleware adds the middleware which limits the size
+// of the response body read by the operation's deserializer, protecting the
+// client from buffering an unbounded body into memory. A response whose
+// Content-Length exceeds the limit fails without its body being read.
+// Otherwise reading past the limit fails with a *ResponseBodyTooLargeError.
+//
+// The middleware should be added after the operation's deserializer, so it
+// wraps the response body before it is read.
+func AddResponseBodyLimitMiddleware(stack *middleware.Stack, optFns ...func(*ResponseBodyLimitOptions)) error {
+	var o ResponseBodyLimitOptions
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	if o.Limit <= 0 {
+		return fmt.Errorf("response body limit must be greater than zero, got %d", o.Limit)
+	}
+
+	return stack.Deserialize.Add(&responseBodyLimit{options: o}, middleware.After)
+}
+
+type responseBodyLimit struct {
+	options ResponseBodyLimitOptions
+}
+
+func (*responseBodyLimit) ID() string { return "ResponseBodyLimit" }
+
+func (m *responseBodyLimit) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	resp, ok := out.RawResponse.(*Response)
+	if !ok || resp.Response == nil || resp.Body == nil {
+		return out, metadata, err
+	}
+	if m.options.ErrorResponsesOnly && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return out, metadata, err
+	}
+
+	if resp.ContentLength > m.options.Limit {
+		resp.Body.Close()
+		return out, metadata, &ResponseError{
+			Response: resp,
+			Err:      &ResponseBodyTooLargeError{Limit: m.options.Limit},
+		}
+	}
+
+	resp.Body = &limitedBody{
+		body:   resp.Body,
+		limit:  m.options.Limit,
+		remain: m.options.Limit,
+	}
+	return out, metadata, err
+}
+
+// limitedBody fails reads past the limit with a ResponseBodyTooLargeError,
+// instead of truncating the body as io.LimitReader does.
+type limitedBody struct {
+	body   io.ReadCloser
+	limit  int64
+	remain int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remain <= 0 {
+		// Probe for data past the limit, so a body of exactly limit bytes
+		// still ends with io.EOF.
+		var probe [1]byte
+		n, err := b.body.Read(probe[:])
+		if n > 0 {
+			return 0, &ResponseBodyTooLargeError{Limit: b.limit}
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > b.remain {
+		p = p[:b.remain]
+	}
+	n, err := b.body.Read(p)
+	b.remain -= int64(n)
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
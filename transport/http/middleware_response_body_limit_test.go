@@ -0,0 +1,117 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestResponseBodyLimitMiddleware(t *testing.T) {
+	cases := map[string]struct {
+		statusCode    int
+		body          string
+		contentLength int64
+		options       ResponseBodyLimitOptions
+		expectBody    string
+		expectErr     bool
+	}{
+		"under limit": {
+			statusCode:    200,
+			body:          "hello",
+			contentLength: -1,
+			options:       ResponseBodyLimitOptions{Limit: 10},
+			expectBody:    "hello",
+		},
+		"exactly limit": {
+			statusCode:    200,
+			body:          "hello",
+			contentLength: -1,
+			options:       ResponseBodyLimitOptions{Limit: 5},
+			expectBody:    "hello",
+		},
+		"over limit": {
+			statusCode:    500,
+			body:          "hello world",
+			contentLength: -1,
+			options:       ResponseBodyLimitOptions{Limit: 5},
+			expectErr:     true,
+		},
+		"content length over limit": {
+			statusCode:    500,
+			body:          "hello world",
+			contentLength: 11,
+			options:       ResponseBodyLimitOptions{Limit: 5},
+			expectErr:     true,
+		},
+		"error responses only": {
+			statusCode:    200,
+			body:          "hello world",
+			contentLength: 11,
+			options:       ResponseBodyLimitOptions{Limit: 5, ErrorResponsesOnly: true},
+			expectBody:    "hello world",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			stack := middleware.NewStack("test", NewStackRequest)
+			var body []byte
+			stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("deserialize",
+				func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+					out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+				) {
+					out, metadata, err = next.HandleDeserialize(ctx, in)
+					if err != nil {
+						return out, metadata, err
+					}
+					body, err = ioutil.ReadAll(out.RawResponse.(*Response).Body)
+					return out, metadata, err
+				}), middleware.After)
+			if err := AddResponseBodyLimitMiddleware(stack, func(o *ResponseBodyLimitOptions) {
+				*o = c.options
+			}); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			handler := middleware.DecorateHandler(middleware.HandlerFunc(
+				func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+					return &Response{Response: &http.Response{
+						StatusCode:    c.statusCode,
+						Header:        http.Header{},
+						ContentLength: c.contentLength,
+						Body:          ioutil.NopCloser(bytes.NewReader([]byte(c.body))),
+					}}, middleware.Metadata{}, nil
+				}), stack)
+
+			_, _, err := handler.Handle(context.Background(), struct{}{})
+			if c.expectErr {
+				var limitErr *ResponseBodyTooLargeError
+				if !errors.As(err, &limitErr) {
+					t.Fatalf("expect %T error, got %v", limitErr, err)
+				}
+				if e, a := c.options.Limit, limitErr.Limit; e != a {
+					t.Errorf("expect %v limit, got %v", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.expectBody, string(body); e != a {
+				t.Errorf("expect %q body, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestAddResponseBodyLimitMiddleware_InvalidLimit(t *testing.T) {
+	stack := middleware.NewStack("test", NewStackRequest)
+	if err := AddResponseBodyLimitMiddleware(stack); err == nil {
+		t.Errorf("expect error for zero limit")
+	}
+}
@@ -0,0 +1,362 @@
+package http
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+// ResponseCacheStatus is the result of an operation's response cache lookup.
+type ResponseCacheStatus string
+
+// Enumeration of ResponseCacheStatus values.
+const (
+	// ResponseCacheMiss indicates the operation was invoked, and its result
+	// cached.
+	ResponseCacheMiss ResponseCacheStatus = "miss"
+
+	// ResponseCacheHit indicates the result was returned from the cache
+	// without invoking the operation.
+	ResponseCacheHit ResponseCacheStatus = "hit"
+
+	// ResponseCacheStale indicates the operation failed, and an expired
+	// result was returned from the cache instead, per the StaleIfError
+	// option.
+	ResponseCacheStale ResponseCacheStatus = "stale"
+)
+
+type responseCacheStatusKey struct{}
+
+// GetResponseCacheStatus returns the response cache status of the
+// operation's result, and false if the operation's stack has no response
+// cache, or the request was not cacheable.
+func GetResponseCacheStatus(metadata middleware.Metadata) (ResponseCacheStatus, bool) {
+	v, ok := metadata.Get(responseCacheStatusKey{}).(ResponseCacheStatus)
+	return v, ok
+}
+
+func setResponseCacheStatus(metadata *middleware.Metadata, status ResponseCacheStatus) {
+	metadata.Set(responseCacheStatusKey{}, status)
+}
+
+// DefaultResponseCacheIgnoreHeaders are the request headers excluded from the
+// cache key derived from the serialized request, if not otherwise
+// configured, as their values change between invocations of the same
+// request.
+var DefaultResponseCacheIgnoreHeaders = []string{
+	"User-Agent",
+	"X-Amz-User-Agent",
+	"Amz-Sdk-Invocation-Id",
+	"Amz-Sdk-Request",
+}
+
+// ResponseCacheOptions provides the configuration of a ResponseCache.
+type ResponseCacheOptions struct {
+	// TTL is the duration a result is returned from the cache for after it
+	// is cached. Required.
+	TTL time.Duration
+
+	// MaxEntries is the maximum number of results cached. The least recently
+	// used result is evicted when the limit is reached. Defaults to 1000.
+	MaxEntries int
+
+	// StaleIfError is the duration after a result expires for which it is
+	// still returned if the operation fails, with a ResponseCacheStale
+	// status. Defaults to zero, expired results are never returned.
+	StaleIfError time.Duration
+
+	// KeyFunc returns the cache key of the operation's input, and false if
+	// the input is not cacheable. If set, the cache is consulted in the
+	// Initialize step, before the request is serialized.
+	//
+	// If nil, the key is derived from the serialized request's method, URL,
+	// headers, and body, and the cache is consulted in the Build step. A
+	// request whose body is not seekable, or is set with a StreamProducer, is
+	// not cached.
+	KeyFunc func(ctx context.Context, input interface{}) (string, bool)
+
+	// IgnoreHeaders are the request headers excluded from the cache key
+	// derived from the serialized request. Defaults to
+	// DefaultResponseCacheIgnoreHeaders.
+	IgnoreHeaders []string
+
+	// Clock is the clock used to expire results. If nil, the clock of the
+	// operation's context is used.
+	Clock smithytime.Clock
+}
+
+// ResponseCache is a cache of operation results, shared by the stacks of an
+// operation's invocations. A ResponseCache is safe for concurrent use.
+//
+// Cached results are returned to every caller which hits the cache, and must
+// not be modified.
+type ResponseCache struct {
+	options       ResponseCacheOptions
+	ignoreHeaders map[string]struct{}
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type responseCacheEntry struct {
+	key      string
+	result   interface{}
+	metadata middleware.Metadata
+	expires  time.Time
+}
+
+// NewResponseCache returns a ResponseCache configured by the functional
+// options. Returns an error if the TTL option is not greater than zero.
+func NewResponseCache(optFns ...func(*ResponseCacheOptions)) (*ResponseCache, error) {
+	o := ResponseCacheOptions{
+		MaxEntries:    1000,
+		IgnoreHeaders: DefaultResponseCacheIgnoreHeaders,
+	}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	if o.TTL <= 0 {
+		return nil, fmt.Errorf("response cache TTL must be greater than zero, got %v", o.TTL)
+	}
+	if o.MaxEntries <= 0 {
+		return nil, fmt.Errorf("response cache max entries must be greater than zero, got %d", o.MaxEntries)
+	}
+
+	ignoreHeaders := make(map[string]struct{}, len(o.IgnoreHeaders))
+	for _, h := range o.IgnoreHeaders {
+		ignoreHeaders[http.CanonicalHeaderKey(h)] = struct{}{}
+	}
+
+	return &ResponseCache{
+		options:       o,
+		ignoreHeaders: ignoreHeaders,
+		entries:       map[string]*list.Element{},
+		lru:           list.New(),
+	}, nil
+}
+
+// Len returns the number of results in the cache, including results which
+// have expired but not yet been evicted.
+func (c *ResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Invalidate discards the cached result for the key.
+func (c *ResponseCache) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+func (c *ResponseCache) clock(ctx context.Context) smithytime.Clock {
+	if c.options.Clock != nil {
+		return c.options.Clock
+	}
+	return smithytime.GetClock(ctx)
+}
+
+// get returns the entry for the key, and whether it has expired. Entries
+// past their stale window are evicted.
+func (c *ResponseCache) get(key string, now time.Time) (*responseCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*responseCacheEntry)
+	if !now.Before(e.expires.Add(c.options.StaleIfError)) {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	return e, !now.Before(e.expires)
+}
+
+func (c *ResponseCache) put(key string, result interface{}, metadata middleware.Metadata, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := &responseCacheEntry{
+		key:      key,
+		result:   result,
+		metadata: metadata.Clone(),
+		expires:  now.Add(c.options.TTL),
+	}
+	if el, ok := c.entries[key]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(e)
+	for c.lru.Len() > c.options.MaxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*responseCacheEntry).key)
+	}
+}
+
+// handle returns the cached result for the key, or invokes next and caches
+// its result.
+func (c *ResponseCache) handle(ctx context.Context, key string, next func() (interface{}, middleware.Metadata, error)) (
+	interface{}, middleware.Metadata, error,
+) {
+	clock := c.clock(ctx)
+
+	cached, expired := c.get(key, clock.Now())
+	if cached != nil && !expired {
+		metadata := cached.metadata.Clone()
+		setResponseCacheStatus(&metadata, ResponseCacheHit)
+		return cached.result, metadata, nil
+	}
+
+	result, metadata, err := next()
+	if err != nil {
+		if cached != nil {
+			metadata := cached.metadata.Clone()
+			setResponseCacheStatus(&metadata, ResponseCacheStale)
+			return cached.result, metadata, nil
+		}
+		return result, metadata, err
+	}
+
+	c.put(key, result, metadata, clock.Now())
+	setResponseCacheStatus(&metadata, ResponseCacheMiss)
+	return result, metadata, nil
+}
+
+// AddResponseCacheMiddleware adds the middleware which returns the result of
+// the operation from the cache, if cached and not expired, without invoking
+// the rest of the stack. Otherwise the operation is invoked, and its result
+// cached. The cache status of the result is available from the operation's
+// metadata with GetResponseCacheStatus.
+//
+// The middleware must only be added to the stacks of idempotent read
+// operations. If the cache's KeyFunc option is set the middleware is added
+// to the Initialize step, otherwise the Build step.
+func AddResponseCacheMiddleware(stack *middleware.Stack, cache *ResponseCache) error {
+	if cache.options.KeyFunc != nil {
+		return stack.Initialize.Add(&responseCacheInitialize{cache: cache}, middleware.After)
+	}
+	return stack.Build.Add(&responseCacheBuild{cache: cache}, middleware.Before)
+}
+
+type responseCacheInitialize struct {
+	cache *ResponseCache
+}
+
+func (*responseCacheInitialize) ID() string { return "ResponseCache" }
+
+func (m *responseCacheInitialize) HandleInitialize(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+	out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+) {
+	key, ok := m.cache.options.KeyFunc(ctx, in.Parameters)
+	if !ok {
+		return next.HandleInitialize(ctx, in)
+	}
+
+	out.Result, metadata, err = m.cache.handle(ctx, key, func() (interface{}, middleware.Metadata, error) {
+		out, metadata, err := next.HandleInitialize(ctx, in)
+		return out.Result, metadata, err
+	})
+	return out, metadata, err
+}
+
+type responseCacheBuild struct {
+	cache *ResponseCache
+}
+
+func (*responseCacheBuild) ID() string { return "ResponseCache" }
+
+func (m *responseCacheBuild) HandleBuild(ctx context.Context, in middleware.BuildInput, next middleware.BuildHandler) (
+	out middleware.BuildOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type %T", in.Request)
+	}
+
+	key, ok := m.cache.requestCacheKey(req)
+	if !ok {
+		return next.HandleBuild(ctx, in)
+	}
+
+	out.Result, metadata, err = m.cache.handle(ctx, key, func() (interface{}, middleware.Metadata, error) {
+		out, metadata, err := next.HandleBuild(ctx, in)
+		return out.Result, metadata, err
+	})
+	return out, metadata, err
+}
+
+// requestCacheKey returns the cache key of the request, derived from its
+// method, URL, the hash of its headers, excluding the ignored headers, and
+// the hash of its body. Returns false if the request's body is not seekable,
+// or is set with a StreamProducer.
+func (c *ResponseCache) requestCacheKey(req *Request) (string, bool) {
+	if req.GetStreamProducer() != nil {
+		return "", false
+	}
+
+	body := sha256.New()
+	if stream := req.GetStream(); stream != nil {
+		if !req.IsStreamSeekable() {
+			return "", false
+		}
+		if err := req.RewindStream(); err != nil {
+			return "", false
+		}
+		if _, err := io.Copy(body, stream); err != nil {
+			return "", false
+		}
+		if err := req.RewindStream(); err != nil {
+			return "", false
+		}
+	}
+
+	return req.Method + " " + req.URL.String() + " " +
+		hex.EncodeToString(c.headersHash(req.Header)) + " " +
+		hex.EncodeToString(body.Sum(nil)), true
+}
+
+// headersHash returns the hash of the canonical form of the headers, sorted
+// by name, excluding the ignored headers.
+func (c *ResponseCache) headersHash(header http.Header) []byte {
+	names := make([]string, 0, len(header))
+	for k := range header {
+		k = http.CanonicalHeaderKey(k)
+		if _, ok := c.ignoreHeaders[k]; ok {
+			continue
+		}
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, k := range names {
+		io.WriteString(h, strings.ToLower(k))
+		io.WriteString(h, ":")
+		io.WriteString(h, strings.Join(header.Values(k), ","))
+		io.WriteString(h, "\n")
+	}
+	return h.Sum(nil)
+}
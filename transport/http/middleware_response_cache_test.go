@@ -0,0 +1,216 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+func TestResponseCacheMiddleware(t *testing.T) {
+	type invoke struct {
+		advance      time.Duration
+		input        string
+		header       http.Header
+		producer     bool
+		handlerErr   error
+		expectResult string
+		expectStatus ResponseCacheStatus
+		expectErr    bool
+	}
+
+	cases := map[string]struct {
+		options ResponseCacheOptions
+		invokes []invoke
+	}{
+		"serialized key": {
+			options: ResponseCacheOptions{TTL: time.Minute},
+			invokes: []invoke{
+				{input: "a", expectResult: "a-1", expectStatus: ResponseCacheMiss},
+				{input: "a", expectResult: "a-1", expectStatus: ResponseCacheHit},
+				{input: "b", expectResult: "b-2", expectStatus: ResponseCacheMiss},
+				{advance: 30 * time.Second, input: "a", expectResult: "a-1", expectStatus: ResponseCacheHit},
+				{advance: 30 * time.Second, input: "a", expectResult: "a-3", expectStatus: ResponseCacheMiss},
+			},
+		},
+		"headers in key": {
+			options: ResponseCacheOptions{TTL: time.Minute},
+			invokes: []invoke{
+				{input: "a", header: http.Header{"Range": {"bytes=0-1"}},
+					expectResult: "a-1", expectStatus: ResponseCacheMiss},
+				{input: "a", header: http.Header{"Range": {"bytes=0-2"}},
+					expectResult: "a-2", expectStatus: ResponseCacheMiss},
+				{input: "a", expectResult: "a-3", expectStatus: ResponseCacheMiss},
+				{input: "a", header: http.Header{"Range": {"bytes=0-1"}, "User-Agent": {"other"}},
+					expectResult: "a-1", expectStatus: ResponseCacheHit},
+			},
+		},
+		"stream producer not cached": {
+			options: ResponseCacheOptions{TTL: time.Minute},
+			invokes: []invoke{
+				{input: "a", producer: true, expectResult: "a-1"},
+				{input: "a", producer: true, expectResult: "a-2"},
+			},
+		},
+		"key func": {
+			options: ResponseCacheOptions{
+				TTL: time.Minute,
+				KeyFunc: func(ctx context.Context, input interface{}) (string, bool) {
+					s := input.(string)
+					return strings.TrimPrefix(s, "uncached-"), !strings.HasPrefix(s, "uncached-")
+				},
+			},
+			invokes: []invoke{
+				{input: "a", expectResult: "a-1", expectStatus: ResponseCacheMiss},
+				{input: "a", expectResult: "a-1", expectStatus: ResponseCacheHit},
+				{input: "uncached-a", expectResult: "uncached-a-2"},
+			},
+		},
+		"max entries": {
+			options: ResponseCacheOptions{TTL: time.Minute, MaxEntries: 2},
+			invokes: []invoke{
+				{input: "a", expectResult: "a-1", expectStatus: ResponseCacheMiss},
+				{input: "b", expectResult: "b-2", expectStatus: ResponseCacheMiss},
+				{input: "a", expectResult: "a-1", expectStatus: ResponseCacheHit},
+				{input: "c", expectResult: "c-3", expectStatus: ResponseCacheMiss},
+				{input: "a", expectResult: "a-1", expectStatus: ResponseCacheHit},
+				{input: "b", expectResult: "b-4", expectStatus: ResponseCacheMiss},
+			},
+		},
+		"errors not cached": {
+			options: ResponseCacheOptions{TTL: time.Minute},
+			invokes: []invoke{
+				{input: "a", handlerErr: fmt.Errorf("failed"), expectErr: true},
+				{input: "a", expectResult: "a-2", expectStatus: ResponseCacheMiss},
+			},
+		},
+		"stale if error": {
+			options: ResponseCacheOptions{TTL: time.Minute, StaleIfError: time.Minute},
+			invokes: []invoke{
+				{input: "a", expectResult: "a-1", expectStatus: ResponseCacheMiss},
+				{advance: 90 * time.Second, input: "a", handlerErr: fmt.Errorf("failed"),
+					expectResult: "a-1", expectStatus: ResponseCacheStale},
+				{advance: 30 * time.Second, input: "a", handlerErr: fmt.Errorf("failed"), expectErr: true},
+			},
+		},
+		"no stale by default": {
+			options: ResponseCacheOptions{TTL: time.Minute},
+			invokes: []invoke{
+				{input: "a", expectResult: "a-1", expectStatus: ResponseCacheMiss},
+				{advance: time.Minute, input: "a", handlerErr: fmt.Errorf("failed"), expectErr: true},
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			clock := smithytime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			ctx := smithytime.WithClock(context.Background(), clock)
+
+			cache, err := NewResponseCache(func(o *ResponseCacheOptions) {
+				*o = c.options
+				if o.MaxEntries == 0 {
+					o.MaxEntries = 1000
+				}
+				if o.IgnoreHeaders == nil {
+					o.IgnoreHeaders = DefaultResponseCacheIgnoreHeaders
+				}
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			var calls int
+			for i, inv := range c.invokes {
+				clock.Advance(inv.advance)
+
+				stack := middleware.NewStack("test", NewStackRequest)
+				stack.Serialize.Add(middleware.SerializeMiddlewareFunc("serialize",
+					func(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+						middleware.SerializeOutput, middleware.Metadata, error,
+					) {
+						req := in.Request.(*Request)
+						req.Method = "GET"
+						req.URL.Path = "/" + in.Parameters.(string)
+						req.Header.Set("User-Agent", fmt.Sprintf("agent-%d", i))
+						for k, vs := range inv.header {
+							req.Header[k] = vs
+						}
+						if inv.producer {
+							in.Request = req.SetStreamProducer(func(ctx context.Context, w io.Writer) error {
+								_, err := io.WriteString(w, "body")
+								return err
+							})
+						}
+						return next.HandleSerialize(ctx, in)
+					}), middleware.After)
+				stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("deserialize",
+					func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+						out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+					) {
+						out, metadata, err = next.HandleDeserialize(ctx, in)
+						out.Result = out.RawResponse
+						return out, metadata, err
+					}), middleware.After)
+				if err := AddResponseCacheMiddleware(stack, cache); err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+
+				handler := middleware.DecorateHandler(middleware.HandlerFunc(
+					func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+						calls++
+						if inv.handlerErr != nil {
+							return nil, middleware.Metadata{}, inv.handlerErr
+						}
+						path := input.(*Request).URL.Path
+						return fmt.Sprintf("%s-%d", path[1:], calls), middleware.Metadata{}, nil
+					}), stack)
+
+				result, metadata, err := handler.Handle(ctx, inv.input)
+				if inv.expectErr {
+					if err == nil {
+						t.Fatalf("%d, expect error", i)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("%d, expect no error, got %v", i, err)
+				}
+				if e, a := inv.expectResult, result; e != a {
+					t.Errorf("%d, expect %v result, got %v", i, e, a)
+				}
+				status, ok := GetResponseCacheStatus(metadata)
+				if e, a := len(inv.expectStatus) != 0, ok; e != a {
+					t.Fatalf("%d, expect %v status, got %v", i, e, a)
+				}
+				if e, a := inv.expectStatus, status; e != a {
+					t.Errorf("%d, expect %v status, got %v", i, e, a)
+				}
+			}
+		})
+	}
+}
+
+func TestNewResponseCacheInvalid(t *testing.T) {
+	cases := map[string]func(*ResponseCacheOptions){
+		"no ttl": func(o *ResponseCacheOptions) {},
+		"negative max entries": func(o *ResponseCacheOptions) {
+			o.TTL = time.Minute
+			o.MaxEntries = -1
+		},
+	}
+
+	for name, fn := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewResponseCache(fn); err == nil {
+				t.Errorf("expect error")
+			}
+		})
+	}
+}
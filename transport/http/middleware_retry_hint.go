@@ -0,0 +1,160 @@
+package http
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+// RetryAfterHeader is the standard HTTP header a service uses to request a
+// delay before the request is retried, RFC 9110 section 10.2.3.
+const RetryAfterHeader = "Retry-After"
+
+// DefaultThrottleStatusCodes are the HTTP status codes a response is
+// considered throttled by, if not otherwise configured.
+var DefaultThrottleStatusCodes = []int{429}
+
+// RetryHintParser updates the retry hint from a protocol specific hint of the
+// response, e.g. a service specific header.
+type RetryHintParser func(resp *Response, hint *smithy.RetryHint)
+
+// RetryHintOptions provides the configuration of the retry hint middleware.
+type RetryHintOptions struct {
+	// ThrottleStatusCodes are the HTTP status codes the response is
+	// considered throttled by. Defaults to DefaultThrottleStatusCodes.
+	ThrottleStatusCodes []int
+
+	// ThrottleErrorCodes are the error codes of the protocol's throttling
+	// errors, e.g. "ThrottlingException". Errors which implement
+	// smithy.ThrottleError are always considered throttling errors.
+	ThrottleErrorCodes []string
+
+	// MaxRetryAfter limits the delay a response may request. Zero means no
+	// limit.
+	MaxRetryAfter time.Duration
+
+	// Parsers are the protocol specific hint parsers, called in order after
+	// the Retry-After header is parsed.
+	Parsers []RetryHintParser
+}
+
+// AddRetryHintMiddleware adds the middleware which parses the retry guidance
+// of the response, e.g. the Retry-After header, throttling status codes, and
+// throttling errors, into a smithy.RetryHint. The hint is set on the
+// operation's result metadata, retrieved with smithy.GetMetadataRetryHint.
+//
+// The hint is only set for responses which provide retry guidance.
+func AddRetryHintMiddleware(stack *middleware.Stack, optFns ...func(*RetryHintOptions)) error {
+	o := RetryHintOptions{
+		ThrottleStatusCodes: DefaultThrottleStatusCodes,
+	}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	return stack.Deserialize.Add(&retryHint{options: o}, middleware.Before)
+}
+
+// RetryAfterMillisecondsParser returns a RetryHintParser which reads the
+// delay, in integer milliseconds, from the header, e.g. retry-after-ms.
+func RetryAfterMillisecondsParser(header string) RetryHintParser {
+	return func(resp *Response, hint *smithy.RetryHint) {
+		v := strings.TrimSpace(resp.Header.Get(header))
+		if len(v) == 0 {
+			return
+		}
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || ms < 0 {
+			return
+		}
+		hint.RetryAfter = time.Duration(ms) * time.Millisecond
+	}
+}
+
+// ParseRetryAfter parses a Retry-After header value, either a non-negative
+// number of seconds or an HTTP-date, into the delay relative to now. Returns
+// false if the value is not valid. A date in the past is a delay of zero.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := smithytime.ParseHTTPDate(value)
+	if err != nil {
+		return 0, false
+	}
+	if d := date.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+type retryHint struct {
+	options RetryHintOptions
+}
+
+func (*retryHint) ID() string {
+	return "RetryHint"
+}
+
+func (m *retryHint) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+
+	resp, ok := out.RawResponse.(*Response)
+	if !ok || resp == nil || resp.Response == nil {
+		return out, metadata, err
+	}
+
+	var hint smithy.RetryHint
+	var found bool
+
+	if v := resp.Header.Get(RetryAfterHeader); len(v) != 0 {
+		hint.RetryAfter, found = ParseRetryAfter(v, smithytime.GetClock(ctx).Now())
+	}
+	for _, code := range m.options.ThrottleStatusCodes {
+		if resp.StatusCode == code {
+			hint.Throttle = true
+			break
+		}
+	}
+	if err != nil {
+		if smithy.IsErrorThrottle(err) {
+			hint.Throttle = true
+		} else if code, ok := smithy.GetErrorCode(err); ok {
+			for _, c := range m.options.ThrottleErrorCodes {
+				if code == c {
+					hint.Throttle = true
+					break
+				}
+			}
+		}
+	}
+	for _, fn := range m.options.Parsers {
+		fn(resp, &hint)
+	}
+
+	if m.options.MaxRetryAfter > 0 && hint.RetryAfter > m.options.MaxRetryAfter {
+		hint.RetryAfter = m.options.MaxRetryAfter
+	}
+
+	if found || hint.Throttle || hint.RetryAfter > 0 {
+		smithy.SetMetadataRetryHint(&metadata, hint)
+	}
+
+	return out, metadata, err
+}
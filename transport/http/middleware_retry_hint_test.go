@@ -0,0 +1,184 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithytime "github.com/aws/smithy-go/time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		value    string
+		expect   time.Duration
+		expectOK bool
+	}{
+		"seconds": {
+			value:    "120",
+			expect:   2 * time.Minute,
+			expectOK: true,
+		},
+		"zero seconds": {
+			value:    "0",
+			expectOK: true,
+		},
+		"http date": {
+			value:    "Wed, 21 Oct 2015 07:28:30 GMT",
+			expect:   30 * time.Second,
+			expectOK: true,
+		},
+		"http date in past": {
+			value:    "Wed, 21 Oct 2015 07:27:00 GMT",
+			expectOK: true,
+		},
+		"negative seconds": {
+			value: "-1",
+		},
+		"invalid": {
+			value: "soon",
+		},
+		"empty": {
+			value: " ",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, ok := ParseRetryAfter(c.value, now)
+			if e, a := c.expectOK, ok; e != a {
+				t.Fatalf("expect %v ok, got %v", e, a)
+			}
+			if e, a := c.expect, actual; e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestRetryHintMiddleware(t *testing.T) {
+	now := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		statusCode int
+		header     http.Header
+		err        error
+		optFns     []func(*RetryHintOptions)
+		expect     smithy.RetryHint
+		expectHint bool
+	}{
+		"no hint": {
+			statusCode: 500,
+			header:     http.Header{},
+		},
+		"retry after seconds": {
+			statusCode: 503,
+			header:     http.Header{"Retry-After": []string{"3"}},
+			expect:     smithy.RetryHint{RetryAfter: 3 * time.Second},
+			expectHint: true,
+		},
+		"retry after date": {
+			statusCode: 503,
+			header:     http.Header{"Retry-After": []string{"Wed, 21 Oct 2015 07:28:10 GMT"}},
+			expect:     smithy.RetryHint{RetryAfter: 10 * time.Second},
+			expectHint: true,
+		},
+		"invalid retry after": {
+			statusCode: 503,
+			header:     http.Header{"Retry-After": []string{"later"}},
+		},
+		"throttle status code": {
+			statusCode: 429,
+			header:     http.Header{"Retry-After": []string{"1"}},
+			expect:     smithy.RetryHint{RetryAfter: time.Second, Throttle: true},
+			expectHint: true,
+		},
+		"throttle error code": {
+			statusCode: 400,
+			header:     http.Header{},
+			err:        &smithy.GenericAPIError{Code: "SlowDown"},
+			optFns: []func(*RetryHintOptions){
+				func(o *RetryHintOptions) {
+					o.ThrottleErrorCodes = []string{"SlowDown"}
+				},
+			},
+			expect:     smithy.RetryHint{Throttle: true},
+			expectHint: true,
+		},
+		"max retry after": {
+			statusCode: 503,
+			header:     http.Header{"Retry-After": []string{"3600"}},
+			optFns: []func(*RetryHintOptions){
+				func(o *RetryHintOptions) {
+					o.MaxRetryAfter = time.Minute
+				},
+			},
+			expect:     smithy.RetryHint{RetryAfter: time.Minute},
+			expectHint: true,
+		},
+		"milliseconds parser": {
+			statusCode: 503,
+			header: http.Header{
+				"Retry-After":    []string{"1"},
+				"Retry-After-Ms": []string{"1500"},
+			},
+			optFns: []func(*RetryHintOptions){
+				func(o *RetryHintOptions) {
+					o.Parsers = append(o.Parsers, RetryAfterMillisecondsParser("retry-after-ms"))
+				},
+			},
+			expect:     smithy.RetryHint{RetryAfter: 1500 * time.Millisecond},
+			expectHint: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			stack := middleware.NewStack("test", NewStackRequest)
+			if err := AddRetryHintMiddleware(stack, c.optFns...); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("deserialize",
+				func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+					out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+				) {
+					out, metadata, err = next.HandleDeserialize(ctx, in)
+					if err != nil {
+						return out, metadata, err
+					}
+					return out, metadata, c.err
+				},
+			), middleware.After)
+
+			handler := middleware.DecorateHandler(middleware.HandlerFunc(
+				func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+					return &Response{Response: &http.Response{
+						StatusCode: c.statusCode,
+						Header:     c.header,
+						Body:       http.NoBody,
+					}}, middleware.Metadata{}, nil
+				},
+			), stack)
+
+			ctx := smithytime.WithClock(context.Background(), smithytime.NewFakeClock(now))
+			_, metadata, err := handler.Handle(ctx, struct{}{})
+			if e, a := c.err, err; !errors.Is(a, e) {
+				t.Errorf("expect %v error, got %v", e, a)
+			}
+
+			hint, ok := smithy.GetMetadataRetryHint(metadata)
+			if e, a := c.expectHint, ok; e != a {
+				t.Fatalf("expect %v hint, got %v", e, a)
+			}
+			if e, a := c.expect, hint; e != a {
+				t.Errorf("expect %+v, got %+v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/tracing"
+)
+
+// HeaderCarrier adapts an http.Header to the tracing.TextMapCarrier
+// interface.
+type HeaderCarrier http.Header
+
+// Get returns the first value associated with the key.
+func (h HeaderCarrier) Get(key string) string {
+	return http.Header(h).Get(key)
+}
+
+// Set sets the header entry associated with key to the value.
+func (h HeaderCarrier) Set(key, value string) {
+	http.Header(h).Set(key, value)
+}
+
+// TracePropagation is a build middleware that injects the tracing context
+// from the request's context into the outgoing HTTP request headers using its
+// propagators.
+type TracePropagation struct {
+	Propagators []tracing.Propagator
+}
+
+// AddTracePropagationMiddleware adds the TracePropagation middleware to the
+// stack's Build step. If no propagators are provided, the W3C Trace Context
+// and Baggage propagators are used.
+func AddTracePropagationMiddleware(stack *middleware.Stack, propagators ...tracing.Propagator) error {
+	if len(propagators) == 0 {
+		propagators = []tracing.Propagator{tracing.TraceContext{}, tracing.Baggage{}}
+	}
+	return stack.Build.Add(&TracePropagation{Propagators: propagators}, middleware.After)
+}
+
+// ID returns the middleware identifier.
+func (m *TracePropagation) ID() string {
+	return "TracePropagation"
+}
+
+// HandleBuild injects the tracing context into the request headers.
+func (m *TracePropagation) HandleBuild(
+	ctx context.Context, in middleware.BuildInput, next middleware.BuildHandler,
+) (
+	out middleware.BuildOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type %T", in.Request)
+	}
+
+	tracing.CompositePropagator(m.Propagators).Inject(ctx, HeaderCarrier(req.Header))
+
+	return next.HandleBuild(ctx, in)
+}
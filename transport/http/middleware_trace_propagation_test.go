@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/tracing"
+)
+
+type mockPropagationSpan struct {
+	tracing.Span
+	ctx tracing.SpanContext
+}
+
+func (s mockPropagationSpan) Context() tracing.SpanContext { return s.ctx }
+
+func TestTracePropagation(t *testing.T) {
+	cases := map[string]struct {
+		Context           func() context.Context
+		Propagators       []tracing.Propagator
+		ExpectTraceParent string
+		ExpectTraceState  string
+		ExpectBaggage     string
+	}{
+		"no span": {
+			Context: context.Background,
+		},
+		"sampled span with state and baggage": {
+			Context: func() context.Context {
+				ctx := tracing.WithSpan(context.Background(), mockPropagationSpan{ctx: tracing.SpanContext{
+					TraceID:    "4bf92f3577b34da6a3ce929d0e0e4736",
+					SpanID:     "00f067aa0ba902b7",
+					Sampled:    true,
+					TraceState: "foo=bar",
+				}})
+				ctx = tracing.WithBaggage(ctx, "userId", "alice")
+				return tracing.WithBaggage(ctx, "isProduction", "false")
+			},
+			ExpectTraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			ExpectTraceState:  "foo=bar",
+			ExpectBaggage:     "isProduction=false,userId=alice",
+		},
+		"custom propagator": {
+			Context: context.Background,
+			Propagators: []tracing.Propagator{
+				tracing.PropagatorFunc(func(ctx context.Context, carrier tracing.TextMapCarrier) {
+					carrier.Set("traceparent", "custom")
+				}),
+			},
+			ExpectTraceParent: "custom",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			stack := middleware.NewStack("test", NewStackRequest)
+			if err := AddTracePropagationMiddleware(stack, c.Propagators...); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			var req *Request
+			handler := middleware.DecorateHandler(middleware.HandlerFunc(
+				func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+					req = input.(*Request)
+					return nil, middleware.Metadata{}, nil
+				}), stack)
+
+			if _, _, err := handler.Handle(c.Context(), struct{}{}); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := c.ExpectTraceParent, req.Header.Get("traceparent"); e != a {
+				t.Errorf("expect %q traceparent, got %q", e, a)
+			}
+			if e, a := c.ExpectTraceState, req.Header.Get("tracestate"); e != a {
+				t.Errorf("expect %q tracestate, got %q", e, a)
+			}
+			if e, a := c.ExpectBaggage, req.Header.Get("baggage"); e != a {
+				t.Errorf("expect %q baggage, got %q", e, a)
+			}
+		})
+	}
+}
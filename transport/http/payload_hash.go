@@ -0,0 +1,242 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"sync"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// HashAlgorithm is an algorithm of the digest of a request payload.
+type HashAlgorithm struct {
+	// Name identifies the algorithm, e.g. "sha256".
+	Name string
+
+	// NewHash returns the hash computing the digest.
+	NewHash func() hash.Hash
+}
+
+// Hash algorithms of payload digests.
+var (
+	HashAlgorithmSHA256 = HashAlgorithm{Name: "sha256", NewHash: sha256.New}
+	HashAlgorithmCRC32  = HashAlgorithm{Name: "crc32", NewHash: func() hash.Hash { return crc32.NewIEEE() }}
+	HashAlgorithmCRC32C = HashAlgorithm{Name: "crc32c", NewHash: func() hash.Hash {
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	}}
+)
+
+// PayloadDigest is the digest of a request payload, computed while the
+// payload is read by the transport, see HashRequestStream. The digest is
+// complete once the whole payload has been read, such as when the request
+// has been sent, for use in trailers, or to validate the payload afterwards.
+// PayloadDigest is safe for concurrent use.
+type PayloadDigest struct {
+	algorithm string
+
+	mu  sync.Mutex
+	sum []byte
+}
+
+// Algorithm returns the name of the digest's hash algorithm.
+func (d *PayloadDigest) Algorithm() string {
+	return d.algorithm
+}
+
+// Sum returns the digest of the payload, and whether it is complete. The
+// digest is not complete until the payload has been read to its end, and is
+// reset when the payload is rewound, e.g. for a retry attempt.
+func (d *PayloadDigest) Sum() ([]byte, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.sum, d.sum != nil
+}
+
+func (d *PayloadDigest) setSum(sum []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sum = sum
+}
+
+func payloadDigestKey(algorithm string) smithy.PropertyKey[*PayloadDigest] {
+	return smithy.NewPropertyKey[*PayloadDigest]("smithy.http.payloadDigest", algorithm)
+}
+
+// SetPayloadDigest sets the payload digest in the properties, keyed by its
+// algorithm, e.g. for a signer to read the digest of the payload it signs.
+func SetPayloadDigest(p *smithy.Properties, d *PayloadDigest) {
+	payloadDigestKey(d.algorithm).Set(p, d)
+}
+
+// GetPayloadDigest returns the payload digest of the algorithm from the
+// properties, and whether it was set.
+func GetPayloadDigest(p smithy.PropertiesReader, algorithm string) (*PayloadDigest, bool) {
+	return payloadDigestKey(algorithm).Get(p)
+}
+
+// HashRequestStream returns a clone of the request with its stream wrapped
+// to compute the digests of the algorithms while the stream is read, tee'ing
+// the payload into the hashes as it is sent by the transport, without
+// reading the payload in advance. Returns the digests, in the order of the
+// algorithms.
+//
+// A seekable stream remains seekable. Seeking the stream to its start
+// position, such as by RewindStream for a retry attempt, resets the digests.
+// The digests of a request without a stream are of the empty payload.
+// Returns an error if the request's stream is set with a StreamProducer.
+func HashRequestStream(req *Request, algorithms ...HashAlgorithm) (*Request, []*PayloadDigest, error) {
+	if req.GetStreamProducer() != nil {
+		return nil, nil, fmt.Errorf("unable to hash request stream set with a StreamProducer")
+	}
+
+	hs := &hashingStream{
+		hashes:  make([]hash.Hash, len(algorithms)),
+		digests: make([]*PayloadDigest, len(algorithms)),
+		valid:   true,
+	}
+	for i, alg := range algorithms {
+		hs.hashes[i] = alg.NewHash()
+		hs.digests[i] = &PayloadDigest{algorithm: alg.Name}
+	}
+
+	stream := req.GetStream()
+	if stream == nil {
+		hs.complete()
+		return req, hs.digests, nil
+	}
+
+	hs.reader = stream
+	if req.IsStreamSeekable() {
+		if err := req.RewindStream(); err != nil {
+			return nil, nil, err
+		}
+		hs.start = req.streamStartPos
+		rc, err := req.SetStream(&seekableHashingStream{hashingStream: hs})
+		return rc, hs.digests, err
+	}
+	rc, err := req.SetStream(hs)
+	return rc, hs.digests, err
+}
+
+// hashingStream writes the bytes read from the reader to its hashes,
+// completing the digests when the reader is read to its end.
+type hashingStream struct {
+	reader  io.Reader
+	hashes  []hash.Hash
+	digests []*PayloadDigest
+
+	// start is the start position of a seekable reader. The hashes are valid
+	// only if the reader has been read from its start position.
+	start int64
+	valid bool
+}
+
+func (s *hashingStream) Read(p []byte) (int, error) {
+	n, err := s.reader.Read(p)
+	if s.valid {
+		for _, h := range s.hashes {
+			h.Write(p[:n])
+		}
+		if err == io.EOF {
+			s.complete()
+			s.valid = false
+		}
+	}
+	return n, err
+}
+
+func (s *hashingStream) complete() {
+	for i, h := range s.hashes {
+		s.digests[i].setSum(h.Sum(nil))
+	}
+}
+
+type seekableHashingStream struct {
+	*hashingStream
+}
+
+// Seek seeks the reader, resetting the digests. The digests are computed
+// again if the reader is seeked to its start position.
+func (s *seekableHashingStream) Seek(offset int64, whence int) (int64, error) {
+	pos, err := s.reader.(io.Seeker).Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+
+	// the position of Seek(0, io.SeekCurrent) is unchanged.
+	if offset == 0 && whence == io.SeekCurrent {
+		return pos, nil
+	}
+
+	for i, h := range s.hashes {
+		h.Reset()
+		s.digests[i].setSum(nil)
+	}
+	s.valid = pos == s.start
+	return pos, nil
+}
+
+// PayloadHashOptions provides the configuration of the payload hash
+// middleware.
+type PayloadHashOptions struct {
+	// Algorithms are the algorithms of the payload digests. Defaults to
+	// HashAlgorithmSHA256.
+	Algorithms []HashAlgorithm
+}
+
+// AddPayloadHashMiddleware adds the Build step middleware which computes the
+// digests of the request's payload while it is sent, see HashRequestStream.
+// The digests are added to the metadata of the operation's result, see
+// GetPayloadDigests, to validate the payload afterwards.
+func AddPayloadHashMiddleware(stack *middleware.Stack, optFns ...func(*PayloadHashOptions)) error {
+	var o PayloadHashOptions
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	if len(o.Algorithms) == 0 {
+		o.Algorithms = []HashAlgorithm{HashAlgorithmSHA256}
+	}
+	return stack.Build.Add(&payloadHash{options: o}, middleware.After)
+}
+
+type payloadHash struct {
+	options PayloadHashOptions
+}
+
+func (*payloadHash) ID() string { return "PayloadHash" }
+
+func (m *payloadHash) HandleBuild(ctx context.Context, in middleware.BuildInput, next middleware.BuildHandler) (
+	out middleware.BuildOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type %T", in.Request)
+	}
+	if req.GetStreamProducer() != nil {
+		return next.HandleBuild(ctx, in)
+	}
+
+	req, digests, err := HashRequestStream(req, m.options.Algorithms...)
+	if err != nil {
+		return out, metadata, fmt.Errorf("failed to hash request stream, %w", err)
+	}
+	in.Request = req
+
+	out, metadata, err = next.HandleBuild(ctx, in)
+	metadata.Set(payloadDigestsKey{}, digests)
+	return out, metadata, err
+}
+
+type payloadDigestsKey struct{}
+
+// GetPayloadDigests returns the digests of the request payload added to the
+// result metadata by the payload hash middleware.
+func GetPayloadDigests(metadata middleware.Metadata) []*PayloadDigest {
+	v, _ := metadata.Get(payloadDigestsKey{}).([]*PayloadDigest)
+	return v
+}
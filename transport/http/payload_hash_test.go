@@ -0,0 +1,185 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestHashRequestStream(t *testing.T) {
+	cases := map[string]struct {
+		Stream   io.Reader
+		Seekable bool
+		Expect   string
+	}{
+		"seekable": {
+			Stream:   strings.NewReader("abc"),
+			Seekable: true,
+			Expect:   sha256Hex("abc"),
+		},
+		"unseekable": {
+			Stream: ioutil.NopCloser(strings.NewReader("abc")),
+			Expect: sha256Hex("abc"),
+		},
+		"no stream": {
+			Expect: sha256Hex(""),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := NewStackRequest().(*Request)
+			if c.Stream != nil {
+				var err error
+				if req, err = req.SetStream(c.Stream); err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+			}
+
+			req, digests, err := HashRequestStream(req, HashAlgorithmSHA256)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Seekable, req.IsStreamSeekable(); e != a {
+				t.Errorf("expect %v seekable, got %v", e, a)
+			}
+			if c.Stream != nil {
+				if _, ok := digests[0].Sum(); ok {
+					t.Errorf("expect digest incomplete before stream is read")
+				}
+				b, _ := ioutil.ReadAll(req.Build(context.Background()).Body)
+				if e, a := "abc", string(b); e != a {
+					t.Errorf("expect %q body, got %q", e, a)
+				}
+			}
+
+			sum, ok := digests[0].Sum()
+			if !ok {
+				t.Fatalf("expect digest complete")
+			}
+			if e, a := c.Expect, hex.EncodeToString(sum); e != a {
+				t.Errorf("expect %v digest, got %v", e, a)
+			}
+			if e, a := "sha256", digests[0].Algorithm(); e != a {
+				t.Errorf("expect %v algorithm, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestHashRequestStream_Rewind(t *testing.T) {
+	req, err := NewStackRequest().(*Request).SetStream(bytes.NewReader([]byte("abcdef")))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	req, digests, err := HashRequestStream(req, HashAlgorithmSHA256, HashAlgorithmCRC32)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	// A partially sent attempt, rewound for a retry attempt.
+	io.ReadFull(req.GetStream(), make([]byte, 3))
+	if err := req.RewindStream(); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	ioutil.ReadAll(req.GetStream())
+
+	sum, ok := digests[0].Sum()
+	if !ok {
+		t.Fatalf("expect digest complete")
+	}
+	if e, a := sha256Hex("abcdef"), hex.EncodeToString(sum); e != a {
+		t.Errorf("expect %v digest, got %v", e, a)
+	}
+	sum, _ = digests[1].Sum()
+	if e, a := fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte("abcdef"))), hex.EncodeToString(sum); e != a {
+		t.Errorf("expect %v crc32 digest, got %v", e, a)
+	}
+
+	// Rewinding resets the digest, and reading from the middle of the stream
+	// does not complete it.
+	if err := req.RewindStream(); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if _, ok := digests[0].Sum(); ok {
+		t.Errorf("expect digest reset by rewind")
+	}
+	req.GetStream().(io.Seeker).Seek(2, io.SeekStart)
+	ioutil.ReadAll(req.GetStream())
+	if _, ok := digests[0].Sum(); ok {
+		t.Errorf("expect digest incomplete when read from middle of stream")
+	}
+}
+
+func TestPayloadHashMiddleware(t *testing.T) {
+	stack := middleware.NewStack("test", NewStackRequest)
+	stack.Serialize.Add(middleware.SerializeMiddlewareFunc("serialize",
+		func(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+			middleware.SerializeOutput, middleware.Metadata, error,
+		) {
+			req, err := in.Request.(*Request).SetStream(strings.NewReader("abc"))
+			if err != nil {
+				return middleware.SerializeOutput{}, middleware.Metadata{}, err
+			}
+			in.Request = req
+			return next.HandleSerialize(ctx, in)
+		}), middleware.After)
+	if err := AddPayloadHashMiddleware(stack); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+			ioutil.ReadAll(input.(*Request).Build(ctx).Body)
+			return nil, middleware.Metadata{}, nil
+		}), stack)
+
+	_, metadata, err := handler.Handle(context.Background(), struct{}{})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	digests := GetPayloadDigests(metadata)
+	if e, a := 1, len(digests); e != a {
+		t.Fatalf("expect %v digests, got %v", e, a)
+	}
+	sum, ok := digests[0].Sum()
+	if !ok {
+		t.Fatalf("expect digest complete")
+	}
+	if e, a := sha256Hex("abc"), hex.EncodeToString(sum); e != a {
+		t.Errorf("expect %v digest, got %v", e, a)
+	}
+}
+
+func TestPayloadDigestProperties(t *testing.T) {
+	_, digests, err := HashRequestStream(NewStackRequest().(*Request), HashAlgorithmCRC32C)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var p smithy.Properties
+	SetPayloadDigest(&p, digests[0])
+
+	if d, ok := GetPayloadDigest(&p, "crc32c"); !ok || d != digests[0] {
+		t.Errorf("expect crc32c digest, got %v, %v", d, ok)
+	}
+	if _, ok := GetPayloadDigest(&p, "sha256"); ok {
+		t.Errorf("expect no sha256 digest")
+	}
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// PresignedRequest is an HTTP request built and signed by an operation's
+// middleware stack, but not sent. The request can be sent later, e.g. by a
+// different client, within the expiration of its signature.
+type PresignedRequest struct {
+	// URL is the request's URL, including the query string.
+	URL string
+
+	// Method is the request's HTTP method.
+	Method string
+
+	// SignedHeader is the request's headers, which must be sent with the
+	// request for its signature to be valid.
+	SignedHeader http.Header
+}
+
+// PresignOptions provides the configuration of presigning a request.
+type PresignOptions struct {
+	// Expires is the duration the presigned request is valid for. Signers
+	// receive it as the auth.SetSigningExpiration signing property. Zero
+	// uses the signer's default.
+	Expires time.Duration
+}
+
+type presignOptionsKey struct{}
+
+// GetPresignOptions returns the presign options of the operation, and whether
+// the operation's stack is being run in presign mode. Signers use this to
+// sign the request for presigning, e.g. with query string parameters instead
+// of headers.
+//
+// Scoped to stack values. Use middleware#ClearStackValues to clear all stack
+// values.
+func GetPresignOptions(ctx context.Context) (v PresignOptions, ok bool) {
+	v, ok = middleware.GetStackValue(ctx, presignOptionsKey{}).(PresignOptions)
+	return v, ok
+}
+
+// PresignRequestID is the ID of the Finalize step middleware which captures
+// the presigned request.
+const PresignRequestID = "PresignRequest"
+
+// Presign runs the operation's stack in presign mode with the input,
+// returning the built and signed request, without invoking the Deserialize
+// step or sending the request.
+//
+// Presign modifies the stack, which must not be used to invoke the operation
+// afterwards. The Deserialize step is cleared, and a middleware is added to
+// the end of the Finalize step which captures the request and stops the
+// stack from continuing.
+func Presign(ctx context.Context, stack *middleware.Stack, input interface{}, optFns ...func(*PresignOptions)) (*PresignedRequest, error) {
+	var o PresignOptions
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	stack.Deserialize.Clear()
+	if err := stack.Finalize.Add(&presignRequest{}, middleware.After); err != nil {
+		return nil, fmt.Errorf("failed to add presign middleware, %w", err)
+	}
+
+	ctx = middleware.WithStackValue(ctx, presignOptionsKey{}, o)
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(context.Context, interface{}) (interface{}, middleware.Metadata, error) {
+			return nil, middleware.Metadata{}, fmt.Errorf("presigned request must not be sent")
+		},
+	), stack)
+
+	result, _, err := handler.Handle(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	presigned, ok := result.(*PresignedRequest)
+	if !ok {
+		return nil, fmt.Errorf("expect %T presign result, got %T", presigned, result)
+	}
+	return presigned, nil
+}
+
+type presignRequest struct{}
+
+func (*presignRequest) ID() string { return PresignRequestID }
+
+func (*presignRequest) HandleFinalize(ctx context.Context, in middleware.FinalizeInput, _ middleware.FinalizeHandler) (
+	out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type %T", in.Request)
+	}
+
+	built := req.Build(ctx)
+	header := built.Header.Clone()
+	if len(built.Host) != 0 {
+		header.Set("Host", built.Host)
+	}
+
+	out.Result = &PresignedRequest{
+		URL:          built.URL.String(),
+		Method:       built.Method,
+		SignedHeader: header,
+	}
+	return out, metadata, nil
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/auth"
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestPresign(t *testing.T) {
+	stack := middleware.NewStack("test", NewStackRequest)
+	stack.Serialize.Add(middleware.SerializeMiddlewareFunc("serialize",
+		func(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+			middleware.SerializeOutput, middleware.Metadata, error,
+		) {
+			req := in.Request.(*Request)
+			req.Method = "GET"
+			req.URL.Scheme = "https"
+			req.URL.Host = "example.com"
+			req.URL.Path = "/bucket/" + in.Parameters.(string)
+			req.Host = "example.com"
+			req.Header.Set("X-Custom", "value")
+			return next.HandleSerialize(ctx, in)
+		}), middleware.After)
+	stack.Initialize.Add(middleware.InitializeMiddlewareFunc("resolveSigner",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+			middleware.InitializeOutput, middleware.Metadata, error,
+		) {
+			return next.HandleInitialize(WithResolvedSigner(ctx, ResolvedSigner{
+				Signer: HTTPSignerFunc(func(ctx context.Context, _ auth.Identity, r *Request, props smithy.Properties) error {
+					if _, ok := GetPresignOptions(ctx); !ok {
+						return fmt.Errorf("expect presign mode")
+					}
+					expires, _ := auth.GetSigningExpiration(&props)
+					q := r.URL.Query()
+					q.Set("Expires", fmt.Sprint(int64(expires/time.Second)))
+					q.Set("Signature", "abc123")
+					r.URL.RawQuery = q.Encode()
+					return nil
+				}),
+				Identity: mockIdentity{key: "abc"},
+			}), in)
+		}), middleware.After)
+	if err := AddSignRequestMiddleware(stack); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("deserialize",
+		func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+			middleware.DeserializeOutput, middleware.Metadata, error,
+		) {
+			t.Errorf("expect deserialize not to be invoked")
+			return next.HandleDeserialize(ctx, in)
+		}), middleware.After)
+
+	presigned, err := Presign(context.Background(), stack, "key", func(o *PresignOptions) {
+		o.Expires = 15 * time.Minute
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := "https://example.com/bucket/key?Expires=900&Signature=abc123", presigned.URL; e != a {
+		t.Errorf("expect %v URL, got %v", e, a)
+	}
+	if e, a := "GET", presigned.Method; e != a {
+		t.Errorf("expect %v method, got %v", e, a)
+	}
+	if e, a := "value", presigned.SignedHeader.Get("X-Custom"); e != a {
+		t.Errorf("expect %v header, got %v", e, a)
+	}
+	if e, a := "example.com", presigned.SignedHeader.Get("Host"); e != a {
+		t.Errorf("expect %v host header, got %v", e, a)
+	}
+}
+
+func TestGetPresignOptions_NotPresigning(t *testing.T) {
+	if _, ok := GetPresignOptions(context.Background()); ok {
+		t.Errorf("expect not presigning")
+	}
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/aws/smithy-go/encoding/httpbinding"
+)
+
+// DuplicateQueryKeys is how the values of keys repeated in a query string
+// are canonicalized.
+type DuplicateQueryKeys int
+
+// Enumeration of DuplicateQueryKeys values.
+const (
+	// DuplicateQueryKeysSorted keeps all values of a key, sorted.
+	DuplicateQueryKeysSorted DuplicateQueryKeys = iota
+
+	// DuplicateQueryKeysOrdered keeps all values of a key, in the order
+	// they appear in the query string.
+	DuplicateQueryKeysOrdered
+
+	// DuplicateQueryKeysFirst keeps only the first value of a key.
+	DuplicateQueryKeysFirst
+
+	// DuplicateQueryKeysLast keeps only the last value of a key.
+	DuplicateQueryKeysLast
+)
+
+// CanonicalQueryOptions provides the configuration of query string
+// canonicalization.
+type CanonicalQueryOptions struct {
+	// DuplicateKeys is how the values of repeated keys are canonicalized.
+	// Defaults to DuplicateQueryKeysSorted.
+	DuplicateKeys DuplicateQueryKeys
+}
+
+// CanonicalQueryString returns the canonical form of the raw query string,
+// for signing requests, and computing the cache keys of requests. Keys and
+// values are decoded, and percent-encoded again with uppercase hex digits,
+// leaving only the unreserved characters of RFC 3986 unescaped, so spaces
+// are encoded as %20. Parameters are sorted by key, and keys without a value
+// have an empty value.
+//
+// Returns an error if the query string is not valid.
+func CanonicalQueryString(rawQuery string, optFns ...func(*CanonicalQueryOptions)) (string, error) {
+	var o CanonicalQueryOptions
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("invalid query string, %w", err)
+	}
+
+	keys := make([]string, 0, len(query))
+	encoded := make(map[string][]string, len(query))
+	for k, vs := range query {
+		ek := httpbinding.EscapePath(k, true)
+		keys = append(keys, ek)
+		encoded[ek] = canonicalQueryValues(vs, o.DuplicateKeys)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		for _, v := range encoded[k] {
+			if b.Len() != 0 {
+				b.WriteByte('&')
+			}
+			b.WriteString(k)
+			b.WriteByte('=')
+			b.WriteString(v)
+		}
+	}
+	return b.String(), nil
+}
+
+func canonicalQueryValues(vs []string, duplicates DuplicateQueryKeys) []string {
+	switch duplicates {
+	case DuplicateQueryKeysFirst:
+		vs = vs[:1]
+	case DuplicateQueryKeysLast:
+		vs = vs[len(vs)-1:]
+	}
+
+	encoded := make([]string, len(vs))
+	for i, v := range vs {
+		encoded[i] = httpbinding.EscapePath(v, true)
+	}
+	if duplicates == DuplicateQueryKeysSorted {
+		sort.Strings(encoded)
+	}
+	return encoded
+}
+
+// CanonicalQueryString returns the canonical form of the request's query
+// string, see CanonicalQueryString.
+func (r *Request) CanonicalQueryString(optFns ...func(*CanonicalQueryOptions)) (string, error) {
+	return CanonicalQueryString(r.URL.RawQuery, optFns...)
+}
+
+// CanonicalizeQuery replaces the request's query string with its canonical
+// form, see CanonicalQueryString.
+func (r *Request) CanonicalizeQuery(optFns ...func(*CanonicalQueryOptions)) error {
+	query, err := r.CanonicalQueryString(optFns...)
+	if err != nil {
+		return err
+	}
+	r.URL.RawQuery = query
+	return nil
+}
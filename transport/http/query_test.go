@@ -0,0 +1,83 @@
+package http
+
+import (
+	"testing"
+)
+
+func TestCanonicalQueryString(t *testing.T) {
+	cases := map[string]struct {
+		Query      string
+		Duplicates DuplicateQueryKeys
+		Expect     string
+		ExpectErr  bool
+	}{
+		"empty": {},
+		"sorted keys": {
+			Query:  "b=2&a=1&c=3",
+			Expect: "a=1&b=2&c=3",
+		},
+		"percent encoding": {
+			Query:  "k%20ey=a+b&x=%7e%2f&y=%2a",
+			Expect: "k%20ey=a%20b&x=~%2F&y=%2A",
+		},
+		"no value": {
+			Query:  "flag&a=1",
+			Expect: "a=1&flag=",
+		},
+		"duplicates sorted": {
+			Query:  "a=2&b=1&a=1",
+			Expect: "a=1&a=2&b=1",
+		},
+		"duplicates ordered": {
+			Query:      "a=2&b=1&a=1",
+			Duplicates: DuplicateQueryKeysOrdered,
+			Expect:     "a=2&a=1&b=1",
+		},
+		"duplicates first": {
+			Query:      "a=2&b=1&a=1",
+			Duplicates: DuplicateQueryKeysFirst,
+			Expect:     "a=2&b=1",
+		},
+		"duplicates last": {
+			Query:      "a=2&b=1&a=1",
+			Duplicates: DuplicateQueryKeysLast,
+			Expect:     "a=1&b=1",
+		},
+		"invalid": {
+			Query:     "a=%zz",
+			ExpectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := CanonicalQueryString(c.Query, func(o *CanonicalQueryOptions) {
+				o.DuplicateKeys = c.Duplicates
+			})
+			if c.ExpectErr {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Expect, actual; e != a {
+				t.Errorf("expect %q, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestRequestCanonicalizeQuery(t *testing.T) {
+	req := NewStackRequest().(*Request)
+	req.URL.RawQuery = "b=2&a=x+y"
+
+	if err := req.CanonicalizeQuery(); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "a=x%20y&b=2", req.URL.RawQuery; e != a {
+		t.Errorf("expect %q query, got %q", e, a)
+	}
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -18,8 +19,19 @@ type Request struct {
 	stream           io.Reader
 	isStreamSeekable bool
 	streamStartPos   int64
+	streamProducer   StreamProducer
 }
 
+// StreamProducer produces a request stream lazily, writing it to w, e.g. from
+// a generator, or a pipe. The producer is invoked each time the request is
+// built for an attempt, and must write the stream from its start.
+//
+// The context is canceled when the attempt's body is closed, including when
+// the attempt is canceled or fails, and writes to w fail with
+// io.ErrClosedPipe once the body is closed, so the producer can stop, and be
+// restarted by a subsequent attempt.
+type StreamProducer func(ctx context.Context, w io.Writer) error
+
 // NewStackRequest returns an initialized request ready to populated with the
 // HTTP request details. Returns empty interface so the function can be used as
 // a parameter to the Smithy middleware Stack constructor.
@@ -33,18 +45,69 @@ func NewStackRequest() interface{} {
 	}
 }
 
-// Clone returns a deep copy of the Request for the new context. A reference to
-// the Stream is copied, but the underlying stream is not copied.
+// Clone returns a deep copy of the Request for the new context. If the
+// request's stream can be duplicated, see DuplicateStream, the clone's stream
+// is a duplicate with an independent read offset, from the stream's start
+// position. Otherwise a reference to the stream is copied, but the
+// underlying stream is not copied.
 func (r *Request) Clone() *Request {
+	rc := r.clone()
+	if stream, ok := r.DuplicateStream(); ok {
+		rc.stream = stream
+		rc.isStreamSeekable = true
+		rc.streamStartPos = 0
+	}
+	return rc
+}
+
+// clone returns a deep copy of the Request, with a reference to its stream.
+func (r *Request) clone() *Request {
 	rc := *r
 	rc.Request = rc.Request.Clone(context.TODO())
 	return &rc
 }
 
+// DuplicateStream returns a reader of the request stream's content, from the
+// stream's start position, with a read offset independent of the stream's,
+// so middleware can read the payload, e.g. to hash or log it, without
+// consuming the stream sent by the transport. Returns false if the stream
+// cannot be duplicated.
+//
+// Seekable streams which implement io.ReaderAt, and report their size with a
+// Size method, such as bytes.Reader, and strings.Reader, and buffered
+// streams which expose their unread content with a Bytes method, such as
+// bytes.Buffer, can be duplicated.
+func (r *Request) DuplicateStream() (io.Reader, bool) {
+	if r.stream == nil || r.streamProducer != nil {
+		return nil, false
+	}
+
+	if r.isStreamSeekable {
+		ra, ok := r.stream.(io.ReaderAt)
+		if !ok {
+			return nil, false
+		}
+		sizer, ok := r.stream.(interface{ Size() int64 })
+		if !ok {
+			return nil, false
+		}
+		return io.NewSectionReader(ra, r.streamStartPos, sizer.Size()-r.streamStartPos), true
+	}
+
+	if b, ok := r.stream.(interface{ Bytes() []byte }); ok {
+		return bytes.NewReader(b.Bytes()), true
+	}
+	return nil, false
+}
+
 // StreamLength returns the number of bytes of the serialized stream attached
 // to the request and ok set. If the length cannot be determined, an error will
 // be returned.
 func (r *Request) StreamLength() (size int64, ok bool, err error) {
+	if r.streamProducer != nil {
+		return 0, false, nil
+	}
+
 	if r.stream == nil {
 		return 0, true, nil
 	}
@@ -78,10 +141,11 @@ func (r *Request) StreamLength() (size int64, ok bool, err error) {
 }
 
 // RewindStream will rewind the io.Reader to the relative start position if it
-// is an io.Seeker.
+// is an io.Seeker. Streams set with a StreamProducer are restarted each time
+// the request is built, and do not need to be rewound.
 func (r *Request) RewindStream() error {
 	// If there is no stream there is nothing to rewind.
-	if r.stream == nil {
+	if r.stream == nil || r.streamProducer != nil {
 		return nil
 	}
 
@@ -106,7 +170,7 @@ func (r *Request) IsStreamSeekable() bool {
 // SetStream returns a clone of the request with the stream set to the provided reader.
 // May return an error if the provided reader is seekable but returns an error.
 func (r *Request) SetStream(reader io.Reader) (rc *Request, err error) {
-	rc = r.Clone()
+	rc = r.clone()
 
 	switch v := reader.(type) {
 	case io.Seeker:
@@ -120,17 +184,52 @@ func (r *Request) SetStream(reader io.Reader) (rc *Request, err error) {
 		rc.isStreamSeekable = false
 	}
 	rc.stream = reader
+	rc.streamProducer = nil
 
 	return rc, err
 }
 
+// SetStreamProducer returns a clone of the request with the stream set to be
+// produced by the StreamProducer for each attempt. Replaces a stream set with
+// SetStream. The length of the stream is unknown unless the request's
+// ContentLength is set.
+func (r *Request) SetStreamProducer(producer StreamProducer) *Request {
+	rc := r.clone()
+	rc.stream = nil
+	rc.isStreamSeekable = false
+	rc.streamStartPos = 0
+	rc.streamProducer = producer
+	return rc
+}
+
+// GetStreamProducer returns the request's StreamProducer if set, or nil.
+func (r *Request) GetStreamProducer() StreamProducer {
+	return r.streamProducer
+}
+
 // Build returns a build standard HTTP request value from the Smithy request.
 // The request's stream is wrapped in a safe container that allows it to be
-// reused for subsequent attempts.
+// reused for subsequent attempts. If the stream can be duplicated, see
+// DuplicateStream, the body is a duplicate of the stream, which is not
+// consumed by sending the request, and GetBody returns new duplicates. If
+// the stream is set with a StreamProducer, the producer is started for the
+// request's body, and restarted for the bodies returned by its GetBody.
 func (r *Request) Build(ctx context.Context) *http.Request {
 	req := r.Request.Clone(ctx)
 
-	if r.stream != nil {
+	if r.streamProducer != nil {
+		producer := r.streamProducer
+		req.Body = newProducerBody(ctx, producer)
+		req.GetBody = func() (io.ReadCloser, error) {
+			return newProducerBody(ctx, producer), nil
+		}
+	} else if stream, ok := r.DuplicateStream(); ok {
+		req.Body = ioutil.NopCloser(stream)
+		req.GetBody = func() (io.ReadCloser, error) {
+			stream, _ := r.DuplicateStream()
+			return ioutil.NopCloser(stream), nil
+		}
+	} else if r.stream != nil {
 		req.Body = iointernal.NewSafeReadCloser(ioutil.NopCloser(r.stream))
 	} else {
 		// we update the content-length to 0,
@@ -146,3 +245,25 @@ func (r *Request) Build(ctx context.Context) *http.Request {
 func RequestCloner(v interface{}) interface{} {
 	return v.(*Request).Clone()
 }
+
+// producerBody is the body of a request whose stream is written by a
+// StreamProducer to a pipe. Closing the body cancels the producer's context,
+// and closes the pipe, unblocking the producer.
+type producerBody struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+}
+
+func newProducerBody(ctx context.Context, producer StreamProducer) io.ReadCloser {
+	ctx, cancel := context.WithCancel(ctx)
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(producer(ctx, pw))
+	}()
+	return &producerBody{PipeReader: pr, cancel: cancel}
+}
+
+func (b *producerBody) Close() error {
+	b.cancel()
+	return b.PipeReader.Close()
+}
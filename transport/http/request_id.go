@@ -0,0 +1,128 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// RequestIDRetriever retrieves the request ID assigned by the service from a
+// response. The body is the response body if the response is an error
+// response, and nil otherwise, so that streaming success responses are not
+// consumed.
+type RequestIDRetriever interface {
+	RetrieveRequestID(resp *Response, body []byte) (string, bool)
+}
+
+// RequestIDRetrieverFunc is a function type that implements the
+// RequestIDRetriever interface.
+type RequestIDRetrieverFunc func(resp *Response, body []byte) (string, bool)
+
+// RetrieveRequestID delegates to the wrapped function.
+func (fn RequestIDRetrieverFunc) RetrieveRequestID(resp *Response, body []byte) (string, bool) {
+	return fn(resp, body)
+}
+
+// RequestIDFromHeader returns a RequestIDRetriever that reads the request ID
+// from the first of the response headers present.
+func RequestIDFromHeader(names ...string) RequestIDRetriever {
+	return RequestIDRetrieverFunc(func(resp *Response, _ []byte) (string, bool) {
+		for _, name := range names {
+			if v := resp.Header.Get(name); len(v) != 0 {
+				return v, true
+			}
+		}
+		return "", false
+	})
+}
+
+// RequestIDFromJSONField returns a RequestIDRetriever that reads the request
+// ID from the first of the top level fields present in a JSON error response
+// body.
+func RequestIDFromJSONField(fields ...string) RequestIDRetriever {
+	return RequestIDRetrieverFunc(func(_ *Response, body []byte) (string, bool) {
+		if len(body) == 0 {
+			return "", false
+		}
+
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return "", false
+		}
+
+		for _, field := range fields {
+			var v string
+			if raw, ok := doc[field]; ok && json.Unmarshal(raw, &v) == nil && len(v) != 0 {
+				return v, true
+			}
+		}
+		return "", false
+	})
+}
+
+// RequestIDRetrieverMiddleware is a deserialize middleware that retrieves
+// the request ID of the raw response with the first of the Retrievers that
+// finds one, and sets it on the operation's result metadata. The request ID
+// is then available to callers with smithy.GetMetadataRequestID, and to the
+// middleware that wrap it, e.g. the ErrorResponseRouter, which includes it in
+// the error's metadata.
+type RequestIDRetrieverMiddleware struct {
+	Retrievers []RequestIDRetriever
+}
+
+// AddRequestIDRetrieverMiddleware adds the RequestIDRetrieverMiddleware to
+// the end of the stack's Deserialize step. The middleware must be added after
+// the ErrorResponseRouter, so that the request ID is set before error
+// responses are deserialized.
+func AddRequestIDRetrieverMiddleware(stack *middleware.Stack, retrievers ...RequestIDRetriever) error {
+	return stack.Deserialize.Add(&RequestIDRetrieverMiddleware{
+		Retrievers: retrievers,
+	}, middleware.After)
+}
+
+// ID returns the middleware identifier.
+func (m *RequestIDRetrieverMiddleware) ID() string {
+	return "RequestIDRetriever"
+}
+
+// HandleDeserialize retrieves the request ID of the response returned by the
+// next handler.
+func (m *RequestIDRetrieverMiddleware) HandleDeserialize(
+	ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler,
+) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+
+	resp, ok := out.RawResponse.(*Response)
+	if !ok || resp == nil {
+		return out, metadata, err
+	}
+
+	var body []byte
+	if resp.StatusCode >= 300 && resp.Body != nil {
+		var readErr error
+		body, readErr = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return out, metadata, &smithy.DeserializationError{
+				Err: fmt.Errorf("failed to read error response body, %w", readErr),
+			}
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	for _, r := range m.Retrievers {
+		if id, ok := r.RetrieveRequestID(resp, body); ok {
+			middleware.SetRequestIDMetadata(&metadata, id)
+			break
+		}
+	}
+
+	return out, metadata, err
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestRequestIDRetrieverMiddleware(t *testing.T) {
+	retrievers := []RequestIDRetriever{
+		RequestIDFromHeader("X-Request-Id", "X-Amzn-RequestId"),
+		RequestIDFromJSONField("requestId"),
+	}
+
+	cases := map[string]struct {
+		StatusCode int
+		Header     http.Header
+		Body       string
+		ExpectID   string
+	}{
+		"header": {
+			StatusCode: 200,
+			Header:     http.Header{"X-Amzn-Requestid": []string{"abc123"}},
+			ExpectID:   "abc123",
+		},
+		"error body": {
+			StatusCode: 400,
+			Body:       `{"requestId":"def456"}`,
+			ExpectID:   "def456",
+		},
+		"success body not read": {
+			StatusCode: 200,
+			Body:       `{"requestId":"def456"}`,
+		},
+		"none": {
+			StatusCode: 500,
+			Body:       `not json`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			stack := middleware.NewStack("test", NewStackRequest)
+			err := AddErrorResponseRouterMiddleware(stack, NewErrorRegistry())
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if err := AddRequestIDRetrieverMiddleware(stack, retrievers...); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			header := c.Header
+			if header == nil {
+				header = http.Header{}
+			}
+			handler := middleware.DecorateHandler(middleware.HandlerFunc(
+				func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+					return &Response{Response: &http.Response{
+						StatusCode: c.StatusCode,
+						Header:     header,
+						Body:       ioutil.NopCloser(strings.NewReader(c.Body)),
+					}}, middleware.Metadata{}, nil
+				}), stack)
+
+			_, metadata, err := handler.Handle(context.Background(), struct{}{})
+
+			id, ok := smithy.GetMetadataRequestID(metadata)
+			if e, a := len(c.ExpectID) != 0, ok; e != a {
+				t.Fatalf("expect request ID %v, got %v", e, a)
+			}
+			if e, a := c.ExpectID, id; e != a {
+				t.Errorf("expect %q request ID, got %q", e, a)
+			}
+
+			if c.StatusCode < 300 {
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				return
+			}
+
+			errID, _ := smithy.GetRequestID(err)
+			if e, a := c.ExpectID, errID; e != a {
+				t.Errorf("expect %q error request ID, got %q", e, a)
+			}
+		})
+	}
+}
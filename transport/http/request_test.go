@@ -2,11 +2,16 @@ package http
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestRequestRewindable(t *testing.T) {
@@ -54,3 +59,198 @@ func TestRequestRewindable(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestStreamProducer(t *testing.T) {
+	req := NewStackRequest().(*Request)
+	var attempts int32
+	req = req.SetStreamProducer(func(ctx context.Context, w io.Writer) error {
+		atomic.AddInt32(&attempts, 1)
+		_, err := w.Write([]byte("hello"))
+		return err
+	})
+
+	if err := req.RewindStream(); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if _, ok, err := req.StreamLength(); err != nil || ok {
+		t.Errorf("expect unknown stream length, got %v, %v", ok, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		body, err := ioutil.ReadAll(req.Build(context.Background()).Body)
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		if e, a := "hello", string(body); e != a {
+			t.Errorf("expect %q body, got %q", e, a)
+		}
+	}
+	if e, a := int32(2), atomic.LoadInt32(&attempts); e != a {
+		t.Errorf("expect %v producer invocations, got %v", e, a)
+	}
+
+	rc, err := req.SetStream(strings.NewReader("world"))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if rc.GetStreamProducer() != nil {
+		t.Errorf("expect stream producer to be replaced")
+	}
+}
+
+func TestRequestStreamProducerClose(t *testing.T) {
+	cases := map[string]struct {
+		Cancel bool
+	}{
+		"body closed":      {},
+		"attempt canceled": {Cancel: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			produced := make(chan error, 1)
+			req := NewStackRequest().(*Request).SetStreamProducer(
+				func(ctx context.Context, w io.Writer) error {
+					for {
+						if _, err := w.Write([]byte("chunk")); err != nil {
+							produced <- err
+							return err
+						}
+					}
+				})
+
+			body := req.Build(ctx).Body
+			if _, err := body.Read(make([]byte, 5)); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if c.Cancel {
+				cancel()
+			}
+			body.Close()
+
+			select {
+			case err := <-produced:
+				if !errors.Is(err, io.ErrClosedPipe) {
+					t.Errorf("expect closed pipe error, got %v", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("expect producer to be unblocked")
+			}
+		})
+	}
+}
+
+func TestRequestDuplicateStream(t *testing.T) {
+	cases := map[string]struct {
+		Stream     func() io.Reader
+		ExpectOK   bool
+		ExpectBody string
+	}{
+		"seekable": {
+			Stream:     func() io.Reader { return bytes.NewReader([]byte("abc")) },
+			ExpectOK:   true,
+			ExpectBody: "abc",
+		},
+		"seekable start offset": {
+			Stream: func() io.Reader {
+				r := strings.NewReader("abcdef")
+				r.Seek(2, io.SeekStart)
+				return r
+			},
+			ExpectOK:   true,
+			ExpectBody: "cdef",
+		},
+		"buffered": {
+			Stream:     func() io.Reader { return bytes.NewBufferString("abc") },
+			ExpectOK:   true,
+			ExpectBody: "abc",
+		},
+		"unseekable": {
+			Stream: func() io.Reader { return ioutil.NopCloser(strings.NewReader("abc")) },
+		},
+		"nil stream": {
+			Stream: func() io.Reader { return nil },
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req, err := NewStackRequest().(*Request).SetStream(c.Stream())
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			dup, ok := req.DuplicateStream()
+			if e, a := c.ExpectOK, ok; e != a {
+				t.Fatalf("expect %v duplicated, got %v", e, a)
+			}
+			if !ok {
+				return
+			}
+
+			// Reading the duplicate does not consume the stream.
+			b, _ := ioutil.ReadAll(dup)
+			if e, a := c.ExpectBody, string(b); e != a {
+				t.Errorf("expect %q duplicate, got %q", e, a)
+			}
+			b, _ = ioutil.ReadAll(req.GetStream())
+			if e, a := c.ExpectBody, string(b); e != a {
+				t.Errorf("expect %q stream, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestRequestCloneStream(t *testing.T) {
+	req, err := NewStackRequest().(*Request).SetStream(strings.NewReader("abc"))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	clone := req.Clone()
+	b, _ := ioutil.ReadAll(clone.GetStream())
+	if e, a := "abc", string(b); e != a {
+		t.Errorf("expect %q clone stream, got %q", e, a)
+	}
+	if err := clone.RewindStream(); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	// The clone's reads are independent of the request's stream.
+	b, _ = ioutil.ReadAll(req.GetStream())
+	if e, a := "abc", string(b); e != a {
+		t.Errorf("expect %q stream, got %q", e, a)
+	}
+}
+
+func TestRequestBuildDuplicateBody(t *testing.T) {
+	req, err := NewStackRequest().(*Request).SetStream(bytes.NewBufferString("abc"))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	built := req.Build(context.Background())
+	b, _ := ioutil.ReadAll(built.Body)
+	if e, a := "abc", string(b); e != a {
+		t.Errorf("expect %q body, got %q", e, a)
+	}
+	if built.GetBody == nil {
+		t.Fatalf("expect GetBody to be set")
+	}
+	body, err := built.GetBody()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	b, _ = ioutil.ReadAll(body)
+	if e, a := "abc", string(b); e != a {
+		t.Errorf("expect %q GetBody, got %q", e, a)
+	}
+
+	// Sending the request does not consume the request's stream.
+	if e, a := 3, req.GetStream().(*bytes.Buffer).Len(); e != a {
+		t.Errorf("expect %v unread stream bytes, got %v", e, a)
+	}
+}
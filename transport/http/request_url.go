@@ -0,0 +1,56 @@
+package http
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/aws/smithy-go/encoding/httpbinding"
+)
+
+// SetHostPrefix prefixes the host of the request's URL, and of its Host
+// header override if set, with the prefix, such as the resolved host prefix
+// of an operation's endpoint trait. Returns an error if the prefixed host is
+// not a valid host, leaving the request unmodified.
+func (r *Request) SetHostPrefix(prefix string) error {
+	host := prefix + r.URL.Host
+	if err := ValidateEndpointHost(host); err != nil {
+		return fmt.Errorf("invalid host prefix %q, %w", prefix, err)
+	}
+
+	r.URL.Host = host
+	if len(r.Host) != 0 {
+		r.Host = prefix + r.Host
+	}
+	return nil
+}
+
+// AppendPathSegment appends the segment to the path of the request's URL,
+// separated by a '/'. The segment is escaped in the URL's RawPath, including
+// any '/', so the segment is a single element of the path. Path and RawPath
+// are kept consistent, preserving the escaping of the existing path.
+func (r *Request) AppendPathSegment(segment string) {
+	if len(segment) == 0 {
+		return
+	}
+
+	rawPath := JoinPath(r.URL.EscapedPath(), "/"+httpbinding.EscapePath(segment, true))
+	r.URL.Path = JoinPath(r.URL.Path, "/"+segment)
+	r.URL.RawPath = rawPath
+}
+
+// SetQueryValues sets the values of the keys in the request's query string,
+// replacing the keys' existing values. The values of other keys are kept.
+// The query string is encoded again, sorted by key. Returns an error if the
+// request's query string is not valid, leaving the request unmodified.
+func (r *Request) SetQueryValues(values url.Values) error {
+	query, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		return fmt.Errorf("invalid request query string, %w", err)
+	}
+
+	for k, vs := range values {
+		query[k] = append([]string(nil), vs...)
+	}
+	r.URL.RawQuery = query.Encode()
+	return nil
+}
@@ -0,0 +1,153 @@
+package http
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestRequestSetHostPrefix(t *testing.T) {
+	cases := map[string]struct {
+		Host       string
+		HostHeader string
+		Prefix     string
+		ExpectHost string
+		ExpectHdr  string
+		ExpectErr  bool
+	}{
+		"prefix": {
+			Host:       "example.com",
+			Prefix:     "data.",
+			ExpectHost: "data.example.com",
+		},
+		"prefix with port": {
+			Host:       "example.com:8443",
+			Prefix:     "123456789012.",
+			ExpectHost: "123456789012.example.com:8443",
+		},
+		"host header": {
+			Host:       "example.com",
+			HostHeader: "alias.example.com",
+			Prefix:     "data-",
+			ExpectHost: "data-example.com",
+			ExpectHdr:  "data-alias.example.com",
+		},
+		"invalid": {
+			Host:       "example.com",
+			Prefix:     "bad_label.",
+			ExpectHost: "example.com",
+			ExpectErr:  true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := NewStackRequest().(*Request)
+			req.URL.Host = c.Host
+			req.Host = c.HostHeader
+
+			err := req.SetHostPrefix(c.Prefix)
+			if c.ExpectErr != (err != nil) {
+				t.Fatalf("expect error %v, got %v", c.ExpectErr, err)
+			}
+			if e, a := c.ExpectHost, req.URL.Host; e != a {
+				t.Errorf("expect %v host, got %v", e, a)
+			}
+			if e, a := c.ExpectHdr, req.Host; e != a {
+				t.Errorf("expect %v host header, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestRequestAppendPathSegment(t *testing.T) {
+	cases := map[string]struct {
+		Path          string
+		RawPath       string
+		Segments      []string
+		ExpectPath    string
+		ExpectEscaped string
+	}{
+		"empty path": {
+			Segments:      []string{"bucket", "key"},
+			ExpectPath:    "/bucket/key",
+			ExpectEscaped: "/bucket/key",
+		},
+		"escaped segment": {
+			Path:          "/prefix/",
+			Segments:      []string{"a b/c?d"},
+			ExpectPath:    "/prefix/a b/c?d",
+			ExpectEscaped: "/prefix/a%20b%2Fc%3Fd",
+		},
+		"existing raw path": {
+			Path:          "/a/b",
+			RawPath:       "/a%2Fb",
+			Segments:      []string{"c"},
+			ExpectPath:    "/a/b/c",
+			ExpectEscaped: "/a%2Fb/c",
+		},
+		"empty segment": {
+			Path:          "/a",
+			Segments:      []string{""},
+			ExpectPath:    "/a",
+			ExpectEscaped: "/a",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := NewStackRequest().(*Request)
+			req.URL.Path = c.Path
+			req.URL.RawPath = c.RawPath
+
+			for _, segment := range c.Segments {
+				req.AppendPathSegment(segment)
+			}
+			if e, a := c.ExpectPath, req.URL.Path; e != a {
+				t.Errorf("expect %q path, got %q", e, a)
+			}
+			if e, a := c.ExpectEscaped, req.URL.EscapedPath(); e != a {
+				t.Errorf("expect %q escaped path, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestRequestSetQueryValues(t *testing.T) {
+	cases := map[string]struct {
+		Query     string
+		Values    url.Values
+		Expect    string
+		ExpectErr bool
+	}{
+		"empty": {
+			Values: url.Values{"a": {"1 2"}},
+			Expect: "a=1+2",
+		},
+		"replace": {
+			Query:  "b=1&a=1&a=2",
+			Values: url.Values{"a": {"3"}, "c": {"x", "y"}},
+			Expect: "a=3&b=1&c=x&c=y",
+		},
+		"invalid": {
+			Query:     "a=%zz",
+			Values:    url.Values{"a": {"1"}},
+			Expect:    "a=%zz",
+			ExpectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := NewStackRequest().(*Request)
+			req.URL.RawQuery = c.Query
+
+			err := req.SetQueryValues(c.Values)
+			if c.ExpectErr != (err != nil) {
+				t.Fatalf("expect error %v, got %v", c.ExpectErr, err)
+			}
+			if e, a := c.Expect, req.URL.RawQuery; e != a {
+				t.Errorf("expect %q query, got %q", e, a)
+			}
+		})
+	}
+}
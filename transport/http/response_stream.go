@@ -0,0 +1,208 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// ResponseStreamIdleTimeoutError is returned by a ResponseStream read when no
+// data is received from the response body for longer than the idle timeout.
+type ResponseStreamIdleTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *ResponseStreamIdleTimeoutError) Error() string {
+	return fmt.Sprintf("response body read idle for more than %v", e.Timeout)
+}
+
+// ResponseStreamOptions provides the configuration of a ResponseStream.
+type ResponseStreamOptions struct {
+	// IdleTimeout is the maximum duration a read of the response body may
+	// block without receiving data, before the stream is aborted. Zero means
+	// no idle timeout.
+	IdleTimeout time.Duration
+}
+
+// ResponseStream is a managed response body. Reads return an error instead
+// of blocking indefinitely, when the stream's context is canceled or the
+// body is idle for longer than the idle timeout. The underlying body is
+// closed when the stream is aborted, unblocking any in-flight read.
+//
+// ResponseStream is safe to Close or Abort concurrently with Read, but
+// concurrent reads are not supported.
+type ResponseStream struct {
+	body        io.ReadCloser
+	idleTimeout time.Duration
+
+	mu     sync.Mutex
+	err    error
+	closed bool
+	timer  *time.Timer
+	stop   func()
+}
+
+// NewResponseStream returns a ResponseStream for the body. The stream is
+// aborted when ctx is canceled.
+func NewResponseStream(ctx context.Context, body io.ReadCloser, optFns ...func(*ResponseStreamOptions)) *ResponseStream {
+	var o ResponseStreamOptions
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	s := &ResponseStream{
+		body:        body,
+		idleTimeout: o.IdleTimeout,
+	}
+	s.stop = afterContextDone(ctx, func() {
+		s.Abort(ctx.Err())
+	})
+	return s
+}
+
+// afterContextDone calls fn in its own goroutine after ctx is done. Returns
+// a function which stops fn from being called, if it has not been called
+// already.
+func afterContextDone(ctx context.Context, fn func()) func() {
+	if ctx.Done() == nil {
+		return func() {}
+	}
+
+	stopCh := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			fn()
+		case <-stopCh:
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stopCh) })
+	}
+}
+
+// Read reads from the response body. Returns the error the stream was
+// aborted with, if the stream was aborted.
+func (s *ResponseStream) Read(p []byte) (int, error) {
+	if err := s.startRead(); err != nil {
+		return 0, err
+	}
+
+	n, err := s.body.Read(p)
+	if err == io.EOF {
+		s.stop()
+	}
+
+	if abortErr := s.endRead(); abortErr != nil && err != nil && err != io.EOF {
+		err = abortErr
+	}
+	return n, err
+}
+
+func (s *ResponseStream) startRead() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	if s.idleTimeout > 0 {
+		if s.timer == nil {
+			s.timer = time.AfterFunc(s.idleTimeout, func() {
+				s.Abort(&ResponseStreamIdleTimeoutError{Timeout: s.idleTimeout})
+			})
+		} else {
+			s.timer.Reset(s.idleTimeout)
+		}
+	}
+	return nil
+}
+
+func (s *ResponseStream) endRead() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	return s.err
+}
+
+// Abort closes the underlying response body, so that in-flight and future
+// reads return err. Has no effect if the stream was already closed or
+// aborted.
+func (s *ResponseStream) Abort(err error) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	s.err = err
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.mu.Unlock()
+
+	s.stop()
+	s.body.Close()
+}
+
+// Err returns the error the stream was aborted with, if any.
+func (s *ResponseStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close closes the underlying response body.
+func (s *ResponseStream) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.err = io.ErrClosedPipe
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.mu.Unlock()
+
+	s.stop()
+	return s.body.Close()
+}
+
+// AddResponseStreamMiddleware adds the middleware which replaces the HTTP
+// response body with a ResponseStream bound to the operation's context. The
+// middleware is added to the end of the Deserialize step, so the response
+// body is wrapped before it is deserialized.
+func AddResponseStreamMiddleware(stack *middleware.Stack, optFns ...func(*ResponseStreamOptions)) error {
+	return stack.Deserialize.Add(&responseStream{optFns: optFns}, middleware.After)
+}
+
+type responseStream struct {
+	optFns []func(*ResponseStreamOptions)
+}
+
+func (*responseStream) ID() string { return "ResponseStream" }
+
+func (m *responseStream) HandleDeserialize(
+	ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler,
+) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+
+	if resp, ok := out.RawResponse.(*Response); ok && resp.Body != nil {
+		resp.Body = NewResponseStream(ctx, resp.Body, m.optFns...)
+	}
+
+	return out, metadata, err
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestResponseStream_Read(t *testing.T) {
+	s := NewResponseStream(context.Background(), ioutil.NopCloser(strings.NewReader("hello world")),
+		func(o *ResponseStreamOptions) {
+			o.IdleTimeout = time.Second
+		})
+
+	b, err := ioutil.ReadAll(s)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "hello world", string(b); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if _, err := s.Read(make([]byte, 1)); err == nil {
+		t.Errorf("expect error reading closed stream")
+	}
+}
+
+func TestResponseStream_ContextCanceled(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewResponseStream(ctx, pr)
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := s.Read(make([]byte, 10))
+		errCh <- err
+	}()
+
+	cancel()
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expect canceled error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expect read to be aborted")
+	}
+
+	if !errors.Is(s.Err(), context.Canceled) {
+		t.Errorf("expect canceled stream error, got %v", s.Err())
+	}
+}
+
+func TestResponseStream_IdleTimeout(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	s := NewResponseStream(context.Background(), pr, func(o *ResponseStreamOptions) {
+		o.IdleTimeout = 10 * time.Millisecond
+	})
+
+	go pw.Write([]byte("abc"))
+	p := make([]byte, 3)
+	if _, err := io.ReadFull(s, p); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	_, err := s.Read(p)
+	var timeoutErr *ResponseStreamIdleTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expect idle timeout error, got %v", err)
+	}
+	if e, a := 10*time.Millisecond, timeoutErr.Timeout; e != a {
+		t.Errorf("expect %v timeout, got %v", e, a)
+	}
+}
+
+func TestAddResponseStreamMiddleware(t *testing.T) {
+	stack := middleware.NewStack("test", NewStackRequest)
+	if err := AddResponseStreamMiddleware(stack); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var body io.ReadCloser
+	stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("deserialize",
+		func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+			middleware.DeserializeOutput, middleware.Metadata, error,
+		) {
+			out, metadata, err := next.HandleDeserialize(ctx, in)
+			body = out.RawResponse.(*Response).Body
+			return out, metadata, err
+		}), middleware.Before)
+
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+			return &Response{Response: &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader("abc")),
+			}}, middleware.Metadata{}, nil
+		}), stack)
+
+	if _, _, err := handler.Handle(context.Background(), struct{}{}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if _, ok := body.(*ResponseStream); !ok {
+		t.Fatalf("expect response stream body, got %T", body)
+	}
+	b, _ := ioutil.ReadAll(body)
+	if e, a := "abc", string(b); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
@@ -0,0 +1,23 @@
+// Package server provides the runtime for implementing Smithy services over
+// HTTP.
+//
+// A Router dispatches requests to the Operation whose Smithy HTTP binding,
+// method and URI pattern, matches the request. URI patterns support labels,
+// greedy labels, and query string literals, e.g.
+//
+//	/{Bucket}/{Key+}?x-id=GetObject
+//
+// Each Operation handles its request with a middleware Stack whose steps
+// mirror the client stack:
+//
+//	Deserialize -> Validate -> Handle
+//
+// with the operation's output, or error, serialized into the HTTP response
+// by the Serialize step wrapping them.
+//
+// Handlers return typed errors. NewErrorSerializer maps them to protocol
+// compliant error responses with ErrorMappers, falling back to
+// DefaultErrorMapper, which selects the status code of modeled errors from
+// their httpError, or error, trait, and reports input validation failures
+// as a ValidationException listing the violated constraints.
+package server
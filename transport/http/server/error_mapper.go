@@ -0,0 +1,227 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aws/smithy-go"
+)
+
+// Error codes of the errors mapped by DefaultErrorMapper which are not
+// modeled errors of the operation.
+const (
+	ValidationErrorCode    = "ValidationException"
+	SerializationErrorCode = "SerializationException"
+	InternalFailureCode    = "InternalFailure"
+)
+
+// ErrorResponse is the protocol independent description of the response an
+// error is serialized into. An ErrorResponseSerializer serializes it with the
+// protocol of the service.
+type ErrorResponse struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Code is the error code, the name of the error's shape.
+	Code string
+
+	// Message is the error's message. Empty if the message must not be
+	// exposed, such as for internal errors.
+	Message string
+
+	// Fault is whether the error is the fault of the client, or the server.
+	Fault smithy.ErrorFault
+
+	// Violations are the constraint violations of the operation input, for
+	// validation errors.
+	Violations []ValidationViolation
+
+	// Body, if set, is serialized as the response body instead of the
+	// Message, and Violations, e.g. the modeled members of the error.
+	Body interface{}
+}
+
+// ValidationViolation is a violation of a constraint of the operation input.
+type ValidationViolation struct {
+	// Path is the JSON pointer of the member which violated the constraint,
+	// e.g. "/Items/0/Name".
+	Path string `json:"path"`
+
+	// Message describes the violation.
+	Message string `json:"message"`
+}
+
+// ErrorMapper maps the error returned by an operation's handler, or its
+// middleware, to the ErrorResponse it is serialized into. Returns false if
+// the mapper does not handle the error.
+type ErrorMapper interface {
+	MapError(ctx context.Context, err error) (ErrorResponse, bool)
+}
+
+// ErrorMapperFunc is a function type that implements the ErrorMapper
+// interface.
+type ErrorMapperFunc func(ctx context.Context, err error) (ErrorResponse, bool)
+
+// MapError delegates to the wrapped function.
+func (fn ErrorMapperFunc) MapError(ctx context.Context, err error) (ErrorResponse, bool) {
+	return fn(ctx, err)
+}
+
+// DefaultErrorMapper maps the error to its ErrorResponse:
+//
+//   - smithy.InvalidParamsError, returned by input validation, to a 400
+//     ValidationException with the violations of the input.
+//   - smithy.DeserializationError to a 400 SerializationException.
+//   - smithy.APIError, modeled errors, to the error's code, message, and
+//     fault, with the status code of ErrorStatusCode, derived from the
+//     error's httpError, or error, trait.
+//   - All other errors, unmodeled errors, to an InternalFailure with the
+//     status code of ErrorStatusCode, 500, without the message of the error,
+//     so internal details are not exposed, even if the error reports a client
+//     error status code. DefaultErrorSerializer serializes these errors with
+//     the same status code.
+func DefaultErrorMapper(ctx context.Context, err error) ErrorResponse {
+	var paramsErr smithy.InvalidParamsError
+	if errors.As(err, &paramsErr) {
+		return ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Code:       ValidationErrorCode,
+			Message:    fmt.Sprintf("%d validation error(s) found", paramsErr.Len()),
+			Fault:      smithy.FaultClient,
+			Violations: validationViolations(paramsErr),
+		}
+	}
+
+	var deserializeErr *smithy.DeserializationError
+	if errors.As(err, &deserializeErr) {
+		return ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Code:       SerializationErrorCode,
+			Message:    deserializeErr.Error(),
+			Fault:      smithy.FaultClient,
+		}
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		status := ErrorStatusCode(err)
+		resp := ErrorResponse{
+			StatusCode: status,
+			Code:       apiErr.ErrorCode(),
+			Message:    apiErr.ErrorMessage(),
+			Fault:      apiErr.ErrorFault(),
+		}
+		if resp.Fault == smithy.FaultUnknown {
+			resp.Fault = smithy.FaultServer
+			if status < 500 {
+				resp.Fault = smithy.FaultClient
+			}
+		}
+		return resp
+	}
+
+	return ErrorResponse{
+		StatusCode: ErrorStatusCode(err),
+		Code:       InternalFailureCode,
+		Fault:      smithy.FaultServer,
+	}
+}
+
+// validationViolations returns the violations of the invalid parameters,
+// with their field paths converted to JSON pointers relative to the input.
+func validationViolations(errs smithy.InvalidParamsError) []ValidationViolation {
+	var violations []ValidationViolation
+	for _, err := range errs.Errs() {
+		path := err.Error()
+		if v, ok := err.(smithy.InvalidParamError); ok {
+			path = fieldPointer(errs.Context, v.Field())
+		}
+		violations = append(violations, ValidationViolation{
+			Path:    path,
+			Message: err.Error(),
+		})
+	}
+	return violations
+}
+
+func fieldPointer(context, field string) string {
+	if len(context) != 0 {
+		field = strings.TrimPrefix(field, context)
+	}
+	field = strings.TrimPrefix(field, ".")
+
+	var sb strings.Builder
+	for _, part := range strings.FieldsFunc(field, func(r rune) bool {
+		return r == '.' || r == '[' || r == ']'
+	}) {
+		sb.WriteByte('/')
+		sb.WriteString(part)
+	}
+	return sb.String()
+}
+
+// ErrorResponseSerializer serializes the ErrorResponse into the HTTP
+// response, with the protocol of the service.
+type ErrorResponseSerializer func(ctx context.Context, er ErrorResponse, resp *Response) error
+
+// JSONErrorResponseSerializer serializes the ErrorResponse as a JSON body,
+// with the error code in the X-Amzn-Errortype header, for the JSON based
+// protocols. The body is the ErrorResponse's Body, if set, or its message,
+// and violations, e.g.
+//
+//	{"message": "1 validation error(s) found", "fieldList": [{"path": "/Name", "message": "..."}]}
+func JSONErrorResponseSerializer(ctx context.Context, er ErrorResponse, resp *Response) error {
+	body := er.Body
+	if body == nil {
+		body = struct {
+			Message    string                `json:"message,omitempty"`
+			Violations []ValidationViolation `json:"fieldList,omitempty"`
+		}{
+			Message:    er.Message,
+			Violations: er.Violations,
+		}
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to serialize error response body, %w", err)
+	}
+
+	resp.StatusCode = er.StatusCode
+	resp.Header.Set("Content-Type", "application/json")
+	if len(er.Code) != 0 {
+		resp.Header.Set("X-Amzn-Errortype", er.Code)
+	}
+	resp.Body = strings.NewReader(string(b))
+	return nil
+}
+
+// NewErrorSerializer returns an ErrorSerializer which maps errors to their
+// ErrorResponse with the first of the mappers which handles the error, or
+// DefaultErrorMapper, and serializes the response with the serializer. Set
+// as the Operation's ErrorSerializer, so operation handlers only return
+// typed errors.
+//
+// If the serializer fails, DefaultErrorSerializer serializes the error.
+func NewErrorSerializer(serializer ErrorResponseSerializer, mappers ...ErrorMapper) ErrorSerializer {
+	return func(ctx context.Context, err error, resp *Response) {
+		er, ok := ErrorResponse{}, false
+		for _, m := range mappers {
+			if er, ok = m.MapError(ctx, err); ok {
+				break
+			}
+		}
+		if !ok {
+			er = DefaultErrorMapper(ctx, err)
+		}
+
+		if serr := serializer(ctx, er, resp); serr != nil {
+			*resp = *NewResponse()
+			DefaultErrorSerializer(ctx, &smithy.SerializationError{Err: serr}, resp)
+		}
+	}
+}
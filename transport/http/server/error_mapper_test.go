@@ -0,0 +1,237 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+type notFoundError struct {
+	Resource string
+}
+
+func (e *notFoundError) Error() string                 { return "resource not found: " + e.Resource }
+func (e *notFoundError) ErrorCode() string             { return "ResourceNotFoundException" }
+func (e *notFoundError) ErrorMessage() string          { return "resource not found: " + e.Resource }
+func (e *notFoundError) ErrorFault() smithy.ErrorFault { return smithy.FaultClient }
+func (e *notFoundError) HTTPStatusCode() int           { return http.StatusNotFound }
+
+func TestDefaultErrorMapper(t *testing.T) {
+	invalidParams := smithy.InvalidParamsError{Context: "GetFooInput"}
+	invalidParams.Add(smithy.NewErrParamRequired("ID"))
+	nested := smithy.InvalidParamsError{Context: "Item"}
+	nested.Add(smithy.NewErrParamConstraint("Name", "length", "minimum field size of 1"))
+	invalidParams.AddNested("Items[0]", nested)
+
+	cases := map[string]struct {
+		Err    error
+		Expect ErrorResponse
+	}{
+		"validation": {
+			Err: invalidParams,
+			Expect: ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Code:       ValidationErrorCode,
+				Message:    "2 validation error(s) found",
+				Fault:      smithy.FaultClient,
+				Violations: []ValidationViolation{
+					{Path: "/ID", Message: "missing required field, GetFooInput.ID."},
+					{Path: "/Items/0/Name", Message: "minimum field size of 1, GetFooInput.Items[0].Name."},
+				},
+			},
+		},
+		"deserialization": {
+			Err: &smithy.DeserializationError{Err: fmt.Errorf("unexpected EOF")},
+			Expect: ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Code:       SerializationErrorCode,
+				Message:    "deserialization failed, unexpected EOF",
+				Fault:      smithy.FaultClient,
+			},
+		},
+		"modeled error": {
+			Err: fmt.Errorf("wrapped, %w", &notFoundError{Resource: "foo"}),
+			Expect: ErrorResponse{
+				StatusCode: http.StatusNotFound,
+				Code:       "ResourceNotFoundException",
+				Message:    "resource not found: foo",
+				Fault:      smithy.FaultClient,
+			},
+		},
+		"modeled server error": {
+			Err: &smithy.GenericAPIError{Code: "ServiceUnavailable", Message: "try again", Fault: smithy.FaultServer},
+			Expect: ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				Code:       "ServiceUnavailable",
+				Message:    "try again",
+				Fault:      smithy.FaultServer,
+			},
+		},
+		"unmodeled client error": {
+			Err: &statusError{status: http.StatusForbidden, msg: "policy db row 42 denied"},
+			Expect: ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				Code:       InternalFailureCode,
+				Fault:      smithy.FaultServer,
+			},
+		},
+		"unmodeled error": {
+			Err: fmt.Errorf("database password is hunter2"),
+			Expect: ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				Code:       InternalFailureCode,
+				Fault:      smithy.FaultServer,
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual := DefaultErrorMapper(context.Background(), c.Err)
+			if !reflect.DeepEqual(c.Expect, actual) {
+				t.Errorf("expect %+v, got %+v", c.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestNewErrorSerializer(t *testing.T) {
+	errTeapot := errors.New("teapot")
+
+	cases := map[string]struct {
+		Input        string
+		Mappers      []ErrorMapper
+		ExpectStatus int
+		ExpectType   string
+		ExpectBody   string
+	}{
+		"validation": {
+			Input:        "toolong",
+			ExpectStatus: http.StatusBadRequest,
+			ExpectType:   ValidationErrorCode,
+			ExpectBody:   `{"message":"1 validation error(s) found","fieldList":[{"path":"/ID","message":"length 7 must be at most 5, getFooInput.ID."}]}`,
+		},
+		"handler error": {
+			Input:        "fail",
+			ExpectStatus: http.StatusInternalServerError,
+			ExpectType:   InternalFailureCode,
+			ExpectBody:   `{}`,
+		},
+		"custom mapper": {
+			Input: "fail",
+			Mappers: []ErrorMapper{
+				ErrorMapperFunc(func(ctx context.Context, err error) (ErrorResponse, bool) {
+					return ErrorResponse{}, false
+				}),
+				ErrorMapperFunc(func(ctx context.Context, err error) (ErrorResponse, bool) {
+					return ErrorResponse{
+						StatusCode: http.StatusTeapot,
+						Code:       "TeapotException",
+						Body:       map[string]string{"reason": errTeapot.Error()},
+					}, true
+				}),
+			},
+			ExpectStatus: http.StatusTeapot,
+			ExpectType:   "TeapotException",
+			ExpectBody:   `{"reason":"teapot"}`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			op := newGetFooOperation(t)
+			op.ErrorSerializer = NewErrorSerializer(JSONErrorResponseSerializer, c.Mappers...)
+
+			rec := httptest.NewRecorder()
+			op.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil).WithContext(
+				withLabels(context.Background(), map[string]string{"ID": c.Input})))
+
+			if e, a := c.ExpectStatus, rec.Code; e != a {
+				t.Errorf("expect %v status, got %v", e, a)
+			}
+			if e, a := c.ExpectType, rec.Header().Get("X-Amzn-Errortype"); e != a {
+				t.Errorf("expect %v error type, got %v", e, a)
+			}
+			if e, a := "application/json", rec.Header().Get("Content-Type"); e != a {
+				t.Errorf("expect %v content type, got %v", e, a)
+			}
+			body, _ := ioutil.ReadAll(rec.Body)
+			if e, a := c.ExpectBody, string(body); e != a {
+				t.Errorf("expect %v body, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestNewErrorSerializer_SerializerFailure(t *testing.T) {
+	serializer := NewErrorSerializer(func(ctx context.Context, er ErrorResponse, resp *Response) error {
+		resp.Header.Set("X-Partial", "true")
+		return fmt.Errorf("serializer failed")
+	})
+
+	resp := NewResponse()
+	serializer(context.Background(), &notFoundError{Resource: "foo"}, resp)
+
+	if e, a := http.StatusInternalServerError, resp.StatusCode; e != a {
+		t.Errorf("expect %v status, got %v", e, a)
+	}
+	if v := resp.Header.Get("X-Partial"); len(v) != 0 {
+		t.Errorf("expect partial response discarded, got header %v", v)
+	}
+}
+
+func TestErrorSerializers_SameStatus(t *testing.T) {
+	cases := map[string]struct {
+		Err          error
+		ExpectStatus int
+		SecretMsg    string
+	}{
+		"unmodeled error": {
+			Err:          fmt.Errorf("database password is hunter2"),
+			ExpectStatus: http.StatusInternalServerError,
+			SecretMsg:    "hunter2",
+		},
+		"unmodeled client error": {
+			Err:          &statusError{status: http.StatusForbidden, msg: "policy db row 42 denied"},
+			ExpectStatus: http.StatusInternalServerError,
+			SecretMsg:    "row 42",
+		},
+		"modeled client error": {
+			Err:          &notFoundError{Resource: "foo"},
+			ExpectStatus: http.StatusNotFound,
+		},
+	}
+
+	serializers := map[string]ErrorSerializer{
+		"default": DefaultErrorSerializer,
+		"mapper":  NewErrorSerializer(JSONErrorResponseSerializer),
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			for sname, serialize := range serializers {
+				resp := NewResponse()
+				serialize(context.Background(), c.Err, resp)
+
+				if e, a := c.ExpectStatus, resp.StatusCode; e != a {
+					t.Errorf("%s: expect %v status, got %v", sname, e, a)
+				}
+				if len(c.SecretMsg) == 0 {
+					continue
+				}
+				body, _ := ioutil.ReadAll(resp.Body)
+				if a := string(body); strings.Contains(a, c.SecretMsg) {
+					t.Errorf("%s: expect error message not exposed, got %q", sname, a)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/validation"
+)
+
+// RequestDeserializer deserializes the HTTP request into the operation's
+// input.
+type RequestDeserializer func(ctx context.Context, req *Request) (input interface{}, err error)
+
+// ResponseSerializer serializes the operation's output into the HTTP
+// response.
+type ResponseSerializer func(ctx context.Context, output interface{}, resp *Response) error
+
+// ErrorSerializer serializes an error returned by the operation, or its
+// middleware, into the HTTP response.
+type ErrorSerializer func(ctx context.Context, err error, resp *Response)
+
+// HandlerFunc is the implementation of an operation.
+type HandlerFunc func(ctx context.Context, input interface{}) (output interface{}, err error)
+
+// Operation is an http.Handler for a single Smithy operation.
+type Operation struct {
+	// Name is the name of the operation.
+	Name string
+
+	// Stack is the middleware stack the operation's requests are handled
+	// with.
+	Stack *Stack
+
+	// Deserializer deserializes the request into the operation input.
+	Deserializer RequestDeserializer
+
+	// Handler is the operation's implementation.
+	Handler HandlerFunc
+
+	// Serializer serializes the operation output into the response.
+	Serializer ResponseSerializer
+
+	// ErrorSerializer serializes errors into the response. If nil,
+	// DefaultErrorSerializer is used.
+	ErrorSerializer ErrorSerializer
+}
+
+// NewOperation returns an Operation with a Stack whose Validate step
+// validates operation input implementing validation.Validator.
+func NewOperation(name string, deserializer RequestDeserializer, handler HandlerFunc, serializer ResponseSerializer) (
+	*Operation, error,
+) {
+	stack := NewStack()
+	if err := stack.Validate.Add(inputValidation{}, middleware.After); err != nil {
+		return nil, err
+	}
+
+	return &Operation{
+		Name:         name,
+		Stack:        stack,
+		Deserializer: deserializer,
+		Handler:      handler,
+		Serializer:   serializer,
+	}, nil
+}
+
+// ServeHTTP handles the request with the operation's stack, and writes the
+// serialized response.
+func (o *Operation) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := &Request{Request: r, Labels: GetLabels(r.Context())}
+
+	out, _, err := o.Stack.Serialize.HandleMiddleware(r.Context(), req, middleware.HandlerFunc(o.serialize))
+	resp, ok := out.(*Response)
+	if err != nil || !ok {
+		if err == nil {
+			err = fmt.Errorf("unexpected serialize step result, %T", out)
+		}
+		resp = NewResponse()
+		o.serializeError(r.Context(), err, resp)
+	}
+
+	resp.Write(w)
+}
+
+// serialize is the terminal handler of the Serialize step.
+func (o *Operation) serialize(ctx context.Context, in interface{}) (
+	out interface{}, metadata middleware.Metadata, err error,
+) {
+	output, metadata, err := o.Stack.Deserialize.HandleMiddleware(ctx, in, middleware.HandlerFunc(o.deserialize))
+
+	resp := NewResponse()
+	if err != nil {
+		o.serializeError(ctx, err, resp)
+		return resp, metadata, nil
+	}
+
+	if o.Serializer != nil {
+		if err := o.Serializer(ctx, output, resp); err != nil {
+			resp = NewResponse()
+			o.serializeError(ctx, &smithy.SerializationError{Err: err}, resp)
+		}
+	}
+	return resp, metadata, nil
+}
+
+// deserialize is the terminal handler of the Deserialize step.
+func (o *Operation) deserialize(ctx context.Context, in interface{}) (
+	out interface{}, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.(*Request)
+	if !ok {
+		return nil, metadata, fmt.Errorf("unexpected deserialize step input, %T", in)
+	}
+
+	var input interface{}
+	if o.Deserializer != nil {
+		input, err = o.Deserializer(ctx, req)
+		if err != nil {
+			return nil, metadata, &smithy.DeserializationError{Err: err}
+		}
+	}
+
+	return o.Stack.Validate.HandleMiddleware(ctx, input, middleware.HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+			return o.Stack.Handle.HandleMiddleware(ctx, input, middleware.HandlerFunc(o.handle))
+		}))
+}
+
+// handle is the terminal handler of the Handle step.
+func (o *Operation) handle(ctx context.Context, in interface{}) (
+	out interface{}, metadata middleware.Metadata, err error,
+) {
+	if o.Handler == nil {
+		return nil, metadata, fmt.Errorf("operation %s not implemented", o.Name)
+	}
+	out, err = o.Handler(ctx, in)
+	return out, metadata, err
+}
+
+func (o *Operation) serializeError(ctx context.Context, err error, resp *Response) {
+	fn := o.ErrorSerializer
+	if fn == nil {
+		fn = DefaultErrorSerializer
+	}
+	fn(ctx, err, resp)
+}
+
+// ErrorStatusCode returns the HTTP status code for an error. Invalid
+// parameter, and deserialization errors result in 400 Bad Request. Modeled
+// errors, smithy.APIError, implementing an HTTPStatusCode method use the
+// status code returned, otherwise client fault errors result in 400 Bad
+// Request. All other errors, including unmodeled errors reporting a status
+// code or fault, result in 500 Internal Server Error.
+func ErrorStatusCode(err error) int {
+	var paramsErr smithy.InvalidParamsError
+	var deserializeErr *smithy.DeserializationError
+	if errors.As(err, &paramsErr) || errors.As(err, &deserializeErr) {
+		return http.StatusBadRequest
+	}
+
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return http.StatusInternalServerError
+	}
+	if v, ok := apiErr.(interface{ HTTPStatusCode() int }); ok {
+		return v.HTTPStatusCode()
+	}
+	if apiErr.ErrorFault() == smithy.FaultClient {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// DefaultErrorSerializer serializes the error as a plain text response with
+// the status code returned by ErrorStatusCode. The error's message is only
+// included for client errors which are modeled errors, smithy.APIError, or
+// input validation and deserialization errors, so internal details of other
+// errors are not exposed.
+func DefaultErrorSerializer(ctx context.Context, err error, resp *Response) {
+	resp.StatusCode = ErrorStatusCode(err)
+	resp.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	msg := http.StatusText(resp.StatusCode)
+	if resp.StatusCode < 500 && isClientErrorMessage(err) {
+		msg = err.Error()
+	}
+	if code, ok := smithy.GetErrorCode(err); ok {
+		resp.Header.Set("X-Smithy-Error-Code", code)
+	}
+
+	resp.Body = strings.NewReader(msg)
+}
+
+// isClientErrorMessage returns whether the error's message may be returned to
+// the client, for modeled errors, and errors describing the client's input.
+func isClientErrorMessage(err error) bool {
+	var apiErr smithy.APIError
+	var paramsErr smithy.InvalidParamsError
+	var deserializeErr *smithy.DeserializationError
+	return errors.As(err, &apiErr) || errors.As(err, &paramsErr) || errors.As(err, &deserializeErr)
+}
+
+// inputValidation validates operation input implementing
+// validation.Validator.
+type inputValidation struct{}
+
+func (inputValidation) ID() string { return "OperationInputValidation" }
+
+func (inputValidation) HandleMiddleware(ctx context.Context, input interface{}, next middleware.Handler) (
+	out interface{}, metadata middleware.Metadata, err error,
+) {
+	v, ok := input.(validation.Validator)
+	if !ok {
+		return next.Handle(ctx, input)
+	}
+
+	errs := smithy.InvalidParamsError{Context: fmt.Sprintf("%T", input)}
+	if i := strings.LastIndexByte(errs.Context, '.'); i != -1 {
+		errs.Context = errs.Context[i+1:]
+	}
+	v.Validate(&errs)
+	if errs.Len() != 0 {
+		return nil, metadata, errs
+	}
+
+	return next.Handle(ctx, input)
+}
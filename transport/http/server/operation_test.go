@@ -0,0 +1,234 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/aws/smithy-go/validation"
+)
+
+type getFooInput struct {
+	ID   string
+	Tags []string
+}
+
+func (v *getFooInput) Validate(errs *smithy.InvalidParamsError) {
+	validation.Check(errs, "ID", v.ID, validation.StringLength[string](nil, ptr.Int64(5)))
+}
+
+type getFooOutput struct {
+	Message string
+}
+
+type statusError struct {
+	status int
+	msg    string
+}
+
+func (e *statusError) Error() string       { return e.msg }
+func (e *statusError) HTTPStatusCode() int { return e.status }
+
+func newGetFooOperation(t *testing.T) *Operation {
+	op, err := NewOperation("GetFoo",
+		func(ctx context.Context, req *Request) (interface{}, error) {
+			id, _ := req.Label("ID")
+			tags, err := req.HeaderList("X-Tags")
+			if err != nil {
+				return nil, err
+			}
+			return &getFooInput{ID: id, Tags: tags}, nil
+		},
+		func(ctx context.Context, input interface{}) (interface{}, error) {
+			in := input.(*getFooInput)
+			if in.ID == "fail" {
+				return nil, fmt.Errorf("internal failure")
+			}
+			if in.ID == "deny" {
+				return nil, &statusError{status: http.StatusForbidden, msg: "policy db row 42 denied"}
+			}
+			return &getFooOutput{Message: in.ID + ":" + strings.Join(in.Tags, "|")}, nil
+		},
+		func(ctx context.Context, output interface{}, resp *Response) error {
+			resp.SetHeader("X-Message").String(output.(*getFooOutput).Message)
+			resp.StatusCode = http.StatusAccepted
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	return op
+}
+
+func TestRouter(t *testing.T) {
+	router := NewRouter()
+	if err := router.Handle("GET", "/foo/{ID}", newGetFooOperation(t)); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := router.Handle("GET", "/foo/{ID}", newGetFooOperation(t)); err == nil {
+		t.Fatalf("expect duplicate route error, got none")
+	}
+	if err := router.Handle("GET", "/foo/special", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	cases := map[string]struct {
+		Method        string
+		Path          string
+		Header        http.Header
+		ExpectStatus  int
+		ExpectMessage string
+		ExpectBody    string
+	}{
+		"success": {
+			Method:        "GET",
+			Path:          "/foo/abc",
+			Header:        http.Header{"X-Tags": []string{`a, "b,c"`}},
+			ExpectStatus:  http.StatusAccepted,
+			ExpectMessage: "abc:a|b,c",
+		},
+		"literal preferred": {
+			Method:       "GET",
+			Path:         "/foo/special",
+			ExpectStatus: http.StatusTeapot,
+		},
+		"validation error": {
+			Method:       "GET",
+			Path:         "/foo/toolong",
+			ExpectStatus: http.StatusBadRequest,
+			ExpectBody:   "getFooInput.ID",
+		},
+		"deserialization error": {
+			Method:       "GET",
+			Path:         "/foo/abc",
+			Header:       http.Header{"X-Tags": []string{`"a`}},
+			ExpectStatus: http.StatusBadRequest,
+			ExpectBody:   "deserialization failed",
+		},
+		"server error": {
+			Method:       "GET",
+			Path:         "/foo/fail",
+			ExpectStatus: http.StatusInternalServerError,
+			ExpectBody:   "Internal Server Error",
+		},
+		"unmodeled client error": {
+			Method:       "GET",
+			Path:         "/foo/deny",
+			ExpectStatus: http.StatusInternalServerError,
+			ExpectBody:   "Internal Server Error",
+		},
+		"method not allowed": {
+			Method:       "PUT",
+			Path:         "/foo/abc",
+			ExpectStatus: http.StatusMethodNotAllowed,
+		},
+		"not found": {
+			Method:       "GET",
+			Path:         "/bar",
+			ExpectStatus: http.StatusNotFound,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(c.Method, c.Path, nil)
+			for k, vs := range c.Header {
+				req.Header[k] = vs
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if e, a := c.ExpectStatus, rec.Code; e != a {
+				t.Errorf("expect %v status, got %v", e, a)
+			}
+			if e, a := c.ExpectMessage, rec.Header().Get("X-Message"); e != a {
+				t.Errorf("expect %q message, got %q", e, a)
+			}
+			body, _ := ioutil.ReadAll(rec.Body)
+			if e, a := c.ExpectBody, string(body); !strings.Contains(a, e) {
+				t.Errorf("expect %q in body, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestRouterConcurrentHandle(t *testing.T) {
+	router := NewRouter()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	for i := 0; i < 8; i++ {
+		if err := router.Handle("GET", fmt.Sprintf("/{Label}/%d", i), handler); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/none/0/1", nil))
+			}
+		}
+	}()
+	for i := 0; i < 500; i++ {
+		if err := router.Handle("GET", fmt.Sprintf("/literal/%d", i), handler); err != nil {
+			t.Errorf("expect no error, got %v", err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
+
+func TestOperationStackOrder(t *testing.T) {
+	op := newGetFooOperation(t)
+
+	var order []string
+	record := func(id string) middleware.Middleware {
+		return mockMiddleware{id: id, fn: func() { order = append(order, id) }}
+	}
+	op.Stack.Serialize.Add(record("serialize"), middleware.After)
+	op.Stack.Deserialize.Add(record("deserialize"), middleware.After)
+	op.Stack.Validate.Add(record("validate"), middleware.Before)
+	op.Stack.Handle.Add(record("handle"), middleware.After)
+
+	rec := httptest.NewRecorder()
+	op.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil).WithContext(
+		withLabels(context.Background(), map[string]string{"ID": "abc"})))
+
+	if e, a := "serialize,deserialize,validate,handle", strings.Join(order, ","); e != a {
+		t.Errorf("expect %v order, got %v", e, a)
+	}
+	if e, a := http.StatusAccepted, rec.Code; e != a {
+		t.Errorf("expect %v status, got %v", e, a)
+	}
+}
+
+type mockMiddleware struct {
+	id string
+	fn func()
+}
+
+func (m mockMiddleware) ID() string { return m.id }
+
+func (m mockMiddleware) HandleMiddleware(ctx context.Context, input interface{}, next middleware.Handler) (
+	interface{}, middleware.Metadata, error,
+) {
+	m.fn()
+	return next.Handle(ctx, input)
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type segmentKind int
+
+const (
+	literalSegment segmentKind = iota
+	labelSegment
+	greedyLabelSegment
+)
+
+type segment struct {
+	kind  segmentKind
+	value string
+}
+
+type queryLiteral struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+// Pattern is a Smithy HTTP binding URI pattern, and the method it is bound
+// to.
+type Pattern struct {
+	method   string
+	raw      string
+	segments []segment
+	query    []queryLiteral
+}
+
+// ParsePattern parses a Smithy HTTP binding URI pattern for the method.
+// Returns an error if the pattern is malformed, contains duplicate labels, or
+// more than one greedy label.
+func ParsePattern(method, uri string) (*Pattern, error) {
+	if !strings.HasPrefix(uri, "/") {
+		return nil, fmt.Errorf("uri pattern must start with '/', %q", uri)
+	}
+
+	p := &Pattern{method: strings.ToUpper(method), raw: uri}
+
+	path, rawQuery := uri, ""
+	if i := strings.IndexByte(uri, '?'); i != -1 {
+		path, rawQuery = uri[:i], uri[i+1:]
+	}
+
+	labels := map[string]bool{}
+	var hasGreedy bool
+	for _, part := range splitPath(path) {
+		if !strings.HasPrefix(part, "{") {
+			if strings.ContainsAny(part, "{}") {
+				return nil, fmt.Errorf("labels must span a full path segment, %q", uri)
+			}
+			p.segments = append(p.segments, segment{kind: literalSegment, value: part})
+			continue
+		}
+
+		if !strings.HasSuffix(part, "}") || len(part) < 3 {
+			return nil, fmt.Errorf("invalid label %q in uri pattern, %q", part, uri)
+		}
+		name, kind := part[1:len(part)-1], labelSegment
+		if strings.HasSuffix(name, "+") {
+			if hasGreedy {
+				return nil, fmt.Errorf("uri pattern must have at most one greedy label, %q", uri)
+			}
+			name, kind, hasGreedy = name[:len(name)-1], greedyLabelSegment, true
+		}
+		if labels[name] {
+			return nil, fmt.Errorf("duplicate label %q in uri pattern, %q", name, uri)
+		}
+		labels[name] = true
+		p.segments = append(p.segments, segment{kind: kind, value: name})
+	}
+
+	if len(rawQuery) != 0 {
+		for _, kv := range strings.Split(rawQuery, "&") {
+			if len(kv) == 0 {
+				continue
+			}
+			var q queryLiteral
+			if i := strings.IndexByte(kv, '='); i != -1 {
+				q.key, q.value, q.hasValue = kv[:i], kv[i+1:], true
+			} else {
+				q.key = kv
+			}
+			p.query = append(p.query, q)
+		}
+	}
+
+	return p, nil
+}
+
+// String returns the method and URI pattern.
+func (p *Pattern) String() string {
+	return p.method + " " + p.raw
+}
+
+// MatchPath returns the label values of the request URL if it matches the
+// pattern's path and query literals. The method is not considered.
+func (p *Pattern) MatchPath(u *url.URL) (labels map[string]string, ok bool) {
+	parts := splitPath(u.EscapedPath())
+
+	labels = map[string]string{}
+	if !p.matchSegments(p.segments, parts, labels) {
+		return nil, false
+	}
+
+	query := u.Query()
+	for _, q := range p.query {
+		vs, ok := query[q.key]
+		if !ok {
+			return nil, false
+		}
+		if q.hasValue && (len(vs) == 0 || vs[0] != q.value) {
+			return nil, false
+		}
+	}
+
+	return labels, true
+}
+
+// Match returns the label values of the request if it matches the pattern's
+// method, path, and query literals.
+func (p *Pattern) Match(r *http.Request) (labels map[string]string, ok bool) {
+	if r.Method != p.method {
+		return nil, false
+	}
+	return p.MatchPath(r.URL)
+}
+
+func (p *Pattern) matchSegments(segments []segment, parts []string, labels map[string]string) bool {
+	for i, seg := range segments {
+		if seg.kind == greedyLabelSegment {
+			// greedy label consumes at least one part, leaving enough parts
+			// for the remaining segments.
+			rest := segments[i+1:]
+			n := len(parts) - i - len(rest)
+			if n < 1 {
+				return false
+			}
+
+			values := make([]string, n)
+			for j := range values {
+				v, err := url.PathUnescape(parts[i+j])
+				if err != nil {
+					return false
+				}
+				values[j] = v
+			}
+			labels[seg.value] = strings.Join(values, "/")
+			if len(labels[seg.value]) == 0 {
+				return false
+			}
+
+			return p.matchSegments(rest, parts[i+n:], labels)
+		}
+
+		if i >= len(parts) {
+			return false
+		}
+
+		switch seg.kind {
+		case literalSegment:
+			v, err := url.PathUnescape(parts[i])
+			if err != nil || v != seg.value {
+				return false
+			}
+		case labelSegment:
+			v, err := url.PathUnescape(parts[i])
+			if err != nil || len(v) == 0 {
+				return false
+			}
+			labels[seg.value] = v
+		}
+	}
+
+	return len(parts) == len(segments)
+}
+
+// specificity orders patterns so that more specific patterns are matched
+// first, preferring literal segments over labels, labels over greedy labels,
+// and patterns with more query literals.
+func (p *Pattern) specificity() [3]int {
+	var literals, labels int
+	for _, seg := range p.segments {
+		switch seg.kind {
+		case literalSegment:
+			literals++
+		case labelSegment:
+			labels++
+		}
+	}
+	return [3]int{literals, labels, len(p.query)}
+}
+
+// splitPath splits a URI path into its segments, ignoring the leading and
+// any trailing slash.
+func splitPath(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	path = strings.TrimSuffix(path, "/")
+	if len(path) == 0 {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
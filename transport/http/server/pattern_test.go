@@ -0,0 +1,120 @@
+package server
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestPatternMatchPath(t *testing.T) {
+	cases := map[string]struct {
+		Pattern      string
+		URL          string
+		ExpectLabels map[string]string
+		ExpectMatch  bool
+	}{
+		"literal": {
+			Pattern:      "/foo/bar",
+			URL:          "/foo/bar",
+			ExpectLabels: map[string]string{},
+			ExpectMatch:  true,
+		},
+		"literal trailing slash": {
+			Pattern:      "/foo/bar",
+			URL:          "/foo/bar/",
+			ExpectLabels: map[string]string{},
+			ExpectMatch:  true,
+		},
+		"literal escaped": {
+			Pattern:      "/foo/a b",
+			URL:          "/foo/a%20b",
+			ExpectLabels: map[string]string{},
+			ExpectMatch:  true,
+		},
+		"literal mismatch": {
+			Pattern: "/foo/bar",
+			URL:     "/foo/baz",
+		},
+		"label": {
+			Pattern:      "/foo/{Id}",
+			URL:          "/foo/a%2Fb",
+			ExpectLabels: map[string]string{"Id": "a/b"},
+			ExpectMatch:  true,
+		},
+		"label too few segments": {
+			Pattern: "/foo/{Id}",
+			URL:     "/foo",
+		},
+		"label too many segments": {
+			Pattern: "/foo/{Id}",
+			URL:     "/foo/a/b",
+		},
+		"greedy label": {
+			Pattern:      "/{Bucket}/{Key+}",
+			URL:          "/bucket/a/b%20c/d",
+			ExpectLabels: map[string]string{"Bucket": "bucket", "Key": "a/b c/d"},
+			ExpectMatch:  true,
+		},
+		"greedy label with suffix": {
+			Pattern:      "/{Key+}/meta",
+			URL:          "/a/b/meta",
+			ExpectLabels: map[string]string{"Key": "a/b"},
+			ExpectMatch:  true,
+		},
+		"greedy label empty": {
+			Pattern: "/{Bucket}/{Key+}",
+			URL:     "/bucket",
+		},
+		"query literal": {
+			Pattern:      "/{Bucket}?x-id=GetObject&flag",
+			URL:          "/bucket?flag&x-id=GetObject&other=1",
+			ExpectLabels: map[string]string{"Bucket": "bucket"},
+			ExpectMatch:  true,
+		},
+		"query literal value mismatch": {
+			Pattern: "/{Bucket}?x-id=GetObject",
+			URL:     "/bucket?x-id=PutObject",
+		},
+		"query literal missing": {
+			Pattern: "/{Bucket}?flag",
+			URL:     "/bucket",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := ParsePattern("GET", c.Pattern)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			u, err := url.Parse(c.URL)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			labels, ok := p.MatchPath(u)
+			if e, a := c.ExpectMatch, ok; e != a {
+				t.Fatalf("expect %v match, got %v", e, a)
+			}
+			if !reflect.DeepEqual(c.ExpectLabels, labels) {
+				t.Errorf("expect %v labels, got %v", c.ExpectLabels, labels)
+			}
+		})
+	}
+}
+
+func TestParsePatternErrors(t *testing.T) {
+	cases := []string{
+		"foo",
+		"/{a+}/{b+}",
+		"/{a}/{a}",
+		"/foo{a}",
+		"/{}",
+	}
+
+	for _, c := range cases {
+		if _, err := ParsePattern("GET", c); err == nil {
+			t.Errorf("expect error for %q, got none", c)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/smithy-go/encoding/httpbinding"
+)
+
+// Request is the HTTP request an operation is invoked with, and the URI label
+// values of the route it was matched by.
+type Request struct {
+	*http.Request
+
+	Labels map[string]string
+}
+
+// Label returns the value of the URI label, and whether it was present.
+func (r *Request) Label(name string) (string, bool) {
+	v, ok := r.Labels[name]
+	return v, ok
+}
+
+// HeaderList returns the items of a header bound to a list member.
+func (r *Request) HeaderList(key string) ([]string, error) {
+	return httpbinding.SplitHeaderListValues(r.Header.Values(key))
+}
+
+// PrefixHeaders returns the values of headers whose name starts with the
+// prefix, keyed by the header name without the prefix. The prefix is matched
+// case-insensitively.
+func (r *Request) PrefixHeaders(prefix string) map[string]string {
+	prefix = strings.ToLower(prefix)
+
+	var vs map[string]string
+	for k := range r.Header {
+		if !strings.HasPrefix(strings.ToLower(k), prefix) {
+			continue
+		}
+		if vs == nil {
+			vs = map[string]string{}
+		}
+		vs[k[len(prefix):]] = r.Header.Get(k)
+	}
+	return vs
+}
+
+// Response is the HTTP response an operation's output, or error, is
+// serialized into.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       io.Reader
+}
+
+// NewResponse returns a Response with a 200 OK status code, and no headers
+// or body.
+func NewResponse() *Response {
+	return &Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+	}
+}
+
+// SetHeader returns a HeaderValue for replacing the value of the header.
+func (r *Response) SetHeader(key string) httpbinding.HeaderValue {
+	return httpbinding.NewHeaderValue(r.Header, http.CanonicalHeaderKey(key), false)
+}
+
+// AddHeader returns a HeaderValue for appending values to the header.
+func (r *Response) AddHeader(key string) httpbinding.HeaderValue {
+	return httpbinding.NewHeaderValue(r.Header, http.CanonicalHeaderKey(key), true)
+}
+
+// Headers returns a Headers for encoding headers with the prefix.
+func (r *Response) Headers(prefix string) httpbinding.Headers {
+	return httpbinding.NewHeaders(r.Header, prefix)
+}
+
+// Write writes the response's headers, status code, and body to w.
+func (r *Response) Write(w http.ResponseWriter) error {
+	header := w.Header()
+	for k, vs := range r.Header {
+		header[k] = append(header[k], vs...)
+	}
+
+	w.WriteHeader(r.StatusCode)
+
+	if r.Body == nil {
+		return nil
+	}
+	_, err := io.Copy(w, r.Body)
+	return err
+}
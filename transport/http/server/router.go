@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type labelsKey struct{}
+
+// GetLabels returns the URI label values of the route matched by the Router
+// for the request's context.
+func GetLabels(ctx context.Context) map[string]string {
+	v, _ := ctx.Value(labelsKey{}).(map[string]string)
+	return v
+}
+
+func withLabels(ctx context.Context, labels map[string]string) context.Context {
+	return context.WithValue(ctx, labelsKey{}, labels)
+}
+
+type route struct {
+	pattern *Pattern
+	handler http.Handler
+}
+
+// Router is an http.Handler that dispatches requests to the handler whose
+// Smithy HTTP binding pattern matches the request. When multiple patterns
+// match a request, the most specific pattern is used.
+//
+// Requests matching no pattern are responded to with 404 Not Found. Requests
+// whose path matches a pattern, but not its method, are responded to with
+// 405 Method Not Allowed.
+//
+// Router is safe for concurrent use.
+type Router struct {
+	mu     sync.RWMutex
+	routes []route
+
+	// NotFound is the handler used when no route matches the request. If
+	// nil, http.NotFound is used.
+	NotFound http.Handler
+}
+
+// NewRouter returns an empty Router.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Handle registers the handler for the method and Smithy URI pattern.
+// Returns an error if the pattern is malformed, or is already registered.
+func (r *Router) Handle(method, uri string, handler http.Handler) error {
+	p, err := ParsePattern(method, uri)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rt := range r.routes {
+		if rt.pattern.String() == p.String() {
+			return &DuplicateRouteError{Pattern: p.String()}
+		}
+	}
+
+	// routes are copied, as ServeHTTP iterates the previous slice without
+	// holding the lock.
+	routes := make([]route, 0, len(r.routes)+1)
+	routes = append(routes, r.routes...)
+	routes = append(routes, route{pattern: p, handler: handler})
+	sort.SliceStable(routes, func(i, j int) bool {
+		a, b := routes[i].pattern.specificity(), routes[j].pattern.specificity()
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] > b[k]
+			}
+		}
+		return false
+	})
+	r.routes = routes
+
+	return nil
+}
+
+// ServeHTTP dispatches the request to the handler of the matching route.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.RLock()
+	routes := r.routes
+	r.mu.RUnlock()
+
+	var allowed []string
+	for _, rt := range routes {
+		labels, ok := rt.pattern.MatchPath(req.URL)
+		if !ok {
+			continue
+		}
+		if rt.pattern.method != req.Method {
+			allowed = append(allowed, rt.pattern.method)
+			continue
+		}
+
+		rt.handler.ServeHTTP(w, req.WithContext(withLabels(req.Context(), labels)))
+		return
+	}
+
+	if len(allowed) != 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if r.NotFound != nil {
+		r.NotFound.ServeHTTP(w, req)
+		return
+	}
+	http.NotFound(w, req)
+}
+
+// DuplicateRouteError is returned when a route is registered for a method and
+// pattern that already has a route.
+type DuplicateRouteError struct {
+	Pattern string
+}
+
+func (e *DuplicateRouteError) Error() string {
+	return "route already registered for " + e.Pattern
+}
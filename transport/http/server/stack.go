@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Stack is the set of middleware an Operation handles a request with. Steps
+// are composed in the following order:
+//
+//	Serialize -> Deserialize -> Validate -> Handle -> handler
+//
+// The Serialize step's input is the *Request, and its result the *Response
+// the operation's output or error was serialized into. The Deserialize
+// step's input is the *Request, and its result the operation output. The
+// Validate and Handle steps' input is the deserialized operation input, and
+// their result the operation output.
+type Stack struct {
+	// Serialize serializes the operation output, or error, returned by the
+	// Deserialize step into the HTTP response.
+	Serialize *Step
+
+	// Deserialize deserializes the HTTP request into the operation input.
+	Deserialize *Step
+
+	// Validate validates the deserialized operation input.
+	Validate *Step
+
+	// Handle invokes the operation's implementation with the validated
+	// input.
+	Handle *Step
+}
+
+// NewStack returns an empty stack.
+func NewStack() *Stack {
+	return &Stack{
+		Serialize:   newStep("Serialize stack step"),
+		Deserialize: newStep("Deserialize stack step"),
+		Validate:    newStep("Validate stack step"),
+		Handle:      newStep("Handle stack step"),
+	}
+}
+
+// Step is an ordered group of middleware.
+type Step struct {
+	id  string
+	mws []middleware.Middleware
+}
+
+func newStep(id string) *Step {
+	return &Step{id: id}
+}
+
+// ID returns the unique name of the step.
+func (s *Step) ID() string {
+	return s.id
+}
+
+// Add injects the middleware to the relative position of the step. Returns
+// an error if the middleware ID is already in the step.
+func (s *Step) Add(m middleware.Middleware, pos middleware.RelativePosition) error {
+	if _, ok := s.Get(m.ID()); ok {
+		return fmt.Errorf("already exists, %v", m.ID())
+	}
+
+	switch pos {
+	case middleware.Before:
+		s.mws = append([]middleware.Middleware{m}, s.mws...)
+	case middleware.After:
+		s.mws = append(s.mws, m)
+	default:
+		return fmt.Errorf("invalid position, %v", int(pos))
+	}
+	return nil
+}
+
+// Insert injects the middleware relative to an existing middleware ID.
+// Returns an error if the original middleware does not exist, or the
+// middleware being added already exists.
+func (s *Step) Insert(m middleware.Middleware, relativeTo string, pos middleware.RelativePosition) error {
+	if _, ok := s.Get(m.ID()); ok {
+		return fmt.Errorf("already exists, %v", m.ID())
+	}
+
+	i := s.index(relativeTo)
+	if i == -1 {
+		return fmt.Errorf("not found, %v", relativeTo)
+	}
+
+	switch pos {
+	case middleware.Before:
+	case middleware.After:
+		i++
+	default:
+		return fmt.Errorf("invalid position, %v", int(pos))
+	}
+
+	s.mws = append(s.mws, nil)
+	copy(s.mws[i+1:], s.mws[i:])
+	s.mws[i] = m
+	return nil
+}
+
+// Get retrieves the middleware identified by id. If the middleware is not
+// present, returns false.
+func (s *Step) Get(id string) (middleware.Middleware, bool) {
+	if i := s.index(id); i != -1 {
+		return s.mws[i], true
+	}
+	return nil, false
+}
+
+// Remove removes the middleware by id. Returns error if the middleware
+// doesn't exist.
+func (s *Step) Remove(id string) (middleware.Middleware, error) {
+	i := s.index(id)
+	if i == -1 {
+		return nil, fmt.Errorf("not found, %v", id)
+	}
+
+	m := s.mws[i]
+	s.mws = append(s.mws[:i], s.mws[i+1:]...)
+	return m, nil
+}
+
+// List returns a list of the middleware in the step.
+func (s *Step) List() []string {
+	ids := make([]string, len(s.mws))
+	for i, m := range s.mws {
+		ids[i] = m.ID()
+	}
+	return ids
+}
+
+// HandleMiddleware invokes the middleware of the step in order, with next as
+// the handler of the last middleware.
+func (s *Step) HandleMiddleware(ctx context.Context, in interface{}, next middleware.Handler) (
+	out interface{}, metadata middleware.Metadata, err error,
+) {
+	return middleware.DecorateHandler(next, s.mws...).Handle(ctx, in)
+}
+
+func (s *Step) index(id string) int {
+	for i, m := range s.mws {
+		if m.ID() == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"context"
+	"fmt"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/auth"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// HTTPSigner signs an HTTP request with an identity. Implementations provide
+// a signature scheme, e.g. a per-service HMAC or a JWT bearer signature, and
+// read the scheme's configuration from the signing properties.
+type HTTPSigner interface {
+	SignRequest(ctx context.Context, identity auth.Identity, request *Request, signingProperties smithy.Properties) error
+}
+
+// HTTPSignerFunc provides a helper to wrap a function as an HTTPSigner.
+type HTTPSignerFunc func(ctx context.Context, identity auth.Identity, request *Request, signingProperties smithy.Properties) error
+
+// SignRequest invokes the underlying function.
+func (fn HTTPSignerFunc) SignRequest(ctx context.Context, identity auth.Identity, request *Request, signingProperties smithy.Properties) error {
+	return fn(ctx, identity, request, signingProperties)
+}
+
+// ResolvedSigner is the signer, identity, and signing properties resolved for
+// an operation's request, e.g. by an auth scheme resolution middleware.
+type ResolvedSigner struct {
+	Signer            HTTPSigner
+	Identity          auth.Identity
+	SigningProperties smithy.Properties
+}
+
+type resolvedSignerKey struct{}
+
+// WithResolvedSigner sets the signer the request will be signed with.
+//
+// Scoped to stack values. Use middleware#ClearStackValues to clear all stack
+// values.
+func WithResolvedSigner(ctx context.Context, signer ResolvedSigner) context.Context {
+	return middleware.WithStackValue(ctx, resolvedSignerKey{}, signer)
+}
+
+// GetResolvedSigner retrieves the signer the request will be signed with,
+// and whether one was set.
+//
+// Scoped to stack values. Use middleware#ClearStackValues to clear all stack
+// values.
+func GetResolvedSigner(ctx context.Context) (v ResolvedSigner, ok bool) {
+	v, ok = middleware.GetStackValue(ctx, resolvedSignerKey{}).(ResolvedSigner)
+	return v, ok
+}
+
+// SigningError is returned when the request fails to be signed.
+type SigningError struct {
+	Err error
+}
+
+func (e *SigningError) Error() string {
+	return fmt.Sprintf("failed to sign request, %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *SigningError) Unwrap() error {
+	return e.Err
+}
+
+// SignRequestMiddleware is the Finalize step middleware which signs the
+// request with the signer resolved for the request, see WithResolvedSigner.
+// Fills the middleware.SlotSigning slot of the stack.
+//
+// If no signer was resolved, or the resolved identity is anonymous, the
+// request is sent unsigned. When the stack is run by Presign with an
+// expiration, the expiration is passed to the signer as the
+// auth.SetSigningExpiration signing property.
+type SignRequestMiddleware struct{}
+
+// AddSignRequestMiddleware adds the SignRequestMiddleware to the stack's
+// Finalize step, in the signing slot.
+func AddSignRequestMiddleware(stack *middleware.Stack) error {
+	m := &SignRequestMiddleware{}
+	if err := stack.Finalize.Insert(m, middleware.SlotSigning, middleware.Before); err == nil {
+		return nil
+	}
+	return stack.Finalize.Add(m, middleware.After)
+}
+
+// ID returns the middleware identifier.
+func (*SignRequestMiddleware) ID() string { return middleware.SlotSigning }
+
+// HandleFinalize signs the request with the resolved signer.
+func (*SignRequestMiddleware) HandleFinalize(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+	out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type %T", in.Request)
+	}
+
+	rs, ok := GetResolvedSigner(ctx)
+	if !ok || rs.Signer == nil {
+		return next.HandleFinalize(ctx, in)
+	}
+	if _, ok := rs.Identity.(*auth.AnonymousIdentity); ok || rs.Identity == nil {
+		return next.HandleFinalize(ctx, in)
+	}
+
+	props := rs.SigningProperties
+	if o, ok := GetPresignOptions(ctx); ok && o.Expires > 0 {
+		props = smithy.Properties{}
+		props.SetAll(&rs.SigningProperties)
+		auth.SetSigningExpiration(&props, o.Expires)
+	}
+
+	if err := rs.Signer.SignRequest(ctx, rs.Identity, req, props); err != nil {
+		return out, metadata, &SigningError{Err: err}
+	}
+
+	return next.HandleFinalize(ctx, in)
+}
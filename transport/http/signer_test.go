@@ -0,0 +1,144 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	smithy "github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/auth"
+	"github.com/aws/smithy-go/middleware"
+)
+
+type mockIdentity struct {
+	key string
+}
+
+func (mockIdentity) Expiration() time.Time { return time.Time{} }
+
+var mockSignatureHeader = smithy.NewPropertyKey[string]("test", "signatureHeader")
+
+func mockSigner(ctx context.Context, identity auth.Identity, request *Request, props smithy.Properties) error {
+	header, _ := mockSignatureHeader.Get(&props)
+	request.Header.Set(header, "signed-by-"+identity.(mockIdentity).key+"-for-"+request.URL.Host)
+	return nil
+}
+
+func TestSignRequestMiddleware(t *testing.T) {
+	signErr := errors.New("sign error")
+
+	cases := map[string]struct {
+		signer      *ResolvedSigner
+		expectValue string
+		expectErr   error
+	}{
+		"no signer": {},
+		"signed": {
+			signer: &ResolvedSigner{
+				Signer:   HTTPSignerFunc(mockSigner),
+				Identity: mockIdentity{key: "abc"},
+			},
+			expectValue: "signed-by-abc-for-example.com",
+		},
+		"anonymous": {
+			signer: &ResolvedSigner{
+				Signer:   HTTPSignerFunc(mockSigner),
+				Identity: &auth.AnonymousIdentity{},
+			},
+		},
+		"sign error": {
+			signer: &ResolvedSigner{
+				Signer: HTTPSignerFunc(func(context.Context, auth.Identity, *Request, smithy.Properties) error {
+					return signErr
+				}),
+				Identity: mockIdentity{key: "abc"},
+			},
+			expectErr: signErr,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			stack := middleware.NewStack("test", NewStackRequest)
+			stack.Serialize.Add(middleware.SerializeMiddlewareFunc("serialize",
+				func(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+					middleware.SerializeOutput, middleware.Metadata, error,
+				) {
+					req := in.Request.(*Request)
+					req.URL.Scheme = "https"
+					req.URL.Host = "example.com"
+					return next.HandleSerialize(ctx, in)
+				}), middleware.After)
+			if err := AddSignRequestMiddleware(stack); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if c.signer != nil {
+				c.signer.SigningProperties.Set(mockSignatureHeader, "X-Signature")
+				stack.Initialize.Add(middleware.InitializeMiddlewareFunc("resolveSigner",
+					func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+						middleware.InitializeOutput, middleware.Metadata, error,
+					) {
+						return next.HandleInitialize(WithResolvedSigner(ctx, *c.signer), in)
+					}), middleware.After)
+			}
+
+			var signature string
+			handler := middleware.DecorateHandler(middleware.HandlerFunc(
+				func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+					signature = input.(*Request).Header.Get("X-Signature")
+					return &Response{Response: &http.Response{StatusCode: 200}}, middleware.Metadata{}, nil
+				}), stack)
+
+			_, _, err := handler.Handle(context.Background(), struct{}{})
+			if c.expectErr != nil {
+				var sigErr *SigningError
+				if !errors.As(err, &sigErr) {
+					t.Fatalf("expect %T error, got %v", sigErr, err)
+				}
+				if !errors.Is(err, c.expectErr) {
+					t.Errorf("expect %v error, got %v", c.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.expectValue, signature; e != a {
+				t.Errorf("expect %q signature, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestAddSignRequestMiddleware_FillsSlot(t *testing.T) {
+	stack := middleware.NewStack("test", NewStackRequest)
+	stack.Finalize.Add(middleware.FinalizeMiddlewareFunc(middleware.SlotRetry,
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+			middleware.FinalizeOutput, middleware.Metadata, error,
+		) {
+			return next.HandleFinalize(ctx, in)
+		}), middleware.Before)
+	stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("after",
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+			middleware.FinalizeOutput, middleware.Metadata, error,
+		) {
+			return next.HandleFinalize(ctx, in)
+		}), middleware.After)
+
+	if err := AddSignRequestMiddleware(stack); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := []string{middleware.SlotRetry, middleware.SlotSigning, "after"}
+	actual := stack.Finalize.List()
+	if len(expect) != len(actual) {
+		t.Fatalf("expect %v, got %v", expect, actual)
+	}
+	for i := range expect {
+		if e, a := expect[i], actual[i]; e != a {
+			t.Errorf("expect %v, got %v", e, a)
+		}
+	}
+}
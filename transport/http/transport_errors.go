@@ -0,0 +1,174 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+)
+
+// DNSError is a failure resolving the host of the request's endpoint.
+type DNSError struct {
+	// Host is the name of the host that failed to resolve.
+	Host string
+
+	Err error
+}
+
+func (e *DNSError) Error() string {
+	return fmt.Sprintf("failed to resolve host %s, %v", e.Host, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DNSError) Unwrap() error { return e.Err }
+
+// ConnectionError returns that the error is related to not being able to
+// send the request.
+func (e *DNSError) ConnectionError() bool { return true }
+
+// ConnectError is a failure establishing the connection to the service, or
+// the connection being reset, or closed, by the service before a response was
+// received.
+type ConnectError struct {
+	Err error
+}
+
+func (e *ConnectError) Error() string {
+	return fmt.Sprintf("connection failed, %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ConnectError) Unwrap() error { return e.Err }
+
+// ConnectionError returns that the error is related to not being able to
+// send the request, or receive a response from the service.
+func (e *ConnectError) ConnectionError() bool { return true }
+
+// TLSError is a failure of the TLS handshake with the service, including
+// failing to verify the service's certificate.
+type TLSError struct {
+	Err error
+}
+
+func (e *TLSError) Error() string {
+	return fmt.Sprintf("TLS handshake failed, %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *TLSError) Unwrap() error { return e.Err }
+
+// ConnectionError returns that the error is related to not being able to
+// send the request.
+func (e *TLSError) ConnectionError() bool { return true }
+
+// RetryableError returns false if the service's certificate failed to be
+// verified, which retrying the request will not resolve.
+func (e *TLSError) RetryableError() bool {
+	return !isCertificateError(e.Err)
+}
+
+// ResponseHeaderTimeoutError is a timeout waiting for the service to respond
+// with the response's headers, after the request was sent, such as
+// net/http.Transport's ResponseHeaderTimeout, or http.Client's Timeout.
+type ResponseHeaderTimeoutError struct {
+	Err error
+}
+
+func (e *ResponseHeaderTimeoutError) Error() string {
+	return fmt.Sprintf("timeout awaiting response headers, %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ResponseHeaderTimeoutError) Unwrap() error { return e.Err }
+
+// ConnectionError returns that the error is related to not receiving a
+// response from the service.
+func (e *ResponseHeaderTimeoutError) ConnectionError() bool { return true }
+
+// Timeout returns that the error is a timeout.
+func (e *ResponseHeaderTimeoutError) Timeout() bool { return true }
+
+// BodyReadError is a failure reading the response body, such as the
+// connection being reset while the body is read. Returned by the reads of
+// the bodies of responses returned by ClientHandler.
+type BodyReadError struct {
+	Err error
+}
+
+func (e *BodyReadError) Error() string {
+	return fmt.Sprintf("failed to read response body, %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *BodyReadError) Unwrap() error { return e.Err }
+
+// ConnectionError returns that the error is related to not receiving the
+// response from the service.
+func (e *BodyReadError) ConnectionError() bool { return true }
+
+// ClassifyTransportError returns the error of an HTTP client failing to send
+// a request wrapped in the typed error of its cause, a *DNSError,
+// *TLSError, *ConnectError, or *ResponseHeaderTimeoutError, so retry
+// classifiers, and applications, can branch on the cause of the failure
+// without matching the error's message. Returns the error unchanged if its
+// cause is not known. ClientHandler classifies the errors it wraps in a
+// RequestSendError.
+func ClassifyTransportError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return &DNSError{Host: dnsErr.Name, Err: err}
+	}
+
+	var recordErr tls.RecordHeaderError
+	var opErr *net.OpError
+	switch {
+	case isCertificateError(err), errors.As(err, &recordErr):
+		return &TLSError{Err: err}
+	case errors.As(err, &opErr) && opErr.Op == "remote error":
+		// TLS alerts sent by the service.
+		return &TLSError{Err: err}
+	case errors.As(err, &opErr) && opErr.Op == "dial",
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.EPIPE),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF):
+		return &ConnectError{Err: err}
+	}
+
+	var timeoutErr interface{ Timeout() bool }
+	if errors.As(err, &timeoutErr) && timeoutErr.Timeout() {
+		return &ResponseHeaderTimeoutError{Err: err}
+	}
+	return err
+}
+
+func isCertificateError(err error) bool {
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	return errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr)
+}
+
+// bodyReadErrorReader wraps the errors reading the response body, other
+// than io.EOF, in a *BodyReadError.
+type bodyReadErrorReader struct {
+	io.ReadCloser
+}
+
+func (r *bodyReadErrorReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		err = &BodyReadError{Err: err}
+	}
+	return n, err
+}
@@ -0,0 +1,184 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	smithy "github.com/aws/smithy-go"
+)
+
+func TestClassifyTransportError(t *testing.T) {
+	cases := map[string]struct {
+		Err    error
+		Expect func(error) bool
+	}{
+		"dns": {
+			Err: &url.Error{Op: "Post", URL: "https://example.invalid", Err: &net.OpError{
+				Op: "dial", Net: "tcp", Err: &net.DNSError{Name: "example.invalid", Err: "no such host"},
+			}},
+			Expect: func(err error) bool {
+				var v *DNSError
+				return errors.As(err, &v) && v.Host == "example.invalid"
+			},
+		},
+		"connection refused": {
+			Err: &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{
+				Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED,
+			}},
+			Expect: func(err error) bool {
+				var v *ConnectError
+				return errors.As(err, &v) && errors.Is(err, syscall.ECONNREFUSED)
+			},
+		},
+		"connection reset": {
+			Err: &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{
+				Op: "read", Net: "tcp", Err: syscall.ECONNRESET,
+			}},
+			Expect: func(err error) bool {
+				var v *ConnectError
+				return errors.As(err, &v)
+			},
+		},
+		"tls alert": {
+			Err: &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{
+				Op: "remote error", Err: errors.New("tls: handshake failure"),
+			}},
+			Expect: func(err error) bool {
+				var v *TLSError
+				return errors.As(err, &v) && v.RetryableError()
+			},
+		},
+		"unknown": {
+			Err: errors.New("some failure"),
+			Expect: func(err error) bool {
+				return err.Error() == "some failure"
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ClassifyTransportError(c.Err)
+			if !c.Expect(err) {
+				t.Errorf("unexpected classification, %T, %v", err, err)
+			}
+			if !errors.Is(err, c.Err) {
+				t.Errorf("expect classified error to wrap %v", c.Err)
+			}
+		})
+	}
+}
+
+func TestClientHandler_TransportErrors(t *testing.T) {
+	cases := map[string]struct {
+		Server func() *httptest.Server
+		Client func(*httptest.Server) *http.Client
+		Read   bool
+		Expect func(*testing.T, error)
+	}{
+		"tls certificate": {
+			Server: func() *httptest.Server {
+				return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			},
+			Client: func(*httptest.Server) *http.Client { return &http.Client{} },
+			Expect: func(t *testing.T, err error) {
+				var v *TLSError
+				if !errors.As(err, &v) {
+					t.Fatalf("expect *TLSError, got %T, %v", err, err)
+				}
+				if v.RetryableError() {
+					t.Errorf("expect certificate error not retryable")
+				}
+				if retryable, ok := smithy.IsErrorRetryable(err); !ok || retryable {
+					t.Errorf("expect not retryable, got %v, %v", retryable, ok)
+				}
+			},
+		},
+		"response header timeout": {
+			Server: func() *httptest.Server {
+				return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					time.Sleep(100 * time.Millisecond)
+				}))
+			},
+			Client: func(*httptest.Server) *http.Client {
+				return &http.Client{Transport: &http.Transport{ResponseHeaderTimeout: 10 * time.Millisecond}}
+			},
+			Expect: func(t *testing.T, err error) {
+				var v *ResponseHeaderTimeoutError
+				if !errors.As(err, &v) {
+					t.Fatalf("expect *ResponseHeaderTimeoutError, got %T, %v", err, err)
+				}
+			},
+		},
+		"connection refused": {
+			Server: func() *httptest.Server {
+				s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+				s.Close()
+				return s
+			},
+			Client: func(*httptest.Server) *http.Client { return &http.Client{} },
+			Expect: func(t *testing.T, err error) {
+				var v *ConnectError
+				if !errors.As(err, &v) {
+					t.Fatalf("expect *ConnectError, got %T, %v", err, err)
+				}
+				var sendErr *RequestSendError
+				if !errors.As(err, &sendErr) {
+					t.Errorf("expect *RequestSendError, got %T", err)
+				}
+			},
+		},
+		"body read": {
+			Server: func() *httptest.Server {
+				return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Length", "10")
+					w.Write([]byte("abc"))
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+				}))
+			},
+			Client: func(*httptest.Server) *http.Client { return &http.Client{} },
+			Read:   true,
+			Expect: func(t *testing.T, err error) {
+				var v *BodyReadError
+				if !errors.As(err, &v) {
+					t.Fatalf("expect *BodyReadError, got %T, %v", err, err)
+				}
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := c.Server()
+			defer server.Close()
+
+			req := NewStackRequest().(*Request)
+			req.URL, _ = url.Parse(server.URL)
+			req.Method = "GET"
+
+			handler := NewClientHandler(c.Client(server))
+			out, _, err := handler.Handle(context.Background(), req)
+			if c.Read {
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				resp := out.(*Response)
+				defer resp.Body.Close()
+				_, err = ioutil.ReadAll(resp.Body)
+			}
+			if err == nil {
+				t.Fatalf("expect error")
+			}
+			c.Expect(t, err)
+		})
+	}
+}
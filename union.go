@@ -0,0 +1,103 @@
+package smithy
+
+import (
+	"fmt"
+)
+
+// UnknownUnionMember is a member of a union not known by the client, e.g. a
+// member added to the service's model after the client was generated. The
+// unknown member types of generated unions embed UnknownUnionMember.
+type UnknownUnionMember struct {
+	// Tag is the name of the member.
+	Tag string
+
+	// Value is the raw serialized value of the member.
+	Value []byte
+}
+
+// UnknownMember returns the unknown member, so the unknown member types of
+// generated unions embedding UnknownUnionMember can be matched by Match.
+func (u UnknownUnionMember) UnknownMember() UnknownUnionMember {
+	return u
+}
+
+type unknownUnionMember interface {
+	UnknownMember() UnknownUnionMember
+}
+
+// UnionCase is a case of Match, matching members of the union U. Created
+// with Case, and UnknownCase.
+type UnionCase[U any] struct {
+	match func(U) (bool, error)
+}
+
+// Case returns the UnionCase matching the members of the union U of type V,
+// calling fn with the member. V is inferred from fn:
+//
+//	smithy.Case[types.Union](func(v *types.UnionMemberFoo) error {
+//		return nil
+//	})
+func Case[U, V any](fn func(V) error) UnionCase[U] {
+	return UnionCase[U]{
+		match: func(u U) (bool, error) {
+			v, ok := any(u).(V)
+			if !ok {
+				return false, nil
+			}
+			return true, fn(v)
+		},
+	}
+}
+
+// UnknownCase returns the UnionCase matching the members of the union U not
+// known by the client, calling fn with the unknown member.
+func UnknownCase[U any](fn func(UnknownUnionMember) error) UnionCase[U] {
+	return UnionCase[U]{
+		match: func(u U) (bool, error) {
+			v, ok := any(u).(unknownUnionMember)
+			if !ok {
+				return false, nil
+			}
+			return true, fn(v.UnknownMember())
+		},
+	}
+}
+
+// Match calls the function of the first of the cases matching the union
+// member, returning the function's error, replacing type switches over the
+// union's member types.
+//
+// Returns an UnknownUnionMemberError if the member is not known by the client,
+// and not matched by an UnknownCase, and an error if the member is nil, or not
+// matched by any case, so unions are matched exhaustively.
+func Match[U any](member U, cases ...UnionCase[U]) error {
+	if any(member) == nil {
+		return fmt.Errorf("union member is nil")
+	}
+
+	for _, c := range cases {
+		if ok, err := c.match(member); ok {
+			return err
+		}
+	}
+
+	if u, ok := any(member).(unknownUnionMember); ok {
+		v := u.UnknownMember()
+		return &UnknownUnionMemberError{Tag: v.Tag, Value: v.Value}
+	}
+	return fmt.Errorf("union member %T not matched by any case", member)
+}
+
+// UnknownUnionMemberError is returned by Match for union members not known by
+// the client, and not matched by an UnknownCase.
+type UnknownUnionMemberError struct {
+	// Tag is the name of the member.
+	Tag string
+
+	// Value is the raw serialized value of the member.
+	Value []byte
+}
+
+func (e *UnknownUnionMemberError) Error() string {
+	return fmt.Sprintf("unknown union member %q", e.Tag)
+}
@@ -0,0 +1,124 @@
+package smithy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type mockUnion interface {
+	isMockUnion()
+}
+
+type mockUnionMemberString struct {
+	Value string
+}
+
+func (*mockUnionMemberString) isMockUnion() {}
+
+type mockUnionMemberNumber struct {
+	Value int
+}
+
+func (*mockUnionMemberNumber) isMockUnion() {}
+
+type mockUnionUnknownMember struct {
+	UnknownUnionMember
+}
+
+func (*mockUnionUnknownMember) isMockUnion() {}
+
+func TestMatch(t *testing.T) {
+	cases := map[string]struct {
+		Member    mockUnion
+		Unknown   bool
+		Expect    string
+		ExpectErr string
+		ExpectTag string
+	}{
+		"string": {
+			Member: &mockUnionMemberString{Value: "abc"},
+			Expect: "string abc",
+		},
+		"unknown matched": {
+			Member: &mockUnionUnknownMember{
+				UnknownUnionMember{Tag: "bool", Value: []byte("true")},
+			},
+			Unknown: true,
+			Expect:  "unknown bool true",
+		},
+		"unknown not matched": {
+			Member: &mockUnionUnknownMember{
+				UnknownUnionMember{Tag: "bool", Value: []byte("true")},
+			},
+			ExpectTag: "bool",
+		},
+		"not matched": {
+			Member:    &mockUnionMemberNumber{Value: 1},
+			ExpectErr: "not matched by any case",
+		},
+		"nil": {
+			ExpectErr: "union member is nil",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var actual string
+			matchCases := []UnionCase[mockUnion]{
+				Case[mockUnion](func(v *mockUnionMemberString) error {
+					actual = "string " + v.Value
+					return nil
+				}),
+			}
+			if c.Unknown {
+				matchCases = append(matchCases, UnknownCase[mockUnion](func(v UnknownUnionMember) error {
+					actual = "unknown " + v.Tag + " " + string(v.Value)
+					return nil
+				}))
+			}
+
+			err := Match(c.Member, matchCases...)
+			if len(c.ExpectTag) != 0 {
+				var unknownErr *UnknownUnionMemberError
+				if !errors.As(err, &unknownErr) {
+					t.Fatalf("expect unknown union member error, got %v", err)
+				}
+				if e, a := c.ExpectTag, unknownErr.Tag; e != a {
+					t.Errorf("expect %v tag, got %v", e, a)
+				}
+				if e, a := "true", string(unknownErr.Value); e != a {
+					t.Errorf("expect %v value, got %v", e, a)
+				}
+				return
+			}
+			if len(c.ExpectErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := c.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %q, got %q", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Expect, actual; e != a {
+				t.Errorf("expect %q, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestMatchCaseError(t *testing.T) {
+	expectErr := errors.New("case error")
+	err := Match[mockUnion](&mockUnionMemberNumber{Value: 1},
+		Case[mockUnion](func(v *mockUnionMemberNumber) error {
+			return expectErr
+		}),
+	)
+	if !errors.Is(err, expectErr) {
+		t.Errorf("expect case error, got %v", err)
+	}
+}
@@ -138,3 +138,24 @@ func NewErrParamRequired(field string) *ParamRequiredError {
 		},
 	}
 }
+
+// ParamConstraintError represents a parameter value that violates a Smithy
+// constraint trait, e.g. length, range, or pattern.
+type ParamConstraintError struct {
+	invalidParamError
+
+	// Constraint is the name of the violated constraint trait.
+	Constraint string
+}
+
+// NewErrParamConstraint creates a new constraint violation error for the
+// named constraint trait.
+func NewErrParamConstraint(field, constraint, reason string) *ParamConstraintError {
+	return &ParamConstraintError{
+		invalidParamError: invalidParamError{
+			field:  field,
+			reason: reason,
+		},
+		Constraint: constraint,
+	}
+}
@@ -0,0 +1,14 @@
+// Package validation provides the runtime for validating operation input
+// against the Smithy constraint traits, required, length, range, pattern,
+// uniqueItems, and enum.
+//
+// Constraints are expressed as composable Rule values that are checked
+// against a member's value with Check or CheckPtr. Violations are accumulated
+// into a smithy.InvalidParamsError, which records the path of each invalid
+// member. Shapes implementing Validator are validated before the operation is
+// serialized by the middleware added with AddInputValidationMiddleware.
+//
+// Operation outputs may also be validated, to detect services returning
+// responses that do not conform to the model, by the opt-in middleware added
+// with AddOutputValidationMiddleware.
+package validation
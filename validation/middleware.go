@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Validator is implemented by shapes that validate their members'
+// constraints, adding any violations to errs.
+type Validator interface {
+	Validate(errs *smithy.InvalidParamsError)
+}
+
+// Nested validates the nested shape v, adding its violations to errs with
+// the field as their nested context. A nil v, or nil pointer, is not
+// validated.
+func Nested(errs *smithy.InvalidParamsError, field string, v Validator) {
+	if v == nil {
+		return
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return
+	}
+
+	var nested smithy.InvalidParamsError
+	v.Validate(&nested)
+	if nested.Len() != 0 {
+		errs.AddNested(field, nested)
+	}
+}
+
+// NestedList validates each shape in the list, adding violations to errs with
+// the field and item index as their nested context.
+func NestedList[E Validator](errs *smithy.InvalidParamsError, field string, vs []E) {
+	for i, v := range vs {
+		Nested(errs, field+"["+strconv.Itoa(i)+"]", v)
+	}
+}
+
+// NestedMap validates each shape in the map, adding violations to errs with
+// the field and item key as their nested context.
+func NestedMap[K comparable, V Validator](errs *smithy.InvalidParamsError, field string, vs map[K]V) {
+	for k, v := range vs {
+		Nested(errs, fmt.Sprintf("%s[%v]", field, k), v)
+	}
+}
+
+// AddInputValidationMiddleware adds the InputValidation middleware to the
+// stack's Initialize step.
+func AddInputValidationMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(&InputValidation{}, middleware.After)
+}
+
+// InputValidation is an initialize middleware that validates operation
+// input parameters implementing Validator. All violations found are returned
+// as a single smithy.InvalidParamsError, with the input's type name as its
+// context. Input parameters not implementing Validator are passed through.
+type InputValidation struct{}
+
+// ID returns the middleware identifier.
+func (*InputValidation) ID() string {
+	return "OperationInputValidation"
+}
+
+// HandleInitialize validates the input parameters before invoking the next
+// handler.
+func (*InputValidation) HandleInitialize(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+	out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+) {
+	v, ok := in.Parameters.(Validator)
+	if !ok {
+		return next.HandleInitialize(ctx, in)
+	}
+
+	errs := smithy.InvalidParamsError{Context: inputContext(in.Parameters)}
+	v.Validate(&errs)
+	if errs.Len() != 0 {
+		return out, metadata, errs
+	}
+
+	return next.HandleInitialize(ctx, in)
+}
+
+func inputContext(v interface{}) string {
+	name := fmt.Sprintf("%T", v)
+	for i := len(name) - 1; i >= 0; i-- {
+		if name[i] == '.' {
+			return name[i+1:]
+		}
+	}
+	return name
+}
@@ -0,0 +1,113 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/logging"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// OutputValidationMode is how OutputValidation reports the violations of an
+// operation's output.
+type OutputValidationMode int
+
+// Enumeration of OutputValidationMode values.
+const (
+	// OutputValidationWarn logs the violations as a warning, and returns the
+	// output.
+	OutputValidationWarn OutputValidationMode = iota
+
+	// OutputValidationFail returns the violations as an
+	// OutputValidationError.
+	OutputValidationFail
+)
+
+// OutputValidationOptions provides the configuration of OutputValidation.
+type OutputValidationOptions struct {
+	// Mode is how violations are reported. Defaults to OutputValidationWarn.
+	Mode OutputValidationMode
+}
+
+// AddOutputValidationMiddleware adds the OutputValidation middleware to the
+// stack's Deserialize step, before the operation's deserializer, validating
+// the deserialized output.
+func AddOutputValidationMiddleware(stack *middleware.Stack, optFns ...func(*OutputValidationOptions)) error {
+	var o OutputValidationOptions
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	return stack.Deserialize.Add(&OutputValidation{Options: o}, middleware.Before)
+}
+
+// OutputValidation is a deserialize middleware that validates operation
+// outputs implementing Validator against their modeled constraints, e.g.
+// enums, ranges, and required members, to detect services returning
+// responses that drift from the model. Output validation is opt-in, as
+// services may add enum values, or relax constraints, without breaking
+// clients.
+//
+// Violations are recorded in the operation's metadata, see
+// GetOutputValidationErrors, and reported per the Mode option.
+type OutputValidation struct {
+	Options OutputValidationOptions
+}
+
+// ID returns the middleware identifier.
+func (*OutputValidation) ID() string {
+	return "OperationOutputValidation"
+}
+
+// HandleDeserialize validates the output deserialized by the next handler.
+func (m *OutputValidation) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	v, ok := out.Result.(Validator)
+	if !ok {
+		return out, metadata, nil
+	}
+
+	errs := smithy.InvalidParamsError{Context: inputContext(out.Result)}
+	v.Validate(&errs)
+	if errs.Len() == 0 {
+		return out, metadata, nil
+	}
+	metadata.Set(outputValidationErrorsKey{}, errs)
+
+	if m.Options.Mode == OutputValidationFail {
+		return out, metadata, &OutputValidationError{Err: errs}
+	}
+	middleware.GetLogger(ctx).Logf(logging.Warn,
+		"operation output does not conform to the model, %v", errs)
+	return out, metadata, nil
+}
+
+// OutputValidationError is returned by OutputValidation, in the
+// OutputValidationFail mode, when the operation's output violates its
+// modeled constraints.
+type OutputValidationError struct {
+	Err smithy.InvalidParamsError
+}
+
+// Error returns the string representation of the error.
+func (e *OutputValidationError) Error() string {
+	return fmt.Sprintf("operation output does not conform to the model, %v", e.Err)
+}
+
+// Unwrap returns the violations of the output's constraints.
+func (e *OutputValidationError) Unwrap() error { return e.Err }
+
+type outputValidationErrorsKey struct{}
+
+// GetOutputValidationErrors returns the violations of the operation output's
+// constraints found by OutputValidation, if any.
+func GetOutputValidationErrors(metadata middleware.Metadata) (smithy.InvalidParamsError, bool) {
+	errs, ok := metadata.Get(outputValidationErrorsKey{}).(smithy.InvalidParamsError)
+	return errs, ok
+}
@@ -0,0 +1,118 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/logging"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/ptr"
+)
+
+type mockOutput struct {
+	Status *string
+	Count  int32
+}
+
+func (s *mockOutput) Validate(errs *smithy.InvalidParamsError) {
+	Required(errs, "Status", s.Status != nil)
+	CheckPtr(errs, "Status", s.Status, Enum("ACTIVE", "DELETED"))
+	Check(errs, "Count", s.Count, Range[int32](ptr.Float64(0), nil))
+}
+
+func TestOutputValidation(t *testing.T) {
+	cases := map[string]struct {
+		Mode         OutputValidationMode
+		Output       interface{}
+		ExpectErr    bool
+		ExpectFields []string
+		ExpectWarn   bool
+	}{
+		"valid": {
+			Output: &mockOutput{Status: ptr.String("ACTIVE")},
+		},
+		"not validator": {
+			Output: struct{}{},
+		},
+		"warn": {
+			Output:       &mockOutput{Status: ptr.String("UNKNOWN"), Count: -1},
+			ExpectFields: []string{"mockOutput.Status", "mockOutput.Count"},
+			ExpectWarn:   true,
+		},
+		"error": {
+			Mode:         OutputValidationFail,
+			Output:       &mockOutput{},
+			ExpectErr:    true,
+			ExpectFields: []string{"mockOutput.Status"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var warnings []string
+			logger := logging.LoggerFunc(func(classification logging.Classification, format string, v ...interface{}) {
+				if classification == logging.Warn {
+					warnings = append(warnings, fmt.Sprintf(format, v...))
+				}
+			})
+
+			stack := middleware.NewStack("test", func() interface{} { return struct{}{} })
+			if err := middleware.AddSetLoggerMiddleware(stack, logger); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("deserializer",
+				func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+					out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+				) {
+					out, metadata, err = next.HandleDeserialize(ctx, in)
+					out.Result = c.Output
+					return out, metadata, err
+				}), middleware.After)
+			if err := AddOutputValidationMiddleware(stack, func(o *OutputValidationOptions) {
+				o.Mode = c.Mode
+			}); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			handler := middleware.DecorateHandler(middleware.HandlerFunc(
+				func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+					return nil, middleware.Metadata{}, nil
+				}), stack)
+
+			result, metadata, err := handler.Handle(context.Background(), struct{}{})
+			if c.ExpectErr {
+				var validationErr *OutputValidationError
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("expect output validation error, got %v", err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				if result != c.Output {
+					t.Errorf("expect output to be returned")
+				}
+			}
+
+			if e, a := c.ExpectWarn, len(warnings) != 0; e != a {
+				t.Errorf("expect warning %v, got %v", e, warnings)
+			}
+
+			errs, ok := GetOutputValidationErrors(metadata)
+			if e, a := len(c.ExpectFields) != 0, ok; e != a {
+				t.Fatalf("expect violations %v, got %v", e, a)
+			}
+			var fields []string
+			for _, err := range errs.Errs() {
+				fields = append(fields, err.(smithy.InvalidParamError).Field())
+			}
+			if e, a := strings.Join(c.ExpectFields, ","), strings.Join(fields, ","); e != a {
+				t.Errorf("expect %v fields, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/aws/smithy-go"
+)
+
+// Rule checks a value against a single constraint. If the value violates
+// the constraint a ParamConstraintError for the field is returned, otherwise
+// nil.
+type Rule[T any] func(field string, v T) *smithy.ParamConstraintError
+
+// Number is the set of types the range constraint may be applied to.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Check checks v against each of the rules, adding any violations for the
+// field to errs.
+func Check[T any](errs *smithy.InvalidParamsError, field string, v T, rules ...Rule[T]) {
+	for _, rule := range rules {
+		if err := rule(field, v); err != nil {
+			errs.Add(err)
+		}
+	}
+}
+
+// CheckPtr checks the value pointed to by v against each of the rules,
+// adding any violations for the field to errs. Rules are not checked if v is
+// nil. Use Required to validate that a member is set.
+func CheckPtr[T any](errs *smithy.InvalidParamsError, field string, v *T, rules ...Rule[T]) {
+	if v == nil {
+		return
+	}
+	Check(errs, field, *v, rules...)
+}
+
+// Required adds a ParamRequiredError for the field to errs if the member is
+// not set.
+func Required(errs *smithy.InvalidParamsError, field string, isSet bool) {
+	if !isSet {
+		errs.Add(smithy.NewErrParamRequired(field))
+	}
+}
+
+// StringLength returns a Rule for the length trait applied to a string. The
+// length of a string is its number of Unicode code points. A nil bound is
+// not checked.
+func StringLength[T ~string](min, max *int64) Rule[T] {
+	return func(field string, v T) *smithy.ParamConstraintError {
+		return checkLength(field, int64(utf8.RuneCountInString(string(v))), min, max)
+	}
+}
+
+// BlobLength returns a Rule for the length trait applied to a blob. A nil
+// bound is not checked.
+func BlobLength(min, max *int64) Rule[[]byte] {
+	return func(field string, v []byte) *smithy.ParamConstraintError {
+		return checkLength(field, int64(len(v)), min, max)
+	}
+}
+
+// ListLength returns a Rule for the length trait applied to a list. A nil
+// bound is not checked.
+func ListLength[E any](min, max *int64) Rule[[]E] {
+	return func(field string, v []E) *smithy.ParamConstraintError {
+		return checkLength(field, int64(len(v)), min, max)
+	}
+}
+
+// MapLength returns a Rule for the length trait applied to a map. A nil
+// bound is not checked.
+func MapLength[K comparable, V any](min, max *int64) Rule[map[K]V] {
+	return func(field string, v map[K]V) *smithy.ParamConstraintError {
+		return checkLength(field, int64(len(v)), min, max)
+	}
+}
+
+func checkLength(field string, n int64, min, max *int64) *smithy.ParamConstraintError {
+	if (min == nil || n >= *min) && (max == nil || n <= *max) {
+		return nil
+	}
+	return smithy.NewErrParamConstraint(field, "length",
+		fmt.Sprintf("length %d %s", n, formatBounds(min, max, strconv.FormatInt)))
+}
+
+// Range returns a Rule for the range trait. A nil bound is not checked.
+func Range[T Number](min, max *float64) Rule[T] {
+	return func(field string, v T) *smithy.ParamConstraintError {
+		n := float64(v)
+		if (min == nil || n >= *min) && (max == nil || n <= *max) {
+			return nil
+		}
+		return smithy.NewErrParamConstraint(field, "range",
+			fmt.Sprintf("value %v %s", v, formatBounds(min, max, formatFloat)))
+	}
+}
+
+func formatFloat(v float64, _ int) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func formatBounds[T any](min, max *T, format func(T, int) string) string {
+	switch {
+	case min != nil && max != nil:
+		return fmt.Sprintf("must be between %s and %s", format(*min, 10), format(*max, 10))
+	case min != nil:
+		return fmt.Sprintf("must be at least %s", format(*min, 10))
+	default:
+		return fmt.Sprintf("must be at most %s", format(*max, 10))
+	}
+}
+
+// Pattern returns a Rule for the pattern trait. As with the Smithy pattern
+// trait, the expression is not implicitly anchored.
+func Pattern[T ~string](re *regexp.Regexp) Rule[T] {
+	return func(field string, v T) *smithy.ParamConstraintError {
+		if re.MatchString(string(v)) {
+			return nil
+		}
+		return smithy.NewErrParamConstraint(field, "pattern",
+			fmt.Sprintf("value must match pattern %s", re.String()))
+	}
+}
+
+// UniqueItems returns a Rule for the uniqueItems trait. Items are compared
+// with reflect.DeepEqual, so lists of structures and nested collections are
+// supported.
+func UniqueItems[E any]() Rule[[]E] {
+	return func(field string, v []E) *smithy.ParamConstraintError {
+		for i := 0; i < len(v); i++ {
+			for j := i + 1; j < len(v); j++ {
+				if reflect.DeepEqual(v[i], v[j]) {
+					return smithy.NewErrParamConstraint(field, "uniqueItems",
+						fmt.Sprintf("items must be unique, item %d duplicates item %d", j, i))
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// Enum returns a Rule for the enum trait, requiring the value to be one of
+// the provided values.
+func Enum[T comparable](values ...T) Rule[T] {
+	return func(field string, v T) *smithy.ParamConstraintError {
+		for _, e := range values {
+			if v == e {
+				return nil
+			}
+		}
+		return smithy.NewErrParamConstraint(field, "enum",
+			fmt.Sprintf("value %v must be one of %v", v, values))
+	}
+}
+
+// Sensitive returns a Rule checking the rule for a member with the sensitive
+// trait. The reason of a violation, which may include the member's value, is
+// replaced with one that does not, unless redaction is disabled, see
+// smithy.SetRedactionDisabled.
+func Sensitive[T any](rule Rule[T]) Rule[T] {
+	return func(field string, v T) *smithy.ParamConstraintError {
+		err := rule(field, v)
+		if err == nil || smithy.IsRedactionDisabled() {
+			return err
+		}
+		return smithy.NewErrParamConstraint(field, err.Constraint,
+			fmt.Sprintf("value %s violates %s constraint", smithy.RedactedValue, err.Constraint))
+	}
+}
@@ -0,0 +1,182 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/ptr"
+)
+
+func TestRules(t *testing.T) {
+	cases := map[string]struct {
+		Check            func(errs *smithy.InvalidParamsError)
+		ExpectConstraint string
+		ExpectReason     string
+	}{
+		"string length valid": {
+			Check: func(errs *smithy.InvalidParamsError) {
+				Check(errs, "Name", "héllo", StringLength[string](ptr.Int64(5), ptr.Int64(5)))
+			},
+		},
+		"string length too long": {
+			Check: func(errs *smithy.InvalidParamsError) {
+				Check(errs, "Name", "hello", StringLength[string](nil, ptr.Int64(3)))
+			},
+			ExpectConstraint: "length",
+			ExpectReason:     "length 5 must be at most 3",
+		},
+		"list length too short": {
+			Check: func(errs *smithy.InvalidParamsError) {
+				Check(errs, "Items", []int{1}, ListLength[int](ptr.Int64(2), ptr.Int64(4)))
+			},
+			ExpectConstraint: "length",
+			ExpectReason:     "length 1 must be between 2 and 4",
+		},
+		"range": {
+			Check: func(errs *smithy.InvalidParamsError) {
+				CheckPtr(errs, "Count", ptr.Int32(11), Range[int32](ptr.Float64(0), ptr.Float64(10)))
+			},
+			ExpectConstraint: "range",
+			ExpectReason:     "value 11 must be between 0 and 10",
+		},
+		"range nil pointer": {
+			Check: func(errs *smithy.InvalidParamsError) {
+				CheckPtr(errs, "Count", (*int32)(nil), Range[int32](ptr.Float64(0), ptr.Float64(10)))
+			},
+		},
+		"pattern": {
+			Check: func(errs *smithy.InvalidParamsError) {
+				Check(errs, "Id", "abc", Pattern[string](regexp.MustCompile(`^\d+$`)))
+			},
+			ExpectConstraint: "pattern",
+			ExpectReason:     `value must match pattern ^\d+$`,
+		},
+		"unique items": {
+			Check: func(errs *smithy.InvalidParamsError) {
+				Check(errs, "Tags", []string{"a", "b", "a"}, UniqueItems[string]())
+			},
+			ExpectConstraint: "uniqueItems",
+			ExpectReason:     "items must be unique, item 2 duplicates item 0",
+		},
+		"enum": {
+			Check: func(errs *smithy.InvalidParamsError) {
+				Check(errs, "Color", "purple", Enum("red", "green"))
+			},
+			ExpectConstraint: "enum",
+			ExpectReason:     "value purple must be one of [red green]",
+		},
+		"sensitive enum": {
+			Check: func(errs *smithy.InvalidParamsError) {
+				Check(errs, "Color", "purple", Sensitive(Enum("red", "green")))
+			},
+			ExpectConstraint: "enum",
+			ExpectReason:     "value *** violates enum constraint",
+		},
+		"sensitive valid": {
+			Check: func(errs *smithy.InvalidParamsError) {
+				Check(errs, "Color", "red", Sensitive(Enum("red", "green")))
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var errs smithy.InvalidParamsError
+			c.Check(&errs)
+
+			if len(c.ExpectConstraint) == 0 {
+				if errs.Len() != 0 {
+					t.Fatalf("expect no violations, got %v", errs)
+				}
+				return
+			}
+
+			if e, a := 1, errs.Len(); e != a {
+				t.Fatalf("expect %v violations, got %v", e, a)
+			}
+			var constraintErr *smithy.ParamConstraintError
+			if !errors.As(errs.Errs()[0], &constraintErr) {
+				t.Fatalf("expect constraint error, got %T", errs.Errs()[0])
+			}
+			if e, a := c.ExpectConstraint, constraintErr.Constraint; e != a {
+				t.Errorf("expect %v constraint, got %v", e, a)
+			}
+			if e, a := c.ExpectReason, constraintErr.Error(); !strings.Contains(a, e) {
+				t.Errorf("expect %q in reason, got %q", e, a)
+			}
+		})
+	}
+}
+
+type mockNestedShape struct {
+	Value *string
+}
+
+func (s *mockNestedShape) Validate(errs *smithy.InvalidParamsError) {
+	Required(errs, "Value", s.Value != nil)
+}
+
+type mockInput struct {
+	Name   *string
+	Nested *mockNestedShape
+	List   []*mockNestedShape
+}
+
+func (s *mockInput) Validate(errs *smithy.InvalidParamsError) {
+	Required(errs, "Name", s.Name != nil)
+	Nested(errs, "Nested", s.Nested)
+	NestedList(errs, "List", s.List)
+}
+
+func TestInputValidation(t *testing.T) {
+	stack := middleware.NewStack("test", func() interface{} { return struct{}{} })
+	if err := AddInputValidationMiddleware(stack); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var called bool
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+			called = true
+			return nil, middleware.Metadata{}, nil
+		}), stack)
+
+	_, _, err := handler.Handle(context.Background(), &mockInput{
+		Nested: &mockNestedShape{},
+		List:   []*mockNestedShape{{Value: ptr.String("v")}, {}, nil},
+	})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if called {
+		t.Errorf("expect handler to not be called")
+	}
+
+	var paramsErr smithy.InvalidParamsError
+	if !errors.As(err, &paramsErr) {
+		t.Fatalf("expect invalid params error, got %T", err)
+	}
+
+	var fields []string
+	for _, err := range paramsErr.Errs() {
+		fields = append(fields, err.(smithy.InvalidParamError).Field())
+	}
+	expect := []string{"mockInput.Name", "mockInput.Nested.Value", "mockInput.List[1].Value"}
+	if e, a := strings.Join(expect, ","), strings.Join(fields, ","); e != a {
+		t.Errorf("expect %v fields, got %v", e, a)
+	}
+
+	called = false
+	_, _, err = handler.Handle(context.Background(), &mockInput{Name: ptr.String("name")})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if !called {
+		t.Errorf("expect handler to be called")
+	}
+}
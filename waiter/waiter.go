@@ -1,10 +1,11 @@
 package waiter
 
 import (
+	"context"
 	"fmt"
-	"math"
 	"time"
 
+	"github.com/aws/smithy-go/backoff"
 	"github.com/aws/smithy-go/rand"
 )
 
@@ -15,6 +16,13 @@ import (
 // Returns the computed delay and if next attempt count is possible within the given input time constraints.
 // Note that the zeroth attempt results in no delay.
 func ComputeDelay(attempt int64, minDelay, maxDelay, remainingTime time.Duration) (delay time.Duration, err error) {
+	return ComputeDelayWithContext(context.Background(), attempt, minDelay, maxDelay, remainingTime)
+}
+
+// ComputeDelayWithContext computes delay between waiter attempts the same as
+// ComputeDelay, with the jitter read from the random source of the context.
+// Use rand.WithEntropySource to provide a deterministic source in tests.
+func ComputeDelayWithContext(ctx context.Context, attempt int64, minDelay, maxDelay, remainingTime time.Duration) (delay time.Duration, err error) {
 	// zeroth attempt, no delay
 	if attempt <= 0 {
 		return 0, nil
@@ -35,21 +43,12 @@ func ComputeDelay(attempt int64, minDelay, maxDelay, remainingTime time.Duration
 		return 0, fmt.Errorf("maxDelay must be greater than zero when computing Delay")
 	}
 
-	// Get attempt ceiling to prevent integer overflow.
-	attemptCeiling := (math.Log(float64(maxDelay/minDelay)) / math.Log(2)) + 1
-
-	if attempt > int64(attemptCeiling) {
-		delay = maxDelay
-	} else {
-		// Compute exponential delay based on attempt.
-		ri := 1 << uint64(attempt-1)
-		// compute delay
-		delay = minDelay * time.Duration(ri)
-	}
+	// Compute exponential delay based on attempt, capped at max delay.
+	delay = backoff.CappedExponential(int(attempt), minDelay, maxDelay)
 
 	if delay != minDelay {
 		// randomize to get jitter between min delay and delay value
-		d, err := rand.CryptoRandInt63n(int64(delay - minDelay))
+		d, err := rand.ContextInt63n(ctx, int64(delay-minDelay))
 		if err != nil {
 			return 0, fmt.Errorf("error computing retry jitter, %w", err)
 		}
@@ -1,6 +1,7 @@
 package waiter
 
 import (
+	"context"
 	mathrand "math/rand"
 	"strings"
 	"testing"
@@ -142,3 +143,25 @@ func mockwait(maxAttempts int64, minDelay, maxDelay, maxWaitTime time.Duration)
 
 	return delays, nil
 }
+
+func TestComputeDelayWithContext_Deterministic(t *testing.T) {
+	compute := func() []time.Duration {
+		ctx := rand.WithEntropySource(context.Background(), rand.NewSeededReader(1))
+		var delays []time.Duration
+		for attempt := int64(1); attempt <= 5; attempt++ {
+			d, err := ComputeDelayWithContext(ctx, attempt, 2*time.Second, 120*time.Second, 300*time.Second)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			delays = append(delays, d)
+		}
+		return delays
+	}
+
+	a, b := compute(), compute()
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("expect same delays for same seed, got %v and %v", a, b)
+		}
+	}
+}